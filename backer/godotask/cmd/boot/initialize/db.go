@@ -1,23 +1,34 @@
 package initialize
 
 import (
+	"log"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"log"
 
-  "github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/migration"
+	"github.com/godotask/infrastructure/db/model"
 )
 
-func InitDB() {
+// OpenDB はデータベースに接続する（マイグレーションは確認しない）
+func OpenDB() {
 	// dsn := os.Getenv("DATABASE_DSN")
 	dsn := "host=db user=dbgodotask password=dbgodotask dbname=dbgodotask port=5432 sslmode=disable"
 	var err error
-  model.DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	model.DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		log.Fatalf("failed to connect database: %v", err)
 	}
-	err = model.DB.AutoMigrate(model.Models()...)
+}
+
+// InitDB はデータベースに接続し、未適用のマイグレーションがあれば起動を止める
+func InitDB() {
+	OpenDB()
+	migrator, err := migration.New(model.DB)
 	if err != nil {
-		log.Fatalf("failed to migrate models: %v", err)
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := migrator.Check(); err != nil {
+		log.Fatalf("database schema is not up to date: %v", err)
 	}
 }
@@ -1,34 +1,124 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 
+	"github.com/godotask/cmd/boot/initialize"
+	"github.com/godotask/infrastructure/db/migration"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/joho/godotenv"
 )
 
+const usage = `usage: go run ./cmd/migrate <command>
+
+commands:
+  up [N]               apply pending migrations (all, or the next N)
+  down [N]             roll back the last N applied migrations (default 1)
+  status               list migrations and whether they are applied
+  create [-go] <name>  write a new SQL (or Go) migration in infrastructure/db/migration
+`
+
 func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	// .envファイルの読み込み
 	if err := godotenv.Load(); err != nil {
 		log.Printf("Warning: .env file not found")
 	}
 
-	// データベース接続と初期化
-	log.Println("Connecting to database and running migrations...")
-	model.InitDB()
+	switch args[0] {
+	case "create":
+		create(args[1:])
+		return
+	case "up", "down", "status":
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	initialize.OpenDB()
+	migrator, err := migration.New(model.DB)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := migrator.Up(steps(args[1:]))
+		for _, m := range done {
+			log.Printf("✓ applied %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(done) == 0 {
+			log.Println("no pending migrations")
+		}
+	case "down":
+		done, err := migrator.Down(steps(args[1:]))
+		for _, m := range done {
+			log.Printf("✓ rolled back %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			switch {
+			case s.Missing:
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05") + " (not in this build)"
+			case s.Applied:
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, state)
+		}
+	}
+}
 
-	// InitDBが既にAutoMigrateを実行しているので、追加の処理は不要
-	log.Println("✅ Database migration completed successfully!")
-	
-	// テーブル確認
-	var tableNames []string
-	model.DB.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = 'public' ORDER BY table_name").Scan(&tableNames)
-	
-	log.Println("Created tables:")
-	for _, table := range tableNames {
-		log.Printf("  - %s", table)
+func create(args []string) {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	goMigration := flags.Bool("go", false, "write a Go migration instead of SQL files")
+	dir := flags.String("dir", "infrastructure/db/migration", "migration package directory")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	existing, err := migration.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	paths, err := migration.Create(*dir, existing, flags.Arg(0), *goMigration)
+	if err != nil {
+		log.Fatal(err)
 	}
+	for _, path := range paths {
+		log.Printf("created %s", path)
+	}
+}
 
-	os.Exit(0)
-}
\ No newline at end of file
+func steps(args []string) int {
+	if len(args) == 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		log.Fatalf("invalid step count: %s", args[0])
+	}
+	return n
+}
@@ -3,6 +3,13 @@ package auth
 import "github.com/godotask/domain/entity"
 
 type TokenService interface {
-	Generate(user *entity.User) (string, error)
+	// Generate は sessionID を含む短命のアクセストークンを発行する
+	Generate(user *entity.User, sessionID uint) (string, error)
 	Parse(token string) (*entity.AuthClaims, error)
 }
+
+// RefreshTokenService は不透明なリフレッシュトークンの生成とハッシュ化を行う
+type RefreshTokenService interface {
+	Generate() (token string, hash string, err error)
+	Hash(token string) string
+}
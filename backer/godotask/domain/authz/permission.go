@@ -0,0 +1,85 @@
+package authz
+
+// Role は認可に用いるロール
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+	// RoleVerifier は editor の権限に加えて定量化ラベルを検証できる。
+	// 自己登録のユーザーは editor のため、管理者が付与した場合のみ検証できる
+	RoleVerifier Role = "verifier"
+)
+
+// Permission はロールに付与される権限
+type Permission string
+
+const (
+	// 自分（または共有先）のリソースの参照・作成/更新・削除
+	PermResourceRead   Permission = "resource:read"
+	PermResourceWrite  Permission = "resource:write"
+	PermResourceDelete Permission = "resource:delete"
+	// 所有者に関係なく全リソースを操作できる
+	PermResourceAny Permission = "resource:any"
+
+	PermUserManage Permission = "user:manage"
+	PermAuditRead  Permission = "audit:read"
+	// ロボット仕様など全ユーザー共通のカタログの登録・更新・削除
+	PermCatalogManage Permission = "catalog:manage"
+	// 他のユーザーが提出した定量化ラベルの検証
+	PermLabelVerify Permission = "label:verify"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermResourceRead, PermResourceWrite, PermResourceDelete, PermResourceAny,
+		PermUserManage, PermAuditRead, PermCatalogManage, PermLabelVerify,
+	},
+	RoleEditor: {
+		PermResourceRead, PermResourceWrite, PermResourceDelete,
+	},
+	RoleVerifier: {
+		PermResourceRead, PermResourceWrite, PermResourceDelete, PermLabelVerify,
+	},
+	RoleViewer: {
+		PermResourceRead,
+	},
+}
+
+// Roles は管理 API で指定可能なロールの一覧
+func Roles() []Role {
+	return []Role{RoleAdmin, RoleEditor, RoleViewer, RoleVerifier}
+}
+
+// IsValidRole は s が管理 API で指定可能なロールかを返す
+func IsValidRole(s string) bool {
+	for _, r := range Roles() {
+		if string(r) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeRole は DB / トークンに保存されたロールを認可用のロールに変換する
+// 既定値の 'user' やシードの職位（engineer, manager など）は従来どおり
+// 自分のデータを編集できるよう editor として扱う
+func NormalizeRole(s string) Role {
+	switch Role(s) {
+	case RoleAdmin, RoleEditor, RoleViewer, RoleVerifier:
+		return Role(s)
+	default:
+		return RoleEditor
+	}
+}
+
+// HasPermission はロールが権限を持つかを返す
+func HasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[NormalizeRole(role)] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
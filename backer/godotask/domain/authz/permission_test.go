@@ -0,0 +1,99 @@
+package authz
+
+import "testing"
+
+func TestNormalizeRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		expected Role
+	}{
+		{"admin", RoleAdmin},
+		{"editor", RoleEditor},
+		{"viewer", RoleViewer},
+		{"verifier", RoleVerifier},
+		{"user", RoleEditor},
+		{"engineer", RoleEditor},
+		{"", RoleEditor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			if got := NormalizeRole(tt.role); got != tt.expected {
+				t.Errorf("NormalizeRole(%q) = %s, want %s", tt.role, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		role     string
+		perm     Permission
+		expected bool
+	}{
+		{"admin", PermResourceAny, true},
+		{"admin", PermUserManage, true},
+		{"editor", PermResourceDelete, true},
+		{"editor", PermResourceAny, false},
+		{"editor", PermAuditRead, false},
+		{"admin", PermCatalogManage, true},
+		{"editor", PermCatalogManage, false},
+		{"editor", PermLabelVerify, false},
+		{"user", PermLabelVerify, false},
+		{"verifier", PermLabelVerify, true},
+		{"verifier", PermResourceWrite, true},
+		{"verifier", PermResourceAny, false},
+		{"admin", PermLabelVerify, true},
+		{"viewer", PermLabelVerify, false},
+		{"viewer", PermResourceRead, true},
+		{"viewer", PermResourceWrite, false},
+		{"viewer", PermResourceDelete, false},
+		{"user", PermResourceWrite, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+"/"+string(tt.perm), func(t *testing.T) {
+			if got := HasPermission(tt.role, tt.perm); got != tt.expected {
+				t.Errorf("HasPermission(%q, %s) = %v, want %v", tt.role, tt.perm, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsValidRole(t *testing.T) {
+	if !IsValidRole("viewer") {
+		t.Error("viewer should be valid")
+	}
+	if IsValidRole("user") {
+		t.Error("legacy role 'user' should not be assignable")
+	}
+}
+
+func TestWorkspaceRoles(t *testing.T) {
+	tests := []struct {
+		role   string
+		valid  bool
+		write  bool
+		manage bool
+	}{
+		{"owner", true, true, true},
+		{"editor", true, true, false},
+		{"viewer", true, false, false},
+		{"admin", false, false, false},
+		{"", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			if got := IsValidWorkspaceRole(tt.role); got != tt.valid {
+				t.Errorf("IsValidWorkspaceRole(%q) = %v, want %v", tt.role, got, tt.valid)
+			}
+			if got := CanWriteWorkspace(tt.role); got != tt.write {
+				t.Errorf("CanWriteWorkspace(%q) = %v, want %v", tt.role, got, tt.write)
+			}
+			if got := CanManageWorkspace(tt.role); got != tt.manage {
+				t.Errorf("CanManageWorkspace(%q) = %v, want %v", tt.role, got, tt.manage)
+			}
+		})
+	}
+}
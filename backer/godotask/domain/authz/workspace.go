@@ -0,0 +1,34 @@
+package authz
+
+// WorkspaceRole はワークスペース内でのメンバーのロール
+// ユーザー全体のロール（Role）とは独立しており、共有されたレコードへの操作範囲を決める
+type WorkspaceRole string
+
+const (
+	WorkspaceOwner  WorkspaceRole = "owner"
+	WorkspaceEditor WorkspaceRole = "editor"
+	WorkspaceViewer WorkspaceRole = "viewer"
+)
+
+// IsValidWorkspaceRole は s がワークスペースのロールとして有効かを返す
+func IsValidWorkspaceRole(s string) bool {
+	switch WorkspaceRole(s) {
+	case WorkspaceOwner, WorkspaceEditor, WorkspaceViewer:
+		return true
+	}
+	return false
+}
+
+// CanWriteWorkspace はワークスペースの共有レコードを作成・更新・削除できるロールかを返す
+func CanWriteWorkspace(role string) bool {
+	switch WorkspaceRole(role) {
+	case WorkspaceOwner, WorkspaceEditor:
+		return true
+	}
+	return false
+}
+
+// CanManageWorkspace はメンバー・招待・ワークスペース自体を管理できるロールかを返す
+func CanManageWorkspace(role string) bool {
+	return WorkspaceRole(role) == WorkspaceOwner
+}
@@ -13,9 +13,10 @@ type Claims struct {
 }
 
 type AuthClaims struct {
-	UserID   uint
-	Username string
-	Role     string
-	Email    string `json:"email"`
+	UserID    uint
+	Username  string
+	Role      string
+	Email     string `json:"email"`
+	SessionID uint   `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
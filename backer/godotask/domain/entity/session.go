@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// Session はログイン（デバイス）ごとのセッション
+// 1つのセッションがリフレッシュトークンのファミリーに対応し、ローテーションしても同じセッションを引き継ぐ
+type Session struct {
+	ID         uint
+	UserID     uint
+	DeviceName string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// Active はセッションが失効しておらず有効期限内かを返す
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken はセッションに紐づくリフレッシュトークン
+// トークン本体は保存せず SHA-256 ハッシュのみを保持する
+type RefreshToken struct {
+	ID        uint
+	SessionID uint
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time // ローテーション済みの場合に設定される
+	CreatedAt time.Time
+}
@@ -0,0 +1,80 @@
+package evaluation
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// ConvergenceWindow は収束判定に使う直近の評価件数
+	ConvergenceWindow = 3
+	// ConvergenceTolerance は直近の評価スコアの振れ幅がこの値以下なら収束とみなす
+	ConvergenceTolerance = 2.0
+)
+
+// Point は履歴上の1回分の評価
+type Point struct {
+	At      time.Time `json:"at"`
+	Score   float64   `json:"score"`
+	GapNorm float64   `json:"gap_norm"`
+}
+
+// Convergence は評価履歴の推移のまとめ
+type Convergence struct {
+	Count       int     `json:"count"`
+	FirstScore  float64 `json:"first_score"`
+	LatestScore float64 `json:"latest_score"`
+	Improvement float64 `json:"improvement"`
+	// SlopePerDay は経過日数に対するスコアの回帰直線の傾き
+	SlopePerDay float64 `json:"slope_per_day"`
+	// RecentSpread は直近 ConvergenceWindow 件のスコアの最大値 - 最小値
+	RecentSpread float64 `json:"recent_spread"`
+	Converged    bool    `json:"converged"`
+}
+
+// Summarize は時系列順に並んだ評価履歴から収束状況を求める
+func Summarize(points []Point) Convergence {
+	summary := Convergence{Count: len(points)}
+	if len(points) == 0 {
+		return summary
+	}
+	first, latest := points[0], points[len(points)-1]
+	summary.FirstScore = first.Score
+	summary.LatestScore = latest.Score
+	summary.Improvement = round(latest.Score-first.Score, 2)
+	summary.SlopePerDay = round(slopePerDay(points), 4)
+
+	if len(points) >= ConvergenceWindow {
+		recent := points[len(points)-ConvergenceWindow:]
+		lo, hi := recent[0].Score, recent[0].Score
+		for _, p := range recent[1:] {
+			lo = math.Min(lo, p.Score)
+			hi = math.Max(hi, p.Score)
+		}
+		summary.RecentSpread = round(hi-lo, 2)
+		summary.Converged = summary.RecentSpread <= ConvergenceTolerance
+	}
+	return summary
+}
+
+// slopePerDay は最小二乗法でスコアの1日あたりの変化量を求める
+func slopePerDay(points []Point) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+	origin := points[0].At
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.At.Sub(origin).Hours() / 24
+		sumX += x
+		sumY += p.Score
+		sumXY += x * p.Score
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
@@ -0,0 +1,164 @@
+package evaluation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// ErrNoDimensions は目標状態に数値の評価軸が1つも含まれない場合のエラー
+var ErrNoDimensions = stderrors.New("target_state has no numeric dimensions")
+
+// ErrInvalidDirection は評価軸の向きが maximize / minimize 以外の場合のエラー
+var ErrInvalidDirection = stderrors.New("direction must be maximize or minimize")
+
+// Direction は評価軸の良い向き
+type Direction string
+
+const (
+	// Maximize は値が大きいほど良い評価軸（精度、効率など）。既定の向き
+	Maximize Direction = "maximize"
+	// Minimize は値が小さいほど良い評価軸（バリの高さ、寸法偏差など）
+	Minimize Direction = "minimize"
+)
+
+// DimensionGap は評価軸ごとの現在値と目標値の差分
+type DimensionGap struct {
+	Name      string    `json:"name"`
+	Direction Direction `json:"direction"`
+	Current   float64   `json:"current"`
+	Target    float64   `json:"target"`
+	// Gap は正の値が不足、負の値が目標超過を表す差（maximize は目標値 - 現在値、minimize は現在値 - 目標値）
+	Gap float64 `json:"gap"`
+	// Achievement は目標に対する達成率 (0〜1)
+	Achievement float64 `json:"achievement"`
+}
+
+// GapAnalysis は現在状態と目標状態のギャップ分析結果
+type GapAnalysis struct {
+	Dimensions []DimensionGap `json:"dimensions"`
+	// Score は達成率の平均を 0〜100 に換算した値
+	Score float64 `json:"score"`
+	// GapNorm は不足分のみを集めたギャップベクトルのユークリッドノルム
+	GapNorm float64 `json:"gap_norm"`
+	// Missing は目標状態にのみ存在し現在状態で測定されていない評価軸
+	Missing []string `json:"missing_dimensions"`
+}
+
+// GapVector は評価軸名 → ギャップ値のマップを返す
+func (a *GapAnalysis) GapVector() map[string]float64 {
+	v := make(map[string]float64, len(a.Dimensions))
+	for _, d := range a.Dimensions {
+		v[d.Name] = d.Gap
+	}
+	return v
+}
+
+// Analyze は目標状態の各評価軸について現在状態とのギャップを計算する。
+// 数値以外の値は評価軸とみなさない。現在状態に無い評価軸は 0 として扱い Missing に記録する
+// directions に無い評価軸は maximize として扱う
+func Analyze(current, target map[string]interface{}, directions map[string]Direction) (*GapAnalysis, error) {
+	for _, d := range directions {
+		if d != Maximize && d != Minimize {
+			return nil, ErrInvalidDirection
+		}
+	}
+	names := make([]string, 0, len(target))
+	for name, v := range target {
+		if _, ok := toFloat(v); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, ErrNoDimensions
+	}
+	sort.Strings(names)
+
+	analysis := &GapAnalysis{
+		Dimensions: make([]DimensionGap, 0, len(names)),
+		Missing:    []string{},
+	}
+	var achievementSum, squaredShortfall float64
+	for _, name := range names {
+		t, _ := toFloat(target[name])
+		c, ok := toFloat(current[name])
+		if !ok {
+			analysis.Missing = append(analysis.Missing, name)
+		}
+		direction := directions[name]
+		if direction == "" {
+			direction = Maximize
+		}
+		gap := t - c
+		achievement := achievementOf(c, t)
+		if direction == Minimize {
+			gap = c - t
+			achievement = achievementOfMinimum(c, t)
+		}
+		analysis.Dimensions = append(analysis.Dimensions, DimensionGap{
+			Name:        name,
+			Direction:   direction,
+			Current:     c,
+			Target:      t,
+			Gap:         round(gap, 4),
+			Achievement: round(achievement, 4),
+		})
+		achievementSum += achievement
+		if gap > 0 {
+			squaredShortfall += gap * gap
+		}
+	}
+
+	analysis.Score = round(100*achievementSum/float64(len(names)), 2)
+	analysis.GapNorm = round(math.Sqrt(squaredShortfall), 4)
+	return analysis, nil
+}
+
+// achievementOf は目標値に対する現在値の達成率を 0〜1 に丸めて返す
+func achievementOf(current, target float64) float64 {
+	if target <= 0 {
+		if current >= target {
+			return 1
+		}
+		return 0
+	}
+	return math.Max(0, math.Min(1, current/target))
+}
+
+// achievementOfMinimum は小さいほど良い評価軸の達成率。目標値以下なら 1、超えた分だけ 目標値 / 現在値 に下がる
+func achievementOfMinimum(current, target float64) float64 {
+	if current <= target {
+		return 1
+	}
+	if target <= 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(1, target/current))
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
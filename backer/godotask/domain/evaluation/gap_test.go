@@ -0,0 +1,93 @@
+package evaluation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	current := map[string]interface{}{"accuracy": 0.6, "efficiency": 0.9, "note": "manual"}
+	target := map[string]interface{}{"accuracy": 0.8, "efficiency": 0.75, "consistency": 0.5, "label": "high"}
+
+	a, err := Analyze(current, target, nil)
+	assert.NoError(t, err)
+	assert.Len(t, a.Dimensions, 3)
+	assert.Equal(t, []string{"consistency"}, a.Missing)
+
+	gap := a.GapVector()
+	assert.InDelta(t, 0.2, gap["accuracy"], 1e-9)
+	assert.InDelta(t, -0.15, gap["efficiency"], 1e-9)
+	assert.InDelta(t, 0.5, gap["consistency"], 1e-9)
+
+	// (0.75 + 1 + 0) / 3
+	assert.InDelta(t, 58.33, a.Score, 1e-9)
+	assert.InDelta(t, 0.5385, a.GapNorm, 1e-9)
+}
+
+func TestAnalyzeReachedTarget(t *testing.T) {
+	a, err := Analyze(map[string]interface{}{"accuracy": 0.9}, map[string]interface{}{"accuracy": 0.9}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, a.Score)
+	assert.Equal(t, 0.0, a.GapNorm)
+}
+
+func TestAnalyzeWithoutNumericTarget(t *testing.T) {
+	_, err := Analyze(map[string]interface{}{}, map[string]interface{}{"label": "high"}, nil)
+	assert.ErrorIs(t, err, ErrNoDimensions)
+}
+
+// 小さいほど良い評価軸は、目標値を超えると達成率が下がり不足として数える
+func TestAnalyzeMinimizeDimension(t *testing.T) {
+	current := map[string]interface{}{"burr_height": 0.1, "deviation": 0.02, "accuracy": 0.9}
+	target := map[string]interface{}{"burr_height": 0.05, "deviation": 0.03, "accuracy": 0.9}
+	directions := map[string]Direction{"burr_height": Minimize, "deviation": Minimize}
+
+	a, err := Analyze(current, target, directions)
+	assert.NoError(t, err)
+	byName := map[string]DimensionGap{}
+	for _, d := range a.Dimensions {
+		byName[d.Name] = d
+	}
+
+	burr := byName["burr_height"]
+	assert.Equal(t, Minimize, burr.Direction)
+	assert.InDelta(t, 0.5, burr.Achievement, 1e-9)
+	assert.InDelta(t, 0.05, burr.Gap, 1e-9)
+	assert.Equal(t, 1.0, byName["deviation"].Achievement)
+	assert.InDelta(t, -0.01, byName["deviation"].Gap, 1e-9)
+	assert.Equal(t, Maximize, byName["accuracy"].Direction)
+
+	// (0.5 + 1 + 1) / 3
+	assert.InDelta(t, 83.33, a.Score, 1e-9)
+	assert.InDelta(t, 0.05, a.GapNorm, 1e-9)
+
+	// 向きを付けないと目標より悪いバリの高さが達成扱いになる
+	naive, err := Analyze(current, target, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, naive.Dimensions[1].Achievement)
+
+	_, err = Analyze(current, target, map[string]Direction{"burr_height": "lower"})
+	assert.ErrorIs(t, err, ErrInvalidDirection)
+}
+
+func TestSummarize(t *testing.T) {
+	base := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	points := []Point{
+		{At: base, Score: 60},
+		{At: base.AddDate(0, 0, 1), Score: 80},
+		{At: base.AddDate(0, 0, 2), Score: 81},
+		{At: base.AddDate(0, 0, 3), Score: 81.5},
+	}
+
+	s := Summarize(points)
+	assert.Equal(t, 4, s.Count)
+	assert.Equal(t, 21.5, s.Improvement)
+	assert.Equal(t, 1.5, s.RecentSpread)
+	assert.True(t, s.Converged)
+	assert.Greater(t, s.SlopePerDay, 0.0)
+
+	assert.False(t, Summarize(points[:2]).Converged)
+	assert.Equal(t, 0, Summarize(nil).Count)
+}
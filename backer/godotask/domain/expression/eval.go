@@ -0,0 +1,263 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// ErrUnknownVariable は式が束縛されていない変数を参照した場合のエラー
+var ErrUnknownVariable = errors.New("unknown variable")
+
+// Env は変数名から値を引く
+type Env interface {
+	Lookup(name string) (float64, error)
+}
+
+// Vars は map による Env
+type Vars map[string]float64
+
+func (v Vars) Lookup(name string) (float64, error) {
+	x, ok := v[name]
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownVariable, name)
+	}
+	return x, nil
+}
+
+// Node は式の構文木。真偽値は 1（真）/ 0（偽）として評価する
+type Node interface {
+	Eval(env Env) (float64, error)
+	String() string
+}
+
+type Number struct {
+	Value float64
+}
+
+type Variable struct {
+	Name string
+}
+
+type Unary struct {
+	Op      string
+	Operand Node
+}
+
+type Binary struct {
+	Op          string
+	Left, Right Node
+}
+
+type Call struct {
+	Func string
+	Args []Node
+}
+
+func (n *Number) Eval(Env) (float64, error) { return n.Value, nil }
+func (n *Number) String() string            { return strconv.FormatFloat(n.Value, 'g', -1, 64) }
+
+func (n *Variable) Eval(env Env) (float64, error) { return env.Lookup(n.Name) }
+func (n *Variable) String() string                { return n.Name }
+
+func (n *Unary) Eval(env Env) (float64, error) {
+	v, err := n.Operand.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	if n.Op == "!" {
+		return boolean(v == 0), nil
+	}
+	return -v, nil
+}
+
+func (n *Unary) String() string { return n.Op + n.Operand.String() }
+
+func (n *Binary) Eval(env Env) (float64, error) {
+	l, err := n.Left.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	// 論理演算は短絡評価する
+	switch n.Op {
+	case "&&":
+		if l == 0 {
+			return 0, nil
+		}
+	case "||":
+		if l != 0 {
+			return 1, nil
+		}
+	}
+	r, err := n.Right.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	var v float64
+	switch n.Op {
+	case "+":
+		v = l + r
+	case "-":
+		v = l - r
+	case "*":
+		v = l * r
+	case "/":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero in %s", n)
+		}
+		v = l / r
+	case "%":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero in %s", n)
+		}
+		v = math.Mod(l, r)
+	case "^":
+		v = math.Pow(l, r)
+	case "<":
+		v = boolean(l < r)
+	case "<=":
+		v = boolean(l <= r)
+	case ">":
+		v = boolean(l > r)
+	case ">=":
+		v = boolean(l >= r)
+	case "==":
+		v = boolean(l == r)
+	case "!=":
+		v = boolean(l != r)
+	case "&&", "||":
+		v = boolean(r != 0)
+	default:
+		return 0, fmt.Errorf("unknown operator %q", n.Op)
+	}
+	return finite(v, n)
+}
+
+func (n *Binary) String() string {
+	return "(" + n.Left.String() + " " + n.Op + " " + n.Right.String() + ")"
+}
+
+func (n *Call) Eval(env Env) (float64, error) {
+	args := make([]float64, len(n.Args))
+	for i, a := range n.Args {
+		v, err := a.Eval(env)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+	v, err := functions[n.Func].fn(args)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", n.Func, err)
+	}
+	return finite(v, n)
+}
+
+func (n *Call) String() string {
+	s := n.Func + "("
+	for i, a := range n.Args {
+		if i > 0 {
+			s += ", "
+		}
+		s += a.String()
+	}
+	return s + ")"
+}
+
+// Variables は式が参照する変数名を重複なく昇順で返す
+func Variables(nodes ...Node) []string {
+	seen := map[string]bool{}
+	var walk func(Node)
+	walk = func(n Node) {
+		switch x := n.(type) {
+		case *Variable:
+			seen[x.Name] = true
+		case *Unary:
+			walk(x.Operand)
+		case *Binary:
+			walk(x.Left)
+			walk(x.Right)
+		case *Call:
+			for _, a := range x.Args {
+				walk(a)
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Violation は制約式がどれだけ破られているかを返す（満たしていれば 0）
+// 比較は差の大きさ、&& は各項の和、|| は各項の最小値、それ以外は偽なら 1 とする
+func Violation(n Node, env Env) (float64, error) {
+	if b, ok := n.(*Binary); ok {
+		switch b.Op {
+		case "&&", "||":
+			l, err := Violation(b.Left, env)
+			if err != nil {
+				return 0, err
+			}
+			r, err := Violation(b.Right, env)
+			if err != nil {
+				return 0, err
+			}
+			if b.Op == "&&" {
+				return l + r, nil
+			}
+			return math.Min(l, r), nil
+		case "<", "<=", ">", ">=", "==", "!=":
+			l, err := b.Left.Eval(env)
+			if err != nil {
+				return 0, err
+			}
+			r, err := b.Right.Eval(env)
+			if err != nil {
+				return 0, err
+			}
+			switch b.Op {
+			case "<", "<=":
+				if ok := (b.Op == "<" && l < r) || (b.Op == "<=" && l <= r); ok {
+					return 0, nil
+				}
+				return math.Max(l-r, math.SmallestNonzeroFloat64), nil
+			case ">", ">=":
+				if ok := (b.Op == ">" && l > r) || (b.Op == ">=" && l >= r); ok {
+					return 0, nil
+				}
+				return math.Max(r-l, math.SmallestNonzeroFloat64), nil
+			case "==":
+				return math.Abs(l - r), nil
+			default:
+				return boolean(l == r), nil
+			}
+		}
+	}
+	v, err := n.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	return boolean(v == 0), nil
+}
+
+func boolean(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func finite(v float64, n Node) (float64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%s is not a finite number", n)
+	}
+	return v, nil
+}
@@ -0,0 +1,215 @@
+package expression
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func eval(t *testing.T, src string, vars Vars) float64 {
+	t.Helper()
+	n, err := Parse(src)
+	if !assert.NoError(t, err, src) {
+		return 0
+	}
+	v, err := n.Eval(vars)
+	assert.NoError(t, err, src)
+	return v
+}
+
+func TestEval(t *testing.T) {
+	vars := Vars{"speed": 200, "feed": 0.1, "tool.wear": 0.3}
+
+	assert.Equal(t, 7.0, eval(t, "1 + 2 * 3", nil))
+	assert.Equal(t, 9.0, eval(t, "(1 + 2) * 3", nil))
+	assert.Equal(t, 512.0, eval(t, "2 ^ 3 ^ 2", nil))
+	assert.Equal(t, -4.0, eval(t, "-2 ^ 2", nil))
+	assert.Equal(t, 1e-3, eval(t, "1e-3", nil))
+	assert.InDelta(t, 20.3, eval(t, "speed * feed + tool.wear", vars), 1e-9)
+	assert.Equal(t, 1.0, eval(t, "speed >= 100 && feed < 0.2", vars))
+	assert.Equal(t, 0.0, eval(t, "!(speed > 100) || feed > 1", vars))
+	assert.Equal(t, 3.0, eval(t, "max(1, 3, 2)", nil))
+	assert.Equal(t, 5.0, eval(t, "clamp(7, 0, 5)", nil))
+	assert.Equal(t, -200.0, eval(t, "maximize(speed)", vars))
+	assert.Equal(t, 2.0, eval(t, "if(feed > 1, 1, 2)", vars))
+	// 短絡評価のため右辺の未定義変数は参照されない
+	assert.Equal(t, 0.0, eval(t, "feed > 1 && unknown > 0", vars))
+}
+
+func TestEvalErrors(t *testing.T) {
+	n, err := Parse("speed / (feed - 0.1)")
+	assert.NoError(t, err)
+	_, err = n.Eval(Vars{"speed": 1, "feed": 0.1})
+	assert.ErrorContains(t, err, "division by zero")
+
+	_, err = n.Eval(Vars{"speed": 1})
+	assert.True(t, errors.Is(err, ErrUnknownVariable))
+
+	n, _ = Parse("sqrt(-1)")
+	_, err = n.Eval(nil)
+	assert.ErrorContains(t, err, "not a finite number")
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"":                 "empty",
+		"1 +":              "unexpected end",
+		"a < b < c":        "cannot be chained",
+		"exec(1)":          "unknown function",
+		"pow(1)":           "expects 2 argument",
+		"(1 + 2":           "expected ')'",
+		"1 $ 2":            "unexpected character",
+		"minimize(time) x": "unexpected",
+		strings.Repeat("(", MaxDepth+1) + "1" + strings.Repeat(")", MaxDepth+1): "nested deeper",
+	}
+	for src, want := range cases {
+		_, err := Parse(src)
+		var perr *ParseError
+		if assert.True(t, errors.As(err, &perr), src) {
+			assert.Contains(t, perr.Error(), want, src)
+		}
+	}
+}
+
+func TestParseListAndVariables(t *testing.T) {
+	nodes, err := ParseList("speed <= 300, feed * speed < limit")
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 2)
+	assert.Equal(t, []string{"feed", "limit", "speed"}, Variables(nodes...))
+}
+
+func TestViolation(t *testing.T) {
+	vars := Vars{"x": 5, "y": 2}
+	cases := map[string]float64{
+		"x <= 10":          0,
+		"x <= 3":           2,
+		"x >= 8":           3,
+		"x <= 3 && y >= 4": 4,
+		"x <= 3 || y >= 4": 2,
+		"x == 7":           2,
+		"y":                0,
+		"y - 2":            1,
+	}
+	for src, want := range cases {
+		n, err := Parse(src)
+		assert.NoError(t, err)
+		v, err := Violation(n, vars)
+		assert.NoError(t, err)
+		assert.InDelta(t, want, v, 1e-9, src)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	valid := map[string]Type{
+		"speed * feed + 1":            TypeNumber,
+		"if(speed > 100, 1, 2)":       TypeNumber,
+		"if(enabled, 1, 2)":           TypeNumber,
+		"speed <= 300 && !enabled":    TypeBool,
+		"collision_free":              TypeBool,
+		"max(a, b) >= clamp(c, 0, 1)": TypeBool,
+	}
+	for src, want := range valid {
+		n, err := Parse(src)
+		assert.NoError(t, err, src)
+		assert.NoError(t, Check(n, want), src)
+	}
+
+	invalid := map[string]string{
+		"speed + (feed > 1)":    "expected a number, got a condition",
+		"!(speed + 1)":          "expected a condition, got a number",
+		"speed > 1 && feed + 1": "expected a condition, got a number",
+		"if(1, 2, 3)":           "expected a condition",
+		"sqrt(a < b)":           "expected a number",
+		"minimize(time)":        "can only be used as a term of an objective function",
+	}
+	for src, want := range invalid {
+		n, err := Parse(src)
+		assert.NoError(t, err, src)
+		err = Check(n, TypeNumber)
+		var typeErr *TypeError
+		if assert.True(t, errors.As(err, &typeErr), src) {
+			assert.Contains(t, err.Error(), want, src)
+			assert.True(t, IsFormatError(err), src)
+		}
+	}
+}
+
+func TestParseObjective(t *testing.T) {
+	o, err := ParseObjective("minimize(time) + 0.5 * maximize(quality) SUBJECT TO collision_free, load <= 5")
+	assert.NoError(t, err)
+	assert.Equal(t, SenseMinimize, o.Sense)
+	assert.Len(t, o.Constraints, 2)
+	v, err := o.Expr.Eval(Vars{"time": 10, "quality": 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, v)
+
+	o, err = ParseObjective("maximize(score / time)")
+	assert.NoError(t, err)
+	assert.Equal(t, SenseMaximize, o.Sense)
+	assert.Equal(t, "(score / time)", o.Measure.String())
+
+	cases := map[string]string{
+		"f(x)=x^2":                           "unexpected character '='",
+		"minimize(maximize(x))":              "can only be used as a term",
+		"time < 10":                          "expected a number, got a condition",
+		"minimize(x) subject to x + 1":       "expected a condition",
+		"minimize(x) subject to minimize(y)": "can only be used as a term",
+		"if(minimize(x) > 1, 1, 2)":          "can only be used as a term",
+		"2 ^ minimize(x)":                    "can only be used as a term",
+	}
+	for src, want := range cases {
+		_, err := ParseObjective(src)
+		if assert.Error(t, err, src) {
+			assert.Contains(t, err.Error(), want, src)
+			assert.True(t, IsFormatError(err), src)
+		}
+	}
+
+	// subject to の後の構文エラーは目的関数全体での位置を返す
+	_, err = ParseObjective("minimize(x) subject to x <")
+	var parseErr *ParseError
+	if assert.True(t, errors.As(err, &parseErr)) {
+		assert.Equal(t, 26, parseErr.Pos)
+	}
+}
+
+func TestFields(t *testing.T) {
+	record := struct {
+		ID      string                 `json:"id"`
+		Score   float64                `json:"score"`
+		Count   int                    `json:"count"`
+		Done    bool                   `json:"done"`
+		Tags    []string               `json:"tags"`
+		Result  map[string]interface{} `json:"result"`
+		Omitted *float64               `json:"omitted"`
+	}{
+		ID:     "r1",
+		Score:  0.5,
+		Count:  3,
+		Done:   true,
+		Tags:   []string{"a"},
+		Result: map[string]interface{}{"success-rate": 0.9, "stage": map[string]interface{}{"ok": false}},
+	}
+	vars, err := Fields("task", record)
+	assert.NoError(t, err)
+	assert.Equal(t, Vars{
+		"task.score":               0.5,
+		"task.count":               3,
+		"task.done":                1,
+		"task.result.success_rate": 0.9,
+		"task.result.stage.ok":     0,
+	}, vars)
+
+	bound := Vars{"x": 1}
+	assert.NoError(t, bound.Bind("", map[string]interface{}{"9lives": 9}))
+	assert.Equal(t, Vars{"x": 1, "_9lives": 9}, bound)
+
+	_, err = Fields("list", []int{1})
+	assert.Error(t, err)
+
+	assert.Equal(t, "label.burr_height", VariableName("label", "burr-height"))
+	assert.Equal(t, "label.a_b_c", VariableName("label", "a.b c"))
+	assert.Equal(t, "label._", VariableName("label", ""))
+}
@@ -0,0 +1,75 @@
+package expression
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// VariableName は prefix とレコードのキーから式で参照できる変数名を作る
+// キーの文字・数字・アンダースコア以外は "_" に置き換える（例: "label", "burr-height" → "label.burr_height"）
+func VariableName(prefix, key string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "" {
+		name = "_"
+	}
+	if prefix == "" {
+		if unicode.IsDigit([]rune(name)[0]) {
+			name = "_" + name
+		}
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Fields はレコードの数値・真偽値のフィールドを JSON のキーから作った変数名で返す（真偽値は 1 / 0）
+// ネストしたオブジェクトは "親.子" の名前にする。文字列・配列・null は含めない
+func Fields(prefix string, record interface{}) (Vars, error) {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", prefix, err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %v", prefix, err)
+	}
+	vars := Vars{}
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: record must be an object", prefix)
+	}
+	vars.flatten(prefix, obj)
+	return vars, nil
+}
+
+// Bind は record のフィールドを prefix 付きの変数として加える
+func (v Vars) Bind(prefix string, record interface{}) error {
+	fields, err := Fields(prefix, record)
+	if err != nil {
+		return err
+	}
+	for name, x := range fields {
+		v[name] = x
+	}
+	return nil
+}
+
+func (v Vars) flatten(prefix string, obj map[string]interface{}) {
+	for key, value := range obj {
+		name := VariableName(prefix, key)
+		switch x := value.(type) {
+		case float64:
+			v[name] = x
+		case bool:
+			v[name] = boolean(x)
+		case map[string]interface{}:
+			v.flatten(name, x)
+		}
+	}
+}
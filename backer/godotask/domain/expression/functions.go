@@ -0,0 +1,84 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+type function struct {
+	// minArgs / maxArgs は引数の数（maxArgs が -1 の場合は上限なし）
+	minArgs, maxArgs int
+	fn               func(args []float64) (float64, error)
+}
+
+func (f function) checkArity(n int) error {
+	if n < f.minArgs || (f.maxArgs >= 0 && n > f.maxArgs) {
+		if f.minArgs == f.maxArgs {
+			return fmt.Errorf("expects %d argument(s), got %d", f.minArgs, n)
+		}
+		return fmt.Errorf("expects at least %d argument(s), got %d", f.minArgs, n)
+	}
+	return nil
+}
+
+func unary(f func(float64) float64) function {
+	return function{minArgs: 1, maxArgs: 1, fn: func(a []float64) (float64, error) { return f(a[0]), nil }}
+}
+
+// functions は式から呼び出せる関数の一覧
+// minimize / maximize は目的関数の向きを表し、最小化問題に揃えるため maximize は符号を反転する
+var functions = map[string]function{
+	"abs":   unary(math.Abs),
+	"sqrt":  unary(math.Sqrt),
+	"exp":   unary(math.Exp),
+	"log":   unary(math.Log),
+	"log10": unary(math.Log10),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"round": unary(math.Round),
+	"pow": {minArgs: 2, maxArgs: 2, fn: func(a []float64) (float64, error) {
+		return math.Pow(a[0], a[1]), nil
+	}},
+	"min": {minArgs: 1, maxArgs: -1, fn: func(a []float64) (float64, error) {
+		m := a[0]
+		for _, v := range a[1:] {
+			m = math.Min(m, v)
+		}
+		return m, nil
+	}},
+	"max": {minArgs: 1, maxArgs: -1, fn: func(a []float64) (float64, error) {
+		m := a[0]
+		for _, v := range a[1:] {
+			m = math.Max(m, v)
+		}
+		return m, nil
+	}},
+	"clamp": {minArgs: 3, maxArgs: 3, fn: func(a []float64) (float64, error) {
+		if a[1] > a[2] {
+			return 0, fmt.Errorf("lower bound %g is greater than upper bound %g", a[1], a[2])
+		}
+		return math.Min(math.Max(a[0], a[1]), a[2]), nil
+	}},
+	"if": {minArgs: 3, maxArgs: 3, fn: func(a []float64) (float64, error) {
+		if a[0] != 0 {
+			return a[1], nil
+		}
+		return a[2], nil
+	}},
+	"minimize": unary(func(x float64) float64 { return x }),
+	"maximize": unary(func(x float64) float64 { return -x }),
+}
+
+// Functions は利用できる関数名を昇順で返す
+func Functions() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,73 @@
+package expression
+
+import (
+	"errors"
+	"regexp"
+)
+
+var subjectTo = regexp.MustCompile(`(?i)\s+subject\s+to\s+`)
+
+// 目的関数の向き
+const (
+	SenseMinimize = "minimize"
+	SenseMaximize = "maximize"
+)
+
+// Objective は目的関数（PhenomenologicalFramework.GoalFunction / OptimizationModel.ObjectiveFunction）
+//
+// "minimize(time) + 0.5 * minimize(energy) subject to collision_free, load <= 5" のように、
+// minimize / maximize の項の和に subject to でカンマ区切りの制約を続けられる
+type Objective struct {
+	// Expr は最小化する式。maximize(x) の項は -x として評価する
+	Expr Node
+	// Sense と Measure は目的関数が minimize(x) / maximize(x) の1項だけの場合はその向きと x、
+	// それ以外は SenseMinimize と Expr
+	Sense       string
+	Measure     Node
+	Constraints []Node
+}
+
+// ParseObjective は目的関数を解析し、型を検査する
+// 目的関数は数値、制約は条件でなければならない
+func ParseObjective(src string) (*Objective, error) {
+	head, tail, offset := src, "", 0
+	if loc := subjectTo.FindStringIndex(src); loc != nil {
+		head, tail, offset = src[:loc[0]], src[loc[1]:], loc[1]
+	}
+	expr, err := Parse(head)
+	if err != nil {
+		return nil, err
+	}
+	t, err := check(expr, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := expect(expr, t, TypeNumber); err != nil {
+		return nil, err
+	}
+	o := &Objective{Expr: expr, Sense: SenseMinimize, Measure: expr}
+	if call, ok := expr.(*Call); ok && (call.Func == SenseMinimize || call.Func == SenseMaximize) {
+		o.Sense = call.Func
+		o.Measure = call.Args[0]
+	}
+
+	if tail == "" {
+		return o, nil
+	}
+	constraints, err := ParseList(tail)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			// 位置は目的関数全体の中の位置にする
+			return nil, &ParseError{Pos: parseErr.Pos + len([]rune(src[:offset])), Msg: parseErr.Msg}
+		}
+		return nil, err
+	}
+	for _, c := range constraints {
+		if err := Check(c, TypeBool); err != nil {
+			return nil, err
+		}
+	}
+	o.Constraints = constraints
+	return o, nil
+}
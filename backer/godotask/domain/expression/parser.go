@@ -0,0 +1,287 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxLength / MaxDepth は解析できる式の大きさの上限（巨大な入力や深い入れ子を拒否する）
+const (
+	MaxLength = 4096
+	MaxDepth  = 64
+)
+
+// Parse は1つの式を解析する
+//
+// 文法（優先順位の低い順）:
+//
+//	a || b, a && b, 比較（<, <=, >, >=, ==, !=。連鎖は不可）,
+//	a + b, a - b, a * b, a / b, a % b, 単項 -a / !a, a ^ b（右結合）,
+//	数値, 変数, 関数呼び出し f(a, b), (a)
+func Parse(src string) (Node, error) {
+	nodes, err := parse(src, false)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// ParseList はカンマ区切りの式の並びを解析する
+func ParseList(src string) ([]Node, error) {
+	return parse(src, true)
+}
+
+func parse(src string, list bool) ([]Node, error) {
+	if len(src) > MaxLength {
+		return nil, &ParseError{Pos: MaxLength, Msg: fmt.Sprintf("expression is longer than %d characters", MaxLength)}
+	}
+	if strings.TrimSpace(src) == "" {
+		return nil, &ParseError{Pos: 0, Msg: "expression is empty"}
+	}
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	var nodes []Node
+	for {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+		if list && p.peek().kind == tokComma {
+			p.next()
+			continue
+		}
+		break
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &ParseError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return nodes, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptOp(ops ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokOperator {
+		return t, false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.next()
+			return t, true
+		}
+	}
+	return t, false
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "||", Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOp("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "&&", Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseCompare() (Node, error) {
+	left, err := p.parseAdd()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.acceptOp("<", "<=", ">", ">=", "==", "!=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseAdd()
+	if err != nil {
+		return nil, err
+	}
+	if t, chained := p.acceptOp("<", "<=", ">", ">=", "==", "!="); chained {
+		return nil, &ParseError{Pos: t.pos, Msg: "comparisons cannot be chained; use &&"}
+	}
+	return &Binary{Op: op.text, Left: left, Right: right}, nil
+}
+
+func (p *parser) parseAdd() (Node, error) {
+	left, err := p.parseMul()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMul()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op.text, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseMul() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op.text, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if op, ok := p.acceptOp("-", "+", "!"); ok {
+		if err := p.enter(op.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op.text == "+" {
+			return operand, nil
+		}
+		return &Unary{Op: op.text, Operand: operand}, nil
+	}
+	return p.parsePow()
+}
+
+func (p *parser) parsePow() (Node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.acceptOp("^"); !ok {
+		return base, nil
+	}
+	exp, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &Binary{Op: "^", Left: base, Right: exp}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &Number{Value: t.num}, nil
+	case tokIdent:
+		if p.peek().kind != tokLParen {
+			return &Variable{Name: t.text}, nil
+		}
+		if _, ok := functions[t.text]; !ok {
+			return nil, &ParseError{Pos: t.pos, Msg: fmt.Sprintf("unknown function %q", t.text)}
+		}
+		p.next()
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		var args []Node
+		if p.peek().kind != tokRParen {
+			for {
+				arg, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.peek().kind != tokComma {
+					break
+				}
+				p.next()
+			}
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &ParseError{Pos: closing.pos, Msg: "expected ')'"}
+		}
+		if err := functions[t.text].checkArity(len(args)); err != nil {
+			return nil, &ParseError{Pos: t.pos, Msg: fmt.Sprintf("%s: %s", t.text, err.Error())}
+		}
+		return &Call{Func: t.text, Args: args}, nil
+	case tokLParen:
+		if err := p.enter(t.pos); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &ParseError{Pos: closing.pos, Msg: "expected ')'"}
+		}
+		return inner, nil
+	case tokEOF:
+		return nil, &ParseError{Pos: t.pos, Msg: "unexpected end of expression"}
+	default:
+		return nil, &ParseError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+}
+
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return &ParseError{Pos: pos, Msg: fmt.Sprintf("expression is nested deeper than %d levels", MaxDepth)}
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
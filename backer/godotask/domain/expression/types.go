@@ -0,0 +1,134 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Type は式の値の種類
+type Type int
+
+const (
+	TypeNumber Type = iota
+	// TypeBool は比較・論理演算の結果。評価すると 1（真）/ 0（偽）になる
+	TypeBool
+)
+
+func (t Type) String() string {
+	if t == TypeBool {
+		return "condition"
+	}
+	return "number"
+}
+
+// TypeError は式の型の誤り（数値が必要な所に条件を書いた、など）
+type TypeError struct {
+	Expr string
+	Msg  string
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("type error in %s: %s", e.Expr, e.Msg)
+}
+
+// IsFormatError は err が式の書式の誤り（構文エラーまたは型エラー）かどうか
+func IsFormatError(err error) bool {
+	var parseErr *ParseError
+	var typeErr *TypeError
+	return errors.As(err, &parseErr) || errors.As(err, &typeErr)
+}
+
+// Check は式の型が want であることを検査する
+//
+//   - 算術演算・比較・関数の引数は数値
+//   - &&, ||, !, if の第1引数, TypeBool の式は条件。変数は 0 / 1 のフラグとして条件にも使える
+//   - minimize / maximize は目的関数（ParseObjective）の中でのみ使える
+func Check(n Node, want Type) error {
+	got, err := check(n, false)
+	if err != nil {
+		return err
+	}
+	return expect(n, got, want)
+}
+
+// check は式の型を返す。objective が真なら minimize / maximize を許す
+// （目的関数の項として +, -, *, / と単項 - の下に置かれている場合）
+func check(n Node, objective bool) (Type, error) {
+	switch x := n.(type) {
+	case *Number, *Variable:
+		return TypeNumber, nil
+	case *Unary:
+		if x.Op == "!" {
+			return TypeBool, operand(x.Operand, TypeBool, false)
+		}
+		return TypeNumber, operand(x.Operand, TypeNumber, objective)
+	case *Binary:
+		switch x.Op {
+		case "&&", "||":
+			if err := operand(x.Left, TypeBool, false); err != nil {
+				return 0, err
+			}
+			return TypeBool, operand(x.Right, TypeBool, false)
+		case "<", "<=", ">", ">=", "==", "!=":
+			if err := operand(x.Left, TypeNumber, false); err != nil {
+				return 0, err
+			}
+			return TypeBool, operand(x.Right, TypeNumber, false)
+		case "+", "-", "*", "/":
+			if err := operand(x.Left, TypeNumber, objective); err != nil {
+				return 0, err
+			}
+			return TypeNumber, operand(x.Right, TypeNumber, objective)
+		default:
+			if err := operand(x.Left, TypeNumber, false); err != nil {
+				return 0, err
+			}
+			return TypeNumber, operand(x.Right, TypeNumber, false)
+		}
+	case *Call:
+		switch x.Func {
+		case "minimize", "maximize":
+			if !objective {
+				return 0, &TypeError{Expr: x.String(), Msg: x.Func + " can only be used as a term of an objective function"}
+			}
+			return TypeNumber, operand(x.Args[0], TypeNumber, false)
+		case "if":
+			if err := operand(x.Args[0], TypeBool, false); err != nil {
+				return 0, err
+			}
+			for _, a := range x.Args[1:] {
+				if err := operand(a, TypeNumber, false); err != nil {
+					return 0, err
+				}
+			}
+			return TypeNumber, nil
+		default:
+			for _, a := range x.Args {
+				if err := operand(a, TypeNumber, false); err != nil {
+					return 0, err
+				}
+			}
+			return TypeNumber, nil
+		}
+	}
+	return 0, &TypeError{Expr: n.String(), Msg: "unsupported expression"}
+}
+
+func operand(n Node, want Type, objective bool) error {
+	got, err := check(n, objective)
+	if err != nil {
+		return err
+	}
+	return expect(n, got, want)
+}
+
+func expect(n Node, got, want Type) error {
+	if got == want {
+		return nil
+	}
+	// 変数はフラグとして条件に使える
+	if _, ok := n.(*Variable); ok && want == TypeBool {
+		return nil
+	}
+	return &TypeError{Expr: n.String(), Msg: fmt.Sprintf("expected a %s, got a %s", want, got)}
+}
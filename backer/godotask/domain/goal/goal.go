@@ -0,0 +1,118 @@
+package goal
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/godotask/domain/expression"
+)
+
+// 評価結果と上下限の関係
+const (
+	StatusWithinLimits = "within_limits"
+	StatusBelowMin     = "below_min"
+	StatusAboveMax     = "above_max"
+	// StatusUnbounded は上下限が設定されていない（どちらも 0）場合
+	StatusUnbounded = "unbounded"
+)
+
+// UnboundError は目標関数が参照する変数に値が無い場合のエラー
+type UnboundError struct {
+	Missing   []string
+	Available []string
+}
+
+func (e *UnboundError) Error() string {
+	msg := "unbound variables: " + strings.Join(e.Missing, ", ")
+	if len(e.Available) > 0 {
+		msg += " (available: " + strings.Join(e.Available, ", ") + ")"
+	}
+	return msg
+}
+
+// Outcome は目標関数の評価結果
+type Outcome struct {
+	Value float64 `json:"value"`
+	// Sense は目標関数の向き（minimize / maximize）
+	Sense  string `json:"sense"`
+	Status string `json:"status"`
+	// Deviation は上下限から外れた量（範囲内なら 0）
+	Deviation float64 `json:"deviation"`
+	// Violations は満たしていない制約（subject to）とその違反量
+	Violations map[string]float64 `json:"violations,omitempty"`
+	// Variables は目標関数が参照した変数の値
+	Variables map[string]float64 `json:"variables"`
+}
+
+// OutOfLimits は評価値が上下限の外にあるかどうか
+func (o *Outcome) OutOfLimits() bool {
+	return o.Status == StatusBelowMin || o.Status == StatusAboveMax
+}
+
+// Satisfied は subject to の制約をすべて満たしているかどうか
+func (o *Outcome) Satisfied() bool {
+	return len(o.Violations) == 0
+}
+
+// Evaluate は目標関数を vars で評価し、LimitMin / LimitMax と比較する
+// 目標関数が minimize(x) / maximize(x) の1項だけの場合は x の値を比較する
+// limitMin と limitMax がどちらも 0 の場合は上下限なしとして扱う
+func Evaluate(goalFunction string, vars expression.Vars, limitMin, limitMax float64) (*Outcome, error) {
+	objective, err := expression.ParseObjective(goalFunction)
+	if err != nil {
+		return nil, err
+	}
+	names := expression.Variables(append([]expression.Node{objective.Measure}, objective.Constraints...)...)
+	used := make(map[string]float64, len(names))
+	var missing []string
+	for _, name := range names {
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		used[name] = v
+	}
+	if len(missing) > 0 {
+		available := make([]string, 0, len(vars))
+		for name := range vars {
+			available = append(available, name)
+		}
+		sort.Strings(available)
+		return nil, &UnboundError{Missing: missing, Available: available}
+	}
+
+	value, err := objective.Measure.Eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	o := &Outcome{Value: round(value), Sense: objective.Sense, Status: StatusWithinLimits, Variables: used}
+	for _, c := range objective.Constraints {
+		v, err := expression.Violation(c, vars)
+		if err != nil {
+			return nil, err
+		}
+		if v > 0 {
+			if o.Violations == nil {
+				o.Violations = map[string]float64{}
+			}
+			o.Violations[c.String()] = round(v)
+		}
+	}
+	switch {
+	case limitMin == 0 && limitMax == 0:
+		o.Status = StatusUnbounded
+	case value < limitMin:
+		o.Status = StatusBelowMin
+		o.Deviation = round(limitMin - value)
+	case value > limitMax:
+		o.Status = StatusAboveMax
+		o.Deviation = round(value - limitMax)
+	}
+	return o, nil
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
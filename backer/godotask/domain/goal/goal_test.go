@@ -0,0 +1,85 @@
+package goal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godotask/domain/expression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateStatus(t *testing.T) {
+	vars := expression.Vars{"x": 4, "unused": 1}
+
+	o, err := Evaluate("x ^ 2", vars, 10, 20)
+	require.NoError(t, err)
+	assert.Equal(t, 16.0, o.Value)
+	assert.Equal(t, StatusWithinLimits, o.Status)
+	assert.False(t, o.OutOfLimits())
+	// 参照した変数だけを返す
+	assert.Equal(t, map[string]float64{"x": 4}, o.Variables)
+
+	o, err = Evaluate("x ^ 2", vars, 20, 30)
+	require.NoError(t, err)
+	assert.Equal(t, StatusBelowMin, o.Status)
+	assert.Equal(t, 4.0, o.Deviation)
+	assert.True(t, o.OutOfLimits())
+
+	o, err = Evaluate("x * 0.1", vars, 0, 0.3)
+	require.NoError(t, err)
+	assert.Equal(t, StatusAboveMax, o.Status)
+	assert.InDelta(t, 0.1, o.Deviation, 1e-9)
+
+	o, err = Evaluate("x", vars, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, StatusUnbounded, o.Status)
+	assert.False(t, o.OutOfLimits())
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	_, err := Evaluate("f(x)=x^2", expression.Vars{"x": 1}, 0, 1)
+	var parseErr *expression.ParseError
+	assert.True(t, errors.As(err, &parseErr))
+
+	_, err = Evaluate("x + (x > 1)", expression.Vars{"x": 1}, 0, 1)
+	assert.True(t, expression.IsFormatError(err))
+
+	_, err = Evaluate("a + b + c", expression.Vars{"b": 1}, 0, 1)
+	var unbound *UnboundError
+	require.True(t, errors.As(err, &unbound))
+	assert.Equal(t, []string{"a", "c"}, unbound.Missing)
+	assert.Equal(t, "unbound variables: a, c (available: b)", err.Error())
+}
+
+func TestEvaluateObjective(t *testing.T) {
+	vars := expression.Vars{"score": 80, "time": 12, "collision_free": 0}
+
+	// 1項だけの maximize はその値を上下限と比較する
+	o, err := Evaluate("maximize(score)", vars, 60, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, o.Value)
+	assert.Equal(t, expression.SenseMaximize, o.Sense)
+	assert.Equal(t, StatusWithinLimits, o.Status)
+	assert.True(t, o.Satisfied())
+
+	o, err = Evaluate("minimize(time) subject to collision_free, time <= 10", vars, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, o.Value)
+	assert.Equal(t, map[string]float64{"collision_free": 1, "(time <= 10)": 2}, o.Violations)
+	assert.False(t, o.Satisfied())
+	assert.Equal(t, map[string]float64{"collision_free": 0, "time": 12}, o.Variables)
+}
+
+func TestAggregate(t *testing.T) {
+	vars := map[string]float64{}
+	a := NewAggregate("assessment")
+	a.Add(map[string]float64{"score": 2})
+	a.Add(map[string]float64{"score": 4})
+	a.Into(vars)
+	assert.Equal(t, map[string]float64{"assessment.count": 2, "assessment.score": 3}, vars)
+
+	NewAggregate("heuristics").Into(vars)
+	assert.Equal(t, 0.0, vars["heuristics.count"])
+	assert.NotContains(t, vars, "heuristics.score")
+}
@@ -0,0 +1,33 @@
+package goal
+
+import "github.com/godotask/domain/expression"
+
+// Aggregate は同じ指標の複数の値を平均してまとめる
+// 評価値は平均、件数は "<prefix>.count" に入る
+type Aggregate struct {
+	prefix string
+	sums   map[string]float64
+	counts map[string]int
+	n      int
+}
+
+func NewAggregate(prefix string) *Aggregate {
+	return &Aggregate{prefix: prefix, sums: map[string]float64{}, counts: map[string]int{}}
+}
+
+// Add は1レコード分の指標を加える
+func (a *Aggregate) Add(fields map[string]float64) {
+	a.n++
+	for k, v := range fields {
+		a.sums[k] += v
+		a.counts[k]++
+	}
+}
+
+// Into は平均値と件数を vars に書き込む。レコードが無い場合も件数 0 を書き込む
+func (a *Aggregate) Into(vars map[string]float64) {
+	vars[a.prefix+".count"] = float64(a.n)
+	for k, sum := range a.sums {
+		vars[expression.VariableName(a.prefix, k)] = sum / float64(a.counts[k])
+	}
+}
@@ -0,0 +1,73 @@
+package imaging
+
+import (
+	"fmt"
+	"math"
+)
+
+// アノテーションの種類
+const (
+	AnnotationRegion      = "region"      // (X, Y) を左上とする Width x Height の矩形
+	AnnotationPoint       = "point"       // (X, Y) の点
+	AnnotationMeasurement = "measurement" // (X, Y) から (X+Width, Y+Height) への線分の長さ
+	AnnotationText        = "text"        // (X, Y) に置いたテキスト
+)
+
+// Shape は画像上のアノテーションの形状（表示上の向きのピクセル座標）
+type Shape struct {
+	Type   string
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// ValidateShape は形状が width x height の画像内に収まっているかを確認する
+func ValidateShape(s Shape, width, height int) error {
+	for _, v := range []float64{s.X, s.Y, s.Width, s.Height} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("coordinates must be finite numbers")
+		}
+	}
+	inside := func(x, y float64) bool {
+		return x >= 0 && y >= 0 && x <= float64(width) && y <= float64(height)
+	}
+	switch s.Type {
+	case AnnotationRegion:
+		if s.Width <= 0 || s.Height <= 0 {
+			return fmt.Errorf("region must have a positive width and height")
+		}
+	case AnnotationMeasurement:
+		if s.Width == 0 && s.Height == 0 {
+			return fmt.Errorf("measurement must have a non-zero length")
+		}
+	case AnnotationPoint, AnnotationText:
+		if s.Width != 0 || s.Height != 0 {
+			return fmt.Errorf("%s must not have a width or height", s.Type)
+		}
+	default:
+		return fmt.Errorf("unknown annotation type %q (region, point, measurement or text)", s.Type)
+	}
+	if !inside(s.X, s.Y) || !inside(s.X+s.Width, s.Y+s.Height) {
+		return fmt.Errorf("%s (%g, %g, %g, %g) is outside the %dx%d image", s.Type, s.X, s.Y, s.Width, s.Height, width, height)
+	}
+	return nil
+}
+
+// 画像上の測定値の単位
+const (
+	UnitPixel       = "px"
+	UnitSquarePixel = "px2"
+)
+
+// PixelMeasure は形状の画像上の大きさを返す
+// 測定は線分の長さ（px）、矩形は面積（px2）。点とテキストは大きさを持たない（ok が false）
+func PixelMeasure(s Shape) (value float64, unit string, ok bool) {
+	switch s.Type {
+	case AnnotationMeasurement:
+		return math.Hypot(s.Width, s.Height), UnitPixel, true
+	case AnnotationRegion:
+		return s.Width * s.Height, UnitSquarePixel, true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,94 @@
+package imaging
+
+import (
+	"fmt"
+	"math"
+)
+
+// 校正後の測定値の単位
+const (
+	UnitMillimeter       = "mm"
+	UnitSquareMillimeter = "mm2"
+)
+
+// DefaultPixelUncertainty は線分の端点を指定するときの長さの標準不確かさ（ピクセル）の既定値
+const DefaultPixelUncertainty = 1.0
+
+// Scale は基準物から求めた画像の縮尺
+// 不確かさはすべて標準不確かさ（1σ）
+type Scale struct {
+	// MillimetersPerPixel は 1 ピクセルあたりの長さ（mm）
+	MillimetersPerPixel float64
+	// RelativeUncertainty は縮尺の相対標準不確かさ
+	RelativeUncertainty float64
+	// PixelUncertainty は測定線分の長さ・矩形の辺の標準不確かさ（ピクセル）
+	PixelUncertainty float64
+}
+
+// Calibrate は基準物の既知の長さ（mm）と画像上の長さ（ピクセル）から縮尺を求める
+// knownRelUncertainty は既知の長さの相対標準不確かさ、pixelUncertainty は画像上の長さの標準不確かさ
+// 縮尺の相対不確かさは両者の二乗和の平方根になる
+func Calibrate(pixelLength, knownLength, knownRelUncertainty, pixelUncertainty float64) (Scale, error) {
+	for name, v := range map[string]float64{
+		"pixel length":             pixelLength,
+		"known length":             knownLength,
+		"known length uncertainty": knownRelUncertainty,
+		"pixel uncertainty":        pixelUncertainty,
+	} {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			return Scale{}, fmt.Errorf("%s must be a non-negative finite number", name)
+		}
+	}
+	if pixelLength == 0 || knownLength == 0 {
+		return Scale{}, fmt.Errorf("pixel length and known length must be positive")
+	}
+	if pixelUncertainty >= pixelLength {
+		return Scale{}, fmt.Errorf("reference is too short (%g px) for a pixel uncertainty of %g px", pixelLength, pixelUncertainty)
+	}
+	return Scale{
+		MillimetersPerPixel: knownLength / pixelLength,
+		RelativeUncertainty: math.Hypot(knownRelUncertainty, pixelUncertainty/pixelLength),
+		PixelUncertainty:    pixelUncertainty,
+	}, nil
+}
+
+// Convert は測定線分の長さ（mm）または矩形の面積（mm2）と、その標準不確かさを返す
+// 点とテキストは大きさを持たない（ok が false）
+func (s Scale) Convert(shape Shape) (value, uncertainty float64, unit string, ok bool) {
+	switch shape.Type {
+	case AnnotationMeasurement:
+		length := math.Hypot(shape.Width, shape.Height)
+		rel := math.Hypot(s.RelativeUncertainty, s.PixelUncertainty/length)
+		value = length * s.MillimetersPerPixel
+		return value, value * rel, UnitMillimeter, true
+	case AnnotationRegion:
+		// 面積は縮尺の 2 乗に比例するため、縮尺の相対不確かさは 2 倍で効く
+		w, h := math.Abs(shape.Width), math.Abs(shape.Height)
+		rel := math.Sqrt(math.Pow(2*s.RelativeUncertainty, 2) + math.Pow(s.PixelUncertainty/w, 2) + math.Pow(s.PixelUncertainty/h, 2))
+		value = w * h * s.MillimetersPerPixel * s.MillimetersPerPixel
+		return value, value * rel, UnitSquareMillimeter, true
+	}
+	return 0, 0, "", false
+}
+
+// Confidence は相対標準不確かさを信頼度（0〜1）に変換する
+// 拡張不確かさ（包含係数 k=2）の相対値を 1 から引いたもので、不確かさが 50% 以上なら 0
+func Confidence(relUncertainty float64) float64 {
+	c := 1 - 2*relUncertainty
+	if math.IsNaN(c) || c < 0 {
+		return 0
+	}
+	if c > 1 {
+		return 1
+	}
+	return c
+}
+
+// VariabilityUncertainty は寸法の相対的なばらつきの範囲 [min, max]（例: -0.01〜0.01）を
+// 一様分布とみなした相対標準不確かさ（半幅 / √3）に変換する
+func VariabilityUncertainty(min, max float64) float64 {
+	if max <= min {
+		return 0
+	}
+	return (max - min) / 2 / math.Sqrt(3)
+}
@@ -0,0 +1,301 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// ErrNoEXIF は画像に EXIF が含まれていない場合のエラー
+var ErrNoEXIF = errors.New("image has no exif metadata")
+
+// exifTags は取り出す EXIF タグとその名前（IFD0 / Exif IFD）
+var exifTags = map[uint16]string{
+	0x010F: "Make",
+	0x0110: "Model",
+	0x0112: "Orientation",
+	0x011A: "XResolution",
+	0x011B: "YResolution",
+	0x0128: "ResolutionUnit",
+	0x0131: "Software",
+	0x0132: "DateTime",
+	0x829A: "ExposureTime",
+	0x829D: "FNumber",
+	0x8827: "ISOSpeedRatings",
+	0x9003: "DateTimeOriginal",
+	0x920A: "FocalLength",
+	0xA002: "PixelXDimension",
+	0xA003: "PixelYDimension",
+	0xA405: "FocalLengthIn35mmFilm",
+	0xA434: "LensModel",
+}
+
+const (
+	tagExifIFD = 0x8769
+	tagGPSIFD  = 0x8825
+)
+
+// ParseEXIF は JPEG（APP1）または PNG（eXIf チャンク）から主要な EXIF タグを取り出す
+// 数値は float64、文字列は前後の空白と NUL を除いた string になる
+// GPS 座標は 10 進の度（南緯・西経は負）に変換して GPSLatitude / GPSLongitude / GPSAltitude に入れる
+func ParseEXIF(data []byte) (map[string]interface{}, error) {
+	tiff := findTIFF(data)
+	if tiff == nil {
+		return nil, ErrNoEXIF
+	}
+	r, err := newTIFFReader(tiff)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]interface{}{}
+	ifd0, err := r.readIFD(r.first)
+	if err != nil {
+		return nil, err
+	}
+	r.collect(ifd0, out)
+	if e, ok := ifd0[tagExifIFD]; ok {
+		if off, ok := e.uint(); ok {
+			if sub, err := r.readIFD(off); err == nil {
+				r.collect(sub, out)
+			}
+		}
+	}
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		if off, ok := e.uint(); ok {
+			if gps, err := r.readIFD(off); err == nil {
+				r.collectGPS(gps, out)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil, ErrNoEXIF
+	}
+	return out, nil
+}
+
+// Orientation は EXIF の Orientation（1〜8）を返す。無い場合や不正な値の場合は 1
+func Orientation(exif map[string]interface{}) int {
+	v, ok := exif["Orientation"].(float64)
+	if !ok || v < 1 || v > 8 {
+		return 1
+	}
+	return int(v)
+}
+
+// findTIFF は EXIF の TIFF ヘッダ以降のバイト列を探す
+func findTIFF(data []byte) []byte {
+	switch {
+	case len(data) > 4 && data[0] == 0xFF && data[1] == 0xD8:
+		// JPEG: SOS までのマーカーセグメントから "Exif\0\0" で始まる APP1 を探す
+		for i := 2; i+4 <= len(data); {
+			if data[i] != 0xFF {
+				return nil
+			}
+			marker := data[i+1]
+			if marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 || marker == 0xFF {
+				i++
+				continue
+			}
+			if marker == 0xDA || marker == 0xD9 {
+				return nil
+			}
+			size := int(binary.BigEndian.Uint16(data[i+2:]))
+			end := i + 2 + size
+			if size < 2 || end > len(data) {
+				return nil
+			}
+			segment := data[i+4 : end]
+			if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				return segment[6:]
+			}
+			i = end
+		}
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		for i := 8; i+12 <= len(data); {
+			size := int(binary.BigEndian.Uint32(data[i:]))
+			end := i + 12 + size
+			if size < 0 || end > len(data) {
+				return nil
+			}
+			switch string(data[i+4 : i+8]) {
+			case "eXIf":
+				return data[i+8 : i+8+size]
+			case "IDAT", "IEND":
+				return nil
+			}
+			i = end
+		}
+	}
+	return nil
+}
+
+// tiffReader は TIFF 構造の IFD を読む
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+	first uint32
+}
+
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+	order binary.ByteOrder
+}
+
+// TIFF のデータ型ごとのバイト数
+var tiffTypeSize = map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 9: 4, 10: 8}
+
+func newTIFFReader(data []byte) (*tiffReader, error) {
+	if len(data) < 8 {
+		return nil, ErrNoEXIF
+	}
+	r := &tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return nil, ErrNoEXIF
+	}
+	if r.order.Uint16(data[2:]) != 42 {
+		return nil, ErrNoEXIF
+	}
+	r.first = r.order.Uint32(data[4:])
+	return r, nil
+}
+
+func (r *tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return nil, ErrNoEXIF
+	}
+	n := uint32(r.order.Uint16(r.data[offset:]))
+	if uint64(offset)+2+uint64(n)*12 > uint64(len(r.data)) {
+		return nil, ErrNoEXIF
+	}
+	entries := make(map[uint16]ifdEntry, n)
+	for i := uint32(0); i < n; i++ {
+		p := offset + 2 + i*12
+		tag := r.order.Uint16(r.data[p:])
+		typ := r.order.Uint16(r.data[p+2:])
+		count := r.order.Uint32(r.data[p+4:])
+		size, ok := tiffTypeSize[typ]
+		if !ok || count == 0 || uint64(count)*uint64(size) > uint64(len(r.data)) {
+			continue
+		}
+		total := count * size
+		value := r.data[p+8 : p+12]
+		if total > 4 {
+			off := r.order.Uint32(value)
+			if uint64(off)+uint64(total) > uint64(len(r.data)) {
+				continue
+			}
+			value = r.data[off : off+total]
+		} else {
+			value = value[:total]
+		}
+		entries[tag] = ifdEntry{typ: typ, count: count, value: value, order: r.order}
+	}
+	return entries, nil
+}
+
+func (r *tiffReader) collect(entries map[uint16]ifdEntry, out map[string]interface{}) {
+	for tag, name := range exifTags {
+		e, ok := entries[tag]
+		if !ok {
+			continue
+		}
+		if e.typ == 2 {
+			if s := e.string(); s != "" {
+				out[name] = s
+			}
+			continue
+		}
+		if v, ok := e.float(0); ok {
+			out[name] = v
+		}
+	}
+}
+
+func (r *tiffReader) collectGPS(entries map[uint16]ifdEntry, out map[string]interface{}) {
+	coord := func(refTag, valueTag uint16, negative string) (float64, bool) {
+		e, ok := entries[valueTag]
+		if !ok || e.count < 3 {
+			return 0, false
+		}
+		deg, ok1 := e.float(0)
+		min, ok2 := e.float(1)
+		sec, ok3 := e.float(2)
+		if !ok1 || !ok2 || !ok3 {
+			return 0, false
+		}
+		v := deg + min/60 + sec/3600
+		if ref, ok := entries[refTag]; ok && strings.EqualFold(ref.string(), negative) {
+			v = -v
+		}
+		return v, true
+	}
+	if v, ok := coord(0x0001, 0x0002, "S"); ok {
+		out["GPSLatitude"] = v
+	}
+	if v, ok := coord(0x0003, 0x0004, "W"); ok {
+		out["GPSLongitude"] = v
+	}
+	if e, ok := entries[0x0006]; ok {
+		if v, ok := e.float(0); ok {
+			// GPSAltitudeRef が 1 の場合は海抜より下
+			if ref, ok := entries[0x0005]; ok && len(ref.value) > 0 && ref.value[0] == 1 {
+				v = -v
+			}
+			out["GPSAltitude"] = v
+		}
+	}
+}
+
+func (e ifdEntry) string() string {
+	return strings.TrimSpace(strings.TrimRight(string(e.value), "\x00"))
+}
+
+// uint は IFD へのオフセットなど単一の整数値を返す
+func (e ifdEntry) uint() (uint32, bool) {
+	switch e.typ {
+	case 3:
+		return uint32(e.order.Uint16(e.value)), true
+	case 4:
+		return e.order.Uint32(e.value), true
+	}
+	return 0, false
+}
+
+// float は i 番目の値を float64 で返す。分母が 0 の有理数は値なしとする
+func (e ifdEntry) float(i uint32) (float64, bool) {
+	if i >= e.count {
+		return 0, false
+	}
+	switch e.typ {
+	case 1, 7:
+		return float64(e.value[i]), true
+	case 3:
+		return float64(e.order.Uint16(e.value[i*2:])), true
+	case 4:
+		return float64(e.order.Uint32(e.value[i*4:])), true
+	case 9:
+		return float64(int32(e.order.Uint32(e.value[i*4:]))), true
+	case 5:
+		num, den := e.order.Uint32(e.value[i*8:]), e.order.Uint32(e.value[i*8+4:])
+		if den == 0 {
+			return 0, false
+		}
+		return float64(num) / float64(den), true
+	case 10:
+		num, den := int32(e.order.Uint32(e.value[i*8:])), int32(e.order.Uint32(e.value[i*8+4:]))
+		if den == 0 {
+			return 0, false
+		}
+		return float64(num) / float64(den), true
+	}
+	return 0, false
+}
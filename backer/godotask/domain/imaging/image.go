@@ -0,0 +1,161 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/nfnt/resize"
+)
+
+var (
+	// ErrUnsupportedFormat は JPEG / PNG / GIF 以外の画像
+	ErrUnsupportedFormat = errors.New("unsupported image format (jpeg, png and gif are supported)")
+	// ErrTooManyPixels は展開すると MaxPixels を超える画像
+	ErrTooManyPixels = errors.New("image has too many pixels")
+)
+
+// MaxPixels はデコードする画像の画素数の上限（展開後のメモリを抑える）
+const MaxPixels = 50_000_000
+
+// ThumbnailSizes は生成するサムネイルの長辺（ピクセル）
+var ThumbnailSizes = []int{160, 480, 1024}
+
+// Analysis は画像の解析結果
+// Width / Height と Image は EXIF の Orientation を適用した表示上の向き
+type Analysis struct {
+	Format      string
+	ContentType string
+	Width       int
+	Height      int
+	// Hash は知覚ハッシュ（pHash）の 16 桁の 16 進表記
+	Hash  string
+	EXIF  map[string]interface{}
+	Image image.Image
+}
+
+// Thumbnail はエンコード済みのサムネイル
+type Thumbnail struct {
+	Size        int
+	Width       int
+	Height      int
+	ContentType string
+	Data        []byte
+}
+
+// Analyze は画像をデコードし、寸法・EXIF・知覚ハッシュを求める
+func Analyze(data []byte) (*Analysis, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("invalid image size %dx%d", cfg.Width, cfg.Height)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Analysis{Format: format, ContentType: "image/" + format}
+	if exif, err := ParseEXIF(data); err == nil {
+		a.EXIF = exif
+		img = Orient(img, Orientation(exif))
+	}
+	a.Image = img
+	a.Width, a.Height = img.Bounds().Dx(), img.Bounds().Dy()
+	a.Hash = fmt.Sprintf("%016x", PHash(img))
+	return a, nil
+}
+
+// Orient は EXIF の Orientation（1〜8）に従って画像を回転・反転する
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	// 5〜8 は縦横が入れ替わる
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = w-1-x, y
+			case 3: // 180 度回転
+				dx, dy = w-1-x, h-1-y
+			case 4: // 上下反転
+				dx, dy = x, h-1-y
+			case 5: // 転置
+				dx, dy = y, x
+			case 6: // 時計回りに 90 度回転
+				dx, dy = h-1-y, x
+			case 7: // 反転置
+				dx, dy = h-1-y, w-1-x
+			case 8: // 反時計回りに 90 度回転
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// Thumbnails は長辺が sizes になるサムネイルを作る。元画像以上の大きさは作らない
+// PNG / GIF は透過を保つため PNG、それ以外は JPEG でエンコードする
+func Thumbnails(a *Analysis, sizes []int) ([]Thumbnail, error) {
+	var thumbs []Thumbnail
+	for _, size := range sizes {
+		if size <= 0 || (size >= a.Width && size >= a.Height) {
+			continue
+		}
+		img := resize.Thumbnail(uint(size), uint(size), a.Image, resize.Lanczos3)
+		var buf bytes.Buffer
+		contentType := "image/jpeg"
+		var err error
+		if a.Format == "png" || a.Format == "gif" {
+			contentType = "image/png"
+			err = png.Encode(&buf, img)
+		} else {
+			err = jpeg.Encode(&buf, opaque(img), &jpeg.Options{Quality: 85})
+		}
+		if err != nil {
+			return nil, err
+		}
+		thumbs = append(thumbs, Thumbnail{
+			Size:        size,
+			Width:       img.Bounds().Dx(),
+			Height:      img.Bounds().Dy(),
+			ContentType: contentType,
+			Data:        buf.Bytes(),
+		})
+	}
+	return thumbs, nil
+}
+
+// opaque は JPEG に書き出せるよう透過部分を白で埋めた画像を返す
+func opaque(img image.Image) image.Image {
+	if _, ok := img.(*image.YCbCr); ok {
+		return img
+	}
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,328 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tiffEntry はテスト用の IFD エントリ。Data はバイト順変換済みの値
+type tiffEntry struct {
+	Tag   uint16
+	Type  uint16
+	Count uint32
+	Data  []byte
+	// Sub が指定されている場合は Data の代わりにサブ IFD へのオフセット（LONG）を入れる
+	Sub []tiffEntry
+}
+
+// buildTIFF は IFD0 とサブ IFD からなる TIFF を組み立てる
+func buildTIFF(order binary.ByteOrder, ifd0 []tiffEntry) []byte {
+	var buf bytes.Buffer
+	if order == binary.LittleEndian {
+		buf.WriteString("II")
+	} else {
+		buf.WriteString("MM")
+	}
+	_ = binary.Write(&buf, order, uint16(42))
+	_ = binary.Write(&buf, order, uint32(8))
+
+	var writeIFD func(entries []tiffEntry) uint32
+	writeIFD = func(entries []tiffEntry) uint32 {
+		start := uint32(buf.Len())
+		size := 2 + 12*len(entries) + 4
+		table := make([]byte, size)
+		buf.Write(table)
+		order.PutUint16(table, uint16(len(entries)))
+		for i, e := range entries {
+			p := 2 + 12*i
+			order.PutUint16(table[p:], e.Tag)
+			order.PutUint16(table[p+2:], e.Type)
+			if e.Sub != nil {
+				order.PutUint16(table[p+2:], 4)
+				order.PutUint32(table[p+4:], 1)
+				order.PutUint32(table[p+8:], writeIFD(e.Sub))
+				continue
+			}
+			order.PutUint32(table[p+4:], e.Count)
+			if len(e.Data) <= 4 {
+				copy(table[p+8:p+12], e.Data)
+			} else {
+				order.PutUint32(table[p+8:], uint32(buf.Len()))
+				buf.Write(e.Data)
+			}
+		}
+		out := buf.Bytes()
+		copy(out[start:], table)
+		return start
+	}
+	writeIFD(ifd0)
+	return buf.Bytes()
+}
+
+func ascii(s string) tiffEntry {
+	return tiffEntry{Type: 2, Count: uint32(len(s) + 1), Data: append([]byte(s), 0)}
+}
+
+func short(order binary.ByteOrder, v uint16) tiffEntry {
+	b := make([]byte, 2)
+	order.PutUint16(b, v)
+	return tiffEntry{Type: 3, Count: 1, Data: b}
+}
+
+func rationals(order binary.ByteOrder, v ...uint32) tiffEntry {
+	b := make([]byte, 4*len(v))
+	for i, x := range v {
+		order.PutUint32(b[i*4:], x)
+	}
+	return tiffEntry{Type: 5, Count: uint32(len(v) / 2), Data: b}
+}
+
+func tagged(tag uint16, e tiffEntry) tiffEntry {
+	e.Tag = tag
+	return e
+}
+
+func sampleTIFF(order binary.ByteOrder, orientation uint16) []byte {
+	return buildTIFF(order, []tiffEntry{
+		tagged(0x010F, ascii("Acme")),
+		tagged(0x0110, ascii("Inspector 3000")),
+		tagged(0x0112, short(order, orientation)),
+		{Tag: tagExifIFD, Sub: []tiffEntry{
+			tagged(0x829D, rationals(order, 28, 10)),
+			tagged(0x8827, short(order, 400)),
+			tagged(0x9003, ascii("2026:01:02 03:04:05")),
+		}},
+		{Tag: tagGPSIFD, Sub: []tiffEntry{
+			tagged(0x0001, ascii("N")),
+			tagged(0x0002, rationals(order, 35, 1, 30, 1, 0, 1)),
+			tagged(0x0003, ascii("W")),
+			tagged(0x0004, rationals(order, 139, 1, 45, 1, 0, 1)),
+		}},
+	})
+}
+
+// withEXIF は JPEG の SOI の直後に EXIF の APP1 セグメントを挿入する
+func withEXIF(jpg, tiff []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	seg := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, seg...)
+	out = append(out, payload...)
+	return append(out, jpg[2:]...)
+}
+
+// gradient は左上から右下に明るくなる画像に、左上の四角を描いたもの
+func gradient(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8((x*255/w + y*255/h) / 2)
+			if x < w/4 && y < h/4 {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{v, v / 2, 255 - v, 255})
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}))
+	return buf.Bytes()
+}
+
+func TestParseEXIF(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			data := withEXIF(encodeJPEG(t, gradient(8, 8)), sampleTIFF(order, 6))
+			exif, err := ParseEXIF(data)
+			require.NoError(t, err)
+
+			assert.Equal(t, "Acme", exif["Make"])
+			assert.Equal(t, "Inspector 3000", exif["Model"])
+			assert.Equal(t, 6.0, exif["Orientation"])
+			assert.InDelta(t, 2.8, exif["FNumber"], 1e-9)
+			assert.Equal(t, 400.0, exif["ISOSpeedRatings"])
+			assert.Equal(t, "2026:01:02 03:04:05", exif["DateTimeOriginal"])
+			assert.InDelta(t, 35.5, exif["GPSLatitude"], 1e-9)
+			assert.InDelta(t, -139.75, exif["GPSLongitude"], 1e-9)
+			assert.Equal(t, 6, Orientation(exif))
+		})
+	}
+
+	_, err := ParseEXIF(encodeJPEG(t, gradient(8, 8)))
+	assert.ErrorIs(t, err, ErrNoEXIF)
+
+	// 壊れたオフセットでも panic しない
+	broken := sampleTIFF(binary.LittleEndian, 1)
+	binary.LittleEndian.PutUint32(broken[4:], 0xFFFFFF)
+	_, err = ParseEXIF(withEXIF(encodeJPEG(t, gradient(8, 8)), broken))
+	assert.Error(t, err)
+}
+
+func TestOrient(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	marker := color.RGBA{255, 0, 0, 255}
+	src.Set(0, 0, marker) // 左上
+
+	tests := []struct {
+		orientation int
+		w, h        int
+		x, y        int
+	}{
+		{1, 3, 2, 0, 0},
+		{2, 3, 2, 2, 0},
+		{3, 3, 2, 2, 1},
+		{4, 3, 2, 0, 1},
+		{5, 2, 3, 0, 0},
+		{6, 2, 3, 1, 0},
+		{7, 2, 3, 1, 2},
+		{8, 2, 3, 0, 2},
+	}
+	for _, tt := range tests {
+		out := Orient(src, tt.orientation)
+		assert.Equal(t, tt.w, out.Bounds().Dx(), "orientation %d", tt.orientation)
+		assert.Equal(t, tt.h, out.Bounds().Dy(), "orientation %d", tt.orientation)
+		r, _, _, _ := out.At(tt.x, tt.y).RGBA()
+		assert.Equal(t, uint32(0xFFFF), r, "orientation %d", tt.orientation)
+	}
+}
+
+func TestPHash(t *testing.T) {
+	base := gradient(400, 300)
+	h := PHash(base)
+
+	// 縮小・再圧縮した同じ画像は近い
+	decoded, err := jpeg.Decode(bytes.NewReader(encodeJPEG(t, base)))
+	require.NoError(t, err)
+	thumbs, err := Thumbnails(&Analysis{Format: "png", Width: 400, Height: 300, Image: decoded}, []int{120})
+	require.NoError(t, err)
+	require.Len(t, thumbs, 1)
+	resized, err := png.Decode(bytes.NewReader(thumbs[0].Data))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, HammingDistance(h, PHash(resized)), 6)
+
+	// 上下反転した画像は遠い
+	assert.Greater(t, HammingDistance(h, PHash(Orient(base, 4))), 16)
+
+	parsed, err := ParseHash("00000000000000ff")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0xff), parsed)
+	assert.Equal(t, 8, HammingDistance(0, parsed))
+}
+
+func TestAnalyze(t *testing.T) {
+	data := withEXIF(encodeJPEG(t, gradient(640, 480)), sampleTIFF(binary.BigEndian, 6))
+	a, err := Analyze(data)
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", a.Format)
+	assert.Equal(t, "image/jpeg", a.ContentType)
+	// Orientation 6 は縦横が入れ替わる
+	assert.Equal(t, 480, a.Width)
+	assert.Equal(t, 640, a.Height)
+	assert.Len(t, a.Hash, 16)
+	assert.Equal(t, "Acme", a.EXIF["Make"])
+
+	thumbs, err := Thumbnails(a, ThumbnailSizes)
+	require.NoError(t, err)
+	// 1024 は元画像より大きいため作らない
+	require.Len(t, thumbs, 2)
+	assert.Equal(t, 160, thumbs[0].Size)
+	assert.Equal(t, 120, thumbs[0].Width)
+	assert.Equal(t, 160, thumbs[0].Height)
+	assert.Equal(t, "image/jpeg", thumbs[0].ContentType)
+	assert.Equal(t, 480, thumbs[1].Height)
+
+	_, err = Analyze([]byte("not an image"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestValidateShape(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape Shape
+		ok    bool
+	}{
+		{"region", Shape{Type: AnnotationRegion, X: 10, Y: 10, Width: 50, Height: 20}, true},
+		{"region to the edge", Shape{Type: AnnotationRegion, X: 0, Y: 0, Width: 100, Height: 80}, true},
+		{"empty region", Shape{Type: AnnotationRegion, X: 10, Y: 10, Width: 0, Height: 20}, false},
+		{"region outside", Shape{Type: AnnotationRegion, X: 90, Y: 10, Width: 20, Height: 20}, false},
+		{"measurement backwards", Shape{Type: AnnotationMeasurement, X: 50, Y: 50, Width: -30, Height: -40}, true},
+		{"zero measurement", Shape{Type: AnnotationMeasurement, X: 50, Y: 50}, false},
+		{"measurement outside", Shape{Type: AnnotationMeasurement, X: 50, Y: 50, Width: -60}, false},
+		{"point", Shape{Type: AnnotationPoint, X: 100, Y: 80}, true},
+		{"point with size", Shape{Type: AnnotationPoint, X: 1, Y: 1, Width: 1}, false},
+		{"negative point", Shape{Type: AnnotationPoint, X: -1, Y: 1}, false},
+		{"nan", Shape{Type: AnnotationText, X: math.NaN()}, false},
+		{"unknown", Shape{Type: "circle"}, false},
+	}
+	for _, tt := range tests {
+		err := ValidateShape(tt.shape, 100, 80)
+		assert.Equal(t, tt.ok, err == nil, "%s: %v", tt.name, err)
+	}
+
+	v, unit, ok := PixelMeasure(Shape{Type: AnnotationMeasurement, Width: -30, Height: 40})
+	assert.True(t, ok)
+	assert.Equal(t, 50.0, v)
+	assert.Equal(t, UnitPixel, unit)
+	v, unit, _ = PixelMeasure(Shape{Type: AnnotationRegion, Width: 4, Height: 5})
+	assert.Equal(t, 20.0, v)
+	assert.Equal(t, UnitSquarePixel, unit)
+	_, _, ok = PixelMeasure(Shape{Type: AnnotationPoint})
+	assert.False(t, ok)
+}
+
+func TestCalibrate(t *testing.T) {
+	// 85.6mm（±0.1% の一様分布）のカードに 428px の線分を引いた
+	known := VariabilityUncertainty(-0.001, 0.001)
+	assert.InDelta(t, 0.001/math.Sqrt(3), known, 1e-12)
+	s, err := Calibrate(428, 85.6, known, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, s.MillimetersPerPixel, 1e-12)
+	assert.InDelta(t, math.Hypot(known, 1.0/428), s.RelativeUncertainty, 1e-12)
+
+	// 300px の線分は 60mm。不確かさは縮尺と線分の長さの二乗和
+	v, u, unit, ok := s.Convert(Shape{Type: AnnotationMeasurement, Width: -180, Height: 240})
+	require.True(t, ok)
+	assert.Equal(t, UnitMillimeter, unit)
+	assert.InDelta(t, 60, v, 1e-9)
+	assert.InDelta(t, 60*math.Hypot(s.RelativeUncertainty, 1.0/300), u, 1e-9)
+
+	// 100x50px の矩形は 200mm2。縮尺の不確かさは 2 倍で効く
+	v, u, unit, ok = s.Convert(Shape{Type: AnnotationRegion, Width: 100, Height: 50})
+	require.True(t, ok)
+	assert.Equal(t, UnitSquareMillimeter, unit)
+	assert.InDelta(t, 200, v, 1e-9)
+	rel := math.Sqrt(4*s.RelativeUncertainty*s.RelativeUncertainty + 1.0/10000 + 1.0/2500)
+	assert.InDelta(t, 200*rel, u, 1e-9)
+
+	_, _, _, ok = s.Convert(Shape{Type: AnnotationPoint})
+	assert.False(t, ok)
+
+	for _, args := range [][4]float64{
+		{0, 10, 0, 1},
+		{10, 0, 0, 1},
+		{10, 10, -0.1, 1},
+		{10, 10, 0, math.NaN()},
+		{1, 10, 0, 1},
+	} {
+		_, err := Calibrate(args[0], args[1], args[2], args[3])
+		assert.Error(t, err, "%v", args)
+	}
+
+	assert.Equal(t, 1.0, Confidence(0))
+	assert.InDelta(t, 0.9, Confidence(0.05), 1e-12)
+	assert.Equal(t, 0.0, Confidence(0.7))
+	assert.Equal(t, 0.0, VariabilityUncertainty(0.1, 0.1))
+}
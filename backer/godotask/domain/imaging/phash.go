@@ -0,0 +1,90 @@
+package imaging
+
+import (
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+
+	"github.com/nfnt/resize"
+)
+
+const (
+	phashSize    = 32
+	phashLowFreq = 8
+)
+
+// PHash は DCT による知覚ハッシュ（64 ビット）を返す
+// 32x32 の輝度画像の DCT から低周波 8x8 成分を取り、直流成分を除いた中央値より大きいかをビットにする
+// 拡大縮小や再圧縮ではハミング距離が小さく、別の画像では大きくなる
+func PHash(img image.Image) uint64 {
+	small := resize.Resize(phashSize, phashSize, img, resize.Bilinear)
+	b := small.Bounds()
+	var pixels [phashSize][phashSize]float64
+	for y := 0; y < phashSize; y++ {
+		for x := 0; x < phashSize; x++ {
+			r, g, bl, _ := small.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			pixels[y][x] = 0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(bl>>8)
+		}
+	}
+
+	coeffs := dct2D(pixels)
+	low := make([]float64, 0, phashLowFreq*phashLowFreq)
+	for y := 0; y < phashLowFreq; y++ {
+		for x := 0; x < phashLowFreq; x++ {
+			low = append(low, coeffs[y][x])
+		}
+	}
+	sorted := append([]float64(nil), low[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash uint64
+	for i, v := range low {
+		if v > median {
+			hash |= 1 << uint(63-i)
+		}
+	}
+	return hash
+}
+
+// HammingDistance は 2 つのハッシュで異なるビットの数を返す
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// ParseHash は PHash の 16 進表記を数値に戻す
+func ParseHash(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
+
+// dct2D は 2 次元 DCT-II（行ごと・列ごとの 1 次元 DCT）を計算する
+func dct2D(in [phashSize][phashSize]float64) [phashSize][phashSize]float64 {
+	var cos [phashSize][phashSize]float64
+	for k := 0; k < phashSize; k++ {
+		for n := 0; n < phashSize; n++ {
+			cos[k][n] = math.Cos(math.Pi / phashSize * (float64(n) + 0.5) * float64(k))
+		}
+	}
+	var rows, out [phashSize][phashSize]float64
+	for y := 0; y < phashSize; y++ {
+		for k := 0; k < phashSize; k++ {
+			var sum float64
+			for n := 0; n < phashSize; n++ {
+				sum += in[y][n] * cos[k][n]
+			}
+			rows[y][k] = sum
+		}
+	}
+	for x := 0; x < phashSize; x++ {
+		for k := 0; k < phashSize; k++ {
+			var sum float64
+			for n := 0; n < phashSize; n++ {
+				sum += rows[n][x] * cos[k][n]
+			}
+			out[k][x] = sum
+		}
+	}
+	return out
+}
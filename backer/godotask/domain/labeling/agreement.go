@@ -0,0 +1,138 @@
+package labeling
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 一致度の算出方法
+const (
+	MethodCohen  = "cohen"  // 検証者が 2 人
+	MethodFleiss = "fleiss" // 検証者が 3 人以上
+)
+
+// CohenKappa は 2 人の評価者が同じ項目に付けた分類 a, b の一致度（Cohen's kappa）を返す
+// 偶然一致の確率が 1（2 人とも同じ 1 つの分類だけを使った）の場合は完全一致として 1 を返す
+func CohenKappa(a, b []string) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("raters judged different numbers of items: %d and %d", len(a), len(b))
+	}
+	if len(a) == 0 {
+		return 0, fmt.Errorf("no items to compare")
+	}
+	n := float64(len(a))
+	agree := 0
+	countA, countB := map[string]int{}, map[string]int{}
+	for i := range a {
+		if a[i] == b[i] {
+			agree++
+		}
+		countA[a[i]]++
+		countB[b[i]]++
+	}
+	observed := float64(agree) / n
+	expected := 0.0
+	for category, ca := range countA {
+		expected += float64(ca) / n * float64(countB[category]) / n
+	}
+	if expected >= 1 {
+		return 1, nil
+	}
+	return (observed - expected) / (1 - expected), nil
+}
+
+// FleissKappa は項目ごとの分類別の評価者数 items から一致度（Fleiss' kappa）を返す
+// 項目ごとに評価者数が異なってもよく、評価者が 2 人未満の項目は除く。対象の項目が無い場合 ok は false
+// 偶然一致の確率が 1 の場合は完全一致として 1 を返す
+func FleissKappa(items []map[string]int) (kappa float64, ok bool) {
+	totals := map[string]int{}
+	ratings := 0
+	sumAgreement := 0.0
+	count := 0
+	for _, item := range items {
+		n := 0
+		for _, c := range item {
+			n += c
+		}
+		if n < 2 {
+			continue
+		}
+		pairs := 0
+		for category, c := range item {
+			pairs += c * (c - 1)
+			totals[category] += c
+		}
+		ratings += n
+		sumAgreement += float64(pairs) / float64(n*(n-1))
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	observed := sumAgreement / float64(count)
+	expected := 0.0
+	for _, c := range totals {
+		p := float64(c) / float64(ratings)
+		expected += p * p
+	}
+	if expected >= 1 {
+		return 1, true
+	}
+	return (observed - expected) / (1 - expected), true
+}
+
+// Judgement は 1 人の検証者の判定（判定項目 → 分類）
+type Judgement map[string]string
+
+// Agreement は 1 つのラベルに対する検証者間の一致度を返す
+// 判定項目（採否と値・単位などの正誤）を評価の対象とし、検証者が 2 人なら Cohen's kappa、
+// 3 人以上なら Fleiss' kappa を使う。検証者が 2 人未満の場合 ok は false
+func Agreement(judgements []Judgement) (kappa float64, method string, ok bool) {
+	if len(judgements) < 2 {
+		return 0, "", false
+	}
+	keys := map[string]bool{}
+	for _, j := range judgements {
+		for k := range j {
+			keys[k] = true
+		}
+	}
+	items := make([]string, 0, len(keys))
+	for k := range keys {
+		items = append(items, k)
+	}
+	sort.Strings(items)
+
+	if len(judgements) == 2 {
+		var a, b []string
+		for _, item := range items {
+			x, okA := judgements[0][item]
+			y, okB := judgements[1][item]
+			if okA && okB {
+				a = append(a, x)
+				b = append(b, y)
+			}
+		}
+		kappa, err := CohenKappa(a, b)
+		if err != nil {
+			return 0, "", false
+		}
+		return kappa, MethodCohen, true
+	}
+
+	counts := make([]map[string]int, 0, len(items))
+	for _, item := range items {
+		c := map[string]int{}
+		for _, j := range judgements {
+			if v, ok := j[item]; ok {
+				c[v]++
+			}
+		}
+		counts = append(counts, c)
+	}
+	kappa, ok = FleissKappa(counts)
+	if !ok {
+		return 0, "", false
+	}
+	return kappa, MethodFleiss, true
+}
@@ -0,0 +1,248 @@
+package labeling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "rz 3.2", Normalize("  Ｒｚ　３．２ "))
+	assert.Equal(t, "バリ 発生", Normalize("バリ、発生！"))
+	assert.Equal(t, "", Normalize("・・・"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("バリの発生程度", "バリの発生程度"))
+	// 一方が他方を含む場合も高い
+	assert.Greater(t, Similarity("バリ", "バリの発生程度"), 0.5)
+	assert.Greater(t, Similarity("Cutting Speed", "cutting speed"), 0.99)
+	assert.Equal(t, 0.0, Similarity("暖機時間", "表面粗さ"))
+	assert.Equal(t, 0.0, Similarity("", "表面粗さ"))
+}
+
+func TestParseModifier(t *testing.T) {
+	tests := []struct {
+		text      string
+		direction int
+		degree    float64
+		rest      string
+	}{
+		{"a bit faster", 1, SlightDegree, ""},
+		{"much less burr", -1, StrongDegree, "burr"},
+		{"もう少し速く", 1, SlightDegree, "もう"},
+		{"かなり短い暖機時間", -1, StrongDegree, "暖機時間"},
+		{"送りを速く", 1, DefaultDegree, "送りを"},
+		{"moreover the burr", 0, 0, "moreover the burr"},
+		{"バリの発生程度", 0, 0, "バリの発生程度"},
+	}
+	for _, tt := range tests {
+		m := ParseModifier(tt.text)
+		assert.Equal(t, tt.direction, m.Direction, tt.text)
+		assert.Equal(t, tt.degree, m.Degree, tt.text)
+		assert.Equal(t, tt.rest, m.Rest, tt.text)
+	}
+	assert.InDelta(t, 1.1, ParseModifier("a bit faster").Factor(), 1e-12)
+	assert.InDelta(t, 0.7, ParseModifier("much slower").Factor(), 1e-12)
+	assert.Equal(t, 1.0, ParseModifier("burr").Factor())
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func TestSuggest(t *testing.T) {
+	candidates := []Candidate{
+		{ID: "speed", Texts: []string{"切削速度の適切さ", "切削速度", "speed"}, Value: 120, MinRange: ptr(100), MaxRange: ptr(140), Unit: "m/min", Confidence: 0.85},
+		{ID: "burr", Texts: []string{"バリの発生程度", "バリ高さ", "quality"}, Value: 0.1, MinRange: ptr(0), MaxRange: ptr(0.5), Unit: "mm", Confidence: 0.75},
+		{ID: "edge-burr", Texts: []string{"エッジのかえり", "かえり高さ"}, Value: 0.08, Unit: "mm", Confidence: 0.9},
+		{ID: "warmup", Texts: []string{"機械暖機時間", "暖機時間", "time"}, Value: 15, Unit: "minute"},
+	}
+	synonyms := []Synonym{{From: "burr", To: "edge-burr", Strength: 0.8}}
+
+	got := Suggest("バリの発生程度", candidates, synonyms, 0)
+	require.Len(t, got, 2)
+	assert.Equal(t, "burr", got[0].LabelID)
+	assert.Equal(t, SourceLabel, got[0].Source)
+	assert.Equal(t, 0.1, got[0].Value)
+	assert.Equal(t, "mm", got[0].Unit)
+	assert.InDelta(t, 0.75, got[0].Confidence, 1e-9)
+	// 同義語のラベルは関係の強さに応じて信頼度が下がる
+	assert.Equal(t, "edge-burr", got[1].LabelID)
+	assert.Equal(t, SourceSynonym, got[1].Source)
+	assert.Equal(t, "burr", got[1].Via)
+	assert.InDelta(t, 0.8*0.9, got[1].Confidence, 1e-9)
+
+	// 比較表現は基準値と範囲を増減し、信頼度を下げる
+	got = Suggest("a bit faster", candidates, nil, 0)
+	require.Len(t, got, 1)
+	assert.Equal(t, "speed", got[0].LabelID)
+	assert.InDelta(t, 132, got[0].Value, 1e-9)
+	assert.InDelta(t, 110, *got[0].MinRange, 1e-9)
+	assert.InDelta(t, 154, *got[0].MaxRange, 1e-9)
+	assert.Less(t, got[0].Confidence, 0.85*modifierPenalty+1e-9)
+
+	got = Suggest("暖機時間を少し短く", candidates, nil, 1)
+	require.Len(t, got, 1)
+	assert.Equal(t, "warmup", got[0].LabelID)
+	assert.InDelta(t, 13.5, got[0].Value, 1e-9)
+	assert.Nil(t, got[0].MinRange)
+
+	assert.Empty(t, Suggest("表面粗さ", candidates, synonyms, 0))
+}
+
+func repeat(pairs ...interface{}) (a, b []string) {
+	for i := 0; i < len(pairs); i += 3 {
+		for n := 0; n < pairs[i+2].(int); n++ {
+			a = append(a, pairs[i].(string))
+			b = append(b, pairs[i+1].(string))
+		}
+	}
+	return a, b
+}
+
+func TestCohenKappa(t *testing.T) {
+	// 一致 35 / 50、偶然一致 0.5 → 0.4
+	a, b := repeat("yes", "yes", 20, "yes", "no", 5, "no", "yes", 10, "no", "no", 15)
+	kappa, err := CohenKappa(a, b)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, kappa, 1e-9)
+
+	kappa, err = CohenKappa([]string{"x", "x"}, []string{"x", "x"})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, kappa)
+
+	_, err = CohenKappa([]string{"x"}, nil)
+	assert.Error(t, err)
+}
+
+func TestFleissKappa(t *testing.T) {
+	// 14 人の評価者が 10 項目を 5 分類に振り分けた例（κ ≈ 0.210）
+	rows := [][]int{
+		{0, 0, 0, 0, 14}, {0, 2, 6, 4, 2}, {0, 0, 3, 5, 6}, {0, 3, 9, 2, 0}, {2, 2, 8, 1, 1},
+		{7, 7, 0, 0, 0}, {3, 2, 6, 3, 0}, {2, 5, 3, 2, 2}, {6, 5, 2, 1, 0}, {0, 2, 2, 3, 7},
+	}
+	var items []map[string]int
+	for _, row := range rows {
+		item := map[string]int{}
+		for j, c := range row {
+			item[string(rune('a'+j))] = c
+		}
+		items = append(items, item)
+	}
+	kappa, ok := FleissKappa(items)
+	require.True(t, ok)
+	assert.InDelta(t, 0.210, kappa, 1e-3)
+
+	// 評価者が 1 人の項目は除く
+	_, ok = FleissKappa([]map[string]int{{"accept": 1}})
+	assert.False(t, ok)
+}
+
+func TestAgreementAndResolve(t *testing.T) {
+	accept := NewJudgement(DecisionAccept, nil)
+	rangeWrong := NewJudgement(DecisionAccept, map[string]bool{"range": false})
+	reject := NewJudgement(DecisionReject, map[string]bool{"category": true})
+
+	_, _, ok := Agreement([]Judgement{accept})
+	assert.False(t, ok)
+
+	kappa, method, ok := Agreement([]Judgement{accept, accept})
+	require.True(t, ok)
+	assert.Equal(t, MethodCohen, method)
+	assert.Equal(t, 1.0, kappa)
+
+	kappa, method, ok = Agreement([]Judgement{accept, rangeWrong, reject})
+	require.True(t, ok)
+	assert.Equal(t, MethodFleiss, method)
+	assert.Less(t, kappa, 0.5)
+
+	accuracy, ok := Accuracy([]Judgement{accept, rangeWrong})
+	require.True(t, ok)
+	assert.InDelta(t, 7.0/8, accuracy, 1e-9)
+
+	assert.Equal(t, StatusSubmitted, Resolve([]string{DecisionAccept}, 2))
+	assert.Equal(t, StatusAccepted, Resolve([]string{DecisionAccept, DecisionAccept}, 2))
+	assert.Equal(t, StatusSubmitted, Resolve([]string{DecisionAccept, DecisionReject}, 2))
+	assert.Equal(t, StatusRejected, Resolve([]string{DecisionAccept, DecisionReject, DecisionReject}, 2))
+	assert.True(t, CanSubmit(StatusRejected))
+	assert.False(t, CanSubmit(StatusSubmitted))
+}
+
+func TestQuality(t *testing.T) {
+	assert.Equal(t, DatasetQuality{}, Quality(nil))
+
+	q := Quality([]LabelQuality{
+		{Category: "burr", Validated: true, Accuracy: ptr(1), Completeness: 1, Decisions: map[string]int{DecisionAccept: 2}},
+		{Category: "burr", Validated: true, Accuracy: ptr(0.5), Completeness: 0.5, Decisions: map[string]int{DecisionAccept: 2}},
+		{Category: "speed", Completeness: 0.75},
+		{Category: "roughness", Completeness: 0.75, Decisions: map[string]int{DecisionAccept: 1, DecisionReject: 1}},
+	})
+	assert.Equal(t, 4, q.TotalLabels)
+	assert.Equal(t, 2, q.VerifiedLabels)
+	assert.InDelta(t, 0.75, q.AverageAccuracy, 1e-9)
+	assert.InDelta(t, 0.75, q.Completeness, 1e-9)
+	assert.InDelta(t, 1-(0.25+0.0625+0.0625), q.Diversity, 1e-9)
+	assert.Greater(t, q.Balance, 0.9)
+	assert.Less(t, q.Balance, 1.0)
+	assert.Less(t, q.Consistency, 1.0)
+
+	q = Quality([]LabelQuality{{Category: "burr"}})
+	assert.Equal(t, 0.0, q.Diversity)
+	assert.Equal(t, 1.0, q.Balance)
+}
+
+func TestNormalizeWidth(t *testing.T) {
+	assert.Equal(t, "バリ 高さ", Normalize("ﾊﾞﾘ　高さ"))
+	assert.Equal(t, "cutting speed", Normalize("Cutting-Speed"))
+	assert.Equal(t, "0.1mm", Normalize("０．１㎜"))
+}
+
+func TestOntology(t *testing.T) {
+	// バリ高さ ⊂ バリ ⊂ 外観不良、burr height はバリ高さの同義語
+	o := NewOntology([]Relation{
+		{Source: "burr-height", Target: "burr", Type: RelationHypernym},
+		{Source: "defect", Target: "burr", Type: RelationHyponym},
+		{Source: "burr-height", Target: "burr-height-en", Type: RelationSynonym, Strength: 0.9, Bidirectional: true},
+		{Source: "burr-height-en", Target: "kaeri", Type: RelationSynonym, Strength: 0.5, Bidirectional: true},
+		{Source: "flash", Target: "burr", Type: RelationHypernym},
+	})
+
+	ancestors := o.Ancestors("burr-height-en", 0)
+	assert.Equal(t, []Node{{ID: "burr", Depth: 1, Strength: 1}, {ID: "defect", Depth: 2, Strength: 1}}, ancestors)
+	assert.Len(t, o.Ancestors("burr-height", 1), 1)
+
+	descendants := o.Descendants("defect", 0)
+	var ids []string
+	for _, n := range descendants {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"burr", "burr-height", "burr-height-en", "flash", "kaeri"}, ids)
+
+	synonyms := o.Synonyms("burr-height")
+	require.Len(t, synonyms, 2)
+	assert.Equal(t, Node{ID: "burr-height-en", Depth: 1, Strength: 0.9}, synonyms[0])
+	assert.Equal(t, "kaeri", synonyms[1].ID)
+	assert.Equal(t, 2, synonyms[1].Depth)
+	assert.InDelta(t, 0.45, synonyms[1].Strength, 1e-9)
+
+	tests := []struct {
+		relation Relation
+		err      error
+	}{
+		{Relation{Source: "burr", Target: "burr", Type: RelationSynonym, Bidirectional: true}, ErrSelfRelation},
+		{Relation{Source: "burr", Target: "x", Type: "antonym"}, ErrUnknownRelation},
+		{Relation{Source: "burr", Target: "burr-height", Type: RelationHyponym}, ErrDuplicateRelation},
+		{Relation{Source: "kaeri", Target: "burr-height-en", Type: RelationSynonym, Bidirectional: true}, ErrDuplicateRelation},
+		{Relation{Source: "defect", Target: "kaeri", Type: RelationHypernym}, ErrHypernymCycle},
+		{Relation{Source: "burr", Target: "defect", Type: RelationHyponym}, ErrHypernymCycle},
+		{Relation{Source: "kaeri", Target: "burr-height", Type: RelationHypernym}, ErrSynonymConflict},
+		{Relation{Source: "flash", Target: "defect", Type: RelationSynonym, Bidirectional: true}, ErrSynonymConflict},
+		{Relation{Source: "flash", Target: "kaeri", Type: RelationSynonym}, ErrAsymmetricSynonym},
+		{Relation{Source: "flash", Target: "x", Type: RelationHypernym, Bidirectional: true}, ErrSymmetricHypernym},
+		{Relation{Source: "flash", Target: "kaeri", Type: RelationSynonym, Bidirectional: true}, nil},
+		{Relation{Source: "kaeri", Target: "defect", Type: RelationHypernym}, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.err, o.Check(tt.relation), "%+v", tt.relation)
+	}
+}
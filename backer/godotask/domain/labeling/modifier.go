@@ -0,0 +1,136 @@
+package labeling
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 程度を表す語の変化率
+const (
+	SlightDegree  = 0.1 // 少し・やや・a bit
+	DefaultDegree = 0.2 // 程度の語が無い場合
+	StrongDegree  = 0.3 // かなり・大幅に・much
+)
+
+// Modifier は「少し速く」「a bit faster」のような比較表現
+type Modifier struct {
+	// Direction は増加なら 1、減少なら -1、比較表現が無ければ 0
+	Direction int
+	// Degree は変化率（Direction が 0 の場合は 0）
+	Degree float64
+	// Concept は比較の語が表す量（「速く」→「速度 speed」）。ラベルとの照合に使う
+	Concept string
+	// Rest は程度と比較の語を除いた語句（正規化済み）
+	Rest string
+}
+
+// Factor は基準値に掛ける倍率を返す
+func (m Modifier) Factor() float64 {
+	return 1 + float64(m.Direction)*m.Degree
+}
+
+var degreeWords = map[string]float64{
+	"a bit": SlightDegree, "a little": SlightDegree, "slightly": SlightDegree, "somewhat": SlightDegree,
+	"少し": SlightDegree, "すこし": SlightDegree, "やや": SlightDegree, "ちょっと": SlightDegree, "若干": SlightDegree, "わずかに": SlightDegree,
+	"much": StrongDegree, "a lot": StrongDegree, "significantly": StrongDegree, "considerably": StrongDegree, "far": StrongDegree,
+	"かなり": StrongDegree, "大幅に": StrongDegree, "ずっと": StrongDegree, "とても": StrongDegree, "非常に": StrongDegree,
+}
+
+type comparative struct {
+	direction int
+	concept   string
+}
+
+var comparativeWords = map[string]comparative{
+	"faster": {1, "速度 speed"}, "quicker": {1, "速度 speed"}, "slower": {-1, "速度 speed"},
+	"higher": {1, "高さ height"}, "lower": {-1, "高さ height"},
+	"more": {1, "量 amount"}, "less": {-1, "量 amount"}, "fewer": {-1, "量 amount"},
+	"larger": {1, "大きさ size"}, "bigger": {1, "大きさ size"}, "smaller": {-1, "大きさ size"},
+	"longer": {1, "長さ 時間 length time"}, "shorter": {-1, "長さ 時間 length time"},
+	"heavier": {1, "重さ weight"}, "lighter": {-1, "重さ weight"},
+	"hotter": {1, "温度 temperature"}, "warmer": {1, "温度 temperature"}, "cooler": {-1, "温度 temperature"}, "colder": {-1, "温度 temperature"},
+	"速く": {1, "速度 speed"}, "速い": {1, "速度 speed"}, "早く": {1, "速度 speed"}, "遅く": {-1, "速度 speed"}, "遅い": {-1, "速度 speed"},
+	"高く": {1, "高さ height"}, "高い": {1, "高さ height"}, "低く": {-1, "高さ height"}, "低い": {-1, "高さ height"},
+	"多く": {1, "量 amount"}, "多い": {1, "量 amount"}, "少なく": {-1, "量 amount"}, "少ない": {-1, "量 amount"},
+	"大きく": {1, "大きさ size"}, "大きい": {1, "大きさ size"}, "小さく": {-1, "大きさ size"}, "小さい": {-1, "大きさ size"},
+	"長く": {1, "長さ 時間 length time"}, "長い": {1, "長さ 時間 length time"}, "短く": {-1, "長さ 時間 length time"}, "短い": {-1, "長さ 時間 length time"},
+	"重く": {1, "重さ weight"}, "重い": {1, "重さ weight"}, "軽く": {-1, "重さ weight"}, "軽い": {-1, "重さ weight"},
+	"熱く": {1, "温度 temperature"}, "熱い": {1, "温度 temperature"}, "冷たく": {-1, "温度 temperature"}, "冷たい": {-1, "温度 temperature"},
+}
+
+// byLength は長い語から照合するためのキー一覧（「a little」を「less」などより先に照合する）
+func byLength[V any](words map[string]V) []string {
+	keys := make([]string, 0, len(words))
+	for k := range words {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+var (
+	degreeKeys      = byLength(degreeWords)
+	comparativeKeys = byLength(comparativeWords)
+)
+
+// cut は text から最初に現れる word を取り除く
+// 英語の語は単語の境界でのみ一致させる（「more」が「moreover」に一致しない）
+func cut(text, word string) (string, bool) {
+	ascii := word[0] < 0x80
+	for start := 0; start < len(text); {
+		i := strings.Index(text[start:], word)
+		if i < 0 {
+			return text, false
+		}
+		i += start
+		j := i + len(word)
+		if !ascii || (boundary(text, i-1) && boundary(text, j)) {
+			return strings.Join(strings.Fields(text[:i]+" "+text[j:]), " "), true
+		}
+		start = j
+	}
+	return text, false
+}
+
+func boundary(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return true
+	}
+	r := rune(text[i])
+	return r >= 0x80 || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// ParseModifier は語句から比較表現を取り出す
+// 比較の語（「速く」「faster」）が無い場合、程度の語があっても Direction は 0 になる
+func ParseModifier(text string) Modifier {
+	rest := Normalize(text)
+	var m Modifier
+	for _, w := range comparativeKeys {
+		if r, ok := cut(rest, w); ok {
+			rest = r
+			m.Direction = comparativeWords[w].direction
+			m.Concept = comparativeWords[w].concept
+			break
+		}
+	}
+	if m.Direction == 0 {
+		m.Rest = rest
+		return m
+	}
+	m.Degree = DefaultDegree
+	for _, w := range degreeKeys {
+		if r, ok := cut(rest, w); ok {
+			rest = r
+			m.Degree = degreeWords[w]
+			break
+		}
+	}
+	m.Rest = rest
+	return m
+}
@@ -0,0 +1,265 @@
+package labeling
+
+import (
+	"errors"
+	"sort"
+)
+
+// ラベル間の関係の種類
+const (
+	RelationSynonym  = "synonym"  // 同義語（対称）
+	RelationHypernym = "hypernym" // Target が Source の上位概念
+	RelationHyponym  = "hyponym"  // Target が Source の下位概念
+)
+
+var (
+	ErrUnknownRelation   = errors.New("relation type must be synonym, hypernym or hyponym")
+	ErrSelfRelation      = errors.New("a label cannot be related to itself")
+	ErrDuplicateRelation = errors.New("the relation already exists")
+	ErrHypernymCycle     = errors.New("the relation would make a hypernym cycle")
+	ErrSynonymConflict   = errors.New("synonyms cannot be hypernyms or hyponyms of each other")
+	ErrAsymmetricSynonym = errors.New("synonym relations must be bidirectional")
+	ErrSymmetricHypernym = errors.New("hypernym and hyponym relations cannot be bidirectional")
+)
+
+// Relation はラベル間の関係
+type Relation struct {
+	Source        string
+	Target        string
+	Type          string
+	Strength      float64
+	Bidirectional bool
+}
+
+// edge は関係の向きを揃えたもの
+// 上位・下位の関係は（下位, 上位）、同義語は ID の小さい順
+func (r Relation) edge() (kind, a, b string) {
+	switch r.Type {
+	case RelationHypernym:
+		return RelationHypernym, r.Source, r.Target
+	case RelationHyponym:
+		return RelationHypernym, r.Target, r.Source
+	}
+	if r.Source > r.Target {
+		return RelationSynonym, r.Target, r.Source
+	}
+	return RelationSynonym, r.Source, r.Target
+}
+
+// Node は関係をたどって見つかったラベル
+type Node struct {
+	ID string
+	// Depth は起点からの段数（同義語は同じ段）
+	Depth int
+	// Strength は起点からの同義語の関係の強さの積（上位・下位の関係では 1）
+	Strength float64
+}
+
+// Ontology はラベルの同義語・上位下位の関係のグラフ
+// 同義語は同値類としてまとめ、上位下位の関係は同値類の間の有向非巡回グラフとして扱う
+type Ontology struct {
+	parent   map[string]string
+	broader  map[string][]string // 下位 → 上位（ラベル単位）
+	narrower map[string][]string // 上位 → 下位（ラベル単位）
+	synonyms map[string]map[string]float64
+	edges    map[[3]string]bool
+}
+
+// NewOntology は既存の関係からグラフを作る。既存の関係の整合性は確認しない
+func NewOntology(relations []Relation) *Ontology {
+	o := &Ontology{
+		parent:   map[string]string{},
+		broader:  map[string][]string{},
+		narrower: map[string][]string{},
+		synonyms: map[string]map[string]float64{},
+		edges:    map[[3]string]bool{},
+	}
+	for _, r := range relations {
+		o.add(r)
+	}
+	return o
+}
+
+func (o *Ontology) find(id string) string {
+	for {
+		p, ok := o.parent[id]
+		if !ok || p == id {
+			return id
+		}
+		id = p
+	}
+}
+
+func (o *Ontology) add(r Relation) {
+	kind, a, b := r.edge()
+	if kind == "" || a == b {
+		return
+	}
+	o.edges[[3]string{kind, a, b}] = true
+	if kind == RelationHypernym {
+		o.broader[a] = append(o.broader[a], b)
+		o.narrower[b] = append(o.narrower[b], a)
+		return
+	}
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		if o.synonyms[pair[0]] == nil {
+			o.synonyms[pair[0]] = map[string]float64{}
+		}
+		o.synonyms[pair[0]][pair[1]] = max(o.synonyms[pair[0]][pair[1]], r.Strength)
+	}
+	if ra, rb := o.find(a), o.find(b); ra != rb {
+		o.parent[ra] = rb
+	}
+}
+
+// Check は関係 r を追加しても整合性が保たれるかを確認する
+// 同義語は双方向、上位・下位の関係は単方向であること、同じ関係が無いこと、
+// 上位下位の関係が循環せず、同義語の間に上位下位の関係が無いことを確認する
+func (o *Ontology) Check(r Relation) error {
+	kind, a, b := r.edge()
+	switch {
+	case r.Type != RelationSynonym && r.Type != RelationHypernym && r.Type != RelationHyponym:
+		return ErrUnknownRelation
+	case a == b:
+		return ErrSelfRelation
+	case o.edges[[3]string{kind, a, b}]:
+		return ErrDuplicateRelation
+	case kind == RelationSynonym && !r.Bidirectional:
+		return ErrAsymmetricSynonym
+	case kind == RelationHypernym && r.Bidirectional:
+		return ErrSymmetricHypernym
+	}
+	ca, cb := o.find(a), o.find(b)
+	if kind == RelationSynonym {
+		if ca != cb && (o.reaches(ca, cb, o.broader) || o.reaches(cb, ca, o.broader)) {
+			return ErrSynonymConflict
+		}
+		return nil
+	}
+	if ca == cb {
+		return ErrSynonymConflict
+	}
+	// 上位 b から上位をたどって下位 a に届くなら循環する
+	if o.reaches(cb, ca, o.broader) {
+		return ErrHypernymCycle
+	}
+	return nil
+}
+
+// members は同値類ごとのラベルの一覧
+func (o *Ontology) members() map[string][]string {
+	m := map[string][]string{}
+	seen := map[string]bool{}
+	for _, ids := range []map[string][]string{o.broader, o.narrower} {
+		for id, targets := range ids {
+			for _, x := range append([]string{id}, targets...) {
+				if !seen[x] {
+					seen[x] = true
+					m[o.find(x)] = append(m[o.find(x)], x)
+				}
+			}
+		}
+	}
+	for id := range o.synonyms {
+		if !seen[id] {
+			seen[id] = true
+			m[o.find(id)] = append(m[o.find(id)], id)
+		}
+	}
+	return m
+}
+
+// walk は同値類 from から next の向きに関係をたどり、同値類ごとの段数を返す（maxDepth が 0 以下なら無制限）
+func (o *Ontology) walk(from string, next map[string][]string, maxDepth int) map[string]int {
+	members := o.members()
+	depth := map[string]int{from: 0}
+	queue := []string{from}
+	for len(queue) > 0 {
+		class := queue[0]
+		queue = queue[1:]
+		if maxDepth > 0 && depth[class] >= maxDepth {
+			continue
+		}
+		for _, id := range members[class] {
+			for _, t := range next[id] {
+				c := o.find(t)
+				if _, ok := depth[c]; !ok {
+					depth[c] = depth[class] + 1
+					queue = append(queue, c)
+				}
+			}
+		}
+	}
+	return depth
+}
+
+func (o *Ontology) reaches(from, to string, next map[string][]string) bool {
+	_, ok := o.walk(from, next, 0)[to]
+	return ok
+}
+
+func (o *Ontology) nodes(id string, next map[string][]string, maxDepth int) []Node {
+	members := o.members()
+	start := o.find(id)
+	var out []Node
+	for class, d := range o.walk(start, next, maxDepth) {
+		if class == start {
+			continue
+		}
+		for _, m := range members[class] {
+			out = append(out, Node{ID: m, Depth: d, Strength: 1})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Depth != out[j].Depth {
+			return out[i].Depth < out[j].Depth
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Ancestors は id の上位概念（同義語の上位概念を含む）を近い順に返す
+func (o *Ontology) Ancestors(id string, maxDepth int) []Node {
+	return o.nodes(id, o.broader, maxDepth)
+}
+
+// Descendants は id の下位概念（同義語の下位概念を含む）を近い順に返す
+func (o *Ontology) Descendants(id string, maxDepth int) []Node {
+	return o.nodes(id, o.narrower, maxDepth)
+}
+
+// Synonyms は id と同義のラベルを返す
+// 同義語の関係を推移的にたどり、Strength は経路上の関係の強さの積の最大値、Depth は最短の段数
+func (o *Ontology) Synonyms(id string) []Node {
+	best := map[string]Node{id: {ID: id, Strength: 1}}
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := best[queue[0]]
+		queue = queue[1:]
+		for next, strength := range o.synonyms[cur.ID] {
+			s := cur.Strength * min(max(strength, 0), 1)
+			if b, ok := best[next]; ok && b.Strength >= s {
+				continue
+			}
+			depth := cur.Depth + 1
+			if b, ok := best[next]; ok {
+				depth = min(depth, b.Depth)
+			}
+			best[next] = Node{ID: next, Depth: depth, Strength: s}
+			queue = append(queue, next)
+		}
+	}
+	delete(best, id)
+	out := make([]Node, 0, len(best))
+	for _, n := range best {
+		out = append(out, n)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Strength != out[j].Strength {
+			return out[i].Strength > out[j].Strength
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
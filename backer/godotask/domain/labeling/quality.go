@@ -0,0 +1,75 @@
+package labeling
+
+import "math"
+
+// LabelQuality はデータセットの品質の算出に使うラベルごとの値
+type LabelQuality struct {
+	Category  string
+	Validated bool
+	// Accuracy は検証者が判定項目を正しいとした割合（未検証なら nil）
+	Accuracy *float64
+	// Completeness は入力された項目の割合（0〜1）
+	Completeness float64
+	// Decisions は現在の検証の採否ごとの検証者数
+	Decisions map[string]int
+}
+
+// DatasetQuality はデータセットの品質メトリクス
+type DatasetQuality struct {
+	TotalLabels     int
+	VerifiedLabels  int
+	AverageAccuracy float64
+	// Completeness はラベルの入力率の平均
+	Completeness float64
+	// Consistency はラベルを項目、採否を分類とした検証者間の一致度（Fleiss' kappa、-1〜1）
+	// 2 人以上が検証したラベルが無い場合は 0
+	Consistency float64
+	// Diversity はカテゴリの Gini-Simpson 指数（無作為に選んだ 2 つのラベルのカテゴリが異なる確率）
+	Diversity float64
+	// Balance はカテゴリの均等度（Pielou の J、正規化した Shannon エントロピー）。カテゴリが 1 つなら 1
+	Balance float64
+}
+
+// Quality はラベルの一覧からデータセットの品質メトリクスを求める
+func Quality(labels []LabelQuality) DatasetQuality {
+	q := DatasetQuality{TotalLabels: len(labels)}
+	if len(labels) == 0 {
+		return q
+	}
+	categories := map[string]int{}
+	var decisions []map[string]int
+	accuracySum, accuracyCount := 0.0, 0
+	for _, l := range labels {
+		if l.Validated {
+			q.VerifiedLabels++
+		}
+		if l.Accuracy != nil {
+			accuracySum += *l.Accuracy
+			accuracyCount++
+		}
+		q.Completeness += l.Completeness
+		categories[l.Category]++
+		decisions = append(decisions, l.Decisions)
+	}
+	n := float64(len(labels))
+	q.Completeness /= n
+	if accuracyCount > 0 {
+		q.AverageAccuracy = accuracySum / float64(accuracyCount)
+	}
+	if kappa, ok := FleissKappa(decisions); ok {
+		q.Consistency = kappa
+	}
+
+	simpson, entropy := 0.0, 0.0
+	for _, c := range categories {
+		p := float64(c) / n
+		simpson += p * p
+		entropy -= p * math.Log(p)
+	}
+	q.Diversity = 1 - simpson
+	q.Balance = 1
+	if len(categories) > 1 {
+		q.Balance = entropy / math.Log(float64(len(categories)))
+	}
+	return q
+}
@@ -0,0 +1,143 @@
+package labeling
+
+import (
+	"sort"
+)
+
+const (
+	// MinSimilarity はラベルを提案に使う語句の類似度の下限
+	MinSimilarity = 0.35
+	// DefaultConfidence は信頼度が未設定のラベルの信頼度
+	DefaultConfidence = 0.5
+	// DefaultSuggestionLimit は提案数の既定値
+	DefaultSuggestionLimit = 5
+	// conceptWeight は比較の語が表す量（「速く」→「速度」）での一致の重み
+	conceptWeight = 0.8
+	// modifierPenalty は比較表現で基準値を増減した提案の信頼度に掛ける係数
+	modifierPenalty = 0.8
+)
+
+// 提案の根拠
+const (
+	SourceLabel   = "label"   // 語句が一致したラベル
+	SourceSynonym = "synonym" // 語句が一致したラベルの同義語のラベル
+)
+
+// Candidate は提案の候補となる検証済みのラベル
+type Candidate struct {
+	ID string
+	// Texts はラベルの語句（原文・正規化した語句・カテゴリなど）
+	Texts      []string
+	Value      float64
+	MinRange   *float64
+	MaxRange   *float64
+	Unit       string
+	Confidence float64
+}
+
+// Synonym は From のラベルから To のラベルへの同義語の関係（Strength は 0〜1）
+type Synonym struct {
+	From     string
+	To       string
+	Strength float64
+}
+
+// Suggestion は語句に対する値の提案
+type Suggestion struct {
+	LabelID    string
+	Value      float64
+	MinRange   *float64
+	MaxRange   *float64
+	Unit       string
+	Confidence float64
+	// Similarity は語句とラベルの類似度（同義語の場合は関係の強さを掛けたもの）
+	Similarity float64
+	Source     string
+	// Via は同義語で提案した場合に語句が一致したラベル
+	Via string
+}
+
+// match は語句とラベルの類似度を返す
+func match(m Modifier, c Candidate) float64 {
+	best := 0.0
+	for _, t := range c.Texts {
+		if m.Rest != "" {
+			best = max(best, Similarity(m.Rest, t))
+		}
+		if m.Concept != "" {
+			best = max(best, conceptWeight*Similarity(m.Concept, t))
+		}
+	}
+	return best
+}
+
+// Suggest は語句 text に近い候補のラベルと、その同義語のラベルから値を提案する
+// 比較表現（「少し速く」）がある場合は基準値と範囲を増減する。信頼度の高い順に最大 limit 件を返す
+func Suggest(text string, candidates []Candidate, synonyms []Synonym, limit int) []Suggestion {
+	if limit <= 0 {
+		limit = DefaultSuggestionLimit
+	}
+	m := ParseModifier(text)
+	byID := map[string]Candidate{}
+	best := map[string]Suggestion{}
+	consider := func(c Candidate, similarity float64, source, via string) {
+		if s, ok := best[c.ID]; ok && s.Similarity >= similarity {
+			return
+		}
+		best[c.ID] = Suggestion{LabelID: c.ID, Similarity: similarity, Source: source, Via: via}
+	}
+	for _, c := range candidates {
+		byID[c.ID] = c
+		if sim := match(m, c); sim >= MinSimilarity {
+			consider(c, sim, SourceLabel, "")
+		}
+	}
+	direct := make(map[string]float64, len(best))
+	for id, s := range best {
+		direct[id] = s.Similarity
+	}
+	for _, syn := range synonyms {
+		sim, ok := direct[syn.From]
+		target, exists := byID[syn.To]
+		if !ok || !exists || syn.Strength <= 0 {
+			continue
+		}
+		consider(target, sim*min(syn.Strength, 1), SourceSynonym, syn.From)
+	}
+
+	factor := m.Factor()
+	scale := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		scaled := *v * factor
+		return &scaled
+	}
+	out := make([]Suggestion, 0, len(best))
+	for id, s := range best {
+		c := byID[id]
+		confidence := c.Confidence
+		if confidence <= 0 {
+			confidence = DefaultConfidence
+		}
+		s.Confidence = s.Similarity * min(confidence, 1)
+		if m.Direction != 0 {
+			s.Confidence *= modifierPenalty
+		}
+		s.Value = c.Value * factor
+		s.MinRange = scale(c.MinRange)
+		s.MaxRange = scale(c.MaxRange)
+		s.Unit = c.Unit
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Confidence != out[j].Confidence {
+			return out[i].Confidence > out[j].Confidence
+		}
+		return out[i].LabelID < out[j].LabelID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package labeling
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize は表記ゆれを吸収した比較用の文字列を返す。ラベルの正規化した語句（NormalizedText）にも使う
+// Unicode 正規化（NFKC）で全角英数記号を半角に、半角カナを全角に（「㎜」は「mm」に）揃え、
+// 英字を小文字にし、句読点・記号を空白として連続する空白を 1 つにまとめる
+// 数字に挟まれた小数点（3.2）は残す
+func Normalize(text string) string {
+	runes := []rune(norm.NFKC.String(text))
+	var b strings.Builder
+	space := false
+	for i, r := range runes {
+		r = unicode.ToLower(r)
+		decimal := r == '.' && i > 0 && i+1 < len(runes) && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1])
+		if !decimal && (unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)) {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteRune(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// bigrams は空白で区切った語ごとの文字 bigram の集合を返す
+// 1 文字の語はその文字を要素とする
+func bigrams(text string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, word := range strings.Fields(Normalize(text)) {
+		runes := []rune(word)
+		if len(runes) == 1 {
+			set[word] = struct{}{}
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			set[string(runes[i:i+2])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Similarity は 2 つの語句の文字 bigram による類似度（0〜1）を返す
+// Dice 係数と重なり係数（短い方が長い方にどれだけ含まれるか）の平均で、
+// 「バリ」と「バリの発生程度」のように一方が他方を含む場合も高くなる
+func Similarity(a, b string) float64 {
+	x, y := bigrams(a), bigrams(b)
+	if len(x) == 0 || len(y) == 0 {
+		return 0
+	}
+	common := 0
+	for g := range x {
+		if _, ok := y[g]; ok {
+			common++
+		}
+	}
+	dice := 2 * float64(common) / float64(len(x)+len(y))
+	overlap := float64(common) / float64(min(len(x), len(y)))
+	return (dice + overlap) / 2
+}
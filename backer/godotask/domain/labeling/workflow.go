@@ -0,0 +1,107 @@
+package labeling
+
+// ラベルの検証の状態
+// 作成・差し戻し後のラベルを提出すると検証待ちになり、必要な人数の検証者の多数決で採否が決まる
+const (
+	StatusDraft     = "draft"
+	StatusSubmitted = "submitted"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected"
+)
+
+// 検証者の採否
+const (
+	DecisionAccept = "accept"
+	DecisionReject = "reject"
+)
+
+// 判定項目の分類
+const (
+	JudgementCorrect   = "correct"
+	JudgementIncorrect = "incorrect"
+)
+
+// DecisionItem は Judgement の採否の判定項目
+const DecisionItem = "decision"
+
+// VerificationCriteria は検証者が正誤を判定する項目
+var VerificationCriteria = []string{"value", "unit", "range", "category"}
+
+// DefaultRequiredVerifications は採否を決めるのに必要な検証者数の既定値
+const DefaultRequiredVerifications = 2
+
+// CanSubmit は status のラベルを検証に提出できるかを返す（空の状態は下書き）
+func CanSubmit(status string) bool {
+	return status == "" || status == StatusDraft || status == StatusRejected
+}
+
+// IsDecision は d が採否の値かを返す
+func IsDecision(d string) bool {
+	return d == DecisionAccept || d == DecisionReject
+}
+
+// NewJudgement は採否と判定項目の正誤から Judgement を作る
+// criteria に無い判定項目は採否に従う（採用なら正しい、却下なら誤り）
+func NewJudgement(decision string, criteria map[string]bool) Judgement {
+	j := Judgement{DecisionItem: decision}
+	for _, c := range VerificationCriteria {
+		correct, ok := criteria[c]
+		if !ok {
+			correct = decision == DecisionAccept
+		}
+		if correct {
+			j[c] = JudgementCorrect
+		} else {
+			j[c] = JudgementIncorrect
+		}
+	}
+	return j
+}
+
+// Resolve は検証者の採否 decisions から検証の結果の状態を返す
+// required 人に満たない場合と、採用と却下が同数の場合は検証待ち（StatusSubmitted）のまま
+func Resolve(decisions []string, required int) string {
+	if required <= 0 {
+		required = DefaultRequiredVerifications
+	}
+	if len(decisions) < required {
+		return StatusSubmitted
+	}
+	accept, reject := 0, 0
+	for _, d := range decisions {
+		switch d {
+		case DecisionAccept:
+			accept++
+		case DecisionReject:
+			reject++
+		}
+	}
+	switch {
+	case accept > reject:
+		return StatusAccepted
+	case reject > accept:
+		return StatusRejected
+	}
+	return StatusSubmitted
+}
+
+// Accuracy は Judgement のうち判定項目（採否を除く）が正しいとされた割合を返す
+func Accuracy(judgements []Judgement) (float64, bool) {
+	correct, total := 0, 0
+	for _, j := range judgements {
+		for _, c := range VerificationCriteria {
+			v, ok := j[c]
+			if !ok {
+				continue
+			}
+			total++
+			if v == JudgementCorrect {
+				correct++
+			}
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(correct) / float64(total), true
+}
@@ -0,0 +1,67 @@
+package learning
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultWindow は学習曲線の1点にまとめる試行数の既定値
+const DefaultWindow = 10
+
+// CurvePoint は学習曲線の1点（連続する Window 回の試行）
+type CurvePoint struct {
+	// From / To はこの点に含まれる試行の通し番号（1 始まり）
+	From        int       `json:"from"`
+	To          int       `json:"to"`
+	Attempts    int       `json:"attempts"`
+	SuccessRate Interval  `json:"success_rate"`
+	MeanTime    *Interval `json:"mean_time,omitempty"`
+	// CumulativeSuccessRate は最初の試行からこの点までの成功率
+	CumulativeSuccessRate float64   `json:"cumulative_success_rate"`
+	StartedAt             time.Time `json:"started_at"`
+	EndedAt               time.Time `json:"ended_at"`
+}
+
+// Curve は試行を時刻順に並べ、window 回ごとの成功率と成功時の所要時間を返す
+// 最後の点は window に満たない場合もそのまま含める
+func Curve(attempts []Attempt, window int) []CurvePoint {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	sorted := append([]Attempt(nil), attempts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].AttemptedAt.Before(sorted[j].AttemptedAt) })
+
+	var points []CurvePoint
+	cumulative := 0
+	for start := 0; start < len(sorted); start += window {
+		end := start + window
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		block := sorted[start:end]
+		successes := 0
+		var durations []float64
+		for _, a := range block {
+			if a.Success {
+				successes++
+				durations = append(durations, a.Duration)
+			}
+		}
+		cumulative += successes
+		p := CurvePoint{
+			From:                  start + 1,
+			To:                    end,
+			Attempts:              len(block),
+			SuccessRate:           Wilson(successes, len(block)),
+			CumulativeSuccessRate: round(float64(cumulative) / float64(end)),
+			StartedAt:             block[0].AttemptedAt,
+			EndedAt:               block[len(block)-1].AttemptedAt,
+		}
+		if len(durations) > 0 {
+			interval := MeanInterval(durations)
+			p.MeanTime = &interval
+		}
+		points = append(points, p)
+	}
+	return points
+}
@@ -0,0 +1,105 @@
+package learning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWilson(t *testing.T) {
+	i := Wilson(8, 10)
+	assert.Equal(t, 0.8, i.Value)
+	assert.InDelta(t, 0.4902, i.Lower, 1e-4)
+	assert.InDelta(t, 0.9433, i.Upper, 1e-4)
+
+	// 全成功でも上限は 1 を超えず、下限は 1 未満になる
+	all := Wilson(5, 5)
+	assert.Equal(t, 1.0, all.Value)
+	assert.Equal(t, 1.0, all.Upper)
+	assert.Less(t, all.Lower, 1.0)
+
+	none := Wilson(0, 0)
+	assert.Equal(t, Interval{Value: 0, Lower: 0, Upper: 1}, none)
+}
+
+func TestMeanInterval(t *testing.T) {
+	i := MeanInterval([]float64{1, 2, 3})
+	assert.Equal(t, 2.0, i.Value)
+	assert.InDelta(t, -0.4843, i.Lower, 1e-4)
+	assert.InDelta(t, 4.4843, i.Upper, 1e-4)
+
+	single := MeanInterval([]float64{4})
+	assert.Equal(t, Interval{Value: 4, Lower: 4, Upper: 4}, single)
+
+	// 自由度が大きい場合は正規近似
+	values := make([]float64, 100)
+	for k := range values {
+		values[k] = float64(k % 2)
+	}
+	large := MeanInterval(values)
+	assert.InDelta(t, 0.5, large.Value, 1e-9)
+	assert.InDelta(t, 0.5-1.959964*0.502519/10, large.Lower, 1e-4)
+}
+
+func TestSummarize(t *testing.T) {
+	attempts := []Attempt{
+		{Success: true, Duration: 2},
+		{Success: true, Duration: 4},
+		{Success: false, ErrorType: "grasp_slip", RecoveryAction: "regrasp", Recovered: true},
+		{Success: false, ErrorType: "grasp_slip", RecoveryAction: "regrasp"},
+		{Success: false, ErrorType: "collision", RecoveryAction: "retract", Recovered: true},
+		{Success: false},
+	}
+	s := Summarize(attempts)
+	assert.Equal(t, 6, s.Attempts)
+	assert.Equal(t, 2, s.Successes)
+	assert.InDelta(t, 0.333333, s.SuccessRate.Value, 1e-6)
+	assert.Equal(t, 3.0, s.AdaptationTime.Value)
+	assert.Equal(t, 0.5, *s.RecoveryRate)
+	assert.Equal(t, Confidence, s.Confidence)
+
+	assert.Len(t, s.Errors, 3)
+	assert.Equal(t, ErrorStat{ErrorType: "grasp_slip", Count: 2, Recovered: 1, RecoveryRate: 0.5}, s.Errors[0])
+	assert.Equal(t, "collision", s.Errors[1].ErrorType)
+	assert.Equal(t, "unknown", s.Errors[2].ErrorType)
+
+	empty := Summarize(nil)
+	assert.Nil(t, empty.AdaptationTime)
+	assert.Nil(t, empty.RecoveryRate)
+	assert.Equal(t, 0, empty.Attempts)
+}
+
+func TestCurve(t *testing.T) {
+	base := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	var attempts []Attempt
+	// 後半ほど成功する（逆順に渡しても時刻順に並べ替える）
+	for k := 24; k >= 0; k-- {
+		attempts = append(attempts, Attempt{
+			Success:     k >= 5 && k%5 != 0 || k >= 20,
+			Duration:    float64(30 - k),
+			AttemptedAt: base.Add(time.Duration(k) * time.Minute),
+		})
+	}
+	points := Curve(attempts, 10)
+	assert.Len(t, points, 3)
+
+	assert.Equal(t, 1, points[0].From)
+	assert.Equal(t, 10, points[0].To)
+	assert.Equal(t, 0.4, points[0].SuccessRate.Value)
+	assert.Equal(t, base, points[0].StartedAt)
+
+	assert.Equal(t, 0.8, points[1].SuccessRate.Value)
+	assert.InDelta(t, 0.6, points[1].CumulativeSuccessRate, 1e-9)
+
+	// 端数の点
+	assert.Equal(t, 21, points[2].From)
+	assert.Equal(t, 25, points[2].To)
+	assert.Equal(t, 5, points[2].Attempts)
+	assert.Equal(t, 1.0, points[2].SuccessRate.Value)
+	assert.Equal(t, 8.0, points[2].MeanTime.Value)
+	assert.Equal(t, base.Add(24*time.Minute), points[2].EndedAt)
+
+	assert.Len(t, Curve(attempts, 0), 3)
+	assert.Empty(t, Curve(nil, 5))
+}
@@ -0,0 +1,166 @@
+package learning
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Confidence は信頼区間の信頼水準（95%）
+const Confidence = 0.95
+
+// z95 は標準正規分布の 97.5% 点
+const z95 = 1.959964
+
+// tTable は自由度 1..30 の t 分布の 97.5% 点。30 を超える自由度は正規分布で近似する
+var tTable = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// Attempt は1回の実行試行
+type Attempt struct {
+	Success bool
+	// Duration は試行にかかった時間（秒）
+	Duration       float64
+	ErrorType      string
+	RecoveryAction string
+	// Recovered は失敗後の復帰動作が成功したかどうか
+	Recovered   bool
+	AttemptedAt time.Time
+}
+
+// Interval は推定値と信頼区間
+type Interval struct {
+	Value float64 `json:"value"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// ErrorStat はエラー種別ごとの件数と復帰率
+type ErrorStat struct {
+	ErrorType    string  `json:"error_type"`
+	Count        int     `json:"count"`
+	Recovered    int     `json:"recovered"`
+	RecoveryRate float64 `json:"recovery_rate"`
+}
+
+// Stats は試行の集計結果
+//
+//   - SuccessRate: 成功率と Wilson スコア区間
+//   - AdaptationTime: 成功した試行の平均所要時間（秒）と t 分布による区間。成功が無い場合は nil
+type Stats struct {
+	Attempts       int         `json:"attempts"`
+	Successes      int         `json:"successes"`
+	SuccessRate    Interval    `json:"success_rate"`
+	AdaptationTime *Interval   `json:"adaptation_time,omitempty"`
+	RecoveryRate   *float64    `json:"recovery_rate,omitempty"`
+	Errors         []ErrorStat `json:"errors,omitempty"`
+	Confidence     float64     `json:"confidence"`
+}
+
+// Summarize は試行から成功率・適応時間・エラー種別ごとの復帰率を計算する
+func Summarize(attempts []Attempt) Stats {
+	s := Stats{Attempts: len(attempts), Confidence: Confidence}
+	var durations []float64
+	errs := map[string]*ErrorStat{}
+	failures, recovered := 0, 0
+	for _, a := range attempts {
+		if a.Success {
+			s.Successes++
+			durations = append(durations, a.Duration)
+			continue
+		}
+		failures++
+		if a.Recovered {
+			recovered++
+		}
+		key := a.ErrorType
+		if key == "" {
+			key = "unknown"
+		}
+		e, ok := errs[key]
+		if !ok {
+			e = &ErrorStat{ErrorType: key}
+			errs[key] = e
+		}
+		e.Count++
+		if a.Recovered {
+			e.Recovered++
+		}
+	}
+	s.SuccessRate = Wilson(s.Successes, s.Attempts)
+	if len(durations) > 0 {
+		interval := MeanInterval(durations)
+		s.AdaptationTime = &interval
+	}
+	if failures > 0 {
+		rate := round(float64(recovered) / float64(failures))
+		s.RecoveryRate = &rate
+	}
+	for _, e := range errs {
+		e.RecoveryRate = round(float64(e.Recovered) / float64(e.Count))
+		s.Errors = append(s.Errors, *e)
+	}
+	sort.Slice(s.Errors, func(i, j int) bool {
+		if s.Errors[i].Count != s.Errors[j].Count {
+			return s.Errors[i].Count > s.Errors[j].Count
+		}
+		return s.Errors[i].ErrorType < s.Errors[j].ErrorType
+	})
+	return s
+}
+
+// Wilson は n 回中 k 回成功したときの成功率と 95% Wilson スコア区間
+// 正規近似と異なり、成功率が 0 や 1 に近い場合や試行数が少ない場合も区間が [0, 1] に収まる
+func Wilson(k, n int) Interval {
+	if n == 0 {
+		return Interval{Value: 0, Lower: 0, Upper: 1}
+	}
+	p := float64(k) / float64(n)
+	nf := float64(n)
+	z2 := z95 * z95
+	denom := 1 + z2/nf
+	center := (p + z2/(2*nf)) / denom
+	half := z95 * math.Sqrt(p*(1-p)/nf+z2/(4*nf*nf)) / denom
+	return Interval{
+		Value: round(p),
+		Lower: round(math.Max(0, center-half)),
+		Upper: round(math.Min(1, center+half)),
+	}
+}
+
+// MeanInterval は平均と t 分布による 95% 信頼区間。値が1つの場合は区間の幅を 0 とする
+func MeanInterval(values []float64) Interval {
+	n := len(values)
+	if n == 0 {
+		return Interval{}
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(n)
+	if n == 1 {
+		return Interval{Value: round(mean), Lower: round(mean), Upper: round(mean)}
+	}
+	ss := 0.0
+	for _, v := range values {
+		ss += (v - mean) * (v - mean)
+	}
+	sd := math.Sqrt(ss / float64(n-1))
+	half := tCritical(n-1) * sd / math.Sqrt(float64(n))
+	return Interval{Value: round(mean), Lower: round(mean - half), Upper: round(mean + half)}
+}
+
+func tCritical(df int) float64 {
+	if df <= len(tTable) {
+		return tTable[df-1]
+	}
+	return z95
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
@@ -0,0 +1,161 @@
+package matching
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 数値で評価する仕様項目
+const (
+	CriterionDOF            = "dof"
+	CriterionReach          = "reach_mm"
+	CriterionPayload        = "payload_kg"
+	CriterionRepeatAccuracy = "repeat_accuracy_mm"
+	CriterionMaxSpeed       = "max_speed_mm_s"
+	// CriterionFeatures は教示方式・ビジョン・力覚センサ・AI 機能・安全機能などの機能名の一覧
+	CriterionFeatures = "features"
+)
+
+// lowerIsBetter は値が小さいほど良い仕様項目（要求値は上限として扱う）
+var lowerIsBetter = map[string]bool{
+	CriterionRepeatAccuracy: true,
+}
+
+var numericCriteria = []string{CriterionDOF, CriterionReach, CriterionPayload, CriterionRepeatAccuracy, CriterionMaxSpeed}
+
+// Criteria はロボットに対する要求・制約
+// Numeric は項目ごとの下限（repeat_accuracy_mm のみ上限）、Features は必要な機能名
+type Criteria struct {
+	Numeric  map[string]float64
+	Features []string
+}
+
+// Empty は要求が1つも無いかを返す
+func (c Criteria) Empty() bool {
+	return len(c.Numeric) == 0 && len(c.Features) == 0
+}
+
+// Merge は override の項目で c を上書きした Criteria を返す
+func (c Criteria) Merge(override Criteria) Criteria {
+	merged := Criteria{Numeric: map[string]float64{}, Features: c.Features}
+	for k, v := range c.Numeric {
+		merged.Numeric[k] = v
+	}
+	for k, v := range override.Numeric {
+		merged.Numeric[k] = v
+	}
+	if len(override.Features) > 0 {
+		merged.Features = override.Features
+	}
+	return merged
+}
+
+// ParseCriteria はリクエストの requirements / constraints を Criteria に変換する
+// 未知の項目や数値でない値はエラーにする
+func ParseCriteria(m map[string]interface{}) (Criteria, error) {
+	c := Criteria{Numeric: map[string]float64{}}
+	for key, v := range m {
+		if key == CriterionFeatures {
+			features, err := toStrings(v)
+			if err != nil {
+				return c, fmt.Errorf("%s: %w", key, err)
+			}
+			c.Features = features
+			continue
+		}
+		if !isNumericCriterion(key) {
+			return c, fmt.Errorf("unknown criterion %q", key)
+		}
+		f, ok := toFloat(v)
+		if !ok {
+			return c, fmt.Errorf("%s must be a number", key)
+		}
+		c.Numeric[key] = f
+	}
+	return c, nil
+}
+
+// ParseWeights は preferences を項目ごとの重みに変換する。重みは 0 以上でなければならない
+func ParseWeights(m map[string]interface{}) (map[string]float64, error) {
+	weights := make(map[string]float64, len(m))
+	for key, v := range m {
+		if key != CriterionFeatures && !isNumericCriterion(key) {
+			return nil, fmt.Errorf("unknown criterion %q", key)
+		}
+		f, ok := toFloat(v)
+		if !ok || f < 0 {
+			return nil, fmt.Errorf("weight of %s must be a non-negative number", key)
+		}
+		weights[key] = f
+	}
+	return weights, nil
+}
+
+func isNumericCriterion(key string) bool {
+	for _, c := range numericCriteria {
+		if c == key {
+			return true
+		}
+	}
+	return false
+}
+
+func toStrings(v interface{}) ([]string, error) {
+	var out []string
+	switch s := v.(type) {
+	case []string:
+		out = s
+	case []interface{}:
+		for _, item := range s {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be a list of strings")
+			}
+			out = append(out, str)
+		}
+	case string:
+		out = strings.Split(s, "|")
+	default:
+		return nil, fmt.Errorf("must be a list of strings")
+	}
+	return NormalizeFeatures(out), nil
+}
+
+// NormalizeFeatures は機能名を小文字化し、空・"none"・重複を除いて整列する
+func NormalizeFeatures(features []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, f := range features {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || f == "none" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		out = append(out, f)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,287 @@
+package matching
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// DefaultSpeedRatio は要求速度が無い場合に推奨する最高速度に対する作業速度の割合
+	DefaultSpeedRatio = 0.6
+	// DefaultPayloadRatio は要求可搬重量が無い場合に推奨する定格可搬重量に対する上限の割合
+	DefaultPayloadRatio = 0.8
+)
+
+// Robot はマッチング対象のロボット仕様
+type Robot struct {
+	ID               string
+	ModelName        string
+	DOF              int
+	ReachMm          float64
+	PayloadKg        float64
+	RepeatAccuracyMm float64
+	MaxSpeedMmS      float64
+	TeachingMethod   string
+	ControlType      string
+	// Features は教示方式・制御方式・ビジョン・力覚センサ・AI 機能・安全機能などの機能名
+	Features []string
+}
+
+// Request はマッチングの入力
+// Requirements は満たすほど高く評価される要求、Constraints は満たさないロボットを除外する制約、
+// Weights は項目ごとの重み（要求に無い項目は候補内での相対評価になる）
+type Request struct {
+	Requirements Criteria
+	Constraints  Criteria
+	Weights      map[string]float64
+}
+
+// Match はマッチング結果の1件
+type Match struct {
+	Robot               Robot                  `json:"-"`
+	Rank                int                    `json:"rank"`
+	Score               float64                `json:"score"`
+	CriterionScores     map[string]float64     `json:"criterion_scores"`
+	Unmet               []string               `json:"unmet_requirements"`
+	Parameters          map[string]interface{} `json:"parameters"`
+	ExpectedPerformance map[string]interface{} `json:"expected_performance"`
+}
+
+// Exclusion は制約を満たさず除外されたロボットと理由
+type Exclusion struct {
+	RobotID string   `json:"robot_id"`
+	Reasons []string `json:"reasons"`
+}
+
+// Rank は制約を満たすロボットを重み付きスコアの降順に並べる
+// スコアが同じ場合はロボット ID の昇順とする
+func Rank(robots []Robot, req Request) ([]Match, []Exclusion) {
+	var candidates []Robot
+	exclusions := []Exclusion{}
+	for _, r := range robots {
+		if reasons := violations(r, req.Constraints); len(reasons) > 0 {
+			exclusions = append(exclusions, Exclusion{RobotID: r.ID, Reasons: reasons})
+			continue
+		}
+		candidates = append(candidates, r)
+	}
+
+	best := bestValues(candidates)
+	matches := make([]Match, 0, len(candidates))
+	for _, r := range candidates {
+		matches = append(matches, score(r, req, best))
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Robot.ID < matches[j].Robot.ID
+	})
+	for i := range matches {
+		matches[i].Rank = i + 1
+	}
+	return matches, exclusions
+}
+
+func (r Robot) value(criterion string) float64 {
+	switch criterion {
+	case CriterionDOF:
+		return float64(r.DOF)
+	case CriterionReach:
+		return r.ReachMm
+	case CriterionPayload:
+		return r.PayloadKg
+	case CriterionRepeatAccuracy:
+		return r.RepeatAccuracyMm
+	case CriterionMaxSpeed:
+		return r.MaxSpeedMmS
+	}
+	return 0
+}
+
+func (r Robot) hasFeature(feature string) bool {
+	for _, f := range r.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// violations は制約を満たさない項目の説明を返す
+func violations(r Robot, c Criteria) []string {
+	var reasons []string
+	for _, key := range sortedKeys(c.Numeric) {
+		limit, v := c.Numeric[key], r.value(key)
+		if lowerIsBetter[key] && v > limit {
+			reasons = append(reasons, fmt.Sprintf("%s %.4g exceeds %.4g", key, v, limit))
+		} else if !lowerIsBetter[key] && v < limit {
+			reasons = append(reasons, fmt.Sprintf("%s %.4g is below %.4g", key, v, limit))
+		}
+	}
+	for _, f := range c.Features {
+		if !r.hasFeature(f) {
+			reasons = append(reasons, "missing feature "+f)
+		}
+	}
+	return reasons
+}
+
+// fit は要求値に対する充足度を 0〜1 で返す
+func fit(criterion string, value, required float64) float64 {
+	if lowerIsBetter[criterion] {
+		if value <= required {
+			return 1
+		}
+		return required / value
+	}
+	if value >= required || required <= 0 {
+		return 1
+	}
+	return math.Max(0, value/required)
+}
+
+// bestValues は候補の中で各項目の最も良い値を返す（相対評価用）
+func bestValues(robots []Robot) map[string]float64 {
+	best := map[string]float64{}
+	for _, key := range numericCriteria {
+		for i, r := range robots {
+			v := r.value(key)
+			if i == 0 || (lowerIsBetter[key] && v < best[key]) || (!lowerIsBetter[key] && v > best[key]) {
+				best[key] = v
+			}
+		}
+	}
+	return best
+}
+
+func score(r Robot, req Request, best map[string]float64) Match {
+	m := Match{
+		Robot:           r,
+		CriterionScores: map[string]float64{},
+		Unmet:           []string{},
+	}
+
+	weights := map[string]float64{}
+	for key := range req.Requirements.Numeric {
+		weights[key] = 1
+	}
+	if len(req.Requirements.Features) > 0 {
+		weights[CriterionFeatures] = 1
+	}
+	for key, w := range req.Weights {
+		weights[key] = w
+	}
+
+	var total, weightSum float64
+	for _, key := range sortedKeys(weights) {
+		var s float64
+		if key == CriterionFeatures {
+			s = featureScore(r, req.Requirements.Features, &m)
+		} else if required, ok := req.Requirements.Numeric[key]; ok {
+			s = fit(key, r.value(key), required)
+			if s < 1 {
+				m.Unmet = append(m.Unmet, key)
+			}
+		} else {
+			// 要求値が無い項目は候補内で最も良い値に対する割合で評価する
+			s = fit(key, r.value(key), best[key])
+		}
+		m.CriterionScores[key] = round(s, 3)
+		total += weights[key] * s
+		weightSum += weights[key]
+	}
+
+	m.Score = 1
+	if weightSum > 0 {
+		m.Score = round(total/weightSum, 3)
+	}
+	m.Parameters = recommendParameters(r, req.Requirements)
+	m.ExpectedPerformance = expectPerformance(r, req.Requirements, m)
+	return m
+}
+
+func featureScore(r Robot, features []string, m *Match) float64 {
+	if len(features) == 0 {
+		return 1
+	}
+	matched := 0
+	for _, f := range features {
+		if r.hasFeature(f) {
+			matched++
+		} else {
+			m.Unmet = append(m.Unmet, "feature:"+f)
+		}
+	}
+	return float64(matched) / float64(len(features))
+}
+
+// recommendParameters は要求に合わせた運用パラメータを推奨する
+// 要求が無い項目は定格値に余裕を持たせた値とする
+func recommendParameters(r Robot, req Criteria) map[string]interface{} {
+	speed := r.MaxSpeedMmS * DefaultSpeedRatio
+	if v, ok := req.Numeric[CriterionMaxSpeed]; ok {
+		speed = math.Min(v, r.MaxSpeedMmS)
+	}
+	payload := r.PayloadKg * DefaultPayloadRatio
+	if v, ok := req.Numeric[CriterionPayload]; ok {
+		payload = math.Min(v, r.PayloadKg)
+	}
+	safetyFactor := 0.0
+	if payload > 0 {
+		safetyFactor = round(r.PayloadKg/payload, 2)
+	}
+	// 要求精度がロボットの繰り返し精度の2倍以内なら精度優先モードを推奨する
+	precisionMode := false
+	if v, ok := req.Numeric[CriterionRepeatAccuracy]; ok {
+		precisionMode = v <= r.RepeatAccuracyMm*2
+	}
+	return map[string]interface{}{
+		"working_speed_mm_s": round(speed, 1),
+		"max_payload_kg":     round(payload, 2),
+		"safety_factor":      safetyFactor,
+		"precision_mode":     precisionMode,
+		"teaching_method":    r.TeachingMethod,
+		"control_type":       r.ControlType,
+	}
+}
+
+func expectPerformance(r Robot, req Criteria, m Match) map[string]interface{} {
+	perf := map[string]interface{}{
+		"predicted_score":    round(m.Score*100, 1),
+		"repeat_accuracy_mm": r.RepeatAccuracyMm,
+	}
+	if v, ok := req.Numeric[CriterionPayload]; ok && r.PayloadKg > 0 {
+		perf["payload_utilization"] = round(v/r.PayloadKg, 3)
+	}
+	if v, ok := req.Numeric[CriterionReach]; ok {
+		perf["reach_margin_mm"] = round(r.ReachMm-v, 1)
+	}
+	if v, ok := req.Numeric[CriterionRepeatAccuracy]; ok {
+		perf["accuracy_margin_mm"] = round(v-r.RepeatAccuracyMm, 4)
+	}
+	switch {
+	case len(m.Unmet) == 0 && m.Score >= 0.85:
+		perf["confidence"] = "high"
+	case m.Score >= 0.6:
+		perf["confidence"] = "medium"
+	default:
+		perf["confidence"] = "low"
+	}
+	return perf
+}
+
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,77 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRobots() []Robot {
+	return []Robot{
+		{ID: "teaching_free_arm_v1", DOF: 6, ReachMm: 850, PayloadKg: 5, RepeatAccuracyMm: 0.02, MaxSpeedMmS: 1000,
+			Features: NormalizeFeatures([]string{"vision", "hybrid", "stereo_camera", "collision_detection"})},
+		{ID: "collaborative_robot_v2", DOF: 7, ReachMm: 1200, PayloadKg: 10, RepeatAccuracyMm: 0.01, MaxSpeedMmS: 2000,
+			Features: NormalizeFeatures([]string{"ai", "adaptive", "multi_camera", "human_detection"})},
+		{ID: "industrial_arm_std", DOF: 6, ReachMm: 1500, PayloadKg: 20, RepeatAccuracyMm: 0.05, MaxSpeedMmS: 1500,
+			Features: NormalizeFeatures([]string{"manual", "position", "None", "emergency_stop"})},
+	}
+}
+
+func TestRankWithRequirements(t *testing.T) {
+	req := Request{Requirements: Criteria{
+		Numeric:  map[string]float64{CriterionPayload: 8, CriterionRepeatAccuracy: 0.02},
+		Features: []string{"human_detection"},
+	}}
+	matches, exclusions := Rank(testRobots(), req)
+
+	assert.Empty(t, exclusions)
+	if assert.Len(t, matches, 3) {
+		assert.Equal(t, "collaborative_robot_v2", matches[0].Robot.ID)
+		assert.Equal(t, 1, matches[0].Rank)
+		assert.Equal(t, 1.0, matches[0].Score)
+		assert.Empty(t, matches[0].Unmet)
+		assert.Equal(t, "high", matches[0].ExpectedPerformance["confidence"])
+		assert.Equal(t, 8.0, matches[0].Parameters["max_payload_kg"])
+
+		// payload 5/8, accuracy 1, feature 0
+		arm := matches[1]
+		assert.Equal(t, "teaching_free_arm_v1", arm.Robot.ID)
+		assert.InDelta(t, (0.625+1+0)/3, arm.Score, 0.001)
+		assert.ElementsMatch(t, []string{CriterionPayload, "feature:human_detection"}, arm.Unmet)
+
+		// payload 1, accuracy 0.02/0.05, feature 0
+		assert.Equal(t, "industrial_arm_std", matches[2].Robot.ID)
+		assert.InDelta(t, (1+0.4+0)/3, matches[2].Score, 0.001)
+	}
+}
+
+func TestRankWithConstraintsAndWeights(t *testing.T) {
+	req := Request{
+		Constraints: Criteria{Numeric: map[string]float64{CriterionReach: 1000}},
+		Weights:     map[string]float64{CriterionPayload: 1},
+	}
+	matches, exclusions := Rank(testRobots(), req)
+
+	if assert.Len(t, exclusions, 1) {
+		assert.Equal(t, "teaching_free_arm_v1", exclusions[0].RobotID)
+		assert.Contains(t, exclusions[0].Reasons[0], CriterionReach)
+	}
+	// 要求が無い項目は候補内の最大値に対する割合で評価される
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "industrial_arm_std", matches[0].Robot.ID)
+		assert.Equal(t, 0.5, matches[1].Score)
+		assert.Equal(t, 1200.0, matches[1].Parameters["working_speed_mm_s"])
+	}
+}
+
+func TestParseCriteria(t *testing.T) {
+	c, err := ParseCriteria(map[string]interface{}{"dof": 6.0, "features": []interface{}{"Vision", "none"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, c.Numeric[CriterionDOF])
+	assert.Equal(t, []string{"vision"}, c.Features)
+
+	_, err = ParseCriteria(map[string]interface{}{"color": "red"})
+	assert.Error(t, err)
+	_, err = ParseWeights(map[string]interface{}{"dof": -1.0})
+	assert.Error(t, err)
+}
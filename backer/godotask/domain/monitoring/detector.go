@@ -0,0 +1,182 @@
+package monitoring
+
+import (
+	"fmt"
+	"math"
+)
+
+// 異常を検知した検知器の種類
+const (
+	DetectorZScore = "z_score"
+	DetectorEWMA   = "ewma"
+	DetectorCUSUM  = "cusum"
+)
+
+// Config はオンライン異常検知器の設定
+type Config struct {
+	// Warmup はベースライン（平均・標準偏差）を推定するまで検知しないサンプル数
+	Warmup int `json:"warmup"`
+	// ZThreshold は z スコアの閾値
+	ZThreshold float64 `json:"z_threshold"`
+	// EWMALambda は EWMA の平滑化係数 (0 < λ <= 1)、EWMALimit は管理限界の σ 倍率
+	EWMALambda float64 `json:"ewma_lambda"`
+	EWMALimit  float64 `json:"ewma_limit"`
+	// CUSUMK は許容量 k、CUSUMH は判定閾値 h（いずれも σ 単位）
+	CUSUMK float64 `json:"cusum_k"`
+	CUSUMH float64 `json:"cusum_h"`
+}
+
+// DefaultConfig は一般的な管理図の設定値
+func DefaultConfig() Config {
+	return Config{
+		Warmup:     5,
+		ZThreshold: 3,
+		EWMALambda: 0.2,
+		EWMALimit:  3,
+		CUSUMK:     0.5,
+		CUSUMH:     5,
+	}
+}
+
+// ParseConfig は既定値を overrides で上書きした設定を返す
+func ParseConfig(overrides map[string]float64) (Config, error) {
+	cfg := DefaultConfig()
+	for key, v := range overrides {
+		switch key {
+		case "warmup":
+			cfg.Warmup = int(v)
+		case "z_threshold":
+			cfg.ZThreshold = v
+		case "ewma_lambda":
+			cfg.EWMALambda = v
+		case "ewma_limit":
+			cfg.EWMALimit = v
+		case "cusum_k":
+			cfg.CUSUMK = v
+		case "cusum_h":
+			cfg.CUSUMH = v
+		default:
+			return cfg, fmt.Errorf("unknown detector setting %q", key)
+		}
+	}
+	if cfg.Warmup < 2 {
+		return cfg, fmt.Errorf("warmup must be at least 2")
+	}
+	if cfg.EWMALambda <= 0 || cfg.EWMALambda > 1 {
+		return cfg, fmt.Errorf("ewma_lambda must be in (0, 1]")
+	}
+	if cfg.ZThreshold <= 0 || cfg.EWMALimit <= 0 || cfg.CUSUMH <= 0 || cfg.CUSUMK < 0 {
+		return cfg, fmt.Errorf("thresholds must be positive")
+	}
+	return cfg, nil
+}
+
+// State は指標ごとの検知器の状態。セッションに保存し、サンプル到着ごとに更新する
+// Mean / M2 はウォームアップ期間（Phase I）のサンプルから Welford 法で求めたベースライン。
+// ウォームアップ後（Phase II）は固定し、持続的なずれがベースラインに吸収されないようにする
+type State struct {
+	Count        int     `json:"count"`
+	BaselineN    int     `json:"baseline_n"`
+	Mean         float64 `json:"mean"`
+	M2           float64 `json:"m2"`
+	EWMA         float64 `json:"ewma"`
+	CUSUMPos     float64 `json:"cusum_pos"`
+	CUSUMNeg     float64 `json:"cusum_neg"`
+	Min          float64 `json:"min"`
+	Max          float64 `json:"max"`
+	Last         float64 `json:"last"`
+	AnomalyCount int     `json:"anomaly_count"`
+}
+
+// StdDev はベースラインの標準偏差（不偏）
+func (s *State) StdDev() float64 {
+	if s.BaselineN < 2 {
+		return 0
+	}
+	return math.Sqrt(s.M2 / float64(s.BaselineN-1))
+}
+
+// Flag は1サンプルに対する判定結果
+type Flag struct {
+	Detectors []string `json:"detectors"`
+	ZScore    float64  `json:"z_score"`
+	Severity  string   `json:"severity"`
+}
+
+// Anomalous は いずれかの検知器が異常と判定したかを返す
+func (f Flag) Anomalous() bool {
+	return len(f.Detectors) > 0
+}
+
+// minSigma は標準偏差が 0（一定値）の場合の下限。一定値からの変化は異常として扱う
+const minSigma = 1e-9
+
+// Observe はサンプル x を状態に取り込み、判定結果を返す
+// ウォームアップ中はベースラインの更新のみ行い、以降はベースラインを固定して判定する
+func (s *State) Observe(cfg Config, x float64) Flag {
+	if s.Count == 0 {
+		s.Min, s.Max, s.EWMA = x, x, x
+	}
+	s.Count++
+	s.Last = x
+	s.Min = math.Min(s.Min, x)
+	s.Max = math.Max(s.Max, x)
+
+	if s.BaselineN < cfg.Warmup {
+		s.updateBaseline(x)
+		s.EWMA = cfg.EWMALambda*x + (1-cfg.EWMALambda)*s.EWMA
+		return Flag{Detectors: []string{}}
+	}
+
+	sigma := math.Max(s.StdDev(), minSigma)
+	dev := (x - s.Mean) / sigma
+	flag := Flag{Detectors: []string{}, ZScore: round(dev, 3)}
+
+	if math.Abs(dev) > cfg.ZThreshold {
+		flag.Detectors = append(flag.Detectors, DetectorZScore)
+	}
+
+	s.EWMA = cfg.EWMALambda*x + (1-cfg.EWMALambda)*s.EWMA
+	ewmaLimit := cfg.EWMALimit * sigma * math.Sqrt(cfg.EWMALambda/(2-cfg.EWMALambda))
+	if math.Abs(s.EWMA-s.Mean) > ewmaLimit {
+		flag.Detectors = append(flag.Detectors, DetectorEWMA)
+	}
+
+	s.CUSUMPos = math.Max(0, s.CUSUMPos+dev-cfg.CUSUMK)
+	s.CUSUMNeg = math.Max(0, s.CUSUMNeg-dev-cfg.CUSUMK)
+	if s.CUSUMPos > cfg.CUSUMH || s.CUSUMNeg > cfg.CUSUMH {
+		flag.Detectors = append(flag.Detectors, DetectorCUSUM)
+		// 検知後は累積和をリセットし、次の変化を検知できるようにする
+		s.CUSUMPos, s.CUSUMNeg = 0, 0
+	}
+
+	if flag.Anomalous() {
+		s.AnomalyCount++
+		flag.Severity = severity(cfg, flag)
+	}
+	return flag
+}
+
+func (s *State) updateBaseline(x float64) {
+	s.BaselineN++
+	delta := x - s.Mean
+	s.Mean += delta / float64(s.BaselineN)
+	s.M2 += delta * (x - s.Mean)
+}
+
+// severity は z スコアの大きさと検知器の数から重大度を決める
+func severity(cfg Config, f Flag) string {
+	switch {
+	case math.Abs(f.ZScore) >= 2*cfg.ZThreshold || len(f.Detectors) >= 3:
+		return "high"
+	case len(f.Detectors) == 2:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,108 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func observeAll(s *State, cfg Config, values ...float64) []Flag {
+	flags := make([]Flag, 0, len(values))
+	for _, v := range values {
+		flags = append(flags, s.Observe(cfg, v))
+	}
+	return flags
+}
+
+func TestObserveSpike(t *testing.T) {
+	cfg := DefaultConfig()
+	s := &State{}
+
+	flags := observeAll(s, cfg, 40.0, 40.5, 39.5, 40.2, 39.8, 40.1, 39.9)
+	for _, f := range flags {
+		assert.False(t, f.Anomalous())
+	}
+
+	spike := s.Observe(cfg, 48)
+	assert.True(t, spike.Anomalous())
+	assert.Contains(t, spike.Detectors, DetectorZScore)
+	assert.Equal(t, "high", spike.Severity)
+
+	// ウォームアップ後のサンプルはベースラインに含めない
+	assert.InDelta(t, 40.0, s.Mean, 0.1)
+	assert.Equal(t, 8, s.Count)
+	assert.Equal(t, 5, s.BaselineN)
+	assert.Equal(t, 48.0, s.Max)
+}
+
+func TestObserveDriftIsCaughtByCUSUM(t *testing.T) {
+	cfg := DefaultConfig()
+	s := &State{}
+	observeAll(s, cfg, 0.010, 0.012, 0.008, 0.011, 0.009, 0.010, 0.012, 0.008)
+
+	// 1σ 程度の小さなずれが続くと z スコアでは検知されず累積和で検知される
+	var cusum bool
+	for i := 0; i < 20 && !cusum; i++ {
+		f := s.Observe(cfg, 0.0125)
+		assert.NotContains(t, f.Detectors, DetectorZScore)
+		for _, d := range f.Detectors {
+			cusum = cusum || d == DetectorCUSUM
+		}
+	}
+	assert.True(t, cusum)
+}
+
+func TestObserveSustainedShiftIsDetected(t *testing.T) {
+	cfg := DefaultConfig()
+	s := &State{}
+	baseline := []float64{100.0, 101.0, 99.0, 100.5, 99.5, 100.2, 99.8, 100.7, 99.3, 100.0}
+	observeAll(s, cfg, baseline...)
+	mean, sigma := s.Mean, s.StdDev()
+
+	// 1σ のステップ変化が続いても、ベースラインは追従せず検知され続ける
+	first, detected := -1, 0
+	for i := 0; i < 100; i++ {
+		if s.Observe(cfg, mean+sigma).Anomalous() {
+			if first < 0 {
+				first = i
+			}
+			detected++
+		}
+	}
+	assert.GreaterOrEqual(t, first, 0)
+	assert.Less(t, first, 15)
+	assert.GreaterOrEqual(t, detected, 5)
+	assert.Equal(t, mean, s.Mean)
+	assert.Equal(t, cfg.Warmup, s.BaselineN)
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(map[string]float64{"z_threshold": 2.5})
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, cfg.ZThreshold)
+	assert.Equal(t, DefaultConfig().Warmup, cfg.Warmup)
+
+	_, err = ParseConfig(map[string]float64{"ewma_lambda": 1.5})
+	assert.Error(t, err)
+	_, err = ParseConfig(map[string]float64{"unknown": 1})
+	assert.Error(t, err)
+}
+
+func TestSummarize(t *testing.T) {
+	cfg := DefaultConfig()
+	temp := &State{}
+	observeAll(temp, cfg, 40, 40.5, 39.5, 40.2, 39.8, 55)
+	dev := &State{}
+	observeAll(dev, cfg, 0.01, 0.02)
+
+	start := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	s := Summarize(map[string]*State{"spindle_temperature": temp, "dimension_deviation": dev}, start, start.Add(90*time.Second))
+
+	assert.Equal(t, 90.0, s.DurationSeconds)
+	assert.Equal(t, 8, s.SampleCount)
+	assert.Equal(t, 1, s.AnomalyCount)
+	assert.Equal(t, 0.875, s.Stability)
+	assert.Equal(t, "spindle_temperature", s.MostAnomalous)
+	assert.Equal(t, 55.0, s.Metrics["spindle_temperature"].Max)
+}
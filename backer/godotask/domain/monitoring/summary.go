@@ -0,0 +1,71 @@
+package monitoring
+
+import (
+	"sort"
+	"time"
+)
+
+// MetricSummary は指標ごとの集計
+type MetricSummary struct {
+	Count        int     `json:"count"`
+	Mean         float64 `json:"mean"`
+	StdDev       float64 `json:"std_dev"`
+	Min          float64 `json:"min"`
+	Max          float64 `json:"max"`
+	Last         float64 `json:"last"`
+	AnomalyCount int     `json:"anomaly_count"`
+}
+
+// Summary は監視セッション終了時のまとめ
+type Summary struct {
+	DurationSeconds float64 `json:"duration_seconds"`
+	SampleCount     int     `json:"sample_count"`
+	AnomalyCount    int     `json:"anomaly_count"`
+	AnomalyRate     float64 `json:"anomaly_rate"`
+	// Stability は異常と判定されなかったサンプルの割合 (0〜1)
+	Stability float64 `json:"stability"`
+	// MostAnomalous は異常が最も多かった指標（異常が無い場合は空）
+	MostAnomalous string                   `json:"most_anomalous_metric"`
+	Metrics       map[string]MetricSummary `json:"metrics"`
+}
+
+// Summarize は指標ごとの検知器の状態からセッションのまとめを作る
+// Mean / StdDev は異常と判定されなかったサンプルによるベースラインの値
+func Summarize(states map[string]*State, start, end time.Time) Summary {
+	summary := Summary{
+		DurationSeconds: round(end.Sub(start).Seconds(), 3),
+		Stability:       1,
+		Metrics:         make(map[string]MetricSummary, len(states)),
+	}
+
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mostAnomalies := 0
+	for _, name := range names {
+		s := states[name]
+		summary.SampleCount += s.Count
+		summary.AnomalyCount += s.AnomalyCount
+		if s.AnomalyCount > mostAnomalies {
+			mostAnomalies = s.AnomalyCount
+			summary.MostAnomalous = name
+		}
+		summary.Metrics[name] = MetricSummary{
+			Count:        s.Count,
+			Mean:         round(s.Mean, 6),
+			StdDev:       round(s.StdDev(), 6),
+			Min:          s.Min,
+			Max:          s.Max,
+			Last:         s.Last,
+			AnomalyCount: s.AnomalyCount,
+		}
+	}
+	if summary.SampleCount > 0 {
+		summary.AnomalyRate = round(float64(summary.AnomalyCount)/float64(summary.SampleCount), 4)
+		summary.Stability = round(1-summary.AnomalyRate, 4)
+	}
+	return summary
+}
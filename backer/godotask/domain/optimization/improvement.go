@@ -0,0 +1,66 @@
+package optimization
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// 指標の向き
+const (
+	DirectionMinimize = "minimize"
+	DirectionMaximize = "maximize"
+)
+
+// MetricValue は状態（JSON オブジェクト）から指標の値を取り出す
+// metric は "cycle_time" や "derived.cycle_time" のようにドット区切りで入れ子をたどる
+func MetricValue(state map[string]interface{}, metric string) (float64, error) {
+	var cur interface{} = state
+	for _, key := range strings.Split(metric, ".") {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("metric %q not found", metric)
+		}
+		if cur, ok = obj[key]; !ok {
+			return 0, fmt.Errorf("metric %q not found", metric)
+		}
+	}
+	switch v := cur.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	default:
+		return 0, fmt.Errorf("metric %q is not a number", metric)
+	}
+}
+
+// Improvement は初期状態から最適化後の状態への指標の改善率（%）を計算する
+// minimize の指標は減少、maximize の指標は増加を改善とし、悪化した場合は負の値になる
+func Improvement(initial, optimized map[string]interface{}, metric, direction string) (float64, error) {
+	if direction == "" {
+		direction = DirectionMinimize
+	}
+	if direction != DirectionMinimize && direction != DirectionMaximize {
+		return 0, fmt.Errorf("metric_direction must be %s or %s", DirectionMinimize, DirectionMaximize)
+	}
+	before, err := MetricValue(initial, metric)
+	if err != nil {
+		return 0, fmt.Errorf("initial_state: %w", err)
+	}
+	after, err := MetricValue(optimized, metric)
+	if err != nil {
+		return 0, fmt.Errorf("optimized_state: %w", err)
+	}
+	if before == 0 {
+		return 0, fmt.Errorf("initial_state: metric %q is 0, so a relative improvement cannot be computed", metric)
+	}
+	change := (before - after) / math.Abs(before)
+	if direction == DirectionMaximize {
+		change = -change
+	}
+	return math.Round(change*10000) / 100, nil
+}
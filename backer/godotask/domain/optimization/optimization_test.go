@@ -0,0 +1,162 @@
+package optimization
+
+import (
+	"testing"
+
+	"github.com/godotask/domain/expression"
+	"github.com/stretchr/testify/assert"
+)
+
+// 切削速度と送りからサイクルタイムとエネルギーを求め、表面粗さの制約の下で最小化する
+const (
+	testObjective   = "minimize(cycle_time) + 0.01 * minimize(energy) subject to roughness <= roughness_max"
+	testConstraints = `{"roughness_max": 1.6, "note": true}`
+	testParameters  = `{
+		"speed": {"min": 50, "max": 300},
+		"feed": {"min": 0.05, "max": 0.5, "initial": 0.1},
+		"cycle_time": {"expr": "length / (speed * feed)"},
+		"energy": {"expr": "speed ^ 2 * 0.001 * cycle_time"},
+		"roughness": {"expr": "feed ^ 2 * 32 / 0.8"},
+		"search_algorithm": "A*"
+	}`
+)
+
+func parseTestSpec(t *testing.T) *Problem {
+	t.Helper()
+	spec, err := ParseSpec(testObjective, testConstraints, testParameters)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	p, err := spec.Bind(map[string]float64{"length": 1000})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return p
+}
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec(testObjective, testConstraints, testParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, "feed", spec.Variables[0].Name)
+	assert.Equal(t, "speed", spec.Variables[1].Name)
+	assert.Len(t, spec.Derived, 3)
+	assert.Equal(t, 1.6, spec.Constants["roughness_max"])
+	assert.Contains(t, spec.Constraints, "subject_to_1")
+
+	// 入力の不足・決定変数の上書き・循環参照
+	_, err = spec.Bind(nil)
+	assert.ErrorContains(t, err, "unbound variables: length")
+	_, err = spec.Bind(map[string]float64{"length": 1, "speed": 100})
+	assert.ErrorContains(t, err, "cannot be given")
+	circular, err := ParseSpec("minimize(a)", "", `{"x": {"min": 0, "max": 1}, "a": {"expr": "b + x"}, "b": {"expr": "a"}}`)
+	assert.NoError(t, err)
+	_, err = circular.Bind(nil)
+	assert.ErrorContains(t, err, "circular reference")
+
+	invalid := []struct{ objective, constraints, parameters, want string }{
+		{"minimize(time) +", "", `{"x": {"min": 0, "max": 1}}`, "objective_function"},
+		{"minimize(x)", `{"c": "x <"}`, `{"x": {"min": 0, "max": 1}}`, "constraints.c"},
+		{"minimize(x)", "", `{"x": {"min": 1, "max": 0}}`, "min must be less than max"},
+		{"minimize(x)", "", `{"x": 1}`, "at least one variable"},
+		{"minimize(x)", "", `[1]`, "JSON object"},
+		{"", "", `{"x": {"min": 0, "max": 1}}`, "expression is empty"},
+	}
+	for _, tc := range invalid {
+		_, err := ParseSpec(tc.objective, tc.constraints, tc.parameters)
+		assert.ErrorContains(t, err, tc.want, tc.objective)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	// 保存時は決定変数や目的関数が無くてもよい
+	assert.NoError(t, Validate("minimize(time) + minimize(energy) subject to collision_free", "", ""))
+	assert.NoError(t, Validate("", `{"max_force": 100}`, `{"kp": 100}`))
+	assert.NoError(t, Validate(testObjective, testConstraints, testParameters))
+
+	invalid := []struct{ objective, constraints, parameters, want string }{
+		{"optimize(force_distribution)", "", "", "unknown function"},
+		{"time < 3", "", "", "expected a number"},
+		{"minimize(x)", `{"joint_limits": "q_min<q<q_max"}`, "", "constraints.joint_limits"},
+		{"minimize(x)", `{"speed": "speed + 1"}`, "", "expected a condition"},
+		{"minimize(x)", "", `{"t": {"expr": "x > 1"}}`, "parameters.t"},
+		{"minimize(x)", "", `{"t": {"expr": "minimize(x)"}}`, "can only be used as a term"},
+		{"minimize(x)", "not json", "", "JSON object"},
+	}
+	for _, tc := range invalid {
+		err := Validate(tc.objective, tc.constraints, tc.parameters)
+		assert.ErrorContains(t, err, tc.want, tc.objective)
+	}
+	assert.True(t, expression.IsFormatError(Validate("time < 3", "", "")))
+}
+
+func TestSolveMethods(t *testing.T) {
+	p := parseTestSpec(t)
+	start := p.Start(nil)
+	initial, err := p.Evaluate(start)
+	assert.NoError(t, err)
+
+	for _, method := range Methods {
+		r, err := Solve(method, p.Variables(), start, p.Penalized, Options{MaxIterations: 2000, Seed: 42})
+		if !assert.NoError(t, err, method) {
+			continue
+		}
+		best, err := p.Evaluate(r.X)
+		assert.NoError(t, err)
+		assert.True(t, best.Feasible, method)
+		assert.Less(t, best.Objective, initial.Objective, method)
+		// 送りは粗さの上限 sqrt(1.6 * 0.8 / 32) = 0.2、速度は範囲の上限で最適になる
+		assert.InDelta(t, 0.2, r.X[0], 0.01, method)
+		assert.InDelta(t, 300, r.X[1], 15, method)
+		assert.LessOrEqual(t, len(r.Trace), traceLength)
+		assert.Equal(t, method, r.Method)
+	}
+}
+
+func TestSolveDeterministicAnnealing(t *testing.T) {
+	p := parseTestSpec(t)
+	opts := Options{MaxIterations: 500, Seed: 7}
+	a, err := Solve(MethodSimulatedAnnealing, p.Variables(), p.Start(nil), p.Penalized, opts)
+	assert.NoError(t, err)
+	b, err := Solve(MethodSimulatedAnnealing, p.Variables(), p.Start(nil), p.Penalized, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, a.X, b.X)
+}
+
+func TestSolveLimits(t *testing.T) {
+	p := parseTestSpec(t)
+	_, err := Solve("gradient", p.Variables(), p.Start(nil), p.Penalized, Options{})
+	assert.ErrorContains(t, err, "unknown method")
+	_, err = Solve(MethodGridSearch, p.Variables(), p.Start(nil), p.Penalized, Options{MaxIterations: MaxIterations + 1})
+	assert.Error(t, err)
+
+	fine := []Variable{{Name: "a", Min: 0, Max: 1, Step: 0.001}, {Name: "b", Min: 0, Max: 1, Step: 0.001}}
+	_, err = Solve(MethodGridSearch, fine, []float64{0, 0}, p.Penalized, Options{})
+	assert.ErrorContains(t, err, "more than")
+}
+
+func TestImprovement(t *testing.T) {
+	initial := map[string]interface{}{"objective": 20.0, "derived": map[string]interface{}{"accuracy": 0.8}}
+	optimized := map[string]interface{}{"objective": 15.0, "derived": map[string]interface{}{"accuracy": 0.9}}
+
+	v, err := Improvement(initial, optimized, "objective", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, v)
+
+	v, err = Improvement(initial, optimized, "derived.accuracy", DirectionMaximize)
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, v)
+
+	// 悪化は負の改善率
+	v, err = Improvement(optimized, initial, "objective", DirectionMinimize)
+	assert.NoError(t, err)
+	assert.InDelta(t, -33.33, v, 1e-9)
+
+	_, err = Improvement(initial, optimized, "derived.missing", "")
+	assert.ErrorContains(t, err, "not found")
+	_, err = Improvement(initial, optimized, "derived", "")
+	assert.ErrorContains(t, err, "not a number")
+	_, err = Improvement(initial, optimized, "objective", "sideways")
+	assert.Error(t, err)
+	_, err = Improvement(map[string]interface{}{"objective": 0.0}, optimized, "objective", "")
+	assert.ErrorContains(t, err, "is 0")
+}
@@ -0,0 +1,304 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// 探索手法
+const (
+	MethodGridSearch         = "grid_search"
+	MethodNelderMead         = "nelder_mead"
+	MethodSimulatedAnnealing = "simulated_annealing"
+)
+
+// 探索の既定値と上限
+const (
+	DefaultMaxIterations = 1000
+	MaxIterations        = 100000
+	DefaultTolerance     = 1e-8
+	// MaxGridPoints はグリッドサーチで評価する点の上限
+	MaxGridPoints = 100000
+	// traceLength は結果に残す最良値の推移の点数
+	traceLength = 50
+)
+
+// Methods は利用できる探索手法
+var Methods = []string{MethodGridSearch, MethodNelderMead, MethodSimulatedAnnealing}
+
+// Options は探索の設定。Seed は焼きなまし法の乱数の種（同じ種なら同じ結果になる）
+type Options struct {
+	MaxIterations int     `json:"max_iterations"`
+	Tolerance     float64 `json:"tolerance"`
+	Seed          int64   `json:"seed"`
+}
+
+// Result は探索結果
+type Result struct {
+	Method      string    `json:"method"`
+	X           []float64 `json:"x"`
+	Value       float64   `json:"value"`
+	Iterations  int       `json:"iterations"`
+	Evaluations int       `json:"evaluations"`
+	Converged   bool      `json:"converged"`
+	// Trace は最良値の推移（最大 traceLength 点に間引く）
+	Trace []float64 `json:"trace"`
+}
+
+// Func は最小化する関数。評価できない点はエラーを返す
+type Func func(x []float64) (float64, error)
+
+// Solve は method で f を bounds の範囲で最小化する
+func Solve(method string, bounds []Variable, start []float64, f Func, opts Options) (*Result, error) {
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultMaxIterations
+	}
+	if opts.MaxIterations > MaxIterations {
+		return nil, fmt.Errorf("max_iterations must be at most %d", MaxIterations)
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = DefaultTolerance
+	}
+	o := &objective{f: f}
+	var r *Result
+	var err error
+	switch method {
+	case MethodGridSearch:
+		r, err = gridSearch(bounds, o, opts)
+	case MethodNelderMead:
+		r = nelderMead(bounds, start, o, opts)
+	case MethodSimulatedAnnealing:
+		r = simulatedAnnealing(bounds, start, o, opts)
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if math.IsInf(r.Value, 1) {
+		return nil, fmt.Errorf("objective could not be evaluated at any point: %v", o.firstErr)
+	}
+	r.Method = method
+	r.Evaluations = o.count
+	r.Trace = thin(r.Trace, traceLength)
+	return r, nil
+}
+
+// objective は評価回数を数え、評価できない点を +Inf として扱う
+type objective struct {
+	f        Func
+	count    int
+	firstErr error
+}
+
+func (o *objective) eval(x []float64) float64 {
+	o.count++
+	v, err := o.f(x)
+	if err != nil {
+		if o.firstErr == nil {
+			o.firstErr = err
+		}
+		return math.Inf(1)
+	}
+	return v
+}
+
+// gridSearch は格子点をすべて評価する。step が無い変数は評価点数が上限に収まるよう等分する
+func gridSearch(bounds []Variable, o *objective, opts Options) (*Result, error) {
+	perAxis := int(math.Floor(math.Pow(float64(opts.MaxIterations), 1/float64(len(bounds)))))
+	if perAxis < 2 {
+		perAxis = 2
+	}
+	axes := make([][]float64, len(bounds))
+	total := 1
+	for i, b := range bounds {
+		n := perAxis
+		if b.Step > 0 {
+			n = int(math.Floor((b.Max-b.Min)/b.Step+1e-9)) + 1
+		}
+		axis := make([]float64, n)
+		for k := range axis {
+			if b.Step > 0 {
+				axis[k] = b.Min + float64(k)*b.Step
+			} else {
+				axis[k] = b.Min + (b.Max-b.Min)*float64(k)/float64(n-1)
+			}
+		}
+		axes[i] = axis
+		total *= n
+		if total > MaxGridPoints {
+			return nil, fmt.Errorf("grid has more than %d points; increase step sizes", MaxGridPoints)
+		}
+	}
+
+	r := &Result{Value: math.Inf(1), Converged: true}
+	index := make([]int, len(bounds))
+	x := make([]float64, len(bounds))
+	for it := 0; it < total; it++ {
+		for i := range x {
+			x[i] = axes[i][index[i]]
+		}
+		if v := o.eval(x); v < r.Value {
+			r.Value = v
+			r.X = append([]float64(nil), x...)
+		}
+		r.Trace = append(r.Trace, r.Value)
+		r.Iterations++
+		// 混合基数のカウンタを進める
+		for i := range index {
+			index[i]++
+			if index[i] < len(axes[i]) {
+				break
+			}
+			index[i] = 0
+		}
+	}
+	return r, nil
+}
+
+// nelderMead は範囲内に射影する Nelder–Mead 法（α=1, γ=2, ρ=0.5, σ=0.5）
+func nelderMead(bounds []Variable, start []float64, o *objective, opts Options) *Result {
+	n := len(bounds)
+	clamp := func(x []float64) []float64 {
+		for i, b := range bounds {
+			x[i] = math.Min(math.Max(x[i], b.Min), b.Max)
+		}
+		return x
+	}
+	// 初期単体: 各軸に範囲の 10% ずらした点
+	simplex := make([][]float64, n+1)
+	values := make([]float64, n+1)
+	simplex[0] = clamp(append([]float64(nil), start...))
+	for i := 0; i < n; i++ {
+		p := append([]float64(nil), simplex[0]...)
+		delta := 0.1 * (bounds[i].Max - bounds[i].Min)
+		if p[i]+delta > bounds[i].Max {
+			delta = -delta
+		}
+		p[i] += delta
+		simplex[i+1] = p
+	}
+	for i := range simplex {
+		values[i] = o.eval(simplex[i])
+	}
+
+	r := &Result{}
+	for r.Iterations < opts.MaxIterations {
+		sortSimplex(simplex, values)
+		r.Trace = append(r.Trace, values[0])
+		if math.Abs(values[n]-values[0]) <= opts.Tolerance*(math.Abs(values[0])+opts.Tolerance) {
+			r.Converged = true
+			break
+		}
+		r.Iterations++
+
+		centroid := make([]float64, n)
+		for _, p := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += p[i] / float64(n)
+			}
+		}
+		along := func(coef float64) []float64 {
+			p := make([]float64, n)
+			for i := range p {
+				p[i] = centroid[i] + coef*(simplex[n][i]-centroid[i])
+			}
+			return clamp(p)
+		}
+
+		reflected := along(-1)
+		fr := o.eval(reflected)
+		switch {
+		case fr < values[0]:
+			expanded := along(-2)
+			if fe := o.eval(expanded); fe < fr {
+				simplex[n], values[n] = expanded, fe
+			} else {
+				simplex[n], values[n] = reflected, fr
+			}
+		case fr < values[n-1]:
+			simplex[n], values[n] = reflected, fr
+		default:
+			contracted := along(0.5)
+			if fc := o.eval(contracted); fc < values[n] {
+				simplex[n], values[n] = contracted, fc
+				continue
+			}
+			// 縮小: 最良点に向かって全体を縮める
+			for k := 1; k <= n; k++ {
+				for i := range simplex[k] {
+					simplex[k][i] = simplex[0][i] + 0.5*(simplex[k][i]-simplex[0][i])
+				}
+				values[k] = o.eval(simplex[k])
+			}
+		}
+	}
+	sortSimplex(simplex, values)
+	r.X, r.Value = simplex[0], values[0]
+	return r
+}
+
+func sortSimplex(simplex [][]float64, values []float64) {
+	// 点数が少ないので挿入ソートで十分
+	for i := 1; i < len(values); i++ {
+		for j := i; j > 0 && values[j] < values[j-1]; j-- {
+			values[j], values[j-1] = values[j-1], values[j]
+			simplex[j], simplex[j-1] = simplex[j-1], simplex[j]
+		}
+	}
+}
+
+// simulatedAnnealing は温度を 1 から 1e-3 まで幾何的に下げる焼きなまし法
+// 近傍は各軸に範囲 × 0.1 × 温度 の正規乱数を加えた点
+func simulatedAnnealing(bounds []Variable, start []float64, o *objective, opts Options) *Result {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	const t0, tEnd = 1.0, 1e-3
+
+	x := append([]float64(nil), start...)
+	fx := o.eval(x)
+	// 受理確率を目的関数の大きさに依存させないための尺度
+	scale := math.Max(math.Abs(fx), 1)
+	if math.IsInf(fx, 1) {
+		scale = 1
+	}
+	r := &Result{X: append([]float64(nil), x...), Value: fx}
+	lastImproved := 0
+
+	for k := 0; k < opts.MaxIterations; k++ {
+		t := t0 * math.Pow(tEnd/t0, float64(k)/float64(opts.MaxIterations))
+		candidate := make([]float64, len(x))
+		for i, b := range bounds {
+			candidate[i] = x[i] + rng.NormFloat64()*(b.Max-b.Min)*0.1*t
+			candidate[i] = math.Min(math.Max(candidate[i], b.Min), b.Max)
+		}
+		fc := o.eval(candidate)
+		delta := fc - fx
+		if delta < 0 || (!math.IsInf(fc, 1) && rng.Float64() < math.Exp(-delta/(t*scale))) {
+			x, fx = candidate, fc
+		}
+		if fx < r.Value {
+			if r.Value-fx > opts.Tolerance*(math.Abs(fx)+opts.Tolerance) {
+				lastImproved = k
+			}
+			r.X, r.Value = append([]float64(nil), x...), fx
+		}
+		r.Trace = append(r.Trace, r.Value)
+		r.Iterations++
+	}
+	// 最後の 2 割で最良値が改善しなければ収束とみなす
+	r.Converged = lastImproved < opts.MaxIterations*8/10
+	return r
+}
+
+// thin は values を最大 n 点に間引く（最後の点は必ず残す）
+func thin(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+	out := make([]float64, 0, n)
+	for i := 0; i < n-1; i++ {
+		out = append(out, values[i*len(values)/(n-1)])
+	}
+	return append(out, values[len(values)-1])
+}
@@ -0,0 +1,357 @@
+package optimization
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/godotask/domain/expression"
+)
+
+// PenaltyWeight は制約違反 1 単位あたりに目的関数へ加えるペナルティ
+const PenaltyWeight = 1e6
+
+// FeasibilityTolerance 以下の違反は丸め誤差とみなし、制約を満たしているとする
+const FeasibilityTolerance = 1e-9
+
+// MaxVariables は1つのモデルで探索できる決定変数の上限
+const MaxVariables = 10
+
+// Variable は決定変数と探索範囲
+type Variable struct {
+	Name    string   `json:"name"`
+	Min     float64  `json:"min"`
+	Max     float64  `json:"max"`
+	Step    float64  `json:"step,omitempty"`
+	Initial *float64 `json:"initial,omitempty"`
+}
+
+// Spec は OptimizationModel の文字列フィールドを解析した最適化問題
+//
+//   - ObjectiveFunction: 最小化する式。"minimize(a) + maximize(b) subject to c1, c2" の形で制約を続けてもよい
+//   - Parameters: {"名前": {"min": x, "max": y, "step": s, "initial": v}} は決定変数、
+//     {"名前": {"expr": "式"}} は他の変数から計算する派生変数、数値は定数。それ以外の値は参照しない
+//   - Constraints: {"名前": "式"} は満たすべき制約、数値は定数
+type Spec struct {
+	Objective   expression.Node
+	Constraints map[string]expression.Node
+	Variables   []Variable
+	Derived     map[string]expression.Node
+	Constants   map[string]float64
+}
+
+// ParseSpec はモデルの目的関数・制約・パラメータを解析する
+func ParseSpec(objective, constraints, parameters string) (*Spec, error) {
+	s, err := parseSpec(objective, constraints, parameters, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Variables) == 0 {
+		return nil, fmt.Errorf("parameters: at least one variable with min and max is required")
+	}
+	if len(s.Variables) > MaxVariables {
+		return nil, fmt.Errorf("parameters: at most %d variables can be optimized, got %d", MaxVariables, len(s.Variables))
+	}
+	return s, nil
+}
+
+// Validate はモデルを保存する前に目的関数・制約・パラメータの書式（JSON・式の構文と型）を検査する
+// ParseSpec と違い、決定変数が無いモデルや目的関数が空のモデルも許す
+func Validate(objective, constraints, parameters string) error {
+	_, err := parseSpec(objective, constraints, parameters, true)
+	return err
+}
+
+// parseSpec は draft の場合、空の目的関数を許す
+func parseSpec(objective, constraints, parameters string, draft bool) (*Spec, error) {
+	s := &Spec{
+		Constraints: map[string]expression.Node{},
+		Derived:     map[string]expression.Node{},
+		Constants:   map[string]float64{},
+	}
+	if !draft || strings.TrimSpace(objective) != "" {
+		obj, err := expression.ParseObjective(objective)
+		if err != nil {
+			return nil, fmt.Errorf("objective_function: %w", err)
+		}
+		s.Objective = obj.Expr
+		for i, n := range obj.Constraints {
+			s.Constraints[fmt.Sprintf("subject_to_%d", i+1)] = n
+		}
+	}
+	if err := s.parseParameters(parameters); err != nil {
+		return nil, err
+	}
+	if err := s.parseConstraints(constraints); err != nil {
+		return nil, err
+	}
+	sort.Slice(s.Variables, func(i, j int) bool { return s.Variables[i].Name < s.Variables[j].Name })
+	return s, nil
+}
+
+func (s *Spec) parseParameters(raw string) error {
+	fields, err := decodeObject(raw)
+	if err != nil {
+		return fmt.Errorf("parameters: %w", err)
+	}
+	for name, v := range fields {
+		switch val := v.(type) {
+		case float64:
+			s.Constants[name] = val
+		case map[string]interface{}:
+			if src, ok := val["expr"].(string); ok {
+				n, err := parseTyped(src, expression.TypeNumber)
+				if err != nil {
+					return fmt.Errorf("parameters.%s: %w", name, err)
+				}
+				s.Derived[name] = n
+				continue
+			}
+			min, okMin := val["min"].(float64)
+			max, okMax := val["max"].(float64)
+			if !okMin || !okMax {
+				continue
+			}
+			if min >= max {
+				return fmt.Errorf("parameters.%s: min must be less than max", name)
+			}
+			variable := Variable{Name: name, Min: min, Max: max}
+			if step, ok := val["step"].(float64); ok {
+				if step <= 0 {
+					return fmt.Errorf("parameters.%s: step must be positive", name)
+				}
+				variable.Step = step
+			}
+			if initial, ok := val["initial"].(float64); ok {
+				if initial < min || initial > max {
+					return fmt.Errorf("parameters.%s: initial must be within [min, max]", name)
+				}
+				variable.Initial = &initial
+			}
+			s.Variables = append(s.Variables, variable)
+		}
+	}
+	return nil
+}
+
+func (s *Spec) parseConstraints(raw string) error {
+	fields, err := decodeObject(raw)
+	if err != nil {
+		return fmt.Errorf("constraints: %w", err)
+	}
+	for name, v := range fields {
+		switch val := v.(type) {
+		case float64:
+			s.Constants[name] = val
+		case string:
+			n, err := parseTyped(val, expression.TypeBool)
+			if err != nil {
+				return fmt.Errorf("constraints.%s: %w", name, err)
+			}
+			s.Constraints[name] = n
+		}
+	}
+	return nil
+}
+
+func parseTyped(src string, want expression.Type) (expression.Node, error) {
+	n, err := expression.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := expression.Check(n, want); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func decodeObject(raw string) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if strings.TrimSpace(raw) == "" {
+		return fields, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return nil, fmt.Errorf("must be a JSON object: %v", err)
+	}
+	return fields, nil
+}
+
+// Bind は入力値を束縛し、すべての式が評価できることを確認する
+// 入力値は同名の定数より優先する。決定変数・派生変数と同名の入力はエラーとする
+func (s *Spec) Bind(inputs map[string]float64) (*Problem, error) {
+	for name := range inputs {
+		if _, ok := s.Derived[name]; ok || s.isVariable(name) {
+			return nil, fmt.Errorf("input %q is computed by the model and cannot be given", name)
+		}
+	}
+	p := &Problem{spec: s, inputs: inputs}
+
+	known := func(name string) bool {
+		_, isInput := inputs[name]
+		_, isConst := s.Constants[name]
+		_, isDerived := s.Derived[name]
+		return isInput || isConst || isDerived || s.isVariable(name)
+	}
+	nodes := []expression.Node{s.Objective}
+	for _, n := range s.Constraints {
+		nodes = append(nodes, n)
+	}
+	for _, n := range s.Derived {
+		nodes = append(nodes, n)
+	}
+	var missing []string
+	for _, name := range expression.Variables(nodes...) {
+		if !known(name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unbound variables: %s", strings.Join(missing, ", "))
+	}
+	// 派生変数の循環参照は探索前に検出する
+	if _, err := p.Evaluate(p.Start(nil)); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *Spec) isVariable(name string) bool {
+	for _, v := range s.Variables {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Problem は入力値を束縛した最適化問題
+type Problem struct {
+	spec   *Spec
+	inputs map[string]float64
+}
+
+// Evaluation は1点での評価結果
+type Evaluation struct {
+	Objective  float64            `json:"objective"`
+	Violation  float64            `json:"violation"`
+	Feasible   bool               `json:"feasible"`
+	Violations map[string]float64 `json:"violations,omitempty"`
+	Derived    map[string]float64 `json:"derived,omitempty"`
+}
+
+// Variables は決定変数を名前順で返す
+func (p *Problem) Variables() []Variable {
+	return p.spec.Variables
+}
+
+// Start は探索の初期点を返す。overrides > 変数の initial > 範囲の中央 の順に使う
+func (p *Problem) Start(overrides map[string]float64) []float64 {
+	x := make([]float64, len(p.spec.Variables))
+	for i, v := range p.spec.Variables {
+		switch {
+		case overrides != nil && hasKey(overrides, v.Name):
+			x[i] = math.Min(math.Max(overrides[v.Name], v.Min), v.Max)
+		case v.Initial != nil:
+			x[i] = *v.Initial
+		default:
+			x[i] = (v.Min + v.Max) / 2
+		}
+	}
+	return x
+}
+
+// Point は決定変数の値を名前付きで返す
+func (p *Problem) Point(x []float64) map[string]float64 {
+	point := make(map[string]float64, len(x))
+	for i, v := range p.spec.Variables {
+		point[v.Name] = x[i]
+	}
+	return point
+}
+
+// Evaluate は x での目的関数値と制約違反を計算する
+func (p *Problem) Evaluate(x []float64) (*Evaluation, error) {
+	env := &env{problem: p, point: p.Point(x), derived: map[string]float64{}, visiting: map[string]bool{}}
+	obj, err := p.spec.Objective.Eval(env)
+	if err != nil {
+		return nil, fmt.Errorf("objective_function: %w", err)
+	}
+	e := &Evaluation{Objective: obj, Feasible: true}
+	for name, c := range p.spec.Constraints {
+		v, err := expression.Violation(c, env)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %s: %w", name, err)
+		}
+		if v > FeasibilityTolerance {
+			if e.Violations == nil {
+				e.Violations = map[string]float64{}
+			}
+			e.Violations[name] = v
+			e.Violation += v
+			e.Feasible = false
+		}
+	}
+	// 制約が参照しない派生変数も結果に含める
+	for name := range p.spec.Derived {
+		if _, err := env.Lookup(name); err != nil {
+			return nil, err
+		}
+	}
+	if len(env.derived) > 0 {
+		e.Derived = env.derived
+	}
+	return e, nil
+}
+
+// Penalized は制約違反をペナルティとして加えた目的関数
+func (p *Problem) Penalized(x []float64) (float64, error) {
+	e, err := p.Evaluate(x)
+	if err != nil {
+		return 0, err
+	}
+	return e.Objective + PenaltyWeight*e.Violation, nil
+}
+
+// env は決定変数・入力値・定数・派生変数を解決する
+type env struct {
+	problem  *Problem
+	point    map[string]float64
+	derived  map[string]float64
+	visiting map[string]bool
+}
+
+func (e *env) Lookup(name string) (float64, error) {
+	if v, ok := e.point[name]; ok {
+		return v, nil
+	}
+	if v, ok := e.problem.inputs[name]; ok {
+		return v, nil
+	}
+	if v, ok := e.problem.spec.Constants[name]; ok {
+		return v, nil
+	}
+	if n, ok := e.problem.spec.Derived[name]; ok {
+		if v, ok := e.derived[name]; ok {
+			return v, nil
+		}
+		if e.visiting[name] {
+			return 0, fmt.Errorf("parameters.%s: circular reference", name)
+		}
+		e.visiting[name] = true
+		v, err := n.Eval(e)
+		delete(e.visiting, name)
+		if err != nil {
+			return 0, fmt.Errorf("parameters.%s: %w", name, err)
+		}
+		e.derived[name] = v
+		return v, nil
+	}
+	return expression.Vars(nil).Lookup(name)
+}
+
+func hasKey(m map[string]float64, key string) bool {
+	_, ok := m[key]
+	return ok
+}
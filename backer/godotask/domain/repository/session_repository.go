@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/godotask/domain/entity"
+)
+
+type SessionRepository interface {
+	Create(session *entity.Session) error
+	FindByID(id uint) (*entity.Session, error)
+	ListActiveByUser(userID uint, now time.Time) ([]entity.Session, error)
+	Touch(id uint, usedAt, expiresAt time.Time) error
+	Revoke(id uint, at time.Time) error
+	RevokeAllByUser(userID uint, at time.Time) (int64, error)
+
+	CreateRefreshToken(token *entity.RefreshToken) error
+	FindRefreshTokenByHash(hash string) (*entity.RefreshToken, error)
+	// MarkRefreshTokenUsed は未使用のトークンのみを使用済みにし、更新できたかを返す
+	MarkRefreshTokenUsed(id uint, at time.Time) (bool, error)
+}
@@ -11,4 +11,7 @@ type UserRepository interface {
 	Create(user *entity.User) error
 	FindByEmail(email string) (*entity.User, error)
 	FindByID(id uint) (*entity.User, error)
+	List() ([]entity.User, error)
+	CountByRole(role string) (int64, error)
+	UpdateRole(id uint, role string) error
 }
\ No newline at end of file
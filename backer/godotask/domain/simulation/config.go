@@ -0,0 +1,105 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Config はシミュレーションのパラメータ
+// TeachingFreeControl の各 JSON 設定から読み取り、指定が無いキーは既定値を使う
+//
+//   - vision_system:  position_noise_mm（位置認識誤差の標準偏差）, detection_rate（検出率）
+//   - force_control:  force_limit（把持力の上限 N）, compliance（0〜1。大きいほど位置ずれを吸収する）
+//   - ai_model:       initial_bias_mm（学習前の系統誤差）, learning_rate（試行ごとの系統誤差の減衰率）, speed_mm_s（移動速度）
+//   - error_recovery: strategy（retry / none）, max_retries（復帰動作の回数）
+type Config struct {
+	PositionNoise float64 `json:"position_noise_mm"`
+	DetectionRate float64 `json:"detection_rate"`
+	ForceLimit    float64 `json:"force_limit"`
+	Compliance    float64 `json:"compliance"`
+	InitialBias   float64 `json:"initial_bias_mm"`
+	LearningRate  float64 `json:"learning_rate"`
+	Speed         float64 `json:"speed_mm_s"`
+	Strategy      string  `json:"strategy"`
+	MaxRetries    int     `json:"max_retries"`
+}
+
+// 復帰戦略
+const (
+	StrategyRetry = "retry"
+	StrategyNone  = "none"
+)
+
+// MaxRetries は復帰動作の回数の上限
+const MaxRetries = 5
+
+// DefaultConfig はキーが指定されなかった場合の値
+func DefaultConfig() Config {
+	return Config{
+		PositionNoise: 2.0,
+		DetectionRate: 0.98,
+		ForceLimit:    20,
+		Compliance:    0.5,
+		InitialBias:   5.0,
+		LearningRate:  0.1,
+		Speed:         250,
+		Strategy:      StrategyRetry,
+		MaxRetries:    1,
+	}
+}
+
+// ParseConfig は制御設定の JSON からパラメータを読み取り、範囲を検証する
+func ParseConfig(vision, force, ai, recovery map[string]interface{}) (Config, error) {
+	c := DefaultConfig()
+	sections := []struct {
+		name   string
+		fields map[string]interface{}
+		keys   []string
+	}{
+		{"vision_system", vision, []string{"position_noise_mm", "detection_rate"}},
+		{"force_control", force, []string{"force_limit", "compliance"}},
+		{"ai_model", ai, []string{"initial_bias_mm", "learning_rate", "speed_mm_s"}},
+		{"error_recovery", recovery, []string{"strategy", "max_retries"}},
+	}
+	// 各セクションの対象キーだけを Config に重ねる
+	for _, s := range sections {
+		picked := map[string]interface{}{}
+		for _, k := range s.keys {
+			if v, ok := s.fields[k]; ok {
+				picked[k] = v
+			}
+		}
+		raw, err := json.Marshal(picked)
+		if err != nil {
+			return c, fmt.Errorf("%s: %v", s.name, err)
+		}
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return c, fmt.Errorf("%s: %v", s.name, err)
+		}
+	}
+	return c, c.validate()
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.PositionNoise < 0:
+		return fmt.Errorf("vision_system.position_noise_mm must not be negative")
+	case c.DetectionRate < 0 || c.DetectionRate > 1:
+		return fmt.Errorf("vision_system.detection_rate must be within [0, 1]")
+	case c.ForceLimit <= 0:
+		return fmt.Errorf("force_control.force_limit must be positive")
+	case c.Compliance < 0 || c.Compliance > 1:
+		return fmt.Errorf("force_control.compliance must be within [0, 1]")
+	case c.InitialBias < 0:
+		return fmt.Errorf("ai_model.initial_bias_mm must not be negative")
+	case c.LearningRate < 0:
+		return fmt.Errorf("ai_model.learning_rate must not be negative")
+	case c.Speed <= 0:
+		return fmt.Errorf("ai_model.speed_mm_s must be positive")
+	case c.Strategy != StrategyRetry && c.Strategy != StrategyNone:
+		return fmt.Errorf("error_recovery.strategy must be %s or %s", StrategyRetry, StrategyNone)
+	case c.MaxRetries < 0 || c.MaxRetries > MaxRetries:
+		return fmt.Errorf("error_recovery.max_retries must be within [0, %d]", MaxRetries)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func successRate(trials []Trial) float64 {
+	n := 0
+	for _, t := range trials {
+		if t.Outcome == "success" {
+			n++
+		}
+	}
+	return float64(n) / float64(len(trials))
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(
+		map[string]interface{}{"camera": "on", "position_noise_mm": 1.5},
+		map[string]interface{}{"force_limit": 10},
+		nil,
+		map[string]interface{}{"strategy": "none"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, cfg.PositionNoise)
+	assert.Equal(t, 10.0, cfg.ForceLimit)
+	assert.Equal(t, DefaultConfig().Speed, cfg.Speed)
+	assert.Equal(t, StrategyNone, cfg.Strategy)
+
+	// 別セクションのキーは読まない
+	cfg, err = ParseConfig(map[string]interface{}{"force_limit": 1}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultConfig().ForceLimit, cfg.ForceLimit)
+
+	for _, tc := range []struct {
+		vision, force, ai, recovery map[string]interface{}
+		msg                         string
+	}{
+		{vision: map[string]interface{}{"detection_rate": 1.5}, msg: "detection_rate"},
+		{force: map[string]interface{}{"compliance": -1}, msg: "compliance"},
+		{force: map[string]interface{}{"force_limit": "strong"}, msg: "force_control"},
+		{ai: map[string]interface{}{"speed_mm_s": 0}, msg: "speed_mm_s"},
+		{recovery: map[string]interface{}{"strategy": "pray"}, msg: "strategy"},
+		{recovery: map[string]interface{}{"max_retries": 9}, msg: "max_retries"},
+	} {
+		_, err := ParseConfig(tc.vision, tc.force, tc.ai, tc.recovery)
+		if assert.Error(t, err, tc.msg) {
+			assert.Contains(t, err.Error(), tc.msg)
+		}
+	}
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+	cfg := DefaultConfig()
+	a, err := Run(cfg, 50, 7)
+	assert.NoError(t, err)
+	b, err := Run(cfg, 50, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	c, err := Run(cfg, 50, 8)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, c)
+
+	// 試行の開始時刻は前の試行の所要時間だけ進む
+	assert.Zero(t, a[0].Offset)
+	assert.Greater(t, a[1].Offset, a[0].Offset)
+	for _, tr := range a {
+		assert.Greater(t, tr.Duration, 0.0)
+		assert.Contains(t, tr.Detail, "position_error_mm")
+	}
+}
+
+func TestRunLearningCurve(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.InitialBias = 20
+	cfg.LearningRate = 0.02
+	cfg.ForceLimit = 100
+	cfg.DetectionRate = 1
+	trials, err := Run(cfg, 200, 1)
+	assert.NoError(t, err)
+
+	// 系統誤差が学習で減るため、後半の成功率が前半より高い
+	early, late := successRate(trials[:50]), successRate(trials[150:])
+	assert.Less(t, early, 0.5)
+	assert.Greater(t, late, 0.9)
+	for _, tr := range trials {
+		if tr.Outcome == "failure" {
+			assert.Equal(t, ErrorGraspMiss, tr.ErrorType)
+			assert.Equal(t, RecoveryRegrasp, tr.RecoveryAction)
+		}
+	}
+}
+
+func TestRunForceLimitAndRecovery(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.InitialBias = 0
+	cfg.PositionNoise = 0
+	cfg.DetectionRate = 1
+	cfg.ForceLimit = 5
+	trials, err := Run(cfg, 100, 3)
+	assert.NoError(t, err)
+
+	slips := 0
+	for _, tr := range trials {
+		if tr.Outcome == "failure" {
+			slips++
+			assert.Equal(t, ErrorGraspSlip, tr.ErrorType)
+			// 把持力不足は復帰できない
+			assert.False(t, tr.Recovered)
+			assert.Equal(t, 1, tr.Detail["retries"])
+		} else {
+			assert.LessOrEqual(t, tr.Detail["required_force_n"], 5.0)
+		}
+	}
+	assert.Greater(t, slips, 50)
+
+	// 検出失敗は再スキャンで復帰できる
+	cfg.ForceLimit = 100
+	cfg.DetectionRate = 0.5
+	cfg.MaxRetries = 3
+	trials, err = Run(cfg, 100, 3)
+	assert.NoError(t, err)
+	recovered := 0
+	for _, tr := range trials {
+		if tr.ErrorType == ErrorDetectionFailed && tr.Recovered {
+			recovered++
+		}
+	}
+	assert.Greater(t, recovered, 20)
+
+	// strategy: none では復帰動作をしない
+	cfg.Strategy = StrategyNone
+	trials, err = Run(cfg, 100, 3)
+	assert.NoError(t, err)
+	for _, tr := range trials {
+		assert.False(t, tr.Recovered)
+		assert.Empty(t, tr.RecoveryAction)
+	}
+}
+
+func TestRunRejectsInvalidTrials(t *testing.T) {
+	_, err := Run(DefaultConfig(), 0, 1)
+	assert.Error(t, err)
+	_, err = Run(DefaultConfig(), MaxTrials+1, 1)
+	assert.Error(t, err)
+}
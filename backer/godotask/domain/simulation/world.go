@@ -0,0 +1,153 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// MaxTrials は1回のシミュレーションで実行できる試行数の上限
+const MaxTrials = 10000
+
+// エラー種別と復帰動作
+const (
+	ErrorDetectionFailed = "detection_failed"
+	ErrorGraspMiss       = "grasp_miss"
+	ErrorGraspSlip       = "grasp_slip"
+
+	RecoveryRescan  = "rescan"
+	RecoveryRegrasp = "regrasp"
+)
+
+// 作業台（2D）の寸法と動作の定数
+const (
+	tableWidth  = 600.0 // mm
+	tableDepth  = 400.0 // mm
+	margin      = 50.0  // 対象物が置かれない縁の幅 mm
+	gripWidth   = 10.0  // 位置ずれの許容量の基準 mm
+	gravity     = 9.81
+	friction    = 0.8
+	safety      = 2.0
+	handlingSec = 1.0 // 把持と解放にかかる時間
+	rescanSec   = 0.5
+)
+
+var (
+	home  = point{0, 0}
+	place = point{500, 200}
+)
+
+type point struct{ X, Y float64 }
+
+func (p point) dist(q point) float64 { return math.Hypot(p.X-q.X, p.Y-q.Y) }
+
+// Trial は1回の試行結果。実機のセルが報告する試行ログと同じ項目を持つ
+type Trial struct {
+	Outcome        string                 `json:"outcome"` // success, failure
+	ErrorType      string                 `json:"error_type,omitempty"`
+	RecoveryAction string                 `json:"recovery_action,omitempty"`
+	Recovered      bool                   `json:"recovered"`
+	Duration       float64                `json:"duration"` // 秒
+	Detail         map[string]interface{} `json:"detail"`
+	// Offset はシミュレーション開始からこの試行の開始までの経過時間
+	Offset time.Duration `json:"-"`
+}
+
+// Run は 2D の作業台でピックアンドプレースを trials 回実行する
+// 同じ Config・trials・seed なら常に同じ結果になる
+//
+// 各試行では対象物（位置・質量）をランダムに置き、次の順で判定する
+//  1. 検出: detection_rate の確率で検出できる
+//  2. 把持位置: 系統誤差 initial_bias × exp(-learning_rate × 試行番号) に正規ノイズを加えた位置ずれが
+//     許容量 gripWidth × (0.5 + compliance) 以内
+//  3. 保持: 必要な把持力（質量 × g × 安全率 / 摩擦係数）が force_limit 以下
+//
+// 失敗した場合、strategy が retry なら max_retries 回まで復帰動作を行う（系統誤差は学習済みとしてノイズのみで再判定する）
+func Run(cfg Config, trials int, seed int64) ([]Trial, error) {
+	if trials <= 0 || trials > MaxTrials {
+		return nil, fmt.Errorf("trials must be within [1, %d]", MaxTrials)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(seed))
+	tolerance := gripWidth * (0.5 + cfg.Compliance)
+	out := make([]Trial, 0, trials)
+	var elapsed time.Duration
+
+	for k := 0; k < trials; k++ {
+		obj := point{
+			X: margin + rng.Float64()*(tableWidth-2*margin),
+			Y: margin + rng.Float64()*(tableDepth-2*margin),
+		}
+		mass := 0.1 + rng.Float64()*0.9
+		requiredForce := mass * gravity * safety / friction
+		bias := cfg.InitialBias * math.Exp(-cfg.LearningRate*float64(k))
+		// 系統誤差は x 方向にかかるものとする
+		errX := bias + rng.NormFloat64()*cfg.PositionNoise
+		errY := rng.NormFloat64() * cfg.PositionNoise
+		posErr := math.Hypot(errX, errY)
+
+		t := Trial{
+			Outcome:  "success",
+			Duration: (home.dist(obj)+obj.dist(place)+place.dist(home))/cfg.Speed + handlingSec,
+			Detail: map[string]interface{}{
+				"trial":             k + 1,
+				"object_x_mm":       round(obj.X),
+				"object_y_mm":       round(obj.Y),
+				"object_mass_kg":    round(mass),
+				"position_error_mm": round(posErr),
+				"required_force_n":  round(requiredForce),
+			},
+			Offset: elapsed,
+		}
+
+		switch {
+		case rng.Float64() >= cfg.DetectionRate:
+			t.fail(ErrorDetectionFailed, RecoveryRescan)
+			t.recover(cfg, func() bool { return rng.Float64() < cfg.DetectionRate }, rescanSec)
+		case posErr > tolerance:
+			t.fail(ErrorGraspMiss, RecoveryRegrasp)
+			t.recover(cfg, func() bool {
+				return math.Hypot(rng.NormFloat64()*cfg.PositionNoise, rng.NormFloat64()*cfg.PositionNoise) <= tolerance
+			}, handlingSec)
+		case requiredForce > cfg.ForceLimit:
+			// 把持力が足りない場合は持ち直しても保持できない
+			t.fail(ErrorGraspSlip, RecoveryRegrasp)
+			t.recover(cfg, func() bool { return false }, handlingSec)
+		}
+		t.Duration = round(t.Duration)
+		elapsed += time.Duration(t.Duration * float64(time.Second))
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (t *Trial) fail(errorType, action string) {
+	t.Outcome = "failure"
+	t.ErrorType = errorType
+	t.RecoveryAction = action
+}
+
+// recover は復帰動作を max_retries 回まで試す。復帰動作ごとに cost 秒かかる
+func (t *Trial) recover(cfg Config, attempt func() bool, cost float64) {
+	if cfg.Strategy != StrategyRetry || cfg.MaxRetries == 0 {
+		t.RecoveryAction = ""
+		return
+	}
+	retries := 0
+	for retries < cfg.MaxRetries {
+		retries++
+		t.Duration += cost
+		if attempt() {
+			t.Recovered = true
+			break
+		}
+	}
+	t.Detail["retries"] = retries
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
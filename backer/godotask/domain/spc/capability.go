@@ -0,0 +1,73 @@
+package spc
+
+import "math"
+
+// Capability は工程能力指数
+// Cp / Cpk は群内変動（管理図から推定した σ）、Pp / Ppk は全体の標準偏差による
+// 片側規格の場合は Cp / Pp を計算できないため nil になる
+type Capability struct {
+	LSL         *float64 `json:"lsl"`
+	USL         *float64 `json:"usl"`
+	Mean        float64  `json:"mean"`
+	SigmaWithin float64  `json:"sigma_within"`
+	SigmaTotal  float64  `json:"sigma_total"`
+	Cp          *float64 `json:"cp"`
+	Cpk         *float64 `json:"cpk"`
+	Pp          *float64 `json:"pp"`
+	Ppk         *float64 `json:"ppk"`
+	// OutOfSpec は規格外の測定値の数
+	OutOfSpec int `json:"out_of_spec"`
+}
+
+// ComputeCapability は規格限界に対する工程能力を計算する
+// 規格限界が1つも無い場合、または σ が 0 の場合は指数を計算しない
+func ComputeCapability(values []float64, sigmaWithin float64, lsl, usl *float64) Capability {
+	c := Capability{LSL: lsl, USL: usl, SigmaWithin: sigmaWithin}
+	if len(values) == 0 {
+		return c
+	}
+	c.Mean = round(average(values))
+	c.SigmaTotal = round(stdDev(values))
+	for _, v := range values {
+		if (lsl != nil && v < *lsl) || (usl != nil && v > *usl) {
+			c.OutOfSpec++
+		}
+	}
+	c.Cp, c.Cpk = indices(c.Mean, sigmaWithin, lsl, usl)
+	c.Pp, c.Ppk = indices(c.Mean, c.SigmaTotal, lsl, usl)
+	return c
+}
+
+func indices(mean, sigma float64, lsl, usl *float64) (*float64, *float64) {
+	if sigma <= 0 || (lsl == nil && usl == nil) {
+		return nil, nil
+	}
+	var p, pk *float64
+	if lsl != nil && usl != nil {
+		v := round((*usl - *lsl) / (6 * sigma))
+		p = &v
+	}
+	k := math.Inf(1)
+	if usl != nil {
+		k = math.Min(k, (*usl-mean)/(3*sigma))
+	}
+	if lsl != nil {
+		k = math.Min(k, (mean-*lsl)/(3*sigma))
+	}
+	k = round(k)
+	pk = &k
+	return p, pk
+}
+
+// stdDev は標本標準偏差（不偏）
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := average(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
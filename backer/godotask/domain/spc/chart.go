@@ -0,0 +1,182 @@
+package spc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// 管理図の種類
+const (
+	ChartIndividuals = "individuals"
+	ChartXbarR       = "xbar_r"
+)
+
+// ErrInsufficientData は管理限界を計算するにはデータが足りない場合のエラー
+var ErrInsufficientData = errors.New("not enough measurements to compute control limits")
+
+// 管理図係数（サブグループサイズ n = 2〜10）
+var (
+	factorA2 = map[int]float64{2: 1.880, 3: 1.023, 4: 0.729, 5: 0.577, 6: 0.483, 7: 0.419, 8: 0.373, 9: 0.337, 10: 0.308}
+	factorD3 = map[int]float64{2: 0, 3: 0, 4: 0, 5: 0, 6: 0, 7: 0.076, 8: 0.136, 9: 0.184, 10: 0.223}
+	factorD4 = map[int]float64{2: 3.267, 3: 2.574, 4: 2.282, 5: 2.114, 6: 2.004, 7: 1.924, 8: 1.864, 9: 1.816, 10: 1.777}
+	factord2 = map[int]float64{2: 1.128, 3: 1.693, 4: 2.059, 5: 2.326, 6: 2.534, 7: 2.704, 8: 2.847, 9: 2.970, 10: 3.078}
+)
+
+// MinSubgroupSize / MaxSubgroupSize は X-bar/R 管理図で扱えるサブグループサイズ
+const (
+	MinSubgroupSize = 2
+	MaxSubgroupSize = 10
+)
+
+// Point は管理図上の1点
+type Point struct {
+	Index    int     `json:"index"`
+	Value    float64 `json:"value"`
+	Subgroup string  `json:"subgroup,omitempty"`
+	// Rules はこの点で成立した Western Electric ルールの番号
+	Rules []int `json:"rules,omitempty"`
+}
+
+// Limits は管理図の中心線と管理限界
+type Limits struct {
+	CenterLine float64 `json:"center_line"`
+	UCL        float64 `json:"ucl"`
+	LCL        float64 `json:"lcl"`
+}
+
+// Chart は管理図。Dispersion は X-bar に対する R 管理図、個々値に対する移動範囲管理図
+type Chart struct {
+	Type       string      `json:"type"`
+	Limits     Limits      `json:"limits"`
+	Points     []Point     `json:"points"`
+	Violations []Violation `json:"violations"`
+	Dispersion *Chart      `json:"dispersion,omitempty"`
+	// SigmaWithin は群内変動から推定した工程の標準偏差（工程能力指数の計算に使う）
+	SigmaWithin float64 `json:"sigma_within"`
+}
+
+// IndividualsChart は個々値（I-MR）管理図を作る
+// σ は移動範囲の平均 / d2 (n=2) で推定する
+func IndividualsChart(values []float64, labels []string) (*Chart, error) {
+	if len(values) < 2 {
+		return nil, ErrInsufficientData
+	}
+	mean := average(values)
+	ranges := make([]float64, 0, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		ranges = append(ranges, math.Abs(values[i]-values[i-1]))
+	}
+	mrBar := average(ranges)
+	sigma := mrBar / factord2[2]
+
+	chart := &Chart{
+		Type:        ChartIndividuals,
+		Limits:      Limits{CenterLine: mean, UCL: mean + 3*sigma, LCL: mean - 3*sigma},
+		Points:      toPoints(values, labels),
+		SigmaWithin: sigma,
+	}
+	chart.Violations = WesternElectric(chart.Points, mean, sigma)
+
+	chart.Dispersion = &Chart{
+		Type:   "moving_range",
+		Limits: Limits{CenterLine: mrBar, UCL: factorD4[2] * mrBar, LCL: 0},
+		Points: toPoints(ranges, nil),
+	}
+	chart.Dispersion.Violations = beyondLimits(chart.Dispersion)
+	roundChart(chart)
+	return chart, nil
+}
+
+// XbarRChart はサブグループの平均と範囲による X-bar/R 管理図を作る
+// サブグループはすべて同じサイズ（2〜10）でなければならない
+func XbarRChart(subgroups [][]float64, labels []string) (*Chart, error) {
+	if len(subgroups) < 2 {
+		return nil, ErrInsufficientData
+	}
+	n := len(subgroups[0])
+	if n < MinSubgroupSize || n > MaxSubgroupSize {
+		return nil, fmt.Errorf("subgroup size must be between %d and %d, got %d", MinSubgroupSize, MaxSubgroupSize, n)
+	}
+	means := make([]float64, 0, len(subgroups))
+	ranges := make([]float64, 0, len(subgroups))
+	for i, g := range subgroups {
+		if len(g) != n {
+			return nil, fmt.Errorf("subgroup %d has %d measurements, expected %d", i, len(g), n)
+		}
+		lo, hi := g[0], g[0]
+		for _, v := range g[1:] {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+		means = append(means, average(g))
+		ranges = append(ranges, hi-lo)
+	}
+	grandMean := average(means)
+	rBar := average(ranges)
+	// X-bar の1σ = A2・R̄ / 3
+	sigmaXbar := factorA2[n] * rBar / 3
+
+	chart := &Chart{
+		Type:        ChartXbarR,
+		Limits:      Limits{CenterLine: grandMean, UCL: grandMean + factorA2[n]*rBar, LCL: grandMean - factorA2[n]*rBar},
+		Points:      toPoints(means, labels),
+		SigmaWithin: rBar / factord2[n],
+	}
+	chart.Violations = WesternElectric(chart.Points, grandMean, sigmaXbar)
+
+	chart.Dispersion = &Chart{
+		Type:   "range",
+		Limits: Limits{CenterLine: rBar, UCL: factorD4[n] * rBar, LCL: factorD3[n] * rBar},
+		Points: toPoints(ranges, labels),
+	}
+	chart.Dispersion.Violations = beyondLimits(chart.Dispersion)
+	roundChart(chart)
+	return chart, nil
+}
+
+// beyondLimits はばらつきの管理図について管理限界外の点（ルール1）のみを判定する
+func beyondLimits(c *Chart) []Violation {
+	violations := []Violation{}
+	for i := range c.Points {
+		if c.Points[i].Value > c.Limits.UCL || c.Points[i].Value < c.Limits.LCL {
+			c.Points[i].Rules = append(c.Points[i].Rules, RuleBeyondLimits)
+			violations = append(violations, Violation{Rule: RuleBeyondLimits, Index: i, Description: ruleDescriptions[RuleBeyondLimits]})
+		}
+	}
+	return violations
+}
+
+func toPoints(values []float64, labels []string) []Point {
+	points := make([]Point, len(values))
+	for i, v := range values {
+		points[i] = Point{Index: i, Value: v}
+		if i < len(labels) {
+			points[i].Subgroup = labels[i]
+		}
+	}
+	return points
+}
+
+func roundChart(c *Chart) {
+	if c == nil {
+		return
+	}
+	c.Limits = Limits{CenterLine: round(c.Limits.CenterLine), UCL: round(c.Limits.UCL), LCL: round(c.Limits.LCL)}
+	c.SigmaWithin = round(c.SigmaWithin)
+	for i := range c.Points {
+		c.Points[i].Value = round(c.Points[i].Value)
+	}
+	roundChart(c.Dispersion)
+}
+
+func average(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
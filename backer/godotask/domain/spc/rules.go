@@ -0,0 +1,87 @@
+package spc
+
+// Western Electric ルールの番号
+const (
+	// RuleBeyondLimits: 1点が 3σ（管理限界）の外にある
+	RuleBeyondLimits = 1
+	// RuleTwoOfThree: 連続する3点中2点が同じ側の 2σ の外にある
+	RuleTwoOfThree = 2
+	// RuleFourOfFive: 連続する5点中4点が同じ側の 1σ の外にある
+	RuleFourOfFive = 3
+	// RuleEightInRow: 連続する8点が中心線の同じ側にある
+	RuleEightInRow = 4
+)
+
+var ruleDescriptions = map[int]string{
+	RuleBeyondLimits: "point beyond 3 sigma control limit",
+	RuleTwoOfThree:   "2 of 3 consecutive points beyond 2 sigma on the same side",
+	RuleFourOfFive:   "4 of 5 consecutive points beyond 1 sigma on the same side",
+	RuleEightInRow:   "8 consecutive points on the same side of the center line",
+}
+
+// Violation はルールが成立した点。Index はパターンを完成させた点の位置
+type Violation struct {
+	Rule        int    `json:"rule"`
+	Index       int    `json:"index"`
+	Description string `json:"description"`
+}
+
+// WesternElectric は Western Electric ルール 1〜4 を判定し、成立した点に印をつける
+// sigma は打点する統計量の標準偏差（X-bar の場合は平均の標準偏差）
+func WesternElectric(points []Point, center, sigma float64) []Violation {
+	violations := []Violation{}
+	if sigma <= 0 {
+		return violations
+	}
+	// 各点の中心からのずれ（σ 単位）
+	z := make([]float64, len(points))
+	for i, p := range points {
+		z[i] = (p.Value - center) / sigma
+	}
+
+	mark := func(rule, i int) {
+		points[i].Rules = append(points[i].Rules, rule)
+		violations = append(violations, Violation{Rule: rule, Index: i, Description: ruleDescriptions[rule]})
+	}
+
+	for i := range points {
+		if z[i] > 3 || z[i] < -3 {
+			mark(RuleBeyondLimits, i)
+		}
+		if i >= 2 && countBeyond(z[i-2:i+1], 2) >= 2 && beyond(z[i], 2) {
+			mark(RuleTwoOfThree, i)
+		}
+		if i >= 4 && countBeyond(z[i-4:i+1], 1) >= 4 && beyond(z[i], 1) {
+			mark(RuleFourOfFive, i)
+		}
+		if i >= 7 && sameSide(z[i-7:i+1]) {
+			mark(RuleEightInRow, i)
+		}
+	}
+	return violations
+}
+
+// countBeyond は window の中で最後の点と同じ側に k σ を超えている点の数を返す
+func countBeyond(window []float64, k float64) int {
+	last := window[len(window)-1]
+	count := 0
+	for _, v := range window {
+		if (last > 0 && v > k) || (last < 0 && v < -k) {
+			count++
+		}
+	}
+	return count
+}
+
+func beyond(v, k float64) bool {
+	return v > k || v < -k
+}
+
+func sameSide(window []float64) bool {
+	for _, v := range window {
+		if (window[0] > 0) != (v > 0) || v == 0 {
+			return false
+		}
+	}
+	return true
+}
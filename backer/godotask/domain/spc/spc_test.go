@@ -0,0 +1,86 @@
+package spc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndividualsChart(t *testing.T) {
+	values := []float64{10, 12, 11, 13, 12, 11, 10, 12, 30}
+	chart, err := IndividualsChart(values, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ChartIndividuals, chart.Type)
+	assert.Greater(t, chart.Limits.UCL, chart.Limits.CenterLine)
+	// 最後の点は 3σ の外
+	assert.Contains(t, chart.Points[8].Rules, RuleBeyondLimits)
+	assert.Equal(t, "moving_range", chart.Dispersion.Type)
+	assert.Len(t, chart.Dispersion.Points, 8)
+
+	_, err = IndividualsChart([]float64{1}, nil)
+	assert.ErrorIs(t, err, ErrInsufficientData)
+}
+
+func TestXbarRChart(t *testing.T) {
+	subgroups := [][]float64{
+		{120, 121, 119, 120, 120},
+		{121, 120, 122, 119, 118},
+		{119, 120, 121, 120, 120},
+		{120, 122, 118, 121, 119},
+	}
+	chart, err := XbarRChart(subgroups, []string{"lot1", "lot2", "lot3", "lot4"})
+	assert.NoError(t, err)
+
+	assert.InDelta(t, 120.0, chart.Limits.CenterLine, 1e-6)
+	rBar := (2.0 + 4 + 2 + 4) / 4
+	assert.InDelta(t, 120+0.577*rBar, chart.Limits.UCL, 1e-6)
+	assert.InDelta(t, 2.114*rBar, chart.Dispersion.Limits.UCL, 1e-6)
+	assert.InDelta(t, rBar/2.326, chart.SigmaWithin, 1e-6)
+	assert.Equal(t, "lot2", chart.Points[1].Subgroup)
+	assert.Empty(t, chart.Violations)
+
+	_, err = XbarRChart([][]float64{{1, 2}, {1, 2, 3}}, nil)
+	assert.Error(t, err)
+	_, err = XbarRChart([][]float64{{1}, {2}}, nil)
+	assert.Error(t, err)
+}
+
+func TestWesternElectric(t *testing.T) {
+	points := func(values ...float64) []Point {
+		return toPoints(values, nil)
+	}
+	rulesOf := func(v []Violation) []int {
+		rules := []int{}
+		for _, x := range v {
+			rules = append(rules, x.Rule)
+		}
+		return rules
+	}
+
+	assert.Equal(t, []int{RuleBeyondLimits}, rulesOf(WesternElectric(points(0, 3.5), 0, 1)))
+	assert.Equal(t, []int{RuleTwoOfThree}, rulesOf(WesternElectric(points(2.5, 0, 2.2), 0, 1)))
+	assert.Equal(t, []int{RuleFourOfFive}, rulesOf(WesternElectric(points(1.5, 1.2, 0.5, 1.1, 1.3), 0, 1)))
+	assert.Equal(t, []int{RuleEightInRow}, rulesOf(WesternElectric(points(0.1, 0.2, 0.3, 0.1, 0.2, 0.5, 0.4, 0.1), 0, 1)))
+	// 反対側のずれは数えない
+	assert.Empty(t, WesternElectric(points(2.5, 0, -2.2), 0, 1))
+}
+
+func TestComputeCapability(t *testing.T) {
+	lsl, usl := 100.0, 140.0
+	values := []float64{118, 120, 122, 119, 121}
+	c := ComputeCapability(values, 2, &lsl, &usl)
+
+	assert.Equal(t, 120.0, c.Mean)
+	if assert.NotNil(t, c.Cp) && assert.NotNil(t, c.Cpk) {
+		assert.InDelta(t, 40.0/12, *c.Cp, 1e-6)
+		assert.InDelta(t, 20.0/6, *c.Cpk, 1e-6)
+	}
+	assert.Equal(t, 0, c.OutOfSpec)
+
+	// 片側規格では Cp は計算しない
+	oneSided := ComputeCapability([]float64{0.1, 0.2, 0.6}, 0.1, nil, &[]float64{0.5}[0])
+	assert.Nil(t, oneSided.Cp)
+	assert.NotNil(t, oneSided.Cpk)
+	assert.Equal(t, 1, oneSided.OutOfSpec)
+}
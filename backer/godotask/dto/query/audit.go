@@ -0,0 +1,13 @@
+package query
+
+import "time"
+
+// AuditLogFilter は監査ログ検索・エクスポート用の条件
+type AuditLogFilter struct {
+	UserID       *uint
+	ResourceType string
+	ResourceID   string
+	Method       string
+	From         *time.Time
+	To           *time.Time
+}
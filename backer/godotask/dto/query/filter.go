@@ -15,6 +15,8 @@ const (
 // QueryFilter は一覧・検索 API 用の共通 DTO
 type QueryFilter struct {
 	UserID   *uint
+	// 指定した場合はそのワークスペースに共有されたレコードのみ
+	WorkspaceID *uint
 	TaskID   *int
 	MemoryID *int
 
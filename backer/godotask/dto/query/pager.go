@@ -7,6 +7,7 @@ type PagerQuery struct {
 
 	// optional filters
 	UserID uint
+	WorkspaceID *uint
 	TaskID *int
 	Search *string
 }
@@ -12,4 +12,18 @@ var (
 		"無効なトークンです",
 		"",
 	)
-)
\ No newline at end of file
+
+	ErrAuthSessionRevoked = NewAppError(
+		AUTH_TOKEN_INVALID,
+		"無効なトークンです",
+		"session has been revoked",
+	)
+
+	// ErrAuthRefreshTokenReused はローテーション済みのリフレッシュトークンが再利用された場合のエラー
+	// 検出時はトークンファミリー（セッション）全体を失効させる
+	ErrAuthRefreshTokenReused = NewAppError(
+		AUTH_TOKEN_INVALID,
+		"無効なトークンです",
+		"refresh token reuse detected; session revoked",
+	)
+)
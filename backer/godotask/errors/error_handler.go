@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"log"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -23,6 +24,15 @@ func HandleError(c *gin.Context, err error) {
 	})
 }
 
+// ToAppError は err が AppError を含む場合はそれを返し、それ以外は code の AppError に変換する
+func ToAppError(err error, code ErrorCode, detail string) *AppError {
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
+		return appErr
+	}
+	return NewAppError(code, GetErrorMessage(code), detail)
+}
+
 // ErrorHandlerMiddleware はアプリケーション全体のエラーハンドリングを行うミドルウェア
 func ErrorHandlerMiddleware() gin.HandlerFunc {
 	return gin.RecoveryWithWriter(gin.DefaultWriter, func(c *gin.Context, err interface{}) {
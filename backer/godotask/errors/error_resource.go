@@ -0,0 +1,15 @@
+package errors
+
+var (
+	ErrResourceNotFound = NewAppError(
+		RES_NOT_FOUND,
+		"リソースが見つかりません",
+		"",
+	)
+
+	ErrResourceAccessDenied = NewAppError(
+		RES_ACCESS_DENIED,
+		"アクセスが拒否されました",
+		"",
+	)
+)
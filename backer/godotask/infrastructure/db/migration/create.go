@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const goTemplate = `package migration
+
+import "gorm.io/gorm"
+
+func init() {
+	register(Migration{
+		Version: %d,
+		Name:    %q,
+		Up: func(tx *gorm.DB) error {
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	})
+}
+`
+
+const sqlTemplate = "-- %04d_%s (%s)\n"
+
+// Create は dir（このパッケージのディレクトリ）に次の版のマイグレーションの雛形を書き、作ったファイルを返す
+// goMigration の場合は Go のファイル、それ以外は sql/ の up / down の SQL ファイルを作る
+func Create(dir string, existing []Migration, name string, goMigration bool) ([]string, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("migration name must match %s", namePattern)
+	}
+	var version int64 = 1
+	for _, m := range existing {
+		if m.Version >= version {
+			version = m.Version + 1
+		}
+	}
+
+	type file struct{ path, content string }
+	var files []file
+	if goMigration {
+		files = append(files, file{filepath.Join(dir, fmt.Sprintf("%04d_%s.go", version, name)), fmt.Sprintf(goTemplate, version, name)})
+	} else {
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, "sql", fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+			files = append(files, file{path, fmt.Sprintf(sqlTemplate, version, name, direction)})
+		}
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		if _, err := os.Stat(f.path); err == nil {
+			return nil, fmt.Errorf("%s already exists", f.path)
+		}
+		paths = append(paths, f.path)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f.path, []byte(f.content), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,304 @@
+// Package migration は番号付きのスキーママイグレーションを適用・巻き戻し、schema_migrations に記録する
+// マイグレーションは Go（このパッケージの init で register する）または sql/ の SQL ファイルで書く
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+// Migration は 1 つの版のスキーマ変更。Down が nil のマイグレーションは巻き戻せない
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// Status はマイグレーションの適用状況
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	// Missing は適用の記録があるが、このバイナリに無いマイグレーション（新しい版で適用された）
+	Missing bool `json:"missing,omitempty"`
+}
+
+var goMigrations []Migration
+
+// register は Go で書いたマイグレーションを登録する（各ファイルの init から呼ぶ）
+func register(m Migration) {
+	goMigrations = append(goMigrations, m)
+}
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// sqlFileName は <版>_<名前>[.<方言>].(up|down).sql。方言（postgres, sqlite）付きのファイルはその方言でだけ使う
+var sqlFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)(?:\.(postgres|sqlite))?\.(up|down)\.sql$`)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// sqlMigration は同じ版の SQL ファイル。キーは方言（共通は ""）
+type sqlMigration struct {
+	version int64
+	name    string
+	up      map[string]string
+	down    map[string]string
+}
+
+func loadSQL(files fs.FS) ([]Migration, error) {
+	paths, err := fs.Glob(files, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*sqlMigration{}
+	for _, p := range paths {
+		m := sqlFileName.FindStringSubmatch(path.Base(p))
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", path.Base(p))
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		content, err := fs.ReadFile(files, p)
+		if err != nil {
+			return nil, err
+		}
+		sm := byVersion[version]
+		if sm == nil {
+			sm = &sqlMigration{version: version, name: m[2], up: map[string]string{}, down: map[string]string{}}
+			byVersion[version] = sm
+		}
+		if sm.name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s, %s", version, sm.name, m[2])
+		}
+		if m[4] == "up" {
+			sm.up[m[3]] = string(content)
+		} else {
+			sm.down[m[3]] = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, sm := range byVersion {
+		if len(sm.up) == 0 {
+			return nil, fmt.Errorf("migration %d_%s has no up.sql", sm.version, sm.name)
+		}
+		m := Migration{Version: sm.version, Name: sm.name, Up: execSQL(sm.up)}
+		if len(sm.down) > 0 {
+			m.Down = execSQL(sm.down)
+		}
+		migrations = append(migrations, m)
+	}
+	return migrations, nil
+}
+
+// execSQL は接続先の方言のファイル、無ければ共通のファイルを実行する。どちらも無い方言では何もしない
+func execSQL(byDialect map[string]string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		sql, ok := byDialect[tx.Dialector.Name()]
+		if !ok {
+			sql = byDialect[""]
+		}
+		if strings.TrimSpace(sql) == "" {
+			return nil
+		}
+		return tx.Exec(sql).Error
+	}
+}
+
+// Load は Go と SQL のマイグレーションを版の順に返す。版の重複はエラー
+func Load() ([]Migration, error) {
+	sqlMigrations, err := loadSQL(sqlFiles)
+	if err != nil {
+		return nil, err
+	}
+	return merge(append(append([]Migration{}, goMigrations...), sqlMigrations...))
+}
+
+func merge(migrations []Migration) ([]Migration, error) {
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version <= 0 || !namePattern.MatchString(m.Name) || m.Up == nil {
+			return nil, fmt.Errorf("invalid migration %d_%s", m.Version, m.Name)
+		}
+		if i > 0 && migrations[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %d: %s, %s", m.Version, migrations[i-1].Name, m.Name)
+		}
+	}
+	return migrations, nil
+}
+
+// Migrator は Migrations を DB に適用し、schema_migrations に記録する
+type Migrator struct {
+	DB         *gorm.DB
+	Migrations []Migration
+	Now        func() time.Time
+}
+
+// New は登録済みのすべてのマイグレーションを扱う Migrator を返す
+func New(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+func (m *Migrator) now() time.Time {
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+func (m *Migrator) applied() (map[int64]model.SchemaMigration, error) {
+	if err := m.DB.AutoMigrate(&model.SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	var records []model.SchemaMigration
+	if err := m.DB.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]model.SchemaMigration, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// Status はマイグレーションと記録を版の順に返す
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.Migrations))
+	for _, mig := range m.Migrations {
+		s := Status{Version: mig.Version, Name: mig.Name}
+		if r, ok := applied[mig.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = &r.AppliedAt
+			delete(applied, mig.Version)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, r := range applied {
+		appliedAt := r.AppliedAt
+		statuses = append(statuses, Status{Version: r.Version, Name: r.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Pending は未適用のマイグレーションを版の順に返す
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mig := range m.Migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Check は未適用のマイグレーションがあればエラーを返す（起動時の確認）
+func (m *Migrator) Check() error {
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(pending))
+	for _, mig := range pending {
+		names = append(names, label(mig))
+	}
+	return fmt.Errorf("%d pending migration(s): %s (run `go run ./cmd/migrate up`)", len(pending), strings.Join(names, ", "))
+}
+
+// Up は未適用のマイグレーションを版の順に最大 steps 件（0 以下ならすべて）適用する
+// 1 件ずつトランザクションで適用し、失敗した時点で止める（それまでの適用は残る）
+func (m *Migrator) Up(steps int) ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+	if steps > 0 && steps < len(pending) {
+		pending = pending[:steps]
+	}
+	var done []Migration
+	for _, mig := range pending {
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := mig.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&model.SchemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: m.now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %s failed: %w", label(mig), err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Down は適用済みのマイグレーションを新しい順に steps 件（0 以下なら 1 件）巻き戻す
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]Migration, len(m.Migrations))
+	for _, mig := range m.Migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var done []Migration
+	for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+		s := statuses[i]
+		if !s.Applied {
+			continue
+		}
+		if s.Missing {
+			return done, fmt.Errorf("migration %04d_%s is applied but unknown to this build", s.Version, s.Name)
+		}
+		mig := byVersion[s.Version]
+		if mig.Down == nil {
+			return done, fmt.Errorf("migration %s cannot be rolled back", label(mig))
+		}
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := mig.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&model.SchemaMigration{}, mig.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback of %s failed: %w", label(mig), err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+func label(m Migration) string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
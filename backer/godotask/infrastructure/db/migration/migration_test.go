@@ -0,0 +1,259 @@
+package migration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godotask/infrastructure/db/model"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return db
+}
+
+func execMigration(version int64, name, up, down string) Migration {
+	m := Migration{Version: version, Name: name, Up: func(tx *gorm.DB) error { return tx.Exec(up).Error }}
+	if down != "" {
+		m.Down = func(tx *gorm.DB) error { return tx.Exec(down).Error }
+	}
+	return m
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(migrations), 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "baseline", migrations[0].Name)
+	assert.Equal(t, "technical_factors_cascade", migrations[1].Name)
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+	}
+
+	_, err = merge([]Migration{execMigration(3, "a", "", ""), execMigration(3, "b", "", "")})
+	assert.EqualError(t, err, "duplicate migration version 3: a, b")
+}
+
+func TestMigratorUpStatusDown(t *testing.T) {
+	db := setupDB(t)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := &Migrator{DB: db, Now: func() time.Time { return now }, Migrations: []Migration{
+		execMigration(1, "create_notes", "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT)", "DROP TABLE notes"),
+		execMigration(2, "add_notes_title", "ALTER TABLE notes ADD COLUMN title TEXT", "ALTER TABLE notes DROP COLUMN title"),
+		execMigration(3, "backfill_titles", "UPDATE notes SET title = body WHERE title IS NULL", ""),
+	}}
+
+	err := m.Check()
+	assert.EqualError(t, err, "3 pending migration(s): 0001_create_notes, 0002_add_notes_title, 0003_backfill_titles (run `go run ./cmd/migrate up`)")
+
+	done, err := m.Up(1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	require.NoError(t, db.Exec("INSERT INTO notes (id, body) VALUES (1, 'hello')").Error)
+
+	done, err = m.Up(0)
+	require.NoError(t, err)
+	assert.Len(t, done, 2)
+	assert.NoError(t, m.Check())
+	var title string
+	db.Raw("SELECT title FROM notes WHERE id = 1").Scan(&title)
+	assert.Equal(t, "hello", title)
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for _, s := range statuses {
+		assert.True(t, s.Applied)
+		assert.True(t, now.Equal(*s.AppliedAt))
+	}
+
+	// 巻き戻せないマイグレーションで止まる
+	_, err = m.Down(1)
+	assert.EqualError(t, err, "migration 0003_backfill_titles cannot be rolled back")
+
+	require.NoError(t, db.Delete(&model.SchemaMigration{}, 3).Error)
+	done, err = m.Down(2)
+	require.NoError(t, err)
+	require.Len(t, done, 2)
+	assert.Equal(t, int64(2), done[0].Version)
+	assert.Equal(t, int64(1), done[1].Version)
+	assert.False(t, db.Migrator().HasTable("notes"))
+
+	pending, err := m.Pending()
+	require.NoError(t, err)
+	assert.Len(t, pending, 3)
+}
+
+func TestMigratorUpStopsAtFailure(t *testing.T) {
+	db := setupDB(t)
+	m := &Migrator{DB: db, Migrations: []Migration{
+		execMigration(1, "create_notes", "CREATE TABLE notes (id INTEGER PRIMARY KEY)", ""),
+		{Version: 2, Name: "broken", Up: func(tx *gorm.DB) error {
+			if err := tx.Exec("CREATE TABLE half_done (id INTEGER)").Error; err != nil {
+				return err
+			}
+			return errors.New("boom")
+		}},
+		execMigration(3, "later", "CREATE TABLE later (id INTEGER)", ""),
+	}}
+
+	done, err := m.Up(0)
+	assert.EqualError(t, err, "migration 0002_broken failed: boom")
+	assert.Len(t, done, 1)
+	assert.True(t, db.Migrator().HasTable("notes"))
+	assert.False(t, db.Migrator().HasTable("half_done"), "the failed migration is rolled back")
+	assert.False(t, db.Migrator().HasTable("later"))
+
+	pending, err := m.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, int64(2), pending[0].Version)
+}
+
+func TestMigratorStatusReportsUnknownVersions(t *testing.T) {
+	db := setupDB(t)
+	m := &Migrator{DB: db, Migrations: []Migration{execMigration(1, "create_notes", "CREATE TABLE notes (id INTEGER)", "DROP TABLE notes")}}
+	_, err := m.Up(0)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&model.SchemaMigration{Version: 7, Name: "from_a_newer_build", AppliedAt: time.Now()}).Error)
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[1].Missing)
+	assert.NoError(t, m.Check())
+
+	_, err = m.Down(1)
+	assert.EqualError(t, err, "migration 0007_from_a_newer_build is applied but unknown to this build")
+}
+
+func TestLoadSQL(t *testing.T) {
+	files := fstest.MapFS{
+		"sql/0005_add_index.up.sql":           {Data: []byte("CREATE INDEX idx_notes_body ON notes (body)")},
+		"sql/0005_add_index.down.sql":         {Data: []byte("DROP INDEX idx_notes_body")},
+		"sql/0006_pg_only.postgres.up.sql":    {Data: []byte("SELECT pg_sleep(0)")},
+		"sql/0007_by_dialect.up.sql":          {Data: []byte("CREATE TABLE generic (id INTEGER)")},
+		"sql/0007_by_dialect.sqlite.up.sql":   {Data: []byte("CREATE TABLE lite (id INTEGER)")},
+		"sql/0007_by_dialect.postgres.up.sql": {Data: []byte("CREATE TABLE pg (id INTEGER)")},
+	}
+	migrations, err := loadSQL(files)
+	require.NoError(t, err)
+	migrations, err = merge(migrations)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.NotNil(t, migrations[0].Down)
+	assert.Nil(t, migrations[1].Down)
+
+	db := setupDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE notes (id INTEGER, body TEXT)").Error)
+	_, err = (&Migrator{DB: db, Migrations: migrations}).Up(0)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasIndex("notes", "idx_notes_body"))
+	assert.True(t, db.Migrator().HasTable("lite"), "the dialect file wins over the generic one")
+	assert.False(t, db.Migrator().HasTable("generic"))
+
+	for name, files := range map[string]fstest.MapFS{
+		"invalid migration file name: 8_Bad.up.sql": {"sql/8_Bad.up.sql": {}},
+		"migration 9_only_down has no up.sql":       {"sql/9_only_down.down.sql": {}},
+	} {
+		_, err := loadSQL(files)
+		assert.EqualError(t, err, name)
+	}
+}
+
+// 0001 のスキーマは空の DB に作り、巻き戻すとテーブルを消す
+func TestRegisteredMigrationsOnEmptyDatabase(t *testing.T) {
+	db := setupDB(t)
+	m, err := New(db)
+	require.NoError(t, err)
+
+	_, err = m.Up(0)
+	require.NoError(t, err)
+	require.NoError(t, m.Check())
+	assert.True(t, db.Migrator().HasTable(&model.Task{}))
+	assert.True(t, db.Migrator().HasTable(&model.SeedRun{}))
+
+	_, err = m.Down(len(m.Migrations))
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable(&model.Task{}))
+	assert.False(t, db.Migrator().HasTable("dataset_labels"))
+	assert.True(t, db.Migrator().HasTable(&model.SchemaMigration{}))
+}
+
+// すべてのマイグレーションを適用したスキーマはモデルのすべての列を持つ（モデルを変えたらマイグレーションも足す）
+func TestMigrationsCoverModels(t *testing.T) {
+	db := setupDB(t)
+	m, err := New(db)
+	require.NoError(t, err)
+	_, err = m.Up(0)
+	require.NoError(t, err)
+
+	for _, value := range model.Models() {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(value))
+		require.True(t, db.Migrator().HasTable(stmt.Schema.Table), stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			assert.True(t, db.Migrator().HasColumn(value, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+		}
+	}
+}
+
+// AutoMigrate で作った既存の DB にも 0001 を適用できる
+func TestBaselineOnAutoMigratedDatabase(t *testing.T) {
+	db := setupDB(t)
+	require.NoError(t, db.AutoMigrate(model.Models()...))
+	require.NoError(t, db.Create(&model.Task{Title: "existing"}).Error)
+	m, err := New(db)
+	require.NoError(t, err)
+
+	_, err = m.Up(0)
+	require.NoError(t, err)
+	var count int64
+	require.NoError(t, db.Model(&model.Task{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sql"), 0o755))
+	existing := []Migration{execMigration(1, "baseline", "", ""), execMigration(2, "other", "", "")}
+
+	paths, err := Create(dir, existing, "add_due_date", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "sql", "0003_add_due_date.up.sql"),
+		filepath.Join(dir, "sql", "0003_add_due_date.down.sql"),
+	}, paths)
+	for _, path := range paths {
+		assert.FileExists(t, path)
+	}
+
+	paths, err = Create(dir, existing, "backfill_due_date", true)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "0003_backfill_due_date.go")}, paths)
+	content, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Version: 3,")
+	assert.Contains(t, string(content), `Name:    "backfill_due_date",`)
+
+	_, err = Create(dir, existing, "backfill_due_date", true)
+	assert.ErrorContains(t, err, "already exists")
+	_, err = Create(dir, existing, "Add Column", false)
+	assert.ErrorContains(t, err, "migration name must match")
+}
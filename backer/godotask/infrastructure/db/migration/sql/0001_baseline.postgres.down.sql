@@ -0,0 +1,49 @@
+DROP TABLE IF EXISTS "seed_runs" CASCADE;
+DROP TABLE IF EXISTS "attachment_blobs" CASCADE;
+DROP TABLE IF EXISTS "attachments" CASCADE;
+DROP TABLE IF EXISTS "workspace_invitations" CASCADE;
+DROP TABLE IF EXISTS "workspace_members" CASCADE;
+DROP TABLE IF EXISTS "workspaces" CASCADE;
+DROP TABLE IF EXISTS "refresh_tokens" CASCADE;
+DROP TABLE IF EXISTS "sessions" CASCADE;
+DROP TABLE IF EXISTS "audit_logs" CASCADE;
+DROP TABLE IF EXISTS "knowledge_entities" CASCADE;
+DROP TABLE IF EXISTS "teaching_free_control_attempts" CASCADE;
+DROP TABLE IF EXISTS "teaching_free_controls" CASCADE;
+DROP TABLE IF EXISTS "spc_measurements" CASCADE;
+DROP TABLE IF EXISTS "label_dataset_snapshots" CASCADE;
+DROP TABLE IF EXISTS "dataset_labels" CASCADE;
+DROP TABLE IF EXISTS "label_datasets" CASCADE;
+DROP TABLE IF EXISTS "label_verifications" CASCADE;
+DROP TABLE IF EXISTS "label_relations" CASCADE;
+DROP TABLE IF EXISTS "label_revisions" CASCADE;
+DROP TABLE IF EXISTS "quantification_labels" CASCADE;
+DROP TABLE IF EXISTS "qualitative_labels" CASCADE;
+DROP TABLE IF EXISTS "process_optimizations" CASCADE;
+DROP TABLE IF EXISTS "process_monitoring_samples" CASCADE;
+DROP TABLE IF EXISTS "process_monitorings" CASCADE;
+DROP TABLE IF EXISTS "robot_specifications" CASCADE;
+DROP TABLE IF EXISTS "tool_matching_results" CASCADE;
+DROP TABLE IF EXISTS "state_evaluations" CASCADE;
+DROP TABLE IF EXISTS "optimization_models" CASCADE;
+DROP TABLE IF EXISTS "phenomenological_frameworks" CASCADE;
+DROP TABLE IF EXISTS "learning_patterns" CASCADE;
+DROP TABLE IF EXISTS "language_optimizations" CASCADE;
+DROP TABLE IF EXISTS "knowledge_patterns" CASCADE;
+DROP TABLE IF EXISTS "visual_metaphors" CASCADE;
+DROP TABLE IF EXISTS "user_calibrations" CASCADE;
+DROP TABLE IF EXISTS "image_annotations" CASCADE;
+DROP TABLE IF EXISTS "multimodal_data" CASCADE;
+DROP TABLE IF EXISTS "heuristics_modelers" CASCADE;
+DROP TABLE IF EXISTS "heuristics_patterns" CASCADE;
+DROP TABLE IF EXISTS "heuristics_insights" CASCADE;
+DROP TABLE IF EXISTS "heuristics_trackings" CASCADE;
+DROP TABLE IF EXISTS "knowledge_transformations" CASCADE;
+DROP TABLE IF EXISTS "technical_factors" CASCADE;
+DROP TABLE IF EXISTS "heuristics_analyses" CASCADE;
+DROP TABLE IF EXISTS "book" CASCADE;
+DROP TABLE IF EXISTS "memory_contexts" CASCADE;
+DROP TABLE IF EXISTS "assessments" CASCADE;
+DROP TABLE IF EXISTS "tasks" CASCADE;
+DROP TABLE IF EXISTS "memories" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
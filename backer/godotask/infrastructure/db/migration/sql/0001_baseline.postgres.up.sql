@@ -0,0 +1,137 @@
+-- 起動時の AutoMigrate（model.Models()）が作っていたスキーマを固定したもの。モデルを変えてもこのファイルは変えず、新しいマイグレーションを足す
+-- AutoMigrate で作った既存の DB にも適用できるよう IF NOT EXISTS で作る
+CREATE TABLE IF NOT EXISTS "users" ("id" bigserial,"username" text NOT NULL,"email" text NOT NULL,"password_hash" text NOT NULL,"role" text DEFAULT 'user',"created_at" timestamptz,"updated_at" timestamptz,"is_active" boolean DEFAULT true,"factor" text,"process" text,"evaluation_axis" text,"information_amount" text,PRIMARY KEY ("id"),CONSTRAINT "uni_users_username" UNIQUE ("username"),CONSTRAINT "uni_users_email" UNIQUE ("email"));
+CREATE TABLE IF NOT EXISTS "memories" ("id" bigserial,"user_id" bigint,"workspace_id" bigint,"source_type" text DEFAULT 'book',"title" text,"author" text,"notes" text,"tags" text,"read_status" text DEFAULT 'unread',"read_date" timestamptz,"factor" text,"process" text,"evaluation_axis" text,"information_amount" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_memories_workspace_id" ON "memories" ("workspace_id");
+CREATE TABLE IF NOT EXISTS "tasks" ("id" bigserial,"user_id" bigint,"workspace_id" bigint,"memory_id" bigint,"title" text,"description" text,"date" timestamptz,"status" text,"priority" bigint,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_memory" FOREIGN KEY ("memory_id") REFERENCES "memories"("id"),CONSTRAINT "fk_users_tasks" FOREIGN KEY ("user_id") REFERENCES "users"("id"));
+CREATE INDEX IF NOT EXISTS "idx_tasks_workspace_id" ON "tasks" ("workspace_id");
+CREATE TABLE IF NOT EXISTS "assessments" ("id" bigserial,"task_id" bigint,"user_id" bigint,"effectiveness_score" bigint,"effort_score" bigint,"impact_score" bigint,"qualitative_feedback" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_assessments" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE TABLE IF NOT EXISTS "memory_contexts" ("id" bigserial,"user_id" bigint,"task_id" bigint,"level" bigint,"work_target" text,"machine" text,"material_spec" text,"change_factor" text,"goal" text,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "book" ("id" bigserial,"task_id" bigint,"title" text,"name" text,"text" text,"disc" text,"img_path" text,"status" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "heuristics_analyses" ("id" bigserial,"user_id" bigint,"task_id" bigint,"analysis_type" text,"result" jsonb,"time_spent_minutes" bigint,"difficulty_score" decimal,"efficiency_score" decimal,"error_count" bigint,"confidence" decimal,"score" decimal,"status" text,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_heuristics_analysis" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_heuristics_analyses_deleted_at" ON "heuristics_analyses" ("deleted_at");
+CREATE TABLE IF NOT EXISTS "technical_factors" ("id" bigserial,"context_id" bigint,"tool_spec" text,"eval_factors" text,"measurement_method" text,"concern" text,"created_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_memory_contexts_technical_factors" FOREIGN KEY ("context_id") REFERENCES "memory_contexts"("id"));
+CREATE TABLE IF NOT EXISTS "knowledge_transformations" ("id" bigserial,"context_id" bigint,"transformation" text,"countermeasure" text,"model_feedback" text,"learned_knowledge" text,"created_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_memory_contexts_knowledge_transformations" FOREIGN KEY ("context_id") REFERENCES "memory_contexts"("id"));
+CREATE TABLE IF NOT EXISTS "heuristics_trackings" ("id" bigserial,"user_id" bigint,"task_id" bigint,"action" text,"context" jsonb,"session_id" text,"focus_level" decimal,"is_distraction" boolean,"timestamp" timestamptz,"duration" bigint,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_heuristics_tracking" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_heuristics_trackings_deleted_at" ON "heuristics_trackings" ("deleted_at");
+CREATE TABLE IF NOT EXISTS "heuristics_insights" ("id" bigserial,"user_id" bigint,"task_id" bigint,"type" text,"title" text,"description" text,"confidence" decimal,"data" jsonb,"source_analysis_id" bigint,"recommendation" text,"expected_impact" decimal,"is_active" boolean DEFAULT true,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_heuristics_insight" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_heuristics_insights_deleted_at" ON "heuristics_insights" ("deleted_at");
+CREATE TABLE IF NOT EXISTS "heuristics_patterns" ("id" bigserial,"name" text,"user_id" bigint,"task_id" bigint,"task_type" text,"impact_score" decimal,"category" text,"pattern" jsonb,"frequency" bigint,"accuracy" decimal,"last_seen" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_heuristics_pattern" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_heuristics_patterns_deleted_at" ON "heuristics_patterns" ("deleted_at");
+CREATE TABLE IF NOT EXISTS "heuristics_modelers" ("id" bigserial,"user_id" bigint,"task_id" bigint,"model_type" text,"version" text,"parameters" jsonb,"performance" jsonb,"status" text,"trained_at" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_heuristics_modeler" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_heuristics_modelers_deleted_at" ON "heuristics_modelers" ("deleted_at");
+CREATE TABLE IF NOT EXISTS "multimodal_data" ("id" varchar(255),"user_id" bigint,"task_id" bigint,"text" text,"tokens" jsonb,"semantic_vector" jsonb,"ambiguity_score" decimal,"image_url" text,"image_attachment_id" varchar(36),"image_format" text,"image_width" bigint,"image_height" bigint,"image_hash" varchar(16),"capture_session" text,"image_metadata" jsonb,"thumbnails" jsonb,"objects" jsonb,"measurements" jsonb,"image_confidence" decimal,"mapping_type" text,"correlation_score" decimal,"context_relevance" decimal,"historical_accuracy" decimal,"value" decimal,"unit" text,"min_range" decimal,"max_range" decimal,"confidence" decimal,"verified" boolean,"user_feedback" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_multimodal_data" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"),CONSTRAINT "fk_users_multimodal_data" FOREIGN KEY ("user_id") REFERENCES "users"("id"));
+CREATE INDEX IF NOT EXISTS "idx_multimodal_data_capture_session" ON "multimodal_data" ("capture_session");
+CREATE INDEX IF NOT EXISTS "idx_multimodal_data_image_hash" ON "multimodal_data" ("image_hash");
+CREATE INDEX IF NOT EXISTS "idx_multimodal_data_task_id" ON "multimodal_data" ("task_id");
+CREATE INDEX IF NOT EXISTS "idx_multimodal_data_user_id" ON "multimodal_data" ("user_id");
+CREATE TABLE IF NOT EXISTS "image_annotations" ("id" varchar(255),"image_id" varchar(255),"task_id" bigint,"user_id" bigint,"label_id" text,"type" text,"x" decimal,"y" decimal,"width" decimal,"height" decimal,"label" text,"value" decimal,"unit" text,"confidence" decimal,"calibration_id" varchar(255),"uncertainty" decimal,"created_by" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_image_annotations_label_id" ON "image_annotations" ("label_id");
+CREATE INDEX IF NOT EXISTS "idx_image_annotations_user_id" ON "image_annotations" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_image_annotations_task_id" ON "image_annotations" ("task_id");
+CREATE INDEX IF NOT EXISTS "idx_image_annotations_image_id" ON "image_annotations" ("image_id");
+CREATE TABLE IF NOT EXISTS "user_calibrations" ("id" varchar(255),"user_id" bigint,"task_id" bigint,"image_id" varchar(255),"session" text,"reference_object" text,"dimension" text,"known_length" decimal,"pixel_length" decimal,"scale" decimal,"scale_uncertainty" decimal,"pixel_uncertainty" decimal,"measurements" jsonb,"image_url" text,"confidence" decimal,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_user_calibrations_session" ON "user_calibrations" ("session");
+CREATE INDEX IF NOT EXISTS "idx_user_calibrations_image_id" ON "user_calibrations" ("image_id");
+CREATE INDEX IF NOT EXISTS "idx_user_calibrations_task_id" ON "user_calibrations" ("task_id");
+CREATE INDEX IF NOT EXISTS "idx_user_calibrations_user_id" ON "user_calibrations" ("user_id");
+CREATE TABLE IF NOT EXISTS "visual_metaphors" ("id" varchar(255),"metaphor" text,"reference_object" text,"width" decimal,"height" decimal,"depth" decimal,"image_url" text,"min_variability" decimal,"max_variability" decimal,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "knowledge_patterns" ("id" varchar(255),"task_id" bigint,"type" varchar(50),"domain" varchar(100),"tacit_knowledge" text,"explicit_form" text,"conversion_path" jsonb,"accuracy" decimal,"coverage" decimal,"consistency" decimal,"abstract_level" varchar(50),"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_knowledge_patterns" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_knowledge_patterns_task_id" ON "knowledge_patterns" ("task_id");
+CREATE TABLE IF NOT EXISTS "language_optimizations" ("id" varchar(255),"task_id" bigint,"original_text" text,"optimized_text" text,"domain" varchar(100),"abstraction_level" text,"precision" decimal,"clarity" decimal,"completeness" decimal,"context" jsonb,"transformation" jsonb,"evaluation_score" decimal,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_language_optimization" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_language_optimizations_deleted_at" ON "language_optimizations" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_language_optimizations_task_id" ON "language_optimizations" ("task_id");
+CREATE TABLE IF NOT EXISTS "learning_patterns" ("id" varchar(255),"user_id" varchar(255) NOT NULL,"pattern_type" varchar(100),"domain" varchar(100),"tacit_knowledge" text,"explicit_form" text,"seci_stage" varchar(50),"method" varchar(100),"accuracy" decimal(5,3),"coverage" decimal(5,3),"consistency" decimal(5,3),"abstract_level" varchar(10),"validated" boolean DEFAULT false,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "phenomenological_frameworks" ("id" varchar(255),"task_id" bigint,"name" varchar(255),"description" text,"goal" text,"scope" text,"process" jsonb,"result" jsonb,"feedback" jsonb,"limit_min" decimal,"limit_max" decimal,"goal_function" text,"abstract_level" varchar(50),"domain" varchar(100),"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_phenomenological_frameworks_task" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_phenomenological_frameworks_deleted_at" ON "phenomenological_frameworks" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_phenomenological_frameworks_task_id" ON "phenomenological_frameworks" ("task_id");
+CREATE TABLE IF NOT EXISTS "optimization_models" ("id" text,"name" text,"type" text,"objective_function" text,"constraints" text,"parameters" text,"performance_metric" text,"iteration_count" decimal,"convergence_rate" decimal,"domain" text,"application" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "state_evaluations" ("id" varchar(255),"user_id" varchar(255) NOT NULL,"task_id" bigint NOT NULL,"level" bigint NOT NULL,"work_target" text,"current_state" JSONB,"target_state" JSONB,"evaluation_score" decimal(5,2),"framework" varchar(255),"tools" JSONB,"process_data" JSONB,"results" JSONB,"learned_knowledge" text,"status" varchar(50) DEFAULT 'pending',"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "tool_matching_results" ("id" varchar(255),"state_evaluation_id" varchar(255) NOT NULL,"robot_id" varchar(255),"optimization_model_id" varchar(255),"matching_score" decimal(5,3),"rank" bigint,"recommendations" JSONB,"parameters" JSONB,"expected_performance" JSONB,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "robot_specifications" ("id" varchar(255),"model_name" varchar(255) NOT NULL,"dof" bigint,"reach_mm" decimal,"payload_kg" decimal,"repeat_accuracy_mm" decimal,"max_speed_mm_s" decimal,"work_envelope_shape" varchar(100),"teaching_method" varchar(100),"control_type" varchar(100),"vision_system" varchar(100),"force_sensor" varchar(100),"ai_capability" JSONB,"safety_features" JSONB,"maintenance_interval_hours" bigint,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "process_monitorings" ("id" varchar(255),"state_evaluation_id" varchar(255) NOT NULL,"process_type" varchar(100),"monitoring_data" JSONB,"metrics" JSONB,"anomalies" JSONB,"detector_config" JSONB,"summary" JSONB,"status" varchar(50),"start_time" timestamptz,"end_time" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE TABLE IF NOT EXISTS "process_monitoring_samples" ("id" bigserial,"process_monitoring_id" varchar(255) NOT NULL,"metric" varchar(100) NOT NULL,"value" decimal,"anomalous" boolean,"recorded_at" timestamptz,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_process_monitoring_samples_recorded_at" ON "process_monitoring_samples" ("recorded_at");
+CREATE INDEX IF NOT EXISTS "idx_process_monitoring_samples_process_monitoring_id" ON "process_monitoring_samples" ("process_monitoring_id");
+CREATE TABLE IF NOT EXISTS "process_optimizations" ("id" varchar(255),"task_id" bigint,"process_id" text,"optimization_model_id" varchar(255),"optimization_type" text,"initial_state" jsonb,"optimized_state" jsonb,"metric" text,"metric_direction" text,"improvement" decimal,"method" text,"iterations" bigint,"convergence_time" decimal,"created_by" bigint,"validation_status" varchar(20) DEFAULT 'pending',"validation_comment" text,"validated_by" text,"validation_date" timestamptz,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_process_optimizations_deleted_at" ON "process_optimizations" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_process_optimizations_validation_status" ON "process_optimizations" ("validation_status");
+CREATE INDEX IF NOT EXISTS "idx_process_optimizations_created_by" ON "process_optimizations" ("created_by");
+CREATE INDEX IF NOT EXISTS "idx_process_optimizations_optimization_model_id" ON "process_optimizations" ("optimization_model_id");
+CREATE INDEX IF NOT EXISTS "idx_process_optimizations_process_id" ON "process_optimizations" ("process_id");
+CREATE INDEX IF NOT EXISTS "idx_process_optimizations_task_id" ON "process_optimizations" ("task_id");
+CREATE TABLE IF NOT EXISTS "qualitative_labels" ("id" bigserial,"task_id" bigint,"user_id" bigint,"content" text,"category" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_qualitative_labels" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_qualitative_labels_category" ON "qualitative_labels" ("category");
+CREATE INDEX IF NOT EXISTS "idx_qualitative_labels_user_id" ON "qualitative_labels" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_qualitative_labels_task_id" ON "qualitative_labels" ("task_id");
+CREATE TABLE IF NOT EXISTS "quantification_labels" ("id" varchar(255),"user_id" bigint,"task_id" bigint,"original_text" text,"normalized_text" text,"category" text,"context" text,"domain" text,"image_url" text,"value" decimal,"unit" text,"min_range" decimal,"max_range" decimal,"typical_value" decimal,"precision" bigint,"confidence" decimal,"abstract_level" text,"related_concepts" JSONB,"status" text DEFAULT 'draft',"review_round" bigint,"accuracy" decimal,"agreement" decimal,"verification_count" bigint,"last_verified" timestamptz,"source" text,"validated" boolean DEFAULT false,"public_visibility" boolean DEFAULT true,"tags" JSONB,"notes" text,"version" bigint DEFAULT 1,"created_by" text,"updated_by" text,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_tasks_quantification_labels" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"),CONSTRAINT "fk_users_quantification_labels" FOREIGN KEY ("user_id") REFERENCES "users"("id"));
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_validated" ON "quantification_labels" ("validated");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_source" ON "quantification_labels" ("source");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_status" ON "quantification_labels" ("status");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_abstract_level" ON "quantification_labels" ("abstract_level");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_unit" ON "quantification_labels" ("unit");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_domain" ON "quantification_labels" ("domain");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_category" ON "quantification_labels" ("category");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_task_id" ON "quantification_labels" ("task_id");
+CREATE INDEX IF NOT EXISTS "idx_quantification_labels_user_id" ON "quantification_labels" ("user_id");
+CREATE TABLE IF NOT EXISTS "label_revisions" ("id" varchar(255),"label_id" text,"version" bigint,"changes" jsonb,"comment" text,"user_id" text,"timestamp" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_label_revisions_label_id" ON "label_revisions" ("label_id");
+CREATE TABLE IF NOT EXISTS "label_relations" ("id" varchar(255),"user_id" bigint,"source_id" text,"target_id" text,"relation_type" text,"strength" decimal,"bidirectional" boolean,"context" text,"confidence" decimal,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_label_relations_target_id" ON "label_relations" ("target_id");
+CREATE INDEX IF NOT EXISTS "idx_label_relations_source_id" ON "label_relations" ("source_id");
+CREATE INDEX IF NOT EXISTS "idx_label_relations_user_id" ON "label_relations" ("user_id");
+CREATE TABLE IF NOT EXISTS "label_verifications" ("id" varchar(255),"label_id" varchar(255),"round" bigint,"verifier_id" bigint,"decision" text,"criteria" jsonb,"comment" text,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_label_verifications_verifier_id" ON "label_verifications" ("verifier_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_label_verification" ON "label_verifications" ("label_id","round","verifier_id");
+CREATE TABLE IF NOT EXISTS "label_datasets" ("id" varchar(255),"user_id" bigint,"name" text,"description" text,"domain" text,"total_labels" bigint,"verified_labels" bigint,"average_accuracy" decimal,"completeness" decimal,"consistency" decimal,"diversity" decimal,"balance" decimal,"version" text,"license" text,"citation" text,"created_by" text,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_label_datasets_deleted_at" ON "label_datasets" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_label_datasets_domain" ON "label_datasets" ("domain");
+CREATE INDEX IF NOT EXISTS "idx_label_datasets_user_id" ON "label_datasets" ("user_id");
+CREATE TABLE IF NOT EXISTS "dataset_labels" ("label_dataset_id" varchar(255),"quantification_label_id" varchar(255),PRIMARY KEY ("label_dataset_id","quantification_label_id"),CONSTRAINT "fk_dataset_labels_label_dataset" FOREIGN KEY ("label_dataset_id") REFERENCES "label_datasets"("id"),CONSTRAINT "fk_dataset_labels_quantification_label" FOREIGN KEY ("quantification_label_id") REFERENCES "quantification_labels"("id"));
+CREATE TABLE IF NOT EXISTS "label_dataset_snapshots" ("id" varchar(255),"dataset_id" varchar(255),"version" bigint,"label_count" bigint,"metrics" jsonb,"checksum" text,"content" text,"created_by" text,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_dataset_snapshot_version" ON "label_dataset_snapshots" ("dataset_id","version");
+CREATE TABLE IF NOT EXISTS "spc_measurements" ("id" bigserial,"user_id" bigint,"task_id" bigint NOT NULL,"label_id" varchar(255) NOT NULL,"value" decimal,"unit" varchar(50),"subgroup" varchar(100),"measured_at" timestamptz,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_spc_measurements_measured_at" ON "spc_measurements" ("measured_at");
+CREATE INDEX IF NOT EXISTS "idx_spc_measurement_series" ON "spc_measurements" ("task_id","label_id");
+CREATE INDEX IF NOT EXISTS "idx_spc_measurements_user_id" ON "spc_measurements" ("user_id");
+CREATE TABLE IF NOT EXISTS "teaching_free_controls" ("id" varchar(255),"task_id" bigint,"robot_id" text,"task_type" text,"vision_system" jsonb,"force_control" jsonb,"ai_model" jsonb,"learning_data" jsonb,"success_rate" decimal,"adaptation_time" decimal,"error_recovery" jsonb,"performance_log" jsonb,"created_at" timestamptz,"updated_at" timestamptz,"deleted_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_teaching_free_controls_task" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_teaching_free_controls_deleted_at" ON "teaching_free_controls" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_teaching_free_controls_robot_id" ON "teaching_free_controls" ("robot_id");
+CREATE INDEX IF NOT EXISTS "idx_teaching_free_controls_task_id" ON "teaching_free_controls" ("task_id");
+CREATE TABLE IF NOT EXISTS "teaching_free_control_attempts" ("id" bigserial,"teaching_free_control_id" varchar(255) NOT NULL,"robot_id" varchar(255),"task_type" varchar(255),"outcome" varchar(20) NOT NULL,"error_type" varchar(100),"recovery_action" varchar(100),"recovered" boolean,"duration" decimal,"source" varchar(20) DEFAULT 'robot',"detail" jsonb,"attempted_at" timestamptz,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_teaching_free_control_attempts_attempted_at" ON "teaching_free_control_attempts" ("attempted_at");
+CREATE INDEX IF NOT EXISTS "idx_tfc_attempt_curve" ON "teaching_free_control_attempts" ("robot_id","task_type");
+CREATE INDEX IF NOT EXISTS "idx_teaching_free_control_attempts_teaching_free_control_id" ON "teaching_free_control_attempts" ("teaching_free_control_id");
+CREATE TABLE IF NOT EXISTS "knowledge_entities" ("id" varchar(255),"task_id" bigint,"entity_type" text,"reference_id" text,"domain" text,"abstract_level" text,"source" text,"tags" jsonb,"linked_entity_ids" jsonb,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_knowledge_entities_task" FOREIGN KEY ("task_id") REFERENCES "tasks"("id"));
+CREATE INDEX IF NOT EXISTS "idx_knowledge_entities_domain" ON "knowledge_entities" ("domain");
+CREATE INDEX IF NOT EXISTS "idx_knowledge_entities_reference_id" ON "knowledge_entities" ("reference_id");
+CREATE INDEX IF NOT EXISTS "idx_knowledge_entities_entity_type" ON "knowledge_entities" ("entity_type");
+CREATE INDEX IF NOT EXISTS "idx_knowledge_entities_task_id" ON "knowledge_entities" ("task_id");
+CREATE TABLE IF NOT EXISTS "audit_logs" ("id" bigserial,"user_id" bigint,"username" text,"role" varchar(50),"method" varchar(10),"route" text,"path" text,"resource_type" varchar(100),"resource_id" varchar(255),"before_hash" varchar(64),"after_hash" varchar(64),"status_code" bigint,"ip" varchar(64),"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_created_at" ON "audit_logs" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_resource_id" ON "audit_logs" ("resource_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_resource_type" ON "audit_logs" ("resource_type");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_method" ON "audit_logs" ("method");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_user_id" ON "audit_logs" ("user_id");
+CREATE TABLE IF NOT EXISTS "sessions" ("id" bigserial,"user_id" bigint NOT NULL,"device_name" varchar(255),"user_agent" text,"ip" varchar(64),"created_at" timestamptz,"last_used_at" timestamptz,"expires_at" timestamptz,"revoked_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_sessions_revoked_at" ON "sessions" ("revoked_at");
+CREATE INDEX IF NOT EXISTS "idx_sessions_expires_at" ON "sessions" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_sessions_user_id" ON "sessions" ("user_id");
+CREATE TABLE IF NOT EXISTS "refresh_tokens" ("id" bigserial,"session_id" bigint NOT NULL,"token_hash" varchar(64) NOT NULL,"expires_at" timestamptz,"used_at" timestamptz,"created_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_refresh_tokens_session" FOREIGN KEY ("session_id") REFERENCES "sessions"("id"));
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_session_id" ON "refresh_tokens" ("session_id");
+CREATE TABLE IF NOT EXISTS "workspaces" ("id" bigserial,"name" varchar(255) NOT NULL,"description" text,"created_by" bigint,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_workspaces_created_by" ON "workspaces" ("created_by");
+CREATE TABLE IF NOT EXISTS "workspace_members" ("id" bigserial,"workspace_id" bigint NOT NULL,"user_id" bigint NOT NULL,"role" varchar(20) NOT NULL,"created_at" timestamptz,"updated_at" timestamptz,PRIMARY KEY ("id"),CONSTRAINT "fk_workspace_members_user" FOREIGN KEY ("user_id") REFERENCES "users"("id"),CONSTRAINT "fk_workspaces_members" FOREIGN KEY ("workspace_id") REFERENCES "workspaces"("id"));
+CREATE INDEX IF NOT EXISTS "idx_workspace_members_user_id" ON "workspace_members" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_workspace_member" ON "workspace_members" ("workspace_id","user_id");
+CREATE TABLE IF NOT EXISTS "workspace_invitations" ("id" bigserial,"workspace_id" bigint NOT NULL,"email" varchar(255) NOT NULL,"role" varchar(20) NOT NULL,"token_hash" varchar(64) NOT NULL,"invited_by" bigint,"expires_at" timestamptz,"accepted_at" timestamptz,"accepted_by" bigint,"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_workspace_invitations_token_hash" ON "workspace_invitations" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_workspace_invitations_email" ON "workspace_invitations" ("email");
+CREATE INDEX IF NOT EXISTS "idx_workspace_invitations_workspace_id" ON "workspace_invitations" ("workspace_id");
+CREATE TABLE IF NOT EXISTS "attachments" ("id" varchar(36),"user_id" bigint NOT NULL,"resource_type" varchar(100) NOT NULL,"resource_id" varchar(255) NOT NULL,"file_name" varchar(255),"content_type" varchar(255),"size" bigint,"sha256" char(64) NOT NULL,"description" varchar(200),"created_at" timestamptz,PRIMARY KEY ("id"));
+CREATE INDEX IF NOT EXISTS "idx_attachments_sha256" ON "attachments" ("sha256");
+CREATE INDEX IF NOT EXISTS "idx_attachment_resource" ON "attachments" ("resource_type","resource_id");
+CREATE INDEX IF NOT EXISTS "idx_attachments_user_id" ON "attachments" ("user_id");
+CREATE TABLE IF NOT EXISTS "attachment_blobs" ("sha256" char(64),"size" bigint,"created_at" timestamptz,PRIMARY KEY ("sha256"));
+CREATE TABLE IF NOT EXISTS "seed_runs" ("name" varchar(100),"checksum" varchar(64) NOT NULL,"applied_at" timestamptz,PRIMARY KEY ("name"));
@@ -0,0 +1,49 @@
+DROP TABLE IF EXISTS `seed_runs`;
+DROP TABLE IF EXISTS `attachment_blobs`;
+DROP TABLE IF EXISTS `attachments`;
+DROP TABLE IF EXISTS `workspace_invitations`;
+DROP TABLE IF EXISTS `workspace_members`;
+DROP TABLE IF EXISTS `workspaces`;
+DROP TABLE IF EXISTS `refresh_tokens`;
+DROP TABLE IF EXISTS `sessions`;
+DROP TABLE IF EXISTS `audit_logs`;
+DROP TABLE IF EXISTS `knowledge_entities`;
+DROP TABLE IF EXISTS `teaching_free_control_attempts`;
+DROP TABLE IF EXISTS `teaching_free_controls`;
+DROP TABLE IF EXISTS `spc_measurements`;
+DROP TABLE IF EXISTS `label_dataset_snapshots`;
+DROP TABLE IF EXISTS `dataset_labels`;
+DROP TABLE IF EXISTS `label_datasets`;
+DROP TABLE IF EXISTS `label_verifications`;
+DROP TABLE IF EXISTS `label_relations`;
+DROP TABLE IF EXISTS `label_revisions`;
+DROP TABLE IF EXISTS `quantification_labels`;
+DROP TABLE IF EXISTS `qualitative_labels`;
+DROP TABLE IF EXISTS `process_optimizations`;
+DROP TABLE IF EXISTS `process_monitoring_samples`;
+DROP TABLE IF EXISTS `process_monitorings`;
+DROP TABLE IF EXISTS `robot_specifications`;
+DROP TABLE IF EXISTS `tool_matching_results`;
+DROP TABLE IF EXISTS `state_evaluations`;
+DROP TABLE IF EXISTS `optimization_models`;
+DROP TABLE IF EXISTS `phenomenological_frameworks`;
+DROP TABLE IF EXISTS `learning_patterns`;
+DROP TABLE IF EXISTS `language_optimizations`;
+DROP TABLE IF EXISTS `knowledge_patterns`;
+DROP TABLE IF EXISTS `visual_metaphors`;
+DROP TABLE IF EXISTS `user_calibrations`;
+DROP TABLE IF EXISTS `image_annotations`;
+DROP TABLE IF EXISTS `multimodal_data`;
+DROP TABLE IF EXISTS `heuristics_modelers`;
+DROP TABLE IF EXISTS `heuristics_patterns`;
+DROP TABLE IF EXISTS `heuristics_insights`;
+DROP TABLE IF EXISTS `heuristics_trackings`;
+DROP TABLE IF EXISTS `knowledge_transformations`;
+DROP TABLE IF EXISTS `technical_factors`;
+DROP TABLE IF EXISTS `heuristics_analyses`;
+DROP TABLE IF EXISTS `book`;
+DROP TABLE IF EXISTS `memory_contexts`;
+DROP TABLE IF EXISTS `assessments`;
+DROP TABLE IF EXISTS `tasks`;
+DROP TABLE IF EXISTS `memories`;
+DROP TABLE IF EXISTS `users`;
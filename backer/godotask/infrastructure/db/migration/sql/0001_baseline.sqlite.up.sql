@@ -0,0 +1,137 @@
+-- 起動時の AutoMigrate（model.Models()）が作っていたスキーマを固定したもの。モデルを変えてもこのファイルは変えず、新しいマイグレーションを足す
+-- AutoMigrate で作った既存の DB にも適用できるよう IF NOT EXISTS で作る
+CREATE TABLE IF NOT EXISTS `users` (`id` integer PRIMARY KEY AUTOINCREMENT,`username` text NOT NULL,`email` text NOT NULL,`password_hash` text NOT NULL,`role` text DEFAULT "user",`created_at` datetime,`updated_at` datetime,`is_active` numeric DEFAULT true,`factor` text,`process` text,`evaluation_axis` text,`information_amount` text,CONSTRAINT `uni_users_username` UNIQUE (`username`),CONSTRAINT `uni_users_email` UNIQUE (`email`));
+CREATE TABLE IF NOT EXISTS `memories` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`workspace_id` integer,`source_type` text DEFAULT "book",`title` text,`author` text,`notes` text,`tags` text,`read_status` text DEFAULT "unread",`read_date` datetime,`factor` text,`process` text,`evaluation_axis` text,`information_amount` text,`created_at` datetime,`updated_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_memories_workspace_id` ON `memories`(`workspace_id`);
+CREATE TABLE IF NOT EXISTS `tasks` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`workspace_id` integer,`memory_id` integer,`title` text,`description` text,`date` datetime,`status` text,`priority` integer,`created_at` datetime,`updated_at` datetime,CONSTRAINT `fk_tasks_memory` FOREIGN KEY (`memory_id`) REFERENCES `memories`(`id`),CONSTRAINT `fk_users_tasks` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_tasks_workspace_id` ON `tasks`(`workspace_id`);
+CREATE TABLE IF NOT EXISTS `assessments` (`id` integer PRIMARY KEY AUTOINCREMENT,`task_id` integer,`user_id` integer,`effectiveness_score` integer,`effort_score` integer,`impact_score` integer,`qualitative_feedback` text,`created_at` datetime,`updated_at` datetime,CONSTRAINT `fk_tasks_assessments` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE TABLE IF NOT EXISTS `memory_contexts` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`task_id` integer,`level` integer,`work_target` text,`machine` text,`material_spec` text,`change_factor` text,`goal` text,`created_at` datetime);
+CREATE TABLE IF NOT EXISTS `book` (`id` integer PRIMARY KEY AUTOINCREMENT,`task_id` integer,`title` text,`name` text,`text` text,`disc` text,`img_path` text,`status` text,`created_at` datetime,`updated_at` datetime);
+CREATE TABLE IF NOT EXISTS `heuristics_analyses` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`task_id` integer,`analysis_type` text,`result` jsonb,`time_spent_minutes` integer,`difficulty_score` real,`efficiency_score` real,`error_count` integer,`confidence` real,`score` real,`status` text,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,CONSTRAINT `fk_tasks_heuristics_analysis` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_heuristics_analyses_deleted_at` ON `heuristics_analyses`(`deleted_at`);
+CREATE TABLE IF NOT EXISTS `technical_factors` (`id` integer PRIMARY KEY AUTOINCREMENT,`context_id` integer,`tool_spec` text,`eval_factors` text,`measurement_method` text,`concern` text,`created_at` datetime,CONSTRAINT `fk_memory_contexts_technical_factors` FOREIGN KEY (`context_id`) REFERENCES `memory_contexts`(`id`));
+CREATE TABLE IF NOT EXISTS `knowledge_transformations` (`id` integer PRIMARY KEY AUTOINCREMENT,`context_id` integer,`transformation` text,`countermeasure` text,`model_feedback` text,`learned_knowledge` text,`created_at` datetime,CONSTRAINT `fk_memory_contexts_knowledge_transformations` FOREIGN KEY (`context_id`) REFERENCES `memory_contexts`(`id`));
+CREATE TABLE IF NOT EXISTS `heuristics_trackings` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`task_id` integer,`action` text,`context` jsonb,`session_id` text,`focus_level` real,`is_distraction` numeric,`timestamp` datetime,`duration` integer,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,CONSTRAINT `fk_tasks_heuristics_tracking` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_heuristics_trackings_deleted_at` ON `heuristics_trackings`(`deleted_at`);
+CREATE TABLE IF NOT EXISTS `heuristics_insights` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`task_id` integer,`type` text,`title` text,`description` text,`confidence` real,`data` jsonb,`source_analysis_id` integer,`recommendation` text,`expected_impact` real,`is_active` numeric DEFAULT true,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,CONSTRAINT `fk_tasks_heuristics_insight` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_heuristics_insights_deleted_at` ON `heuristics_insights`(`deleted_at`);
+CREATE TABLE IF NOT EXISTS `heuristics_patterns` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text,`user_id` integer,`task_id` integer,`task_type` text,`impact_score` real,`category` text,`pattern` jsonb,`frequency` integer,`accuracy` real,`last_seen` datetime,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,CONSTRAINT `fk_tasks_heuristics_pattern` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_heuristics_patterns_deleted_at` ON `heuristics_patterns`(`deleted_at`);
+CREATE TABLE IF NOT EXISTS `heuristics_modelers` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`task_id` integer,`model_type` text,`version` text,`parameters` jsonb,`performance` jsonb,`status` text,`trained_at` datetime,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,CONSTRAINT `fk_tasks_heuristics_modeler` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_heuristics_modelers_deleted_at` ON `heuristics_modelers`(`deleted_at`);
+CREATE TABLE IF NOT EXISTS `multimodal_data` (`id` varchar(255),`user_id` integer,`task_id` integer,`text` text,`tokens` jsonb,`semantic_vector` jsonb,`ambiguity_score` real,`image_url` text,`image_attachment_id` varchar(36),`image_format` text,`image_width` integer,`image_height` integer,`image_hash` varchar(16),`capture_session` text,`image_metadata` jsonb,`thumbnails` jsonb,`objects` jsonb,`measurements` jsonb,`image_confidence` real,`mapping_type` text,`correlation_score` real,`context_relevance` real,`historical_accuracy` real,`value` real,`unit` text,`min_range` real,`max_range` real,`confidence` real,`verified` numeric,`user_feedback` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_tasks_multimodal_data` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`),CONSTRAINT `fk_users_multimodal_data` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_multimodal_data_capture_session` ON `multimodal_data`(`capture_session`);
+CREATE INDEX IF NOT EXISTS `idx_multimodal_data_image_hash` ON `multimodal_data`(`image_hash`);
+CREATE INDEX IF NOT EXISTS `idx_multimodal_data_task_id` ON `multimodal_data`(`task_id`);
+CREATE INDEX IF NOT EXISTS `idx_multimodal_data_user_id` ON `multimodal_data`(`user_id`);
+CREATE TABLE IF NOT EXISTS `image_annotations` (`id` varchar(255),`image_id` varchar(255),`task_id` integer,`user_id` integer,`label_id` text,`type` text,`x` real,`y` real,`width` real,`height` real,`label` text,`value` real,`unit` text,`confidence` real,`calibration_id` varchar(255),`uncertainty` real,`created_by` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_image_annotations_label_id` ON `image_annotations`(`label_id`);
+CREATE INDEX IF NOT EXISTS `idx_image_annotations_user_id` ON `image_annotations`(`user_id`);
+CREATE INDEX IF NOT EXISTS `idx_image_annotations_task_id` ON `image_annotations`(`task_id`);
+CREATE INDEX IF NOT EXISTS `idx_image_annotations_image_id` ON `image_annotations`(`image_id`);
+CREATE TABLE IF NOT EXISTS `user_calibrations` (`id` varchar(255),`user_id` integer,`task_id` integer,`image_id` varchar(255),`session` text,`reference_object` text,`dimension` text,`known_length` real,`pixel_length` real,`scale` real,`scale_uncertainty` real,`pixel_uncertainty` real,`measurements` jsonb,`image_url` text,`confidence` real,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_user_calibrations_session` ON `user_calibrations`(`session`);
+CREATE INDEX IF NOT EXISTS `idx_user_calibrations_image_id` ON `user_calibrations`(`image_id`);
+CREATE INDEX IF NOT EXISTS `idx_user_calibrations_task_id` ON `user_calibrations`(`task_id`);
+CREATE INDEX IF NOT EXISTS `idx_user_calibrations_user_id` ON `user_calibrations`(`user_id`);
+CREATE TABLE IF NOT EXISTS `visual_metaphors` (`id` varchar(255),`metaphor` text,`reference_object` text,`width` real,`height` real,`depth` real,`image_url` text,`min_variability` real,`max_variability` real,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `knowledge_patterns` (`id` varchar(255),`task_id` integer,`type` varchar(50),`domain` varchar(100),`tacit_knowledge` text,`explicit_form` text,`conversion_path` jsonb,`accuracy` real,`coverage` real,`consistency` real,`abstract_level` varchar(50),`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_tasks_knowledge_patterns` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_knowledge_patterns_task_id` ON `knowledge_patterns`(`task_id`);
+CREATE TABLE IF NOT EXISTS `language_optimizations` (`id` varchar(255),`task_id` integer,`original_text` text,`optimized_text` text,`domain` varchar(100),`abstraction_level` text,`precision` real,`clarity` real,`completeness` real,`context` jsonb,`transformation` jsonb,`evaluation_score` real,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_tasks_language_optimization` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_language_optimizations_deleted_at` ON `language_optimizations`(`deleted_at`);
+CREATE INDEX IF NOT EXISTS `idx_language_optimizations_task_id` ON `language_optimizations`(`task_id`);
+CREATE TABLE IF NOT EXISTS `learning_patterns` (`id` varchar(255),`user_id` varchar(255) NOT NULL,`pattern_type` varchar(100),`domain` varchar(100),`tacit_knowledge` text,`explicit_form` text,`seci_stage` varchar(50),`method` varchar(100),`accuracy` decimal(5,3),`coverage` decimal(5,3),`consistency` decimal(5,3),`abstract_level` varchar(10),`validated` numeric DEFAULT false,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `phenomenological_frameworks` (`id` varchar(255),`task_id` integer,`name` varchar(255),`description` text,`goal` text,`scope` text,`process` jsonb,`result` jsonb,`feedback` jsonb,`limit_min` real,`limit_max` real,`goal_function` text,`abstract_level` varchar(50),`domain` varchar(100),`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_phenomenological_frameworks_task` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_phenomenological_frameworks_deleted_at` ON `phenomenological_frameworks`(`deleted_at`);
+CREATE INDEX IF NOT EXISTS `idx_phenomenological_frameworks_task_id` ON `phenomenological_frameworks`(`task_id`);
+CREATE TABLE IF NOT EXISTS `optimization_models` (`id` text,`name` text,`type` text,`objective_function` text,`constraints` text,`parameters` text,`performance_metric` text,`iteration_count` real,`convergence_rate` real,`domain` text,`application` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `state_evaluations` (`id` varchar(255),`user_id` varchar(255) NOT NULL,`task_id` integer NOT NULL,`level` integer NOT NULL,`work_target` text,`current_state` JSON,`target_state` JSON,`evaluation_score` decimal(5,2),`framework` varchar(255),`tools` JSON,`process_data` JSON,`results` JSON,`learned_knowledge` text,`status` varchar(50) DEFAULT "pending",`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `tool_matching_results` (`id` varchar(255),`state_evaluation_id` varchar(255) NOT NULL,`robot_id` varchar(255),`optimization_model_id` varchar(255),`matching_score` decimal(5,3),`rank` integer,`recommendations` JSON,`parameters` JSON,`expected_performance` JSON,`created_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `robot_specifications` (`id` varchar(255),`model_name` varchar(255) NOT NULL,`dof` integer,`reach_mm` real,`payload_kg` real,`repeat_accuracy_mm` real,`max_speed_mm_s` real,`work_envelope_shape` varchar(100),`teaching_method` varchar(100),`control_type` varchar(100),`vision_system` varchar(100),`force_sensor` varchar(100),`ai_capability` JSON,`safety_features` JSON,`maintenance_interval_hours` integer,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `process_monitorings` (`id` varchar(255),`state_evaluation_id` varchar(255) NOT NULL,`process_type` varchar(100),`monitoring_data` JSON,`metrics` JSON,`anomalies` JSON,`detector_config` JSON,`summary` JSON,`status` varchar(50),`start_time` datetime,`end_time` datetime,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+CREATE TABLE IF NOT EXISTS `process_monitoring_samples` (`id` integer PRIMARY KEY AUTOINCREMENT,`process_monitoring_id` varchar(255) NOT NULL,`metric` varchar(100) NOT NULL,`value` real,`anomalous` numeric,`recorded_at` datetime,`created_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_process_monitoring_samples_recorded_at` ON `process_monitoring_samples`(`recorded_at`);
+CREATE INDEX IF NOT EXISTS `idx_process_monitoring_samples_process_monitoring_id` ON `process_monitoring_samples`(`process_monitoring_id`);
+CREATE TABLE IF NOT EXISTS `process_optimizations` (`id` varchar(255),`task_id` integer,`process_id` text,`optimization_model_id` varchar(255),`optimization_type` text,`initial_state` jsonb,`optimized_state` jsonb,`metric` text,`metric_direction` text,`improvement` real,`method` text,`iterations` integer,`convergence_time` real,`created_by` integer,`validation_status` varchar(20) DEFAULT "pending",`validation_comment` text,`validated_by` text,`validation_date` datetime,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_process_optimizations_deleted_at` ON `process_optimizations`(`deleted_at`);
+CREATE INDEX IF NOT EXISTS `idx_process_optimizations_validation_status` ON `process_optimizations`(`validation_status`);
+CREATE INDEX IF NOT EXISTS `idx_process_optimizations_created_by` ON `process_optimizations`(`created_by`);
+CREATE INDEX IF NOT EXISTS `idx_process_optimizations_optimization_model_id` ON `process_optimizations`(`optimization_model_id`);
+CREATE INDEX IF NOT EXISTS `idx_process_optimizations_process_id` ON `process_optimizations`(`process_id`);
+CREATE INDEX IF NOT EXISTS `idx_process_optimizations_task_id` ON `process_optimizations`(`task_id`);
+CREATE TABLE IF NOT EXISTS `qualitative_labels` (`id` varchar(255),`task_id` integer,`user_id` integer,`content` text,`category` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_tasks_qualitative_labels` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_qualitative_labels_category` ON `qualitative_labels`(`category`);
+CREATE INDEX IF NOT EXISTS `idx_qualitative_labels_user_id` ON `qualitative_labels`(`user_id`);
+CREATE INDEX IF NOT EXISTS `idx_qualitative_labels_task_id` ON `qualitative_labels`(`task_id`);
+CREATE TABLE IF NOT EXISTS `quantification_labels` (`id` varchar(255),`user_id` integer,`task_id` integer,`original_text` text,`normalized_text` text,`category` text,`context` text,`domain` text,`image_url` text,`value` real,`unit` text,`min_range` real,`max_range` real,`typical_value` real,`precision` integer,`confidence` real,`abstract_level` text,`related_concepts` JSON,`status` text DEFAULT "draft",`review_round` integer,`accuracy` real,`agreement` real,`verification_count` integer,`last_verified` datetime,`source` text,`validated` numeric DEFAULT false,`public_visibility` numeric DEFAULT true,`tags` JSON,`notes` text,`version` integer DEFAULT 1,`created_by` text,`updated_by` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_users_quantification_labels` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),CONSTRAINT `fk_tasks_quantification_labels` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_validated` ON `quantification_labels`(`validated`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_source` ON `quantification_labels`(`source`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_status` ON `quantification_labels`(`status`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_abstract_level` ON `quantification_labels`(`abstract_level`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_unit` ON `quantification_labels`(`unit`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_domain` ON `quantification_labels`(`domain`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_category` ON `quantification_labels`(`category`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_task_id` ON `quantification_labels`(`task_id`);
+CREATE INDEX IF NOT EXISTS `idx_quantification_labels_user_id` ON `quantification_labels`(`user_id`);
+CREATE TABLE IF NOT EXISTS `label_revisions` (`id` varchar(255),`label_id` text,`version` integer,`changes` jsonb,`comment` text,`user_id` text,`timestamp` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_label_revisions_label_id` ON `label_revisions`(`label_id`);
+CREATE TABLE IF NOT EXISTS `label_relations` (`id` varchar(255),`user_id` integer,`source_id` text,`target_id` text,`relation_type` text,`strength` real,`bidirectional` numeric,`context` text,`confidence` real,`created_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_label_relations_target_id` ON `label_relations`(`target_id`);
+CREATE INDEX IF NOT EXISTS `idx_label_relations_source_id` ON `label_relations`(`source_id`);
+CREATE INDEX IF NOT EXISTS `idx_label_relations_user_id` ON `label_relations`(`user_id`);
+CREATE TABLE IF NOT EXISTS `label_verifications` (`id` varchar(255),`label_id` varchar(255),`round` integer,`verifier_id` integer,`decision` text,`criteria` jsonb,`comment` text,`created_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_label_verifications_verifier_id` ON `label_verifications`(`verifier_id`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_label_verification` ON `label_verifications`(`label_id`,`round`,`verifier_id`);
+CREATE TABLE IF NOT EXISTS `label_datasets` (`id` varchar(255),`user_id` integer,`name` text,`description` text,`domain` text,`total_labels` integer,`verified_labels` integer,`average_accuracy` real,`completeness` real,`consistency` real,`diversity` real,`balance` real,`version` text,`license` text,`citation` text,`created_by` text,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_label_datasets_deleted_at` ON `label_datasets`(`deleted_at`);
+CREATE INDEX IF NOT EXISTS `idx_label_datasets_domain` ON `label_datasets`(`domain`);
+CREATE INDEX IF NOT EXISTS `idx_label_datasets_user_id` ON `label_datasets`(`user_id`);
+CREATE TABLE IF NOT EXISTS `dataset_labels` (`label_dataset_id` varchar(255),`quantification_label_id` varchar(255),PRIMARY KEY (`label_dataset_id`,`quantification_label_id`),CONSTRAINT `fk_dataset_labels_label_dataset` FOREIGN KEY (`label_dataset_id`) REFERENCES `label_datasets`(`id`),CONSTRAINT `fk_dataset_labels_quantification_label` FOREIGN KEY (`quantification_label_id`) REFERENCES `quantification_labels`(`id`));
+CREATE TABLE IF NOT EXISTS `label_dataset_snapshots` (`id` varchar(255),`dataset_id` varchar(255),`version` integer,`label_count` integer,`metrics` jsonb,`checksum` text,`content` text,`created_by` text,`created_at` datetime,PRIMARY KEY (`id`));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_dataset_snapshot_version` ON `label_dataset_snapshots`(`dataset_id`,`version`);
+CREATE TABLE IF NOT EXISTS `spc_measurements` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`task_id` integer NOT NULL,`label_id` varchar(255) NOT NULL,`value` real,`unit` varchar(50),`subgroup` varchar(100),`measured_at` datetime,`created_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_spc_measurements_measured_at` ON `spc_measurements`(`measured_at`);
+CREATE INDEX IF NOT EXISTS `idx_spc_measurement_series` ON `spc_measurements`(`task_id`,`label_id`);
+CREATE INDEX IF NOT EXISTS `idx_spc_measurements_user_id` ON `spc_measurements`(`user_id`);
+CREATE TABLE IF NOT EXISTS `teaching_free_controls` (`id` varchar(255),`task_id` integer,`robot_id` text,`task_type` text,`vision_system` jsonb,`force_control` jsonb,`ai_model` jsonb,`learning_data` jsonb,`success_rate` real,`adaptation_time` real,`error_recovery` jsonb,`performance_log` jsonb,`created_at` datetime,`updated_at` datetime,`deleted_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_teaching_free_controls_task` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_teaching_free_controls_deleted_at` ON `teaching_free_controls`(`deleted_at`);
+CREATE INDEX IF NOT EXISTS `idx_teaching_free_controls_robot_id` ON `teaching_free_controls`(`robot_id`);
+CREATE INDEX IF NOT EXISTS `idx_teaching_free_controls_task_id` ON `teaching_free_controls`(`task_id`);
+CREATE TABLE IF NOT EXISTS `teaching_free_control_attempts` (`id` integer PRIMARY KEY AUTOINCREMENT,`teaching_free_control_id` varchar(255) NOT NULL,`robot_id` varchar(255),`task_type` varchar(255),`outcome` varchar(20) NOT NULL,`error_type` varchar(100),`recovery_action` varchar(100),`recovered` numeric,`duration` real,`source` varchar(20) DEFAULT "robot",`detail` jsonb,`attempted_at` datetime,`created_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_teaching_free_control_attempts_attempted_at` ON `teaching_free_control_attempts`(`attempted_at`);
+CREATE INDEX IF NOT EXISTS `idx_tfc_attempt_curve` ON `teaching_free_control_attempts`(`robot_id`,`task_type`);
+CREATE INDEX IF NOT EXISTS `idx_teaching_free_control_attempts_teaching_free_control_id` ON `teaching_free_control_attempts`(`teaching_free_control_id`);
+CREATE TABLE IF NOT EXISTS `knowledge_entities` (`id` varchar(255),`task_id` integer,`entity_type` text,`reference_id` text,`domain` text,`abstract_level` text,`source` text,`tags` jsonb,`linked_entity_ids` jsonb,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_knowledge_entities_task` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_knowledge_entities_domain` ON `knowledge_entities`(`domain`);
+CREATE INDEX IF NOT EXISTS `idx_knowledge_entities_reference_id` ON `knowledge_entities`(`reference_id`);
+CREATE INDEX IF NOT EXISTS `idx_knowledge_entities_entity_type` ON `knowledge_entities`(`entity_type`);
+CREATE INDEX IF NOT EXISTS `idx_knowledge_entities_task_id` ON `knowledge_entities`(`task_id`);
+CREATE TABLE IF NOT EXISTS `audit_logs` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer,`username` text,`role` varchar(50),`method` varchar(10),`route` text,`path` text,`resource_type` varchar(100),`resource_id` varchar(255),`before_hash` varchar(64),`after_hash` varchar(64),`status_code` integer,`ip` varchar(64),`created_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_audit_logs_created_at` ON `audit_logs`(`created_at`);
+CREATE INDEX IF NOT EXISTS `idx_audit_logs_resource_id` ON `audit_logs`(`resource_id`);
+CREATE INDEX IF NOT EXISTS `idx_audit_logs_resource_type` ON `audit_logs`(`resource_type`);
+CREATE INDEX IF NOT EXISTS `idx_audit_logs_method` ON `audit_logs`(`method`);
+CREATE INDEX IF NOT EXISTS `idx_audit_logs_user_id` ON `audit_logs`(`user_id`);
+CREATE TABLE IF NOT EXISTS `sessions` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer NOT NULL,`device_name` varchar(255),`user_agent` text,`ip` varchar(64),`created_at` datetime,`last_used_at` datetime,`expires_at` datetime,`revoked_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_sessions_revoked_at` ON `sessions`(`revoked_at`);
+CREATE INDEX IF NOT EXISTS `idx_sessions_expires_at` ON `sessions`(`expires_at`);
+CREATE INDEX IF NOT EXISTS `idx_sessions_user_id` ON `sessions`(`user_id`);
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (`id` integer PRIMARY KEY AUTOINCREMENT,`session_id` integer NOT NULL,`token_hash` varchar(64) NOT NULL,`expires_at` datetime,`used_at` datetime,`created_at` datetime,CONSTRAINT `fk_refresh_tokens_session` FOREIGN KEY (`session_id`) REFERENCES `sessions`(`id`));
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_refresh_tokens_token_hash` ON `refresh_tokens`(`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_refresh_tokens_session_id` ON `refresh_tokens`(`session_id`);
+CREATE TABLE IF NOT EXISTS `workspaces` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` varchar(255) NOT NULL,`description` text,`created_by` integer,`created_at` datetime,`updated_at` datetime);
+CREATE INDEX IF NOT EXISTS `idx_workspaces_created_by` ON `workspaces`(`created_by`);
+CREATE TABLE IF NOT EXISTS `workspace_members` (`id` integer PRIMARY KEY AUTOINCREMENT,`workspace_id` integer NOT NULL,`user_id` integer NOT NULL,`role` varchar(20) NOT NULL,`created_at` datetime,`updated_at` datetime,CONSTRAINT `fk_workspace_members_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),CONSTRAINT `fk_workspaces_members` FOREIGN KEY (`workspace_id`) REFERENCES `workspaces`(`id`));
+CREATE INDEX IF NOT EXISTS `idx_workspace_members_user_id` ON `workspace_members`(`user_id`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_workspace_member` ON `workspace_members`(`workspace_id`,`user_id`);
+CREATE TABLE IF NOT EXISTS `workspace_invitations` (`id` integer PRIMARY KEY AUTOINCREMENT,`workspace_id` integer NOT NULL,`email` varchar(255) NOT NULL,`role` varchar(20) NOT NULL,`token_hash` varchar(64) NOT NULL,`invited_by` integer,`expires_at` datetime,`accepted_at` datetime,`accepted_by` integer,`created_at` datetime);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_workspace_invitations_token_hash` ON `workspace_invitations`(`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_workspace_invitations_email` ON `workspace_invitations`(`email`);
+CREATE INDEX IF NOT EXISTS `idx_workspace_invitations_workspace_id` ON `workspace_invitations`(`workspace_id`);
+CREATE TABLE IF NOT EXISTS `attachments` (`id` varchar(36),`user_id` integer NOT NULL,`resource_type` varchar(100) NOT NULL,`resource_id` varchar(255) NOT NULL,`file_name` varchar(255),`content_type` varchar(255),`size` integer,`sha256` char(64) NOT NULL,`description` varchar(200),`created_at` datetime,PRIMARY KEY (`id`));
+CREATE INDEX IF NOT EXISTS `idx_attachments_sha256` ON `attachments`(`sha256`);
+CREATE INDEX IF NOT EXISTS `idx_attachment_resource` ON `attachments`(`resource_type`,`resource_id`);
+CREATE INDEX IF NOT EXISTS `idx_attachments_user_id` ON `attachments`(`user_id`);
+CREATE TABLE IF NOT EXISTS `attachment_blobs` (`sha256` char(64),`size` integer,`created_at` datetime,PRIMARY KEY (`sha256`));
+CREATE TABLE IF NOT EXISTS `seed_runs` (`name` varchar(100),`checksum` varchar(64) NOT NULL,`applied_at` datetime,PRIMARY KEY (`name`));
@@ -0,0 +1,4 @@
+ALTER TABLE technical_factors DROP CONSTRAINT IF EXISTS fk_memory_contexts_technical_factors;
+ALTER TABLE technical_factors
+    ADD CONSTRAINT fk_memory_contexts_technical_factors
+    FOREIGN KEY (context_id) REFERENCES memory_contexts(id) NOT VALID;
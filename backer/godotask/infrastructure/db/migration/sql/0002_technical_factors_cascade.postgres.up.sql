@@ -0,0 +1,6 @@
+-- scripts/fix_table.sql の置き換え。technical_factors を作り直さずに、memory_contexts の削除で技術要因も消えるようにする
+-- 既存の行は検証しない（NOT VALID）。参照先の無い古い行は残る
+ALTER TABLE technical_factors DROP CONSTRAINT IF EXISTS fk_memory_contexts_technical_factors;
+ALTER TABLE technical_factors
+    ADD CONSTRAINT fk_memory_contexts_technical_factors
+    FOREIGN KEY (context_id) REFERENCES memory_contexts(id) ON DELETE CASCADE NOT VALID;
@@ -8,6 +8,7 @@ import (
 type Task struct {
 	ID          int        `gorm:"primaryKey" json:"id"`
 	UserID      int        `json:"user_id"`
+	WorkspaceID *uint      `json:"workspace_id" gorm:"index"` // nil の場合は個人のタスク
 	MemoryID    int       `json:"memory_id"`
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// ExportSchemaVersion はアカウントのエクスポートの形式の版
+// ファイルの構成や列を変える場合は上げ、インポートで古い版を読めるようにする
+const ExportSchemaVersion = 1
+
+// AccountData はアカウントのエクスポートに含めるユーザーのレコード
+type AccountData struct {
+	Tasks                    []Task
+	Memories                 []Memory
+	MemoryContexts           []MemoryContext
+	TechnicalFactors         []TechnicalFactor
+	KnowledgeTransformations []KnowledgeTransformation
+	Assessments              []Assessment
+	Books                    []Book
+	HeuristicsAnalyses       []HeuristicsAnalysis
+	HeuristicsInsights       []HeuristicsInsight
+	HeuristicsPatterns       []HeuristicsPattern
+	HeuristicsTrackings      []HeuristicsTracking
+	HeuristicsModelers       []HeuristicsModeler
+	KnowledgePatterns        []KnowledgePattern
+	QuantificationLabels     []QuantificationLabel
+	QualitativeLabels        []QualitativeLabel
+	LabelRelations           []LabelRelation
+}
+
+// ExportManifest はエクスポートの zip の manifest.json
+type ExportManifest struct {
+	SchemaVersion int          `json:"schema_version"`
+	Format        string       `json:"format"` // json, csv
+	ExportedAt    time.Time    `json:"exported_at"`
+	UserID        int          `json:"user_id"` // エクスポートしたユーザー（インポート先では使わない）
+	Files         []ExportFile `json:"files"`
+}
+
+// ExportFile は zip に含めたリソースのファイル
+type ExportFile struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+}
+
+// AccountImportResult はアカウントのインポートの結果
+// Imported はリソースごとの作成した件数、Skipped は参照先が無いなどで作成しなかったレコード
+type AccountImportResult struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Imported      map[string]int      `json:"imported"`
+	Skipped       []AccountImportSkip `json:"skipped"`
+}
+
+// AccountImportSkip は作成しなかったレコード。ID はエクスポート元の ID
+type AccountImportSkip struct {
+	Resource string `json:"resource"`
+	ID       string `json:"id"`
+	Reason   string `json:"reason"`
+}
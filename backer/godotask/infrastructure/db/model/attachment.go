@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Attachment はリソース（タスク・本など）に添付されたファイル
+// 内容は SHA256 をキーに BlobStore に保存し、同じ内容の添付は1つのブロブを共有する
+type Attachment struct {
+	ID           string    `gorm:"type:varchar(36);primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;index" json:"user_id"` // アップロードしたユーザー
+	ResourceType string    `gorm:"type:varchar(100);not null;index:idx_attachment_resource" json:"resource_type"`
+	ResourceID   string    `gorm:"type:varchar(255);not null;index:idx_attachment_resource" json:"resource_id"`
+	FileName     string    `gorm:"type:varchar(255)" json:"file_name"`
+	ContentType  string    `gorm:"type:varchar(255)" json:"content_type"`
+	Size         int64     `json:"size"`
+	SHA256       string    `gorm:"type:char(64);not null;index" json:"sha256"`
+	Description  string    `gorm:"type:varchar(200)" json:"description"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AttachmentBlob は BlobStore に保存したブロブ。添付から参照されなくなったら削除する
+// ブロブの保存・削除はこの行をロックして行い、同じ内容の添付の作成と削除が競合しないようにする
+type AttachmentBlob struct {
+	SHA256    string    `gorm:"type:char(64);primaryKey" json:"sha256"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
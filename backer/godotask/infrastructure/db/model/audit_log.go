@@ -0,0 +1,42 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAuditLogImmutable は監査ログを更新・削除しようとした場合のエラー
+var ErrAuditLogImmutable = errors.New("audit log is append-only")
+
+// AuditLog - 認証済みユーザーによる変更操作（POST/PUT/DELETE）の監査ログ
+// 追記のみを許可し、更新・削除はフックで拒否する
+type AuditLog struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID       uint      `json:"user_id" gorm:"index"`
+	Username     string    `json:"username"`
+	Role         string    `json:"role" gorm:"type:varchar(50)"`
+	Method       string    `json:"method" gorm:"type:varchar(10);index"`
+	Route        string    `json:"route"` // 例: /api/task/:id
+	Path         string    `json:"path"`  // 例: /api/task/12
+	ResourceType string    `json:"resource_type" gorm:"type:varchar(100);index"`
+	ResourceID   string    `json:"resource_id" gorm:"type:varchar(255);index"`
+	BeforeHash   string    `json:"before_hash" gorm:"type:varchar(64)"` // 変更前レコードの SHA-256
+	AfterHash    string    `json:"after_hash" gorm:"type:varchar(64)"`  // 変更後レコードの SHA-256
+	StatusCode   int       `json:"status_code"`
+	IP           string    `json:"ip" gorm:"type:varchar(64)"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+func (AuditLog) BeforeUpdate(tx *gorm.DB) error {
+	return ErrAuditLogImmutable
+}
+
+func (AuditLog) BeforeDelete(tx *gorm.DB) error {
+	return ErrAuditLogImmutable
+}
@@ -0,0 +1,25 @@
+package model
+
+// ImportResult - 一括インポートの結果
+// DryRun の場合は検証のみ行い、Committed は常に false。
+// 誤りのある行が 1 つでもあれば何も保存しない
+type ImportResult struct {
+	Resource  string           `json:"resource"`
+	Format    string           `json:"format"`
+	DryRun    bool             `json:"dry_run"`
+	Committed bool             `json:"committed"`
+	Total     int              `json:"total"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Failed    int              `json:"failed"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// ImportRowError - インポートの行の誤り
+// Row は CSV ではヘッダーを 1 行目とした行番号、JSON では配列の 1 始まりの位置
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
@@ -7,6 +7,7 @@ import (
 type Memory struct {
 	ID         int       `gorm:"primaryKey" json:"id"`
 	UserID     int       `json:"user_id"`
+	WorkspaceID *uint     `json:"workspace_id" gorm:"index"` // nil の場合は個人のメモリ
 	SourceType string    `json:"source_type" gorm:"default:book"`
 	Title      string    `json:"title"`
 	Author     string    `json:"author"`
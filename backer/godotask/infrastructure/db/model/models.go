@@ -17,6 +17,9 @@ func Models() []interface{} {
 		&HeuristicsPattern{},
 		&HeuristicsModeler{},
 		&MultimodalData{},
+		&ImageAnnotation{},
+		&UserCalibration{},
+		&VisualMetaphor{},
 		&KnowledgePattern{},
 		&LanguageOptimization{},
 		&LearningPattern{},
@@ -24,11 +27,30 @@ func Models() []interface{} {
 		&OptimizationModel{},
 		&StateEvaluation{},
 		&ToolMatchingResult{},
+		&RobotSpecification{},
+		&ProcessMonitoring{},
+		&ProcessMonitoringSample{},
 
 		&ProcessOptimization{},
 		&QualitativeLabel{},
 		&QuantificationLabel{},
+		&LabelRevision{},
+		&LabelRelation{},
+		&LabelVerification{},
+		&LabelDataset{},
+		&LabelDatasetSnapshot{},
+		&SPCMeasurement{},
 		&TeachingFreeControl{},
+		&TeachingFreeControlAttempt{},
 		&KnowledgeEntity{},
+		&AuditLog{},
+		&Session{},
+		&RefreshToken{},
+		&Workspace{},
+		&WorkspaceMember{},
+		&WorkspaceInvitation{},
+		&Attachment{},
+		&AttachmentBlob{},
+		&SeedRun{},
 	}
 }
@@ -20,3 +20,17 @@ type OptimizationModel struct {
 	// 補助情報（関連テーブルがある場合の例）
 	// ParametersList []OptimizationParameter `gorm:"foreignKey:ModelID" json:"parameters_list"`
 }
+
+// OptimizationRunRequest は最適化モデルの実行リクエスト
+// Inputs はモデルの式が参照する値、Initial は決定変数の初期値（省略時は範囲の中央）
+type OptimizationRunRequest struct {
+	TaskID           int                `json:"task_id" binding:"required"`
+	ProcessID        string             `json:"process_id"`
+	OptimizationType string             `json:"optimization_type"`
+	Method           string             `json:"method" binding:"required"`
+	Inputs           map[string]float64 `json:"inputs"`
+	Initial          map[string]float64 `json:"initial"`
+	MaxIterations    int                `json:"max_iterations"`
+	Tolerance        float64            `json:"tolerance"`
+	Seed             int64              `json:"seed"`
+}
@@ -27,3 +27,10 @@ type PhenomenologicalFramework struct {
 
 	Task Task `json:"task" gorm:"foreignKey:TaskID"`
 }
+
+// PhenomenologicalFrameworkEvaluationRequest は目標関数の評価リクエスト
+// TaskID を省略した場合はフレームワークのタスクで評価する。Variables はタスクの指標より優先する
+type PhenomenologicalFrameworkEvaluationRequest struct {
+	TaskID    int                `json:"task_id"`
+	Variables map[string]float64 `json:"variables"`
+}
@@ -10,16 +10,38 @@ type ProcessOptimization struct {
 	ID              string    `gorm:"type:varchar(255);primaryKey" json:"id"`
 	TaskID        int            `json:"task_id" gorm:"index"`
 	ProcessID       string    `json:"process_id" gorm:"index"`
+	// OptimizationModelID は最適化モデルの実行で作られた記録の場合の実行元モデル
+	OptimizationModelID string `json:"optimization_model_id,omitempty" gorm:"type:varchar(255);index"`
 	OptimizationType string   `json:"optimization_type"` // speed, accuracy, energy, cost
 	InitialState    JSON      `json:"initial_state" gorm:"type:jsonb"`
 	OptimizedState  JSON      `json:"optimized_state" gorm:"type:jsonb"`
-	Improvement     float64   `json:"improvement"` // 改善率（%）
+	// Metric は InitialState / OptimizedState から改善率を計算する指標（"derived.cycle_time" のようにドット区切りで入れ子を指定できる）
+	Metric          string    `json:"metric"`
+	MetricDirection string    `json:"metric_direction"` // minimize, maximize
+	Improvement     float64   `json:"improvement"` // 改善率（%）。Metric から計算する
 	Method          string    `json:"method" gorm:"type:text"`
 	Iterations      int       `json:"iterations"`
 	ConvergenceTime float64   `json:"convergence_time"` // 収束時間（秒）
-	ValidatedBy     string    `json:"validated_by"`
-	ValidationDate  time.Time `json:"validation_date"`
+	// CreatedBy は記録したユーザー。検証は別のユーザーが行う
+	CreatedBy         uint      `json:"created_by" gorm:"index"`
+	ValidationStatus  string    `json:"validation_status" gorm:"type:varchar(20);index;default:pending"` // pending, verified, rejected
+	ValidationComment string    `json:"validation_comment" gorm:"type:text"`
+	ValidatedBy       string    `json:"validated_by"` // 検証したユーザーID
+	ValidationDate    time.Time `json:"validation_date"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
+
+// 検証状態
+const (
+	ValidationPending  = "pending"
+	ValidationVerified = "verified"
+	ValidationRejected = "rejected"
+)
+
+// ProcessOptimizationValidationRequest は最適化結果の検証リクエスト
+type ProcessOptimizationValidationRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=verify reject"`
+	Comment  string `json:"comment"`
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"time"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"github.com/godotask/lib"
@@ -20,51 +21,57 @@ type QuantificationLabel struct {
 	OriginalText    string `json:"original_text" gorm:"type:text"`
 	NormalizedText  string `json:"normalized_text" gorm:"type:text"`
 	Category        string `json:"category" gorm:"index"`
-	// Context         *string `json:"context" gorm:"type:text"`
+	Context         string `json:"context" gorm:"type:text"`
 	Domain          string `json:"domain" gorm:"index"`
 
 	// 画像情報
-	// ImageURL         *string `json:"image_url" gorm:"type:text"`
+	ImageURL         string `json:"image_url" gorm:"type:text"`
 	// ThumbnailURL     *string `json:"thumbnail_url" gorm:"type:text"`
 	// ImageDescription *string `json:"image_description" gorm:"type:text"`
 	// ImageMetadata    *JSON   `json:"image_metadata" gorm:"type:jsonb"`
 
-	// 定量化情報
-	// Value       *float64 `json:"value"`
-	// Unit        *string  `json:"unit" gorm:"index"`
-	// MinRange    *float64 `json:"min_range"`
-	// MaxRange    *float64 `json:"max_range"`
-	// TypicalValue *float64 `json:"typical_value"`
-	// Precision   *int     `json:"precision"`
-	// Confidence  *float64 `json:"confidence"`
+	// 定量化情報（MinRange / MaxRange は SPC の規格限界としても使う）
+	Value        *float64 `json:"value"`
+	Unit         *string  `json:"unit" gorm:"index"`
+	MinRange     *float64 `json:"min_range"`
+	MaxRange     *float64 `json:"max_range"`
+	TypicalValue *float64 `json:"typical_value"`
+	Precision    *int     `json:"precision"`
+	Confidence   *float64 `json:"confidence"`
 
 	// 概念情報
-	// AbstractLevel     *string `json:"abstract_level" gorm:"index"` // concrete, semi-abstract, abstract
+	AbstractLevel     string `json:"abstract_level" gorm:"index"` // concrete, semi-abstract, abstract
 	// CulturalContext   *string `json:"cultural_context"`
 	// TemporalContext   *string `json:"temporal_context"`
 	// SpatialContext    *string `json:"spatial_context"`
-	// RelatedConcepts   *JSON   `json:"related_concepts" gorm:"type:jsonb"`
+	RelatedConcepts   datatypes.JSON `json:"related_concepts" gorm:"type:jsonb"` // 文字列の配列
 	// SemanticTags      *JSON   `json:"semantic_tags" gorm:"type:jsonb"`
 
 	// 評価情報
-	// Accuracy       *float64 `json:"accuracy"`
+	// Status は検証の状態（draft, submitted, accepted, rejected）。ReviewRound は提出の回数で、検証は回ごとに記録する
+	// Accuracy は検証者が値・単位などを正しいとした割合、Agreement は検証者間の一致度（kappa）
+	Status            string     `json:"status" gorm:"index;default:draft"`
+	ReviewRound       int        `json:"review_round"`
+	Accuracy          *float64   `json:"accuracy"`
+	Agreement         *float64   `json:"agreement"`
 	// Consistency    *float64 `json:"consistency"`
 	// Reproducibility *float64 `json:"reproducibility"`
 	// Usability      *float64 `json:"usability"`
-	// VerificationCount *int  `json:"verification_count"`
-	// LastVerified   *time.Time `json:"last_verified"`
+	VerificationCount int        `json:"verification_count"`
+	LastVerified      *time.Time `json:"last_verified"`
 
 	// メタデータ
-	// Source           *string `json:"source" gorm:"index"` // manual, automatic, hybrid
-	// Validated        *bool   `json:"validated" gorm:"index;default:false"`
-	// PublicVisibility *bool   `json:"public_visibility" gorm:"default:true"`
-	// Tags             *JSON   `json:"tags" gorm:"type:jsonb"`
-	// Notes            *string `json:"notes" gorm:"type:text"`
+	// 検証済み（Validated）かつ公開（PublicVisibility）のラベルは他のユーザーへの値の提案にも使う
+	Source           string `json:"source" gorm:"index"` // manual, automatic, hybrid
+	Validated        bool   `json:"validated" gorm:"index;default:false"`
+	PublicVisibility *bool  `json:"public_visibility" gorm:"default:true"`
+	Tags             datatypes.JSON `json:"tags" gorm:"type:jsonb"` // 文字列の配列
+	Notes            string `json:"notes" gorm:"type:text"`
 
 	// 履歴管理
-	// Version   *int    `json:"version" gorm:"default:1"`
-	// CreatedBy *string `json:"created_by"`
-	// UpdatedBy *string `json:"updated_by"`
+	Version   int    `json:"version" gorm:"default:1"`
+	CreatedBy string `json:"created_by"`
+	UpdatedBy string `json:"updated_by"`
 
 	// タイムスタンプ
 	CreatedAt time.Time      `json:"created_at"`
@@ -77,8 +84,13 @@ type QuantificationLabel struct {
 }
 
 // ImageAnnotation - 画像アノテーション
+// 座標は MultimodalData の画像（EXIF の向きを適用した表示上の向き）のピクセル座標
+// measurement は (X, Y) から (X+Width, Y+Height) への線分
 type ImageAnnotation struct {
 	ID       string  `gorm:"type:varchar(255);primaryKey" json:"id"`
+	ImageID  string  `json:"image_id" gorm:"type:varchar(255);index"` // MultimodalData.ID
+	TaskID   uint    `json:"task_id" gorm:"index"`
+	UserID   uint    `json:"user_id" gorm:"index"`
 	LabelID  string  `json:"label_id" gorm:"index"`
 	Type     string  `json:"type"` // region, point, measurement, text
 	X        float64 `json:"x"`
@@ -89,8 +101,12 @@ type ImageAnnotation struct {
 	Value    float64 `json:"value"`
 	Unit     string  `json:"unit"`
 	Confidence float64 `json:"confidence"`
+	// 校正を適用した場合の UserCalibration.ID と Value の標準不確かさ（Unit と同じ単位）
+	CalibrationID string  `json:"calibration_id" gorm:"type:varchar(255)"`
+	Uncertainty   float64 `json:"uncertainty"`
 	CreatedBy  string  `json:"created_by"`
 	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 // LabelRevision - ラベル改訂履歴
@@ -104,9 +120,24 @@ type LabelRevision struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// LabelVerification - ラベルの検証
+// 検証者はラベルの提出の回（Round）ごとに 1 回だけ検証できる
+type LabelVerification struct {
+	ID         string    `gorm:"type:varchar(255);primaryKey" json:"id"`
+	LabelID    string    `json:"label_id" gorm:"type:varchar(255);uniqueIndex:idx_label_verification"`
+	Round      int       `json:"round" gorm:"uniqueIndex:idx_label_verification"`
+	VerifierID uint      `json:"verifier_id" gorm:"uniqueIndex:idx_label_verification;index"`
+	Decision   string    `json:"decision"` // accept, reject
+	Criteria   JSON      `json:"criteria" gorm:"type:jsonb"` // 判定項目（value, unit, range, category）→ correct / incorrect
+	Comment    string    `json:"comment" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // LabelDataset - ラベルデータセット
+// 統計情報と品質メトリクスはラベルの追加・変更時とスナップショットの作成時に再計算する
 type LabelDataset struct {
 	ID          string `gorm:"type:varchar(255);primaryKey" json:"id"`
+	UserID      int    `json:"user_id" gorm:"index"`
 	Name        string `json:"name"`
 	Description string `json:"description" gorm:"type:text"`
 	Domain      string `json:"domain" gorm:"index"`
@@ -137,9 +168,25 @@ type LabelDataset struct {
 	Labels []QuantificationLabel `json:"labels" gorm:"many2many:dataset_labels;"`
 }
 
+// LabelDatasetSnapshot - データセットのスナップショット
+// Content はスナップショット時点のラベルを 1 行 1 件の JSON にしたもの（JSONL）
+type LabelDatasetSnapshot struct {
+	ID         string    `gorm:"type:varchar(255);primaryKey" json:"id"`
+	DatasetID  string    `json:"dataset_id" gorm:"type:varchar(255);uniqueIndex:idx_dataset_snapshot_version"`
+	Version    int       `json:"version" gorm:"uniqueIndex:idx_dataset_snapshot_version"`
+	LabelCount int       `json:"label_count"`
+	Metrics    JSON      `json:"metrics" gorm:"type:jsonb"`
+	Checksum   string    `json:"checksum"` // Content の SHA-256
+	Content    string    `json:"-" gorm:"type:text"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // LabelRelation - ラベル間の関係
+// hypernym は Target が Source の上位概念、hyponym は下位概念。同義語は双方向、上位・下位の関係は単方向
 type LabelRelation struct {
 	ID           string  `gorm:"type:varchar(255);primaryKey" json:"id"`
+	UserID       int     `json:"user_id" gorm:"index"` // 関係を作成したユーザー
 	SourceID     string  `json:"source_id" gorm:"index"`
 	TargetID     string  `json:"target_id" gorm:"index"`
 	RelationType string  `json:"relation_type"` // synonym, hypernym, hyponym, etc.
@@ -151,6 +198,8 @@ type LabelRelation struct {
 }
 
 // VisualMetaphor - 視覚的メタファー
+// 校正の基準物のカタログ。Width / Height / Depth は mm、
+// MinVariability / MaxVariability は寸法の相対的なばらつきの範囲（例: -0.01〜0.01）
 type VisualMetaphor struct {
 	ID              string  `gorm:"type:varchar(255);primaryKey" json:"id"`
 	Metaphor        string  `json:"metaphor"`
@@ -166,11 +215,21 @@ type VisualMetaphor struct {
 }
 
 // UserCalibration - ユーザーキャリブレーション
+// 画像上で既知の長さの基準物に引いた線分から求めた縮尺。同じ画像と、同じタスク・セッションの画像の測定に適用する
 type UserCalibration struct {
 	ID              string    `gorm:"type:varchar(255);primaryKey" json:"id"`
 	UserID          uint      `json:"user_id" gorm:"index"`
-	ReferenceObject string    `json:"reference_object"`
-	Measurements    JSON      `json:"measurements" gorm:"type:jsonb"`
+	TaskID          uint      `json:"task_id" gorm:"index"`
+	ImageID         string    `json:"image_id" gorm:"type:varchar(255);index"` // MultimodalData.ID
+	Session         string    `json:"session" gorm:"index"`
+	ReferenceObject string    `json:"reference_object"` // VisualMetaphor.ID（カタログに無い基準物は空）
+	Dimension       string    `json:"dimension"`        // width, height, depth
+	KnownLength     float64   `json:"known_length"`     // mm
+	PixelLength     float64   `json:"pixel_length"`
+	Scale           float64   `json:"scale"`             // mm / px
+	ScaleUncertainty float64  `json:"scale_uncertainty"` // 縮尺の相対標準不確かさ
+	PixelUncertainty float64  `json:"pixel_uncertainty"` // 線分の長さの標準不確かさ（px）
+	Measurements    JSON      `json:"measurements" gorm:"type:jsonb"` // 基準物に引いた線分
 	ImageURL        string    `json:"image_url"`
 	Confidence      float64   `json:"confidence"`
 	CreatedAt       time.Time `json:"created_at"`
@@ -189,8 +248,16 @@ type MultimodalData struct {
 	SemanticVector  JSON    `json:"semantic_vector" gorm:"type:jsonb"`
 	AmbiguityScore  float64 `json:"ambiguity_score"`
 
-	// 画像特徴
+	// 画像特徴（寸法は EXIF の向きを適用した表示上の向き）
 	ImageURL    string  `json:"image_url"`
+	ImageAttachmentID string `json:"image_attachment_id" gorm:"type:varchar(36)"`
+	ImageFormat string  `json:"image_format"`
+	ImageWidth  int     `json:"image_width"`
+	ImageHeight int     `json:"image_height"`
+	ImageHash   string  `json:"image_hash" gorm:"type:varchar(16);index"` // 知覚ハッシュ（pHash）
+	CaptureSession string `json:"capture_session" gorm:"index"` // 同じ撮影条件の画像のまとまり。校正を共有する
+	ImageMetadata JSON  `json:"image_metadata" gorm:"type:jsonb"` // EXIF
+	Thumbnails  JSON    `json:"thumbnails" gorm:"type:jsonb"` // 長辺のサイズ → {attachment_id, width, height}
 	Objects     JSON    `json:"objects" gorm:"type:jsonb"`
 	Measurements JSON   `json:"measurements" gorm:"type:jsonb"`
 	ImageConfidence float64 `json:"image_confidence"`
@@ -220,49 +287,113 @@ type MultimodalData struct {
 // リクエスト/レスポンス構造体
 
 // CreateLabelRequest - ラベル作成リクエスト
+// Description はラベルの Notes になる。TaskID を指定する場合はタスクを更新できる必要がある
 type CreateLabelRequest struct {
-	Text        string   `json:"text" binding:"required"`
-	Description string   `json:"description" binding:"required"`
-	Value       float64  `json:"value" binding:"required"`
-	Unit        string   `json:"unit" binding:"required"`
-	Domain      string   `json:"domain" binding:"required"`
-	Category    string   `json:"category" binding:"required"`
-	ImageURL    string   `json:"image_url"`
-	Concepts    []string `json:"concepts"`
-	Tags        []string `json:"tags"`
+	TaskID           int      `json:"task_id"`
+	Text             string   `json:"text" binding:"required"`
+	NormalizedText   string   `json:"normalized_text"`
+	Description      string   `json:"description" binding:"required"`
+	Value            *float64 `json:"value" binding:"required"`
+	Unit             string   `json:"unit" binding:"required"`
+	MinRange         *float64 `json:"min_range"`
+	MaxRange         *float64 `json:"max_range"`
+	TypicalValue     *float64 `json:"typical_value"`
+	Precision        *int     `json:"precision"`
+	Confidence       *float64 `json:"confidence"`
+	Domain           string   `json:"domain" binding:"required"`
+	Category         string   `json:"category" binding:"required"`
+	Context          string   `json:"context"`
+	AbstractLevel    string   `json:"abstract_level"`
+	Source           string   `json:"source"`
+	PublicVisibility *bool    `json:"public_visibility"`
+	ImageURL         string   `json:"image_url"`
+	Concepts         []string `json:"concepts"`
+	Tags             []string `json:"tags"`
 }
 
 // UpdateLabelRequest - ラベル更新リクエスト
+// Updates は JSON の項目名 → 新しい値。変更内容は Reason とともに LabelRevision に記録する
 type UpdateLabelRequest struct {
-	ID      string                 `json:"id" binding:"required"`
+	ID      string                 `json:"id"`
 	Updates map[string]interface{} `json:"updates" binding:"required"`
 	Reason  string                 `json:"reason" binding:"required"`
 }
 
 // VerifyLabelRequest - ラベル検証リクエスト
+// Criteria は判定項目（value, unit, range, category）の正誤。省略した項目は Decision に従う
 type VerifyLabelRequest struct {
-	LabelID      string                 `json:"label_id" binding:"required"`
-	Verification map[string]interface{} `json:"verification" binding:"required"`
-	VerifierID   string                 `json:"verifier_id" binding:"required"`
+	Decision string          `json:"decision" binding:"required"` // accept, reject
+	Criteria map[string]bool `json:"criteria"`
+	Comment  string          `json:"comment"`
+}
+
+// LabelAgreement - ラベルの検証者間の一致度
+type LabelAgreement struct {
+	Method    string   `json:"method,omitempty"` // cohen, fleiss
+	Kappa     *float64 `json:"kappa"`
+	Verifiers int      `json:"verifiers"`
+}
+
+// DatasetRequest - データセットの作成・更新リクエスト
+// LabelIDs を指定した場合はデータセットのラベルを置き換える（更新時に nil なら変更しない）
+type DatasetRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Domain      string   `json:"domain"`
+	License     string   `json:"license"`
+	Citation    string   `json:"citation"`
+	LabelIDs    []string `json:"label_ids"`
+}
+
+// CreateRelationRequest - ラベル間の関係の作成リクエスト
+// Bidirectional を省略した場合、同義語は双方向、上位・下位の関係は単方向になる
+type CreateRelationRequest struct {
+	SourceID      string   `json:"source_id" binding:"required"`
+	TargetID      string   `json:"target_id" binding:"required"`
+	RelationType  string   `json:"relation_type" binding:"required"` // synonym, hypernym, hyponym
+	Strength      *float64 `json:"strength"`                          // 0〜1、既定 1
+	Bidirectional *bool    `json:"bidirectional"`
+	Context       string   `json:"context"`
+	Confidence    *float64 `json:"confidence"`
+}
+
+// OntologyNode - 関係をたどって見つかったラベル
+type OntologyNode struct {
+	LabelID  string  `json:"label_id"`
+	Text     string  `json:"text"`
+	Depth    int     `json:"depth"`
+	Strength float64 `json:"strength"`
+}
+
+// QueryExpansion - 同義語による検索語の展開
+// Labels は語句に一致したラベルとその同義語のラベル、Terms は検索に使う語句
+type QueryExpansion struct {
+	Text   string         `json:"text"`
+	Terms  []string       `json:"terms"`
+	Labels []OntologyNode `json:"labels"`
 }
 
 // LabelSearchQuery - ラベル検索クエリ
+// From / To は作成日時の範囲（RFC3339 または 2006-01-02）
 type LabelSearchQuery struct {
-	Text         string   `json:"text"`
-	Domain       string   `json:"domain"`
-	Category     string   `json:"category"`
-	MinValue     float64  `json:"min_value"`
-	MaxValue     float64  `json:"max_value"`
-	Unit         string   `json:"unit"`
-	Concepts     []string `json:"concepts"`
-	MinConfidence float64 `json:"min_confidence"`
-	Verified     *bool    `json:"verified"`
-	From         string   `json:"from"`
-	To           string   `json:"to"`
-	Limit        int      `json:"limit"`
-	Offset       int      `json:"offset"`
-	SortBy       string   `json:"sort_by"`
-	SortOrder    string   `json:"sort_order"`
+	Text          string   `json:"text" form:"text"`
+	// Expand は Text を同義語で展開して検索する（展開した語句は Terms に入る）
+	Expand        bool     `json:"expand" form:"expand"`
+	Terms         []string `json:"-" form:"-"`
+	Domain        string   `json:"domain" form:"domain"`
+	Category      string   `json:"category" form:"category"`
+	MinValue      *float64 `json:"min_value" form:"min_value"`
+	MaxValue      *float64 `json:"max_value" form:"max_value"`
+	Unit          string   `json:"unit" form:"unit"`
+	Concepts      []string `json:"concepts" form:"concepts"`
+	MinConfidence float64  `json:"min_confidence" form:"min_confidence"`
+	Verified      *bool    `json:"verified" form:"verified"`
+	From          string   `json:"from" form:"from"`
+	To            string   `json:"to" form:"to"`
+	Limit         int      `json:"limit" form:"limit"`
+	Offset        int      `json:"offset" form:"offset"`
+	SortBy        string   `json:"sort_by" form:"sort_by"`
+	SortOrder     string   `json:"sort_order" form:"sort_order"`
 }
 
 // LabelStatistics - ラベル統計
@@ -281,17 +412,27 @@ type LabelStatistics struct {
 
 // SuggestionRequest - 定量化提案リクエスト
 type SuggestionRequest struct {
-	Text     string `json:"text" binding:"required"`
-	ImageURL string `json:"image_url"`
-	Domain   string `json:"domain"`
+	Text     string `json:"text" form:"text" binding:"required"`
+	ImageURL string `json:"image_url" form:"image_url"`
+	Domain   string `json:"domain" form:"domain"`
+	Limit    int    `json:"limit" form:"limit"`
+}
+
+// LabelSuggestion - 語句に対する値の提案
+// Source は label（語句が一致したラベル）または synonym（Via のラベルの同義語）
+type LabelSuggestion struct {
+	LabelID    string   `json:"label_id"`
+	Text       string   `json:"text"`
+	Value      float64  `json:"value"`
+	MinRange   *float64 `json:"min_range"`
+	MaxRange   *float64 `json:"max_range"`
+	Unit       string   `json:"unit"`
+	Confidence float64  `json:"confidence"`
+	Source     string   `json:"source"`
+	Via        string   `json:"via,omitempty"`
 }
 
 // SuggestionResponse - 定量化提案レスポンス
 type SuggestionResponse struct {
-	Suggestions []struct {
-		Value      float64 `json:"value"`
-		Unit       string  `json:"unit"`
-		Confidence float64 `json:"confidence"`
-		Source     string  `json:"source"`
-	} `json:"suggestions"`
+	Suggestions []LabelSuggestion `json:"suggestions"`
 }
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RobotSpecification はツールマッチングの対象となるロボットの仕様
+// AICapability / SafetyFeatures は文字列の配列を保持する
+type RobotSpecification struct {
+	ID                       string         `gorm:"type:varchar(255);primaryKey" json:"id"`
+	ModelName                string         `gorm:"type:varchar(255);not null" json:"model_name"`
+	DOF                      int            `json:"dof"`
+	ReachMm                  float64        `json:"reach_mm"`
+	PayloadKg                float64        `json:"payload_kg"`
+	RepeatAccuracyMm         float64        `json:"repeat_accuracy_mm"`
+	MaxSpeedMmS              float64        `json:"max_speed_mm_s"`
+	WorkEnvelopeShape        string         `gorm:"type:varchar(100)" json:"work_envelope_shape"`
+	TeachingMethod           string         `gorm:"type:varchar(100)" json:"teaching_method"`
+	ControlType              string         `gorm:"type:varchar(100)" json:"control_type"`
+	VisionSystem             string         `gorm:"type:varchar(100)" json:"vision_system"`
+	ForceSensor              string         `gorm:"type:varchar(100)" json:"force_sensor"`
+	AICapability             datatypes.JSON `gorm:"type:jsonb" json:"ai_capability"`
+	SafetyFeatures           datatypes.JSON `gorm:"type:jsonb" json:"safety_features"`
+	MaintenanceIntervalHours int            `json:"maintenance_interval_hours"`
+	CreatedAt                time.Time      `json:"created_at"`
+	UpdatedAt                time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// SchemaMigration - 適用済みのスキーママイグレーション（Models には含めず、マイグレーションの仕組みが作る）
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false" json:"version"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+}
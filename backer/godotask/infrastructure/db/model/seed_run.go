@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// SeedRun - シードセットごとに最後に投入した CSV のチェックサム
+type SeedRun struct {
+	Name      string    `gorm:"primaryKey;type:varchar(100)" json:"name"`
+	Checksum  string    `gorm:"type:varchar(64);not null" json:"checksum"`
+	AppliedAt time.Time `json:"applied_at"`
+}
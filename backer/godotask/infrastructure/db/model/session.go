@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// Session - ログイン（デバイス）ごとのセッション。リフレッシュトークンのファミリー単位
+type Session struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     uint       `json:"user_id" gorm:"index;not null"`
+	DeviceName string     `json:"device_name" gorm:"type:varchar(255)"`
+	UserAgent  string     `json:"user_agent" gorm:"type:text"`
+	IP         string     `json:"ip" gorm:"type:varchar(64)"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"index"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" gorm:"index"`
+}
+
+// RefreshToken - セッションに紐づくリフレッシュトークン（SHA-256 ハッシュのみ保存）
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	SessionID uint       `json:"session_id" gorm:"index;not null"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	Session Session `json:"-" gorm:"foreignKey:SessionID"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// SPCMeasurement は定量化ラベルに対するタスク内の測定値（統計的工程管理の入力）
+// Subgroup が同じ測定値は X-bar/R 管理図で1つのサブグループとして扱う
+type SPCMeasurement struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"index" json:"user_id"`
+	TaskID     int       `gorm:"not null;index:idx_spc_measurement_series" json:"task_id"`
+	LabelID    string    `gorm:"type:varchar(255);not null;index:idx_spc_measurement_series" json:"label_id"`
+	Value      float64   `json:"value"`
+	Unit       string    `gorm:"type:varchar(50)" json:"unit"`
+	Subgroup   string    `gorm:"type:varchar(100)" json:"subgroup"`
+	MeasuredAt time.Time `gorm:"index" json:"measured_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SPCMeasurementsRequest は測定値の一括登録リクエスト
+type SPCMeasurementsRequest struct {
+	TaskID       int                   `json:"task_id" binding:"required"`
+	LabelID      string                `json:"label_id" binding:"required"`
+	Unit         string                `json:"unit"`
+	Measurements []SPCMeasurementInput `json:"measurements" binding:"required,min=1,dive"`
+}
+
+type SPCMeasurementInput struct {
+	Value      *float64   `json:"value" binding:"required"`
+	Subgroup   string     `json:"subgroup"`
+	MeasuredAt *time.Time `json:"measured_at"`
+}
@@ -30,6 +30,7 @@ type ToolMatchingResult struct {
 	RobotID             string                 `gorm:"type:varchar(255)" json:"robot_id"`
 	OptimizationModelID string                 `gorm:"type:varchar(255)" json:"optimization_model_id"`
 	MatchingScore       float64                `gorm:"type:decimal(5,3)" json:"matching_score"`
+	Rank                int                    `json:"rank"`
 	Recommendations     datatypes.JSON         `gorm:"type:jsonb" json:"recommendations"`
 	Parameters          datatypes.JSON         `gorm:"type:jsonb" json:"parameters"`
 	ExpectedPerformance datatypes.JSON         `gorm:"type:jsonb" json:"expected_performance"`
@@ -43,6 +44,8 @@ type ProcessMonitoring struct {
 	MonitoringData      datatypes.JSON         `gorm:"type:jsonb" json:"monitoring_data"`
 	Metrics             datatypes.JSON         `gorm:"type:jsonb" json:"metrics"`
 	Anomalies           datatypes.JSON         `gorm:"type:jsonb" json:"anomalies"`
+	DetectorConfig      datatypes.JSON         `gorm:"type:jsonb" json:"detector_config"`
+	Summary             datatypes.JSON         `gorm:"type:jsonb" json:"summary"`
 	Status              string                 `gorm:"type:varchar(50)" json:"status"`
 	StartTime           time.Time              `json:"start_time"`
 	EndTime             *time.Time             `json:"end_time"`
@@ -69,12 +72,14 @@ type LearningPattern struct {
 }
 
 type EvaluationRequest struct {
-	UserID          string                 `json:"user_id" binding:"required"`
+	UserID          string                 `json:"user_id"`
 	TaskID          int                    `json:"task_id" binding:"required"`
 	Level           int                    `json:"level" binding:"required"`
 	WorkTarget      string                 `json:"work_target" binding:"required"`
 	CurrentState    map[string]interface{} `json:"current_state" binding:"required"`
 	TargetState     map[string]interface{} `json:"target_state" binding:"required"`
+	// Directions は評価軸ごとの良い向き（maximize / minimize）。省略した評価軸は maximize
+	Directions      map[string]string      `json:"directions"`
 	Framework       string                 `json:"framework"`
 }
 
@@ -89,4 +94,29 @@ type ProcessMonitoringRequest struct {
 	StateEvaluationID string                 `json:"state_evaluation_id" binding:"required"`
 	ProcessType       string                 `json:"process_type" binding:"required"`
 	InitialData       map[string]interface{} `json:"initial_data"`
+	// DetectorConfig は異常検知器の設定（warmup, z_threshold, ewma_lambda, ewma_limit, cusum_k, cusum_h）の上書き
+	DetectorConfig    map[string]float64     `json:"detector_config"`
+}
+
+// ProcessMonitoringSamplesRequest は監視セッションに送るサンプルのまとまり
+type ProcessMonitoringSamplesRequest struct {
+	Samples []MonitoringSampleInput `json:"samples" binding:"required,min=1,dive"`
+}
+
+type MonitoringSampleInput struct {
+	Metric    string     `json:"metric" binding:"required"`
+	Value     *float64   `json:"value" binding:"required"`
+	// Timestamp が無い場合は受信時刻を記録時刻とする
+	Timestamp *time.Time `json:"timestamp"`
+}
+
+// ProcessMonitoringSample は監視セッションに送られた指標のサンプル
+type ProcessMonitoringSample struct {
+	ID                  uint                   `gorm:"primaryKey" json:"id"`
+	ProcessMonitoringID string                 `gorm:"type:varchar(255);not null;index" json:"process_monitoring_id"`
+	Metric              string                 `gorm:"type:varchar(100);not null" json:"metric"`
+	Value               float64                `json:"value"`
+	Anomalous           bool                   `json:"anomalous"`
+	RecordedAt          time.Time              `gorm:"index" json:"recorded_at"`
+	CreatedAt           time.Time              `json:"created_at"`
 }
\ No newline at end of file
@@ -15,6 +15,7 @@ type TeachingFreeControl struct {
 	ForceControl   JSON           `json:"force_control" gorm:"type:jsonb"`
 	AIModel        JSON           `json:"ai_model" gorm:"type:jsonb"`
 	LearningData   JSON           `json:"learning_data" gorm:"type:jsonb"`
+	// SuccessRate / AdaptationTime は記録された試行から計算する（成功率、成功した試行の平均所要時間（秒））
 	SuccessRate    float64        `json:"success_rate"`
 	AdaptationTime float64        `json:"adaptation_time"`
 	ErrorRecovery  JSON           `json:"error_recovery" gorm:"type:jsonb"`
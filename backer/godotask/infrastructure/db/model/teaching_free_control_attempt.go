@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// 試行の結果
+const (
+	AttemptSuccess = "success"
+	AttemptFailure = "failure"
+)
+
+// 試行の記録元
+const (
+	AttemptSourceRobot      = "robot"
+	AttemptSourceSimulation = "simulation"
+)
+
+// TeachingFreeControlAttempt はティーチング不要制御の設定でロボットが実行した1回の試行
+// RobotID / TaskType は記録時の制御設定の値を複製し、学習曲線の集計に使う
+type TeachingFreeControlAttempt struct {
+	ID                    uint   `gorm:"primaryKey" json:"id"`
+	TeachingFreeControlID string `gorm:"type:varchar(255);not null;index" json:"teaching_free_control_id"`
+	RobotID               string `gorm:"type:varchar(255);index:idx_tfc_attempt_curve" json:"robot_id"`
+	TaskType              string `gorm:"type:varchar(255);index:idx_tfc_attempt_curve" json:"task_type"`
+	Outcome               string `gorm:"type:varchar(20);not null" json:"outcome"` // success, failure
+	ErrorType             string `gorm:"type:varchar(100)" json:"error_type,omitempty"`
+	RecoveryAction        string `gorm:"type:varchar(100)" json:"recovery_action,omitempty"`
+	// Recovered は失敗後の復帰動作が成功したかどうか
+	Recovered   bool      `json:"recovered"`
+	Duration    float64   `json:"duration"`                                     // 所要時間（秒）
+	Source      string    `gorm:"type:varchar(20);default:robot" json:"source"` // robot, simulation
+	Detail      JSON      `gorm:"type:jsonb" json:"detail,omitempty"`
+	AttemptedAt time.Time `gorm:"index" json:"attempted_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TeachingFreeControlAttemptsRequest は試行の一括登録リクエスト
+type TeachingFreeControlAttemptsRequest struct {
+	Attempts []TeachingFreeControlAttemptInput `json:"attempts" binding:"required,min=1,dive"`
+}
+
+type TeachingFreeControlAttemptInput struct {
+	Outcome        string     `json:"outcome" binding:"required,oneof=success failure"`
+	ErrorType      string     `json:"error_type"`
+	RecoveryAction string     `json:"recovery_action"`
+	Recovered      bool       `json:"recovered"`
+	Duration       *float64   `json:"duration" binding:"required,gte=0"`
+	Source         string     `json:"source" binding:"omitempty,oneof=robot simulation"`
+	Detail         JSON       `json:"detail"`
+	AttemptedAt    *time.Time `json:"attempted_at"`
+}
+
+// TeachingFreeControlSimulationRequest は制御設定をシミュレータで実行するリクエスト
+// Record が true の場合は試行を source=simulation として登録する
+type TeachingFreeControlSimulationRequest struct {
+	Trials  int        `json:"trials" binding:"required,min=1,max=10000"`
+	Seed    int64      `json:"seed"`
+	Record  bool       `json:"record"`
+	StartAt *time.Time `json:"start_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+)
+
+// Workspace - チームでタスク・メモリ・知識パターンを共有する単位
+type Workspace struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string    `json:"name" gorm:"type:varchar(255);not null"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedBy   uint      `json:"created_by" gorm:"index"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Members []WorkspaceMember `json:"members,omitempty" gorm:"foreignKey:WorkspaceID"`
+}
+
+// WorkspaceMember - ワークスペースの所属とロール（owner / editor / viewer）
+type WorkspaceMember struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID uint      `json:"workspace_id" gorm:"uniqueIndex:idx_workspace_member;not null"`
+	UserID      uint      `json:"user_id" gorm:"uniqueIndex:idx_workspace_member;index;not null"`
+	Role        string    `json:"role" gorm:"type:varchar(20);not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// WorkspaceInvitation - メールアドレス宛の招待。トークンは SHA-256 ハッシュのみ保存する
+type WorkspaceInvitation struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	WorkspaceID uint       `json:"workspace_id" gorm:"index;not null"`
+	Email       string     `json:"email" gorm:"type:varchar(255);index;not null"`
+	Role        string     `json:"role" gorm:"type:varchar(20);not null"`
+	TokenHash   string     `json:"-" gorm:"type:varchar(64);uniqueIndex;not null"`
+	InvitedBy   uint       `json:"invited_by"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy  *uint      `json:"accepted_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
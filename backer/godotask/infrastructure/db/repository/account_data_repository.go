@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+type AccountDataRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// FindAccountData は userID が所有するレコード（ワークスペースで共有されたものは含まない）を読み込む
+// タスクに属するレコード（本・知識パターン）はタスクの所有者で、技術要因・知識変換はメモリコンテキストで絞り込む
+// ラベル間の関係は自分が作成し、両端が自分のラベルであるものに限る
+func (r *AccountDataRepositoryImpl) FindAccountData(userID uint) (*model.AccountData, error) {
+	data := &model.AccountData{}
+	newDB := func() *gorm.DB { return r.DB.Session(&gorm.Session{NewDB: true}) }
+	tasks := newDB().Model(&model.Task{}).Select("id").Where("user_id = ?", userID)
+	contexts := newDB().Model(&model.MemoryContext{}).Select("id").Where("user_id = ?", userID)
+	labels := newDB().Model(&model.QuantificationLabel{}).Select("id").Where("user_id = ?", userID)
+
+	queries := []struct {
+		dest  interface{}
+		query *gorm.DB
+	}{
+		{&data.Tasks, r.DB.Where("user_id = ?", userID)},
+		{&data.Memories, r.DB.Where("user_id = ?", userID)},
+		{&data.MemoryContexts, r.DB.Where("user_id = ?", userID)},
+		{&data.TechnicalFactors, r.DB.Where("context_id IN (?)", contexts)},
+		{&data.KnowledgeTransformations, r.DB.Where("context_id IN (?)", contexts)},
+		{&data.Assessments, r.DB.Where("user_id = ?", userID)},
+		{&data.Books, r.DB.Where("task_id IN (?)", tasks)},
+		{&data.HeuristicsAnalyses, r.DB.Where("user_id = ?", userID)},
+		{&data.HeuristicsInsights, r.DB.Where("user_id = ?", userID)},
+		{&data.HeuristicsPatterns, r.DB.Where("user_id = ?", userID)},
+		{&data.HeuristicsTrackings, r.DB.Where("user_id = ?", userID)},
+		{&data.HeuristicsModelers, r.DB.Where("user_id = ?", userID)},
+		{&data.KnowledgePatterns, r.DB.Where("task_id IN (?)", tasks)},
+		{&data.QuantificationLabels, r.DB.Where("user_id = ?", userID)},
+		{&data.QualitativeLabels, r.DB.Where("user_id = ?", userID)},
+		{&data.LabelRelations, r.DB.Where("user_id = ? AND source_id IN (?) AND target_id IN (?)", userID, labels, labels)},
+	}
+	for _, q := range queries {
+		if err := q.query.Order("id ASC").Find(q.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// restoreRows は行を新しい ID で作成し、元の ID から作成した ID への対応を返す
+// newID は新しい ID（自動採番の場合はゼロ値）を返す。prepare は参照を付け替え、作成しない場合は理由を返す
+func restoreRows[T any, K comparable](tx *gorm.DB, result *model.AccountImportResult, resource string, rows []T, id func(*T) *K, newID func() K, prepare func(*T) string) (map[K]K, error) {
+	ids := map[K]K{}
+	for i := range rows {
+		row := &rows[i]
+		old := *id(row)
+		if reason := prepare(row); reason != "" {
+			result.Skipped = append(result.Skipped, model.AccountImportSkip{Resource: resource, ID: fmt.Sprint(old), Reason: reason})
+			continue
+		}
+		*id(row) = newID()
+		if err := tx.Omit(clause.Associations).Create(row).Error; err != nil {
+			return nil, fmt.Errorf("failed to import %s %v: %w", resource, old, err)
+		}
+		ids[old] = *id(row)
+		result.Imported[resource]++
+	}
+	return ids, nil
+}
+
+func autoID() int { return 0 }
+
+func newUUID() string { return uuid.New().String() }
+
+// remap は参照を作成した ID に付け替える。参照先がアーカイブに無い場合は 0（参照なし）にする
+func remap(ids map[int]int, ref *int) {
+	if *ref != 0 {
+		*ref = ids[*ref]
+	}
+}
+
+// remapRequired は必須の参照を付け替え、参照先がアーカイブに無い場合は理由を返す
+func remapRequired(ids map[int]int, ref *int, name string) string {
+	newRef, ok := ids[*ref]
+	if !ok {
+		return name + " " + strconv.Itoa(*ref) + " is not in the archive"
+	}
+	*ref = newRef
+	return ""
+}
+
+// RestoreAccountData は data を userID のレコードとして 1 つのトランザクションで作成する
+// ID は作成し直し、レコード間の参照（タスク・メモリ・メモリコンテキスト・分析・ラベル）を新しい ID に付け替える
+// 所有者は userID になり、ワークスペースでの共有は引き継がない
+func (r *AccountDataRepositoryImpl) RestoreAccountData(userID uint, data *model.AccountData) (*model.AccountImportResult, error) {
+	result := &model.AccountImportResult{SchemaVersion: model.ExportSchemaVersion, Imported: map[string]int{}, Skipped: []model.AccountImportSkip{}}
+	owner := int(userID)
+	actor := strconv.FormatUint(uint64(userID), 10)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		memories, err := restoreRows(tx, result, "memories", data.Memories, func(m *model.Memory) *int { return &m.ID }, autoID, func(m *model.Memory) string {
+			m.UserID, m.WorkspaceID = owner, nil
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		tasks, err := restoreRows(tx, result, "tasks", data.Tasks, func(m *model.Task) *int { return &m.ID }, autoID, func(m *model.Task) string {
+			m.UserID, m.WorkspaceID = owner, nil
+			remap(memories, &m.MemoryID)
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		contexts, err := restoreRows(tx, result, "memory_contexts", data.MemoryContexts, func(m *model.MemoryContext) *int { return &m.ID }, autoID, func(m *model.MemoryContext) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "technical_factors", data.TechnicalFactors, func(m *model.TechnicalFactor) *int { return &m.ID }, autoID, func(m *model.TechnicalFactor) string {
+			return remapRequired(contexts, &m.ContextID, "memory context")
+		}); err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "knowledge_transformations", data.KnowledgeTransformations, func(m *model.KnowledgeTransformation) *int { return &m.ID }, autoID, func(m *model.KnowledgeTransformation) string {
+			return remapRequired(contexts, &m.ContextID, "memory context")
+		}); err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "assessments", data.Assessments, func(m *model.Assessment) *int { return &m.ID }, autoID, func(m *model.Assessment) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		}); err != nil {
+			return err
+		}
+		// 本はタスクを通して所有するため、タスクが必須
+		if _, err := restoreRows(tx, result, "books", data.Books, func(m *model.Book) *int { return &m.ID }, autoID, func(m *model.Book) string {
+			return remapRequired(tasks, &m.TaskID, "task")
+		}); err != nil {
+			return err
+		}
+		analyses, err := restoreRows(tx, result, "heuristics_analyses", data.HeuristicsAnalyses, func(m *model.HeuristicsAnalysis) *int { return &m.ID }, autoID, func(m *model.HeuristicsAnalysis) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "heuristics_insights", data.HeuristicsInsights, func(m *model.HeuristicsInsight) *int { return &m.ID }, autoID, func(m *model.HeuristicsInsight) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			if m.SourceAnalysisID != nil {
+				if id, ok := analyses[*m.SourceAnalysisID]; ok {
+					m.SourceAnalysisID = &id
+				} else {
+					m.SourceAnalysisID = nil
+				}
+			}
+			return ""
+		}); err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "heuristics_patterns", data.HeuristicsPatterns, func(m *model.HeuristicsPattern) *int { return &m.ID }, autoID, func(m *model.HeuristicsPattern) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		}); err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "heuristics_trackings", data.HeuristicsTrackings, func(m *model.HeuristicsTracking) *int { return &m.ID }, autoID, func(m *model.HeuristicsTracking) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		}); err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "heuristics_modelers", data.HeuristicsModelers, func(m *model.HeuristicsModeler) *int { return &m.ID }, autoID, func(m *model.HeuristicsModeler) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		}); err != nil {
+			return err
+		}
+		// 知識パターンはタスクを通して所有するため、タスクが必須
+		if _, err := restoreRows(tx, result, "knowledge_patterns", data.KnowledgePatterns, func(m *model.KnowledgePattern) *string { return &m.ID }, newUUID, func(m *model.KnowledgePattern) string {
+			return remapRequired(tasks, &m.TaskID, "task")
+		}); err != nil {
+			return err
+		}
+		// 検証の記録は含めないため、検証待ちのラベルは下書きに戻す
+		labels, err := restoreRows(tx, result, "quantification_labels", data.QuantificationLabels, func(m *model.QuantificationLabel) *string { return &m.ID }, newUUID, func(m *model.QuantificationLabel) string {
+			m.UserID, m.CreatedBy, m.UpdatedBy = owner, actor, actor
+			remap(tasks, &m.TaskID)
+			if m.Status == labeling.StatusSubmitted {
+				m.Status = labeling.StatusDraft
+			}
+			return ""
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := restoreRows(tx, result, "qualitative_labels", data.QualitativeLabels, func(m *model.QualitativeLabel) *int { return &m.ID }, autoID, func(m *model.QualitativeLabel) string {
+			m.UserID = owner
+			remap(tasks, &m.TaskID)
+			return ""
+		}); err != nil {
+			return err
+		}
+		_, err = restoreRows(tx, result, "label_relations", data.LabelRelations, func(m *model.LabelRelation) *string { return &m.ID }, newUUID, func(m *model.LabelRelation) string {
+			source, ok := labels[m.SourceID]
+			target, ok2 := labels[m.TargetID]
+			if !ok || !ok2 {
+				return "label is not in the archive"
+			}
+			m.UserID, m.SourceID, m.TargetID = owner, source, target
+			return ""
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// NewAccountDataRepository は AccountDataRepositoryInterface を返すコンストラクタ
+func NewAccountDataRepository(db *gorm.DB) AccountDataRepositoryInterface {
+	return &AccountDataRepositoryImpl{DB: db}
+}
@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAccountDataTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Task{}, &model.Memory{}, &model.MemoryContext{}, &model.TechnicalFactor{}, &model.KnowledgeTransformation{},
+		&model.Assessment{}, &model.Book{}, &model.HeuristicsAnalysis{}, &model.HeuristicsInsight{}, &model.HeuristicsPattern{},
+		&model.HeuristicsTracking{}, &model.HeuristicsModeler{}, &model.KnowledgePattern{}, &model.QuantificationLabel{},
+		&model.QualitativeLabel{}, &model.LabelRelation{}))
+	return db
+}
+
+func TestAccountDataRepository(t *testing.T) {
+	db := setupAccountDataTestDB(t)
+	repo := repository.NewAccountDataRepository(db)
+
+	// ユーザー 2 のレコードを先に作り、ユーザー 1 の ID とずらす
+	other := &model.Task{UserID: 2, Title: "Other"}
+	require.NoError(t, db.Create(other).Error)
+	require.NoError(t, db.Create(&model.Memory{UserID: 2, Title: "Other"}).Error)
+	require.NoError(t, db.Create(&model.Book{TaskID: other.ID, Title: "Other"}).Error)
+	require.NoError(t, db.Create(&model.QuantificationLabel{ID: "other", UserID: 2, OriginalText: "他"}).Error)
+
+	memory := &model.Memory{UserID: 1, Title: "Handbook"}
+	require.NoError(t, db.Create(memory).Error)
+	task := &model.Task{UserID: 1, MemoryID: memory.ID, Title: "Deburring"}
+	require.NoError(t, db.Create(task).Error)
+	context := &model.MemoryContext{UserID: 1, TaskID: task.ID, Goal: "Ra 0.8"}
+	require.NoError(t, db.Create(context).Error)
+	require.NoError(t, db.Create(&model.TechnicalFactor{ContextID: context.ID, ToolSpec: "R0.5"}).Error)
+	require.NoError(t, db.Create(&model.Book{TaskID: task.ID, Title: "Book"}).Error)
+	analysis := &model.HeuristicsAnalysis{UserID: 1, TaskID: task.ID, Result: "{}"}
+	require.NoError(t, db.Create(analysis).Error)
+	require.NoError(t, db.Create(&model.HeuristicsInsight{UserID: 1, TaskID: task.ID, Data: "{}", SourceAnalysisID: &analysis.ID}).Error)
+	require.NoError(t, db.Create(&model.KnowledgePattern{ID: "kp", TaskID: task.ID, TacitKnowledge: "angle"}).Error)
+	require.NoError(t, db.Create(&[]model.QuantificationLabel{
+		{ID: "burr", UserID: 1, TaskID: task.ID, OriginalText: "バリ", Status: "submitted"},
+		{ID: "flash", UserID: 1, OriginalText: "かえり"},
+	}).Error)
+	require.NoError(t, db.Create(&[]model.LabelRelation{
+		{ID: "r1", UserID: 1, SourceID: "burr", TargetID: "flash", RelationType: "synonym"},
+		{ID: "r2", UserID: 1, SourceID: "burr", TargetID: "other", RelationType: "synonym"},
+	}).Error)
+
+	// 自分のレコードのみ（本・知識パターンはタスク、技術要因はメモリコンテキストで絞り込む）
+	data, err := repo.FindAccountData(1)
+	require.NoError(t, err)
+	require.Len(t, data.Tasks, 1)
+	require.Len(t, data.Memories, 1)
+	assert.Len(t, data.MemoryContexts, 1)
+	assert.Len(t, data.TechnicalFactors, 1)
+	require.Len(t, data.Books, 1)
+	assert.Equal(t, "Book", data.Books[0].Title)
+	assert.Len(t, data.HeuristicsInsights, 1)
+	assert.Len(t, data.KnowledgePatterns, 1)
+	assert.Len(t, data.QuantificationLabels, 2)
+	require.Len(t, data.LabelRelations, 1)
+	assert.Equal(t, "r1", data.LabelRelations[0].ID)
+
+	// 参照先が無い本は作成しない
+	data.Books = append(data.Books, model.Book{ID: 99, TaskID: 12345, Title: "Orphan"})
+	result, err := repo.RestoreAccountData(3, data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported["tasks"])
+	assert.Equal(t, 1, result.Imported["books"])
+	assert.Equal(t, 2, result.Imported["quantification_labels"])
+	assert.Equal(t, []model.AccountImportSkip{{Resource: "books", ID: "99", Reason: "task 12345 is not in the archive"}}, result.Skipped)
+
+	// 参照は作成したレコードの ID に付け替える
+	restored, err := repo.FindAccountData(3)
+	require.NoError(t, err)
+	require.Len(t, restored.Tasks, 1)
+	newTask := restored.Tasks[0]
+	assert.NotEqual(t, task.ID, newTask.ID)
+	assert.Equal(t, restored.Memories[0].ID, newTask.MemoryID)
+	assert.Equal(t, newTask.ID, restored.MemoryContexts[0].TaskID)
+	assert.Equal(t, restored.MemoryContexts[0].ID, restored.TechnicalFactors[0].ContextID)
+	assert.Equal(t, newTask.ID, restored.Books[0].TaskID)
+	require.NotNil(t, restored.HeuristicsInsights[0].SourceAnalysisID)
+	assert.Equal(t, restored.HeuristicsAnalyses[0].ID, *restored.HeuristicsInsights[0].SourceAnalysisID)
+	assert.NotEqual(t, "kp", restored.KnowledgePatterns[0].ID)
+	assert.Equal(t, newTask.ID, restored.KnowledgePatterns[0].TaskID)
+
+	labels := map[string]model.QuantificationLabel{}
+	for _, l := range restored.QuantificationLabels {
+		labels[l.OriginalText] = l
+	}
+	assert.Equal(t, "draft", labels["バリ"].Status)
+	assert.Equal(t, "3", labels["バリ"].CreatedBy)
+	assert.Equal(t, newTask.ID, labels["バリ"].TaskID)
+	require.Len(t, restored.LabelRelations, 1)
+	assert.Equal(t, labels["バリ"].ID, restored.LabelRelations[0].SourceID)
+	assert.Equal(t, labels["かえり"].ID, restored.LabelRelations[0].TargetID)
+
+	// 元のレコードは変わらない
+	original, err := repo.FindAccountData(1)
+	require.NoError(t, err)
+	assert.Len(t, original.QuantificationLabels, 2)
+	assert.Equal(t, "submitted", original.QuantificationLabels[0].Status)
+}
@@ -13,11 +13,18 @@ type AssessmentRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *AssessmentRepositoryImpl) Create(a *model.Assessment) error {
+func (r *AssessmentRepositoryImpl) Create(userID uint, a *model.Assessment) error {
+	// 他のユーザーのタスクには作成できない
+	if err := authorizeWrite(r.DB, "task", userID, a.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(a).Error
 }
 
-func (r *AssessmentRepositoryImpl) FindByID(id string) (*model.Assessment, error) {
+func (r *AssessmentRepositoryImpl) FindByID(userID uint, id string) (*model.Assessment, error) {
+	if err := authorizeRecord(r.DB, "assessment", userID, id); err != nil {
+		return nil, err
+	}
 	var assessment model.Assessment
 	if err := r.DB.Where("id = ?", id).First(&assessment).Error; err != nil {
 		return nil, err
@@ -77,11 +84,24 @@ func (r *AssessmentRepositoryImpl) ListAssessmentsForTaskUserPager(filter dtoque
 	return assessments, total, nil
 }
 
-func (r *AssessmentRepositoryImpl) Update(id string, a *model.Assessment) error {
-	return r.DB.Model(&model.Assessment{}).Where("id = ?", id).Updates(a).Error
+func (r *AssessmentRepositoryImpl) Update(userID uint, id string, a *model.Assessment) error {
+	if err := authorizeWrite(r.DB, "assessment", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if a.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, a.TaskID); err != nil {
+			return err
+		}
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.Assessment{}).Where("id = ?", id).Omit("user_id").Updates(a).Error
 }
 
-func (r *AssessmentRepositoryImpl) Delete(id string) error {
+func (r *AssessmentRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "assessment", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.Assessment{}, id).Error
 }
 
@@ -89,11 +109,11 @@ func (r *AssessmentRepositoryImpl) Delete(id string) error {
 // ErrorMockAssessmentRepository is a mock implementation of AssessmentRepositoryInterface that returns an error for FindByID
 type ErrorMockAssessmentRepository struct{}
 
-func (e *ErrorMockAssessmentRepository) Create(assessment *model.Assessment) error {
+func (e *ErrorMockAssessmentRepository) Create(userID uint, assessment *model.Assessment) error {
 	return errors.New("not implemented")
 }
 
-func (e *ErrorMockAssessmentRepository) FindByID(id string) (*model.Assessment, error) {
+func (e *ErrorMockAssessmentRepository) FindByID(userID uint, id string) (*model.Assessment, error) {
 	return nil, gorm.ErrRecordNotFound
 }
 
@@ -101,10 +121,10 @@ func (e *ErrorMockAssessmentRepository) FindAll() ([]model.Assessment, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (e *ErrorMockAssessmentRepository) Update(id string, assessment *model.Assessment) error {
+func (e *ErrorMockAssessmentRepository) Update(userID uint, id string, assessment *model.Assessment) error {
 	return errors.New("not implemented")
 }
 
-func (e *ErrorMockAssessmentRepository) Delete(id string) error {
+func (e *ErrorMockAssessmentRepository) Delete(userID uint, id string) error {
 	return errors.New("not implemented")
 }
\ No newline at end of file
@@ -5,28 +5,34 @@ import (
 	"time"
 	"strconv"
 
+	apperrors "github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-func setupTestDB() *gorm.DB {
+func setupAssessmentTestDB() *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		panic("failed to connect to database")
 	}
-	db.AutoMigrate(&model.Assessment{})
+	db.AutoMigrate(&model.Task{}, &model.WorkspaceMember{}, &model.Assessment{})
 	return db
 }
 
 func TestAssessmentRepository(t *testing.T) {
-	db := setupTestDB()
+	db := setupAssessmentTestDB()
 	repo := &repository.AssessmentRepositoryImpl{DB: db}
 
+	// Assessment は task_id 経由で所有者を判定する
+	task := &model.Task{UserID: 1, Title: "Assessment Task"}
+	assert.NoError(t, db.Create(task).Error)
+
 	// 作成
 	assessment := &model.Assessment{
-		TaskID:              1,
+		TaskID:              task.ID,
 		UserID:              1,
 		EffectivenessScore:  8,
 		EffortScore:         5,
@@ -36,12 +42,12 @@ func TestAssessmentRepository(t *testing.T) {
 		UpdatedAt:           time.Now(),
 	}
 
-	err := repo.Create(assessment)
+	err := repo.Create(1, assessment)
 	assert.NoError(t, err)
 	assert.NotZero(t, assessment.ID)
 
 	// 取得
-	found, err := repo.FindByID(strconv.Itoa(assessment.ID))
+	found, err := repo.FindByID(1, strconv.Itoa(assessment.ID))
 	assert.NoError(t, err)
 	assert.Equal(t, assessment.TaskID, found.TaskID)
 	assert.Equal(t, assessment.QualitativeFeedback, found.QualitativeFeedback)
@@ -53,26 +59,50 @@ func TestAssessmentRepository(t *testing.T) {
 		ImpactScore:        8,
 		QualitativeFeedback: "Improved result",
 	}
-	err = repo.Update(strconv.Itoa(assessment.ID), updated)
+	err = repo.Update(1, strconv.Itoa(assessment.ID), updated)
 	assert.NoError(t, err)
 
 	// 再取得して確認
-	found, _ = repo.FindByID(strconv.Itoa(assessment.ID))
+	found, _ = repo.FindByID(1, strconv.Itoa(assessment.ID))
 	assert.Equal(t, 9, found.EffectivenessScore)
 	assert.Equal(t, "Improved result", found.QualitativeFeedback)
 
 	// 全取得
-	all, err := repo.FindAll()
+	all, _, err := repo.FindAll(1)
 	assert.NoError(t, err)
 	assert.Len(t, all, 1)
 
 	// 削除
-	err = repo.Delete(strconv.Itoa(assessment.ID))
+	err = repo.Delete(1, strconv.Itoa(assessment.ID))
 	assert.NoError(t, err)
 
 	// 削除後確認
-	deleted, err := repo.FindByID(strconv.Itoa(assessment.ID))
+	deleted, err := repo.FindByID(1, strconv.Itoa(assessment.ID))
 	assert.Nil(t, deleted)
 	assert.Error(t, err)
 }
 
+
+func TestAssessmentRepositoryRejectsOtherUsersTask(t *testing.T) {
+	db := setupAssessmentTestDB()
+	repo := &repository.AssessmentRepositoryImpl{DB: db}
+
+	task := &model.Task{UserID: 1, Title: "Mine"}
+	assert.NoError(t, db.Create(task).Error)
+	other := &model.Task{UserID: 2, Title: "Other"}
+	assert.NoError(t, db.Create(other).Error)
+
+	// 他人のタスクには作成できない
+	err := repo.Create(1, &model.Assessment{TaskID: other.ID, UserID: 1, QualitativeFeedback: "x"})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	// 他人のタスクへの付け替えもできない
+	assessment := &model.Assessment{TaskID: task.ID, UserID: 1, QualitativeFeedback: "mine"}
+	assert.NoError(t, repo.Create(1, assessment))
+	err = repo.Update(1, strconv.Itoa(assessment.ID), &model.Assessment{TaskID: other.ID})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	found, err := repo.FindByID(1, strconv.Itoa(assessment.ID))
+	assert.NoError(t, err)
+	assert.Equal(t, task.ID, found.TaskID)
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	stderrors "errors"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttachmentRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create は添付先のリソースを更新できるかを確認し、ブロブの行をロックしてから store（BlobStore への保存）を呼び、添付を作成する
+func (r *AttachmentRepositoryImpl) Create(userID uint, attachment *model.Attachment, store func() error) error {
+	if err := authorizeWrite(r.DB, attachment.ResourceType, userID, attachment.ResourceID); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		blob := model.AttachmentBlob{SHA256: attachment.SHA256, Size: attachment.Size}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&blob).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&blob, "sha256 = ?", attachment.SHA256).Error; err != nil {
+			return err
+		}
+		if err := store(); err != nil {
+			return err
+		}
+		return tx.Create(attachment).Error
+	})
+}
+
+// FindByID はアップロードしたユーザーか、添付先のリソースを参照できるユーザーに添付を返す
+func (r *AttachmentRepositoryImpl) FindByID(userID uint, id string) (*model.Attachment, error) {
+	var attachment model.Attachment
+	err := r.DB.First(&attachment, "id = ?", id).Error
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userID == 0 || attachment.UserID == userID {
+		return &attachment, nil
+	}
+	if err := authorizeRecord(r.DB, attachment.ResourceType, userID, attachment.ResourceID); err != nil {
+		if stderrors.Is(err, apperrors.ErrResourceNotFound) {
+			return nil, apperrors.ErrResourceAccessDenied
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// FindByResource はリソースに添付されたファイルを新しい順に返す
+func (r *AttachmentRepositoryImpl) FindByResource(userID uint, resourceType string, resourceID string) ([]model.Attachment, error) {
+	if err := authorizeRecord(r.DB, resourceType, userID, resourceID); err != nil {
+		return nil, err
+	}
+	var attachments []model.Attachment
+	err := r.DB.Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("created_at DESC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// FindByUploader は userID がアップロードした添付を新しい順に返す（userID が 0 の場合はすべて）
+func (r *AttachmentRepositoryImpl) FindByUploader(userID uint) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	err := r.DB.Scopes(ownerScope("attachment", userID)).
+		Order("created_at DESC").
+		Find(&attachments).Error
+	return attachments, err
+}
+
+// Delete は添付を削除し、そのブロブを参照する添付が無くなった場合はブロブの行を削除して collect（BlobStore からの削除）を呼ぶ
+// collect が失敗した場合は添付の削除も取り消す
+func (r *AttachmentRepositoryImpl) Delete(userID uint, id string, collect func(digest string) error) error {
+	if err := authorizeWrite(r.DB, "attachment", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var attachment model.Attachment
+		if err := tx.First(&attachment, "id = ?", id).Error; err != nil {
+			if stderrors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrResourceNotFound
+			}
+			return err
+		}
+		var blob model.AttachmentBlob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&blob, "sha256 = ?", attachment.SHA256).Error
+		if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := tx.Delete(&attachment).Error; err != nil {
+			return err
+		}
+		var refs int64
+		if err := tx.Model(&model.Attachment{}).Where("sha256 = ?", attachment.SHA256).Count(&refs).Error; err != nil {
+			return err
+		}
+		if refs > 0 {
+			return nil
+		}
+		if err := tx.Delete(&model.AttachmentBlob{}, "sha256 = ?", attachment.SHA256).Error; err != nil {
+			return err
+		}
+		return collect(attachment.SHA256)
+	})
+}
@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"strings"
+	"testing"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAttachmentTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to in-memory DB: %v", err)
+	}
+	err = db.AutoMigrate(&model.Task{}, &model.Book{}, &model.WorkspaceMember{}, &model.Attachment{}, &model.AttachmentBlob{})
+	if err != nil {
+		t.Fatalf("Failed to migrate Attachment model: %v", err)
+	}
+	return db
+}
+
+func TestAttachmentRepositorySharedBlob(t *testing.T) {
+	db := setupAttachmentTestDB(t)
+	repo := &repository.AttachmentRepositoryImpl{DB: db}
+
+	task := &model.Task{UserID: 1, Title: "Attachment Task"}
+	assert.NoError(t, db.Create(task).Error)
+	book := &model.Book{TaskID: task.ID, Title: "Book"}
+	assert.NoError(t, db.Create(book).Error)
+
+	digest := strings.Repeat("a", 64)
+	stored := 0
+	store := func() error { stored++; return nil }
+
+	// 同じ内容を 2 つのリソースに添付してもブロブの行は 1 つ
+	first := &model.Attachment{ID: "att-1", UserID: 1, ResourceType: "task", ResourceID: intToStr(task.ID), FileName: "a.txt", Size: 3, SHA256: digest}
+	second := &model.Attachment{ID: "att-2", UserID: 1, ResourceType: "book", ResourceID: intToStr(book.ID), FileName: "b.txt", Size: 3, SHA256: digest}
+	assert.NoError(t, repo.Create(1, first, store))
+	assert.NoError(t, repo.Create(1, second, store))
+	assert.Equal(t, 2, stored)
+
+	var blobs int64
+	db.Model(&model.AttachmentBlob{}).Count(&blobs)
+	assert.Equal(t, int64(1), blobs)
+
+	found, err := repo.FindByResource(1, "task", intToStr(task.ID))
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, "att-1", found[0].ID)
+
+	all, err := repo.FindByUploader(1)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	// 最後の参照が消えたときだけ collect が呼ばれる
+	var collected []string
+	collect := func(d string) error { collected = append(collected, d); return nil }
+	assert.NoError(t, repo.Delete(1, "att-1", collect))
+	assert.Empty(t, collected)
+	assert.NoError(t, repo.Delete(1, "att-2", collect))
+	assert.Equal(t, []string{digest}, collected)
+
+	db.Model(&model.AttachmentBlob{}).Count(&blobs)
+	assert.Equal(t, int64(0), blobs)
+}
+
+func TestAttachmentRepositoryAuthorization(t *testing.T) {
+	db := setupAttachmentTestDB(t)
+	repo := &repository.AttachmentRepositoryImpl{DB: db}
+
+	task := &model.Task{UserID: 1, Title: "Owner Task"}
+	assert.NoError(t, db.Create(task).Error)
+
+	digest := strings.Repeat("b", 64)
+	store := func() error { return nil }
+
+	// 他人のタスクには添付できない
+	err := repo.Create(2, &model.Attachment{ID: "att-x", UserID: 2, ResourceType: "task", ResourceID: intToStr(task.ID), SHA256: digest}, store)
+	assert.Error(t, err)
+
+	attachment := &model.Attachment{ID: "att-1", UserID: 1, ResourceType: "task", ResourceID: intToStr(task.ID), SHA256: digest}
+	assert.NoError(t, repo.Create(1, attachment, store))
+
+	_, err = repo.FindByID(2, "att-1")
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+	_, err = repo.FindByID(1, "missing")
+	assert.ErrorIs(t, err, apperrors.ErrResourceNotFound)
+
+	// 管理者（userID 0）はすべて参照できる
+	found, err := repo.FindByID(0, "att-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "att-1", found.ID)
+
+	collect := func(string) error { t.Fatal("collect must not be called"); return nil }
+	assert.Error(t, repo.Delete(2, "att-1", collect))
+
+	var count int64
+	db.Model(&model.Attachment{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
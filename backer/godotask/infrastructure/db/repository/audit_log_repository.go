@@ -0,0 +1,85 @@
+package repository
+
+import (
+	stderrors "errors"
+
+	dtoquery "github.com/godotask/dto/query"
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *AuditLogRepositoryImpl) Create(auditLog *model.AuditLog) error {
+	return r.DB.Create(auditLog).Error
+}
+
+func (r *AuditLogRepositoryImpl) ListPager(filter dtoquery.AuditLogFilter, offset int, limit int) ([]model.AuditLog, int64, error) {
+	var logs []model.AuditLog
+	var total int64
+
+	q := r.DB.Model(&model.AuditLog{}).Scopes(withAuditLogFilter(filter))
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := q.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// FindInBatches: CSVエクスポート用に古い順でバッチ取得する
+func (r *AuditLogRepositoryImpl) FindInBatches(filter dtoquery.AuditLogFilter, batchSize int, fn func(logs []model.AuditLog) error) error {
+	var logs []model.AuditLog
+	return r.DB.Model(&model.AuditLog{}).
+		Scopes(withAuditLogFilter(filter)).
+		Order("id ASC").
+		FindInBatches(&logs, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(logs)
+		}).Error
+}
+
+// Snapshot: 監査対象レコードの現在値をカラム名→値のマップで返す（存在しない場合は nil）
+func (r *AuditLogRepositoryImpl) Snapshot(resourceType string, id string) (map[string]interface{}, error) {
+	table, ok := ResourceTable(resourceType)
+	if !ok || id == "" {
+		return nil, nil
+	}
+
+	row := map[string]interface{}{}
+	err := r.DB.Table(table).Where("id = ?", id).Take(&row).Error
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func withAuditLogFilter(f dtoquery.AuditLogFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if f.UserID != nil {
+			db = db.Where("user_id = ?", *f.UserID)
+		}
+		if f.ResourceType != "" {
+			db = db.Where("resource_type = ?", f.ResourceType)
+		}
+		if f.ResourceID != "" {
+			db = db.Where("resource_id = ?", f.ResourceID)
+		}
+		if f.Method != "" {
+			db = db.Where("method = ?", f.Method)
+		}
+		if f.From != nil {
+			db = db.Where("created_at >= ?", *f.From)
+		}
+		if f.To != nil {
+			db = db.Where("created_at < ?", *f.To)
+		}
+		return db
+	}
+}
@@ -3,7 +3,6 @@ package repository
 import (
 	"gorm.io/gorm"
 	"github.com/godotask/infrastructure/db/model"
-	helperquery "github.com/godotask/infrastructure/helper/query"
 )
 
 // BookRepositoryImpl
@@ -11,11 +10,17 @@ type BookRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *BookRepositoryImpl) Create(book *model.Book) error {
+func (r *BookRepositoryImpl) Create(userID uint, book *model.Book) error {
+	if err := authorizeWrite(r.DB, "task", userID, book.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(book).Error
 }
 
-func (r *BookRepositoryImpl) FindByID(id string) (*model.Book, error) {
+func (r *BookRepositoryImpl) FindByID(userID uint, id string) (*model.Book, error) {
+	if err := authorizeRecord(r.DB, "book", userID, id); err != nil {
+		return nil, err
+	}
   var book model.Book
   if err := r.DB.Where("id = ?", id).First(&book).Error; err != nil {
 		return nil, err
@@ -25,7 +30,7 @@ func (r *BookRepositoryImpl) FindByID(id string) (*model.Book, error) {
 
 func (r *BookRepositoryImpl) FindAll(userID uint) ([]model.Book, error) {
 	var books []model.Book
-	if err := r.DB.Scopes(helperquery.WithUserFilter(userID)).Order("created_at DESC, id DESC").Find(&books).Error; err != nil {
+	if err := r.DB.Scopes(ownerScope("book", userID)).Order("created_at DESC, id DESC").Find(&books).Error; err != nil {
 		return nil, err
 	}
 	return books, nil
@@ -35,7 +40,7 @@ func (r *BookRepositoryImpl) FindAllPager(userID uint, offset, limit int) ([]mod
     var books []model.Book
     var total int64
 
-    q := r.DB.Model(&model.Book{}).Scopes(helperquery.WithUserFilter(userID))
+    q := r.DB.Model(&model.Book{}).Scopes(ownerScope("book", userID))
 
     if err := q.Count(&total).Error; err != nil {
         return nil, 0, err
@@ -47,11 +52,23 @@ func (r *BookRepositoryImpl) FindAllPager(userID uint, offset, limit int) ([]mod
     return books, total, nil
 }
 
-func (r *BookRepositoryImpl) Update(id string, book *model.Book) error {
+func (r *BookRepositoryImpl) Update(userID uint, id string, book *model.Book) error {
+	if err := authorizeWrite(r.DB, "book", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if book.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, book.TaskID); err != nil {
+			return err
+		}
+	}
 	return r.DB.Model(&model.Book{}).Where("id = ?", id).Updates(book).Error
 }
 
-func (r *BookRepositoryImpl) Delete(id string) error {
+func (r *BookRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "book", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.Book{}, id).Error
 }
 
@@ -1,4 +1,4 @@
-package repository
+package repository_test
 
 import (
 	"fmt"
@@ -11,13 +11,13 @@ import (
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) *gorm.DB {
+func setupBookTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to connect to in-memory DB: %v", err)
 	}
 	// テーブル作成
-	err = db.AutoMigrate(&model.Book{})
+	err = db.AutoMigrate(&model.Task{}, &model.Book{}, &model.WorkspaceMember{})
 	if err != nil {
 		t.Fatalf("Failed to migrate Book model: %v", err)
 	}
@@ -29,10 +29,15 @@ func intToStr(id int) string {
 }
 
 func TestBookRepositoryCRUD(t *testing.T) {
-	db := setupTestDB(t)
+	db := setupBookTestDB(t)
 	repo := repository.NewBookRepository(db)
 
+	// Book は task_id 経由で所有者を判定する
+	task := &model.Task{UserID: 1, Title: "Book Task"}
+	assert.NoError(t, db.Create(task).Error)
+
 	book := &model.Book{
+		TaskID:  task.ID,
 		Title:   "Test Title",
 		Name:    "Test Author",
 		Text:    "Test content",
@@ -42,37 +47,37 @@ func TestBookRepositoryCRUD(t *testing.T) {
 	}
 
 	// --- Create ---
-	err := repo.Create(book)
+	err := repo.Create(1, book)
 	assert.NoError(t, err)
 	assert.NotZero(t, book.ID)
 
 	// --- FindByID ---
-	found, err := repo.FindByID(intToStr(book.ID))
+	found, err := repo.FindByID(1, intToStr(book.ID))
 	assert.NoError(t, err)
 	assert.Equal(t, book.Title, found.Title)
 	assert.Equal(t, book.Name, found.Name)
 
 	// --- FindAll ---
-	books, err := repo.FindAll()
+	books, err := repo.FindAll(1)
 	assert.NoError(t, err)
 	assert.Len(t, books, 1)
 
 	// --- Update ---
 	book.Status = "published"
 	book.Title = "Updated Title"
-	err = repo.Update(intToStr(book.ID), book)
+	err = repo.Update(1, intToStr(book.ID), book)
 	assert.NoError(t, err)
 
-	updated, err := repo.FindByID(intToStr(book.ID))
+	updated, err := repo.FindByID(1, intToStr(book.ID))
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Title", updated.Title)
 	assert.Equal(t, "published", updated.Status)
 
 	// --- Delete ---
-	err = repo.Delete(intToStr(book.ID))
+	err = repo.Delete(1, intToStr(book.ID))
 	assert.NoError(t, err)
 
-	deleted, err := repo.FindByID(intToStr(book.ID))
+	deleted, err := repo.FindByID(1, intToStr(book.ID))
 	assert.Error(t, err)
 	assert.Nil(t, deleted)
 }
@@ -11,11 +11,18 @@ type HeuristicsAnalysisRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *HeuristicsAnalysisRepositoryImpl) CreateAnalysis(analysis *model.HeuristicsAnalysis) error {
-  return r.DB.Create(analysis).Error
+func (r *HeuristicsAnalysisRepositoryImpl) CreateAnalysis(userID uint, analysis *model.HeuristicsAnalysis) error {
+	// 他のユーザーのタスクには作成できない
+	if err := authorizeWrite(r.DB, "task", userID, analysis.TaskID); err != nil {
+		return err
+	}
+	return r.DB.Create(analysis).Error
 }
 
-func (r *HeuristicsAnalysisRepositoryImpl) GetAnalysisById(id string) (*model.HeuristicsAnalysis, error) {
+func (r *HeuristicsAnalysisRepositoryImpl) GetAnalysisById(userID uint, id string) (*model.HeuristicsAnalysis, error) {
+	if err := authorizeRecord(r.DB, "heuristics/analyze", userID, id); err != nil {
+		return nil, err
+	}
 	var analysis model.HeuristicsAnalysis
 	if err := r.DB.First(&analysis, id).Error; err != nil {
 		return nil, err
@@ -91,11 +98,24 @@ func (r *HeuristicsAnalysisRepositoryImpl) ListAnalysesPager(filter dtoquery.Que
 	return analyses, total, nil
 }
 
-func (r *HeuristicsAnalysisRepositoryImpl) UpdateAnalysis(id string, analysis *model.HeuristicsAnalysis) error {
-    return r.DB.Model(&model.HeuristicsAnalysis{}).Where("id = ?", id).Updates(analysis).Error
+func (r *HeuristicsAnalysisRepositoryImpl) UpdateAnalysis(userID uint, id string, analysis *model.HeuristicsAnalysis) error {
+	if err := authorizeWrite(r.DB, "heuristics/analyze", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if analysis.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, analysis.TaskID); err != nil {
+			return err
+		}
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.HeuristicsAnalysis{}).Where("id = ?", id).Omit("user_id").Updates(analysis).Error
 }
 
-func (r *HeuristicsAnalysisRepositoryImpl) DeleteAnalysis(id string) error {
+func (r *HeuristicsAnalysisRepositoryImpl) DeleteAnalysis(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "heuristics/analyze", userID, id); err != nil {
+		return err
+	}
     return r.DB.Delete(&model.HeuristicsAnalysis{}, id).Error
 }
 
@@ -11,11 +11,18 @@ type HeuristicsInsightRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *HeuristicsInsightRepositoryImpl) CreateInsight(insight *model.HeuristicsInsight) error {
-  return r.DB.Create(insight).Error
+func (r *HeuristicsInsightRepositoryImpl) CreateInsight(userID uint, insight *model.HeuristicsInsight) error {
+	// 他のユーザーのタスクには作成できない
+	if err := authorizeWrite(r.DB, "task", userID, insight.TaskID); err != nil {
+		return err
+	}
+	return r.DB.Create(insight).Error
 }
 
-func (r *HeuristicsInsightRepositoryImpl) GetInsightById(id string) (*model.HeuristicsInsight, error) {
+func (r *HeuristicsInsightRepositoryImpl) GetInsightById(userID uint, id string) (*model.HeuristicsInsight, error) {
+	if err := authorizeRecord(r.DB, "heuristics/insight", userID, id); err != nil {
+		return nil, err
+	}
 	var insight model.HeuristicsInsight
 	if err := r.DB.First(&insight, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -48,10 +55,23 @@ func (r *HeuristicsInsightRepositoryImpl) ListInsightPager(filter dtoquery.Query
 	return insights, total, nil
 }
 
-func (r *HeuristicsInsightRepositoryImpl) UpdateInsight(id string, insight *model.HeuristicsInsight) error {
-  return r.DB.Model(&model.HeuristicsInsight{}).Where("id = ?", id).Updates(insight).Error
+func (r *HeuristicsInsightRepositoryImpl) UpdateInsight(userID uint, id string, insight *model.HeuristicsInsight) error {
+	if err := authorizeWrite(r.DB, "heuristics/insight", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if insight.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, insight.TaskID); err != nil {
+			return err
+		}
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.HeuristicsInsight{}).Where("id = ?", id).Omit("user_id").Updates(insight).Error
 }
 
-func (r *HeuristicsInsightRepositoryImpl) DeleteInsight(id string) error {
+func (r *HeuristicsInsightRepositoryImpl) DeleteInsight(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "heuristics/insight", userID, id); err != nil {
+		return err
+	}
   return r.DB.Delete(&model.HeuristicsInsight{}, id).Error
 }
\ No newline at end of file
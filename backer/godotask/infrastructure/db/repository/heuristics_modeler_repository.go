@@ -11,11 +11,18 @@ type HeuristicsModelerRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *HeuristicsModelerRepositoryImpl) CreateModeler(modeler *model.HeuristicsModeler) error {
-  return r.DB.Create(modeler).Error
+func (r *HeuristicsModelerRepositoryImpl) CreateModeler(userID uint, modeler *model.HeuristicsModeler) error {
+	// 他のユーザーのタスクには作成できない
+	if err := authorizeWrite(r.DB, "task", userID, modeler.TaskID); err != nil {
+		return err
+	}
+	return r.DB.Create(modeler).Error
 }
 
-func (r *HeuristicsModelerRepositoryImpl) GetModelerById(id string) (*model.HeuristicsModeler, error) {
+func (r *HeuristicsModelerRepositoryImpl) GetModelerById(userID uint, id string) (*model.HeuristicsModeler, error) {
+	if err := authorizeRecord(r.DB, "heuristics/modeler", userID, id); err != nil {
+		return nil, err
+	}
 	var modeler model.HeuristicsModeler
 	if err := r.DB.First(&modeler, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -48,10 +55,23 @@ func (r *HeuristicsModelerRepositoryImpl) ListModelerPager(filter dtoquery.Query
 	return modelers, total, nil
 }
 
-func (r *HeuristicsModelerRepositoryImpl) UpdateModeler(id string, modeler *model.HeuristicsModeler) error {
-  return r.DB.Model(&model.HeuristicsModeler{}).Where("id = ?", id).Updates(modeler).Error
+func (r *HeuristicsModelerRepositoryImpl) UpdateModeler(userID uint, id string, modeler *model.HeuristicsModeler) error {
+	if err := authorizeWrite(r.DB, "heuristics/modeler", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if modeler.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, modeler.TaskID); err != nil {
+			return err
+		}
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.HeuristicsModeler{}).Where("id = ?", id).Omit("user_id").Updates(modeler).Error
 }
 
-func (r *HeuristicsModelerRepositoryImpl) DeleteModeler(id string) error {
+func (r *HeuristicsModelerRepositoryImpl) DeleteModeler(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "heuristics/modeler", userID, id); err != nil {
+		return err
+	}
   return r.DB.Delete(&model.HeuristicsModeler{}, id).Error
 }
@@ -12,11 +12,18 @@ type HeuristicsPatternRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *HeuristicsPatternRepositoryImpl) CreatePattern(pattern *model.HeuristicsPattern) error {
-  return r.DB.Create(pattern).Error
+func (r *HeuristicsPatternRepositoryImpl) CreatePattern(userID uint, pattern *model.HeuristicsPattern) error {
+	// 他のユーザーのタスクには作成できない
+	if err := authorizeWrite(r.DB, "task", userID, pattern.TaskID); err != nil {
+		return err
+	}
+	return r.DB.Create(pattern).Error
 }
 
-func (r *HeuristicsPatternRepositoryImpl) GetPatternById(id string) (*model.HeuristicsPattern, error) {
+func (r *HeuristicsPatternRepositoryImpl) GetPatternById(userID uint, id string) (*model.HeuristicsPattern, error) {
+	if err := authorizeRecord(r.DB, "heuristics/pattern", userID, id); err != nil {
+		return nil, err
+	}
 	var pattern model.HeuristicsPattern
 	if err := r.DB.First(&pattern, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -69,10 +76,23 @@ func (r *HeuristicsPatternRepositoryImpl) GetPatterns(userID string, limit, offs
 	return patterns, int(total), nil
 }
 
-func (r *HeuristicsPatternRepositoryImpl) UpdatePattern(id string, pattern *model.HeuristicsPattern) error {
-  return r.DB.Model(&model.HeuristicsPattern{}).Where("id = ?", id).Updates(pattern).Error
+func (r *HeuristicsPatternRepositoryImpl) UpdatePattern(userID uint, id string, pattern *model.HeuristicsPattern) error {
+	if err := authorizeWrite(r.DB, "heuristics/pattern", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if pattern.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, pattern.TaskID); err != nil {
+			return err
+		}
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.HeuristicsPattern{}).Where("id = ?", id).Omit("user_id").Updates(pattern).Error
 }
 
-func (r *HeuristicsPatternRepositoryImpl) DeletePattern(id string) error {
+func (r *HeuristicsPatternRepositoryImpl) DeletePattern(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "heuristics/pattern", userID, id); err != nil {
+		return err
+	}
   return r.DB.Delete(&model.HeuristicsPattern{}, id).Error
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"strconv"
+	"testing"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupHeuristicsTestDB(t *testing.T) (*gorm.DB, *model.Task, *model.Task) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to in-memory DB: %v", err)
+	}
+	err = db.AutoMigrate(&model.Task{}, &model.WorkspaceMember{}, &model.HeuristicsAnalysis{}, &model.HeuristicsInsight{}, &model.HeuristicsPattern{}, &model.HeuristicsModeler{})
+	if err != nil {
+		t.Fatalf("Failed to migrate Heuristics models: %v", err)
+	}
+
+	task := &model.Task{UserID: 1, Title: "Mine"}
+	assert.NoError(t, db.Create(task).Error)
+	other := &model.Task{UserID: 2, Title: "Other"}
+	assert.NoError(t, db.Create(other).Error)
+	return db, task, other
+}
+
+func TestHeuristicsAnalysisRepositoryRejectsOtherUsersTask(t *testing.T) {
+	db, task, other := setupHeuristicsTestDB(t)
+	repo := &repository.HeuristicsAnalysisRepositoryImpl{DB: db}
+
+	// 他人のタスクには作成できない
+	err := repo.CreateAnalysis(1, &model.HeuristicsAnalysis{UserID: 1, TaskID: other.ID, Result: "{}"})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	analysis := &model.HeuristicsAnalysis{UserID: 1, TaskID: task.ID, Result: "{}"}
+	assert.NoError(t, repo.CreateAnalysis(1, analysis))
+	// 他人のタスクへの付け替えもできない
+	err = repo.UpdateAnalysis(1, strconv.Itoa(analysis.ID), &model.HeuristicsAnalysis{TaskID: other.ID})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+}
+
+func TestHeuristicsInsightRepositoryRejectsOtherUsersTask(t *testing.T) {
+	db, task, other := setupHeuristicsTestDB(t)
+	repo := &repository.HeuristicsInsightRepositoryImpl{DB: db}
+
+	err := repo.CreateInsight(1, &model.HeuristicsInsight{UserID: 1, TaskID: other.ID, Data: "{}"})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	insight := &model.HeuristicsInsight{UserID: 1, TaskID: task.ID, Data: "{}"}
+	assert.NoError(t, repo.CreateInsight(1, insight))
+	err = repo.UpdateInsight(1, strconv.Itoa(insight.ID), &model.HeuristicsInsight{TaskID: other.ID})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+}
+
+func TestHeuristicsPatternRepositoryRejectsOtherUsersTask(t *testing.T) {
+	db, task, other := setupHeuristicsTestDB(t)
+	repo := &repository.HeuristicsPatternRepositoryImpl{DB: db}
+
+	err := repo.CreatePattern(1, &model.HeuristicsPattern{UserID: 1, TaskID: other.ID, Pattern: "{}"})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	pattern := &model.HeuristicsPattern{UserID: 1, TaskID: task.ID, Pattern: "{}"}
+	assert.NoError(t, repo.CreatePattern(1, pattern))
+	err = repo.UpdatePattern(1, strconv.Itoa(pattern.ID), &model.HeuristicsPattern{TaskID: other.ID})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+}
+
+func TestHeuristicsModelerRepositoryRejectsOtherUsersTask(t *testing.T) {
+	db, task, other := setupHeuristicsTestDB(t)
+	repo := &repository.HeuristicsModelerRepositoryImpl{DB: db}
+
+	err := repo.CreateModeler(1, &model.HeuristicsModeler{UserID: 1, TaskID: other.ID, Parameters: "{}", Performance: "{}"})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	modeler := &model.HeuristicsModeler{UserID: 1, TaskID: task.ID, Parameters: "{}", Performance: "{}"}
+	assert.NoError(t, repo.CreateModeler(1, modeler))
+	err = repo.UpdateModeler(1, strconv.Itoa(modeler.ID), &model.HeuristicsModeler{TaskID: other.ID})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImportRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// FindOwned は userID が所有するレコード（ワークスペースで共有されたものは含まない）を
+// dest（モデルのスライスへのポインタ）に読み込む。インポートの自然キーの照合に使う
+func (r *ImportRepositoryImpl) FindOwned(userID uint, resourceType string, dest interface{}) error {
+	rt, ok := resourceTables[resourceType]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownResourceType, resourceType)
+	}
+	q := r.DB.Table(rt.Table)
+	if rt.OwnedViaTask {
+		tasks := r.DB.Session(&gorm.Session{NewDB: true}).Table("tasks").Select("id").Where("user_id = ?", userID)
+		q = q.Where(rt.Table+".task_id IN (?)", tasks)
+	} else {
+		q = q.Where(rt.Table+".user_id = ?", userID)
+	}
+	return q.Order(rt.Table + ".id ASC").Find(dest).Error
+}
+
+// AuthorizeReference は行が参照するレコード（タスクなど）を userID が更新できるかを確認する
+func (r *ImportRepositoryImpl) AuthorizeReference(userID uint, resourceType string, id interface{}) error {
+	return authorizeWrite(r.DB, resourceType, userID, id)
+}
+
+// Apply は creates を作成し、updates を更新する（いずれもモデルへのポインタ）。1 つのトランザクションで行う
+func (r *ImportRepositoryImpl) Apply(creates, updates []interface{}) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, record := range creates {
+			if err := tx.Omit(clause.Associations).Create(record).Error; err != nil {
+				return err
+			}
+		}
+		for _, record := range updates {
+			if err := tx.Select("*").Omit(clause.Associations, "id", "created_at").Updates(record).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// NewImportRepository は ImportRepositoryInterface を返すコンストラクタ
+func NewImportRepository(db *gorm.DB) ImportRepositoryInterface {
+	return &ImportRepositoryImpl{DB: db}
+}
@@ -0,0 +1,95 @@
+package repository_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupImportTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Task{}, &model.Memory{}, &model.WorkspaceMember{}, &model.KnowledgePattern{}))
+	return db
+}
+
+func TestImportRepository(t *testing.T) {
+	db := setupImportTestDB(t)
+	repo := repository.NewImportRepository(db)
+
+	own := &model.Task{UserID: 1, Title: "Deburring"}
+	other := &model.Task{UserID: 2, Title: "Other"}
+	require.NoError(t, db.Create(own).Error)
+	require.NoError(t, db.Create(other).Error)
+	require.NoError(t, db.Create(&[]model.KnowledgePattern{
+		{ID: "k1", TaskID: own.ID, TacitKnowledge: "angle"},
+		{ID: "k2", TaskID: other.ID, TacitKnowledge: "speed"},
+	}).Error)
+
+	// 自分のレコードのみ（タスクに属するものはタスクの所有者で絞り込む）
+	var tasks []model.Task
+	require.NoError(t, repo.FindOwned(1, "task", &tasks))
+	require.Len(t, tasks, 1)
+	assert.Equal(t, own.ID, tasks[0].ID)
+	var patterns []model.KnowledgePattern
+	require.NoError(t, repo.FindOwned(1, "knowledge_pattern", &patterns))
+	require.Len(t, patterns, 1)
+	assert.Equal(t, "k1", patterns[0].ID)
+
+	assert.NoError(t, repo.AuthorizeReference(1, "task", own.ID))
+	assert.ErrorIs(t, repo.AuthorizeReference(1, "task", other.ID), apperrors.ErrResourceAccessDenied)
+	assert.ErrorIs(t, repo.AuthorizeReference(1, "task", 999), apperrors.ErrResourceNotFound)
+
+	// 1 件でも失敗すればすべて戻す
+	tasks[0].Description = "updated"
+	err := repo.Apply([]interface{}{&model.Task{UserID: 1, Title: "New"}, &model.KnowledgePattern{ID: "k1"}}, []interface{}{&tasks[0]})
+	assert.Error(t, err)
+	var count int64
+	db.Model(&model.Task{}).Where("title = ?", "New").Count(&count)
+	assert.Zero(t, count)
+
+	require.NoError(t, repo.Apply([]interface{}{&model.Task{UserID: 1, Title: "New"}}, []interface{}{&tasks[0]}))
+	var saved model.Task
+	require.NoError(t, db.First(&saved, own.ID).Error)
+	assert.Equal(t, "updated", saved.Description)
+	db.Model(&model.Task{}).Where("title = ?", "New").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+// 同じファイルを 2 回取り込むと 2 回目はすべて変更なしになる
+func TestImportServiceIsIdempotent(t *testing.T) {
+	db := setupImportTestDB(t)
+	s := &service.ImportService{
+		Repo: repository.NewImportRepository(db),
+		Now:  func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) },
+	}
+	csv := "title,description,date,status,priority,created_at\nDeburring,Remove burrs,2026-04-01,todo,2,2026-03-01 10:00:00\nInspection,Check,,done,1,\n"
+
+	res, err := s.Import(1, "task", service.ImportFormatCSV, strings.NewReader(csv), true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Created)
+
+	res, err = s.Import(1, "task", service.ImportFormatCSV, strings.NewReader(csv), true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Created)
+	assert.Equal(t, 0, res.Updated)
+	assert.Equal(t, 2, res.Unchanged)
+
+	res, err = s.Import(1, "task", service.ImportFormatCSV, strings.NewReader(strings.Replace(csv, "Check", "Recheck", 1)), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Updated)
+	var tasks []model.Task
+	require.NoError(t, db.Order("id").Find(&tasks).Error)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "Recheck", tasks[1].Description)
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), tasks[0].CreatedAt.UTC())
+}
@@ -1,35 +1,37 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/godotask/infrastructure/db/model"
 	dtoquery "github.com/godotask/dto/query"
 )
 
 type BookRepositoryInterface interface {
-	Create(book *model.Book) error
-	FindByID(id string) (*model.Book, error)
+	Create(userID uint, book *model.Book) error
+	FindByID(userID uint, id string) (*model.Book, error)
 	FindAll(userID uint) ([]model.Book, error)
-	Update(id string, book *model.Book) error
-	Delete(id string) error
+	Update(userID uint, id string, book *model.Book) error
+	Delete(userID uint, id string) error
 }
 
 type AssessmentRepositoryInterface interface {
-	Create(assessment *model.Assessment) error
-	FindByID(id string) (*model.Assessment, error)
+	Create(userID uint, assessment *model.Assessment) error
+	FindByID(userID uint, id string) (*model.Assessment, error)
 	FindAll(userID uint) ([]model.Assessment, int64, error)
 	ListAssessmentsPager(userID uint, offset int, perPage int) ([]model.Assessment, int64, error)
 	ListAssessmentsForTaskUserPager(filter dtoquery.QueryFilter, offset int, perPage int) ([]model.Assessment, int64, error)
-	Update(id string, assessment *model.Assessment) error
-	Delete(id string) error
+	Update(userID uint, id string, assessment *model.Assessment) error
+	Delete(userID uint, id string) error
 }
 
 type MemoryRepositoryInterface interface {
 	Create(memory *model.Memory) error
-	FindByID(id string) (*model.Memory, error)
+	FindByID(userID uint, id string) (*model.Memory, error)
 	FindAll(userID uint) ([]model.Memory, error)
 	ListMemoriesPager(userID uint, offset int, limit int) ([]model.Memory, int64, error)
-	Update(id string, memory *model.Memory) error
-	Delete(id string) error
+	Update(userID uint, id string, memory *model.Memory) error
+	Delete(userID uint, id string) error
 }
 
 type MemoryContextRepositoryInterface interface {
@@ -39,96 +41,266 @@ type MemoryContextRepositoryInterface interface {
 
 type TaskRepositoryInterface interface {
 	Create(task *model.Task) error
-	FindByID(id string) (*model.Task, error)
+	FindByID(userID uint, id string) (*model.Task, error)
 	FindAll(userID uint) ([]model.Task, error)
 	ListTasksPager(filter dtoquery.QueryFilter, offset int, perPage int) ([]model.Task, int64, error)
 	ListSearchTasksPager(filter dtoquery.QueryFilter, offset int, perPage int) ([]model.Task, int64, error)
 	ListTasksByUserPager(userID uint, offset int, perPage int) ([]model.Task, int64, error)
-	Update(id string, task *model.Task) error
-	Delete(id string) error
+	Update(userID uint, id string, task *model.Task) error
+	Delete(userID uint, id string) error
 }
 
 type HeuristicsAnalysisRepositoryInterface interface {
-	CreateAnalysis(analysis *model.HeuristicsAnalysis) error
-	GetAnalysisById(id string) (*model.HeuristicsAnalysis, error)
+	CreateAnalysis(userID uint, analysis *model.HeuristicsAnalysis) error
+	GetAnalysisById(userID uint, id string) (*model.HeuristicsAnalysis, error)
 	ListAnalyze() ([]model.HeuristicsAnalysis, error)
 	ListAnalysesPager(filter dtoquery.QueryFilter, offset int, limit int) ([]model.HeuristicsAnalysis, int64, error)
-	UpdateAnalysis(id string, analysis *model.HeuristicsAnalysis) error
-	DeleteAnalysis(id string) error
+	UpdateAnalysis(userID uint, id string, analysis *model.HeuristicsAnalysis) error
+	DeleteAnalysis(userID uint, id string) error
 }
 
 type HeuristicsInsightRepositoryInterface interface {
-	CreateInsight(insight *model.HeuristicsInsight) error
-	GetInsightById(id string) (*model.HeuristicsInsight, error)
+	CreateInsight(userID uint, insight *model.HeuristicsInsight) error
+	GetInsightById(userID uint, id string) (*model.HeuristicsInsight, error)
 	ListInsight() ([]model.HeuristicsInsight, error)
 	ListInsightPager(filter dtoquery.QueryFilter, offset int, limit int) ([]model.HeuristicsInsight, int64, error)
-	UpdateInsight(id string, insight *model.HeuristicsInsight) error
-	DeleteInsight(id string) error
+	UpdateInsight(userID uint, id string, insight *model.HeuristicsInsight) error
+	DeleteInsight(userID uint, id string) error
 }
 
 type HeuristicsPatternRepositoryInterface interface {
-	CreatePattern(pattern *model.HeuristicsPattern) error
-	GetPatternById(id string) (*model.HeuristicsPattern, error)
+	CreatePattern(userID uint, pattern *model.HeuristicsPattern) error
+	GetPatternById(userID uint, id string) (*model.HeuristicsPattern, error)
 	ListPattern(userID uint) ([]model.HeuristicsPattern, error)
 	ListPatternPager(filter dtoquery.QueryFilter, offset int, limit int) ([]model.HeuristicsPattern, int64, error)
 	GetPatterns(userID string, limit, offset int) ([]model.HeuristicsPattern, int, error)
-	UpdatePattern(id string, insight *model.HeuristicsPattern) error
-	DeletePattern(id string) error
+	UpdatePattern(userID uint, id string, insight *model.HeuristicsPattern) error
+	DeletePattern(userID uint, id string) error
 }
 
 type HeuristicsModelerRepositoryInterface interface {
-	CreateModeler(modeler *model.HeuristicsModeler) error
-	GetModelerById(id string) (*model.HeuristicsModeler, error)
+	CreateModeler(userID uint, modeler *model.HeuristicsModeler) error
+	GetModelerById(userID uint, id string) (*model.HeuristicsModeler, error)
 	ListModeler(userID uint) ([]model.HeuristicsModeler, error)
 	ListModelerPager(filter dtoquery.QueryFilter, offset int, limit int) ([]model.HeuristicsModeler, int64, error)
-	UpdateModeler(id string, modeler *model.HeuristicsModeler) error
-	DeleteModeler(id string) error
+	UpdateModeler(userID uint, id string, modeler *model.HeuristicsModeler) error
+	DeleteModeler(userID uint, id string) error
+}
+
+type OptimizationModelRepositoryInterface interface {
+	Create(optimizationModel *model.OptimizationModel) error
+	FindByID(id string) (*model.OptimizationModel, error)
+	FindAll() ([]model.OptimizationModel, error)
+	Update(id string, optimizationModel *model.OptimizationModel) error
+	Delete(id string) error
 }
 
 type ProcessOptimizationRepositoryInterface interface {
-	Create(processOptimization *model.ProcessOptimization) error
-	FindByID(id string) (*model.ProcessOptimization, error)
+	Create(userID uint, processOptimization *model.ProcessOptimization) error
+	FindByID(userID uint, id string) (*model.ProcessOptimization, error)
 	FindAll(userID uint) ([]model.ProcessOptimization, error)
-	Update(id string, processOptimization *model.ProcessOptimization) error
+	Update(userID uint, id string, processOptimization *model.ProcessOptimization) error
+	// Modify は記録を行ロックして読み込み、update が変更した内容を保存する
+	Modify(userID uint, id string, update func(processOptimization *model.ProcessOptimization) error) (*model.ProcessOptimization, error)
+	Delete(userID uint, id string) error
+}
+
+type StateEvaluationRepositoryInterface interface {
+	Create(userID uint, stateEvaluation *model.StateEvaluation) error
+	FindByID(userID uint, id string) (*model.StateEvaluation, error)
+	FindAll(userID uint) ([]model.StateEvaluation, error)
+	// History は作業者・作業対象ごとの評価を作成日時の昇順で返す
+	History(userID uint, workerID string, workTarget string) ([]model.StateEvaluation, error)
+}
+
+// RobotSpecificationRepositoryInterface は全ユーザー共通のロボット仕様のカタログ
+type RobotSpecificationRepositoryInterface interface {
+	Create(robot *model.RobotSpecification) error
+	FindByID(id string) (*model.RobotSpecification, error)
+	FindAll() ([]model.RobotSpecification, error)
+	Update(id string, robot *model.RobotSpecification) error
 	Delete(id string) error
 }
 
+type ToolMatchingResultRepositoryInterface interface {
+	// ReplaceForEvaluation は状態評価に対するマッチング結果を入れ替える
+	ReplaceForEvaluation(userID uint, stateEvaluationID string, results []model.ToolMatchingResult) error
+	FindByEvaluation(userID uint, stateEvaluationID string) ([]model.ToolMatchingResult, error)
+}
+
+type ProcessMonitoringRepositoryInterface interface {
+	Create(userID uint, monitoring *model.ProcessMonitoring) error
+	FindByID(userID uint, id string) (*model.ProcessMonitoring, error)
+	FindByEvaluation(userID uint, stateEvaluationID string) ([]model.ProcessMonitoring, error)
+	Modify(userID uint, id string, update func(monitoring *model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error)) (*model.ProcessMonitoring, error)
+	FindSamples(userID uint, id string, metric string) ([]model.ProcessMonitoringSample, error)
+}
+
+type SPCRepositoryInterface interface {
+	CreateMeasurements(userID uint, taskID int, measurements []model.SPCMeasurement) error
+	FindMeasurements(userID uint, taskID int, labelID string, limit int) ([]model.SPCMeasurement, error)
+	FindMultimodal(userID uint, taskID int, unit string, limit int) ([]model.MultimodalData, error)
+	FindLabel(userID uint, taskID int, labelID string) (*model.QuantificationLabel, error)
+}
+
+type QuantificationLabelRepositoryInterface interface {
+	Create(userID uint, label *model.QuantificationLabel) error
+	FindByID(userID uint, id string) (*model.QuantificationLabel, error)
+	Search(userID uint, query *model.LabelSearchQuery) ([]model.QuantificationLabel, int64, error)
+	FindAll(userID uint, domain string) ([]model.QuantificationLabel, error)
+	Modify(userID uint, id string, update func(label *model.QuantificationLabel) (*model.LabelRevision, error)) (*model.QuantificationLabel, error)
+	Delete(userID uint, id string) error
+	FindRevisions(userID uint, id string) ([]model.LabelRevision, error)
+	FindSuggestionCandidates(userID uint, domain string) ([]model.QuantificationLabel, error)
+	FindRelations(labelIDs []string, relationType string) ([]model.LabelRelation, error)
+	Verify(id string, verify func(label *model.QuantificationLabel, verifications []model.LabelVerification) (*model.LabelVerification, *model.LabelRevision, error)) (*model.QuantificationLabel, error)
+	FindVerifications(userID uint, id string) ([]model.LabelVerification, error)
+	FindReviewQueue(verifierID uint, domain string) ([]model.QuantificationLabel, error)
+}
+
+type LabelDatasetRepositoryInterface interface {
+	Create(userID uint, dataset *model.LabelDataset) error
+	FindByID(userID uint, id string) (*model.LabelDataset, error)
+	FindAll(userID uint) ([]model.LabelDataset, error)
+	Modify(userID uint, id string, update func(dataset *model.LabelDataset) error) (*model.LabelDataset, error)
+	UpdateMetrics(dataset *model.LabelDataset) error
+	Delete(userID uint, id string) error
+	FindLabels(userID uint, ids []string) ([]model.QuantificationLabel, error)
+	FindVerifications(labelIDs []string) ([]model.LabelVerification, error)
+	CreateSnapshot(userID uint, dataset *model.LabelDataset, snapshot *model.LabelDatasetSnapshot) error
+	FindSnapshots(userID uint, datasetID string) ([]model.LabelDatasetSnapshot, error)
+	FindSnapshot(userID uint, datasetID string, version int) (*model.LabelDatasetSnapshot, error)
+}
+
+type LabelOntologyRepositoryInterface interface {
+	FindRelations() ([]model.LabelRelation, error)
+	FindLabelRelations(labelID string) ([]model.LabelRelation, error)
+	CreateRelation(userID uint, relation *model.LabelRelation, check func(relations []model.LabelRelation) error) error
+	DeleteRelation(userID uint, id string) error
+	FindLabels(userID uint, ids []string) ([]model.QuantificationLabel, error)
+	FindLabelsByText(userID uint, normalized string) ([]model.QuantificationLabel, error)
+	NormalizeTexts(userID uint, normalize func(label *model.QuantificationLabel) string) (int, error)
+}
+
+type ImportRepositoryInterface interface {
+	FindOwned(userID uint, resourceType string, dest interface{}) error
+	AuthorizeReference(userID uint, resourceType string, id interface{}) error
+	Apply(creates, updates []interface{}) error
+}
+
+type AccountDataRepositoryInterface interface {
+	FindAccountData(userID uint) (*model.AccountData, error)
+	RestoreAccountData(userID uint, data *model.AccountData) (*model.AccountImportResult, error)
+}
+
 type PhenomenologicalFrameworkRepositoryInterface interface {
-	Create(phenomenologicalFramework *model.PhenomenologicalFramework) error
-	FindByID(id string) (*model.PhenomenologicalFramework, error)
+	Create(userID uint, phenomenologicalFramework *model.PhenomenologicalFramework) error
+	FindByID(userID uint, id string) (*model.PhenomenologicalFramework, error)
 	FindAll(userID uint) ([]model.PhenomenologicalFramework, error)
-	Update(id string, phenomenologicalFramework *model.PhenomenologicalFramework) error
-	Delete(id string) error
+	Update(userID uint, id string, phenomenologicalFramework *model.PhenomenologicalFramework) error
+	Delete(userID uint, id string) error
+	Modify(userID uint, id string, update func(phenomenologicalFramework *model.PhenomenologicalFramework) error) (*model.PhenomenologicalFramework, error)
+	FindTaskRecords(userID uint, taskID int) ([]model.Assessment, []model.HeuristicsAnalysis, []model.QuantificationLabel, error)
 }
 
 type QualitativeLabelRepositoryInterface interface {
-	Create(qualitativeLabel *model.QualitativeLabel) error
-	FindByID(id string) (*model.QualitativeLabel, error)
+	Create(userID uint, qualitativeLabel *model.QualitativeLabel) error
+	FindByID(userID uint, id string) (*model.QualitativeLabel, error)
 	FindAll(userID uint) ([]model.QualitativeLabel, error)
-	Update(id string, qualitativeLabel *model.QualitativeLabel) error
-	Delete(id string) error
+	Update(userID uint, id string, qualitativeLabel *model.QualitativeLabel) error
+	Delete(userID uint, id string) error
 }
 
 type KnowledgePatternRepositoryInterface interface {
-	Create(knowledgePattern *model.KnowledgePattern) error
-	FindByID(id string) (*model.KnowledgePattern, error)
+	Create(userID uint, knowledgePattern *model.KnowledgePattern) error
+	FindByID(userID uint, id string) (*model.KnowledgePattern, error)
 	FindAll(userID uint) ([]model.KnowledgePattern, error)
-	Update(id string, knowledgePattern *model.KnowledgePattern) error
-	Delete(id string) error
+	Update(userID uint, id string, knowledgePattern *model.KnowledgePattern) error
+	Delete(userID uint, id string) error
 }
 
 type LanguageOptimizationRepositoryInterface interface {
-	Create(languageOptimization *model.LanguageOptimization) error
-	FindByID(id string) (*model.LanguageOptimization, error)
+	Create(userID uint, languageOptimization *model.LanguageOptimization) error
+	FindByID(userID uint, id string) (*model.LanguageOptimization, error)
 	FindAll(userID uint) ([]model.LanguageOptimization, error)
-	Update(id string, languageOptimization *model.LanguageOptimization) error
-	Delete(id string) error
+	Update(userID uint, id string, languageOptimization *model.LanguageOptimization) error
+	Delete(userID uint, id string) error
 }
 
 type TeachingFreeControlRepositoryInterface interface {
-	Create(teachingFreeControl *model.TeachingFreeControl) error
-	FindByID(id string) (*model.TeachingFreeControl, error)
+	Create(userID uint, teachingFreeControl *model.TeachingFreeControl) error
+	FindByID(userID uint, id string) (*model.TeachingFreeControl, error)
 	FindAll(userID uint) ([]model.TeachingFreeControl, error)
-	Update(id string, teachingFreeControl *model.TeachingFreeControl) error
+	Update(userID uint, id string, teachingFreeControl *model.TeachingFreeControl) error
+	Delete(userID uint, id string) error
+	RecordAttempts(userID uint, id string, attempts []model.TeachingFreeControlAttempt, update func(teachingFreeControl *model.TeachingFreeControl, all []model.TeachingFreeControlAttempt) error) (*model.TeachingFreeControl, error)
+	FindAttempts(userID uint, id string, limit int) ([]model.TeachingFreeControlAttempt, error)
+	FindAttemptsBy(userID uint, robotID string, taskType string, limit int) ([]model.TeachingFreeControlAttempt, error)
+}
+
+type AuditLogRepositoryInterface interface {
+	Create(auditLog *model.AuditLog) error
+	ListPager(filter dtoquery.AuditLogFilter, offset int, limit int) ([]model.AuditLog, int64, error)
+	FindInBatches(filter dtoquery.AuditLogFilter, batchSize int, fn func(logs []model.AuditLog) error) error
+	Snapshot(resourceType string, id string) (map[string]interface{}, error)
+}
+
+type ResourceRepositoryInterface interface {
+	FindOwnerID(resourceType string, id string) (uint, error)
+	HasWorkspaceAccess(resourceType string, id string, userID uint, write bool) (bool, error)
+}
+
+type WorkspaceRepositoryInterface interface {
+	Create(workspace *model.Workspace, ownerID uint) error
+	FindByID(id uint) (*model.Workspace, error)
+	ListByUser(userID uint) ([]model.Workspace, error)
+	Update(id uint, workspace *model.Workspace) error
+	Delete(id uint) error
+
+	FindMember(workspaceID, userID uint) (*model.WorkspaceMember, error)
+	UpdateMemberRole(workspaceID, userID uint, role string) error
+	RemoveMember(workspaceID, userID uint) error
+	CountOwners(workspaceID uint) (int64, error)
+
+	CreateInvitation(invitation *model.WorkspaceInvitation) error
+	ListPendingInvitations(workspaceID uint, now time.Time) ([]model.WorkspaceInvitation, error)
+	FindInvitationByHash(hash string) (*model.WorkspaceInvitation, error)
+	AcceptInvitation(invitation *model.WorkspaceInvitation, userID uint, at time.Time) (*model.WorkspaceMember, error)
+	DeleteInvitation(workspaceID, id uint) error
+	FindUserEmail(userID uint) (string, error)
+}
+
+type AttachmentRepositoryInterface interface {
+	Create(userID uint, attachment *model.Attachment, store func() error) error
+	FindByID(userID uint, id string) (*model.Attachment, error)
+	FindByResource(userID uint, resourceType string, resourceID string) ([]model.Attachment, error)
+	FindByUploader(userID uint) ([]model.Attachment, error)
+	Delete(userID uint, id string, collect func(digest string) error) error
+}
+
+type MultimodalRepositoryInterface interface {
+	Create(userID uint, data *model.MultimodalData) error
+	FindByID(userID uint, id string) (*model.MultimodalData, error)
+	FindByTask(userID uint, taskID int) ([]model.MultimodalData, error)
+	FindImages(userID uint) ([]model.MultimodalData, error)
+	Delete(userID uint, id string) error
+	CreateAnnotation(userID uint, annotation *model.ImageAnnotation) error
+	FindAnnotation(userID uint, id string) (*model.ImageAnnotation, error)
+	FindAnnotations(userID uint, imageID string) ([]model.ImageAnnotation, error)
+	UpdateAnnotation(userID uint, id string, annotation *model.ImageAnnotation) error
+	DeleteAnnotation(userID uint, id string) error
+	CreateCalibration(userID uint, calibration *model.UserCalibration) error
+	FindCalibrations(userID uint, imageID string) ([]model.UserCalibration, error)
+	// FindEffectiveCalibration は画像に適用する校正を返す。無い場合は nil
+	FindEffectiveCalibration(userID uint, data *model.MultimodalData) (*model.UserCalibration, error)
+	DeleteCalibration(userID uint, id string) error
+}
+
+// VisualMetaphorRepositoryInterface は全ユーザー共通の校正の基準物のカタログ
+type VisualMetaphorRepositoryInterface interface {
+	Create(metaphor *model.VisualMetaphor) error
+	FindByID(id string) (*model.VisualMetaphor, error)
+	FindAll() ([]model.VisualMetaphor, error)
+	Update(id string, metaphor *model.VisualMetaphor) error
 	Delete(id string) error
 }
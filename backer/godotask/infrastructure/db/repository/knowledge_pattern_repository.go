@@ -3,18 +3,23 @@ package repository
 import (
 	"gorm.io/gorm"
 	"github.com/godotask/infrastructure/db/model"
-	helperquery "github.com/godotask/infrastructure/helper/query"
 )
 
 type KnowledgePatternRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *KnowledgePatternRepositoryImpl) Create(knowledgePattern *model.KnowledgePattern) error {
+func (r *KnowledgePatternRepositoryImpl) Create(userID uint, knowledgePattern *model.KnowledgePattern) error {
+	if err := authorizeWrite(r.DB, "task", userID, knowledgePattern.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(knowledgePattern).Error
 }
 
-func (r *KnowledgePatternRepositoryImpl) FindByID(id string) (*model.KnowledgePattern, error) {
+func (r *KnowledgePatternRepositoryImpl) FindByID(userID uint, id string) (*model.KnowledgePattern, error) {
+	if err := authorizeRecord(r.DB, "knowledge_pattern", userID, id); err != nil {
+		return nil, err
+	}
 	var knowledgePattern model.KnowledgePattern
 	if err := r.DB.Where("id = ?", id).First(&knowledgePattern).Error; err != nil {
 		return nil, err
@@ -24,17 +29,29 @@ func (r *KnowledgePatternRepositoryImpl) FindByID(id string) (*model.KnowledgePa
 
 func (r *KnowledgePatternRepositoryImpl) FindAll(userID uint) ([]model.KnowledgePattern, error) {
 	var knowledgePatterns []model.KnowledgePattern
-	if err := r.DB.Scopes(helperquery.WithUserFilter(userID)).Order("created_at DESC, id DESC").Find(&knowledgePatterns).Error; err != nil {
+	if err := r.DB.Scopes(ownerScope("knowledge_pattern", userID)).Order("created_at DESC, id DESC").Find(&knowledgePatterns).Error; err != nil {
 		return nil, err
 	}
 	return knowledgePatterns, nil
 }
 
-func (r *KnowledgePatternRepositoryImpl) Update(id string, knowledgePattern *model.KnowledgePattern) error {
+func (r *KnowledgePatternRepositoryImpl) Update(userID uint, id string, knowledgePattern *model.KnowledgePattern) error {
+	if err := authorizeWrite(r.DB, "knowledge_pattern", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if knowledgePattern.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, knowledgePattern.TaskID); err != nil {
+			return err
+		}
+	}
 	return r.DB.Model(&model.KnowledgePattern{}).Where("id = ?", id).Updates(knowledgePattern).Error
 }
 
-func (r *KnowledgePatternRepositoryImpl) Delete(id string) error {
+func (r *KnowledgePatternRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "knowledge_pattern", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.KnowledgePattern{}, id).Error
 }
 
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"strconv"
+
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LabelDatasetRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// datasetLabels はデータセットのラベルを ID 順に読み込む
+func datasetLabels(db *gorm.DB) *gorm.DB {
+	return db.Order("quantification_labels.id ASC")
+}
+
+// Create はデータセットを作成する。Labels は既存のラベルとして関連付けのみ行う
+func (r *LabelDatasetRepositoryImpl) Create(userID uint, dataset *model.LabelDataset) error {
+	return r.DB.Omit("Labels.*").Create(dataset).Error
+}
+
+func (r *LabelDatasetRepositoryImpl) FindByID(userID uint, id string) (*model.LabelDataset, error) {
+	if err := authorizeRecord(r.DB, "label_dataset", userID, id); err != nil {
+		return nil, err
+	}
+	var dataset model.LabelDataset
+	if err := r.DB.Preload("Labels", datasetLabels).Where("id = ?", id).First(&dataset).Error; err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+// FindAll は自分のデータセットを返す（ラベルは含まない）
+func (r *LabelDatasetRepositoryImpl) FindAll(userID uint) ([]model.LabelDataset, error) {
+	var datasets []model.LabelDataset
+	err := r.DB.Scopes(ownerScope("label_dataset", userID)).Order("created_at DESC, id DESC").Find(&datasets).Error
+	if err != nil {
+		return nil, err
+	}
+	return datasets, nil
+}
+
+// Modify はデータセットを行ロックして読み込み、update が変更した項目とラベルの関連付けを保存する
+func (r *LabelDatasetRepositoryImpl) Modify(userID uint, id string, update func(dataset *model.LabelDataset) error) (*model.LabelDataset, error) {
+	if err := authorizeWrite(r.DB, "label_dataset", userID, id); err != nil {
+		return nil, err
+	}
+	var dataset model.LabelDataset
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Labels", datasetLabels).
+			Where("id = ?", id).
+			First(&dataset).Error
+		if err != nil {
+			return err
+		}
+		if err := update(&dataset); err != nil {
+			return err
+		}
+		err = tx.Model(&dataset).Select("name", "description", "domain", "license", "citation", "updated_at").Updates(&dataset).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&dataset).Omit("Labels.*").Association("Labels").Replace(dataset.Labels)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+// UpdateMetrics はデータセットの統計情報と品質メトリクスを保存する
+func (r *LabelDatasetRepositoryImpl) UpdateMetrics(dataset *model.LabelDataset) error {
+	return r.DB.Model(dataset).
+		Select("total_labels", "verified_labels", "average_accuracy", "completeness", "consistency", "diversity", "balance").
+		Updates(dataset).Error
+}
+
+// Delete はデータセットを論理削除する。ラベルとスナップショットは残す
+func (r *LabelDatasetRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "label_dataset", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Where("id = ?", id).Delete(&model.LabelDataset{}).Error
+}
+
+// FindLabels は ids のうちデータセットに加えられるラベル（自分のもの、または検証済みで公開されたもの）を返す
+func (r *LabelDatasetRepositoryImpl) FindLabels(userID uint, ids []string) ([]model.QuantificationLabel, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	q := r.DB.Where("id IN ?", ids)
+	if userID != 0 {
+		q = q.Where("(user_id = ? OR (validated = ? AND public_visibility = ?))", userID, true, true)
+	}
+	var labels []model.QuantificationLabel
+	if err := q.Order("id ASC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// FindVerifications はラベルの現在の提出の回の検証を返す
+func (r *LabelDatasetRepositoryImpl) FindVerifications(labelIDs []string) ([]model.LabelVerification, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	var verifications []model.LabelVerification
+	err := r.DB.Joins("JOIN quantification_labels ON quantification_labels.id = label_verifications.label_id AND quantification_labels.review_round = label_verifications.round").
+		Where("label_verifications.label_id IN ?", labelIDs).
+		Order("label_verifications.label_id ASC, label_verifications.created_at ASC").
+		Find(&verifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return verifications, nil
+}
+
+// CreateSnapshot はデータセットを行ロックして次の版のスナップショットを作成し、
+// データセットの版と、スナップショット作成時に求めた統計情報・品質メトリクスを保存する
+func (r *LabelDatasetRepositoryImpl) CreateSnapshot(userID uint, dataset *model.LabelDataset, snapshot *model.LabelDatasetSnapshot) error {
+	if err := authorizeWrite(r.DB, "label_dataset", userID, dataset.ID); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var locked model.LabelDataset
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", dataset.ID).First(&locked).Error; err != nil {
+			return err
+		}
+		var latest int
+		err := tx.Model(&model.LabelDatasetSnapshot{}).
+			Where("dataset_id = ?", dataset.ID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+		snapshot.Version = latest + 1
+		if err := tx.Create(snapshot).Error; err != nil {
+			return err
+		}
+		dataset.Version = strconv.Itoa(snapshot.Version)
+		return tx.Model(dataset).
+			Select("version", "total_labels", "verified_labels", "average_accuracy", "completeness", "consistency", "diversity", "balance").
+			Updates(dataset).Error
+	})
+}
+
+// FindSnapshots はデータセットのスナップショットを版の順に返す（内容は含まない）
+func (r *LabelDatasetRepositoryImpl) FindSnapshots(userID uint, datasetID string) ([]model.LabelDatasetSnapshot, error) {
+	if err := authorizeRecord(r.DB, "label_dataset", userID, datasetID); err != nil {
+		return nil, err
+	}
+	var snapshots []model.LabelDatasetSnapshot
+	err := r.DB.Omit("content").Where("dataset_id = ?", datasetID).Order("version ASC").Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func (r *LabelDatasetRepositoryImpl) FindSnapshot(userID uint, datasetID string, version int) (*model.LabelDatasetSnapshot, error) {
+	if err := authorizeRecord(r.DB, "label_dataset", userID, datasetID); err != nil {
+		return nil, err
+	}
+	var snapshot model.LabelDatasetSnapshot
+	if err := r.DB.Where("dataset_id = ? AND version = ?", datasetID, version).First(&snapshot).Error; err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// NewLabelDatasetRepository は LabelDatasetRepositoryInterface を返すコンストラクタ
+func NewLabelDatasetRepository(db *gorm.DB) LabelDatasetRepositoryInterface {
+	return &LabelDatasetRepositoryImpl{DB: db}
+}
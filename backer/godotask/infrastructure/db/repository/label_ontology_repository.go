@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"github.com/godotask/domain/labeling"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+type LabelOntologyRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// ontologyRelationTypes はオントロジーとして扱う関係の種類
+var ontologyRelationTypes = []string{labeling.RelationSynonym, labeling.RelationHypernym, labeling.RelationHyponym}
+
+// visibleLabels は userID が参照できるラベル（自分のもの、または検証済みで公開されたもの）に絞り込む
+func visibleLabels(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == 0 {
+			return db
+		}
+		return db.Where("(quantification_labels.user_id = ? OR (quantification_labels.validated = ? AND quantification_labels.public_visibility = ?))", userID, true, true)
+	}
+}
+
+// FindRelations はすべての同義語・上位下位の関係を返す
+// 整合性の確認と階層の探索は全体のグラフで行い、結果を参照できるラベルに絞り込む
+func (r *LabelOntologyRepositoryImpl) FindRelations() ([]model.LabelRelation, error) {
+	var relations []model.LabelRelation
+	if err := r.DB.Where("relation_type IN ?", ontologyRelationTypes).Order("id ASC").Find(&relations).Error; err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// FindLabelRelations はラベルを起点または終点とする関係を返す
+func (r *LabelOntologyRepositoryImpl) FindLabelRelations(labelID string) ([]model.LabelRelation, error) {
+	var relations []model.LabelRelation
+	err := r.DB.Where("source_id = ? OR target_id = ?", labelID, labelID).
+		Order("created_at ASC, id ASC").
+		Find(&relations).Error
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// CreateRelation は既存の関係を読み込み、check が整合性を確認した場合に関係を作成する
+// 起点のラベルを更新でき、終点のラベルを参照できる必要がある
+// PostgreSQL では同時に作成された関係で循環しないよう、トランザクションの間 label_relations への書き込みを止める
+func (r *LabelOntologyRepositoryImpl) CreateRelation(userID uint, relation *model.LabelRelation, check func(relations []model.LabelRelation) error) error {
+	if err := authorizeWrite(r.DB, "quantification_label", userID, relation.SourceID); err != nil {
+		return err
+	}
+	var targets int64
+	if err := r.DB.Model(&model.QuantificationLabel{}).Scopes(visibleLabels(userID)).Where("id = ?", relation.TargetID).Count(&targets).Error; err != nil {
+		return err
+	}
+	if targets == 0 {
+		return apperrors.ErrResourceNotFound
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("LOCK TABLE label_relations IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+				return err
+			}
+		}
+		var relations []model.LabelRelation
+		if err := tx.Where("relation_type IN ?", ontologyRelationTypes).Find(&relations).Error; err != nil {
+			return err
+		}
+		if err := check(relations); err != nil {
+			return err
+		}
+		return tx.Create(relation).Error
+	})
+}
+
+// DeleteRelation は関係を削除する。関係を作成したユーザーのみ削除できる
+func (r *LabelOntologyRepositoryImpl) DeleteRelation(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "label_relation", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Where("id = ?", id).Delete(&model.LabelRelation{}).Error
+}
+
+// FindLabels は ids のうち userID が参照できるラベルを返す
+func (r *LabelOntologyRepositoryImpl) FindLabels(userID uint, ids []string) ([]model.QuantificationLabel, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var labels []model.QuantificationLabel
+	if err := r.DB.Scopes(visibleLabels(userID)).Where("id IN ?", ids).Order("id ASC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// FindLabelsByText は正規化した語句が normalized に一致する、userID が参照できるラベルを返す
+func (r *LabelOntologyRepositoryImpl) FindLabelsByText(userID uint, normalized string) ([]model.QuantificationLabel, error) {
+	var labels []model.QuantificationLabel
+	if err := r.DB.Scopes(visibleLabels(userID)).Where("normalized_text = ?", normalized).Order("id ASC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// NormalizeTexts は自分のラベルの normalized_text を normalize の結果で置き換え、変更した件数を返す
+// 表記を揃えるだけのため版と更新日時は変えない
+func (r *LabelOntologyRepositoryImpl) NormalizeTexts(userID uint, normalize func(label *model.QuantificationLabel) string) (int, error) {
+	var labels []model.QuantificationLabel
+	if err := r.DB.Scopes(ownerScope("quantification_label", userID)).Order("id ASC").Find(&labels).Error; err != nil {
+		return 0, err
+	}
+	updated := 0
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range labels {
+			text := normalize(&labels[i])
+			if text == labels[i].NormalizedText {
+				continue
+			}
+			if err := tx.Model(&labels[i]).UpdateColumn("normalized_text", text).Error; err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// NewLabelOntologyRepository は LabelOntologyRepositoryInterface を返すコンストラクタ
+func NewLabelOntologyRepository(db *gorm.DB) LabelOntologyRepositoryInterface {
+	return &LabelOntologyRepositoryImpl{DB: db}
+}
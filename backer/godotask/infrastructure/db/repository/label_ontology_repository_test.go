@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelOntologyRepository(t *testing.T) {
+	db := setupQuantificationLabelTestDB(t)
+	repo := repository.NewLabelOntologyRepository(db)
+
+	public := true
+	labels := []model.QuantificationLabel{
+		{ID: "burr", UserID: 1, OriginalText: "バリ", NormalizedText: "バリ"},
+		{ID: "deburr", UserID: 1, OriginalText: "ﾊﾞﾘ 取り", NormalizedText: ""},
+		{ID: "public", UserID: 2, OriginalText: "かえり", NormalizedText: "バリ", Validated: true, PublicVisibility: &public},
+		{ID: "private", UserID: 2, OriginalText: "返り", NormalizedText: "バリ"},
+	}
+	require.NoError(t, db.Create(&labels).Error)
+
+	found, err := repo.FindLabelsByText(1, "バリ")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "burr", found[0].ID)
+	assert.Equal(t, "public", found[1].ID)
+	found, err = repo.FindLabels(0, []string{"private", "burr"})
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	// 起点は更新できるラベル、終点は参照できるラベルのみ
+	check := func(existing []model.LabelRelation) error { return nil }
+	relation := &model.LabelRelation{ID: "r1", UserID: 1, SourceID: "burr", TargetID: "public", RelationType: "synonym", Strength: 0.9, Bidirectional: true}
+	require.NoError(t, repo.CreateRelation(1, relation, check))
+	assert.ErrorIs(t, repo.CreateRelation(1, &model.LabelRelation{ID: "r2", SourceID: "burr", TargetID: "private", RelationType: "synonym"}, check), apperrors.ErrResourceNotFound)
+	assert.ErrorIs(t, repo.CreateRelation(1, &model.LabelRelation{ID: "r3", SourceID: "public", TargetID: "burr", RelationType: "synonym"}, check), apperrors.ErrResourceAccessDenied)
+
+	// check は既存の関係を受け取り、エラーの場合は作成しない
+	rejected := errors.New("rejected")
+	err = repo.CreateRelation(1, &model.LabelRelation{ID: "r4", SourceID: "deburr", TargetID: "burr", RelationType: "hypernym"}, func(existing []model.LabelRelation) error {
+		require.Len(t, existing, 1)
+		assert.Equal(t, "r1", existing[0].ID)
+		return rejected
+	})
+	assert.ErrorIs(t, err, rejected)
+	relations, err := repo.FindRelations()
+	require.NoError(t, err)
+	assert.Len(t, relations, 1)
+	relations, err = repo.FindLabelRelations("public")
+	require.NoError(t, err)
+	assert.Len(t, relations, 1)
+
+	// 関係を作成したユーザーのみ削除できる
+	assert.ErrorIs(t, repo.DeleteRelation(2, "r1"), apperrors.ErrResourceAccessDenied)
+	require.NoError(t, repo.DeleteRelation(1, "r1"))
+	relations, err = repo.FindRelations()
+	require.NoError(t, err)
+	assert.Empty(t, relations)
+
+	// 自分のラベルの正規化した語句のみ変更する
+	updated, err := repo.NormalizeTexts(1, func(label *model.QuantificationLabel) string {
+		if label.NormalizedText == "" {
+			return label.OriginalText
+		}
+		return label.NormalizedText
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	var deburr model.QuantificationLabel
+	require.NoError(t, db.First(&deburr, "id = ?", "deburr").Error)
+	assert.Equal(t, "ﾊﾞﾘ 取り", deburr.NormalizedText)
+}
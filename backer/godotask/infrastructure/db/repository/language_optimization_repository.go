@@ -3,18 +3,23 @@ package repository
 import (
 	"gorm.io/gorm"
 	"github.com/godotask/infrastructure/db/model"
-	helperquery "github.com/godotask/infrastructure/helper/query"
 )
 
 type LanguageOptimizationRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *LanguageOptimizationRepositoryImpl) Create(languageOptimization *model.LanguageOptimization) error {
+func (r *LanguageOptimizationRepositoryImpl) Create(userID uint, languageOptimization *model.LanguageOptimization) error {
+	if err := authorizeWrite(r.DB, "task", userID, languageOptimization.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(languageOptimization).Error
 }
 
-func (r *LanguageOptimizationRepositoryImpl) FindByID(id string) (*model.LanguageOptimization, error) {
+func (r *LanguageOptimizationRepositoryImpl) FindByID(userID uint, id string) (*model.LanguageOptimization, error) {
+	if err := authorizeRecord(r.DB, "language_optimization", userID, id); err != nil {
+		return nil, err
+	}
 	var languageOptimization model.LanguageOptimization
 	if err := r.DB.Where("id = ?", id).First(&languageOptimization).Error; err != nil {
 		return nil, err
@@ -24,17 +29,29 @@ func (r *LanguageOptimizationRepositoryImpl) FindByID(id string) (*model.Languag
 
 func (r *LanguageOptimizationRepositoryImpl) FindAll(userID uint) ([]model.LanguageOptimization, error) {
 	var languageOptimizations []model.LanguageOptimization
-	if err := r.DB.Scopes(helperquery.WithUserFilter(userID)).Order("created_at DESC, id DESC").Find(&languageOptimizations).Error; err != nil {
+	if err := r.DB.Scopes(ownerScope("language_optimization", userID)).Order("created_at DESC, id DESC").Find(&languageOptimizations).Error; err != nil {
 		return nil, err
 	}
 	return languageOptimizations, nil
 }
 
-func (r *LanguageOptimizationRepositoryImpl) Update(id string, languageOptimization *model.LanguageOptimization) error {
+func (r *LanguageOptimizationRepositoryImpl) Update(userID uint, id string, languageOptimization *model.LanguageOptimization) error {
+	if err := authorizeWrite(r.DB, "language_optimization", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if languageOptimization.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, languageOptimization.TaskID); err != nil {
+			return err
+		}
+	}
 	return r.DB.Model(&model.LanguageOptimization{}).Where("id = ?", id).Updates(languageOptimization).Error
 }
 
-func (r *LanguageOptimizationRepositoryImpl) Delete(id string) error {
+func (r *LanguageOptimizationRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "language_optimization", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.LanguageOptimization{}, id).Error
 }
 
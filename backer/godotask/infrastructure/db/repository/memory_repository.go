@@ -12,10 +12,17 @@ type MemoryRepositoryImpl struct {
 }
 
 func (r *MemoryRepositoryImpl) Create(memory *model.Memory) error {
+	// ワークスペースに作成する場合は作成者が owner / editor である必要がある
+	if err := authorizeWorkspace(r.DB, uint(memory.UserID), memory.WorkspaceID); err != nil {
+		return err
+	}
 	return r.DB.Create(memory).Error
 }
 
-func (r *MemoryRepositoryImpl) FindByID(id string) (*model.Memory, error) {
+func (r *MemoryRepositoryImpl) FindByID(userID uint, id string) (*model.Memory, error) {
+	if err := authorizeRecord(r.DB, "memory", userID, id); err != nil {
+		return nil, err
+	}
 	var memory model.Memory
 
 	if err := r.DB.Where("id = ?", id).First(&memory).Error; err != nil {
@@ -47,11 +54,22 @@ func (r *MemoryRepositoryImpl) ListMemoriesPager(userID uint, offset int, limit
 	return memories, total, nil
 }
 
-func (r *MemoryRepositoryImpl) Update(id string, memory *model.Memory) error {
-	return r.DB.Model(&model.Memory{}).Where("id = ?", id).Updates(memory).Error
+func (r *MemoryRepositoryImpl) Update(userID uint, id string, memory *model.Memory) error {
+	if err := authorizeWrite(r.DB, "memory", userID, id); err != nil {
+		return err
+	}
+	// 移動先のワークスペースでも編集できる必要がある
+	if err := authorizeWorkspace(r.DB, userID, memory.WorkspaceID); err != nil {
+		return err
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.Memory{}).Where("id = ?", id).Omit("user_id").Updates(memory).Error
 }
 
-func (r *MemoryRepositoryImpl) Delete(id string) error {
+func (r *MemoryRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "memory", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.Memory{}, id).Error
 }
 
@@ -13,12 +13,12 @@ import (
 	"github.com/godotask/infrastructure/db/repository"
 )
 
-func setupTestDB(t *testing.T) *gorm.DB {
+func setupMemoryTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("failed to connect to in-memory DB: %v", err)
 	}
-	err = db.AutoMigrate(&model.Memory{})
+	err = db.AutoMigrate(&model.Memory{}, &model.WorkspaceMember{})
 	if err != nil {
 		t.Fatalf("failed to migrate: %v", err)
 	}
@@ -26,7 +26,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 }
 
 func TestMemoryRepository_CRUD(t *testing.T) {
-	db := setupTestDB(t)
+	db := setupMemoryTestDB(t)
 	repo := repository.NewMemoryRepository(db)
 
 	// --- Create ---
@@ -51,29 +51,29 @@ func TestMemoryRepository_CRUD(t *testing.T) {
 
 	intStr := strconv.Itoa(memory.ID)
 	// --- FindByID ---
-	found, err := repo.FindByID(intStr)
+	found, err := repo.FindByID(1, intStr)
 	assert.NoError(t, err)
 	assert.Equal(t, memory.Title, found.Title)
 
 	// --- Update ---
 	memory.Title = "Updated Title"
-	err = repo.Update(intStr, memory)
+	err = repo.Update(1, intStr, memory)
 	assert.NoError(t, err)
 
-	updated, err := repo.FindByID(intStr)
+	updated, err := repo.FindByID(1, intStr)
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Title", updated.Title)
 
 	// --- FindAll ---
-	all, err := repo.FindAll()
+	all, err := repo.FindAll(1)
 	assert.NoError(t, err)
 	assert.Len(t, all, 1)
 
 	// --- Delete ---
-	err = repo.Delete(intStr)
+	err = repo.Delete(1, intStr)
 	assert.NoError(t, err)
 
-	deleted, err := repo.FindByID(intStr)
+	deleted, err := repo.FindByID(1, intStr)
 	assert.Error(t, err)
 	assert.Nil(t, deleted)
 }
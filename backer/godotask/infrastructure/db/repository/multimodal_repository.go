@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+type MultimodalRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *MultimodalRepositoryImpl) Create(userID uint, data *model.MultimodalData) error {
+	if err := authorizeWrite(r.DB, "task", userID, data.TaskID); err != nil {
+		return err
+	}
+	return r.DB.Create(data).Error
+}
+
+func (r *MultimodalRepositoryImpl) FindByID(userID uint, id string) (*model.MultimodalData, error) {
+	if err := authorizeRecord(r.DB, "multimodal_data", userID, id); err != nil {
+		return nil, err
+	}
+	var data model.MultimodalData
+	if err := r.DB.Where("id = ?", id).First(&data).Error; err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// FindByTask はタスクのマルチモーダルデータを新しい順に返す
+func (r *MultimodalRepositoryImpl) FindByTask(userID uint, taskID int) ([]model.MultimodalData, error) {
+	if err := authorizeRecord(r.DB, "task", userID, taskID); err != nil {
+		return nil, err
+	}
+	var data []model.MultimodalData
+	if err := r.DB.Where("task_id = ?", taskID).Order("created_at DESC, id DESC").Find(&data).Error; err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FindImages は参照できるマルチモーダルデータのうち知覚ハッシュを持つものを返す
+func (r *MultimodalRepositoryImpl) FindImages(userID uint) ([]model.MultimodalData, error) {
+	var data []model.MultimodalData
+	err := r.DB.Scopes(ownerScope("multimodal_data", userID)).
+		Where("image_hash <> ''").
+		Order("created_at DESC, id DESC").
+		Find(&data).Error
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Delete はマルチモーダルデータとその画像のアノテーション・校正を削除する
+func (r *MultimodalRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "multimodal_data", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("image_id = ?", id).Delete(&model.ImageAnnotation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("image_id = ?", id).Delete(&model.UserCalibration{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.MultimodalData{}).Error
+	})
+}
+
+// CreateAnnotation は画像 annotation.ImageID にアノテーションを追加する。タスクは画像から引き継ぐ
+func (r *MultimodalRepositoryImpl) CreateAnnotation(userID uint, annotation *model.ImageAnnotation) error {
+	if err := authorizeWrite(r.DB, "multimodal_data", userID, annotation.ImageID); err != nil {
+		return err
+	}
+	var data model.MultimodalData
+	if err := r.DB.Select("task_id").Where("id = ?", annotation.ImageID).First(&data).Error; err != nil {
+		return err
+	}
+	annotation.TaskID = data.TaskID
+	return r.DB.Create(annotation).Error
+}
+
+func (r *MultimodalRepositoryImpl) FindAnnotation(userID uint, id string) (*model.ImageAnnotation, error) {
+	if err := authorizeRecord(r.DB, "image_annotation", userID, id); err != nil {
+		return nil, err
+	}
+	var annotation model.ImageAnnotation
+	if err := r.DB.Where("id = ?", id).First(&annotation).Error; err != nil {
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+// FindAnnotations は画像のアノテーションを作成順に返す
+func (r *MultimodalRepositoryImpl) FindAnnotations(userID uint, imageID string) ([]model.ImageAnnotation, error) {
+	if err := authorizeRecord(r.DB, "multimodal_data", userID, imageID); err != nil {
+		return nil, err
+	}
+	var annotations []model.ImageAnnotation
+	if err := r.DB.Where("image_id = ?", imageID).Order("created_at ASC, id ASC").Find(&annotations).Error; err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+// UpdateAnnotation はアノテーションを置き換える。画像・タスク・作成者は変更しない
+func (r *MultimodalRepositoryImpl) UpdateAnnotation(userID uint, id string, annotation *model.ImageAnnotation) error {
+	if err := authorizeWrite(r.DB, "image_annotation", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Model(&model.ImageAnnotation{}).Where("id = ?", id).
+		Select("label_id", "type", "x", "y", "width", "height", "label", "value", "unit", "confidence", "calibration_id", "uncertainty", "updated_at").
+		Updates(annotation).Error
+}
+
+func (r *MultimodalRepositoryImpl) DeleteAnnotation(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "image_annotation", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Where("id = ?", id).Delete(&model.ImageAnnotation{}).Error
+}
+
+// CreateCalibration は画像 calibration.ImageID に校正を追加する。タスクは画像から引き継ぐ
+func (r *MultimodalRepositoryImpl) CreateCalibration(userID uint, calibration *model.UserCalibration) error {
+	if err := authorizeWrite(r.DB, "multimodal_data", userID, calibration.ImageID); err != nil {
+		return err
+	}
+	var data model.MultimodalData
+	if err := r.DB.Select("task_id").Where("id = ?", calibration.ImageID).First(&data).Error; err != nil {
+		return err
+	}
+	calibration.TaskID = data.TaskID
+	return r.DB.Create(calibration).Error
+}
+
+// FindCalibrations は画像上で行った校正を新しい順に返す
+func (r *MultimodalRepositoryImpl) FindCalibrations(userID uint, imageID string) ([]model.UserCalibration, error) {
+	if err := authorizeRecord(r.DB, "multimodal_data", userID, imageID); err != nil {
+		return nil, err
+	}
+	var calibrations []model.UserCalibration
+	if err := r.DB.Where("image_id = ?", imageID).Order("created_at DESC, id DESC").Find(&calibrations).Error; err != nil {
+		return nil, err
+	}
+	return calibrations, nil
+}
+
+// FindEffectiveCalibration は画像の測定に適用する校正を返す
+// 画像上の最新の校正、無ければ同じタスク・同じ撮影セッションの画像の最新の校正。どちらも無ければ nil
+func (r *MultimodalRepositoryImpl) FindEffectiveCalibration(userID uint, data *model.MultimodalData) (*model.UserCalibration, error) {
+	if err := authorizeRecord(r.DB, "multimodal_data", userID, data.ID); err != nil {
+		return nil, err
+	}
+	var calibrations []model.UserCalibration
+	if err := r.DB.Where("image_id = ?", data.ID).Order("created_at DESC, id DESC").Limit(1).Find(&calibrations).Error; err != nil {
+		return nil, err
+	}
+	if len(calibrations) == 0 && data.CaptureSession != "" {
+		err := r.DB.Where("task_id = ? AND session = ?", data.TaskID, data.CaptureSession).
+			Order("created_at DESC, id DESC").Limit(1).Find(&calibrations).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(calibrations) == 0 {
+		return nil, nil
+	}
+	return &calibrations[0], nil
+}
+
+func (r *MultimodalRepositoryImpl) DeleteCalibration(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "calibration", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Where("id = ?", id).Delete(&model.UserCalibration{}).Error
+}
+
+// NewMultimodalRepository は MultimodalRepositoryInterface を返すコンストラクタ
+func NewMultimodalRepository(db *gorm.DB) MultimodalRepositoryInterface {
+	return &MultimodalRepositoryImpl{DB: db}
+}
@@ -0,0 +1,121 @@
+package repository_test
+
+import (
+	"testing"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupMultimodalTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to in-memory DB: %v", err)
+	}
+	err = db.AutoMigrate(&model.Task{}, &model.WorkspaceMember{}, &model.MultimodalData{}, &model.ImageAnnotation{}, &model.UserCalibration{})
+	if err != nil {
+		t.Fatalf("Failed to migrate MultimodalData model: %v", err)
+	}
+	return db
+}
+
+func TestMultimodalRepositoryAnnotations(t *testing.T) {
+	db := setupMultimodalTestDB(t)
+	repo := repository.NewMultimodalRepository(db)
+
+	task := &model.Task{UserID: 1, Title: "Burr inspection"}
+	assert.NoError(t, db.Create(task).Error)
+	other := &model.Task{UserID: 2, Title: "Other"}
+	assert.NoError(t, db.Create(other).Error)
+
+	data := &model.MultimodalData{ID: "img-1", UserID: 1, TaskID: uint(task.ID), ImageWidth: 640, ImageHeight: 480, ImageHash: "ffff000000000000"}
+	assert.NoError(t, repo.Create(1, data))
+	// 他人のタスクには作成できない
+	assert.ErrorIs(t, repo.Create(1, &model.MultimodalData{ID: "img-x", TaskID: uint(other.ID)}), apperrors.ErrResourceAccessDenied)
+
+	found, err := repo.FindByID(1, "img-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 640, found.ImageWidth)
+	_, err = repo.FindByID(2, "img-1")
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	list, err := repo.FindByTask(1, task.ID)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	images, err := repo.FindImages(2)
+	assert.NoError(t, err)
+	assert.Empty(t, images)
+
+	// アノテーションのタスクは画像から引き継ぐ
+	annotation := &model.ImageAnnotation{ID: "ann-1", ImageID: "img-1", UserID: 1, Type: "region", X: 1, Y: 2, Width: 3, Height: 4}
+	assert.NoError(t, repo.CreateAnnotation(1, annotation))
+	assert.Equal(t, uint(task.ID), annotation.TaskID)
+	assert.ErrorIs(t, repo.CreateAnnotation(2, &model.ImageAnnotation{ID: "ann-x", ImageID: "img-1"}), apperrors.ErrResourceAccessDenied)
+
+	assert.NoError(t, repo.UpdateAnnotation(1, "ann-1", &model.ImageAnnotation{ImageID: "moved", Type: "point", X: 5, Y: 6}))
+	updated, err := repo.FindAnnotation(1, "ann-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "point", updated.Type)
+	assert.Equal(t, 0.0, updated.Width)
+	assert.Equal(t, "img-1", updated.ImageID, "image cannot be changed")
+	assert.ErrorIs(t, repo.DeleteAnnotation(2, "ann-1"), apperrors.ErrResourceAccessDenied)
+
+	annotations, err := repo.FindAnnotations(1, "img-1")
+	assert.NoError(t, err)
+	assert.Len(t, annotations, 1)
+
+	// 画像を削除するとアノテーションも削除される
+	assert.NoError(t, repo.Delete(1, "img-1"))
+	var count int64
+	db.Model(&model.ImageAnnotation{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	_, err = repo.FindByID(1, "img-1")
+	assert.ErrorIs(t, err, apperrors.ErrResourceNotFound)
+}
+
+func TestMultimodalRepositoryCalibrations(t *testing.T) {
+	db := setupMultimodalTestDB(t)
+	repo := repository.NewMultimodalRepository(db)
+
+	task := &model.Task{UserID: 1, Title: "Burr inspection"}
+	assert.NoError(t, db.Create(task).Error)
+	reference := &model.MultimodalData{ID: "img-ref", UserID: 1, TaskID: uint(task.ID), CaptureSession: "line-a", ImageWidth: 640, ImageHeight: 480}
+	same := &model.MultimodalData{ID: "img-same", UserID: 1, TaskID: uint(task.ID), CaptureSession: "line-a", ImageWidth: 640, ImageHeight: 480}
+	unrelated := &model.MultimodalData{ID: "img-other", UserID: 1, TaskID: uint(task.ID), CaptureSession: "line-b", ImageWidth: 640, ImageHeight: 480}
+	for _, d := range []*model.MultimodalData{reference, same, unrelated} {
+		assert.NoError(t, repo.Create(1, d))
+	}
+
+	calibration := &model.UserCalibration{ID: "cal-1", UserID: 1, ImageID: "img-ref", Session: "line-a", Scale: 0.2}
+	assert.NoError(t, repo.CreateCalibration(1, calibration))
+	assert.Equal(t, uint(task.ID), calibration.TaskID)
+	assert.ErrorIs(t, repo.CreateCalibration(2, &model.UserCalibration{ID: "cal-x", ImageID: "img-ref"}), apperrors.ErrResourceAccessDenied)
+
+	// 校正した画像と同じセッションの画像には適用され、別セッションの画像には適用されない
+	effective, err := repo.FindEffectiveCalibration(1, reference)
+	assert.NoError(t, err)
+	assert.Equal(t, "cal-1", effective.ID)
+	effective, err = repo.FindEffectiveCalibration(1, same)
+	assert.NoError(t, err)
+	assert.Equal(t, "cal-1", effective.ID)
+	effective, err = repo.FindEffectiveCalibration(1, unrelated)
+	assert.NoError(t, err)
+	assert.Nil(t, effective)
+	_, err = repo.FindEffectiveCalibration(2, same)
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	calibrations, err := repo.FindCalibrations(1, "img-ref")
+	assert.NoError(t, err)
+	assert.Len(t, calibrations, 1)
+	assert.ErrorIs(t, repo.DeleteCalibration(2, "cal-1"), apperrors.ErrResourceAccessDenied)
+
+	// 校正した画像を削除すると校正も削除される
+	assert.NoError(t, repo.Delete(1, "img-ref"))
+	effective, err = repo.FindEffectiveCalibration(1, same)
+	assert.NoError(t, err)
+	assert.Nil(t, effective)
+}
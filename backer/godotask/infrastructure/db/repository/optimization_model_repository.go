@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// OptimizationModelRepositoryImpl は最適化モデルのカタログを扱う（ユーザーに属さない共有データ）
+type OptimizationModelRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *OptimizationModelRepositoryImpl) Create(optimizationModel *model.OptimizationModel) error {
+	return r.DB.Create(optimizationModel).Error
+}
+
+func (r *OptimizationModelRepositoryImpl) FindByID(id string) (*model.OptimizationModel, error) {
+	var optimizationModel model.OptimizationModel
+	if err := r.DB.Where("id = ?", id).First(&optimizationModel).Error; err != nil {
+		return nil, err
+	}
+	return &optimizationModel, nil
+}
+
+func (r *OptimizationModelRepositoryImpl) FindAll() ([]model.OptimizationModel, error) {
+	var optimizationModels []model.OptimizationModel
+	if err := r.DB.Order("id ASC").Find(&optimizationModels).Error; err != nil {
+		return nil, err
+	}
+	return optimizationModels, nil
+}
+
+func (r *OptimizationModelRepositoryImpl) Update(id string, optimizationModel *model.OptimizationModel) error {
+	result := r.DB.Model(&model.OptimizationModel{}).Where("id = ?", id).Omit("id").Updates(optimizationModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *OptimizationModelRepositoryImpl) Delete(id string) error {
+	result := r.DB.Where("id = ?", id).Delete(&model.OptimizationModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
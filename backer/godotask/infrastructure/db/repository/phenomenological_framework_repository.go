@@ -2,19 +2,25 @@ package repository
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"github.com/godotask/infrastructure/db/model"
-	helperquery "github.com/godotask/infrastructure/helper/query"
 )
 
 type PhenomenologicalFrameworkRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *PhenomenologicalFrameworkRepositoryImpl) Create(phenomenologicalFramework *model.PhenomenologicalFramework) error {
+func (r *PhenomenologicalFrameworkRepositoryImpl) Create(userID uint, phenomenologicalFramework *model.PhenomenologicalFramework) error {
+	if err := authorizeWrite(r.DB, "task", userID, phenomenologicalFramework.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(phenomenologicalFramework).Error
 }
 
-func (r *PhenomenologicalFrameworkRepositoryImpl) FindByID(id string) (*model.PhenomenologicalFramework, error) {
+func (r *PhenomenologicalFrameworkRepositoryImpl) FindByID(userID uint, id string) (*model.PhenomenologicalFramework, error) {
+	if err := authorizeRecord(r.DB, "phenomenological_framework", userID, id); err != nil {
+		return nil, err
+	}
 	var phenomenologicalFramework model.PhenomenologicalFramework
 	if err := r.DB.Where("id = ?", id).First(&phenomenologicalFramework).Error; err != nil {
 		return nil, err
@@ -24,20 +30,77 @@ func (r *PhenomenologicalFrameworkRepositoryImpl) FindByID(id string) (*model.Ph
 
 func (r *PhenomenologicalFrameworkRepositoryImpl) FindAll(userID uint) ([]model.PhenomenologicalFramework, error) {
 	var phenomenologicalFrameworks []model.PhenomenologicalFramework
-	if err := r.DB.Scopes(helperquery.WithUserFilter(userID)).Order("created_at DESC, id DESC").Find(&phenomenologicalFrameworks).Error; err != nil {
+	if err := r.DB.Scopes(ownerScope("phenomenological_framework", userID)).Order("created_at DESC, id DESC").Find(&phenomenologicalFrameworks).Error; err != nil {
 		return nil, err
 	}
 	return phenomenologicalFrameworks, nil
 }
 
-func (r *PhenomenologicalFrameworkRepositoryImpl) Update(id string, phenomenologicalFramework *model.PhenomenologicalFramework) error {
+func (r *PhenomenologicalFrameworkRepositoryImpl) Update(userID uint, id string, phenomenologicalFramework *model.PhenomenologicalFramework) error {
+	if err := authorizeWrite(r.DB, "phenomenological_framework", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if phenomenologicalFramework.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, phenomenologicalFramework.TaskID); err != nil {
+			return err
+		}
+	}
 	return r.DB.Model(&model.PhenomenologicalFramework{}).Where("id = ?", id).Updates(phenomenologicalFramework).Error
 }
 
-func (r *PhenomenologicalFrameworkRepositoryImpl) Delete(id string) error {
+func (r *PhenomenologicalFrameworkRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "phenomenological_framework", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.PhenomenologicalFramework{}, id).Error
 }
 
+// Modify はフレームワークを行ロックして読み込み、update が変更した result / feedback を保存する
+// 同時に評価しても履歴の追記が失われないようにする
+func (r *PhenomenologicalFrameworkRepositoryImpl) Modify(userID uint, id string, update func(phenomenologicalFramework *model.PhenomenologicalFramework) error) (*model.PhenomenologicalFramework, error) {
+	if err := authorizeWrite(r.DB, "phenomenological_framework", userID, id); err != nil {
+		return nil, err
+	}
+	var phenomenologicalFramework model.PhenomenologicalFramework
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&phenomenologicalFramework).Error; err != nil {
+			return err
+		}
+		if err := update(&phenomenologicalFramework); err != nil {
+			return err
+		}
+		return tx.Model(&phenomenologicalFramework).Updates(map[string]interface{}{
+			"result":   phenomenologicalFramework.Result,
+			"feedback": phenomenologicalFramework.Feedback,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &phenomenologicalFramework, nil
+}
+
+// FindTaskRecords は目標関数の評価に使うタスクの評価・ヒューリスティクス分析・定量化ラベルを返す
+func (r *PhenomenologicalFrameworkRepositoryImpl) FindTaskRecords(userID uint, taskID int) ([]model.Assessment, []model.HeuristicsAnalysis, []model.QuantificationLabel, error) {
+	if err := authorizeRecord(r.DB, "task", userID, taskID); err != nil {
+		return nil, nil, nil, err
+	}
+	var assessments []model.Assessment
+	if err := r.DB.Where("task_id = ?", taskID).Order("id ASC").Find(&assessments).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var analyses []model.HeuristicsAnalysis
+	if err := r.DB.Where("task_id = ?", taskID).Order("id ASC").Find(&analyses).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	var labels []model.QuantificationLabel
+	if err := r.DB.Where("task_id = ?", taskID).Order("id ASC").Find(&labels).Error; err != nil {
+		return nil, nil, nil, err
+	}
+	return assessments, analyses, labels, nil
+}
+
 // NewPhenomenologicalFrameworkRepository は PhenomenologicalFrameworkRepositoryInterface を返すコンストラクタ
 func NewPhenomenologicalFrameworkRepository(db *gorm.DB) PhenomenologicalFrameworkRepositoryInterface {
 	return &PhenomenologicalFrameworkRepositoryImpl{DB: db}
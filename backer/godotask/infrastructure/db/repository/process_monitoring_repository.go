@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// ProcessMonitoringRepositoryImpl は監視セッションとサンプルを扱う
+// セッションは状態評価（→ タスク）の所有者・共有先のみが参照・操作できる
+type ProcessMonitoringRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *ProcessMonitoringRepositoryImpl) Create(userID uint, monitoring *model.ProcessMonitoring) error {
+	if err := authorizeWrite(r.DB, "state_evaluation", userID, monitoring.StateEvaluationID); err != nil {
+		return err
+	}
+	return r.DB.Create(monitoring).Error
+}
+
+func (r *ProcessMonitoringRepositoryImpl) FindByID(userID uint, id string) (*model.ProcessMonitoring, error) {
+	if err := authorizeMonitoring(r.DB, userID, id, false); err != nil {
+		return nil, err
+	}
+	var monitoring model.ProcessMonitoring
+	if err := r.DB.Where("id = ?", id).First(&monitoring).Error; err != nil {
+		return nil, err
+	}
+	return &monitoring, nil
+}
+
+func (r *ProcessMonitoringRepositoryImpl) FindByEvaluation(userID uint, stateEvaluationID string) ([]model.ProcessMonitoring, error) {
+	if err := authorizeRecord(r.DB, "state_evaluation", userID, stateEvaluationID); err != nil {
+		return nil, err
+	}
+	var monitorings []model.ProcessMonitoring
+	if err := r.DB.Where("state_evaluation_id = ?", stateEvaluationID).Order("start_time DESC, id DESC").Find(&monitorings).Error; err != nil {
+		return nil, err
+	}
+	return monitorings, nil
+}
+
+// Modify はセッションを行ロックして読み込み、update が変更したセッションと返したサンプルを同一トランザクションで保存する
+// 同じセッションへのサンプル送信が並行しても検知器の状態が失われないようにする
+func (r *ProcessMonitoringRepositoryImpl) Modify(userID uint, id string, update func(monitoring *model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error)) (*model.ProcessMonitoring, error) {
+	if err := authorizeMonitoring(r.DB, userID, id, true); err != nil {
+		return nil, err
+	}
+	var monitoring model.ProcessMonitoring
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&monitoring).Error; err != nil {
+			return err
+		}
+		samples, err := update(&monitoring)
+		if err != nil {
+			return err
+		}
+		if len(samples) > 0 {
+			if err := tx.Create(&samples).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(&monitoring).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &monitoring, nil
+}
+
+// FindSamples はセッションのサンプルを記録時刻の昇順で返す。metric が空の場合は全指標を返す
+func (r *ProcessMonitoringRepositoryImpl) FindSamples(userID uint, id string, metric string) ([]model.ProcessMonitoringSample, error) {
+	if err := authorizeMonitoring(r.DB, userID, id, false); err != nil {
+		return nil, err
+	}
+	q := r.DB.Where("process_monitoring_id = ?", id)
+	if metric != "" {
+		q = q.Where("metric = ?", metric)
+	}
+	var samples []model.ProcessMonitoringSample
+	if err := q.Order("recorded_at ASC, id ASC").Find(&samples).Error; err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// authorizeMonitoring はセッションが属する状態評価に対する権限を確認する
+func authorizeMonitoring(db *gorm.DB, userID uint, id string, write bool) error {
+	var stateEvaluationIDs []string
+	if err := db.Model(&model.ProcessMonitoring{}).Where("id = ?", id).Limit(1).Pluck("state_evaluation_id", &stateEvaluationIDs).Error; err != nil {
+		return err
+	}
+	if len(stateEvaluationIDs) == 0 {
+		return apperrors.ErrResourceNotFound
+	}
+	return authorizeAccess(db, "state_evaluation", userID, stateEvaluationIDs[0], write)
+}
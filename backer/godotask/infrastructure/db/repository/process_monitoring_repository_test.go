@@ -0,0 +1,59 @@
+package repository_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+func TestProcessMonitoringModify(t *testing.T) {
+	db := setupStateEvaluationTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&model.ProcessMonitoring{}, &model.ProcessMonitoringSample{}))
+	evaluations := &repository.StateEvaluationRepositoryImpl{DB: db}
+	repo := &repository.ProcessMonitoringRepositoryImpl{DB: db}
+
+	task := &model.Task{UserID: 1, Title: "Own Task"}
+	assert.NoError(t, db.Create(task).Error)
+	assert.NoError(t, evaluations.Create(1, &model.StateEvaluation{ID: "se", UserID: "w1", TaskID: task.ID, Level: 1}))
+
+	// 他ユーザーの状態評価には監視セッションを作成できない
+	err := repo.Create(2, &model.ProcessMonitoring{ID: "x", StateEvaluationID: "se", StartTime: time.Now()})
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+
+	assert.NoError(t, repo.Create(1, &model.ProcessMonitoring{ID: "pm", StateEvaluationID: "se", Status: "running", StartTime: time.Now()}))
+
+	updated, err := repo.Modify(1, "pm", func(m *model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error) {
+		m.Status = "stopped"
+		return []model.ProcessMonitoringSample{
+			{ProcessMonitoringID: m.ID, Metric: "temp", Value: 41, RecordedAt: time.Now()},
+			{ProcessMonitoringID: m.ID, Metric: "deviation", Value: 0.01, RecordedAt: time.Now()},
+		}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "stopped", updated.Status)
+
+	samples, err := repo.FindSamples(1, "pm", "temp")
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+
+	// update がエラーを返した場合は何も保存されない
+	_, err = repo.Modify(1, "pm", func(m *model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error) {
+		m.Status = "running"
+		return nil, apperrors.ErrResourceAccessDenied
+	})
+	assert.Error(t, err)
+	found, err := repo.FindByID(1, "pm")
+	assert.NoError(t, err)
+	assert.Equal(t, "stopped", found.Status)
+
+	_, err = repo.FindByID(2, "pm")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+	_, err = repo.FindByID(1, "missing")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceNotFound))
+}
@@ -2,19 +2,25 @@ package repository
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"github.com/godotask/infrastructure/db/model"
-	helperquery "github.com/godotask/infrastructure/helper/query"
 )
 
 type ProcessOptimizationRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *ProcessOptimizationRepositoryImpl) Create(processOptimization *model.ProcessOptimization) error {
+func (r *ProcessOptimizationRepositoryImpl) Create(userID uint, processOptimization *model.ProcessOptimization) error {
+	if err := authorizeWrite(r.DB, "task", userID, processOptimization.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(processOptimization).Error
 }
 
-func (r *ProcessOptimizationRepositoryImpl) FindByID(id string) (*model.ProcessOptimization, error) {
+func (r *ProcessOptimizationRepositoryImpl) FindByID(userID uint, id string) (*model.ProcessOptimization, error) {
+	if err := authorizeRecord(r.DB, "process_optimization", userID, id); err != nil {
+		return nil, err
+	}
 	var processOptimization model.ProcessOptimization
 	if err := r.DB.Where("id = ?", id).First(&processOptimization).Error; err != nil {
 		return nil, err
@@ -24,17 +30,57 @@ func (r *ProcessOptimizationRepositoryImpl) FindByID(id string) (*model.ProcessO
 
 func (r *ProcessOptimizationRepositoryImpl) FindAll(userID uint) ([]model.ProcessOptimization, error) {
 	var processOptimizations []model.ProcessOptimization
-	if err := r.DB.Scopes(helperquery.WithUserFilter(userID)).Order("created_at DESC, id DESC").Find(&processOptimizations).Error; err != nil {
+	if err := r.DB.Scopes(ownerScope("process_optimization", userID)).Order("created_at DESC, id DESC").Find(&processOptimizations).Error; err != nil {
 		return nil, err
 	}
 	return processOptimizations, nil
 }
 
-func (r *ProcessOptimizationRepositoryImpl) Update(id string, processOptimization *model.ProcessOptimization) error {
+func (r *ProcessOptimizationRepositoryImpl) Update(userID uint, id string, processOptimization *model.ProcessOptimization) error {
+	if err := authorizeWrite(r.DB, "process_optimization", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if processOptimization.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, processOptimization.TaskID); err != nil {
+			return err
+		}
+	}
 	return r.DB.Model(&model.ProcessOptimization{}).Where("id = ?", id).Updates(processOptimization).Error
 }
 
-func (r *ProcessOptimizationRepositoryImpl) Delete(id string) error {
+// Modify は記録を行ロックして読み込み、update が変更した内容を保存する
+// 検証と編集が並行しても一方の変更が失われないようにする。付け替え先のタスクも所有している必要がある
+func (r *ProcessOptimizationRepositoryImpl) Modify(userID uint, id string, update func(processOptimization *model.ProcessOptimization) error) (*model.ProcessOptimization, error) {
+	if err := authorizeWrite(r.DB, "process_optimization", userID, id); err != nil {
+		return nil, err
+	}
+	var processOptimization model.ProcessOptimization
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&processOptimization).Error; err != nil {
+			return err
+		}
+		taskID := processOptimization.TaskID
+		if err := update(&processOptimization); err != nil {
+			return err
+		}
+		if processOptimization.TaskID != taskID {
+			if err := authorizeWrite(tx, "task", userID, processOptimization.TaskID); err != nil {
+				return err
+			}
+		}
+		return tx.Save(&processOptimization).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &processOptimization, nil
+}
+
+func (r *ProcessOptimizationRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "process_optimization", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.ProcessOptimization{}, id).Error
 }
 
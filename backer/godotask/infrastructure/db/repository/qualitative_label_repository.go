@@ -10,11 +10,18 @@ type QualitativeLabelRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *QualitativeLabelRepositoryImpl) Create(qualitativeLabel *model.QualitativeLabel) error {
+func (r *QualitativeLabelRepositoryImpl) Create(userID uint, qualitativeLabel *model.QualitativeLabel) error {
+	// 他のユーザーのタスクには作成できない
+	if err := authorizeWrite(r.DB, "task", userID, qualitativeLabel.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(qualitativeLabel).Error
 }
 
-func (r *QualitativeLabelRepositoryImpl) FindByID(id string) (*model.QualitativeLabel, error) {
+func (r *QualitativeLabelRepositoryImpl) FindByID(userID uint, id string) (*model.QualitativeLabel, error) {
+	if err := authorizeRecord(r.DB, "qualitative_label", userID, id); err != nil {
+		return nil, err
+	}
 	var qualitativeLabel model.QualitativeLabel
 	if err := r.DB.Where("id = ?", id).First(&qualitativeLabel).Error; err != nil {
 		return nil, err
@@ -30,11 +37,24 @@ func (r *QualitativeLabelRepositoryImpl) FindAll(userID uint) ([]model.Qualitati
 	return qualitativeLabels, nil
 }
 
-func (r *QualitativeLabelRepositoryImpl) Update(id string, qualitativeLabel *model.QualitativeLabel) error {
-	return r.DB.Model(&model.QualitativeLabel{}).Where("id = ?", id).Updates(qualitativeLabel).Error
+func (r *QualitativeLabelRepositoryImpl) Update(userID uint, id string, qualitativeLabel *model.QualitativeLabel) error {
+	if err := authorizeWrite(r.DB, "qualitative_label", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if qualitativeLabel.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, qualitativeLabel.TaskID); err != nil {
+			return err
+		}
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.QualitativeLabel{}).Where("id = ?", id).Omit("user_id").Updates(qualitativeLabel).Error
 }
 
-func (r *QualitativeLabelRepositoryImpl) Delete(id string) error {
+func (r *QualitativeLabelRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "qualitative_label", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.QualitativeLabel{}, id).Error
 }
 
@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"strconv"
+	"testing"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestQualitativeLabelRepositoryRejectsOtherUsersTask(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to connect to in-memory DB: %v", err)
+	}
+	if err := db.AutoMigrate(&model.Task{}, &model.WorkspaceMember{}, &model.QualitativeLabel{}); err != nil {
+		t.Fatalf("Failed to migrate QualitativeLabel model: %v", err)
+	}
+	repo := repository.NewQualitativeLabelRepository(db)
+
+	task := &model.Task{UserID: 1, Title: "Mine"}
+	assert.NoError(t, db.Create(task).Error)
+	other := &model.Task{UserID: 2, Title: "Other"}
+	assert.NoError(t, db.Create(other).Error)
+
+	// 他人のタスクには作成できない
+	err = repo.Create(1, &model.QualitativeLabel{TaskID: other.ID, UserID: 1, Content: "x"})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	label := &model.QualitativeLabel{ID: 1, TaskID: task.ID, UserID: 1, Content: "burr"}
+	assert.NoError(t, repo.Create(1, label))
+	// 他人のタスクへの付け替えもできない
+	err = repo.Update(1, strconv.Itoa(label.ID), &model.QualitativeLabel{TaskID: other.ID})
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type QuantificationLabelRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create はラベルを作成する。タスクに紐づける場合はタスクを更新できる必要がある
+func (r *QuantificationLabelRepositoryImpl) Create(userID uint, label *model.QuantificationLabel) error {
+	if label.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, label.TaskID); err != nil {
+			return err
+		}
+	}
+	return r.DB.Create(label).Error
+}
+
+func (r *QuantificationLabelRepositoryImpl) FindByID(userID uint, id string) (*model.QuantificationLabel, error) {
+	if err := authorizeRecord(r.DB, "quantification_label", userID, id); err != nil {
+		return nil, err
+	}
+	var label model.QuantificationLabel
+	if err := r.DB.Where("id = ?", id).First(&label).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// Search は条件に一致する自分のラベルと、ページングしない場合の件数を返す
+// query は呼び出し側で検証済みであること（SortBy は列名、From / To は RFC3339）
+// Terms がある場合は Text と Terms のいずれかを含むラベルを返す
+func (r *QuantificationLabelRepositoryImpl) Search(userID uint, query *model.LabelSearchQuery) ([]model.QuantificationLabel, int64, error) {
+	q := r.DB.Model(&model.QuantificationLabel{}).Scopes(ownerScope("quantification_label", userID))
+	if query.Text != "" {
+		text := r.DB.Session(&gorm.Session{NewDB: true})
+		for _, term := range append([]string{query.Text}, query.Terms...) {
+			keyword := "%" + term + "%"
+			text = text.Or("LOWER(original_text) LIKE LOWER(?) OR LOWER(normalized_text) LIKE LOWER(?)", keyword, keyword)
+		}
+		q = q.Where(text)
+	}
+	if query.Domain != "" {
+		q = q.Where("domain = ?", query.Domain)
+	}
+	if query.Category != "" {
+		q = q.Where("category = ?", query.Category)
+	}
+	if query.Unit != "" {
+		q = q.Where("unit = ?", query.Unit)
+	}
+	if query.MinValue != nil {
+		q = q.Where("value >= ?", *query.MinValue)
+	}
+	if query.MaxValue != nil {
+		q = q.Where("value <= ?", *query.MaxValue)
+	}
+	for _, concept := range query.Concepts {
+		// related_concepts は文字列の配列
+		q = q.Where("CAST(related_concepts AS TEXT) LIKE ?", `%"`+concept+`"%`)
+	}
+	if query.MinConfidence > 0 {
+		q = q.Where("confidence >= ?", query.MinConfidence)
+	}
+	if query.Verified != nil {
+		q = q.Where("validated = ?", *query.Verified)
+	}
+	if t, err := time.Parse(time.RFC3339, query.From); err == nil {
+		q = q.Where("created_at >= ?", t)
+	}
+	if t, err := time.Parse(time.RFC3339, query.To); err == nil {
+		q = q.Where("created_at < ?", t)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var labels []model.QuantificationLabel
+	err := q.Order(clause.OrderByColumn{Column: clause.Column{Name: query.SortBy}, Desc: query.SortOrder == "desc"}).
+		Order("id ASC").
+		Limit(query.Limit).Offset(query.Offset).
+		Find(&labels).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return labels, total, nil
+}
+
+// FindAll は自分のラベルを返す。domain を指定した場合はその分野のみ
+func (r *QuantificationLabelRepositoryImpl) FindAll(userID uint, domain string) ([]model.QuantificationLabel, error) {
+	q := r.DB.Scopes(ownerScope("quantification_label", userID))
+	if domain != "" {
+		q = q.Where("domain = ?", domain)
+	}
+	var labels []model.QuantificationLabel
+	if err := q.Order("created_at DESC, id DESC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// Modify はラベルを行ロックして読み込み、update が変更したラベルと返した改訂履歴を保存する
+// update が nil の改訂履歴を返した場合は何も保存しない
+func (r *QuantificationLabelRepositoryImpl) Modify(userID uint, id string, update func(label *model.QuantificationLabel) (*model.LabelRevision, error)) (*model.QuantificationLabel, error) {
+	if err := authorizeWrite(r.DB, "quantification_label", userID, id); err != nil {
+		return nil, err
+	}
+	var label model.QuantificationLabel
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&label).Error; err != nil {
+			return err
+		}
+		revision, err := update(&label)
+		if err != nil || revision == nil {
+			return err
+		}
+		// 所有者と作成日時は変更しない
+		if err := tx.Model(&label).Select("*").Omit("id", "user_id", "created_at", "created_by").Updates(&label).Error; err != nil {
+			return err
+		}
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// Delete はラベルとその改訂履歴・検証・関係を削除し、データセットから外す
+func (r *QuantificationLabelRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "quantification_label", userID, id); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("label_id = ?", id).Delete(&model.LabelRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("label_id = ?", id).Delete(&model.LabelVerification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM dataset_labels WHERE quantification_label_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("source_id = ? OR target_id = ?", id, id).Delete(&model.LabelRelation{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&model.QuantificationLabel{}).Error
+	})
+}
+
+// FindRevisions はラベルの改訂履歴を版の順に返す
+func (r *QuantificationLabelRepositoryImpl) FindRevisions(userID uint, id string) ([]model.LabelRevision, error) {
+	if err := authorizeRecord(r.DB, "quantification_label", userID, id); err != nil {
+		return nil, err
+	}
+	var revisions []model.LabelRevision
+	if err := r.DB.Where("label_id = ?", id).Order("version ASC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// FindSuggestionCandidates は値の提案に使う検証済みのラベル（自分のもの、または公開されたもの）を返す
+func (r *QuantificationLabelRepositoryImpl) FindSuggestionCandidates(userID uint, domain string) ([]model.QuantificationLabel, error) {
+	q := r.DB.Where("validated = ?", true)
+	if userID != 0 {
+		q = q.Where("(user_id = ? OR public_visibility = ?)", userID, true)
+	}
+	if domain != "" {
+		q = q.Where("domain = ?", domain)
+	}
+	var labels []model.QuantificationLabel
+	if err := q.Order("id ASC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// FindRelations は labelIDs のいずれかを起点とする relationType の関係を返す
+// 双方向の関係は終点が labelIDs に含まれる場合も返す
+func (r *QuantificationLabelRepositoryImpl) FindRelations(labelIDs []string, relationType string) ([]model.LabelRelation, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	var relations []model.LabelRelation
+	err := r.DB.Where("relation_type = ?", relationType).
+		Where("(source_id IN ? OR (bidirectional = ? AND target_id IN ?))", labelIDs, true, labelIDs).
+		Order("id ASC").
+		Find(&relations).Error
+	if err != nil {
+		return nil, err
+	}
+	return relations, nil
+}
+
+// Verify はラベルを行ロックして現在の提出の回の検証を読み込み、verify が返した検証と改訂履歴、
+// 変更したラベルを保存する。検証者はラベルの所有者ではないため所有者の確認は行わない
+func (r *QuantificationLabelRepositoryImpl) Verify(id string, verify func(label *model.QuantificationLabel, verifications []model.LabelVerification) (*model.LabelVerification, *model.LabelRevision, error)) (*model.QuantificationLabel, error) {
+	var label model.QuantificationLabel
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&label).Error; err != nil {
+			return err
+		}
+		var verifications []model.LabelVerification
+		err := tx.Where("label_id = ? AND round = ?", id, label.ReviewRound).
+			Order("created_at ASC, id ASC").
+			Find(&verifications).Error
+		if err != nil {
+			return err
+		}
+		verification, revision, err := verify(&label, verifications)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(verification).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&label).Select("*").Omit("id", "user_id", "created_at", "created_by").Updates(&label).Error; err != nil {
+			return err
+		}
+		if revision == nil {
+			return nil
+		}
+		return tx.Create(revision).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// FindVerifications はラベルの検証を提出の回の順に返す
+func (r *QuantificationLabelRepositoryImpl) FindVerifications(userID uint, id string) ([]model.LabelVerification, error) {
+	if err := authorizeRecord(r.DB, "quantification_label", userID, id); err != nil {
+		return nil, err
+	}
+	var verifications []model.LabelVerification
+	err := r.DB.Where("label_id = ?", id).Order("round ASC, created_at ASC, id ASC").Find(&verifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return verifications, nil
+}
+
+// FindReviewQueue は verifierID が検証できる検証待ちのラベル（他のユーザーのもので、
+// 現在の提出の回をまだ検証していないもの）を提出の古い順に返す
+func (r *QuantificationLabelRepositoryImpl) FindReviewQueue(verifierID uint, domain string) ([]model.QuantificationLabel, error) {
+	verified := r.DB.Session(&gorm.Session{NewDB: true}).
+		Table("label_verifications").
+		Select("1").
+		Where("label_verifications.label_id = quantification_labels.id").
+		Where("label_verifications.round = quantification_labels.review_round").
+		Where("label_verifications.verifier_id = ?", verifierID)
+	q := r.DB.Where("status = ? AND user_id <> ?", labeling.StatusSubmitted, verifierID).
+		Where("NOT EXISTS (?)", verified)
+	if domain != "" {
+		q = q.Where("domain = ?", domain)
+	}
+	var labels []model.QuantificationLabel
+	if err := q.Order("updated_at ASC, id ASC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// NewQuantificationLabelRepository は QuantificationLabelRepositoryInterface を返すコンストラクタ
+func NewQuantificationLabelRepository(db *gorm.DB) QuantificationLabelRepositoryInterface {
+	return &QuantificationLabelRepositoryImpl{DB: db}
+}
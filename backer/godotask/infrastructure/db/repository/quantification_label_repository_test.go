@@ -0,0 +1,276 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupQuantificationLabelTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Task{}, &model.WorkspaceMember{}, &model.QuantificationLabel{}, &model.LabelRevision{}, &model.LabelRelation{},
+		&model.LabelVerification{}, &model.LabelDataset{}, &model.LabelDatasetSnapshot{}))
+	return db
+}
+
+func ptrFloat(v float64) *float64 { return &v }
+
+func TestQuantificationLabelRepositorySearch(t *testing.T) {
+	db := setupQuantificationLabelTestDB(t)
+	repo := repository.NewQuantificationLabelRepository(db)
+
+	task := &model.Task{UserID: 1, Title: "Deburring"}
+	require.NoError(t, db.Create(task).Error)
+	other := &model.Task{UserID: 2, Title: "Other"}
+	require.NoError(t, db.Create(other).Error)
+
+	mm := "mm"
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	labels := []model.QuantificationLabel{
+		{ID: "burr", UserID: 1, TaskID: task.ID, OriginalText: "バリの発生程度", Domain: "machining", Category: "quality", Value: ptrFloat(0.1), Unit: &mm, Confidence: ptrFloat(0.75), Validated: true, RelatedConcepts: datatypes.JSON(`["バリ","品質"]`), CreatedAt: base},
+		{ID: "tolerance", UserID: 1, OriginalText: "寸法のばらつき", Domain: "machining", Category: "tolerance", Value: ptrFloat(0.02), Unit: &mm, Confidence: ptrFloat(0.9), CreatedAt: base.AddDate(0, 1, 0)},
+		{ID: "warmup", UserID: 1, OriginalText: "Warm-up time", Domain: "operation", Value: ptrFloat(15), CreatedAt: base.AddDate(0, 2, 0)},
+		{ID: "others", UserID: 2, OriginalText: "バリ", Domain: "machining", CreatedAt: base},
+	}
+	for i := range labels {
+		require.NoError(t, repo.Create(uint(labels[i].UserID), &labels[i]))
+	}
+	// 他人のタスクには作成できない
+	assert.ErrorIs(t, repo.Create(1, &model.QuantificationLabel{ID: "x", UserID: 1, TaskID: other.ID}), apperrors.ErrResourceAccessDenied)
+
+	search := func(q model.LabelSearchQuery) ([]string, int64) {
+		if q.SortBy == "" {
+			q.SortBy, q.SortOrder = "created_at", "asc"
+		}
+		if q.Limit == 0 {
+			q.Limit = 50
+		}
+		found, total, err := repo.Search(1, &q)
+		require.NoError(t, err)
+		var ids []string
+		for _, l := range found {
+			ids = append(ids, l.ID)
+		}
+		return ids, total
+	}
+
+	ids, total := search(model.LabelSearchQuery{})
+	assert.Equal(t, []string{"burr", "tolerance", "warmup"}, ids)
+	assert.Equal(t, int64(3), total)
+	ids, _ = search(model.LabelSearchQuery{Text: "warm"})
+	assert.Equal(t, []string{"warmup"}, ids)
+	// 同義語で展開した語句のいずれかを含むラベル
+	ids, _ = search(model.LabelSearchQuery{Text: "warm", Terms: []string{"ばらつき"}})
+	assert.Equal(t, []string{"tolerance", "warmup"}, ids)
+	ids, _ = search(model.LabelSearchQuery{Text: "warm", Terms: []string{"ばらつき"}, Domain: "operation"})
+	assert.Equal(t, []string{"warmup"}, ids)
+	ids, _ = search(model.LabelSearchQuery{Domain: "machining", Unit: "mm", MaxValue: ptrFloat(0.05)})
+	assert.Equal(t, []string{"tolerance"}, ids)
+	ids, _ = search(model.LabelSearchQuery{Concepts: []string{"バリ"}})
+	assert.Equal(t, []string{"burr"}, ids)
+	verified := false
+	ids, _ = search(model.LabelSearchQuery{Verified: &verified, MinConfidence: 0.8})
+	assert.Equal(t, []string{"tolerance"}, ids)
+	ids, _ = search(model.LabelSearchQuery{From: base.AddDate(0, 1, 0).Format(time.RFC3339)})
+	assert.Equal(t, []string{"tolerance", "warmup"}, ids)
+	ids, total = search(model.LabelSearchQuery{SortBy: "value", SortOrder: "desc", Limit: 1, Offset: 1})
+	assert.Equal(t, []string{"burr"}, ids)
+	assert.Equal(t, int64(3), total)
+
+	// 提案には検証済みで、自分のものか公開されたラベルを使う
+	hidden := false
+	require.NoError(t, db.Create(&model.QuantificationLabel{ID: "private", UserID: 2, Validated: true, PublicVisibility: &hidden}).Error)
+	require.NoError(t, db.Model(&model.QuantificationLabel{}).Where("id = ?", "others").Update("validated", true).Error)
+	candidates, err := repo.FindSuggestionCandidates(1, "")
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "burr", candidates[0].ID)
+	assert.Equal(t, "others", candidates[1].ID)
+}
+
+func TestQuantificationLabelRepositoryModify(t *testing.T) {
+	db := setupQuantificationLabelTestDB(t)
+	repo := repository.NewQuantificationLabelRepository(db)
+
+	label := &model.QuantificationLabel{ID: "burr", UserID: 1, OriginalText: "バリ", Version: 1, CreatedBy: "1"}
+	require.NoError(t, repo.Create(1, label))
+
+	updated, err := repo.Modify(1, "burr", func(l *model.QuantificationLabel) (*model.LabelRevision, error) {
+		l.OriginalText = "バリの発生程度"
+		l.UserID = 2
+		l.Version++
+		return &model.LabelRevision{ID: "rev-2", LabelID: l.ID, Version: l.Version, Comment: "clarify"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	found, err := repo.FindByID(1, "burr")
+	require.NoError(t, err)
+	assert.Equal(t, "バリの発生程度", found.OriginalText)
+	assert.Equal(t, 1, found.UserID, "owner cannot be changed")
+	revisions, err := repo.FindRevisions(1, "burr")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "clarify", revisions[0].Comment)
+
+	_, err = repo.Modify(2, "burr", func(l *model.QuantificationLabel) (*model.LabelRevision, error) { return nil, nil })
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	// 双方向の関係は終点からも辿れる
+	require.NoError(t, db.Create(&model.LabelRelation{ID: "r1", SourceID: "edge", TargetID: "burr", RelationType: "synonym", Bidirectional: true}).Error)
+	require.NoError(t, db.Create(&model.LabelRelation{ID: "r2", SourceID: "chip", TargetID: "burr", RelationType: "synonym"}).Error)
+	relations, err := repo.FindRelations([]string{"burr"}, "synonym")
+	require.NoError(t, err)
+	require.Len(t, relations, 1)
+	assert.Equal(t, "r1", relations[0].ID)
+
+	require.NoError(t, repo.Delete(1, "burr"))
+	var count int64
+	db.Model(&model.LabelRevision{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	db.Model(&model.LabelRelation{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestQuantificationLabelRepositoryVerify(t *testing.T) {
+	db := setupQuantificationLabelTestDB(t)
+	repo := repository.NewQuantificationLabelRepository(db)
+
+	labels := []model.QuantificationLabel{
+		{ID: "burr", UserID: 1, OriginalText: "バリ", Status: "submitted", ReviewRound: 1},
+		{ID: "draft", UserID: 1, OriginalText: "下書き", Status: "draft"},
+		{ID: "mine", UserID: 2, OriginalText: "自分のラベル", Status: "submitted", ReviewRound: 1},
+	}
+	for i := range labels {
+		require.NoError(t, repo.Create(uint(labels[i].UserID), &labels[i]))
+	}
+	// 前の回の検証は現在の回の検証に含まない
+	require.NoError(t, db.Create(&model.LabelVerification{ID: "old", LabelID: "burr", Round: 0, VerifierID: 3, Decision: "reject"}).Error)
+
+	queue, err := repo.FindReviewQueue(2, "")
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	assert.Equal(t, "burr", queue[0].ID)
+
+	updated, err := repo.Verify("burr", func(label *model.QuantificationLabel, verifications []model.LabelVerification) (*model.LabelVerification, *model.LabelRevision, error) {
+		assert.Empty(t, verifications)
+		label.VerificationCount = len(verifications) + 1
+		return &model.LabelVerification{ID: "v1", LabelID: label.ID, Round: label.ReviewRound, VerifierID: 2, Decision: "accept"}, nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.VerificationCount)
+
+	queue, err = repo.FindReviewQueue(2, "")
+	require.NoError(t, err)
+	assert.Empty(t, queue)
+	queue, err = repo.FindReviewQueue(3, "")
+	require.NoError(t, err)
+	assert.Len(t, queue, 2)
+
+	// 同じ回を同じ検証者が再び検証することはできない
+	_, err = repo.Verify("burr", func(label *model.QuantificationLabel, verifications []model.LabelVerification) (*model.LabelVerification, *model.LabelRevision, error) {
+		require.Len(t, verifications, 1)
+		return &model.LabelVerification{ID: "v2", LabelID: label.ID, Round: label.ReviewRound, VerifierID: 2, Decision: "reject"}, nil, nil
+	})
+	assert.Error(t, err)
+
+	verifications, err := repo.FindVerifications(1, "burr")
+	require.NoError(t, err)
+	assert.Len(t, verifications, 2)
+	_, err = repo.FindVerifications(2, "burr")
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	require.NoError(t, repo.Delete(1, "burr"))
+	var count int64
+	db.Model(&model.LabelVerification{}).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestLabelDatasetRepository(t *testing.T) {
+	db := setupQuantificationLabelTestDB(t)
+	labelRepo := repository.NewQuantificationLabelRepository(db)
+	repo := repository.NewLabelDatasetRepository(db)
+
+	public, private := true, false
+	labels := []model.QuantificationLabel{
+		{ID: "a", UserID: 1, OriginalText: "a", ReviewRound: 1},
+		{ID: "b", UserID: 1, OriginalText: "b"},
+		{ID: "shared", UserID: 2, OriginalText: "shared", Validated: true, PublicVisibility: &public},
+		{ID: "hidden", UserID: 2, OriginalText: "hidden", Validated: true, PublicVisibility: &private},
+	}
+	for i := range labels {
+		require.NoError(t, labelRepo.Create(uint(labels[i].UserID), &labels[i]))
+	}
+	found, err := repo.FindLabels(1, []string{"a", "shared", "hidden"})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "shared", found[1].ID)
+
+	dataset := &model.LabelDataset{ID: "ds", UserID: 1, Name: "machining", Labels: found}
+	require.NoError(t, repo.Create(1, dataset))
+	got, err := repo.FindByID(1, "ds")
+	require.NoError(t, err)
+	assert.Len(t, got.Labels, 2)
+	_, err = repo.FindByID(2, "ds")
+	assert.ErrorIs(t, err, apperrors.ErrResourceAccessDenied)
+
+	b, err := repo.FindLabels(1, []string{"b"})
+	require.NoError(t, err)
+	got, err = repo.Modify(1, "ds", func(d *model.LabelDataset) error {
+		d.Name = "renamed"
+		d.Labels = append(d.Labels[:1], b...)
+		return nil
+	})
+	require.NoError(t, err)
+	got, err = repo.FindByID(1, "ds")
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", got.Name)
+	require.Len(t, got.Labels, 2)
+	assert.Equal(t, "b", got.Labels[1].ID)
+
+	require.NoError(t, db.Create(&[]model.LabelVerification{
+		{ID: "v1", LabelID: "a", Round: 1, VerifierID: 2, Decision: "accept"},
+		{ID: "v0", LabelID: "a", Round: 0, VerifierID: 2, Decision: "reject"},
+	}).Error)
+	verifications, err := repo.FindVerifications([]string{"a", "b"})
+	require.NoError(t, err)
+	require.Len(t, verifications, 1)
+	assert.Equal(t, "v1", verifications[0].ID)
+
+	for i := 1; i <= 2; i++ {
+		got.TotalLabels = i
+		snapshot := &model.LabelDatasetSnapshot{ID: "s" + string(rune('0'+i)), DatasetID: "ds", Content: "{}\n"}
+		require.NoError(t, repo.CreateSnapshot(1, got, snapshot))
+		assert.Equal(t, i, snapshot.Version)
+	}
+	snapshots, err := repo.FindSnapshots(1, "ds")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Empty(t, snapshots[0].Content)
+	snapshot, err := repo.FindSnapshot(1, "ds", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", snapshot.Content)
+	got, err = repo.FindByID(1, "ds")
+	require.NoError(t, err)
+	assert.Equal(t, "2", got.Version)
+	assert.Equal(t, 2, got.TotalLabels)
+
+	// ラベルを削除するとデータセットから外れる
+	require.NoError(t, labelRepo.Delete(1, "b"))
+	got, err = repo.FindByID(1, "ds")
+	require.NoError(t, err)
+	assert.Len(t, got.Labels, 1)
+
+	require.NoError(t, repo.Delete(1, "ds"))
+	_, err = repo.FindByID(1, "ds")
+	assert.Error(t, err)
+}
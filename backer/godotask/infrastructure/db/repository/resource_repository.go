@@ -0,0 +1,179 @@
+package repository
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/godotask/domain/authz"
+	apperrors "github.com/godotask/errors"
+	helperquery "github.com/godotask/infrastructure/helper/query"
+	"gorm.io/gorm"
+)
+
+// ErrUnknownResourceType は resourceTables に登録されていないリソース種別
+var ErrUnknownResourceType = stderrors.New("unknown resource type")
+
+type ResourceRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// ownership はレコードの所有ユーザーと共有先ワークスペース
+type ownership struct {
+	UserID      *uint
+	WorkspaceID *uint
+}
+
+// FindOwnerID: リソースの所有ユーザーIDを返す。レコードが存在しない場合は gorm.ErrRecordNotFound
+func (r *ResourceRepositoryImpl) FindOwnerID(resourceType string, id string) (uint, error) {
+	return findOwnerID(r.DB, resourceType, id)
+}
+
+// HasWorkspaceAccess: userID がレコードの共有先ワークスペースのメンバーとして操作できるかを返す
+// write が true の場合は作成・更新・削除ができるロール（owner / editor）のみ許可する
+func (r *ResourceRepositoryImpl) HasWorkspaceAccess(resourceType string, id string, userID uint, write bool) (bool, error) {
+	o, err := findOwnership(r.DB, resourceType, id)
+	if err != nil {
+		return false, err
+	}
+	return hasWorkspaceAccess(r.DB, o.WorkspaceID, userID, write)
+}
+
+func findOwnerID(db *gorm.DB, resourceType string, id interface{}) (uint, error) {
+	o, err := findOwnership(db, resourceType, id)
+	if err != nil {
+		return 0, err
+	}
+	if o.UserID == nil {
+		return 0, nil
+	}
+	return *o.UserID, nil
+}
+
+func findOwnership(db *gorm.DB, resourceType string, id interface{}) (*ownership, error) {
+	rt, ok := resourceTables[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownResourceType, resourceType)
+	}
+
+	// タスクが削除済みの場合は所有者なし（NULL）として扱う
+	var o ownership
+	q := db.Table(rt.Table)
+	switch {
+	case rt.OwnedViaTask:
+		q = q.Select("tasks.user_id AS user_id, tasks.workspace_id AS workspace_id").
+			Joins("LEFT JOIN tasks ON tasks.id = " + rt.Table + ".task_id").
+			Where(rt.Table+".id = ?", id)
+	case rt.Workspaced:
+		q = q.Select("user_id, workspace_id").Where("id = ?", id)
+	default:
+		q = q.Select("user_id").Where("id = ?", id)
+	}
+
+	if err := q.Take(&o).Error; err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// hasWorkspaceAccess: userID が workspaceID のメンバーであり、必要なロールを持つかを返す
+func hasWorkspaceAccess(db *gorm.DB, workspaceID *uint, userID uint, write bool) (bool, error) {
+	if workspaceID == nil || userID == 0 {
+		return false, nil
+	}
+	role, err := workspaceRole(db, *workspaceID, userID)
+	if err != nil || role == "" {
+		return false, err
+	}
+	if write {
+		return authz.CanWriteWorkspace(role), nil
+	}
+	return true, nil
+}
+
+// workspaceRole: ワークスペースでの userID のロールを返す。メンバーでない場合は空文字
+func workspaceRole(db *gorm.DB, workspaceID, userID uint) (string, error) {
+	var roles []string
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Table("workspace_members").
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Limit(1).
+		Pluck("role", &roles).Error
+	if err != nil || len(roles) == 0 {
+		return "", err
+	}
+	return roles[0], nil
+}
+
+// authorizeRecord: userID が id のレコードを参照できるかを確認する
+// userID が 0 の場合（全リソースへの権限を持つロール）は存在確認のみ行う
+// 所有者でなくても、共有先ワークスペースのメンバーであれば参照できる
+func authorizeRecord(db *gorm.DB, resourceType string, userID uint, id interface{}) error {
+	return authorizeAccess(db, resourceType, userID, id, false)
+}
+
+// authorizeWrite: userID が id のレコードを更新・削除できるかを確認する
+// ワークスペースの viewer は参照のみ可能
+func authorizeWrite(db *gorm.DB, resourceType string, userID uint, id interface{}) error {
+	return authorizeAccess(db, resourceType, userID, id, true)
+}
+
+func authorizeAccess(db *gorm.DB, resourceType string, userID uint, id interface{}, write bool) error {
+	o, err := findOwnership(db, resourceType, id)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if userID == 0 || (o.UserID != nil && *o.UserID == userID) {
+		return nil
+	}
+
+	ok, err := hasWorkspaceAccess(db, o.WorkspaceID, userID, write)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperrors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+// authorizeWorkspace: userID が workspaceID にレコードを作成・移動できるかを確認する
+// workspaceID が nil（個人のレコード）の場合は確認しない
+func authorizeWorkspace(db *gorm.DB, userID uint, workspaceID *uint) error {
+	if workspaceID == nil || userID == 0 {
+		return nil
+	}
+	ok, err := hasWorkspaceAccess(db, workspaceID, userID, true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperrors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+// ownerScope: userID が参照できるレコードに絞り込む（userID が 0 の場合は絞り込まない）
+// task_id 経由で所有者を判定するテーブルは、参照できるタスクのサブクエリで絞り込む
+func ownerScope(resourceType string, userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == 0 {
+			return db
+		}
+		rt := resourceTables[resourceType]
+		if rt.OwnedViaTask {
+			tasks := db.Session(&gorm.Session{NewDB: true}).
+				Table("tasks").
+				Select("id").
+				Where("user_id = ? OR workspace_id IN (?)", userID, helperquery.MemberWorkspaceIDs(db, userID))
+			return db.Where(rt.Table+".task_id IN (?)", tasks)
+		}
+		if rt.Workspaced {
+			return db.Where("("+rt.Table+".user_id = ? OR "+rt.Table+".workspace_id IN (?))",
+				userID, helperquery.MemberWorkspaceIDs(db, userID))
+		}
+		return db.Where(rt.Table+".user_id = ?", userID)
+	}
+}
@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+func TestOwnership_UserOwnedResource(t *testing.T) {
+	db := setupMemoryTestDB(t)
+	repo := &repository.MemoryRepositoryImpl{DB: db}
+
+	memory := &model.Memory{UserID: 1, Title: "Owner memory"}
+	assert.NoError(t, repo.Create(memory))
+	id := intToStr(memory.ID)
+
+	// 他ユーザーからの参照・更新・削除は拒否される
+	_, err := repo.FindByID(2, id)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+	assert.True(t, stderrors.Is(repo.Update(2, id, &model.Memory{Title: "Hijacked"}), apperrors.ErrResourceAccessDenied))
+	assert.True(t, stderrors.Is(repo.Delete(2, id), apperrors.ErrResourceAccessDenied))
+
+	// 所有者の付け替えは無視される
+	assert.NoError(t, repo.Update(1, id, &model.Memory{UserID: 2, Title: "Renamed"}))
+	found, err := repo.FindByID(1, id)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, found.UserID)
+	assert.Equal(t, "Renamed", found.Title)
+
+	// スコープ 0 (管理者) は制限されない
+	_, err = repo.FindByID(0, id)
+	assert.NoError(t, err)
+
+	// 存在しない ID は not found
+	_, err = repo.FindByID(1, "9999")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceNotFound))
+}
+
+func TestOwnership_TaskOwnedResource(t *testing.T) {
+	db := setupBookTestDB(t)
+	repo := repository.NewBookRepository(db)
+
+	own := &model.Task{UserID: 1, Title: "Own Task"}
+	other := &model.Task{UserID: 2, Title: "Other Task"}
+	assert.NoError(t, db.Create(own).Error)
+	assert.NoError(t, db.Create(other).Error)
+
+	// 他ユーザーのタスクにはぶら下げられない
+	assert.True(t, stderrors.Is(repo.Create(1, &model.Book{TaskID: other.ID, Title: "x"}), apperrors.ErrResourceAccessDenied))
+
+	book := &model.Book{TaskID: own.ID, Title: "Mine"}
+	assert.NoError(t, repo.Create(1, book))
+	id := intToStr(book.ID)
+
+	_, err := repo.FindByID(2, id)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+
+	// 他ユーザーのタスクへの付け替えは拒否される
+	assert.True(t, stderrors.Is(repo.Update(1, id, &model.Book{TaskID: other.ID}), apperrors.ErrResourceAccessDenied))
+
+	// 一覧はタスクの所有者で絞り込まれる
+	mine, err := repo.FindAll(1)
+	assert.NoError(t, err)
+	assert.Len(t, mine, 1)
+	theirs, err := repo.FindAll(2)
+	assert.NoError(t, err)
+	assert.Len(t, theirs, 0)
+}
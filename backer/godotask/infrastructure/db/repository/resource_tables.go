@@ -0,0 +1,44 @@
+package repository
+
+// resourceTable はリソース種別ごとのテーブルと所有者の判定方法
+type resourceTable struct {
+	Table string
+	// true の場合 user_id を持たないため task_id → tasks.user_id で所有者を判定する
+	OwnedViaTask bool
+	// true の場合 workspace_id を持ち、ワークスペースのメンバーにも共有される
+	// task_id 経由のテーブルはタスクの workspace_id に従う
+	Workspaced bool
+}
+
+// resourceTables はルートから得たリソース種別（/api/ 以降のパラメータを除いたパス）と
+// 対応するテーブルの対応表。監査ログのスナップショット取得と所有者判定に利用する
+var resourceTables = map[string]resourceTable{
+	"task":                       {Table: "tasks", Workspaced: true},
+	"memory":                     {Table: "memories", Workspaced: true},
+	"assessment":                 {Table: "assessments"},
+	"heuristics/analyze":         {Table: "heuristics_analyses"},
+	"heuristics/insight":         {Table: "heuristics_insights"},
+	"heuristics/pattern":         {Table: "heuristics_patterns"},
+	"heuristics/modeler":         {Table: "heuristics_modelers"},
+	"qualitative_label":          {Table: "qualitative_labels"},
+	"quantification_label":       {Table: "quantification_labels"},
+	"label_dataset":              {Table: "label_datasets"},
+	"label_relation":             {Table: "label_relations"},
+	"phenomenological_framework": {Table: "phenomenological_frameworks", OwnedViaTask: true},
+	"process_optimization":       {Table: "process_optimizations", OwnedViaTask: true},
+	"knowledge_pattern":          {Table: "knowledge_patterns", OwnedViaTask: true},
+	"language_optimization":      {Table: "language_optimizations", OwnedViaTask: true},
+	"teaching_free_control":      {Table: "teaching_free_controls", OwnedViaTask: true},
+	"book":                       {Table: "book", OwnedViaTask: true},
+	"state_evaluation":           {Table: "state_evaluations", OwnedViaTask: true},
+	"attachment":                 {Table: "attachments"},
+	"multimodal_data":            {Table: "multimodal_data", OwnedViaTask: true},
+	"image_annotation":           {Table: "image_annotations", OwnedViaTask: true},
+	"calibration":                {Table: "user_calibrations", OwnedViaTask: true},
+}
+
+// ResourceTable はリソース種別に対応するテーブル名を返す
+func ResourceTable(resourceType string) (string, bool) {
+	rt, ok := resourceTables[resourceType]
+	return rt.Table, ok
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+type RobotSpecificationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *RobotSpecificationRepositoryImpl) Create(robot *model.RobotSpecification) error {
+	return r.DB.Create(robot).Error
+}
+
+func (r *RobotSpecificationRepositoryImpl) FindByID(id string) (*model.RobotSpecification, error) {
+	var robot model.RobotSpecification
+	if err := r.DB.Where("id = ?", id).First(&robot).Error; err != nil {
+		return nil, err
+	}
+	return &robot, nil
+}
+
+func (r *RobotSpecificationRepositoryImpl) FindAll() ([]model.RobotSpecification, error) {
+	var robots []model.RobotSpecification
+	if err := r.DB.Order("id ASC").Find(&robots).Error; err != nil {
+		return nil, err
+	}
+	return robots, nil
+}
+
+func (r *RobotSpecificationRepositoryImpl) Update(id string, robot *model.RobotSpecification) error {
+	result := r.DB.Model(&model.RobotSpecification{}).Where("id = ?", id).Omit("id").Updates(robot)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *RobotSpecificationRepositoryImpl) Delete(id string) error {
+	result := r.DB.Where("id = ?", id).Delete(&model.RobotSpecification{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/godotask/domain/entity"
+	domainRepo "github.com/godotask/domain/repository"
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryGorm struct {
+	db *gorm.DB
+}
+
+var _ domainRepo.SessionRepository = (*SessionRepositoryGorm)(nil)
+
+func NewGormSessionRepository(db *gorm.DB) domainRepo.SessionRepository {
+	return &SessionRepositoryGorm{db: db}
+}
+
+func (r *SessionRepositoryGorm) Create(session *entity.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *SessionRepositoryGorm) FindByID(id uint) (*entity.Session, error) {
+	var session entity.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepositoryGorm) ListActiveByUser(userID uint, now time.Time) ([]entity.Session, error) {
+	var sessions []entity.Session
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *SessionRepositoryGorm) Touch(id uint, usedAt, expiresAt time.Time) error {
+	return r.db.Model(&entity.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"last_used_at": usedAt, "expires_at": expiresAt}).Error
+}
+
+func (r *SessionRepositoryGorm) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&entity.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *SessionRepositoryGorm) RevokeAllByUser(userID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&entity.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (r *SessionRepositoryGorm) CreateRefreshToken(token *entity.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *SessionRepositoryGorm) FindRefreshTokenByHash(hash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *SessionRepositoryGorm) MarkRefreshTokenUsed(id uint, at time.Time) (bool, error) {
+	// 同時リクエストで同じトークンが使われた場合、更新できるのは1件だけ
+	result := r.db.Model(&entity.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
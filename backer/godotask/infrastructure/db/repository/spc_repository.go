@@ -0,0 +1,78 @@
+package repository
+
+import (
+	stderrors "errors"
+	"slices"
+
+	"gorm.io/gorm"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// SPCRepositoryImpl は統計的工程管理の測定値を扱う
+// 測定値はタスクの所有者・共有先のみが参照・登録できる
+type SPCRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *SPCRepositoryImpl) CreateMeasurements(userID uint, taskID int, measurements []model.SPCMeasurement) error {
+	if err := authorizeWrite(r.DB, "task", userID, taskID); err != nil {
+		return err
+	}
+	return r.DB.Create(&measurements).Error
+}
+
+// FindMeasurements はタスク・ラベルの測定値のうち新しい limit 件を測定時刻の昇順で返す
+func (r *SPCRepositoryImpl) FindMeasurements(userID uint, taskID int, labelID string, limit int) ([]model.SPCMeasurement, error) {
+	if err := authorizeRecord(r.DB, "task", userID, taskID); err != nil {
+		return nil, err
+	}
+	var measurements []model.SPCMeasurement
+	q := r.DB.Where("task_id = ? AND label_id = ?", taskID, labelID).Order("measured_at DESC, id DESC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&measurements).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(measurements)
+	return measurements, nil
+}
+
+// FindMultimodal はタスクのマルチモーダルデータのうち新しい limit 件を作成日時の昇順で返す
+func (r *SPCRepositoryImpl) FindMultimodal(userID uint, taskID int, unit string, limit int) ([]model.MultimodalData, error) {
+	if err := authorizeRecord(r.DB, "task", userID, taskID); err != nil {
+		return nil, err
+	}
+	var data []model.MultimodalData
+	q := r.DB.Where("task_id = ?", taskID).Order("created_at DESC, id DESC")
+	if unit != "" {
+		q = q.Where("unit = ?", unit)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&data).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(data)
+	return data, nil
+}
+
+// FindLabel は規格限界の取得に使う定量化ラベルを返す
+// 自分のラベルか、参照を確認済みのタスク taskID に紐づくラベルのみ参照できる
+func (r *SPCRepositoryImpl) FindLabel(userID uint, taskID int, labelID string) (*model.QuantificationLabel, error) {
+	var label model.QuantificationLabel
+	err := r.DB.Where("id = ?", labelID).First(&label).Error
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 && uint(label.UserID) != userID && label.TaskID != taskID {
+		return nil, apperrors.ErrResourceAccessDenied
+	}
+	return &label, nil
+}
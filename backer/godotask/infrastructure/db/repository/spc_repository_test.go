@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+func TestSPCMeasurements(t *testing.T) {
+	db := setupStateEvaluationTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&model.SPCMeasurement{}, &model.QuantificationLabel{}))
+	repo := &repository.SPCRepositoryImpl{DB: db}
+
+	task := &model.Task{UserID: 1, Title: "Own Task"}
+	assert.NoError(t, db.Create(task).Error)
+	assert.NoError(t, db.Create(&model.QuantificationLabel{ID: "burr", UserID: 1, TaskID: task.ID}).Error)
+	assert.NoError(t, db.Create(&model.QuantificationLabel{ID: "other", UserID: 3, TaskID: 999}).Error)
+
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	measurements := []model.SPCMeasurement{}
+	for i := 0; i < 5; i++ {
+		measurements = append(measurements, model.SPCMeasurement{TaskID: task.ID, LabelID: "burr", Value: float64(i), MeasuredAt: base.Add(time.Duration(i) * time.Minute)})
+	}
+
+	// 他ユーザーのタスクには測定値を登録・参照できない
+	err := repo.CreateMeasurements(2, task.ID, measurements)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+	_, err = repo.FindMeasurements(2, task.ID, "burr", 0)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+
+	assert.NoError(t, repo.CreateMeasurements(1, task.ID, measurements))
+
+	// 最新の3件を測定時刻の昇順で返す
+	found, err := repo.FindMeasurements(1, task.ID, "burr", 3)
+	assert.NoError(t, err)
+	if assert.Len(t, found, 3) {
+		assert.Equal(t, []float64{2, 3, 4}, []float64{found[0].Value, found[1].Value, found[2].Value})
+	}
+
+	_, err = repo.FindLabel(1, task.ID, "burr")
+	assert.NoError(t, err)
+	_, err = repo.FindLabel(1, task.ID, "other")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+	_, err = repo.FindLabel(1, task.ID, "missing")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceNotFound))
+}
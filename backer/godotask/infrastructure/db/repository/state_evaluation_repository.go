@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+type StateEvaluationRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *StateEvaluationRepositoryImpl) Create(userID uint, stateEvaluation *model.StateEvaluation) error {
+	if err := authorizeWrite(r.DB, "task", userID, stateEvaluation.TaskID); err != nil {
+		return err
+	}
+	return r.DB.Create(stateEvaluation).Error
+}
+
+func (r *StateEvaluationRepositoryImpl) FindByID(userID uint, id string) (*model.StateEvaluation, error) {
+	if err := authorizeRecord(r.DB, "state_evaluation", userID, id); err != nil {
+		return nil, err
+	}
+	var stateEvaluation model.StateEvaluation
+	if err := r.DB.Where("id = ?", id).First(&stateEvaluation).Error; err != nil {
+		return nil, err
+	}
+	return &stateEvaluation, nil
+}
+
+func (r *StateEvaluationRepositoryImpl) FindAll(userID uint) ([]model.StateEvaluation, error) {
+	var stateEvaluations []model.StateEvaluation
+	if err := r.DB.Scopes(ownerScope("state_evaluation", userID)).Order("created_at DESC, id DESC").Find(&stateEvaluations).Error; err != nil {
+		return nil, err
+	}
+	return stateEvaluations, nil
+}
+
+func (r *StateEvaluationRepositoryImpl) History(userID uint, workerID string, workTarget string) ([]model.StateEvaluation, error) {
+	var stateEvaluations []model.StateEvaluation
+	q := r.DB.Scopes(ownerScope("state_evaluation", userID)).Where("work_target = ?", workTarget)
+	if workerID != "" {
+		q = q.Where("user_id = ?", workerID)
+	}
+	if err := q.Order("created_at ASC, id ASC").Find(&stateEvaluations).Error; err != nil {
+		return nil, err
+	}
+	return stateEvaluations, nil
+}
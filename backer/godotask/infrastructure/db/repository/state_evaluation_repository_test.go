@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+func setupStateEvaluationTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to test DB: %v", err)
+	}
+	if err := db.AutoMigrate(&model.Task{}, &model.StateEvaluation{}, &model.WorkspaceMember{}); err != nil {
+		t.Fatalf("failed to migrate state evaluation models: %v", err)
+	}
+	return db
+}
+
+func TestStateEvaluationHistory(t *testing.T) {
+	db := setupStateEvaluationTestDB(t)
+	repo := &repository.StateEvaluationRepositoryImpl{DB: db}
+
+	own := &model.Task{UserID: 1, Title: "Own Task"}
+	other := &model.Task{UserID: 2, Title: "Other Task"}
+	assert.NoError(t, db.Create(own).Error)
+	assert.NoError(t, db.Create(other).Error)
+
+	// 他ユーザーのタスクには評価を作成できない
+	err := repo.Create(1, &model.StateEvaluation{ID: "x", UserID: "w1", TaskID: other.ID, Level: 1, WorkTarget: "welding"})
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+
+	base := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"c", "a", "b"} {
+		assert.NoError(t, repo.Create(1, &model.StateEvaluation{
+			ID: id, UserID: "w1", TaskID: own.ID, Level: 1, WorkTarget: "welding",
+			EvaluationScore: float64(60 + i*10), CreatedAt: base.AddDate(0, 0, i),
+		}))
+	}
+	assert.NoError(t, repo.Create(1, &model.StateEvaluation{ID: "d", UserID: "w2", TaskID: own.ID, Level: 1, WorkTarget: "welding"}))
+	assert.NoError(t, repo.Create(2, &model.StateEvaluation{ID: "e", UserID: "w1", TaskID: other.ID, Level: 1, WorkTarget: "welding"}))
+
+	// 作成日時の昇順で、作業者と閲覧可能なタスクに絞り込まれる
+	history, err := repo.History(1, "w1", "welding")
+	assert.NoError(t, err)
+	if assert.Len(t, history, 3) {
+		assert.Equal(t, "c", history[0].ID)
+		assert.Equal(t, "b", history[2].ID)
+	}
+
+	_, err = repo.FindByID(2, "c")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+}
+
+func TestToolMatchingResultReplaceForEvaluation(t *testing.T) {
+	db := setupStateEvaluationTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&model.ToolMatchingResult{}))
+	evaluations := &repository.StateEvaluationRepositoryImpl{DB: db}
+	repo := &repository.ToolMatchingResultRepositoryImpl{DB: db}
+
+	task := &model.Task{UserID: 1, Title: "Own Task"}
+	assert.NoError(t, db.Create(task).Error)
+	assert.NoError(t, evaluations.Create(1, &model.StateEvaluation{ID: "se", UserID: "w1", TaskID: task.ID, Level: 1}))
+
+	first := []model.ToolMatchingResult{{ID: "r1", StateEvaluationID: "se", RobotID: "a", Rank: 1}}
+	assert.NoError(t, repo.ReplaceForEvaluation(1, "se", first))
+
+	// 再実行すると前回の結果は置き換えられ、順位順に返る
+	second := []model.ToolMatchingResult{
+		{ID: "r3", StateEvaluationID: "se", RobotID: "c", Rank: 2},
+		{ID: "r2", StateEvaluationID: "se", RobotID: "b", Rank: 1},
+	}
+	assert.NoError(t, repo.ReplaceForEvaluation(1, "se", second))
+	results, err := repo.FindByEvaluation(1, "se")
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "b", results[0].RobotID)
+	}
+
+	assert.True(t, stderrors.Is(repo.ReplaceForEvaluation(2, "se", nil), apperrors.ErrResourceAccessDenied))
+	_, err = repo.FindByEvaluation(2, "se")
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+}
@@ -13,10 +13,17 @@ type TaskRepositoryImpl struct {
 }
 
 func (r *TaskRepositoryImpl) Create(task *model.Task) error {
+	// ワークスペースに作成する場合は作成者が owner / editor である必要がある
+	if err := authorizeWorkspace(r.DB, uint(task.UserID), task.WorkspaceID); err != nil {
+		return err
+	}
 	return r.DB.Create(task).Error
 }
 
-func (r *TaskRepositoryImpl) FindByID(id string) (*model.Task, error) {
+func (r *TaskRepositoryImpl) FindByID(userID uint, id string) (*model.Task, error) {
+	if err := authorizeRecord(r.DB, "task", userID, id); err != nil {
+		return nil, err
+	}
 	var task model.Task
 	if err := r.DB.Where("id = ?", id).First(&task).Error; err != nil {
 		return nil, err
@@ -96,11 +103,22 @@ func (r *TaskRepositoryImpl) ListTasksByUserPager(userID uint, offset, limit int
 	return tasks, total, nil
 }
 
-func (r *TaskRepositoryImpl) Update(id string, task *model.Task) error {
-	return r.DB.Model(&model.Task{}).Where("id = ?", id).Updates(task).Error
+func (r *TaskRepositoryImpl) Update(userID uint, id string, task *model.Task) error {
+	if err := authorizeWrite(r.DB, "task", userID, id); err != nil {
+		return err
+	}
+	// 移動先のワークスペースでも編集できる必要がある
+	if err := authorizeWorkspace(r.DB, userID, task.WorkspaceID); err != nil {
+		return err
+	}
+	// 所有者の付け替えは許可しない
+	return r.DB.Model(&model.Task{}).Where("id = ?", id).Omit("user_id").Updates(task).Error
 }
 
-func (r *TaskRepositoryImpl) Delete(id string) error {
+func (r *TaskRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "task", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.Task{}, id).Error
 }
 
@@ -18,7 +18,7 @@ func setupTaskTestDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("failed to connect to test DB: %v", err)
 	}
-	if err := db.AutoMigrate(&model.Task{}); err != nil {
+	if err := db.AutoMigrate(&model.Task{}, &model.Assessment{}, &model.KnowledgePattern{}, &model.WorkspaceMember{}); err != nil {
 		t.Fatalf("failed to migrate Task model: %v", err)
 	}
 	return db
@@ -32,7 +32,7 @@ func TestTaskRepository_CRUD(t *testing.T) {
 	now := time.Now()
 	task := &model.Task{
 		UserID:      1,
-		MemoryID:    nil,
+		MemoryID:    0,
 		Title:       "Test Task",
 		Description: "This is a test task.",
 		Date:        &now,
@@ -46,29 +46,29 @@ func TestTaskRepository_CRUD(t *testing.T) {
 	idStr := strconv.Itoa(task.ID)
 
 	// FindByID
-	found, err := repo.FindByID(idStr)
+	found, err := repo.FindByID(1, idStr)
 	assert.NoError(t, err)
 	assert.Equal(t, task.Title, found.Title)
 	assert.Equal(t, task.Status, found.Status)
 
 	// Update
 	task.Title = "Updated Task"
-	err = repo.Update(idStr, task)
+	err = repo.Update(1, idStr, task)
 	assert.NoError(t, err)
 
-	updated, err := repo.FindByID(idStr)
+	updated, err := repo.FindByID(1, idStr)
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Task", updated.Title)
 
 	// FindAll
-	all, err := repo.FindAll()
+	all, err := repo.FindAll(1)
 	assert.NoError(t, err)
 	assert.Len(t, all, 1)
 
 	// Delete
-	err = repo.Delete(idStr)
+	err = repo.Delete(1, idStr)
 	assert.NoError(t, err)
 
-	_, err = repo.FindByID(idStr)
+	_, err = repo.FindByID(1, idStr)
 	assert.Error(t, err) // should return not found error
 }
@@ -1,20 +1,28 @@
 package repository
 
 import (
+	"slices"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"github.com/godotask/infrastructure/db/model"
-	helperquery "github.com/godotask/infrastructure/helper/query"
 )
 
 type TeachingFreeControlRepositoryImpl struct {
 	DB *gorm.DB
 }
 
-func (r *TeachingFreeControlRepositoryImpl) Create(teachingFreeControl *model.TeachingFreeControl) error {
+func (r *TeachingFreeControlRepositoryImpl) Create(userID uint, teachingFreeControl *model.TeachingFreeControl) error {
+	if err := authorizeWrite(r.DB, "task", userID, teachingFreeControl.TaskID); err != nil {
+		return err
+	}
 	return r.DB.Create(teachingFreeControl).Error
 }
 
-func (r *TeachingFreeControlRepositoryImpl) FindByID(id string) (*model.TeachingFreeControl, error) {
+func (r *TeachingFreeControlRepositoryImpl) FindByID(userID uint, id string) (*model.TeachingFreeControl, error) {
+	if err := authorizeRecord(r.DB, "teaching_free_control", userID, id); err != nil {
+		return nil, err
+	}
 	var teachingFreeControl model.TeachingFreeControl
 	if err := r.DB.Where("id = ?", id).First(&teachingFreeControl).Error; err != nil {
 		return nil, err
@@ -24,20 +32,102 @@ func (r *TeachingFreeControlRepositoryImpl) FindByID(id string) (*model.Teaching
 
 func (r *TeachingFreeControlRepositoryImpl) FindAll(userID uint) ([]model.TeachingFreeControl, error) {
 	var teachingFreeControls []model.TeachingFreeControl
-	if err := r.DB.Scopes(helperquery.WithUserFilter(userID)).Order("created_at DESC, id DESC").Find(&teachingFreeControls).Error; err != nil {
+	if err := r.DB.Scopes(ownerScope("teaching_free_control", userID)).Order("created_at DESC, id DESC").Find(&teachingFreeControls).Error; err != nil {
 		return nil, err
 	}
 	return teachingFreeControls, nil
 }
 
-func (r *TeachingFreeControlRepositoryImpl) Update(id string, teachingFreeControl *model.TeachingFreeControl) error {
+func (r *TeachingFreeControlRepositoryImpl) Update(userID uint, id string, teachingFreeControl *model.TeachingFreeControl) error {
+	if err := authorizeWrite(r.DB, "teaching_free_control", userID, id); err != nil {
+		return err
+	}
+	// 付け替え先のタスクも所有している必要がある
+	if teachingFreeControl.TaskID != 0 {
+		if err := authorizeWrite(r.DB, "task", userID, teachingFreeControl.TaskID); err != nil {
+			return err
+		}
+	}
 	return r.DB.Model(&model.TeachingFreeControl{}).Where("id = ?", id).Updates(teachingFreeControl).Error
 }
 
-func (r *TeachingFreeControlRepositoryImpl) Delete(id string) error {
+func (r *TeachingFreeControlRepositoryImpl) Delete(userID uint, id string) error {
+	if err := authorizeWrite(r.DB, "teaching_free_control", userID, id); err != nil {
+		return err
+	}
 	return r.DB.Delete(&model.TeachingFreeControl{}, id).Error
 }
 
+// RecordAttempts は制御設定を行ロックして試行を登録し、登録後の全試行を update に渡して設定を更新する
+// update は SuccessRate / AdaptationTime を試行から計算し直す
+func (r *TeachingFreeControlRepositoryImpl) RecordAttempts(userID uint, id string, attempts []model.TeachingFreeControlAttempt, update func(teachingFreeControl *model.TeachingFreeControl, all []model.TeachingFreeControlAttempt) error) (*model.TeachingFreeControl, error) {
+	if err := authorizeWrite(r.DB, "teaching_free_control", userID, id); err != nil {
+		return nil, err
+	}
+	var teachingFreeControl model.TeachingFreeControl
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&teachingFreeControl).Error; err != nil {
+			return err
+		}
+		for i := range attempts {
+			attempts[i].TeachingFreeControlID = teachingFreeControl.ID
+			attempts[i].RobotID = teachingFreeControl.RobotID
+			attempts[i].TaskType = teachingFreeControl.TaskType
+		}
+		// シミュレーションでは1度に大量の試行を登録するため、プレースホルダ数の上限に収まるよう分割する
+		if err := tx.CreateInBatches(&attempts, 500).Error; err != nil {
+			return err
+		}
+		var all []model.TeachingFreeControlAttempt
+		if err := tx.Where("teaching_free_control_id = ?", id).Order("attempted_at ASC, id ASC").Find(&all).Error; err != nil {
+			return err
+		}
+		if err := update(&teachingFreeControl, all); err != nil {
+			return err
+		}
+		return tx.Model(&teachingFreeControl).Updates(map[string]interface{}{
+			"success_rate":    teachingFreeControl.SuccessRate,
+			"adaptation_time": teachingFreeControl.AdaptationTime,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &teachingFreeControl, nil
+}
+
+// FindAttempts は制御設定の試行のうち最新 limit 件を時刻の昇順で返す
+func (r *TeachingFreeControlRepositoryImpl) FindAttempts(userID uint, id string, limit int) ([]model.TeachingFreeControlAttempt, error) {
+	if err := authorizeRecord(r.DB, "teaching_free_control", userID, id); err != nil {
+		return nil, err
+	}
+	var attempts []model.TeachingFreeControlAttempt
+	if err := r.DB.Where("teaching_free_control_id = ?", id).Order("attempted_at DESC, id DESC").Limit(limit).Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(attempts)
+	return attempts, nil
+}
+
+// FindAttemptsBy は参照できる制御設定の試行をロボット・タスク種別で絞り込み、最新 limit 件を時刻の昇順で返す
+// robotID / taskType が空の場合はその条件で絞り込まない
+func (r *TeachingFreeControlRepositoryImpl) FindAttemptsBy(userID uint, robotID string, taskType string, limit int) ([]model.TeachingFreeControlAttempt, error) {
+	controls := r.DB.Model(&model.TeachingFreeControl{}).Select("teaching_free_controls.id").Scopes(ownerScope("teaching_free_control", userID))
+	q := r.DB.Where("teaching_free_control_id IN (?)", controls)
+	if robotID != "" {
+		q = q.Where("robot_id = ?", robotID)
+	}
+	if taskType != "" {
+		q = q.Where("task_type = ?", taskType)
+	}
+	var attempts []model.TeachingFreeControlAttempt
+	if err := q.Order("attempted_at DESC, id DESC").Limit(limit).Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(attempts)
+	return attempts, nil
+}
+
 // NewTeachingFreeControlRepository は TeachingFreeControlRepositoryInterface を返すコンストラクタ
 func NewTeachingFreeControlRepository(db *gorm.DB) TeachingFreeControlRepositoryInterface {
 	return &TeachingFreeControlRepositoryImpl{DB: db}
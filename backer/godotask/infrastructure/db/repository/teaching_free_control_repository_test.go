@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+func TestTeachingFreeControlAttempts(t *testing.T) {
+	db := setupStateEvaluationTestDB(t)
+	assert.NoError(t, db.AutoMigrate(&model.TeachingFreeControl{}, &model.TeachingFreeControlAttempt{}))
+	repo := &repository.TeachingFreeControlRepositoryImpl{DB: db}
+
+	own := &model.Task{UserID: 1, Title: "Own Task"}
+	other := &model.Task{UserID: 2, Title: "Other Task"}
+	assert.NoError(t, db.Create(own).Error)
+	assert.NoError(t, db.Create(other).Error)
+	assert.NoError(t, db.Create(&model.TeachingFreeControl{ID: "tfc-1", TaskID: own.ID, RobotID: "arm-1", TaskType: "pick_and_place"}).Error)
+	assert.NoError(t, db.Create(&model.TeachingFreeControl{ID: "tfc-2", TaskID: other.ID, RobotID: "arm-1", TaskType: "pick_and_place"}).Error)
+
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	attempts := func(outcomes ...string) []model.TeachingFreeControlAttempt {
+		out := make([]model.TeachingFreeControlAttempt, 0, len(outcomes))
+		for i, o := range outcomes {
+			out = append(out, model.TeachingFreeControlAttempt{Outcome: o, Duration: float64(i + 1), Source: model.AttemptSourceRobot, AttemptedAt: base.Add(time.Duration(i) * time.Minute)})
+		}
+		return out
+	}
+
+	// 他ユーザーの制御設定には試行を登録・参照できない
+	_, err := repo.RecordAttempts(1, "tfc-2", attempts(model.AttemptSuccess), func(*model.TeachingFreeControl, []model.TeachingFreeControlAttempt) error { return nil })
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+	_, err = repo.FindAttempts(1, "tfc-2", 10)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+
+	var seen int
+	control, err := repo.RecordAttempts(1, "tfc-1", attempts(model.AttemptFailure, model.AttemptSuccess, model.AttemptSuccess), func(c *model.TeachingFreeControl, all []model.TeachingFreeControlAttempt) error {
+		seen = len(all)
+		c.SuccessRate = 0.5
+		c.AdaptationTime = 2.5
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, seen)
+	assert.Equal(t, 0.5, control.SuccessRate)
+
+	// 試行には制御設定のロボット・タスク種別が複製される
+	found, err := repo.FindAttempts(1, "tfc-1", 2)
+	assert.NoError(t, err)
+	if assert.Len(t, found, 2) {
+		assert.Equal(t, []float64{2, 3}, []float64{found[0].Duration, found[1].Duration})
+		assert.Equal(t, "arm-1", found[0].RobotID)
+		assert.Equal(t, "pick_and_place", found[0].TaskType)
+	}
+	var stored model.TeachingFreeControl
+	assert.NoError(t, db.First(&stored, "id = ?", "tfc-1").Error)
+	assert.Equal(t, 2.5, stored.AdaptationTime)
+
+	// ロボット・タスク種別での集計は参照できる制御設定の試行のみを対象にする
+	_, err = repo.RecordAttempts(2, "tfc-2", attempts(model.AttemptSuccess), func(*model.TeachingFreeControl, []model.TeachingFreeControlAttempt) error { return nil })
+	assert.NoError(t, err)
+	byRobot, err := repo.FindAttemptsBy(1, "arm-1", "", 100)
+	assert.NoError(t, err)
+	assert.Len(t, byRobot, 3)
+	all, err := repo.FindAttemptsBy(0, "arm-1", "pick_and_place", 100)
+	assert.NoError(t, err)
+	assert.Len(t, all, 4)
+	none, err := repo.FindAttemptsBy(1, "", "welding", 100)
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// ToolMatchingResultRepositoryImpl はマッチング結果を扱う
+// 結果は状態評価（→ タスク）の所有者・共有先のみが参照・作成できる
+type ToolMatchingResultRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *ToolMatchingResultRepositoryImpl) ReplaceForEvaluation(userID uint, stateEvaluationID string, results []model.ToolMatchingResult) error {
+	if err := authorizeWrite(r.DB, "state_evaluation", userID, stateEvaluationID); err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_evaluation_id = ?", stateEvaluationID).Delete(&model.ToolMatchingResult{}).Error; err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return nil
+		}
+		return tx.Create(&results).Error
+	})
+}
+
+func (r *ToolMatchingResultRepositoryImpl) FindByEvaluation(userID uint, stateEvaluationID string) ([]model.ToolMatchingResult, error) {
+	if err := authorizeRecord(r.DB, "state_evaluation", userID, stateEvaluationID); err != nil {
+		return nil, err
+	}
+	var results []model.ToolMatchingResult
+	if err := r.DB.Where("state_evaluation_id = ?", stateEvaluationID).Order("rank ASC, id ASC").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -36,3 +36,21 @@ func (r *UserRepositoryGorm) FindByID(id uint) (*entity.User, error) {
 	}
 	return &user, nil
 }
+
+func (r *UserRepositoryGorm) List() ([]entity.User, error) {
+	var users []entity.User
+	if err := r.db.Order("id ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *UserRepositoryGorm) CountByRole(role string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.User{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
+
+func (r *UserRepositoryGorm) UpdateRole(id uint, role string) error {
+	return r.db.Model(&entity.User{}).Where("id = ?", id).Update("role", role).Error
+}
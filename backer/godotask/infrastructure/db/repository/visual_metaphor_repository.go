@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+)
+
+type VisualMetaphorRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+func (r *VisualMetaphorRepositoryImpl) Create(metaphor *model.VisualMetaphor) error {
+	return r.DB.Create(metaphor).Error
+}
+
+func (r *VisualMetaphorRepositoryImpl) FindByID(id string) (*model.VisualMetaphor, error) {
+	var metaphor model.VisualMetaphor
+	if err := r.DB.Where("id = ?", id).First(&metaphor).Error; err != nil {
+		return nil, err
+	}
+	return &metaphor, nil
+}
+
+func (r *VisualMetaphorRepositoryImpl) FindAll() ([]model.VisualMetaphor, error) {
+	var metaphors []model.VisualMetaphor
+	if err := r.DB.Order("id ASC").Find(&metaphors).Error; err != nil {
+		return nil, err
+	}
+	return metaphors, nil
+}
+
+func (r *VisualMetaphorRepositoryImpl) Update(id string, metaphor *model.VisualMetaphor) error {
+	result := r.DB.Model(&model.VisualMetaphor{}).Where("id = ?", id).Omit("id").Updates(metaphor)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *VisualMetaphorRepositoryImpl) Delete(id string) error {
+	result := r.DB.Where("id = ?", id).Delete(&model.VisualMetaphor{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
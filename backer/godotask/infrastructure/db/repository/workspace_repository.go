@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkspaceRepositoryImpl struct {
+	DB *gorm.DB
+}
+
+// Create: ワークスペースを作成し、作成者を owner として登録する
+func (r *WorkspaceRepositoryImpl) Create(workspace *model.Workspace, ownerID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		workspace.CreatedBy = ownerID
+		if err := tx.Omit("Members").Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      ownerID,
+			Role:        string(authz.WorkspaceOwner),
+		}).Error
+	})
+}
+
+func (r *WorkspaceRepositoryImpl) FindByID(id uint) (*model.Workspace, error) {
+	var workspace model.Workspace
+	if err := r.DB.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&workspace, id).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// ListByUser: userID が所属するワークスペース一覧（userID が 0 の場合は全件）
+func (r *WorkspaceRepositoryImpl) ListByUser(userID uint) ([]model.Workspace, error) {
+	var workspaces []model.Workspace
+	q := r.DB.Model(&model.Workspace{})
+	if userID != 0 {
+		q = q.Where("id IN (?)", r.DB.Model(&model.WorkspaceMember{}).Select("workspace_id").Where("user_id = ?", userID))
+	}
+	if err := q.Order("created_at ASC, id ASC").Find(&workspaces).Error; err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+func (r *WorkspaceRepositoryImpl) Update(id uint, workspace *model.Workspace) error {
+	return r.DB.Model(&model.Workspace{}).
+		Where("id = ?", id).
+		Select("name", "description").
+		Updates(workspace).Error
+}
+
+// Delete: ワークスペースを削除する。共有されていたタスク・メモリは作成者個人のものに戻す
+func (r *WorkspaceRepositoryImpl) Delete(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, m := range []interface{}{&model.Task{}, &model.Memory{}} {
+			if err := tx.Model(m).Where("workspace_id = ?", id).Update("workspace_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&model.WorkspaceInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&model.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Workspace{}, id).Error
+	})
+}
+
+func (r *WorkspaceRepositoryImpl) FindMember(workspaceID, userID uint) (*model.WorkspaceMember, error) {
+	var member model.WorkspaceMember
+	if err := r.DB.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *WorkspaceRepositoryImpl) UpdateMemberRole(workspaceID, userID uint, role string) error {
+	return r.DB.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role).Error
+}
+
+func (r *WorkspaceRepositoryImpl) RemoveMember(workspaceID, userID uint) error {
+	return r.DB.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&model.WorkspaceMember{}).Error
+}
+
+func (r *WorkspaceRepositoryImpl) CountOwners(workspaceID uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND role = ?", workspaceID, string(authz.WorkspaceOwner)).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *WorkspaceRepositoryImpl) CreateInvitation(invitation *model.WorkspaceInvitation) error {
+	return r.DB.Create(invitation).Error
+}
+
+func (r *WorkspaceRepositoryImpl) ListPendingInvitations(workspaceID uint, now time.Time) ([]model.WorkspaceInvitation, error) {
+	var invitations []model.WorkspaceInvitation
+	err := r.DB.
+		Where("workspace_id = ? AND accepted_at IS NULL AND expires_at > ?", workspaceID, now).
+		Order("created_at DESC, id DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *WorkspaceRepositoryImpl) FindInvitationByHash(hash string) (*model.WorkspaceInvitation, error) {
+	var invitation model.WorkspaceInvitation
+	if err := r.DB.Where("token_hash = ?", hash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// AcceptInvitation: 招待を承諾済みにしてメンバーに追加する
+// 既にメンバーの場合はロールを招待のロールで上書きする。承諾済みの招待は gorm.ErrRecordNotFound
+func (r *WorkspaceRepositoryImpl) AcceptInvitation(invitation *model.WorkspaceInvitation, userID uint, at time.Time) (*model.WorkspaceMember, error) {
+	member := &model.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userID,
+		Role:        invitation.Role,
+	}
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.WorkspaceInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_at": at, "accepted_by": userID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+		}).Create(member).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+func (r *WorkspaceRepositoryImpl) DeleteInvitation(workspaceID, id uint) error {
+	result := r.DB.Where("workspace_id = ? AND id = ?", workspaceID, id).Delete(&model.WorkspaceInvitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *WorkspaceRepositoryImpl) FindUserEmail(userID uint) (string, error) {
+	var user model.User
+	if err := r.DB.Select("email").First(&user, userID).Error; err != nil {
+		return "", err
+	}
+	return user.Email, nil
+}
@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	stderrors "errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	dtoquery "github.com/godotask/dto/query"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+func setupWorkspaceTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to test DB: %v", err)
+	}
+	err = db.AutoMigrate(
+		&model.User{}, &model.Task{}, &model.Memory{}, &model.KnowledgePattern{},
+		&model.Workspace{}, &model.WorkspaceMember{}, &model.WorkspaceInvitation{},
+	)
+	if err != nil {
+		t.Fatalf("failed to migrate workspace models: %v", err)
+	}
+	return db
+}
+
+func TestWorkspace_SharedTaskAccess(t *testing.T) {
+	db := setupWorkspaceTestDB(t)
+	workspaces := &repository.WorkspaceRepositoryImpl{DB: db}
+	tasks := &repository.TaskRepositoryImpl{DB: db}
+	patterns := &repository.KnowledgePatternRepositoryImpl{DB: db}
+
+	// ユーザー 1 が owner、2 が editor、3 が viewer、4 は非メンバー
+	ws := &model.Workspace{Name: "Team"}
+	require.NoError(t, workspaces.Create(ws, 1))
+	require.NoError(t, db.Create(&model.WorkspaceMember{WorkspaceID: ws.ID, UserID: 2, Role: "editor"}).Error)
+	require.NoError(t, db.Create(&model.WorkspaceMember{WorkspaceID: ws.ID, UserID: 3, Role: "viewer"}).Error)
+
+	shared := &model.Task{UserID: 1, WorkspaceID: &ws.ID, Title: "Shared"}
+	require.NoError(t, tasks.Create(shared))
+	require.NoError(t, tasks.Create(&model.Task{UserID: 1, Title: "Private"}))
+	id := strconv.Itoa(shared.ID)
+
+	// 非メンバーはワークスペースにタスクを作成できない
+	err := tasks.Create(&model.Task{UserID: 4, WorkspaceID: &ws.ID, Title: "Intruder"})
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+
+	// 参照はメンバー全員、更新は owner / editor のみ
+	for _, userID := range []uint{2, 3} {
+		_, err := tasks.FindByID(userID, id)
+		assert.NoError(t, err, "user %d", userID)
+	}
+	_, err = tasks.FindByID(4, id)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceAccessDenied))
+	assert.NoError(t, tasks.Update(2, id, &model.Task{Title: "Edited"}))
+	assert.True(t, stderrors.Is(tasks.Update(3, id, &model.Task{Title: "Viewer"}), apperrors.ErrResourceAccessDenied))
+
+	// task_id 経由のテーブルもタスクのワークスペースに従う
+	require.NoError(t, patterns.Create(2, &model.KnowledgePattern{ID: "kp-1", TaskID: shared.ID}))
+	assert.True(t, stderrors.Is(patterns.Create(3, &model.KnowledgePattern{ID: "kp-2", TaskID: shared.ID}), apperrors.ErrResourceAccessDenied))
+	visible, err := patterns.FindAll(3)
+	require.NoError(t, err)
+	assert.Len(t, visible, 1)
+
+	// 一覧: メンバーは共有タスクのみ、owner は個人のタスクも含む
+	userID := uint(2)
+	list, total, err := tasks.ListTasksPager(dtoquery.QueryFilter{UserID: &userID}, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Edited", list[0].Title)
+
+	ownerID := uint(1)
+	_, total, err = tasks.ListTasksPager(dtoquery.QueryFilter{UserID: &ownerID}, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	_, total, err = tasks.ListTasksPager(dtoquery.QueryFilter{UserID: &ownerID, WorkspaceID: &ws.ID}, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	outsider := uint(4)
+	_, total, err = tasks.ListTasksPager(dtoquery.QueryFilter{UserID: &outsider}, 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func TestWorkspace_DeleteUnsharesRecords(t *testing.T) {
+	db := setupWorkspaceTestDB(t)
+	workspaces := &repository.WorkspaceRepositoryImpl{DB: db}
+
+	ws := &model.Workspace{Name: "Team"}
+	require.NoError(t, workspaces.Create(ws, 1))
+	memory := &model.Memory{UserID: 1, WorkspaceID: &ws.ID, Title: "Shared"}
+	require.NoError(t, db.Create(memory).Error)
+
+	require.NoError(t, workspaces.Delete(ws.ID))
+
+	var reloaded model.Memory
+	require.NoError(t, db.First(&reloaded, memory.ID).Error)
+	assert.Nil(t, reloaded.WorkspaceID)
+
+	var members int64
+	db.Model(&model.WorkspaceMember{}).Where("workspace_id = ?", ws.ID).Count(&members)
+	assert.Equal(t, int64(0), members)
+}
@@ -9,8 +9,13 @@ import (
 // Include + optional ID + Search に応じて WHERE を動的構築
 func WithDynamicFilters(q dtoquery.QueryFilter) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
+		// 所有者だけでなく、所属するワークスペースに共有されたレコードも対象にする
 		if q.UserID != nil && *q.UserID != 0 {
-			db = db.Where("user_id = ?", *q.UserID)
+			db = WithUserFilter(*q.UserID)(db)
+		}
+
+		if q.WorkspaceID != nil {
+			db = WithWorkspaceFilter(*q.WorkspaceID)(db)
 		}
 
 		if q.TaskID != nil {
@@ -4,16 +4,56 @@ import (
   "gorm.io/gorm"
 )
 
+// WithUserFilter は userID が参照できるレコードに絞り込む（userID が 0 の場合は絞り込まない）
+// workspace_id を持つテーブルでは、所属するワークスペースに共有されたレコードも含める
 func WithUserFilter(userID uint) func(db *gorm.DB) *gorm.DB {
   return func(db *gorm.DB) *gorm.DB {
     if userID == 0 {
       return db
     }
+    if hasWorkspaceColumn(db) {
+      return db.Where("(user_id = ? OR workspace_id IN (?))", userID, MemberWorkspaceIDs(db, userID))
+    }
     return db.Where("user_id = ?", userID)
   }
 }
 
+// WithWorkspaceFilter は指定したワークスペースのレコードに絞り込む
+// メンバーであるかの確認は WithUserFilter と組み合わせて行う
+func WithWorkspaceFilter(workspaceID uint) func(db *gorm.DB) *gorm.DB {
+  return func(db *gorm.DB) *gorm.DB {
+    if !hasWorkspaceColumn(db) {
+      return db
+    }
+    return db.Where("workspace_id = ?", workspaceID)
+  }
+}
+
+// MemberWorkspaceIDs は userID が所属するワークスペース ID のサブクエリを返す
+func MemberWorkspaceIDs(db *gorm.DB, userID uint) *gorm.DB {
+  return db.Session(&gorm.Session{NewDB: true}).
+    Table("workspace_members").
+    Select("workspace_id").
+    Where("user_id = ?", userID)
+}
+
+// hasWorkspaceColumn はクエリ対象のモデルが workspace_id を持つかを返す
+// スコープはモデルの解析前に実行されるため、必要であればここで解析する
+func hasWorkspaceColumn(db *gorm.DB) bool {
+  stmt := db.Statement
+  if stmt.Schema == nil {
+    target := stmt.Model
+    if target == nil {
+      target = stmt.Dest
+    }
+    if target == nil || stmt.Parse(target) != nil {
+      return false
+    }
+  }
+  return stmt.Schema.LookUpField("workspace_id") != nil
+}
+
 func BuildPaginationQuery(db *gorm.DB, userID uint, offset, limit int) (*gorm.DB, error) {
   q := db.Scopes(WithUserFilter(userID))
   return q, nil
-}
\ No newline at end of file
+}
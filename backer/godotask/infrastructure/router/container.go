@@ -2,11 +2,16 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/infrastructure/storage"
 	"github.com/godotask/interface/http/controller"
 )
 
 var (
 	router         *gin.Engine
 	authController *controller.AuthController
+	userRoleController *controller.UserRoleController
+	sessionController *controller.SessionController
 	authMiddleware gin.HandlerFunc
+	blobStore      storage.BlobStore
+	urlSigner      *storage.URLSigner
 )
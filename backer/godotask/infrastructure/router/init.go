@@ -1,10 +1,13 @@
 package router
 
 import (
+	"log"
+	"os"
 	"time"
 	"github.com/godotask/interface/http/controller"
 	"github.com/godotask/usecase"
 	"github.com/godotask/infrastructure/security"
+	"github.com/godotask/infrastructure/storage"
 	"github.com/godotask/infrastructure/db/repository"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/interface/http/middleware"
@@ -13,20 +16,55 @@ import (
 func Init() {
 	userRepo := repository.NewGormUserRepository(model.DB)
 	passwordSvc := security.NewBcryptPasswordService()
+	// アクセストークンは短命にし、リフレッシュトークンでローテーションする
+	// 有効期限は ACCESS_TOKEN_TTL / REFRESH_TOKEN_TTL（例: 15m, 720h）で変えられる
 	tokenSvc := security.NewJWTService(
 		[]byte("secret"),
-		24*time.Hour,
+		durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
 	)
+	sessionRepo := repository.NewGormSessionRepository(model.DB)
+	refreshSvc := security.NewRefreshTokenService()
 
 	authUsecase := usecase.NewAuthUsecase(
 		userRepo,
+		sessionRepo,
 		passwordSvc,
 		tokenSvc,
+		refreshSvc,
+		durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
 	)
 
 	authController = controller.NewAuthController(authUsecase)
 
-	authMiddleware = middleware.AuthMiddleware(tokenSvc)
+	userRoleUsecase := usecase.NewUserRoleUsecase(userRepo)
+	userRoleController = controller.NewUserRoleController(userRoleUsecase)
+
+	sessionUsecase := usecase.NewSessionUsecase(sessionRepo)
+	sessionController = controller.NewSessionController(sessionUsecase)
+
+	authMiddleware = middleware.AuthMiddleware(tokenSvc, sessionUsecase)
+
+	// 添付ファイルの保存先（STORAGE_BACKEND=local|s3）と署名付き URL の鍵
+	var err error
+	if blobStore, err = storage.NewFromEnv(); err != nil {
+		log.Fatalf("failed to initialize attachment storage: %v", err)
+	}
+	if urlSigner, err = storage.NewURLSignerFromEnv(); err != nil {
+		log.Fatalf("failed to initialize attachment url signer: %v", err)
+	}
 
 	router = setupRouter()
 }
+
+// durationFromEnv は環境変数の期間（time.ParseDuration の書式）を読む。未設定なら def
+func durationFromEnv(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Fatalf("invalid %s: %q", name, v)
+	}
+	return d
+}
@@ -2,8 +2,11 @@ package router
 
 import (
 	"github.com/godotask/interface/http/controller"
+	"github.com/godotask/interface/controller/attachment"
 	"github.com/godotask/interface/controller/book"
 	"github.com/godotask/interface/controller/memory"
+	"github.com/godotask/interface/controller/multimodal"
+	"github.com/godotask/interface/controller/visual_metaphor"
 	"github.com/godotask/interface/controller/task"
 	"github.com/godotask/interface/controller/assessment"
 	"github.com/godotask/interface/controller/heuristics/analyze"
@@ -11,12 +14,27 @@ import (
 	"github.com/godotask/interface/controller/heuristics/modeler"
 	"github.com/godotask/interface/controller/heuristics/pattern"
 	"github.com/godotask/interface/controller/process_optimization"
+	"github.com/godotask/interface/controller/optimization_model"
 	"github.com/godotask/interface/controller/qualitative_label"
+	"github.com/godotask/interface/controller/quantification_label"
+	"github.com/godotask/interface/controller/label_dataset"
+	"github.com/godotask/interface/controller/label_ontology"
 	"github.com/godotask/interface/controller/knowledge_pattern"
 	"github.com/godotask/interface/controller/language_optimization"
 	"github.com/godotask/interface/controller/teaching_free_control"
 	"github.com/godotask/interface/controller/phenomenological_framework"
+	"github.com/godotask/interface/controller/audit_log"
+	"github.com/godotask/interface/controller/workspace"
+	"github.com/godotask/interface/controller/state_evaluation"
+	"github.com/godotask/interface/controller/robot"
+	"github.com/godotask/interface/controller/tool_matching"
+	"github.com/godotask/interface/controller/process_monitoring"
+	"github.com/godotask/interface/controller/spc"
+	"github.com/godotask/interface/controller/data_import"
+	"github.com/godotask/interface/controller/account_export"
+	"github.com/godotask/interface/http/middleware"
 	"github.com/godotask/usecase/service"
+	"github.com/godotask/domain/authz"
 	"github.com/godotask/infrastructure/db/repository"
 	"github.com/godotask/infrastructure/db/model"
 	// "github.com/godotask/middleware" // 一時的にコメントアウト
@@ -37,9 +55,21 @@ func setupRouter() *gin.Engine {
 
 	r.Use(static.Serve("/usr/local/go/godotask/static", static.LocalFile("./images", true)))
 
+	attachmentRepo := &repository.AttachmentRepositoryImpl{DB: model.DB}
+	attachmentService := &service.AttachmentService{Repo: attachmentRepo, Blobs: blobStore, Signer: urlSigner}
+	attachmentController := attachment.AttachmentController{Service: attachmentService}
+
+	visualMetaphorRepo := &repository.VisualMetaphorRepositoryImpl{DB: model.DB}
+	visualMetaphorService := &service.VisualMetaphorService{Repo: visualMetaphorRepo}
+	visualMetaphorController := visual_metaphor.VisualMetaphorController{Service: visualMetaphorService}
+
+	multimodalRepo := &repository.MultimodalRepositoryImpl{DB: model.DB}
+	multimodalService := &service.MultimodalService{Repo: multimodalRepo, Attachments: attachmentService, References: visualMetaphorRepo}
+	multimodalController := multimodal.MultimodalController{Service: multimodalService}
+
   bookRepo := &repository.BookRepositoryImpl{DB: model.DB}
 	bookService := &service.BookService{Repo: bookRepo}
-	bookController := book.BookController{Service: bookService}
+	bookController := book.BookController{Service: bookService, Attachments: attachmentService}
 
 	memoryRepo := &repository.MemoryRepositoryImpl{DB: model.DB}
 	memoryContextRepo := &repository.MemoryContextRepositoryImpl{DB: model.DB}
@@ -51,7 +81,9 @@ func setupRouter() *gin.Engine {
 
 	taskRepo := &repository.TaskRepositoryImpl{DB: model.DB}
 	taskService := &service.TaskService{Repo: taskRepo}
-	taskController := task.TaskController{Service: taskService}
+	knowledgeEntityRepo := &repository.KnowledgeEntityRepositoryImpl{DB: model.DB}
+	knowledgeEntityService := &service.KnowledgeEntityService{Repo: knowledgeEntityRepo}
+	taskController := task.TaskController{Service: taskService, KnowledgeEntityService: knowledgeEntityService}
 
   assessmentRepo := &repository.AssessmentRepositoryImpl{DB: model.DB}
 	assessmentService := &service.AssessmentService{Repo: assessmentRepo}
@@ -77,10 +109,36 @@ func setupRouter() *gin.Engine {
 	processOptimizationService := &service.ProcessOptimizationService{Repo: processOptimizationRepo}
 	processOptimizationController := process_optimization.ProcessOptimizationController{Service: processOptimizationService}
 
+	optimizationModelService := &service.OptimizationModelService{
+		Repo:          &repository.OptimizationModelRepositoryImpl{DB: model.DB},
+		Optimizations: processOptimizationRepo,
+	}
+	optimizationModelController := optimization_model.OptimizationModelController{Service: optimizationModelService}
+
 	qualitativeLabelRepo := &repository.QualitativeLabelRepositoryImpl{DB: model.DB}
 	qualitativeLabelService := &service.QualitativeLabelService{Repo: qualitativeLabelRepo}
 	qualitativeLabelController := qualitative_label.QualitativeLabelController{Service: qualitativeLabelService}
 
+	labelOntologyRepo := &repository.LabelOntologyRepositoryImpl{DB: model.DB}
+	labelOntologyService := &service.LabelOntologyService{Repo: labelOntologyRepo}
+	labelOntologyController := label_ontology.LabelOntologyController{Service: labelOntologyService}
+
+	quantificationLabelRepo := &repository.QuantificationLabelRepositoryImpl{DB: model.DB}
+	quantificationLabelService := &service.QuantificationLabelService{Repo: quantificationLabelRepo, Ontology: labelOntologyService}
+	quantificationLabelController := quantification_label.QuantificationLabelController{Service: quantificationLabelService}
+
+	importRepo := &repository.ImportRepositoryImpl{DB: model.DB}
+	importService := &service.ImportService{Repo: importRepo}
+	dataImportController := data_import.DataImportController{Service: importService}
+
+	accountDataRepo := &repository.AccountDataRepositoryImpl{DB: model.DB}
+	accountExportService := &service.AccountExportService{Repo: accountDataRepo}
+	accountExportController := account_export.AccountExportController{Service: accountExportService}
+
+	labelDatasetRepo := &repository.LabelDatasetRepositoryImpl{DB: model.DB}
+	labelDatasetService := &service.LabelDatasetService{Repo: labelDatasetRepo}
+	labelDatasetController := label_dataset.LabelDatasetController{Service: labelDatasetService}
+
 	knowledgePatternRepo := &repository.KnowledgePatternRepositoryImpl{DB: model.DB}
 	knowledgePatternService := &service.KnowledgePatternService{Repo: knowledgePatternRepo}
 	knowledgePatternController := knowledge_pattern.KnowledgePatternController{Service: knowledgePatternService}
@@ -97,25 +155,72 @@ func setupRouter() *gin.Engine {
 	phenomenologicalFrameworkService := &service.PhenomenologicalFrameworkService{Repo: phenomenologicalFrameworkRepo}
 	phenomenologicalFrameworkController := phenomenological_framework.PhenomenologicalFrameworkController{Service: phenomenologicalFrameworkService}
 
+	auditLogRepo := &repository.AuditLogRepositoryImpl{DB: model.DB}
+	auditLogService := &service.AuditLogService{Repo: auditLogRepo}
+	auditLogController := audit_log.AuditLogController{Service: auditLogService}
+
+	stateEvaluationRepo := &repository.StateEvaluationRepositoryImpl{DB: model.DB}
+	stateEvaluationService := &service.StateEvaluationService{Repo: stateEvaluationRepo}
+	stateEvaluationController := state_evaluation.StateEvaluationController{Service: stateEvaluationService}
+
+	robotRepo := &repository.RobotSpecificationRepositoryImpl{DB: model.DB}
+	robotService := &service.RobotSpecificationService{Repo: robotRepo}
+	robotController := robot.RobotController{Service: robotService}
+
+	toolMatchingService := &service.ToolMatchingService{
+		Robots:      robotRepo,
+		Evaluations: stateEvaluationRepo,
+		Results:     &repository.ToolMatchingResultRepositoryImpl{DB: model.DB},
+	}
+	toolMatchingController := tool_matching.ToolMatchingController{Service: toolMatchingService}
+
+	processMonitoringRepo := &repository.ProcessMonitoringRepositoryImpl{DB: model.DB}
+	processMonitoringService := &service.ProcessMonitoringService{Repo: processMonitoringRepo}
+	processMonitoringController := process_monitoring.ProcessMonitoringController{Service: processMonitoringService}
+
+	spcRepo := &repository.SPCRepositoryImpl{DB: model.DB}
+	spcService := &service.SPCService{Repo: spcRepo}
+	spcController := spc.SPCController{Service: spcService}
+
+	workspaceRepo := &repository.WorkspaceRepositoryImpl{DB: model.DB}
+	workspaceService := &service.WorkspaceService{Repo: workspaceRepo}
+	workspaceController := workspace.WorkspaceController{Service: workspaceService}
+
+	resourceRepo := &repository.ResourceRepositoryImpl{DB: model.DB}
+	authorizationService := &service.AuthorizationService{Repo: resourceRepo}
+
 	// 認証不要のエンドポイント
 	public := r.Group("/api")
 	{
 		public.POST("/login", authController.Login)
 		public.POST("/register", authController.Register)
 		public.POST("/logout", authController.Logout)
+		public.POST("/refresh", authController.Refresh)
+		// 署名付き URL で認証する（Authorization ヘッダを付けられないリンクやタグから使う）
+		public.GET("/attachment_download/:id", attachmentController.DownloadAttachment)
 	}
 
-	// Book API (CRUD)
-	r.GET("/book", bookController.ListBooks)
-	r.POST("/file", book.HundleUplond)
-	r.POST("/book", bookController.AddBook)
-	r.DELETE("/deletebook/:id", bookController.DeleteBook)
-	r.PUT("/updatebook/:id", bookController.EditBook)
+	// セッション管理は自分のセッションのみを扱うため、ロールに関係なく利用できる
+	sessions := r.Group("/api/sessions")
+	sessions.Use(authMiddleware, middleware.AuditMiddleware(auditLogService))
+	{
+		sessions.GET("", sessionController.ListSessions)
+		sessions.DELETE("", sessionController.DeleteSessions)
+		sessions.DELETE("/:id", sessionController.DeleteSession)
+	}
 
 	// ===== 認証必須のエンドポイント =====
 	// AuthMiddleware をこのグループに一括適用
+	// 変更系リクエストは AuditMiddleware で監査ログに記録する（認可で拒否されたものも含む）
+	// ロールによるメソッド単位の認可と、:id を持つ変更系ルートの所有者確認を行う
+	protectedMiddleware := []gin.HandlerFunc{
+		authMiddleware,
+		middleware.AuditMiddleware(auditLogService),
+		middleware.RequireMethodPermission(),
+		middleware.AuthorizeResourceOwner(authorizationService),
+	}
 	protected := r.Group("/api")
-	protected.Use(authMiddleware)
+	protected.Use(protectedMiddleware...)
 	{
 		// Task API (CRUD)
 		protected.POST("/task", taskController.AddTask)
@@ -127,6 +232,40 @@ func setupRouter() *gin.Engine {
 		protected.PUT("/task/:id", taskController.EditTask)
 		protected.DELETE("/task/:id", taskController.DeleteTask)
 
+		// Book API (CRUD)
+		protected.POST("/book", bookController.AddBook)
+		protected.GET("/book", bookController.ListBooks)
+		protected.PUT("/book/:id", bookController.EditBook)
+		protected.DELETE("/book/:id", bookController.DeleteBook)
+		protected.POST("/book/:id/image", bookController.UploadBookImage)
+
+		// Attachment API（任意のリソースへの添付ファイル）
+		protected.POST("/attachment", attachmentController.AddAttachment)
+		protected.GET("/attachment", attachmentController.ListAttachments)
+		protected.GET("/attachment/:id", attachmentController.GetAttachment)
+		protected.DELETE("/attachment/:id", attachmentController.DeleteAttachment)
+
+		// Multimodal API（画像の解析とアノテーション）
+		protected.POST("/multimodal_data", multimodalController.UploadMultimodalImage)
+		protected.GET("/multimodal_data", multimodalController.ListMultimodalData)
+		protected.GET("/multimodal_data/:id", multimodalController.GetMultimodalData)
+		protected.DELETE("/multimodal_data/:id", multimodalController.DeleteMultimodalData)
+		protected.GET("/multimodal_data/:id/similar", multimodalController.FindSimilarImages)
+		protected.POST("/multimodal_data/:id/annotation", multimodalController.AddImageAnnotation)
+		protected.GET("/multimodal_data/:id/annotation", multimodalController.ListImageAnnotations)
+		protected.PUT("/image_annotation/:id", multimodalController.EditImageAnnotation)
+		protected.DELETE("/image_annotation/:id", multimodalController.DeleteImageAnnotation)
+		protected.POST("/multimodal_data/:id/calibration", multimodalController.CalibrateImage)
+		protected.GET("/multimodal_data/:id/calibration", multimodalController.ListCalibrations)
+		protected.DELETE("/calibration/:id", multimodalController.DeleteCalibration)
+
+		// 校正の基準物のカタログ（全ユーザー共通、更新は catalog:manage 権限が必要）
+		protected.GET("/visual_metaphor", visualMetaphorController.ListVisualMetaphors)
+		protected.GET("/visual_metaphor/:id", visualMetaphorController.GetVisualMetaphor)
+		protected.POST("/visual_metaphor", middleware.RequirePermission(authz.PermCatalogManage), visualMetaphorController.AddVisualMetaphor)
+		protected.PUT("/visual_metaphor/:id", middleware.RequirePermission(authz.PermCatalogManage), visualMetaphorController.EditVisualMetaphor)
+		protected.DELETE("/visual_metaphor/:id", middleware.RequirePermission(authz.PermCatalogManage), visualMetaphorController.DeleteVisualMetaphor)
+
 		// User profile
 		protected.GET("/user/profile", controller.Profile)
 
@@ -180,6 +319,33 @@ func setupRouter() *gin.Engine {
 		protected.GET("/phenomenological_framework/:id", phenomenologicalFrameworkController.GetPhenomenologicalFramework)
 		protected.PUT("/phenomenological_framework/:id", phenomenologicalFrameworkController.EditPhenomenologicalFramework)
 		protected.DELETE("/phenomenological_framework/:id", phenomenologicalFrameworkController.DeletePhenomenologicalFramework)
+		protected.POST("/phenomenological_framework/:id/evaluate", phenomenologicalFrameworkController.EvaluatePhenomenologicalFramework)
+
+		protected.POST("/state_evaluation", stateEvaluationController.AddStateEvaluation)
+		protected.GET("/state_evaluation", stateEvaluationController.ListStateEvaluations)
+		protected.GET("/state_evaluation/:id", stateEvaluationController.GetStateEvaluation)
+		protected.GET("/state_evaluation_history", stateEvaluationController.GetStateEvaluationHistory)
+
+		// ロボット仕様は全ユーザー共通のカタログのため、変更は管理者のみ
+		protected.GET("/robot", robotController.ListRobots)
+		protected.GET("/robot/:id", robotController.GetRobot)
+		protected.POST("/robot", middleware.RequirePermission(authz.PermCatalogManage), robotController.AddRobot)
+		protected.PUT("/robot/:id", middleware.RequirePermission(authz.PermCatalogManage), robotController.EditRobot)
+		protected.DELETE("/robot/:id", middleware.RequirePermission(authz.PermCatalogManage), robotController.DeleteRobot)
+
+		protected.POST("/tool_matching", toolMatchingController.AddToolMatching)
+		protected.GET("/tool_matching", toolMatchingController.ListToolMatchingResults)
+
+		protected.POST("/process_monitoring", processMonitoringController.AddProcessMonitoring)
+		protected.GET("/process_monitoring", processMonitoringController.ListProcessMonitorings)
+		protected.GET("/process_monitoring/:id", processMonitoringController.GetProcessMonitoring)
+		protected.POST("/process_monitoring/:id/samples", processMonitoringController.AddSamples)
+		protected.GET("/process_monitoring/:id/samples", processMonitoringController.ListSamples)
+		protected.POST("/process_monitoring/:id/stop", processMonitoringController.StopProcessMonitoring)
+
+		protected.POST("/spc/measurement", spcController.AddMeasurements)
+		protected.GET("/spc/measurement", spcController.ListMeasurements)
+		protected.GET("/spc/chart", spcController.GetChart)
 
 		// Process Optimization API (CRUD)
 		protected.POST("/process_optimization", processOptimizationController.AddProcessOptimization)
@@ -187,6 +353,15 @@ func setupRouter() *gin.Engine {
 		protected.GET("/process_optimization/:id", processOptimizationController.GetProcessOptimization)
 		protected.PUT("/process_optimization/:id", processOptimizationController.EditProcessOptimization)
 		protected.DELETE("/process_optimization/:id", processOptimizationController.DeleteProcessOptimization)
+		protected.POST("/process_optimization/:id/validate", processOptimizationController.ValidateProcessOptimization)
+		protected.GET("/process_optimization_summary", processOptimizationController.GetProcessOptimizationSummary)
+
+		protected.GET("/optimization_model", optimizationModelController.ListOptimizationModels)
+		protected.GET("/optimization_model/:id", optimizationModelController.GetOptimizationModel)
+		protected.POST("/optimization_model", middleware.RequirePermission(authz.PermCatalogManage), optimizationModelController.AddOptimizationModel)
+		protected.PUT("/optimization_model/:id", middleware.RequirePermission(authz.PermCatalogManage), optimizationModelController.EditOptimizationModel)
+		protected.DELETE("/optimization_model/:id", middleware.RequirePermission(authz.PermCatalogManage), optimizationModelController.DeleteOptimizationModel)
+		protected.POST("/optimization_model/:id/run", optimizationModelController.RunOptimizationModel)
 
 		// Qualitative Label API (CRUD)
 		protected.POST("/qualitative_label", qualitativeLabelController.AddQualitativeLabel)
@@ -195,6 +370,48 @@ func setupRouter() *gin.Engine {
 		protected.PUT("/qualitative_label/:id", qualitativeLabelController.EditQualitativeLabel)
 		protected.DELETE("/qualitative_label/:id", qualitativeLabelController.DeleteQualitativeLabel)
 
+		// 定量化ラベル（曖昧な語句と単位付きの値の対応）
+		protected.POST("/quantification_label", quantificationLabelController.AddQuantificationLabel)
+		protected.GET("/quantification_label", quantificationLabelController.ListQuantificationLabels)
+		protected.GET("/quantification_label/:id", quantificationLabelController.GetQuantificationLabel)
+		protected.PUT("/quantification_label/:id", quantificationLabelController.EditQuantificationLabel)
+		protected.DELETE("/quantification_label/:id", quantificationLabelController.DeleteQuantificationLabel)
+		protected.GET("/quantification_label/:id/revision", quantificationLabelController.ListLabelRevisions)
+		protected.GET("/quantification_label_statistics", quantificationLabelController.GetLabelStatistics)
+		protected.GET("/quantification_label_suggestion", quantificationLabelController.SuggestLabelValues)
+
+		// ラベルの検証（提出 → 検証 → 採用・差し戻し、検証は label:verify 権限が必要）
+		protected.POST("/quantification_label/:id/submit", quantificationLabelController.SubmitQuantificationLabel)
+		protected.POST("/quantification_label/:id/verification", middleware.RequirePermission(authz.PermLabelVerify), quantificationLabelController.VerifyQuantificationLabel)
+		protected.GET("/quantification_label/:id/verification", quantificationLabelController.ListLabelVerifications)
+		protected.GET("/label_review_queue", middleware.RequirePermission(authz.PermLabelVerify), quantificationLabelController.ListReviewQueue)
+
+		// ラベルのデータセットと版付きのスナップショット（JSONL）
+		protected.POST("/label_dataset", labelDatasetController.AddLabelDataset)
+		protected.GET("/label_dataset", labelDatasetController.ListLabelDatasets)
+		protected.GET("/label_dataset/:id", labelDatasetController.GetLabelDataset)
+		protected.PUT("/label_dataset/:id", labelDatasetController.EditLabelDataset)
+		protected.DELETE("/label_dataset/:id", labelDatasetController.DeleteLabelDataset)
+		protected.POST("/label_dataset/:id/snapshot", labelDatasetController.AddSnapshot)
+		protected.GET("/label_dataset/:id/snapshot", labelDatasetController.ListSnapshots)
+		protected.GET("/label_dataset/:id/snapshot/:version", labelDatasetController.DownloadSnapshot)
+
+		// ラベルのオントロジー（同義語・上位下位の関係、検索語の展開、語句の正規化）
+		protected.POST("/label_relation", labelOntologyController.AddLabelRelation)
+		protected.DELETE("/label_relation/:id", labelOntologyController.DeleteLabelRelation)
+		protected.GET("/quantification_label/:id/relation", labelOntologyController.ListLabelRelations)
+		protected.GET("/quantification_label/:id/ancestor", labelOntologyController.ListLabelAncestors)
+		protected.GET("/quantification_label/:id/descendant", labelOntologyController.ListLabelDescendants)
+		protected.GET("/label_query_expansion", labelOntologyController.ExpandLabelQuery)
+		protected.POST("/quantification_label_normalization", labelOntologyController.NormalizeLabels)
+
+		// 一括インポート（シードと同じ列の CSV / JSON、dry_run / commit）
+		protected.POST("/import/:resource", dataImportController.ImportData)
+
+		// アカウントのエクスポート・インポート（zip、ID を付け替えて復元）
+		protected.GET("/export", accountExportController.ExportAccount)
+		protected.POST("/import", accountExportController.ImportAccount)
+
 		// Knowledge Pattern API (CRUD)
 		protected.POST("/knowledge_pattern", knowledgePatternController.AddKnowledgePattern)
 		protected.GET("/knowledge_pattern", knowledgePatternController.ListKnowledgePatterns)
@@ -215,6 +432,48 @@ func setupRouter() *gin.Engine {
 		protected.GET("/teaching_free_control/:id", TeachingFreeControlController.GetTeachingFreeControl)
 		protected.PUT("/teaching_free_control/:id", TeachingFreeControlController.EditTeachingFreeControl)
 		protected.DELETE("/teaching_free_control/:id", TeachingFreeControlController.DeleteTeachingFreeControl)
+		protected.POST("/teaching_free_control/:id/attempt", TeachingFreeControlController.RecordAttempts)
+		protected.GET("/teaching_free_control/:id/attempt", TeachingFreeControlController.ListAttempts)
+		protected.GET("/teaching_free_control/:id/analytics", TeachingFreeControlController.GetAnalytics)
+		protected.POST("/teaching_free_control/:id/simulate", TeachingFreeControlController.Simulate)
+		protected.GET("/teaching_free_control_learning_curve", TeachingFreeControlController.GetLearningCurve)
+
+		// Workspace API (メンバー・招待の管理は owner のみ)
+		protected.POST("/workspace", workspaceController.AddWorkspace)
+		protected.GET("/workspace", workspaceController.ListWorkspaces)
+		protected.GET("/workspace/:id", workspaceController.GetWorkspace)
+		protected.PUT("/workspace/:id", workspaceController.EditWorkspace)
+		protected.DELETE("/workspace/:id", workspaceController.DeleteWorkspace)
+		protected.PUT("/workspace/:id/members/:user_id", workspaceController.EditMemberRole)
+		protected.DELETE("/workspace/:id/members/:user_id", workspaceController.DeleteMember)
+		protected.POST("/workspace/:id/invitations", workspaceController.AddInvitation)
+		protected.GET("/workspace/:id/invitations", workspaceController.ListInvitations)
+		protected.DELETE("/workspace/:id/invitations/:invitation_id", workspaceController.DeleteInvitation)
+		protected.POST("/workspace_invitation/accept", workspaceController.AcceptInvitation)
+
+		// Admin API
+		admin := protected.Group("/admin")
+		{
+			// Audit Log API (参照・エクスポートのみ)
+			admin.GET("/audit_logs", middleware.RequirePermission(authz.PermAuditRead), auditLogController.ListAuditLogs)
+			admin.GET("/audit_logs/export", middleware.RequirePermission(authz.PermAuditRead), auditLogController.ExportAuditLogs)
+
+			// User Role API
+			admin.GET("/users", middleware.RequirePermission(authz.PermUserManage), userRoleController.ListUsers)
+			admin.PUT("/users/:id/role", middleware.RequirePermission(authz.PermUserManage), userRoleController.UpdateUserRole)
+		}
+	}
+
+	// 旧ルート（/api なし）。既存のクライアントのために残し、/api と同じ認証・認可を適用する
+	// 新しいクライアントは /api/book, /api/book/:id, /api/book/:id/image を使う
+	legacy := r.Group("")
+	legacy.Use(protectedMiddleware...)
+	{
+		legacy.GET("/book", bookController.ListBooks)
+		legacy.POST("/book", bookController.AddBook)
+		legacy.PUT("/updatebook/:id", bookController.EditBook)
+		legacy.DELETE("/deletebook/:id", bookController.DeleteBook)
+		legacy.POST("/file", bookController.HundleUplond)
 	}
 
 	// 404ハンドラー（一時的にコメントアウト）
@@ -23,11 +23,13 @@ func NewJWTService(secret []byte, exp time.Duration) auth.TokenService {
 	}
 }
 
-func (s *JWTService) Generate(user *entity.User) (string, error) {
+func (s *JWTService) Generate(user *entity.User, sessionID uint) (string, error) {
 	claims := entity.AuthClaims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -0,0 +1,34 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/godotask/domain/auth"
+)
+
+// refreshTokenBytes はリフレッシュトークンの乱数部の長さ
+const refreshTokenBytes = 32
+
+type OpaqueRefreshTokenService struct{}
+
+func NewRefreshTokenService() auth.RefreshTokenService {
+	return &OpaqueRefreshTokenService{}
+}
+
+func (s *OpaqueRefreshTokenService) Generate() (string, string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, s.Hash(token), nil
+}
+
+// Hash はトークンを DB 保存用の SHA-256（16進）に変換する
+func (s *OpaqueRefreshTokenService) Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config は S3 互換ストレージの接続設定
+// ForcePathStyle の場合は {Endpoint}/{Bucket}/{key}、それ以外は {Bucket}.{Endpoint のホスト}/{key} にアクセスする
+type S3Config struct {
+	Endpoint       string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	ForcePathStyle bool
+}
+
+// S3Store は S3 互換 API（AWS S3, MinIO）にブロブを保存する。リクエストは署名バージョン 4 で署名する
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	Client   *http.Client
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("s3: endpoint, bucket, access key and secret key are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3: invalid endpoint %q", cfg.Endpoint)
+	}
+	return &S3Store{cfg: cfg, endpoint: endpoint, Client: &http.Client{Timeout: 5 * time.Minute}}, nil
+}
+
+func (s *S3Store) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *S3Store) objectURL(digest string) (*url.URL, error) {
+	key, err := Key(digest)
+	if err != nil {
+		return nil, err
+	}
+	u := *s.endpoint
+	if s.cfg.ForcePathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	return &u, nil
+}
+
+// do は digest のオブジェクトへのリクエストを署名して送る
+// payloadHash は本文の SHA-256（本文が無い場合は空文字列の SHA-256）
+func (s *S3Store) do(ctx context.Context, method, digest string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	u, err := s.objectURL(digest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	signV4(req, payloadHash, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, "s3", s.now())
+	return s.Client.Do(req)
+}
+
+// Put は内容が digest と一致することをストレージ側で検証させる（x-amz-content-sha256）
+func (s *S3Store) Put(ctx context.Context, digest string, r io.Reader, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, digest, r, size, digest)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func (s *S3Store) Open(ctx context.Context, digest string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, digest, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Store) Exists(ctx context.Context, digest string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, digest, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	return true, checkResponse(resp)
+}
+
+// Delete は無いオブジェクトの削除もエラーにしない（S3 は 204 を返す）
+func (s *S3Store) Delete(ctx context.Context, digest string) error {
+	resp, err := s.do(ctx, http.MethodDelete, digest, nil, 0, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return checkResponse(resp)
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3: %s %s: %s", resp.Request.Method, resp.Status, strings.TrimSpace(string(msg)))
+}
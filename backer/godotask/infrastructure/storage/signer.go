@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrSignatureInvalid = errors.New("invalid signature")
+	ErrSignatureExpired = errors.New("signature has expired")
+)
+
+// URLSigner はダウンロード URL に有効期限付きの HMAC 署名を付ける
+type URLSigner struct {
+	Key []byte
+}
+
+// NewURLSignerFromEnv は ATTACHMENT_SIGNING_KEY から署名鍵を読む
+// 未設定の場合はランダムな鍵を使うため、再起動すると発行済みの URL は無効になる
+func NewURLSignerFromEnv() (*URLSigner, error) {
+	if key := os.Getenv("ATTACHMENT_SIGNING_KEY"); key != "" {
+		return &URLSigner{Key: []byte(key)}, nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &URLSigner{Key: key}, nil
+}
+
+// Sign は id と有効期限（Unix 秒）に対する署名を返す
+func (s *URLSigner) Sign(id string, expires int64) string {
+	h := hmac.New(sha256.New, s.Key)
+	h.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify は署名と有効期限を確認する
+func (s *URLSigner) Verify(id string, expires int64, signature string, now time.Time) error {
+	if !hmac.Equal([]byte(s.Sign(id, expires)), []byte(signature)) {
+		return ErrSignatureInvalid
+	}
+	if now.Unix() > expires {
+		return ErrSignatureExpired
+	}
+	return nil
+}
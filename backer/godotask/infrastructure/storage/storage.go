@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ErrNotFound は指定のダイジェストのブロブが無い場合のエラー
+var ErrNotFound = errors.New("blob not found")
+
+// ErrTooLarge は Spool の上限を超えた場合のエラー
+var ErrTooLarge = errors.New("content is too large")
+
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// BlobStore は内容の SHA-256（16進小文字）をキーにしてファイルを保存する
+// 同じ内容は同じキーになるため、Put は何度呼んでもよい
+type BlobStore interface {
+	Put(ctx context.Context, digest string, r io.Reader, size int64) error
+	Open(ctx context.Context, digest string) (io.ReadCloser, error)
+	Exists(ctx context.Context, digest string) (bool, error)
+	Delete(ctx context.Context, digest string) error
+}
+
+// Key はダイジェストからバックエンド上のパスを作る（例: "ab/cd/abcd..."）
+func Key(digest string) (string, error) {
+	if !digestPattern.MatchString(digest) {
+		return "", fmt.Errorf("invalid digest %q", digest)
+	}
+	return digest[:2] + "/" + digest[2:4] + "/" + digest, nil
+}
+
+// Spooled は一時ファイルに書き出した内容とそのダイジェスト
+type Spooled struct {
+	File   *os.File
+	Digest string
+	Size   int64
+	// ContentType は先頭 512 バイトから判定した MIME タイプ
+	ContentType string
+}
+
+// Spool は r を一時ファイルに書き出しながら SHA-256 を計算する。limit バイトを超えると ErrTooLarge
+// 呼び出し側は Close で一時ファイルを削除する
+func Spool(r io.Reader, limit int64) (*Spooled, error) {
+	f, err := os.CreateTemp("", "blob-*")
+	if err != nil {
+		return nil, err
+	}
+	s := &Spooled{File: f}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(r, limit+1))
+	if err == nil && n > limit {
+		err = ErrTooLarge
+	}
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.Digest = hex.EncodeToString(h.Sum(nil))
+	s.Size = n
+
+	head := make([]byte, 512)
+	m, _ := f.ReadAt(head, 0)
+	s.ContentType = http.DetectContentType(head[:m])
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reader は先頭から読み直せる Reader を返す
+func (s *Spooled) Reader() (io.Reader, error) {
+	if _, err := s.File.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return s.File, nil
+}
+
+func (s *Spooled) Close() error {
+	name := s.File.Name()
+	s.File.Close()
+	return os.Remove(name)
+}
+
+// NewFromEnv は環境変数からバックエンドを作る
+//
+//   - STORAGE_BACKEND: local（既定）または s3
+//   - STORAGE_LOCAL_ROOT: local の保存先（既定 ./static/attachments）
+//   - S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY, S3_FORCE_PATH_STYLE:
+//     S3 互換ストレージ（MinIO など）の接続先
+func NewFromEnv() (BlobStore, error) {
+	switch backend := strings.ToLower(os.Getenv("STORAGE_BACKEND")); backend {
+	case "", "local":
+		root := os.Getenv("STORAGE_LOCAL_ROOT")
+		if root == "" {
+			root = "./static/attachments"
+		}
+		return NewLocalStore(root)
+	case "s3":
+		return NewS3Store(S3Config{
+			Endpoint:       os.Getenv("S3_ENDPOINT"),
+			Region:         os.Getenv("S3_REGION"),
+			Bucket:         os.Getenv("S3_BUCKET"),
+			AccessKey:      os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:      os.Getenv("S3_SECRET_KEY"),
+			ForcePathStyle: os.Getenv("S3_FORCE_PATH_STYLE") != "false",
+		})
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
@@ -0,0 +1,7 @@
+package account_export
+
+import "github.com/godotask/usecase/service"
+
+type AccountExportController struct {
+	Service *service.AccountExportService
+}
@@ -0,0 +1,275 @@
+package account_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+type MockAccountDataRepository struct {
+	Data     *model.AccountData
+	Restored *model.AccountData
+	UserID   uint
+	// FoundUserID は FindAccountData に渡されたユーザー ID
+	FoundUserID uint
+}
+
+func (m *MockAccountDataRepository) FindAccountData(userID uint) (*model.AccountData, error) {
+	m.FoundUserID = userID
+	return m.Data, nil
+}
+
+func (m *MockAccountDataRepository) RestoreAccountData(userID uint, data *model.AccountData) (*model.AccountImportResult, error) {
+	m.Restored, m.UserID = data, userID
+	return &model.AccountImportResult{Imported: map[string]int{"tasks": len(data.Tasks)}, Skipped: []model.AccountImportSkip{}}, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+var now = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func newData() *model.AccountData {
+	date := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	return &model.AccountData{
+		Tasks:              []model.Task{{ID: 3, UserID: 1, MemoryID: 2, Title: "Deburring, \"edge\"", Description: "line1\nline2", Date: &date, Status: "todo", Priority: 2, CreatedAt: now, UpdatedAt: now}},
+		Memories:           []model.Memory{{ID: 2, UserID: 1, Title: "Handbook", SourceType: "book", ReadStatus: "unread", CreatedAt: now, UpdatedAt: now}},
+		MemoryContexts:     []model.MemoryContext{{ID: 4, UserID: 1, TaskID: 3, Goal: "Ra 0.8", CreatedAt: now}},
+		HeuristicsInsights: []model.HeuristicsInsight{{ID: 5, UserID: 1, TaskID: 3, Data: `{"k":1}`, IsActive: true, CreatedAt: now, UpdatedAt: now}},
+		KnowledgePatterns:  []model.KnowledgePattern{{ID: "kp", TaskID: 3, TacitKnowledge: "angle", ConversionPath: model.JSON{"stages": []interface{}{"socialization"}}, Accuracy: 0.5, CreatedAt: now, UpdatedAt: now}},
+		QuantificationLabels: []model.QuantificationLabel{{
+			ID: "burr", UserID: 1, OriginalText: "バリ", NormalizedText: "バリ", Value: ptr(0.2), Unit: ptr("mm"),
+			Tags: datatypes.JSON(`["edge"]`), PublicVisibility: ptr(true), Status: "accepted", Validated: true, Version: 2, CreatedAt: now, UpdatedAt: now,
+		}},
+		QualitativeLabels: []model.QualitativeLabel{{ID: 6, TaskID: 3, UserID: 1, Content: "rough", CreatedAt: now, UpdatedAt: now}},
+	}
+}
+
+func setupRouter(repo *MockAccountDataRepository, userID uint) *gin.Engine {
+	return setupRouterAs(repo, userID, "editor")
+}
+
+func setupRouterAs(repo *MockAccountDataRepository, userID uint, role string) *gin.Engine {
+	return setupRouterWith(repo, userID, role, &service.AccountExportService{Repo: repo})
+}
+
+func setupRouterWith(repo *MockAccountDataRepository, userID uint, role string, svc *service.AccountExportService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	svc.Now = func() time.Time { return now }
+	ctl := &AccountExportController{Service: svc}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", role)
+	})
+	r.GET("/api/export", ctl.ExportAccount)
+	r.POST("/api/import", ctl.ImportAccount)
+	return r
+}
+
+func do(r *gin.Engine, method, path, contentType string, body []byte) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func unzip(t *testing.T, content []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+func rezip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// エクスポートした zip をインポートすると同じレコードに戻る
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, format := range []string{"json", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			repo := &MockAccountDataRepository{Data: newData()}
+			r := setupRouter(repo, 1)
+
+			rec := do(r, http.MethodGet, "/api/export?format="+format, "", nil)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Header().Get("Content-Disposition"), "account-export-20260501-090000.zip")
+
+			files := unzip(t, rec.Body.Bytes())
+			var manifest model.ExportManifest
+			require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+			assert.Equal(t, model.ExportSchemaVersion, manifest.SchemaVersion)
+			assert.Equal(t, format, manifest.Format)
+			assert.Len(t, manifest.Files, 16)
+			assert.Contains(t, manifest.Files, model.ExportFile{Resource: "tasks", Name: "tasks." + format, Count: 1})
+			assert.Contains(t, manifest.Files, model.ExportFile{Resource: "books", Name: "books." + format, Count: 0})
+			// 関連のフィールドは含めない
+			assert.NotContains(t, files["tasks."+format], "assessments")
+
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			part, err := w.CreateFormFile("file", "export.zip")
+			require.NoError(t, err)
+			_, _ = part.Write(rec.Body.Bytes())
+			require.NoError(t, w.Close())
+			rec = do(r, http.MethodPost, "/api/import", w.FormDataContentType(), body.Bytes())
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, uint(1), repo.UserID)
+			assert.Equal(t, newData(), repo.Restored)
+		})
+	}
+}
+
+// admin でも絞り込みなし（0）ではなく自分のデータをエクスポート・復元する
+func TestExportImportAsAdmin(t *testing.T) {
+	repo := &MockAccountDataRepository{Data: newData()}
+	r := setupRouterAs(repo, 4, "admin")
+
+	rec := do(r, http.MethodGet, "/api/export", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, uint(4), repo.FoundUserID)
+
+	rec = do(r, http.MethodPost, "/api/import", "application/zip", rec.Body.Bytes())
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, uint(4), repo.UserID)
+}
+
+func TestImportRejectsInvalidArchives(t *testing.T) {
+	repo := &MockAccountDataRepository{Data: newData()}
+	r := setupRouter(repo, 1)
+	rec := do(r, http.MethodGet, "/api/export", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	files := unzip(t, rec.Body.Bytes())
+
+	withManifest := func(edit func(m map[string]interface{})) map[string]string {
+		var m map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &m))
+		edit(m)
+		raw, _ := json.Marshal(m)
+		out := map[string]string{}
+		for k, v := range files {
+			out[k] = v
+		}
+		out["manifest.json"] = string(raw)
+		return out
+	}
+	withoutManifest := map[string]string{"tasks.json": files["tasks.json"]}
+	wrongCount := withManifest(func(m map[string]interface{}) {
+		m["files"].([]interface{})[0].(map[string]interface{})["count"] = 2
+	})
+
+	for name, tc := range map[string]struct {
+		body   []byte
+		status int
+		detail string
+	}{
+		"not a zip":        {[]byte("hello"), http.StatusBadRequest, "archive must be a zip file"},
+		"missing manifest": {rezip(t, withoutManifest), http.StatusBadRequest, "manifest.json is missing"},
+		"newer schema":     {rezip(t, withManifest(func(m map[string]interface{}) { m["schema_version"] = 99 })), http.StatusBadRequest, "schema version 99 is not supported"},
+		"unknown resource": {rezip(t, withManifest(func(m map[string]interface{}) {
+			m["files"] = []interface{}{map[string]interface{}{"resource": "users", "name": "tasks.json"}}
+		})), http.StatusBadRequest, "unknown resource users"},
+		"count mismatch":     {rezip(t, wrongCount), http.StatusBadRequest, "tasks.json has 1 records but the manifest lists 2"},
+		"malformed resource": {rezip(t, withManifest(func(m map[string]interface{}) { m["format"] = "csv" })), http.StatusBadRequest, "tasks.json"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo.Restored = nil
+			rec := do(r, http.MethodPost, "/api/import", "application/zip", tc.body)
+			assert.Equal(t, tc.status, rec.Code, rec.Body.String())
+			assert.Contains(t, rec.Body.String(), tc.detail)
+			assert.Nil(t, repo.Restored)
+		})
+	}
+
+	rec = do(r, http.MethodGet, "/api/export?format=xml", "", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "format must be json or csv"))
+}
+
+// 展開後のサイズが 1 ファイルまたは合計の上限を超える zip は拒否する
+func TestImportRejectsOversizedArchives(t *testing.T) {
+	repo := &MockAccountDataRepository{Data: newData()}
+	rec := do(setupRouter(repo, 1), http.MethodGet, "/api/export", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	files := unzip(t, rec.Body.Bytes())
+
+	// declared はヘッダーの展開後のサイズを偽ったファイル。上限を超えるものは開かないため圧縮データは空でよい
+	build := func(declared map[string]uint64) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for name, content := range files {
+			if size, ok := declared[name]; ok {
+				_, err := w.CreateRaw(&zip.FileHeader{Name: name, Method: zip.Deflate, UncompressedSize64: size})
+				require.NoError(t, err)
+				continue
+			}
+			f, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+	var total int64
+	for _, content := range files {
+		total += int64(len(content))
+	}
+
+	for name, tc := range map[string]struct {
+		max  int64
+		body []byte
+	}{
+		"file over limit": {0, build(map[string]uint64{"tasks.json": service.MaxAccountFileBytes + 1})},
+		"declared total":  {total + 10, build(map[string]uint64{"tasks.json": 1 << 20})},
+		"actual total":    {total - 10, build(nil)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := setupRouterWith(repo, 1, "editor", &service.AccountExportService{Repo: repo, MaxExtractedBytes: tc.max})
+			rec := do(r, http.MethodPost, "/api/import", "application/zip", tc.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			assert.Contains(t, rec.Body.String(), "exceeds")
+			assert.Nil(t, repo.Restored)
+		})
+	}
+
+	// 上限内であれば取り込める
+	r := setupRouterWith(repo, 1, "editor", &service.AccountExportService{Repo: repo, MaxExtractedBytes: total})
+	rec = do(r, http.MethodPost, "/api/import", "application/zip", build(nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
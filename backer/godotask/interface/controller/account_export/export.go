@@ -0,0 +1,35 @@
+package account_export
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
+)
+
+// ExportAccount: GET /api/export?format=json|csv
+// 自分のタスク・メモリ・メモリコンテキスト・評価・本・ヒューリスティクス・知識パターン・ラベルを zip で返す
+// zip は manifest.json（形式の版・件数）とリソースごとのファイルを持つ。format の既定は json
+func (ctl *AccountExportController) ExportAccount(c *gin.Context) {
+	// admin も含め、エクスポートするのは操作したユーザー本人のデータ
+	userID, _ := authcontext.UserID(c)
+	content, manifest, err := ctl.Service.Export(userID, c.DefaultQuery("format", service.ImportFormatJSON))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to export account")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	filename := "account-export-" + manifest.ExportedAt.Format("20060102-150405") + ".zip"
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("X-Export-Schema-Version", strconv.Itoa(manifest.SchemaVersion))
+	c.Data(http.StatusOK, "application/zip", content)
+}
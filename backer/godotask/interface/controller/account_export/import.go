@@ -0,0 +1,75 @@
+package account_export
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// MaxAccountArchiveBytes はインポートする zip の上限（50MB）
+const MaxAccountArchiveBytes = 50 << 20
+
+// ImportAccount: POST /api/import
+// GET /api/export の zip を自分のレコードとして取り込む。zip は multipart/form-data の "file" またはリクエストボディで送る
+// ID は作成し直し、レコード間の参照を新しい ID に付け替える。すべて 1 つのトランザクションで作成する
+func (ctl *AccountExportController) ImportAccount(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxAccountArchiveBytes)
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			appErr := errors.NewAppError(errors.VAL_MISSING_FIELD, errors.GetErrorMessage(errors.VAL_MISSING_FIELD), "file is required")
+			c.JSON(appErr.HTTPStatus, gin.H{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"detail":  appErr.Detail,
+			})
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			appErr := errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), err.Error())
+			c.JSON(appErr.HTTPStatus, gin.H{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"detail":  appErr.Detail,
+			})
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		appErr := errors.NewAppError(errors.VAL_CONSTRAINT_FAILED, errors.GetErrorMessage(errors.VAL_CONSTRAINT_FAILED), err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	// admin も含め、復元したレコードの所有者は操作したユーザー本人
+	userID, _ := authcontext.UserID(c)
+	result, err := ctl.Service.Import(userID, bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to import account")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Account data imported",
+		"result":  result,
+	})
+}
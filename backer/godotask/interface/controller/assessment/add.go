@@ -5,6 +5,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddAssessment: POST /api/assessment
@@ -24,12 +25,9 @@ func (ctl *AssessmentController) AddAssessment(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.CreateAssessment(&assessment); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add assessment",
-		)
+	userID, _ := authcontext.UserID(c)
+	if err := ctl.Service.CreateAssessment(userID, &assessment); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add assessment")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -9,57 +9,82 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    dtoquery "github.com/godotask/dto/query"
+    apperrors "github.com/godotask/errors"
     "github.com/godotask/infrastructure/db/model"
     "github.com/godotask/usecase/service"
 )
 
 // モックリポジトリを作成
+// ID 1 の Assessment はユーザー 1 が所有している
 type MockAssessmentRepository struct{}
 
-func (m *MockAssessmentRepository) Create(assessment *model.Assessment) error {
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
     return nil
 }
 
-func (m *MockAssessmentRepository) FindByID(id string) (*model.Assessment, error) {
-    return &model.Assessment{
+func mockAssessment() model.Assessment {
+    return model.Assessment{
         ID:                  1,
         TaskID:              1,
-        UserID:              1,
+        UserID:              int(mockOwnerID),
         EffectivenessScore:  85,
         EffortScore:         70,
         ImpactScore:         90,
         QualitativeFeedback: "Test feedback",
         CreatedAt:           time.Now(),
         UpdatedAt:           time.Now(),
-    }, nil
+    }
+}
+
+func (m *MockAssessmentRepository) Create(userID uint, assessment *model.Assessment) error {
+    return nil
 }
 
-func (m *MockAssessmentRepository) FindAll() ([]model.Assessment, error) {
-    return []model.Assessment{
-        {
-            ID:                  1,
-            TaskID:              1,
-            UserID:              1,
-            EffectivenessScore:  85,
-            EffortScore:         70,
-            ImpactScore:         90,
-            QualitativeFeedback: "Test feedback",
-            CreatedAt:           time.Now(),
-            UpdatedAt:           time.Now(),
-        },
-    }, nil
+func (m *MockAssessmentRepository) FindByID(userID uint, id string) (*model.Assessment, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
+    a := mockAssessment()
+    return &a, nil
 }
 
-func (m *MockAssessmentRepository) Update(id string, assessment *model.Assessment) error {
-    return nil // テスト用なので常に成功
+func (m *MockAssessmentRepository) FindAll(userID uint) ([]model.Assessment, int64, error) {
+    return []model.Assessment{mockAssessment()}, 1, nil
 }
 
-func (m *MockAssessmentRepository) Delete(id string) error {
-    return nil // テスト用なので常に成功
+func (m *MockAssessmentRepository) ListAssessmentsPager(userID uint, offset int, perPage int) ([]model.Assessment, int64, error) {
+    return []model.Assessment{mockAssessment()}, 1, nil
+}
+
+func (m *MockAssessmentRepository) ListAssessmentsForTaskUserPager(filter dtoquery.QueryFilter, offset int, perPage int) ([]model.Assessment, int64, error) {
+    return []model.Assessment{mockAssessment()}, 1, nil
+}
+
+func (m *MockAssessmentRepository) Update(userID uint, id string, assessment *model.Assessment) error {
+    return authorizeMock(userID)
+}
+
+func (m *MockAssessmentRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
 }
 
 func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
     r := gin.Default()
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
     
     // モックサービスとリポジトリを作成
     mockRepo := &MockAssessmentRepository{}
@@ -188,13 +213,18 @@ func TestGetAssessment(t *testing.T) {
     assert.Contains(t, w.Body.String(), "Test feedback")
 }
 
-func setupRouterWithMockService(repo repository.AssessmentRepository) *gin.Engine {
+func TestAssessmentAccessDeniedForOtherUser(t *testing.T) {
     gin.SetMode(gin.TestMode)
-    r := gin.Default()
+    r := setupRouterAs(2, "editor")
 
-    service := service.NewAssessmentService(repo)
-    controller := NewAssessmentController(service)
+    for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/assessment/1", bytes.NewBuffer([]byte(`{"qualitative_feedback": "Hijacked"}`)))
+        req.Header.Set("Content-Type", "application/json")
 
-    r.GET("/api/assessment/:id", controller.GetAssessment)
-    return r
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
 }
@@ -4,18 +4,15 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"	
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteAssessment: DELETE /api/assessment/:id
 func (ctl *AssessmentController) DeleteAssessment(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteAssessment(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete assessment"})
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Failed to delete assessment",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteAssessment(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Failed to delete assessment")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
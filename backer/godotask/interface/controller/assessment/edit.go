@@ -5,11 +5,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditAssessment: PUT /api/assessment/:id
 func (ctl *AssessmentController) EditAssessment(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var assessment model.Assessment
 	if err := c.ShouldBindJSON(&assessment); err != nil {
 		appErr := errors.NewAppError(
@@ -24,12 +26,8 @@ func (ctl *AssessmentController) EditAssessment(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.UpdateAssessment(id, &assessment); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to edit assessment",
-		)
+	if err := ctl.Service.UpdateAssessment(userID, id, &assessment); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to edit assessment")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
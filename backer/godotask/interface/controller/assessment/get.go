@@ -4,18 +4,16 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetAssessment: GET /api/assessment/:id
 func (ctl *AssessmentController) GetAssessment(c *gin.Context) {
 	id := c.Param("id")
-	assessment, err := ctl.Service.GetAssessmentByID(id)
+	userID := authcontext.ScopeUserID(c)
+	assessment, err := ctl.Service.GetAssessmentByID(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Assessments not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Assessments not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -0,0 +1,89 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
+)
+
+// AttachmentForm はアップロードのフォーム項目。ファイルは "file" で送る
+type AttachmentForm struct {
+	ResourceType string `form:"resource_type" binding:"required"`
+	ResourceID   string `form:"resource_id" binding:"required"`
+	Description  string `form:"description" binding:"max=200"`
+}
+
+// AddAttachment: POST /api/attachment (multipart/form-data)
+// ファイルを保存し、resource_type / resource_id のリソースに添付する
+func (ctl *AttachmentController) AddAttachment(c *gin.Context) {
+	var form AttachmentForm
+	if err := c.ShouldBind(&form); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"file is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	defer file.Close()
+
+	actorID, _ := authcontext.UserID(c)
+	attachment, err := ctl.Service.Upload(authcontext.ScopeUserID(c), actorID, &service.AttachmentUpload{
+		ResourceType: form.ResourceType,
+		ResourceID:   form.ResourceID,
+		FileName:     header.Filename,
+		ContentType:  header.Header.Get("Content-Type"),
+		Description:  form.Description,
+		Body:         file,
+	})
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add attachment")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Attachment added",
+		"attachment": attachment,
+	})
+}
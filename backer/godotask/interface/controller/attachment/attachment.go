@@ -0,0 +1,7 @@
+package attachment
+
+import "github.com/godotask/usecase/service"
+
+type AttachmentController struct {
+	Service *service.AttachmentService
+}
@@ -0,0 +1,285 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/storage"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockOwnerID uint = 1
+
+// MockAttachmentRepository は添付をメモリ上に保持する。mockOwnerID 以外は task 100 に添付できない
+type MockAttachmentRepository struct {
+	Attachments map[string]model.Attachment
+	Collected   []string
+}
+
+func (m *MockAttachmentRepository) Create(userID uint, a *model.Attachment, store func() error) error {
+	if a.ResourceType != "task" || a.ResourceID != "100" {
+		return errors.ErrResourceNotFound
+	}
+	if userID != 0 && userID != mockOwnerID {
+		return errors.ErrResourceAccessDenied
+	}
+	if err := store(); err != nil {
+		return err
+	}
+	m.Attachments[a.ID] = *a
+	return nil
+}
+func (m *MockAttachmentRepository) FindByID(userID uint, id string) (*model.Attachment, error) {
+	a, ok := m.Attachments[id]
+	if !ok {
+		return nil, errors.ErrResourceNotFound
+	}
+	if userID != 0 && userID != a.UserID {
+		return nil, errors.ErrResourceAccessDenied
+	}
+	return &a, nil
+}
+func (m *MockAttachmentRepository) FindByResource(userID uint, resourceType, resourceID string) ([]model.Attachment, error) {
+	var out []model.Attachment
+	for _, a := range m.Attachments {
+		if a.ResourceType == resourceType && a.ResourceID == resourceID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (m *MockAttachmentRepository) FindByUploader(userID uint) ([]model.Attachment, error) {
+	var out []model.Attachment
+	for _, a := range m.Attachments {
+		if userID == 0 || a.UserID == userID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (m *MockAttachmentRepository) Delete(userID uint, id string, collect func(string) error) error {
+	a, err := m.FindByID(userID, id)
+	if err != nil {
+		return err
+	}
+	delete(m.Attachments, id)
+	for _, other := range m.Attachments {
+		if other.SHA256 == a.SHA256 {
+			return nil
+		}
+	}
+	m.Collected = append(m.Collected, a.SHA256)
+	return collect(a.SHA256)
+}
+
+type fixture struct {
+	router *gin.Engine
+	repo   *MockAttachmentRepository
+	store  *storage.LocalStore
+	now    time.Time
+}
+
+func setupRouter(t *testing.T, userID uint) *fixture {
+	gin.SetMode(gin.TestMode)
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fixture{
+		repo:  &MockAttachmentRepository{Attachments: map[string]model.Attachment{}},
+		store: store,
+		now:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	svc := &service.AttachmentService{
+		Repo:   f.repo,
+		Blobs:  store,
+		Signer: &storage.URLSigner{Key: []byte("test-key")},
+		Now:    func() time.Time { return f.now },
+	}
+	ctl := &AttachmentController{Service: svc}
+
+	r := gin.New()
+	r.GET("/api/attachment_download/:id", ctl.DownloadAttachment)
+	protected := r.Group("/api")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	protected.POST("/attachment", ctl.AddAttachment)
+	protected.GET("/attachment", ctl.ListAttachments)
+	protected.GET("/attachment/:id", ctl.GetAttachment)
+	protected.DELETE("/attachment/:id", ctl.DeleteAttachment)
+	f.router = r
+	return f
+}
+
+func uploadRequest(t *testing.T, fields map[string]string, fileName string, content []byte) *http.Request {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		assert.NoError(t, w.WriteField(k, v))
+	}
+	if fileName != "" {
+		part, err := w.CreateFormFile("file", fileName)
+		assert.NoError(t, err)
+		_, _ = part.Write(content)
+	}
+	assert.NoError(t, w.Close())
+	req, _ := http.NewRequest("POST", "/api/attachment", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+func (f *fixture) upload(t *testing.T, content string) service.AttachmentDownload {
+	req := uploadRequest(t, map[string]string{"resource_type": "task", "resource_id": "100"}, "../notes.txt", []byte(content))
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var res struct {
+		Attachment service.AttachmentDownload `json:"attachment"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return res.Attachment
+}
+
+func TestAddAttachmentAndDownload(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	a := f.upload(t, "hello attachment")
+
+	assert.Equal(t, "notes.txt", a.FileName)
+	assert.Equal(t, int64(16), a.Size)
+	assert.Equal(t, mockOwnerID, a.UserID)
+	assert.Len(t, a.SHA256, 64)
+	assert.True(t, strings.HasPrefix(a.DownloadURL, "/api/attachment_download/"+a.ID+"?"))
+
+	exists, err := f.store.Exists(context.Background(), a.SHA256)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", a.DownloadURL, nil)
+	f.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello attachment", w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `filename=notes.txt`)
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+}
+
+func TestDownloadAttachmentRejectsBadSignature(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	a := f.upload(t, "secret")
+	u, _ := url.Parse(a.DownloadURL)
+
+	tampered := u.Query()
+	tampered.Set("signature", strings.Repeat("0", 64))
+	expired := u.Query()
+
+	tests := []struct {
+		name  string
+		query url.Values
+		after time.Duration
+		code  errors.ErrorCode
+	}{
+		{"tampered", tampered, 0, errors.AUTH_TOKEN_INVALID},
+		{"expired", expired, service.DefaultDownloadURLTTL + time.Minute, errors.AUTH_TOKEN_EXPIRED},
+		{"missing", url.Values{}, 0, errors.AUTH_TOKEN_INVALID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f.now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(tt.after)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", u.Path+"?"+tt.query.Encode(), nil)
+			f.router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Contains(t, w.Body.String(), string(tt.code))
+		})
+	}
+}
+
+func TestAddAttachmentErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		fields map[string]string
+		file   string
+		status int
+	}{
+		{"no file", mockOwnerID, map[string]string{"resource_type": "task", "resource_id": "100"}, "", http.StatusBadRequest},
+		{"no resource", mockOwnerID, map[string]string{"resource_type": "task"}, "a.txt", http.StatusBadRequest},
+		{"unknown resource type", mockOwnerID, map[string]string{"resource_type": "nope", "resource_id": "1"}, "a.txt", http.StatusBadRequest},
+		{"attachment to attachment", mockOwnerID, map[string]string{"resource_type": "attachment", "resource_id": "x"}, "a.txt", http.StatusBadRequest},
+		{"other user's task", 2, map[string]string{"resource_type": "task", "resource_id": "100"}, "a.txt", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupRouter(t, tt.userID)
+			w := httptest.NewRecorder()
+			f.router.ServeHTTP(w, uploadRequest(t, tt.fields, tt.file, []byte("data")))
+			assert.Equal(t, tt.status, w.Code, w.Body.String())
+			assert.Empty(t, f.repo.Attachments)
+		})
+	}
+}
+
+func TestDeleteAttachmentCollectsBlob(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	first := f.upload(t, "shared content")
+	second := f.upload(t, "shared content")
+	assert.Equal(t, first.SHA256, second.SHA256)
+
+	del := func(id string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/attachment/"+id, nil)
+		f.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, del(first.ID))
+	exists, _ := f.store.Exists(context.Background(), first.SHA256)
+	assert.True(t, exists, "blob is still referenced by the second attachment")
+
+	assert.Equal(t, http.StatusOK, del(second.ID))
+	exists, _ = f.store.Exists(context.Background(), first.SHA256)
+	assert.False(t, exists)
+	assert.Equal(t, []string{first.SHA256}, f.repo.Collected)
+
+	assert.Equal(t, http.StatusNotFound, del(second.ID))
+}
+
+func TestListAttachments(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	f.upload(t, "one")
+	f.upload(t, "two")
+
+	for _, q := range []string{"?resource_type=task&resource_id=100", ""} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/attachment"+q, nil)
+		f.router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res struct {
+			Attachments []service.AttachmentDownload `json:"attachments"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Len(t, res.Attachments, 2)
+		for _, a := range res.Attachments {
+			assert.NotEmpty(t, a.DownloadURL)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/attachment?resource_type=task", nil)
+	f.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,29 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// DeleteAttachment: DELETE /api/attachment/:id
+// どの添付からも参照されなくなったファイルはストレージからも削除する
+func (ctl *AttachmentController) DeleteAttachment(c *gin.Context) {
+	id := c.Param("id")
+	if err := ctl.Service.DeleteAttachment(authcontext.ScopeUserID(c), id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete attachment")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Attachment deleted",
+		"attachment_id": id,
+	})
+}
@@ -0,0 +1,37 @@
+package attachment
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// DownloadAttachment: GET /api/attachment_download/:id?expires=...&signature=...
+// 認証の代わりに署名付き URL を確認してファイルを返す
+func (ctl *AttachmentController) DownloadAttachment(c *gin.Context) {
+	attachment, body, err := ctl.Service.OpenSigned(c.Param("id"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to download attachment")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	defer body.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, body, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   strconv.Quote(attachment.SHA256),
+		"Cache-Control":          "private, max-age=0",
+	})
+}
@@ -0,0 +1,32 @@
+package attachment
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetAttachment: GET /api/attachment/:id?ttl=秒
+// 添付と ttl 秒の間有効な署名付きダウンロード URL を返す
+func (ctl *AttachmentController) GetAttachment(c *gin.Context) {
+	ttl, _ := strconv.Atoi(c.Query("ttl"))
+	attachment, err := ctl.Service.GetAttachment(authcontext.ScopeUserID(c), c.Param("id"), time.Duration(ttl)*time.Second)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to get attachment")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Attachment retrieved",
+		"attachment": attachment,
+	})
+}
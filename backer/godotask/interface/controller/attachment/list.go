@@ -0,0 +1,30 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListAttachments: GET /api/attachment?resource_type=...&resource_id=...
+// リソースを指定しない場合は自分がアップロードした添付を返す
+func (ctl *AttachmentController) ListAttachments(c *gin.Context) {
+	actorID, _ := authcontext.UserID(c)
+	attachments, err := ctl.Service.ListAttachments(authcontext.ScopeUserID(c), actorID, c.Query("resource_type"), c.Query("resource_id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list attachments")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     "Attachments retrieved",
+		"attachments": attachments,
+	})
+}
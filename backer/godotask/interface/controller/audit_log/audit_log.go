@@ -0,0 +1,61 @@
+package audit_log
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	dtoquery "github.com/godotask/dto/query"
+	"github.com/godotask/usecase/service"
+)
+
+type AuditLogController struct {
+	Service *service.AuditLogService
+}
+
+// parseAuditLogFilter: user_id, resource_type, resource_id, method, from, to を読み取る
+// from / to は RFC3339 もしくは 2006-01-02 形式
+func parseAuditLogFilter(c *gin.Context) (dtoquery.AuditLogFilter, error) {
+	var filter dtoquery.AuditLogFilter
+
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return filter, err
+		}
+		userID := uint(id)
+		filter.UserID = &userID
+	}
+
+	filter.ResourceType = c.Query("resource_type")
+	filter.ResourceID = c.Query("resource_id")
+	filter.Method = c.Query("method")
+
+	from, err := parseAuditTime(c.Query("from"))
+	if err != nil {
+		return filter, err
+	}
+	filter.From = from
+
+	to, err := parseAuditTime(c.Query("to"))
+	if err != nil {
+		return filter, err
+	}
+	filter.To = to
+
+	return filter, nil
+}
+
+func parseAuditTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,165 @@
+package audit_log
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	dtoquery "github.com/godotask/dto/query"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/middleware"
+	"github.com/godotask/usecase/service"
+)
+
+// モックリポジトリ
+type MockAuditLogRepository struct {
+	Logs      []model.AuditLog
+	Snapshots map[string]map[string]interface{}
+}
+
+func (m *MockAuditLogRepository) Create(auditLog *model.AuditLog) error {
+	auditLog.ID = uint(len(m.Logs) + 1)
+	m.Logs = append(m.Logs, *auditLog)
+	return nil
+}
+
+func (m *MockAuditLogRepository) ListPager(filter dtoquery.AuditLogFilter, offset int, limit int) ([]model.AuditLog, int64, error) {
+	return m.Logs, int64(len(m.Logs)), nil
+}
+
+func (m *MockAuditLogRepository) FindInBatches(filter dtoquery.AuditLogFilter, batchSize int, fn func(logs []model.AuditLog) error) error {
+	return fn(m.Logs)
+}
+
+func (m *MockAuditLogRepository) Snapshot(resourceType string, id string) (map[string]interface{}, error) {
+	return m.Snapshots[resourceType+"/"+id], nil
+}
+
+func newMockRepo() *MockAuditLogRepository {
+	return &MockAuditLogRepository{
+		Logs: []model.AuditLog{
+			{
+				ID:           1,
+				UserID:       7,
+				Username:     "machinist",
+				Role:         "user",
+				Method:       "PUT",
+				Route:        "/api/process_optimization/:id",
+				Path:         "/api/process_optimization/po-1",
+				ResourceType: "process_optimization",
+				ResourceID:   "po-1",
+				BeforeHash:   "aaa",
+				AfterHash:    "bbb",
+				StatusCode:   200,
+				IP:           "10.0.0.1",
+				CreatedAt:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		Snapshots: map[string]map[string]interface{}{},
+	}
+}
+
+func setupRouter(repo *MockAuditLogRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ctl := &AuditLogController{Service: &service.AuditLogService{Repo: repo}}
+	r.GET("/api/admin/audit_logs", ctl.ListAuditLogs)
+	r.GET("/api/admin/audit_logs/export", ctl.ExportAuditLogs)
+	return r
+}
+
+func TestListAuditLogs(t *testing.T) {
+	r := setupRouter(newMockRepo())
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/audit_logs?page=1&limit=10&resource_type=process_optimization", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Audit logs retrieved")
+	assert.Contains(t, w.Body.String(), "po-1")
+}
+
+func TestListAuditLogsInvalidDate(t *testing.T) {
+	r := setupRouter(newMockRepo())
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/audit_logs?from=yesterday", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "VAL_003")
+}
+
+func TestExportAuditLogs(t *testing.T) {
+	r := setupRouter(newMockRepo())
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/audit_logs/export", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "id,created_at,user_id"))
+	assert.Contains(t, lines[1], "2025-01-02T03:04:05Z,7,machinist")
+}
+
+func TestAuditMiddlewareRecordsMutation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockAuditLogRepository{Snapshots: map[string]map[string]interface{}{
+		"task/12": {"id": 12, "title": "before"},
+	}}
+	auditSvc := &service.AuditLogService{Repo: repo}
+
+	r := gin.New()
+	api := r.Group("/api")
+	api.Use(func(c *gin.Context) {
+		c.Set("user_id", uint(3))
+		c.Set("username", "inspector")
+		c.Set("role", "user")
+	}, middleware.AuditMiddleware(auditSvc))
+	api.PUT("/task/:id", func(c *gin.Context) {
+		repo.Snapshots["task/12"] = map[string]interface{}{"id": 12, "title": "after"}
+		c.JSON(http.StatusOK, gin.H{"message": "Task edited"})
+	})
+	api.POST("/task", func(c *gin.Context) {
+		repo.Snapshots["task/13"] = map[string]interface{}{"id": 13, "title": "new"}
+		c.JSON(http.StatusOK, gin.H{"task": gin.H{"id": 13}})
+	})
+	api.GET("/task/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPut, "/api/task/12", nil),
+		httptest.NewRequest(http.MethodPost, "/api/task", nil),
+		httptest.NewRequest(http.MethodGet, "/api/task/12", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// GET は記録されない
+	assert.Len(t, repo.Logs, 2)
+
+	put := repo.Logs[0]
+	assert.Equal(t, uint(3), put.UserID)
+	assert.Equal(t, "inspector", put.Username)
+	assert.Equal(t, "task", put.ResourceType)
+	assert.Equal(t, "12", put.ResourceID)
+	assert.Equal(t, "/api/task/:id", put.Route)
+	assert.Len(t, put.BeforeHash, 64)
+	assert.Len(t, put.AfterHash, 64)
+	assert.NotEqual(t, put.BeforeHash, put.AfterHash)
+
+	post := repo.Logs[1]
+	assert.Equal(t, "13", post.ResourceID)
+	assert.Empty(t, post.BeforeHash)
+	assert.NotEmpty(t, post.AfterHash)
+}
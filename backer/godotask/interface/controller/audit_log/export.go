@@ -0,0 +1,39 @@
+package audit_log
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// ExportAuditLogs: GET /api/admin/audit_logs/export
+func (ctl *AuditLogController) ExportAuditLogs(c *gin.Context) {
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("audit_logs_%s.csv", time.Now().Format("20060102150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// ヘッダー送信後のエラーはレスポンスを変更できないためログのみ
+	if err := ctl.Service.ExportAuditLogsCSV(c.Writer, filter); err != nil {
+		log.Error().Err(err).Msg("ExportAuditLogs: failed to write csv")
+	}
+}
@@ -0,0 +1,50 @@
+package audit_log
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/tools"
+)
+
+// ListAuditLogs: GET /api/admin/audit_logs
+func (ctl *AuditLogController) ListAuditLogs(c *gin.Context) {
+	filter, err := parseAuditLogFilter(c)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	pager := tools.ParsePagerQuery(c)
+	logs, total, err := ctl.Service.ListAuditLogsPager(filter, pager)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error()+" | Failed to list audit logs",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Audit logs retrieved",
+		"audit_logs": logs,
+		"meta":       tools.BuildPageMeta(total, pager.Page, pager.Limit),
+	})
+}
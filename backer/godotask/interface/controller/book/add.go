@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 func BookAddDisplayAction(c *gin.Context) {
@@ -28,12 +29,9 @@ func (ctl *BookController) AddBook(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.CreateBook(&book); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add book",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.CreateBook(userID, &book); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add book")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -3,5 +3,6 @@ package book
 import "github.com/godotask/usecase/service"
 
 type BookController struct {
-  Service *service.BookService
+  Service     *service.BookService
+  Attachments *service.AttachmentService
 }
@@ -2,24 +2,44 @@ package book
 
 import (
     "bytes"
+    "encoding/json"
+    "image"
+    "image/png"
+    "mime/multipart"
     "net/http"
     "net/http/httptest"
+    "net/textproto"
     "testing"
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    apperrors "github.com/godotask/errors"
     "github.com/godotask/infrastructure/db/model"
+    "github.com/godotask/infrastructure/storage"
     "github.com/godotask/usecase/service"
 )
 
 // モックリポジトリを作成
+// Book は task_id 経由で所有者を判定する。ID 1 の Book とそのタスクはユーザー 1 が所有している
 type MockBookRepository struct{}
 
-func (m *MockBookRepository) Create(book *model.Book) error {
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
     return nil
 }
 
-func (m *MockBookRepository) FindByID(id string) (*model.Book, error) {
+func (m *MockBookRepository) Create(userID uint, book *model.Book) error {
+    return authorizeMock(userID)
+}
+
+func (m *MockBookRepository) FindByID(userID uint, id string) (*model.Book, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
     return &model.Book{
         ID:      1,
         Title:   "Test Book",
@@ -86,12 +106,12 @@ func (m *MockBookRepository) FindAllPager(userID uint, offset, limit int) ([]mod
     return books[offset:end], total, nil
 }
 
-func (m *MockBookRepository) Update(id string, book *model.Book) error {
-    return nil // テスト用なので常に成功
+func (m *MockBookRepository) Update(userID uint, id string, book *model.Book) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockBookRepository) Delete(id string) error {
-    return nil // テスト用なので常に成功
+func (m *MockBookRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
 }
 
 
@@ -105,6 +125,7 @@ func authMiddlewareMock(userID uint) gin.HandlerFunc {
 
 func setupRouter() *gin.Engine {
   r := gin.Default()
+  r.Use(authMiddlewareMock(mockOwnerID))
   
   mockRepo := &MockBookRepository{}
   mockService := &service.BookService{Repo: mockRepo}
@@ -154,7 +175,7 @@ func TestUpdateBook(t *testing.T) {
   mockService := &service.BookService{Repo: mockRepo}
   ctl := &BookController{Service: mockService}
   
-  r.PUT("/api/updatebook/:id", ctl.EditBook)
+  r.PUT("/api/book/:id", authMiddlewareMock(mockOwnerID), ctl.EditBook)
 
   body := `{
       "title": "Updated Title",
@@ -164,7 +185,7 @@ func TestUpdateBook(t *testing.T) {
       "imgPath": "Updated Image Path"
   }`
 
-  req, err := http.NewRequest(http.MethodPut, "/api/updatebook/1", bytes.NewBuffer([]byte(body)))
+  req, err := http.NewRequest(http.MethodPut, "/api/book/1", bytes.NewBuffer([]byte(body)))
   if err != nil {
       t.Fatalf("Couldn't create request: %v\n", err)
   }
@@ -189,9 +210,9 @@ func TestDeleteBook(t *testing.T) {
   mockService := &service.BookService{Repo: mockRepo}
   ctl := &BookController{Service: mockService}
   
-  r.DELETE("/api/deletebook/:id", ctl.DeleteBook)
+  r.DELETE("/api/book/:id", authMiddlewareMock(mockOwnerID), ctl.DeleteBook)
 
-  req, err := http.NewRequest(http.MethodDelete, "/api/deletebook/1", nil)
+  req, err := http.NewRequest(http.MethodDelete, "/api/book/1", nil)
   if err != nil {
     t.Fatalf("Couldn't create request: %v\n", err)
   }
@@ -226,4 +247,215 @@ func TestListBooks(t *testing.T) {
     assert.Equal(t, http.StatusOK, w.Code)
     assert.Contains(t, w.Body.String(), "Test Book")
     assert.Contains(t, w.Body.String(), "success")
-}
\ No newline at end of file
+}
+
+func TestBookAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+
+    r := gin.Default()
+    r.Use(authMiddlewareMock(2))
+
+    mockRepo := &MockBookRepository{}
+    mockService := &service.BookService{Repo: mockRepo}
+    ctl := &BookController{Service: mockService}
+
+    r.POST("/api/book", ctl.AddBook)
+    r.PUT("/api/book/:id", ctl.EditBook)
+    r.DELETE("/api/book/:id", ctl.DeleteBook)
+
+    cases := []struct {
+        method string
+        path   string
+    }{
+        {http.MethodPost, "/api/book"},
+        {http.MethodPut, "/api/book/1"},
+        {http.MethodDelete, "/api/book/1"},
+    }
+    for _, tc := range cases {
+        req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBuffer([]byte(`{"title": "Hijacked", "task_id": 1}`)))
+        req.Header.Set("Content-Type", "application/json")
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, tc.method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), tc.method)
+    }
+}
+
+// recordingBookRepository は Update で受け取った Book を記録する
+type recordingBookRepository struct {
+    MockBookRepository
+    Updated *model.Book
+}
+
+func (m *recordingBookRepository) Update(userID uint, id string, book *model.Book) error {
+    if err := authorizeMock(userID); err != nil {
+        return err
+    }
+    m.Updated = book
+    return nil
+}
+
+// mockAttachmentRepository は Book 1 への添付だけを受け付ける
+type mockAttachmentRepository struct {
+    Attachments []model.Attachment
+}
+
+func (m *mockAttachmentRepository) Create(userID uint, a *model.Attachment, store func() error) error {
+    if err := authorizeMock(userID); err != nil {
+        return err
+    }
+    if a.ResourceType != "book" || a.ResourceID != "1" {
+        return apperrors.ErrResourceNotFound
+    }
+    if err := store(); err != nil {
+        return err
+    }
+    m.Attachments = append(m.Attachments, *a)
+    return nil
+}
+func (m *mockAttachmentRepository) FindByID(userID uint, id string) (*model.Attachment, error) {
+    return nil, apperrors.ErrResourceNotFound
+}
+func (m *mockAttachmentRepository) FindByResource(userID uint, resourceType, resourceID string) ([]model.Attachment, error) {
+    return m.Attachments, nil
+}
+func (m *mockAttachmentRepository) FindByUploader(userID uint) ([]model.Attachment, error) {
+    return m.Attachments, nil
+}
+func (m *mockAttachmentRepository) Delete(userID uint, id string, collect func(string) error) error {
+    return nil
+}
+
+func imageUploadRequest(t *testing.T, path, fileName string, content []byte) *http.Request {
+    var buf bytes.Buffer
+    w := multipart.NewWriter(&buf)
+    // ブラウザと同様にパートの Content-Type を付ける（imageupload はこれを確認する）
+    header := make(textproto.MIMEHeader)
+    header.Set("Content-Disposition", `form-data; name="upfile"; filename="`+fileName+`"`)
+    header.Set("Content-Type", "image/png")
+    part, err := w.CreatePart(header)
+    assert.NoError(t, err)
+    _, _ = part.Write(content)
+    assert.NoError(t, w.Close())
+    req, _ := http.NewRequest(http.MethodPost, path, &buf)
+    req.Header.Set("Content-Type", w.FormDataContentType())
+    return req
+}
+
+func TestUploadBookImage(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+
+    store, err := storage.NewLocalStore(t.TempDir())
+    assert.NoError(t, err)
+    bookRepo := &recordingBookRepository{}
+    attachmentRepo := &mockAttachmentRepository{}
+    ctl := &BookController{
+        Service: &service.BookService{Repo: bookRepo},
+        Attachments: &service.AttachmentService{
+            Repo:   attachmentRepo,
+            Blobs:  store,
+            Signer: &storage.URLSigner{Key: []byte("test-key")},
+        },
+    }
+    r := gin.Default()
+    r.Use(authMiddlewareMock(mockOwnerID))
+    r.POST("/api/book/:id/image", ctl.UploadBookImage)
+
+    var img bytes.Buffer
+    assert.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 600, 400))))
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, imageUploadRequest(t, "/api/book/1/image", "cover.png", img.Bytes()))
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+    var res struct {
+        Image     service.AttachmentDownload `json:"image"`
+        Thumbnail service.AttachmentDownload `json:"thumbnail"`
+    }
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Equal(t, "cover.png", res.Image.FileName)
+    assert.Equal(t, "image/png", res.Image.ContentType)
+    assert.Equal(t, "cover_thumb.png", res.Thumbnail.FileName)
+    assert.NotEqual(t, res.Image.SHA256, res.Thumbnail.SHA256)
+    assert.Len(t, attachmentRepo.Attachments, 2)
+
+    // Book の画像は保存した添付を指す
+    assert.NotNil(t, bookRepo.Updated)
+    assert.Equal(t, "/api/attachment/"+res.Image.ID, bookRepo.Updated.ImgPath)
+
+    cases := []struct {
+        name   string
+        path   string
+        file   string
+        body   []byte
+        status int
+    }{
+        {"unsupported extension", "/api/book/1/image", "cover.gif", img.Bytes(), http.StatusBadRequest},
+        {"not an image", "/api/book/1/image", "cover.png", []byte("not an image"), http.StatusBadRequest},
+        {"unknown book", "/api/book/2/image", "cover.png", img.Bytes(), http.StatusNotFound},
+    }
+    for _, tc := range cases {
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, imageUploadRequest(t, tc.path, tc.file, tc.body))
+        assert.Equal(t, tc.status, w.Code, tc.name)
+    }
+    assert.Len(t, attachmentRepo.Attachments, 2)
+}
+
+// 旧ルート POST /file は book_id の本の所有者のみ
+func TestHundleUplondChecksBookOwner(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+
+    store, err := storage.NewLocalStore(t.TempDir())
+    assert.NoError(t, err)
+    attachmentRepo := &mockAttachmentRepository{}
+    ctl := &BookController{
+        Service: &service.BookService{Repo: &recordingBookRepository{}},
+        Attachments: &service.AttachmentService{
+            Repo:   attachmentRepo,
+            Blobs:  store,
+            Signer: &storage.URLSigner{Key: []byte("test-key")},
+        },
+    }
+
+    var img bytes.Buffer
+    assert.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 600, 400))))
+
+    upload := func(userID uint, bookID string) *httptest.ResponseRecorder {
+        var buf bytes.Buffer
+        mw := multipart.NewWriter(&buf)
+        if bookID != "" {
+            assert.NoError(t, mw.WriteField("book_id", bookID))
+        }
+        header := make(textproto.MIMEHeader)
+        header.Set("Content-Disposition", `form-data; name="upfile"; filename="cover.png"`)
+        header.Set("Content-Type", "image/png")
+        part, err := mw.CreatePart(header)
+        assert.NoError(t, err)
+        _, _ = part.Write(img.Bytes())
+        assert.NoError(t, mw.Close())
+        req, _ := http.NewRequest(http.MethodPost, "/file", &buf)
+        req.Header.Set("Content-Type", mw.FormDataContentType())
+
+        r := gin.New()
+        r.Use(authMiddlewareMock(userID))
+        r.POST("/file", ctl.HundleUplond)
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+        return w
+    }
+
+    w := upload(2, "1")
+    assert.Equal(t, http.StatusForbidden, w.Code)
+    assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+    assert.Empty(t, attachmentRepo.Attachments)
+
+    w = upload(mockOwnerID, "")
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+
+    w = upload(mockOwnerID, "1")
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    assert.Len(t, attachmentRepo.Attachments, 2)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 func BookEleteDisplayAction(c *gin.Context) {
@@ -14,12 +15,9 @@ func BookEleteDisplayAction(c *gin.Context) {
 // DeleteBook: DELETE /api/book/:id
 func (ctl *BookController) DeleteBook(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteBook(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Failed to delete book",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteBook(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Failed to delete book")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
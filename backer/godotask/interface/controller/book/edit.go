@@ -6,12 +6,14 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 
 // EditBook: PUT /api/book/:id
 func (ctl *BookController) EditBook(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var book model.Book
 	if err := c.ShouldBindJSON(&book); err != nil {
 		appErr := errors.NewAppError(
@@ -26,18 +28,13 @@ func (ctl *BookController) EditBook(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.UpdateBook(id, &book); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to edit book",
-		)
+	if err := ctl.Service.UpdateBook(userID, id, &book); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to edit book")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
 			"detail":  appErr.Detail,
 		})
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to edit book"})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Book edited", "book": book})
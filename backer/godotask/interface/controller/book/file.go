@@ -1,28 +1,69 @@
 package book
 
 import (
-	"crypto/sha1"
-	"fmt"
+	"bytes"
 	"net/http"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
 	"github.com/olahol/go-imageupload"
 )
+
 type UploadInput struct {
-    Description string `form:"description" binding:"max=200"`
+	Description string `form:"description" binding:"max=200"`
+}
+
+// HundleUplond: POST /file（旧ルート。multipart/form-data の "book_id" で対象の本を指定する）
+// 本の所有者を確認してから UploadBookImage と同じ処理を行う
+func (ctl *BookController) HundleUplond(c *gin.Context) {
+	bookID := c.PostForm("book_id")
+	if bookID == "" {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"book_id is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	// 画像を保存する前に、他のユーザーの本でないことを確認する
+	if _, err := ctl.Service.GetBookByID(authcontext.ScopeUserID(c), bookID); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to get book")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.Params = append(c.Params, gin.Param{Key: "id", Value: bookID})
+	ctl.UploadBookImage(c)
 }
 
-func HundleUplond(c *gin.Context) {
-	// バリデーション: ファイルがアップロードされているか
+// UploadBookImage: POST /api/book/:id/image (multipart/form-data, ファイルは "upfile")
+// 画像と 300x300 のサムネイルを添付として保存し、本の ImgPath を画像の添付に向ける
+func (ctl *BookController) UploadBookImage(c *gin.Context) {
+	id := c.Param("id")
 	var input UploadInput
 	if err := c.ShouldBind(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid form data",
-			"detail":  err.Error(),
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
 		})
 		return
 	}
@@ -30,9 +71,15 @@ func HundleUplond(c *gin.Context) {
 	// ファイルがアップロードされているか
 	file, err := c.FormFile("upfile")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "No file selected",
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"upfile is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
 		})
 		return
 	}
@@ -41,60 +88,88 @@ func HundleUplond(c *gin.Context) {
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 	allowedExt := map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
 	if !allowedExt[ext] {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Unsupported file type (only jpg, jpeg, png allowed)",
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"unsupported file type (only jpg, jpeg, png allowed)",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
 		})
 		return
 	}
 
-	// 画像処理
+	// 画像処理（保存前にデコードできることを確認する）
 	img, err := imageupload.Process(c.Request, "upfile")
+	var thumb *imageupload.Image
+	if err == nil {
+		thumb, err = imageupload.ThumbnailPNG(img, 300, 300)
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Failed to process image",
-			"detail":  err.Error(),
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error()+" | Failed to process image",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
 		})
 		return
 	}
 
-	thumb, err := imageupload.ThumbnailPNG(img, 300, 300)
+	userID := authcontext.ScopeUserID(c)
+	actorID, _ := authcontext.UserID(c)
+	image, err := ctl.Attachments.Upload(userID, actorID, &service.AttachmentUpload{
+		ResourceType: "book",
+		ResourceID:   id,
+		FileName:     file.Filename,
+		ContentType:  img.ContentType,
+		Description:  input.Description,
+		Body:         bytes.NewReader(img.Data),
+	})
 	if err != nil {
-		panic(err)
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to upload book image")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
 	}
-	h := sha1.Sum(thumb.Data)
-	thumbPath := fmt.Sprintf("./static/images/%s_%x.png",
-	time.Now().Format("20060102150405"), h[4])
-	if err := thumb.Save(thumbPath); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"msg": "unable to save file",
+	thumbnail, err := ctl.Attachments.Upload(userID, actorID, &service.AttachmentUpload{
+		ResourceType: "book",
+		ResourceID:   id,
+		FileName:     strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename)) + "_thumb.png",
+		ContentType:  thumb.ContentType,
+		Description:  "thumbnail",
+		Body:         bytes.NewReader(thumb.Data),
+	})
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to upload book thumbnail")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
 		})
 		return
 	}
-
-	// extension := filepath.Ext(file.Filename)
-	newFileName := "t" + ext
-	uploadPath := "./static/uploads/" + newFileName
-
-	if err := c.SaveUploadedFile(file, uploadPath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "error",
-			"message": "ファイルの保存に失敗しました",
+	if err := ctl.Service.UpdateBook(userID, id, &model.Book{ImgPath: "/api/attachment/" + image.ID}); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to edit book")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
 		})
 		return
 	}
-
 	c.JSON(http.StatusOK, gin.H{
-		"status":      "success",
-		"message":     "ファイルをアップロードしました",
-		"uploadPath":  uploadPath,
-		"thumbPath":   thumbPath,
-		"originalName": file.Filename,
+		"success":   true,
+		"message":   "Book image uploaded",
+		"image":     image,
+		"thumbnail": thumbnail,
 	})
-
-	// c.JSON(http.StatusOK, gin.H{
-	// 	"message": "Successfully upload",
-	// })
-
 }
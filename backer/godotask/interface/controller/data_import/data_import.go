@@ -0,0 +1,7 @@
+package data_import
+
+import "github.com/godotask/usecase/service"
+
+type DataImportController struct {
+	Service *service.ImportService
+}
@@ -0,0 +1,110 @@
+package data_import
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
+)
+
+// MaxImportBytes は取り込むファイルの上限（10MB）
+const MaxImportBytes = 10 << 20
+
+// インポートのモード
+const (
+	ModeDryRun = "dry_run"
+	ModeCommit = "commit"
+)
+
+// importFormat は format、ファイルの拡張子、Content-Type の順に形式を決める
+func importFormat(format, fileName, contentType string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return service.ImportFormatCSV
+	case ".json":
+		return service.ImportFormatJSON
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return service.ImportFormatCSV
+	case "application/json":
+		return service.ImportFormatJSON
+	}
+	return ""
+}
+
+func abort(c *gin.Context, appErr *errors.AppError, extra gin.H) {
+	body := gin.H{
+		"code":    appErr.Code,
+		"message": appErr.Message,
+		"detail":  appErr.Detail,
+	}
+	for k, v := range extra {
+		body[k] = v
+	}
+	c.JSON(appErr.HTTPStatus, body)
+}
+
+// ImportData: POST /api/import/:resource?mode=dry_run|commit&format=csv|json
+// シードと同じ列の CSV、または同じキーを持つオブジェクトの JSON 配列を現在のユーザーのレコードとして取り込む
+// ファイルは multipart/form-data の "file" またはリクエストボディで送る。mode の既定は dry_run（検証のみ）
+// 行の誤りは result.errors に行番号とともに返し、commit の場合は誤りが 1 行でもあれば何も保存しない
+func (ctl *DataImportController) ImportData(c *gin.Context) {
+	mode := c.DefaultQuery("mode", ModeDryRun)
+	if mode != ModeDryRun && mode != ModeCommit {
+		abort(c, errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), "mode must be dry_run or commit"), nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportBytes)
+	var body io.Reader = c.Request.Body
+	fileName, contentType := "", c.ContentType()
+	if strings.HasPrefix(contentType, "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			abort(c, errors.NewAppError(errors.VAL_MISSING_FIELD, errors.GetErrorMessage(errors.VAL_MISSING_FIELD), "file is required"), nil)
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			abort(c, errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), err.Error()), nil)
+			return
+		}
+		defer file.Close()
+		body, fileName, contentType = file, header.Filename, header.Header.Get("Content-Type")
+	}
+	format := importFormat(c.Query("format"), fileName, contentType)
+
+	// admin も含め、取り込んだレコードの所有者は操作したユーザー本人
+	userID, _ := authcontext.UserID(c)
+	result, err := ctl.Service.Import(userID, c.Param("resource"), format, body, mode == ModeCommit)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to import "+c.Param("resource"))
+		var extra gin.H
+		if result != nil {
+			extra = gin.H{"result": result}
+		}
+		abort(c, appErr, extra)
+		return
+	}
+	message := fmt.Sprintf("Validated %d rows", result.Total)
+	if result.Committed {
+		message = fmt.Sprintf("Imported %d rows", result.Total)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"result":  result,
+	})
+}
@@ -0,0 +1,249 @@
+package data_import
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockImportRepository struct {
+	// Owned はリソースごとのユーザーのレコード（モデルのスライス）
+	Owned map[string]interface{}
+	// Refs は参照できないレコード（"resource/id"）のエラー
+	Refs    map[string]error
+	Creates []interface{}
+	Updates []interface{}
+	Applied int
+	// OwnerIDs は FindOwned に渡されたユーザー ID
+	OwnerIDs []uint
+}
+
+func (m *MockImportRepository) FindOwned(userID uint, resourceType string, dest interface{}) error {
+	m.OwnerIDs = append(m.OwnerIDs, userID)
+	if owned, ok := m.Owned[resourceType]; ok {
+		reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(owned))
+	}
+	return nil
+}
+
+func (m *MockImportRepository) AuthorizeReference(userID uint, resourceType string, id interface{}) error {
+	return m.Refs[fmt.Sprintf("%s/%v", resourceType, id)]
+}
+
+func (m *MockImportRepository) Apply(creates, updates []interface{}) error {
+	m.Creates, m.Updates = creates, updates
+	m.Applied++
+	return nil
+}
+
+var now = time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func setupRouter(repo *MockImportRepository, userID uint) *gin.Engine {
+	return setupRouterAs(repo, userID, "editor")
+}
+
+func setupRouterAs(repo *MockImportRepository, userID uint, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ctl := &DataImportController{Service: &service.ImportService{
+		Repo: repo,
+		Now:  func() time.Time { return now },
+	}}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", role)
+	})
+	r.POST("/api/import/:resource", ctl.ImportData)
+	return r
+}
+
+func do(r *gin.Engine, path, contentType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func result(t *testing.T, rec *httptest.ResponseRecorder, status int) model.ImportResult {
+	require.Equal(t, status, rec.Code, rec.Body.String())
+	var res struct {
+		Result model.ImportResult `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res.Result
+}
+
+const taskCSV = `id,user_id,memory_id,title,description,date,status,priority
+1,9,0,Deburring,Remove burrs,2026-04-01,todo,2
+2,9,0,Polishing,,2026-04-02,todo,x
+5,9,7,Grinding,,2026-04-02,todo,1
+3,9,0,Deburring,Again,2026-04-03,todo,1
+4,9,0,Inspection,Check,2026-04-04,done,1
+`
+
+func TestImportDryRunReportsRowErrors(t *testing.T) {
+	repo := &MockImportRepository{
+		Owned: map[string]interface{}{"task": []model.Task{{ID: 5, UserID: 1, Title: "Inspection", Description: "Old", Status: "todo"}}},
+		Refs:  map[string]error{"memory/7": errors.ErrResourceNotFound},
+	}
+	r := setupRouter(repo, 1)
+
+	res := result(t, do(r, "/api/import/task", "text/csv", taskCSV), http.StatusOK)
+	assert.True(t, res.DryRun)
+	assert.False(t, res.Committed)
+	assert.Equal(t, 5, res.Total)
+	assert.Equal(t, 1, res.Created)
+	assert.Equal(t, 1, res.Updated)
+	assert.Equal(t, 3, res.Failed)
+	assert.Equal(t, []model.ImportRowError{
+		{Row: 3, Field: "priority", Message: "must be an integer"},
+		{Row: 4, Field: "memory_id", Message: "memory 7 does not exist or cannot be edited"},
+		{Row: 5, Message: "duplicates the record on row 2"},
+	}, res.Errors)
+	assert.Zero(t, repo.Applied)
+
+	// commit でも誤りがあれば何も保存しない
+	rec := do(r, "/api/import/task?mode=commit", "text/csv", taskCSV)
+	res = result(t, rec, http.StatusBadRequest)
+	assert.Contains(t, rec.Body.String(), "3 of 5 rows have errors; nothing was imported")
+	assert.Equal(t, 3, res.Failed)
+	assert.Zero(t, repo.Applied)
+}
+
+func TestImportCommitUpsertsByNaturalKey(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &MockImportRepository{Owned: map[string]interface{}{"task": []model.Task{
+		{ID: 5, UserID: 1, Title: "Inspection", Description: "Old", Status: "todo", Priority: 1, CreatedAt: created, UpdatedAt: created},
+		{ID: 6, UserID: 1, Title: "Packing", Description: "Box", Status: "todo", Priority: 1, CreatedAt: created, UpdatedAt: created},
+	}}}
+	r := setupRouter(repo, 1)
+
+	body := `[
+		{"id": 99, "user_id": 9, "title": "Deburring", "status": "todo", "priority": 2},
+		{"title": "Inspection", "description": "Check", "status": "done", "priority": 1},
+		{"title": "Packing", "description": "Box", "status": "todo", "priority": 1}
+	]`
+	res := result(t, do(r, "/api/import/task?mode=commit", "application/json", body), http.StatusOK)
+	assert.True(t, res.Committed)
+	assert.Equal(t, "json", res.Format)
+	assert.Equal(t, 1, res.Created)
+	assert.Equal(t, 1, res.Updated)
+	assert.Equal(t, 1, res.Unchanged)
+	assert.Empty(t, res.Errors)
+	require.Equal(t, 1, repo.Applied)
+
+	// 所有者は現在のユーザー、ID はファイルではなく既存のレコードのもの
+	require.Len(t, repo.Creates, 1)
+	task := repo.Creates[0].(*model.Task)
+	assert.Zero(t, task.ID)
+	assert.Equal(t, 1, task.UserID)
+	assert.Equal(t, now, task.CreatedAt)
+	require.Len(t, repo.Updates, 1)
+	task = repo.Updates[0].(*model.Task)
+	assert.Equal(t, 5, task.ID)
+	assert.Equal(t, "done", task.Status)
+	assert.Equal(t, created, task.CreatedAt)
+	assert.Equal(t, now, task.UpdatedAt)
+}
+
+func TestImportAsAdminOwnsRecords(t *testing.T) {
+	repo := &MockImportRepository{}
+	r := setupRouterAs(repo, 3, "admin")
+
+	res := result(t, do(r, "/api/import/task?mode=commit", "text/csv", "title,status\nDeburring,todo\n"), http.StatusOK)
+	assert.Equal(t, 1, res.Created)
+
+	// admin でも絞り込みなし（0）ではなく操作したユーザーのレコードとして取り込む
+	assert.Equal(t, []uint{3}, repo.OwnerIDs)
+	require.Len(t, repo.Creates, 1)
+	assert.Equal(t, 3, repo.Creates[0].(*model.Task).UserID)
+}
+
+func TestImportQuantificationLabelRevision(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &MockImportRepository{Owned: map[string]interface{}{"quantification_label": []model.QuantificationLabel{
+		{ID: "l1", UserID: 1, Domain: "deburring", OriginalText: "バリ高さ", NormalizedText: "バリ高さ", Unit: ptr("mm"), Status: "accepted", Validated: true, Version: 2, CreatedAt: created, UpdatedAt: created},
+		{ID: "l2", UserID: 1, Domain: "deburring", OriginalText: "かえり", NormalizedText: "かえり", Status: "submitted", Version: 1, CreatedAt: created, UpdatedAt: created},
+	}}}
+	r := setupRouter(repo, 1)
+
+	csv := "domain,original_text,unit,confidence,validated\ndeburring,バリ高さ,μm,0.9,true\ndeburring,かえり,,0.5,\ndeburring,ﾊﾞﾘ幅,mm,0.8,true\n"
+	res := result(t, do(r, "/api/import/quantification_label", "text/csv", csv), http.StatusOK)
+	assert.Equal(t, []model.ImportRowError{{Row: 3, Message: "label is under verification"}}, res.Errors)
+
+	csv = strings.Replace(csv, "deburring,かえり,,0.5,\n", "", 1)
+	res = result(t, do(r, "/api/import/quantification_label?mode=commit", "text/csv", csv), http.StatusOK)
+	assert.Equal(t, 1, res.Created)
+	assert.Equal(t, 1, res.Updated)
+
+	// 取り込んだラベルは検証前の下書き
+	require.Len(t, repo.Creates, 2)
+	revision := repo.Creates[0].(*model.LabelRevision)
+	label := repo.Creates[1].(*model.QuantificationLabel)
+	assert.Equal(t, "バリ幅", label.NormalizedText)
+	assert.Equal(t, "draft", label.Status)
+	assert.False(t, label.Validated)
+	assert.Equal(t, 1, label.Version)
+	assert.Equal(t, "1", label.CreatedBy)
+
+	// 更新したラベルは版を上げて下書きに戻し、改訂履歴を残す
+	require.Len(t, repo.Updates, 1)
+	label = repo.Updates[0].(*model.QuantificationLabel)
+	assert.Equal(t, "l1", label.ID)
+	assert.Equal(t, 3, label.Version)
+	assert.Equal(t, "draft", label.Status)
+	assert.False(t, label.Validated)
+	assert.Equal(t, "l1", revision.LabelID)
+	assert.Equal(t, 3, revision.Version)
+	assert.Contains(t, revision.Changes, "unit")
+	assert.Contains(t, revision.Changes, "status")
+}
+
+func TestImportMultipartAndValidation(t *testing.T) {
+	repo := &MockImportRepository{}
+	r := setupRouter(repo, 1)
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", "tasks.csv")
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("title\nDeburring\n"))
+	require.NoError(t, w.Close())
+	res := result(t, do(r, "/api/import/task", w.FormDataContentType(), buf.String()), http.StatusOK)
+	assert.Equal(t, "csv", res.Format)
+	assert.Equal(t, 1, res.Created)
+
+	for name, tc := range map[string]struct {
+		path, contentType, body string
+		status                  int
+	}{
+		"unknown resource": {"/api/import/user", "text/csv", "title\nA\n", http.StatusBadRequest},
+		"unknown mode":     {"/api/import/task?mode=force", "text/csv", "title\nA\n", http.StatusBadRequest},
+		"unknown format":   {"/api/import/task", "text/plain", "title\nA\n", http.StatusBadRequest},
+		"malformed json":   {"/api/import/task", "application/json", `{"title":"A"}`, http.StatusBadRequest},
+		"missing file":     {"/api/import/task", "multipart/form-data; boundary=x", "--x--\r\n", http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := do(r, tc.path, tc.contentType, tc.body)
+			assert.Equal(t, tc.status, rec.Code, rec.Body.String())
+		})
+	}
+	assert.Zero(t, repo.Applied)
+}
+
+func ptr[T any](v T) *T { return &v }
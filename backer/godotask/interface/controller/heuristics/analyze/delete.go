@@ -5,18 +5,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteAnalyzeData: DELETE /api/heuristics/analyze/:id
 func (ctl *HeuristicsAnalyzeController) DeleteAnalyzeData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	if err := ctl.Service.DeleteAnalyzeData(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to delete analyze data",
-		)
+	if err := ctl.Service.DeleteAnalyzeData(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete analyze data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,19 +5,17 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetAnalyzeData: GET /api/heuristics/analyze/:id
 func (ctl *HeuristicsAnalyzeController) GetAnalyzeData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	analysis, err := ctl.Service.GetAnalysisById(id)
+	analysis, err := ctl.Service.GetAnalysisById(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to get analyze data",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to get analyze data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,18 +5,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteInsightData: DELETE /api/heuristics/insight/:id
 func (ctl *HeuristicsInsightController) DeleteInsightData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	if err := ctl.Service.DeleteInsightData(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to delete insight data",
-		)
+	if err := ctl.Service.DeleteInsightData(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete insight data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,19 +5,17 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetInsightData: GET /api/heuristics/insight/:id
 func (ctl *HeuristicsInsightController) GetInsightData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	insight, err := ctl.Service.GetInsightById(id)
+	insight, err := ctl.Service.GetInsightById(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to get insight data",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to get insight data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
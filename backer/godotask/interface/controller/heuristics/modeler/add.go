@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddModelerData: POST /api/heuristics/modeler
@@ -28,13 +29,10 @@ func (ctl *HeuristicsModelerController) AddModelerData(c *gin.Context) {
 	}
 
 	// モデルデータを追加
-	putModeler, err := ctl.Service.CreateModelerData(&modeler)
+	userID, _ := authcontext.UserID(c)
+	putModeler, err := ctl.Service.CreateModelerData(userID, &modeler)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add modeler data",
-		)
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add modeler data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,18 +5,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteModelerData: DELETE /api/heuristics/modeler/:id
 func (ctl *HeuristicsModelerController) DeleteModelerData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	if err := ctl.Service.DeleteModelerData(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to delete Modeler data",
-		)
+	if err := ctl.Service.DeleteModelerData(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete Modeler data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
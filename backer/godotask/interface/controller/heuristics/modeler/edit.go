@@ -6,11 +6,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditModelerData: PUT /api/heuristics/modeler/:id
 func (ctl *HeuristicsModelerController) EditModelerData(c *gin.Context) {
 	id := c.Param("id") // URLパラメータからIDを取得
+	userID := authcontext.ScopeUserID(c)
 
 	var modeler model.HeuristicsModeler
 	// リクエストボディをバインド
@@ -29,12 +31,8 @@ func (ctl *HeuristicsModelerController) EditModelerData(c *gin.Context) {
 	}
 
 	// モデルデータを更新
-	if err := ctl.Service.UpdateModelerData(id, &modeler); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error()+" | Failed to update modeler data",
-		)
+	if err := ctl.Service.UpdateModelerData(userID, id, &modeler); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to update modeler data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,19 +5,17 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetModelerData: GET /api/heuristics/modeler/:id
 func (ctl *HeuristicsModelerController) GetModelerData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	modeler, err := ctl.Service.GetModelerById(id)
+	modeler, err := ctl.Service.GetModelerById(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to get modeler data",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to get modeler data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 func (ctl *HeuristicsPatternController) AddPatternData(c *gin.Context) {
@@ -27,13 +28,10 @@ func (ctl *HeuristicsPatternController) AddPatternData(c *gin.Context) {
 	}
 
 	// 分析データを追加
-	putPattern, err := ctl.Service.CreatePatternData(&pattern)
+	userID, _ := authcontext.UserID(c)
+	putPattern, err := ctl.Service.CreatePatternData(userID, &pattern)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add pattern",
-		)
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add pattern")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -6,11 +6,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditPatternData: PUT /api/heuristics/pattern/:id
 func (ctl *HeuristicsPatternController) EditPatternData(c *gin.Context) {
 	id := c.Param("id") // URLパラメータからIDを取得
+	userID := authcontext.ScopeUserID(c)
 
 	var pattern model.HeuristicsPattern
 	// リクエストボディをバインド
@@ -29,12 +31,8 @@ func (ctl *HeuristicsPatternController) EditPatternData(c *gin.Context) {
 	}
 
 	// 分析データを更新
-	if err := ctl.Service.UpdatePatternData(id, &pattern); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error()+" | Failed to update insight data",
-		)
+	if err := ctl.Service.UpdatePatternData(userID, id, &pattern); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to update insight data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,19 +5,17 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetPatternData: GET /api/heuristics/pattern/:id
 func (ctl *HeuristicsPatternController) GetPatternData(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 
-	pattern, err := ctl.Service.GetPatternById(id)
+	pattern, err := ctl.Service.GetPatternById(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error()+" | Failed to get pattern data",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to get pattern data")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
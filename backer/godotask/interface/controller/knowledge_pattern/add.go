@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddKnowledgePattern: POST /api/knowledge_pattern
@@ -24,12 +25,9 @@ func (ctl *KnowledgePatternController) AddKnowledgePattern(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.CreateKnowledgePattern(&knowledgePattern); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add knowledge pattern",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.CreateKnowledgePattern(userID, &knowledgePattern); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add knowledge pattern")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
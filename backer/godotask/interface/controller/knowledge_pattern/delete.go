@@ -5,17 +5,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteKnowledgePattern: DELETE /api/knowledge_pattern/:id
 func (ctl *KnowledgePatternController) DeleteKnowledgePattern(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteKnowledgePattern(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Failed to delete knowledge pattern",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteKnowledgePattern(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Failed to delete knowledge pattern")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
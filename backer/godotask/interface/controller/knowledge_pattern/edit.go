@@ -6,11 +6,13 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditKnowledgePattern: PUT /api/knowledge_pattern/:id
 func (ctl *KnowledgePatternController) EditKnowledgePattern(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var knowledgePattern model.KnowledgePattern
 	if err := c.ShouldBindJSON(&knowledgePattern); err != nil {
 		appErr := errors.NewAppError(
@@ -25,12 +27,8 @@ func (ctl *KnowledgePatternController) EditKnowledgePattern(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.UpdateKnowledgePattern(id, &knowledgePattern); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to edit process optimization",
-		)
+	if err := ctl.Service.UpdateKnowledgePattern(userID, id, &knowledgePattern); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to edit process optimization")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
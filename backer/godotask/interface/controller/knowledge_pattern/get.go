@@ -4,18 +4,16 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetKnowledgePattern: GET /api/knowledge_pattern/:id
 func (ctl *KnowledgePatternController) GetKnowledgePattern(c *gin.Context) {
 	id := c.Param("id")
-	knowledgePattern, err := ctl.Service.GetKnowledgePatternByID(id)
+	userID := authcontext.ScopeUserID(c)
+	knowledgePattern, err := ctl.Service.GetKnowledgePatternByID(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Knowledge pattern not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Knowledge pattern not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
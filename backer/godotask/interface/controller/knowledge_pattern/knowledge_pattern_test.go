@@ -9,6 +9,7 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    apperrors "github.com/godotask/errors"
     "github.com/godotask/infrastructure/db/model"
     "github.com/godotask/usecase/service"
 )
@@ -16,11 +17,23 @@ import (
 // モックリポジトリ
 type MockKnowledgePatternsRepository struct{}
 
-func (m *MockKnowledgePatternsRepository) Create(knowledgePattern *model.KnowledgePattern) error {
-  return nil
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
+    return nil
+}
+
+func (m *MockKnowledgePatternsRepository) Create(userID uint, knowledgePattern *model.KnowledgePattern) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockKnowledgePatternsRepository) FindByID(id string) (*model.KnowledgePattern, error) {
+func (m *MockKnowledgePatternsRepository) FindByID(userID uint, id string) (*model.KnowledgePattern, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
   return &model.KnowledgePattern{
       ID:             "1",
       Type:           "tacit",
@@ -37,7 +50,7 @@ func (m *MockKnowledgePatternsRepository) FindByID(id string) (*model.KnowledgeP
   }, nil
 }
 
-func (m *MockKnowledgePatternsRepository) FindAll() ([]model.KnowledgePattern, error) {
+func (m *MockKnowledgePatternsRepository) FindAll(userID uint) ([]model.KnowledgePattern, error) {
   return []model.KnowledgePattern{
     {
 			ID:             "1",
@@ -56,17 +69,26 @@ func (m *MockKnowledgePatternsRepository) FindAll() ([]model.KnowledgePattern, e
   }, nil
 }
 
-func (m *MockKnowledgePatternsRepository) Update(id string, knowledgePattern *model.KnowledgePattern) error {
-    return nil
+func (m *MockKnowledgePatternsRepository) Update(userID uint, id string, knowledgePattern *model.KnowledgePattern) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockKnowledgePatternsRepository) Delete(id string) error {
-    return nil
+func (m *MockKnowledgePatternsRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
 }
 
 // テスト用ルーター
 func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
     r := gin.Default()
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
     mockRepo := &MockKnowledgePatternsRepository{}
     mockService := &service.KnowledgePatternService{Repo: mockRepo}
     ctl := &KnowledgePatternController{Service: mockService}
@@ -131,3 +153,18 @@ func TestDeleteKnowledgePattern(t *testing.T) {
     assert.Equal(t, http.StatusOK, w.Code)
     assert.Contains(t, w.Body.String(), "Knowledge pattern deleted")
 }
+
+func TestKnowledgePatternAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "editor")
+
+    for _, method := range []string{http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/knowledge_patterns/1", bytes.NewBuffer([]byte(`{"task_id": 1}`)))
+        req.Header.Set("Content-Type", "application/json")
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
+}
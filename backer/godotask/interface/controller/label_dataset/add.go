@@ -0,0 +1,45 @@
+package label_dataset
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddLabelDataset: POST /api/label_dataset
+// label_ids のラベル（自分のもの、または検証済みで公開されたもの）からデータセットを作成し、品質メトリクスを求める
+func (ctl *LabelDatasetController) AddLabelDataset(c *gin.Context) {
+	var req model.DatasetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	dataset, err := ctl.Service.CreateDataset(authcontext.ScopeUserID(c), actorID, &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add label dataset")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Label dataset added",
+		"label_dataset": dataset,
+	})
+}
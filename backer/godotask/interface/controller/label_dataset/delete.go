@@ -0,0 +1,26 @@
+package label_dataset
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// DeleteLabelDataset: DELETE /api/label_dataset/:id
+func (ctl *LabelDatasetController) DeleteLabelDataset(c *gin.Context) {
+	if err := ctl.Service.DeleteDataset(authcontext.ScopeUserID(c), c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete label dataset")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Label dataset deleted",
+	})
+}
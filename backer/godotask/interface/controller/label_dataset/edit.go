@@ -0,0 +1,44 @@
+package label_dataset
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// EditLabelDataset: PUT /api/label_dataset/:id
+// label_ids を指定した場合はデータセットのラベルを置き換え、品質メトリクスを求め直す
+func (ctl *LabelDatasetController) EditLabelDataset(c *gin.Context) {
+	var req model.DatasetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	dataset, err := ctl.Service.UpdateDataset(authcontext.ScopeUserID(c), c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to edit label dataset")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Label dataset edited",
+		"label_dataset": dataset,
+	})
+}
@@ -0,0 +1,28 @@
+package label_dataset
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetLabelDataset: GET /api/label_dataset/:id
+func (ctl *LabelDatasetController) GetLabelDataset(c *gin.Context) {
+	dataset, err := ctl.Service.GetDataset(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Label dataset not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Label dataset retrieved",
+		"label_dataset": dataset,
+	})
+}
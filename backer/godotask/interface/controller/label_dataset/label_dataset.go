@@ -0,0 +1,7 @@
+package label_dataset
+
+import "github.com/godotask/usecase/service"
+
+type LabelDatasetController struct {
+	Service *service.LabelDatasetService
+}
@@ -0,0 +1,301 @@
+package label_dataset
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLabelDatasetRepository struct {
+	Datasets      map[string]model.LabelDataset
+	Labels        map[string]model.QuantificationLabel
+	Verifications []model.LabelVerification
+	Snapshots     []model.LabelDatasetSnapshot
+}
+
+func (m *MockLabelDatasetRepository) Create(userID uint, dataset *model.LabelDataset) error {
+	m.Datasets[dataset.ID] = *dataset
+	return nil
+}
+func (m *MockLabelDatasetRepository) FindByID(userID uint, id string) (*model.LabelDataset, error) {
+	d, ok := m.Datasets[id]
+	if !ok {
+		return nil, errors.ErrResourceNotFound
+	}
+	if userID != 0 && uint(d.UserID) != userID {
+		return nil, errors.ErrResourceAccessDenied
+	}
+	return &d, nil
+}
+func (m *MockLabelDatasetRepository) FindAll(userID uint) ([]model.LabelDataset, error) {
+	var out []model.LabelDataset
+	for _, d := range m.Datasets {
+		if userID == 0 || uint(d.UserID) == userID {
+			d.Labels = nil
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+func (m *MockLabelDatasetRepository) Modify(userID uint, id string, update func(dataset *model.LabelDataset) error) (*model.LabelDataset, error) {
+	d, err := m.FindByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(d); err != nil {
+		return nil, err
+	}
+	m.Datasets[id] = *d
+	return d, nil
+}
+func (m *MockLabelDatasetRepository) UpdateMetrics(dataset *model.LabelDataset) error {
+	m.Datasets[dataset.ID] = *dataset
+	return nil
+}
+func (m *MockLabelDatasetRepository) Delete(userID uint, id string) error {
+	if _, err := m.FindByID(userID, id); err != nil {
+		return err
+	}
+	delete(m.Datasets, id)
+	return nil
+}
+func (m *MockLabelDatasetRepository) FindLabels(userID uint, ids []string) ([]model.QuantificationLabel, error) {
+	var out []model.QuantificationLabel
+	for _, id := range ids {
+		l, ok := m.Labels[id]
+		public := l.Validated && (l.PublicVisibility == nil || *l.PublicVisibility)
+		if ok && (userID == 0 || uint(l.UserID) == userID || public) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+func (m *MockLabelDatasetRepository) FindVerifications(labelIDs []string) ([]model.LabelVerification, error) {
+	var out []model.LabelVerification
+	for _, v := range m.Verifications {
+		for _, id := range labelIDs {
+			if v.LabelID == id && v.Round == m.Labels[id].ReviewRound {
+				out = append(out, v)
+			}
+		}
+	}
+	return out, nil
+}
+func (m *MockLabelDatasetRepository) CreateSnapshot(userID uint, dataset *model.LabelDataset, snapshot *model.LabelDatasetSnapshot) error {
+	if _, err := m.FindByID(userID, dataset.ID); err != nil {
+		return err
+	}
+	snapshot.Version = 1
+	for _, s := range m.Snapshots {
+		if s.DatasetID == dataset.ID && s.Version >= snapshot.Version {
+			snapshot.Version = s.Version + 1
+		}
+	}
+	m.Snapshots = append(m.Snapshots, *snapshot)
+	dataset.Version = strconv.Itoa(snapshot.Version)
+	m.Datasets[dataset.ID] = *dataset
+	return nil
+}
+func (m *MockLabelDatasetRepository) FindSnapshots(userID uint, datasetID string) ([]model.LabelDatasetSnapshot, error) {
+	if _, err := m.FindByID(userID, datasetID); err != nil {
+		return nil, err
+	}
+	var out []model.LabelDatasetSnapshot
+	for _, s := range m.Snapshots {
+		if s.DatasetID == datasetID {
+			s.Content = ""
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (m *MockLabelDatasetRepository) FindSnapshot(userID uint, datasetID string, version int) (*model.LabelDatasetSnapshot, error) {
+	if _, err := m.FindByID(userID, datasetID); err != nil {
+		return nil, err
+	}
+	for _, s := range m.Snapshots {
+		if s.DatasetID == datasetID && s.Version == version {
+			return &s, nil
+		}
+	}
+	return nil, errors.ErrResourceNotFound
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// ユーザー 1 のラベル（採用済み 2 件・下書き 1 件）と、ユーザー 2 の公開・非公開の検証済みラベル
+func newRepo() *MockLabelDatasetRepository {
+	return &MockLabelDatasetRepository{
+		Datasets: map[string]model.LabelDataset{},
+		Labels: map[string]model.QuantificationLabel{
+			"burr": {ID: "burr", UserID: 1, OriginalText: "バリの高さ", Category: "burr", Value: ptr(0.1), Unit: ptr("mm"),
+				MinRange: ptr(0.05), MaxRange: ptr(0.2), TypicalValue: ptr(0.1), Precision: ptr(2), Confidence: ptr(0.9),
+				NormalizedText: "バリ 高さ", Context: "deburring", Status: "accepted", Validated: true, ReviewRound: 1, Accuracy: ptr(1.0), Version: 3},
+			"speed": {ID: "speed", UserID: 1, OriginalText: "feed speed", Category: "speed", Value: ptr(100.0), Unit: ptr("mm/min"),
+				Status: "accepted", Validated: true, ReviewRound: 1, Accuracy: ptr(0.5), Version: 2},
+			"draft":  {ID: "draft", UserID: 1, OriginalText: "surface", Category: "burr", Status: "draft", Version: 1},
+			"shared": {ID: "shared", UserID: 2, OriginalText: "flash", Category: "burr", Value: ptr(0.2), Status: "accepted", Validated: true},
+			"hidden": {ID: "hidden", UserID: 2, OriginalText: "secret", Category: "burr", Status: "accepted", Validated: true, PublicVisibility: ptr(false)},
+		},
+		Verifications: []model.LabelVerification{
+			{LabelID: "burr", Round: 1, VerifierID: 2, Decision: "accept"},
+			{LabelID: "burr", Round: 1, VerifierID: 3, Decision: "accept"},
+			{LabelID: "speed", Round: 1, VerifierID: 2, Decision: "accept"},
+			{LabelID: "speed", Round: 1, VerifierID: 3, Decision: "reject"},
+			{LabelID: "speed", Round: 1, VerifierID: 4, Decision: "accept"},
+			{LabelID: "speed", Round: 0, VerifierID: 5, Decision: "reject"},
+		},
+	}
+}
+
+func setupRouter(repo *MockLabelDatasetRepository, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ctl := &LabelDatasetController{Service: &service.LabelDatasetService{
+		Repo: repo,
+		Now:  func() time.Time { return time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC) },
+	}}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	r.POST("/api/label_dataset", ctl.AddLabelDataset)
+	r.GET("/api/label_dataset", ctl.ListLabelDatasets)
+	r.GET("/api/label_dataset/:id", ctl.GetLabelDataset)
+	r.PUT("/api/label_dataset/:id", ctl.EditLabelDataset)
+	r.DELETE("/api/label_dataset/:id", ctl.DeleteLabelDataset)
+	r.POST("/api/label_dataset/:id/snapshot", ctl.AddSnapshot)
+	r.GET("/api/label_dataset/:id/snapshot", ctl.ListSnapshots)
+	r.GET("/api/label_dataset/:id/snapshot/:version", ctl.DownloadSnapshot)
+	return r
+}
+
+func do(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder, key string) T {
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	var v T
+	require.NoError(t, json.Unmarshal(res[key], &v))
+	return v
+}
+
+func TestLabelDatasetQuality(t *testing.T) {
+	repo := newRepo()
+	r := setupRouter(repo, 1)
+
+	// 他のユーザーの非公開ラベルは加えられない
+	rec := do(r, http.MethodPost, "/api/label_dataset", `{"name":"machining","label_ids":["burr","hidden"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "hidden")
+
+	rec = do(r, http.MethodPost, "/api/label_dataset", `{"name":"machining","license":"CC-BY-4.0","label_ids":["burr","speed","draft","shared","burr"]}`)
+	dataset := decode[model.LabelDataset](t, rec, "label_dataset")
+	assert.Equal(t, 1, dataset.UserID)
+	assert.Equal(t, 4, dataset.TotalLabels)
+	assert.Equal(t, 3, dataset.VerifiedLabels)
+	assert.InDelta(t, 0.75, dataset.AverageAccuracy, 1e-9)
+	assert.InDelta(t, (1.0+3.0/9+1.0/9+2.0/9)/4, dataset.Completeness, 1e-9)
+	// burr 3 件・speed 1 件
+	assert.InDelta(t, 1-(9.0/16+1.0/16), dataset.Diversity, 1e-9)
+	assert.Greater(t, dataset.Balance, 0.8)
+	assert.Less(t, dataset.Balance, 0.82)
+	assert.Less(t, dataset.Consistency, 0.0)
+
+	// ラベルを置き換えるとメトリクスを求め直す
+	dataset = decode[model.LabelDataset](t, do(r, http.MethodPut, "/api/label_dataset/"+dataset.ID, `{"name":"burr only","label_ids":["burr"]}`), "label_dataset")
+	assert.Equal(t, 1, dataset.TotalLabels)
+	assert.Equal(t, 1.0, dataset.Completeness)
+	assert.Equal(t, 1.0, dataset.Consistency)
+	assert.Equal(t, 0.0, dataset.Diversity)
+	dataset = decode[model.LabelDataset](t, do(r, http.MethodPut, "/api/label_dataset/"+dataset.ID, `{"name":"renamed"}`), "label_dataset")
+	assert.Equal(t, "renamed", dataset.Name)
+	assert.Equal(t, 1, dataset.TotalLabels)
+
+	assert.Len(t, decode[[]model.LabelDataset](t, do(r, http.MethodGet, "/api/label_dataset", ""), "label_datasets"), 1)
+	rec = do(setupRouter(repo, 2), http.MethodGet, "/api/label_dataset/"+dataset.ID, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodPost, "/api/label_dataset", `{"label_ids":["burr"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+func TestLabelDatasetSnapshots(t *testing.T) {
+	repo := newRepo()
+	r := setupRouter(repo, 1)
+
+	empty := decode[model.LabelDataset](t, do(r, http.MethodPost, "/api/label_dataset", `{"name":"empty"}`), "label_dataset")
+	rec := do(r, http.MethodPost, "/api/label_dataset/"+empty.ID+"/snapshot", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	dataset := decode[model.LabelDataset](t, do(r, http.MethodPost, "/api/label_dataset", `{"name":"machining","label_ids":["speed","burr"]}`), "label_dataset")
+	path := "/api/label_dataset/" + dataset.ID
+
+	first := decode[model.LabelDatasetSnapshot](t, do(r, http.MethodPost, path+"/snapshot", ""), "snapshot")
+	assert.Equal(t, 1, first.Version)
+	assert.Equal(t, 2, first.LabelCount)
+	assert.EqualValues(t, 2, first.Metrics["verified_labels"])
+
+	// ラベルが変わると次の版の内容も変わる
+	label := repo.Labels["burr"]
+	label.Value = ptr(0.12)
+	repo.Labels["burr"] = label
+	stored := repo.Datasets[dataset.ID]
+	stored.Labels = []model.QuantificationLabel{label, repo.Labels["speed"]}
+	repo.Datasets[dataset.ID] = stored
+	second := decode[model.LabelDatasetSnapshot](t, do(r, http.MethodPost, path+"/snapshot", ""), "snapshot")
+	assert.Equal(t, 2, second.Version)
+	assert.NotEqual(t, first.Checksum, second.Checksum)
+	assert.Equal(t, "2", decode[model.LabelDataset](t, do(r, http.MethodGet, path, ""), "label_dataset").Version)
+
+	snapshots := decode[[]model.LabelDatasetSnapshot](t, do(r, http.MethodGet, path+"/snapshot", ""), "snapshots")
+	assert.Len(t, snapshots, 2)
+
+	rec = do(r, http.MethodGet, path+"/snapshot/1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "-v1.jsonl")
+	sum := sha256.Sum256(rec.Body.Bytes())
+	assert.Equal(t, first.Checksum, hex.EncodeToString(sum[:]))
+
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 2)
+	assert.Equal(t, "speed", records[0]["id"])
+	assert.Equal(t, "バリの高さ", records[1]["text"])
+	assert.Equal(t, 0.1, records[1]["value"])
+	assert.Equal(t, "accepted", records[1]["status"])
+	assert.EqualValues(t, 3, records[1]["label_version"])
+
+	rec = do(r, http.MethodGet, path+"/snapshot/3", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodGet, path+"/snapshot/latest", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(setupRouter(repo, 2), http.MethodGet, path+"/snapshot/1", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,28 @@
+package label_dataset
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListLabelDatasets: GET /api/label_dataset
+func (ctl *LabelDatasetController) ListLabelDatasets(c *gin.Context) {
+	datasets, err := ctl.Service.ListDatasets(authcontext.ScopeUserID(c))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list label datasets")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"message":        "Label datasets retrieved",
+		"label_datasets": datasets,
+	})
+}
@@ -0,0 +1,72 @@
+package label_dataset
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddSnapshot: POST /api/label_dataset/:id/snapshot
+// データセットの現在のラベルを次の版のスナップショット（JSONL）として保存する
+func (ctl *LabelDatasetController) AddSnapshot(c *gin.Context) {
+	actorID, _ := authcontext.UserID(c)
+	snapshot, err := ctl.Service.CreateSnapshot(authcontext.ScopeUserID(c), actorID, c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to create dataset snapshot")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"message":  "Dataset snapshot created",
+		"snapshot": snapshot,
+	})
+}
+
+// ListSnapshots: GET /api/label_dataset/:id/snapshot
+func (ctl *LabelDatasetController) ListSnapshots(c *gin.Context) {
+	snapshots, err := ctl.Service.ListSnapshots(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list dataset snapshots")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Dataset snapshots retrieved",
+		"snapshots": snapshots,
+	})
+}
+
+// DownloadSnapshot: GET /api/label_dataset/:id/snapshot/:version
+// スナップショットを JSONL（1 行 1 ラベル）でダウンロードする。ETag は内容の SHA-256
+func (ctl *LabelDatasetController) DownloadSnapshot(c *gin.Context) {
+	snapshot, err := ctl.Service.GetSnapshot(authcontext.ScopeUserID(c), c.Param("id"), c.Param("version"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Dataset snapshot not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	filename := fmt.Sprintf("dataset-%s-v%d.jsonl", snapshot.DatasetID, snapshot.Version)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("ETag", strconv.Quote(snapshot.Checksum))
+	c.Data(http.StatusOK, "application/x-ndjson", []byte(snapshot.Content))
+}
@@ -0,0 +1,50 @@
+package label_ontology
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ExpandLabelQuery: GET /api/label_query_expansion?text=
+// 語句を正規化し、一致するラベルとその同義語のラベルの語句に展開する
+func (ctl *LabelOntologyController) ExpandLabelQuery(c *gin.Context) {
+	expansion, err := ctl.Service.ExpandQuery(authcontext.ScopeUserID(c), c.Query("text"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to expand label query")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Label query expanded",
+		"expansion": expansion,
+	})
+}
+
+// NormalizeLabels: POST /api/quantification_label_normalization
+// 自分のラベルの正規化した語句（normalized_text）を揃え、変更した件数を返す
+func (ctl *LabelOntologyController) NormalizeLabels(c *gin.Context) {
+	actorID, _ := authcontext.UserID(c)
+	updated, err := ctl.Service.NormalizeLabels(actorID)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to normalize labels")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Labels normalized",
+		"updated": updated,
+	})
+}
@@ -0,0 +1,56 @@
+package label_ontology
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// hierarchy は depth（省略時は無制限）を読み、walk の結果を返す
+func (ctl *LabelOntologyController) hierarchy(c *gin.Context, action string, walk func(userID uint, id string, depth int) ([]model.OntologyNode, error)) {
+	depth := 0
+	if v := c.Query("depth"); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil || d < 0 {
+			appErr := errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), "depth must be a non-negative integer")
+			c.JSON(appErr.HTTPStatus, gin.H{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"detail":  appErr.Detail,
+			})
+			return
+		}
+		depth = d
+	}
+	nodes, err := walk(authcontext.ScopeUserID(c), c.Param("id"), depth)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list label "+action)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Label " + action + " retrieved",
+		"labels":  nodes,
+	})
+}
+
+// ListLabelAncestors: GET /api/quantification_label/:id/ancestor?depth=
+// 上位概念（同義語の上位概念を含む）を近い順に返す
+func (ctl *LabelOntologyController) ListLabelAncestors(c *gin.Context) {
+	ctl.hierarchy(c, "ancestors", ctl.Service.Ancestors)
+}
+
+// ListLabelDescendants: GET /api/quantification_label/:id/descendant?depth=
+// 下位概念（同義語の下位概念を含む）を近い順に返す
+func (ctl *LabelOntologyController) ListLabelDescendants(c *gin.Context) {
+	ctl.hierarchy(c, "descendants", ctl.Service.Descendants)
+}
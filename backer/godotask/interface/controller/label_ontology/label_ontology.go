@@ -0,0 +1,7 @@
+package label_ontology
+
+import "github.com/godotask/usecase/service"
+
+type LabelOntologyController struct {
+	Service *service.LabelOntologyService
+}
@@ -0,0 +1,277 @@
+package label_ontology
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLabelOntologyRepository struct {
+	Labels    map[string]model.QuantificationLabel
+	Relations []model.LabelRelation
+}
+
+func (m *MockLabelOntologyRepository) visible(userID uint, l model.QuantificationLabel) bool {
+	public := l.Validated && l.PublicVisibility != nil && *l.PublicVisibility
+	return userID == 0 || uint(l.UserID) == userID || public
+}
+
+func (m *MockLabelOntologyRepository) FindRelations() ([]model.LabelRelation, error) {
+	return m.Relations, nil
+}
+
+func (m *MockLabelOntologyRepository) FindLabelRelations(labelID string) ([]model.LabelRelation, error) {
+	var out []model.LabelRelation
+	for _, r := range m.Relations {
+		if r.SourceID == labelID || r.TargetID == labelID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *MockLabelOntologyRepository) CreateRelation(userID uint, relation *model.LabelRelation, check func(relations []model.LabelRelation) error) error {
+	source, ok := m.Labels[relation.SourceID]
+	if !ok {
+		return errors.ErrResourceNotFound
+	}
+	if userID != 0 && uint(source.UserID) != userID {
+		return errors.ErrResourceAccessDenied
+	}
+	if target, ok := m.Labels[relation.TargetID]; !ok || !m.visible(userID, target) {
+		return errors.ErrResourceNotFound
+	}
+	if err := check(m.Relations); err != nil {
+		return err
+	}
+	m.Relations = append(m.Relations, *relation)
+	return nil
+}
+
+func (m *MockLabelOntologyRepository) DeleteRelation(userID uint, id string) error {
+	for i, r := range m.Relations {
+		if r.ID != id {
+			continue
+		}
+		if userID != 0 && uint(r.UserID) != userID {
+			return errors.ErrResourceAccessDenied
+		}
+		m.Relations = append(m.Relations[:i], m.Relations[i+1:]...)
+		return nil
+	}
+	return errors.ErrResourceNotFound
+}
+
+func (m *MockLabelOntologyRepository) FindLabels(userID uint, ids []string) ([]model.QuantificationLabel, error) {
+	var out []model.QuantificationLabel
+	for _, id := range ids {
+		if l, ok := m.Labels[id]; ok && m.visible(userID, l) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (m *MockLabelOntologyRepository) FindLabelsByText(userID uint, normalized string) ([]model.QuantificationLabel, error) {
+	var out []model.QuantificationLabel
+	for _, l := range m.Labels {
+		if l.NormalizedText == normalized && m.visible(userID, l) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (m *MockLabelOntologyRepository) NormalizeTexts(userID uint, normalize func(label *model.QuantificationLabel) string) (int, error) {
+	updated := 0
+	for id, l := range m.Labels {
+		if uint(l.UserID) != userID {
+			continue
+		}
+		if text := normalize(&l); text != l.NormalizedText {
+			l.NormalizedText = text
+			m.Labels[id] = l
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// ユーザー 1 の「不良 > 品質」「バリ」「かえり」「バリ高さ」と、ユーザー 2 の公開・非公開のラベル
+func newRepo() *MockLabelOntologyRepository {
+	return &MockLabelOntologyRepository{
+		Labels: map[string]model.QuantificationLabel{
+			"quality": {ID: "quality", UserID: 1, OriginalText: "品質", NormalizedText: "品質"},
+			"defect":  {ID: "defect", UserID: 1, OriginalText: "不良", NormalizedText: "不良"},
+			"burr":    {ID: "burr", UserID: 1, OriginalText: "バリ", NormalizedText: "バリ"},
+			"flash":   {ID: "flash", UserID: 1, OriginalText: "かえり", NormalizedText: "かえり"},
+			"height":  {ID: "height", UserID: 1, OriginalText: "ﾊﾞﾘ　高さ", NormalizedText: ""},
+			"shared":  {ID: "shared", UserID: 2, OriginalText: "Burr", NormalizedText: "burr", Validated: true, PublicVisibility: ptr(true)},
+			"hidden":  {ID: "hidden", UserID: 2, OriginalText: "ばり", NormalizedText: "ばり"},
+		},
+	}
+}
+
+func setupRouter(repo *MockLabelOntologyRepository, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ctl := &LabelOntologyController{Service: &service.LabelOntologyService{
+		Repo: repo,
+		Now:  func() time.Time { return time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC) },
+	}}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	r.POST("/api/label_relation", ctl.AddLabelRelation)
+	r.DELETE("/api/label_relation/:id", ctl.DeleteLabelRelation)
+	r.GET("/api/quantification_label/:id/relation", ctl.ListLabelRelations)
+	r.GET("/api/quantification_label/:id/ancestor", ctl.ListLabelAncestors)
+	r.GET("/api/quantification_label/:id/descendant", ctl.ListLabelDescendants)
+	r.GET("/api/label_query_expansion", ctl.ExpandLabelQuery)
+	r.POST("/api/quantification_label_normalization", ctl.NormalizeLabels)
+	return r
+}
+
+func do(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder, key string) T {
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	var v T
+	require.NoError(t, json.Unmarshal(res[key], &v))
+	return v
+}
+
+func ids(nodes []model.OntologyNode) []string {
+	out := []string{}
+	for _, n := range nodes {
+		out = append(out, n.LabelID)
+	}
+	return out
+}
+
+func TestLabelRelationConsistency(t *testing.T) {
+	repo := newRepo()
+	r := setupRouter(repo, 1)
+	add := func(body string) *httptest.ResponseRecorder {
+		return do(r, http.MethodPost, "/api/label_relation", body)
+	}
+
+	synonym := decode[model.LabelRelation](t, add(`{"source_id":"burr","target_id":"flash","relation_type":"synonym","strength":0.8}`), "label_relation")
+	assert.True(t, synonym.Bidirectional)
+	assert.Equal(t, 1, synonym.UserID)
+	assert.Equal(t, 0.8, synonym.Strength)
+	hypernym := decode[model.LabelRelation](t, add(`{"source_id":"burr","target_id":"defect","relation_type":"hypernym"}`), "label_relation")
+	assert.False(t, hypernym.Bidirectional)
+	assert.Equal(t, 1.0, hypernym.Strength)
+	decode[model.LabelRelation](t, add(`{"source_id":"quality","target_id":"defect","relation_type":"hyponym"}`), "label_relation")
+	decode[model.LabelRelation](t, add(`{"source_id":"height","target_id":"flash","relation_type":"hypernym"}`), "label_relation")
+
+	// 同じ関係（同義語は逆向きも同じ）は 409
+	rec := add(`{"source_id":"flash","target_id":"burr","relation_type":"synonym"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	rec = add(`{"source_id":"defect","target_id":"burr","relation_type":"hyponym"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+	for name, body := range map[string]string{
+		"cycle via synonym":     `{"source_id":"quality","target_id":"flash","relation_type":"hypernym"}`,
+		"synonym of ancestor":   `{"source_id":"flash","target_id":"quality","relation_type":"synonym"}`,
+		"hypernym of synonym":   `{"source_id":"flash","target_id":"burr","relation_type":"hypernym"}`,
+		"one-way synonym":       `{"source_id":"burr","target_id":"quality","relation_type":"synonym","bidirectional":false}`,
+		"two-way hypernym":      `{"source_id":"height","target_id":"quality","relation_type":"hypernym","bidirectional":true}`,
+		"self relation":         `{"source_id":"burr","target_id":"burr","relation_type":"synonym"}`,
+		"unknown relation type": `{"source_id":"burr","target_id":"quality","relation_type":"antonym"}`,
+		"strength out of range": `{"source_id":"burr","target_id":"quality","relation_type":"hypernym","strength":1.5}`,
+	} {
+		rec := add(body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name+": "+rec.Body.String())
+	}
+	assert.Contains(t, add(`{"source_id":"defect","target_id":"height","relation_type":"hypernym"}`).Body.String(), "VAL_005")
+
+	// 終点は参照できるラベル、起点は自分のラベルのみ
+	rec = add(`{"source_id":"burr","target_id":"hidden","relation_type":"synonym"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+	decode[model.LabelRelation](t, add(`{"source_id":"burr","target_id":"shared","relation_type":"synonym","strength":0.5}`), "label_relation")
+	rec = do(setupRouter(repo, 2), http.MethodPost, "/api/label_relation", `{"source_id":"burr","target_id":"shared","relation_type":"hypernym"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	assert.Len(t, decode[[]model.LabelRelation](t, do(r, http.MethodGet, "/api/quantification_label/burr/relation", ""), "label_relations"), 3)
+	rec = do(r, http.MethodGet, "/api/quantification_label/hidden/relation", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	// 関係を作成したユーザーのみ削除できる
+	rec = do(setupRouter(repo, 2), http.MethodDelete, "/api/label_relation/"+synonym.ID, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Equal(t, http.StatusOK, do(r, http.MethodDelete, "/api/label_relation/"+synonym.ID, "").Code)
+	assert.Len(t, decode[[]model.LabelRelation](t, do(r, http.MethodGet, "/api/quantification_label/burr/relation", ""), "label_relations"), 2)
+}
+
+func TestLabelHierarchyAndExpansion(t *testing.T) {
+	repo := newRepo()
+	r := setupRouter(repo, 1)
+	for _, body := range []string{
+		`{"source_id":"burr","target_id":"flash","relation_type":"synonym","strength":0.8}`,
+		`{"source_id":"flash","target_id":"shared","relation_type":"synonym","strength":0.5}`,
+		`{"source_id":"burr","target_id":"defect","relation_type":"hypernym"}`,
+		`{"source_id":"defect","target_id":"quality","relation_type":"hypernym"}`,
+		`{"source_id":"flash","target_id":"height","relation_type":"hyponym"}`,
+	} {
+		require.Equal(t, http.StatusOK, do(r, http.MethodPost, "/api/label_relation", body).Code, body)
+	}
+
+	// 同義語の上位概念も上位概念になる
+	ancestors := decode[[]model.OntologyNode](t, do(r, http.MethodGet, "/api/quantification_label/flash/ancestor", ""), "labels")
+	assert.Equal(t, []string{"defect", "quality"}, ids(ancestors))
+	assert.Equal(t, []int{1, 2}, []int{ancestors[0].Depth, ancestors[1].Depth})
+	assert.Equal(t, "不良", ancestors[0].Text)
+	ancestors = decode[[]model.OntologyNode](t, do(r, http.MethodGet, "/api/quantification_label/height/ancestor?depth=1", ""), "labels")
+	assert.Equal(t, []string{"burr", "flash", "shared"}, ids(ancestors))
+
+	descendants := decode[[]model.OntologyNode](t, do(r, http.MethodGet, "/api/quantification_label/quality/descendant", ""), "labels")
+	assert.Equal(t, []string{"defect", "burr", "flash", "shared", "height"}, ids(descendants))
+	// 他のユーザーの非公開ラベルは含めない
+	descendants = decode[[]model.OntologyNode](t, do(setupRouter(repo, 2), http.MethodGet, "/api/quantification_label/shared/descendant", ""), "labels")
+	assert.Empty(t, descendants)
+	rec := do(r, http.MethodGet, "/api/quantification_label/burr/ancestor?depth=-1", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// 表記ゆれを正規化して一致したラベルと、その同義語の語句に展開する
+	expansion := decode[model.QueryExpansion](t, do(r, http.MethodGet, "/api/label_query_expansion?text=%EF%BE%8A%EF%BE%9E%EF%BE%98", ""), "expansion")
+	assert.Equal(t, "ﾊﾞﾘ", expansion.Text)
+	assert.Equal(t, []string{"バリ", "かえり", "Burr", "burr"}, expansion.Terms)
+	require.Len(t, expansion.Labels, 3)
+	assert.Equal(t, "burr", expansion.Labels[0].LabelID)
+	assert.Equal(t, "flash", expansion.Labels[1].LabelID)
+	assert.InDelta(t, 0.8, expansion.Labels[1].Strength, 1e-9)
+	assert.InDelta(t, 0.4, expansion.Labels[2].Strength, 1e-9)
+	expansion = decode[model.QueryExpansion](t, do(r, http.MethodGet, "/api/label_query_expansion?text=unknown", ""), "expansion")
+	assert.Equal(t, []string{"unknown"}, expansion.Terms)
+	assert.Empty(t, expansion.Labels)
+	rec = do(r, http.MethodGet, "/api/label_query_expansion?text=%E3%80%80", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// 正規化した語句が無い・揃っていない自分のラベルを揃える
+	assert.EqualValues(t, 1, decode[int](t, do(r, http.MethodPost, "/api/quantification_label_normalization", ""), "updated"))
+	assert.Equal(t, "バリ 高さ", repo.Labels["height"].NormalizedText)
+	assert.Equal(t, "ばり", repo.Labels["hidden"].NormalizedText)
+}
@@ -0,0 +1,84 @@
+package label_ontology
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddLabelRelation: POST /api/label_relation
+// 起点のラベルを更新でき、終点のラベルを参照できる必要がある
+// 同義語は双方向、上位・下位の関係は単方向で、上位下位の関係の循環や同義語との矛盾は 400、同じ関係は 409
+func (ctl *LabelOntologyController) AddLabelRelation(c *gin.Context) {
+	var req model.CreateRelationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	relation, err := ctl.Service.CreateRelation(authcontext.ScopeUserID(c), actorID, &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add label relation")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"message":        "Label relation added",
+		"label_relation": relation,
+	})
+}
+
+// DeleteLabelRelation: DELETE /api/label_relation/:id
+// 関係を作成したユーザーのみ削除できる
+func (ctl *LabelOntologyController) DeleteLabelRelation(c *gin.Context) {
+	if err := ctl.Service.DeleteRelation(authcontext.ScopeUserID(c), c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete label relation")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Label relation deleted",
+	})
+}
+
+// ListLabelRelations: GET /api/quantification_label/:id/relation
+// ラベルを起点または終点とする関係を返す
+func (ctl *LabelOntologyController) ListLabelRelations(c *gin.Context) {
+	relations, err := ctl.Service.ListRelations(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list label relations")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Label relations retrieved",
+		"label_relations": relations,
+	})
+}
@@ -6,15 +6,12 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddLanguageOptimization: POST /api/language_optimization
 func (ctl *LanguageOptimizationController) AddLanguageOptimization(c *gin.Context) {
 	var languageOptimization model.LanguageOptimization
-	if err := c.ShouldBindJSON(&languageOptimization); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
 	if err := c.ShouldBindJSON(&languageOptimization); err != nil {
 		appErr := errors.NewAppError(
 			errors.VAL_INVALID_INPUT,
@@ -28,12 +25,9 @@ func (ctl *LanguageOptimizationController) AddLanguageOptimization(c *gin.Contex
 		})
 		return
 	}
-	if err := ctl.Service.CreateLanguageOptimization(&languageOptimization); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add language optimization",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.CreateLanguageOptimization(userID, &languageOptimization); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add language optimization")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -6,11 +6,13 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditLanguageOptimization: PUT /api/language_optimization/:id
 func (ctl *LanguageOptimizationController) EditLanguageOptimization(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var languageOptimization model.LanguageOptimization
 	if err := c.ShouldBindJSON(&languageOptimization); err != nil {
 		appErr := errors.NewAppError(
@@ -25,12 +27,8 @@ func (ctl *LanguageOptimizationController) EditLanguageOptimization(c *gin.Conte
 		})
 		return
 	}
-	if err := ctl.Service.UpdateLanguageOptimization(id, &languageOptimization); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to edit language optimization",
-		)
+	if err := ctl.Service.UpdateLanguageOptimization(userID, id, &languageOptimization); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to edit language optimization")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
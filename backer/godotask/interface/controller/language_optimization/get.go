@@ -4,18 +4,16 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetLanguageOptimization: GET /api/language_optimization/:id
 func (ctl *LanguageOptimizationController) GetLanguageOptimization(c *gin.Context) {
 	id := c.Param("id")
-	languageOptimization, err := ctl.Service.GetLanguageOptimizationByID(id)
+	userID := authcontext.ScopeUserID(c)
+	languageOptimization, err := ctl.Service.GetLanguageOptimizationByID(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Language optimization not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Language optimization not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
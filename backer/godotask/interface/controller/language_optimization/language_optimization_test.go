@@ -0,0 +1,170 @@
+package language_optimization
+
+import (
+    "bytes"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/stretchr/testify/assert"
+    apperrors "github.com/godotask/errors"
+    "github.com/godotask/infrastructure/db/model"
+    "github.com/godotask/usecase/service"
+)
+
+// モックリポジトリ
+type MockLanguageOptimizationRepository struct{}
+
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
+    return nil
+}
+
+func (m *MockLanguageOptimizationRepository) Create(userID uint, lo *model.LanguageOptimization) error {
+    return authorizeMock(userID)
+}
+
+func (m *MockLanguageOptimizationRepository) FindByID(userID uint, id string) (*model.LanguageOptimization, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
+    return &model.LanguageOptimization{
+        ID:               "1",
+        TaskID:           1,
+        OriginalText:     "original",
+        OptimizedText:    "optimized",
+        Domain:           "manufacturing",
+        AbstractionLevel: "concrete",
+        Precision:        0.8,
+        Clarity:          0.9,
+        Completeness:     0.7,
+        CreatedAt:        time.Now(),
+        UpdatedAt:        time.Now(),
+    }, nil
+}
+
+func (m *MockLanguageOptimizationRepository) FindAll(userID uint) ([]model.LanguageOptimization, error) {
+    lo, err := m.FindByID(userID, "1")
+    if err != nil {
+        return []model.LanguageOptimization{}, nil
+    }
+    return []model.LanguageOptimization{*lo}, nil
+}
+
+func (m *MockLanguageOptimizationRepository) Update(userID uint, id string, lo *model.LanguageOptimization) error {
+    return authorizeMock(userID)
+}
+
+func (m *MockLanguageOptimizationRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
+}
+
+// テスト用ルーター
+func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
+    r := gin.Default()
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
+    mockRepo := &MockLanguageOptimizationRepository{}
+    mockService := &service.LanguageOptimizationService{Repo: mockRepo}
+    ctl := &LanguageOptimizationController{Service: mockService}
+
+    r.POST("/api/language_optimization", ctl.AddLanguageOptimization)
+    r.GET("/api/language_optimization/:id", ctl.GetLanguageOptimization)
+    r.PUT("/api/language_optimization/:id", ctl.EditLanguageOptimization)
+    r.DELETE("/api/language_optimization/:id", ctl.DeleteLanguageOptimization)
+    return r
+}
+
+func TestAddLanguageOptimization(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    body := `{
+        "task_id": 1,
+        "original_text": "original",
+        "optimized_text": "optimized",
+        "domain": "manufacturing",
+        "abstraction_level": "concrete",
+        "precision": 0.8,
+        "clarity": 0.9,
+        "completeness": 0.7
+    }`
+
+    req, _ := http.NewRequest(http.MethodPost, "/api/language_optimization", bytes.NewBuffer([]byte(body)))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), "Language optimization added")
+}
+
+func TestGetLanguageOptimization(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/language_optimization/1", nil)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), "Language optimization retrieved")
+}
+
+func TestUpdateLanguageOptimization(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    body := `{
+        "optimized_text": "more optimized",
+        "clarity": 0.95
+    }`
+
+    req, _ := http.NewRequest(http.MethodPut, "/api/language_optimization/1", bytes.NewBuffer([]byte(body)))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), "Language optimization edited")
+}
+
+func TestDeleteLanguageOptimization(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    req, _ := http.NewRequest(http.MethodDelete, "/api/language_optimization/1", nil)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), "Language optimization deleted")
+}
+
+func TestLanguageOptimizationAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "editor")
+
+    for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/language_optimization/1", bytes.NewBuffer([]byte(`{"task_id": 1}`)))
+        req.Header.Set("Content-Type", "application/json")
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
+}
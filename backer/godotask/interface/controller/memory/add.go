@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
 	"github.com/rs/zerolog/log"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddMemory: POST /api/memory
@@ -25,12 +26,9 @@ func (ctl *MemoryController) AddMemory(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.CreateMemory(&memory); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error(),
-		)
+	userID, _ := authcontext.UserID(c)
+	if err := ctl.Service.CreateMemory(userID, &memory); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
 		log.Error().Msgf("AddMemory CreateMemory error: %v", err)
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
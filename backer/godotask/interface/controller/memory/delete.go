@@ -4,17 +4,15 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteMemory: DELETE /api/memory/:id
 func (ctl *MemoryController) DeleteMemory(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteMemory(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error(),
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteMemory(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
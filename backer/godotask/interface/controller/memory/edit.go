@@ -5,11 +5,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditMemory: PUT /api/memory/:id
 func (ctl *MemoryController) EditMemory(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var memory model.Memory
 	if err := c.ShouldBindJSON(&memory); err != nil {
 		appErr := errors.NewAppError(
@@ -24,12 +26,8 @@ func (ctl *MemoryController) EditMemory(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.UpdateMemory(id, &memory); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error(),
-		)
+	if err := ctl.Service.UpdateMemory(userID, id, &memory); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
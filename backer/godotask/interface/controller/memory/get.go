@@ -5,19 +5,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
 	"github.com/rs/zerolog/log"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetMemory: GET /api/memory/:id
 func (ctl *MemoryController) GetMemory(c *gin.Context) {
 	id := c.Param("id")
-	memory, err := ctl.Service.GetMemoryByID(id)
+	userID := authcontext.ScopeUserID(c)
+	memory, err := ctl.Service.GetMemoryByID(userID, id)
 	log.Info().Msgf("GetMemory: Retrieved memory: %+v", memory)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			"Memory not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, "Memory not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
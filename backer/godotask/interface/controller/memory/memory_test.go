@@ -9,18 +9,32 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    apperrors "github.com/godotask/errors"
     "github.com/godotask/infrastructure/db/model"
     "github.com/godotask/usecase/service"
 )
 
 // モックリポジトリを作成
+// ID 1 の Memory はユーザー 1 が所有している
 type MockMemoryRepository struct{}
 
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
+    return nil
+}
+
 func (m *MockMemoryRepository) Create(memory *model.Memory) error {
     return nil
 }
 
-func (m *MockMemoryRepository) FindByID(id string) (*model.Memory, error) {
+func (m *MockMemoryRepository) FindByID(userID uint, id string) (*model.Memory, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
     now := time.Now()
     return &model.Memory{
         ID:                1,
@@ -42,16 +56,16 @@ func (m *MockMemoryRepository) FindByID(id string) (*model.Memory, error) {
 }
 
 func (m *MockMemoryRepository) FindAll(userID uint) ([]model.Memory, error) {
-    memory, _ := m.FindByID("1")
+    memory, _ := m.FindByID(0, "1")
     return []model.Memory{*memory}, nil
 }
 
-func (m *MockMemoryRepository) Update(id string, memory *model.Memory) error {
-    return nil
+func (m *MockMemoryRepository) Update(userID uint, id string, memory *model.Memory) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockMemoryRepository) Delete(id string) error {
-    return nil
+func (m *MockMemoryRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
 }
 
 func (m *MockMemoryRepository) ListMemoriesPager(
@@ -60,7 +74,7 @@ func (m *MockMemoryRepository) ListMemoriesPager(
     offset int,
 ) ([]model.Memory, int64, error) {
 
-    memory, _ := m.FindByID("1")
+    memory, _ := m.FindByID(0, "1")
     list := []model.Memory{*memory}
 
     var total int64 = int64(len(list))
@@ -69,7 +83,16 @@ func (m *MockMemoryRepository) ListMemoriesPager(
 }
 
 func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
     r := gin.Default()
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
     mockRepo := &MockMemoryRepository{}
     mockService := &service.MemoryService{Repo: mockRepo}
     ctl := &MemoryController{Service: mockService}
@@ -191,3 +214,31 @@ func TestGetMemory(t *testing.T) {
     assert.Equal(t, http.StatusOK, w.Code)
     assert.Contains(t, w.Body.String(), "Test Title")
 }
+
+func TestMemoryAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "editor")
+
+    for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/memory/1", bytes.NewBuffer([]byte(`{"title": "Hijacked"}`)))
+        req.Header.Set("Content-Type", "application/json")
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
+}
+
+func TestMemoryAdminCanAccessOthers(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "admin")
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/memory/1", nil)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), "Test Title")
+}
@@ -0,0 +1,117 @@
+package multimodal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddImageAnnotation: POST /api/multimodal_data/:id/annotation
+// 画像に領域・点・測定線・テキストのアノテーションを追加する
+// 単位を指定しない測定・領域には、校正があれば mm / mm2 に換算した値と標準不確かさ、
+// 無ければ画像上の長さ（px）・面積（px2）が値として入る
+func (ctl *MultimodalController) AddImageAnnotation(c *gin.Context) {
+	var annotation model.ImageAnnotation
+	if err := c.ShouldBindJSON(&annotation); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	if err := ctl.Service.AddAnnotation(authcontext.ScopeUserID(c), actorID, c.Param("id"), &annotation); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add annotation")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Annotation added",
+		"annotation": annotation,
+	})
+}
+
+// ListImageAnnotations: GET /api/multimodal_data/:id/annotation
+func (ctl *MultimodalController) ListImageAnnotations(c *gin.Context) {
+	annotations, err := ctl.Service.ListAnnotations(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list annotations")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     "Annotations retrieved",
+		"annotations": annotations,
+	})
+}
+
+// EditImageAnnotation: PUT /api/image_annotation/:id
+func (ctl *MultimodalController) EditImageAnnotation(c *gin.Context) {
+	var annotation model.ImageAnnotation
+	if err := c.ShouldBindJSON(&annotation); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	updated, err := ctl.Service.UpdateAnnotation(authcontext.ScopeUserID(c), c.Param("id"), &annotation)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to edit annotation")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Annotation edited",
+		"annotation": updated,
+	})
+}
+
+// DeleteImageAnnotation: DELETE /api/image_annotation/:id
+func (ctl *MultimodalController) DeleteImageAnnotation(c *gin.Context) {
+	id := c.Param("id")
+	if err := ctl.Service.DeleteAnnotation(authcontext.ScopeUserID(c), id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete annotation")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Annotation deleted",
+		"annotation_id": id,
+	})
+}
@@ -0,0 +1,84 @@
+package multimodal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
+)
+
+// CalibrateImage: POST /api/multimodal_data/:id/calibration
+// 画像上の基準物に引いた線分から縮尺（mm/px）を求め、画像と同じ撮影セッションの画像の測定に適用する
+func (ctl *MultimodalController) CalibrateImage(c *gin.Context) {
+	var req service.CalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	calibration, err := ctl.Service.CalibrateImage(authcontext.ScopeUserID(c), actorID, c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to calibrate image")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     "Image calibrated",
+		"calibration": calibration,
+	})
+}
+
+// ListCalibrations: GET /api/multimodal_data/:id/calibration
+// 画像に適用される校正（effective、無ければ null）と画像上で行った校正の一覧を返す
+func (ctl *MultimodalController) ListCalibrations(c *gin.Context) {
+	effective, calibrations, err := ctl.Service.ListCalibrations(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list calibrations")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Calibrations retrieved",
+		"effective":    effective,
+		"calibrations": calibrations,
+	})
+}
+
+// DeleteCalibration: DELETE /api/calibration/:id
+// 校正を削除する。換算済みのアノテーションの値は変わらない
+func (ctl *MultimodalController) DeleteCalibration(c *gin.Context) {
+	if err := ctl.Service.DeleteCalibration(authcontext.ScopeUserID(c), c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to delete calibration")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Calibration deleted",
+	})
+}
@@ -0,0 +1,29 @@
+package multimodal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// DeleteMultimodalData: DELETE /api/multimodal_data/:id
+// アノテーションと、元画像・サムネイルの添付も削除する
+func (ctl *MultimodalController) DeleteMultimodalData(c *gin.Context) {
+	id := c.Param("id")
+	if err := ctl.Service.DeleteMultimodalData(authcontext.ScopeUserID(c), id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete multimodal data")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Multimodal data deleted",
+		"multimodal_data_id": id,
+	})
+}
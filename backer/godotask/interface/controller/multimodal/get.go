@@ -0,0 +1,28 @@
+package multimodal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetMultimodalData: GET /api/multimodal_data/:id
+func (ctl *MultimodalController) GetMultimodalData(c *gin.Context) {
+	data, err := ctl.Service.GetMultimodalData(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to get multimodal data")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Multimodal data retrieved",
+		"multimodal_data": data,
+	})
+}
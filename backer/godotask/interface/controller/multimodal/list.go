@@ -0,0 +1,43 @@
+package multimodal
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListMultimodalData: GET /api/multimodal_data?task_id=...
+func (ctl *MultimodalController) ListMultimodalData(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Query("task_id"))
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"task_id is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	data, err := ctl.Service.ListMultimodalData(authcontext.ScopeUserID(c), taskID)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list multimodal data")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Multimodal data retrieved",
+		"multimodal_data": data,
+	})
+}
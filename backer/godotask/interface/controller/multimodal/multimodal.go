@@ -0,0 +1,7 @@
+package multimodal
+
+import "github.com/godotask/usecase/service"
+
+type MultimodalController struct {
+	Service *service.MultimodalService
+}
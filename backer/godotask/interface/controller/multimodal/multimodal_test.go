@@ -0,0 +1,577 @@
+package multimodal
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/storage"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// タスク 100 はユーザー 1 が所有している
+const (
+	mockOwnerID uint = 1
+	mockTaskID  uint = 100
+)
+
+func authorizeMock(userID uint, taskID uint) error {
+	if taskID != mockTaskID {
+		return errors.ErrResourceNotFound
+	}
+	if userID != 0 && userID != mockOwnerID {
+		return errors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+type MockMultimodalRepository struct {
+	Data         map[string]model.MultimodalData
+	Annotations  map[string]model.ImageAnnotation
+	Calibrations []model.UserCalibration
+}
+
+func (m *MockMultimodalRepository) Create(userID uint, data *model.MultimodalData) error {
+	if err := authorizeMock(userID, data.TaskID); err != nil {
+		return err
+	}
+	// DB を経由した場合と同じく JSON 列は map[string]interface{} として読み出される
+	raw, _ := json.Marshal(data)
+	var stored model.MultimodalData
+	_ = json.Unmarshal(raw, &stored)
+	m.Data[data.ID] = stored
+	return nil
+}
+func (m *MockMultimodalRepository) FindByID(userID uint, id string) (*model.MultimodalData, error) {
+	d, ok := m.Data[id]
+	if !ok {
+		return nil, errors.ErrResourceNotFound
+	}
+	if err := authorizeMock(userID, d.TaskID); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+func (m *MockMultimodalRepository) FindByTask(userID uint, taskID int) ([]model.MultimodalData, error) {
+	if err := authorizeMock(userID, uint(taskID)); err != nil {
+		return nil, err
+	}
+	return m.FindImages(userID)
+}
+func (m *MockMultimodalRepository) FindImages(userID uint) ([]model.MultimodalData, error) {
+	var out []model.MultimodalData
+	for _, d := range m.Data {
+		out = append(out, d)
+	}
+	return out, nil
+}
+func (m *MockMultimodalRepository) Delete(userID uint, id string) error {
+	if _, err := m.FindByID(userID, id); err != nil {
+		return err
+	}
+	delete(m.Data, id)
+	for aid, a := range m.Annotations {
+		if a.ImageID == id {
+			delete(m.Annotations, aid)
+		}
+	}
+	return nil
+}
+func (m *MockMultimodalRepository) CreateAnnotation(userID uint, a *model.ImageAnnotation) error {
+	d, err := m.FindByID(userID, a.ImageID)
+	if err != nil {
+		return err
+	}
+	a.TaskID = d.TaskID
+	m.Annotations[a.ID] = *a
+	return nil
+}
+func (m *MockMultimodalRepository) FindAnnotation(userID uint, id string) (*model.ImageAnnotation, error) {
+	a, ok := m.Annotations[id]
+	if !ok {
+		return nil, errors.ErrResourceNotFound
+	}
+	if err := authorizeMock(userID, a.TaskID); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+func (m *MockMultimodalRepository) FindAnnotations(userID uint, imageID string) ([]model.ImageAnnotation, error) {
+	if _, err := m.FindByID(userID, imageID); err != nil {
+		return nil, err
+	}
+	var out []model.ImageAnnotation
+	for _, a := range m.Annotations {
+		if a.ImageID == imageID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+func (m *MockMultimodalRepository) UpdateAnnotation(userID uint, id string, a *model.ImageAnnotation) error {
+	existing, err := m.FindAnnotation(userID, id)
+	if err != nil {
+		return err
+	}
+	a.ID, a.ImageID, a.TaskID, a.UserID = existing.ID, existing.ImageID, existing.TaskID, existing.UserID
+	m.Annotations[id] = *a
+	return nil
+}
+func (m *MockMultimodalRepository) DeleteAnnotation(userID uint, id string) error {
+	if _, err := m.FindAnnotation(userID, id); err != nil {
+		return err
+	}
+	delete(m.Annotations, id)
+	return nil
+}
+
+func (m *MockMultimodalRepository) CreateCalibration(userID uint, c *model.UserCalibration) error {
+	d, err := m.FindByID(userID, c.ImageID)
+	if err != nil {
+		return err
+	}
+	c.TaskID = d.TaskID
+	m.Calibrations = append(m.Calibrations, *c)
+	return nil
+}
+func (m *MockMultimodalRepository) FindCalibrations(userID uint, imageID string) ([]model.UserCalibration, error) {
+	if _, err := m.FindByID(userID, imageID); err != nil {
+		return nil, err
+	}
+	var out []model.UserCalibration
+	for i := len(m.Calibrations) - 1; i >= 0; i-- {
+		if m.Calibrations[i].ImageID == imageID {
+			out = append(out, m.Calibrations[i])
+		}
+	}
+	return out, nil
+}
+func (m *MockMultimodalRepository) FindEffectiveCalibration(userID uint, data *model.MultimodalData) (*model.UserCalibration, error) {
+	calibrations, err := m.FindCalibrations(userID, data.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(calibrations) > 0 {
+		return &calibrations[0], nil
+	}
+	for i := len(m.Calibrations) - 1; i >= 0; i-- {
+		c := m.Calibrations[i]
+		if data.CaptureSession != "" && c.TaskID == data.TaskID && c.Session == data.CaptureSession {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockMultimodalRepository) DeleteCalibration(userID uint, id string) error {
+	for i, c := range m.Calibrations {
+		if c.ID == id {
+			if err := authorizeMock(userID, c.TaskID); err != nil {
+				return err
+			}
+			m.Calibrations = append(m.Calibrations[:i], m.Calibrations[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrResourceNotFound
+}
+
+// MockVisualMetaphorRepository は基準物のカタログ
+type MockVisualMetaphorRepository struct {
+	Metaphors map[string]model.VisualMetaphor
+}
+
+func (m *MockVisualMetaphorRepository) Create(v *model.VisualMetaphor) error {
+	m.Metaphors[v.ID] = *v
+	return nil
+}
+func (m *MockVisualMetaphorRepository) FindByID(id string) (*model.VisualMetaphor, error) {
+	v, ok := m.Metaphors[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &v, nil
+}
+func (m *MockVisualMetaphorRepository) FindAll() ([]model.VisualMetaphor, error) {
+	var out []model.VisualMetaphor
+	for _, v := range m.Metaphors {
+		out = append(out, v)
+	}
+	return out, nil
+}
+func (m *MockVisualMetaphorRepository) Update(id string, v *model.VisualMetaphor) error {
+	m.Metaphors[id] = *v
+	return nil
+}
+func (m *MockVisualMetaphorRepository) Delete(id string) error {
+	delete(m.Metaphors, id)
+	return nil
+}
+
+// MockAttachmentRepository はタスク 100 への添付だけを受け付ける
+type MockAttachmentRepository struct {
+	Attachments map[string]model.Attachment
+}
+
+func (m *MockAttachmentRepository) Create(userID uint, a *model.Attachment, store func() error) error {
+	if a.ResourceType != "task" || a.ResourceID != "100" {
+		return errors.ErrResourceNotFound
+	}
+	if err := authorizeMock(userID, mockTaskID); err != nil {
+		return err
+	}
+	if err := store(); err != nil {
+		return err
+	}
+	m.Attachments[a.ID] = *a
+	return nil
+}
+func (m *MockAttachmentRepository) FindByID(userID uint, id string) (*model.Attachment, error) {
+	a, ok := m.Attachments[id]
+	if !ok {
+		return nil, errors.ErrResourceNotFound
+	}
+	return &a, nil
+}
+func (m *MockAttachmentRepository) FindByResource(userID uint, resourceType, resourceID string) ([]model.Attachment, error) {
+	return nil, nil
+}
+func (m *MockAttachmentRepository) FindByUploader(userID uint) ([]model.Attachment, error) {
+	return nil, nil
+}
+func (m *MockAttachmentRepository) Delete(userID uint, id string, collect func(string) error) error {
+	if _, ok := m.Attachments[id]; !ok {
+		return errors.ErrResourceNotFound
+	}
+	delete(m.Attachments, id)
+	return nil
+}
+
+type fixture struct {
+	router      *gin.Engine
+	repo        *MockMultimodalRepository
+	attachments *MockAttachmentRepository
+}
+
+func setupRouter(t *testing.T, userID uint) *fixture {
+	gin.SetMode(gin.TestMode)
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	f := &fixture{
+		repo:        &MockMultimodalRepository{Data: map[string]model.MultimodalData{}, Annotations: map[string]model.ImageAnnotation{}},
+		attachments: &MockAttachmentRepository{Attachments: map[string]model.Attachment{}},
+	}
+	ctl := &MultimodalController{Service: &service.MultimodalService{
+		Repo: f.repo,
+		// ISO/IEC 7810 ID-1 のカード（85.60 x 53.98 mm、±0.1%）
+		References: &MockVisualMetaphorRepository{Metaphors: map[string]model.VisualMetaphor{
+			"id-1-card": {ID: "id-1-card", ReferenceObject: "ID-1 card", Width: 85.6, Height: 53.98, MinVariability: -0.001, MaxVariability: 0.001},
+		}},
+		Attachments: &service.AttachmentService{
+			Repo:   f.attachments,
+			Blobs:  store,
+			Signer: &storage.URLSigner{Key: []byte("test-key")},
+		},
+	}}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	r.POST("/api/multimodal_data", ctl.UploadMultimodalImage)
+	r.GET("/api/multimodal_data", ctl.ListMultimodalData)
+	r.GET("/api/multimodal_data/:id", ctl.GetMultimodalData)
+	r.DELETE("/api/multimodal_data/:id", ctl.DeleteMultimodalData)
+	r.GET("/api/multimodal_data/:id/similar", ctl.FindSimilarImages)
+	r.POST("/api/multimodal_data/:id/annotation", ctl.AddImageAnnotation)
+	r.GET("/api/multimodal_data/:id/annotation", ctl.ListImageAnnotations)
+	r.PUT("/api/image_annotation/:id", ctl.EditImageAnnotation)
+	r.DELETE("/api/image_annotation/:id", ctl.DeleteImageAnnotation)
+	r.POST("/api/multimodal_data/:id/calibration", ctl.CalibrateImage)
+	r.GET("/api/multimodal_data/:id/calibration", ctl.ListCalibrations)
+	r.DELETE("/api/calibration/:id", ctl.DeleteCalibration)
+	f.router = r
+	return f
+}
+
+func samplePNG(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{uint8(x % 256), uint8(y % 256), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func (f *fixture) upload(t *testing.T, taskID string, content []byte) *httptest.ResponseRecorder {
+	return f.uploadInSession(t, taskID, "", content)
+}
+
+func (f *fixture) uploadInSession(t *testing.T, taskID, session string, content []byte) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	require.NoError(t, w.WriteField("task_id", taskID))
+	require.NoError(t, w.WriteField("text", "burr on the edge"))
+	require.NoError(t, w.WriteField("session", session))
+	part, err := w.CreateFormFile("file", "burr.png")
+	require.NoError(t, err)
+	_, _ = part.Write(content)
+	require.NoError(t, w.Close())
+	req, _ := http.NewRequest(http.MethodPost, "/api/multimodal_data", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func (f *fixture) do(method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func uploaded(t *testing.T, rec *httptest.ResponseRecorder) model.MultimodalData {
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res struct {
+		Data model.MultimodalData `json:"multimodal_data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res.Data
+}
+
+func TestUploadMultimodalImage(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	data := uploaded(t, f.upload(t, "100", samplePNG(t, 800, 600)))
+
+	assert.Equal(t, mockTaskID, data.TaskID)
+	assert.Equal(t, "burr on the edge", data.Text)
+	assert.Equal(t, "png", data.ImageFormat)
+	assert.Equal(t, 800, data.ImageWidth)
+	assert.Equal(t, 600, data.ImageHeight)
+	assert.Len(t, data.ImageHash, 16)
+	assert.Equal(t, "/api/attachment/"+data.ImageAttachmentID, data.ImageURL)
+	// 160 / 480 のサムネイル（1024 は元画像より大きいため作らない）
+	assert.Len(t, data.Thumbnails, 2)
+	thumb := data.Thumbnails["160"].(map[string]interface{})
+	assert.Equal(t, 160.0, thumb["width"])
+	assert.Equal(t, 120.0, thumb["height"])
+	assert.Len(t, f.attachments.Attachments, 3)
+
+	rec := f.do(http.MethodGet, "/api/multimodal_data?task_id=100", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), data.ID)
+
+	// 同じ画像は距離 0 の類似画像になる
+	second := uploaded(t, f.upload(t, "100", samplePNG(t, 800, 600)))
+	rec = f.do(http.MethodGet, "/api/multimodal_data/"+data.ID+"/similar", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var similar struct {
+		Similar []service.SimilarImage `json:"similar"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &similar))
+	require.Len(t, similar.Similar, 1)
+	assert.Equal(t, second.ID, similar.Similar[0].ID)
+	assert.Equal(t, 0, similar.Similar[0].Distance)
+
+	// 削除すると元画像とサムネイルの添付も削除される
+	rec = f.do(http.MethodDelete, "/api/multimodal_data/"+data.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, f.attachments.Attachments, 3) // 2 枚目の画像の分だけが残る
+	assert.NotContains(t, f.repo.Data, data.ID)
+}
+
+func TestUploadMultimodalImageErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		taskID  string
+		content []byte
+		status  int
+	}{
+		{"not an image", mockOwnerID, "100", []byte("plain text"), http.StatusBadRequest},
+		{"missing task", mockOwnerID, "", nil, http.StatusBadRequest},
+		{"other user's task", 2, "100", nil, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupRouter(t, tt.userID)
+			content := tt.content
+			if content == nil {
+				content = samplePNG(t, 40, 30)
+			}
+			rec := f.upload(t, tt.taskID, content)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			assert.Empty(t, f.repo.Data)
+			assert.Empty(t, f.attachments.Attachments)
+		})
+	}
+}
+
+func TestImageAnnotations(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	data := uploaded(t, f.upload(t, "100", samplePNG(t, 200, 100)))
+	path := "/api/multimodal_data/" + data.ID + "/annotation"
+
+	// 単位なしの測定は画像上の長さ（px）になる
+	rec := f.do(http.MethodPost, path, `{"type": "measurement", "x": 10, "y": 10, "width": 30, "height": 40, "label": "burr height"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res struct {
+		Annotation model.ImageAnnotation `json:"annotation"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, 50.0, res.Annotation.Value)
+	assert.Equal(t, "px", res.Annotation.Unit)
+	assert.Equal(t, data.ID, res.Annotation.ImageID)
+	assert.Equal(t, mockTaskID, res.Annotation.TaskID)
+	assert.Equal(t, mockOwnerID, res.Annotation.UserID)
+
+	// 単位を指定した値はそのまま保存する
+	rec = f.do(http.MethodPost, path, `{"type": "region", "x": 0, "y": 0, "width": 20, "height": 10, "label": "Ra", "value": 1.6, "unit": "um", "confidence": 0.9}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"unit":"um"`)
+
+	for _, body := range []string{
+		`{"type": "region", "x": 190, "y": 0, "width": 20, "height": 10}`,
+		`{"type": "measurement", "x": 10, "y": 10}`,
+		`{"type": "text", "x": 10, "y": 10}`,
+		`{"type": "point", "x": 10, "y": 10, "confidence": 1.5}`,
+		`{"type": "circle", "x": 10, "y": 10}`,
+	} {
+		rec = f.do(http.MethodPost, path, body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+
+	rec = f.do(http.MethodPut, "/api/image_annotation/"+res.Annotation.ID, `{"type": "measurement", "x": 0, "y": 0, "width": 6, "height": 8}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"value":10`)
+	assert.Contains(t, rec.Body.String(), `"image_id":"`+data.ID+`"`)
+
+	rec = f.do(http.MethodGet, path, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Annotations []model.ImageAnnotation `json:"annotations"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Annotations, 2)
+
+	rec = f.do(http.MethodDelete, "/api/image_annotation/"+res.Annotation.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, f.repo.Annotations, 1)
+
+	// 他のユーザーは注釈を追加できない
+	other := setupRouter(t, 2)
+	other.repo.Data = f.repo.Data
+	rec = other.do(http.MethodPost, path, `{"type": "point", "x": 1, "y": 1}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestImageCalibration(t *testing.T) {
+	f := setupRouter(t, mockOwnerID)
+	reference := uploaded(t, f.uploadInSession(t, "100", "line-a", samplePNG(t, 600, 400)))
+	same := uploaded(t, f.uploadInSession(t, "100", "line-a", samplePNG(t, 600, 400)))
+	unrelated := uploaded(t, f.uploadInSession(t, "100", "line-b", samplePNG(t, 600, 400)))
+	assert.Equal(t, "line-a", reference.CaptureSession)
+
+	// 校正前は mm に換算できない
+	rec := f.do(http.MethodPost, "/api/multimodal_data/"+same.ID+"/annotation", `{"type": "measurement", "x": 0, "y": 0, "width": 100, "unit": "mm"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// カードの幅 85.6mm に 428px の線分を引く（0.2 mm/px）
+	rec = f.do(http.MethodPost, "/api/multimodal_data/"+reference.ID+"/calibration", `{"reference_object": "id-1-card", "x": 100, "y": 50, "width": 428}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var calibrated struct {
+		Calibration model.UserCalibration `json:"calibration"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &calibrated))
+	cal := calibrated.Calibration
+	assert.InDelta(t, 0.2, cal.Scale, 1e-12)
+	assert.Equal(t, "width", cal.Dimension)
+	assert.Equal(t, "line-a", cal.Session)
+	assert.Equal(t, mockTaskID, cal.TaskID)
+	rel := math.Hypot(0.001/math.Sqrt(3), 1.0/428)
+	assert.InDelta(t, rel, cal.ScaleUncertainty, 1e-12)
+
+	// 同じセッションの画像の単位なしの測定は mm に換算され、不確かさが伝播する
+	rec = f.do(http.MethodPost, "/api/multimodal_data/"+same.ID+"/annotation", `{"type": "measurement", "x": 10, "y": 10, "width": 180, "height": 240, "label": "burr length", "calibration_id": "forged"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res struct {
+		Annotation model.ImageAnnotation `json:"annotation"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	u := 60 * math.Hypot(rel, 1.0/300)
+	assert.Equal(t, 60.0, res.Annotation.Value)
+	assert.Equal(t, "mm", res.Annotation.Unit)
+	assert.InDelta(t, u, res.Annotation.Uncertainty, 1e-3)
+	assert.InDelta(t, 1-2*u/60, res.Annotation.Confidence, 1e-9)
+	assert.Equal(t, cal.ID, res.Annotation.CalibrationID)
+
+	// 矩形は mm2。px を指定すれば換算しない
+	rec = f.do(http.MethodPost, "/api/multimodal_data/"+same.ID+"/annotation", `{"type": "region", "x": 0, "y": 0, "width": 100, "height": 50}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"value":200,"unit":"mm2"`)
+	rec = f.do(http.MethodPut, "/api/image_annotation/"+res.Annotation.ID, `{"type": "measurement", "x": 0, "y": 0, "width": 30, "height": 40, "unit": "px"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"value":50,"unit":"px"`)
+	assert.Contains(t, rec.Body.String(), `"calibration_id":""`)
+
+	// 別セッションの画像には適用されない
+	rec = f.do(http.MethodPost, "/api/multimodal_data/"+unrelated.ID+"/annotation", `{"type": "measurement", "x": 0, "y": 0, "width": 30, "height": 40}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"unit":"px"`)
+
+	rec = f.do(http.MethodGet, "/api/multimodal_data/"+same.ID+"/calibration", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Effective    *model.UserCalibration  `json:"effective"`
+		Calibrations []model.UserCalibration `json:"calibrations"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.NotNil(t, list.Effective)
+	assert.Equal(t, cal.ID, list.Effective.ID)
+	assert.Empty(t, list.Calibrations)
+
+	for _, tt := range []struct {
+		body   string
+		status int
+	}{
+		{`{"reference_object": "unknown", "x": 0, "y": 0, "width": 100}`, http.StatusNotFound},
+		{`{"reference_object": "id-1-card", "dimension": "depth", "x": 0, "y": 0, "width": 100}`, http.StatusBadRequest},
+		{`{"reference_object": "id-1-card", "known_length": 10, "x": 0, "y": 0, "width": 100}`, http.StatusBadRequest},
+		{`{"x": 0, "y": 0, "width": 100}`, http.StatusBadRequest},
+		{`{"known_length": 10, "x": 0, "y": 0}`, http.StatusBadRequest},
+		{`{"known_length": 10, "x": 0, "y": 0, "width": 700}`, http.StatusBadRequest},
+		{`{"known_length": 10, "x": 0, "y": 0, "width": 2, "pixel_uncertainty": 3}`, http.StatusBadRequest},
+	} {
+		rec = f.do(http.MethodPost, "/api/multimodal_data/"+reference.ID+"/calibration", tt.body)
+		assert.Equal(t, tt.status, rec.Code, tt.body)
+	}
+
+	// 他のユーザーは校正を削除できない
+	other := setupRouter(t, 2)
+	other.repo.Data, other.repo.Calibrations = f.repo.Data, f.repo.Calibrations
+	rec = other.do(http.MethodDelete, "/api/calibration/"+cal.ID, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = f.do(http.MethodDelete, "/api/calibration/"+cal.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, f.repo.Calibrations)
+}
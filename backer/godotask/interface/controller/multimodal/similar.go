@@ -0,0 +1,47 @@
+package multimodal
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// FindSimilarImages: GET /api/multimodal_data/:id/similar?max_distance=...
+// 知覚ハッシュのハミング距離が max_distance（既定 10、0〜64）以下の画像を近い順に返す
+func (ctl *MultimodalController) FindSimilarImages(c *gin.Context) {
+	maxDistance := 0
+	if v := c.Query("max_distance"); v != "" {
+		var err error
+		if maxDistance, err = strconv.Atoi(v); err != nil || maxDistance < 0 || maxDistance > 64 {
+			appErr := errors.NewAppError(
+				errors.VAL_INVALID_INPUT,
+				errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+				"max_distance must be an integer between 0 and 64",
+			)
+			c.JSON(appErr.HTTPStatus, gin.H{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"detail":  appErr.Detail,
+			})
+			return
+		}
+	}
+	similar, err := ctl.Service.FindSimilarImages(authcontext.ScopeUserID(c), c.Param("id"), maxDistance)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to find similar images")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Similar images retrieved",
+		"similar": similar,
+	})
+}
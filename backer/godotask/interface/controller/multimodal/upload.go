@@ -0,0 +1,90 @@
+package multimodal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
+)
+
+// ImageForm は画像アップロードのフォーム項目。ファイルは "file" で送る
+type ImageForm struct {
+	TaskID uint   `form:"task_id" binding:"required"`
+	Text   string `form:"text"`
+	// Session は撮影セッション。同じセッションの画像は校正を共有する
+	Session string `form:"session"`
+}
+
+// UploadMultimodalImage: POST /api/multimodal_data (multipart/form-data)
+// 画像を解析し、EXIF・知覚ハッシュ・寸法・サムネイルを持つマルチモーダルデータを作成する
+func (ctl *MultimodalController) UploadMultimodalImage(c *gin.Context) {
+	var form ImageForm
+	if err := c.ShouldBind(&form); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"file is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	defer file.Close()
+
+	actorID, _ := authcontext.UserID(c)
+	data, err := ctl.Service.UploadImage(authcontext.ScopeUserID(c), actorID, &service.ImageUpload{
+		TaskID:      form.TaskID,
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Text:        form.Text,
+		Session:     form.Session,
+		Body:        file,
+	})
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to upload image")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Image uploaded",
+		"multimodal_data": data,
+	})
+}
@@ -0,0 +1,41 @@
+package optimization_model
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// AddOptimizationModel: POST /api/optimization_model
+func (ctl *OptimizationModelController) AddOptimizationModel(c *gin.Context) {
+	var optimizationModel model.OptimizationModel
+	if err := c.ShouldBindJSON(&optimizationModel); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	if err := ctl.Service.CreateOptimizationModel(&optimizationModel); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add optimization model")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Optimization model added",
+		"optimization_model": optimizationModel,
+	})
+}
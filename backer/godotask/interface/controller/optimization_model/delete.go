@@ -0,0 +1,25 @@
+package optimization_model
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// DeleteOptimizationModel: DELETE /api/optimization_model/:id
+func (ctl *OptimizationModelController) DeleteOptimizationModel(c *gin.Context) {
+	if err := ctl.Service.DeleteOptimizationModel(c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete optimization model")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Optimization model deleted",
+	})
+}
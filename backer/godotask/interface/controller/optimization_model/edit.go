@@ -0,0 +1,42 @@
+package optimization_model
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// EditOptimizationModel: PUT /api/optimization_model/:id
+func (ctl *OptimizationModelController) EditOptimizationModel(c *gin.Context) {
+	id := c.Param("id")
+	var optimizationModel model.OptimizationModel
+	if err := c.ShouldBindJSON(&optimizationModel); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	if err := ctl.Service.UpdateOptimizationModel(id, &optimizationModel); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to edit optimization model")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Optimization model edited",
+		"optimization_model": optimizationModel,
+	})
+}
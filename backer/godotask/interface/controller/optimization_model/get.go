@@ -0,0 +1,27 @@
+package optimization_model
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// GetOptimizationModel: GET /api/optimization_model/:id
+func (ctl *OptimizationModelController) GetOptimizationModel(c *gin.Context) {
+	optimizationModel, err := ctl.Service.GetOptimizationModelByID(c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Optimization model not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Optimization model retrieved",
+		"optimization_model": optimizationModel,
+	})
+}
@@ -0,0 +1,31 @@
+package optimization_model
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// ListOptimizationModels: GET /api/optimization_model
+func (ctl *OptimizationModelController) ListOptimizationModels(c *gin.Context) {
+	optimizationModels, err := ctl.Service.ListOptimizationModels()
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error()+" | Failed to list optimization models",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":             true,
+		"message":             "Optimization models retrieved",
+		"optimization_models": optimizationModels,
+	})
+}
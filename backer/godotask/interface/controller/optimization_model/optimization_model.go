@@ -0,0 +1,7 @@
+package optimization_model
+
+import "github.com/godotask/usecase/service"
+
+type OptimizationModelController struct {
+	Service *service.OptimizationModelService
+}
@@ -0,0 +1,241 @@
+package optimization_model
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const mockOwnerID uint = 1
+
+// モックリポジトリ
+type MockOptimizationModelRepository struct {
+	Models map[string]model.OptimizationModel
+}
+
+func (m *MockOptimizationModelRepository) Create(om *model.OptimizationModel) error {
+	m.Models[om.ID] = *om
+	return nil
+}
+
+func (m *MockOptimizationModelRepository) FindByID(id string) (*model.OptimizationModel, error) {
+	om, ok := m.Models[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &om, nil
+}
+
+func (m *MockOptimizationModelRepository) FindAll() ([]model.OptimizationModel, error) {
+	var models []model.OptimizationModel
+	for _, om := range m.Models {
+		models = append(models, om)
+	}
+	return models, nil
+}
+
+func (m *MockOptimizationModelRepository) Update(id string, om *model.OptimizationModel) error {
+	if _, ok := m.Models[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.Models[id] = *om
+	return nil
+}
+
+func (m *MockOptimizationModelRepository) Delete(id string) error {
+	if _, ok := m.Models[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.Models, id)
+	return nil
+}
+
+// 実行結果の記録先。mockOwnerID のタスクにのみ記録できる
+type MockProcessOptimizationRepository struct {
+	Records []model.ProcessOptimization
+}
+
+func (m *MockProcessOptimizationRepository) Create(userID uint, po *model.ProcessOptimization) error {
+	if userID != 0 && userID != mockOwnerID {
+		return apperrors.ErrResourceAccessDenied
+	}
+	m.Records = append(m.Records, *po)
+	return nil
+}
+
+func (m *MockProcessOptimizationRepository) FindByID(userID uint, id string) (*model.ProcessOptimization, error) {
+	return nil, apperrors.ErrResourceNotFound
+}
+
+func (m *MockProcessOptimizationRepository) FindAll(userID uint) ([]model.ProcessOptimization, error) {
+	return m.Records, nil
+}
+
+func (m *MockProcessOptimizationRepository) Update(userID uint, id string, po *model.ProcessOptimization) error {
+	return nil
+}
+
+func (m *MockProcessOptimizationRepository) Modify(userID uint, id string, update func(*model.ProcessOptimization) error) (*model.ProcessOptimization, error) {
+	return nil, apperrors.ErrResourceNotFound
+}
+
+func (m *MockProcessOptimizationRepository) Delete(userID uint, id string) error {
+	return nil
+}
+
+func setupRouterAs(userID uint) (*gin.Engine, *MockProcessOptimizationRepository) {
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	repo := &MockOptimizationModelRepository{Models: map[string]model.OptimizationModel{
+		"cutting": {
+			ID:                "cutting",
+			Name:              "切削条件最適化",
+			ObjectiveFunction: "minimize(cycle_time) subject to roughness <= roughness_max",
+			Constraints:       `{"roughness_max": 1.6}`,
+			Parameters:        `{"speed": {"min": 50, "max": 300}, "feed": {"min": 0.05, "max": 0.5}, "cycle_time": {"expr": "length / (speed * feed)"}, "roughness": {"expr": "feed ^ 2 * 32 / 0.8"}}`,
+		},
+		// シードのような解釈できない記述のみのモデル
+		"trajectory_optimization": {
+			ID:                "trajectory_optimization",
+			Name:              "軌道最適化",
+			ObjectiveFunction: "minimize(time) + minimize(energy) subject to collision_free",
+			Parameters:        `{"max_velocity": 1000}`,
+		},
+	}}
+	optimizations := &MockProcessOptimizationRepository{}
+	ctl := &OptimizationModelController{Service: &service.OptimizationModelService{Repo: repo, Optimizations: optimizations}}
+
+	r.POST("/api/optimization_model", ctl.AddOptimizationModel)
+	r.GET("/api/optimization_model", ctl.ListOptimizationModels)
+	r.GET("/api/optimization_model/:id", ctl.GetOptimizationModel)
+	r.PUT("/api/optimization_model/:id", ctl.EditOptimizationModel)
+	r.DELETE("/api/optimization_model/:id", ctl.DeleteOptimizationModel)
+	r.POST("/api/optimization_model/:id/run", ctl.RunOptimizationModel)
+	return r, optimizations
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestOptimizationModelCRUD(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, _ := setupRouterAs(mockOwnerID)
+
+	w := serve(r, http.MethodPost, "/api/optimization_model", `{"name": "energy", "objective_function": "minimize(x)"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var added struct {
+		OptimizationModel model.OptimizationModel `json:"optimization_model"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &added))
+	assert.NotEmpty(t, added.OptimizationModel.ID)
+
+	w = serve(r, http.MethodPost, "/api/optimization_model", `{"objective_function": "minimize(x)"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(r, http.MethodGet, "/api/optimization_model/cutting", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(r, http.MethodPut, "/api/optimization_model/missing", `{"name": "x"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serve(r, http.MethodDelete, "/api/optimization_model/"+added.OptimizationModel.ID, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestOptimizationModelValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, _ := setupRouterAs(mockOwnerID)
+
+	invalid := map[string]string{
+		`{"name": "a", "objective_function": "optimize(force_distribution) + minimize(stress)"}`: "unknown function",
+		`{"name": "b", "objective_function": "minimize(x) subject to x + 1"}`:                    "expected a condition",
+		`{"name": "c", "constraints": "{\"joint_limits\": \"q_min<q<q_max\"}"}`:                  "constraints.joint_limits",
+		`{"name": "d", "parameters": "{\"t\": {\"expr\": \"x >\"}}"}`:                            "parameters.t",
+	}
+	for body, want := range invalid {
+		w := serve(r, http.MethodPost, "/api/optimization_model", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_FORMAT), body)
+		assert.Contains(t, w.Body.String(), want, body)
+	}
+
+	w := serve(r, http.MethodPut, "/api/optimization_model/cutting", `{"name": "切削", "objective_function": "minimize(cycle_time) < 3"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_FORMAT))
+
+	// 決定変数の無いモデルも保存はできる
+	w = serve(r, http.MethodPut, "/api/optimization_model/trajectory_optimization", `{"name": "軌道最適化", "objective_function": "minimize(time) + minimize(energy) subject to collision_free"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRunOptimizationModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, optimizations := setupRouterAs(mockOwnerID)
+
+	for _, method := range []string{"grid_search", "nelder_mead", "simulated_annealing"} {
+		body := `{"task_id": 3, "method": "` + method + `", "optimization_type": "speed", "inputs": {"length": 1000}, "initial": {"speed": 100, "feed": 0.1}, "seed": 1}`
+		w := serve(r, http.MethodPost, "/api/optimization_model/cutting/run", body)
+		if !assert.Equal(t, http.StatusOK, w.Code, method) {
+			continue
+		}
+		var res struct {
+			ProcessOptimization model.ProcessOptimization `json:"process_optimization"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		po := res.ProcessOptimization
+		assert.Equal(t, "cutting", po.OptimizationModelID)
+		assert.Equal(t, "cutting", po.ProcessID)
+		assert.Equal(t, method, po.Method)
+		assert.Greater(t, po.Iterations, 0)
+		assert.Greater(t, po.Improvement, 50.0, method)
+		assert.Equal(t, "objective", po.Metric)
+		assert.Equal(t, mockOwnerID, po.CreatedBy)
+		assert.Equal(t, model.ValidationPending, po.ValidationStatus)
+		assert.Equal(t, true, po.OptimizedState["feasible"])
+		params := po.OptimizedState["parameters"].(map[string]interface{})
+		assert.InDelta(t, 0.2, params["feed"], 0.01, method)
+	}
+	assert.Len(t, optimizations.Records, 3)
+}
+
+func TestRunOptimizationModelErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, optimizations := setupRouterAs(mockOwnerID)
+
+	cases := []struct {
+		path, body string
+		code       int
+		detail     string
+	}{
+		{"/api/optimization_model/missing/run", `{"task_id": 3, "method": "nelder_mead"}`, http.StatusNotFound, ""},
+		{"/api/optimization_model/cutting/run", `{"task_id": 3}`, http.StatusBadRequest, ""},
+		{"/api/optimization_model/cutting/run", `{"task_id": 3, "method": "gradient", "inputs": {"length": 1}}`, http.StatusBadRequest, "unknown method"},
+		{"/api/optimization_model/cutting/run", `{"task_id": 3, "method": "nelder_mead"}`, http.StatusBadRequest, "unbound variables: length"},
+		{"/api/optimization_model/trajectory_optimization/run", `{"task_id": 3, "method": "nelder_mead"}`, http.StatusBadRequest, "at least one variable"},
+	}
+	for _, tc := range cases {
+		w := serve(r, http.MethodPost, tc.path, tc.body)
+		assert.Equal(t, tc.code, w.Code, tc.body)
+		assert.Contains(t, w.Body.String(), tc.detail)
+	}
+	assert.Empty(t, optimizations.Records)
+
+	other, _ := setupRouterAs(2)
+	w := serve(other, http.MethodPost, "/api/optimization_model/cutting/run", `{"task_id": 3, "method": "nelder_mead", "inputs": {"length": 1000}}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,45 @@
+package optimization_model
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// RunOptimizationModel: POST /api/optimization_model/:id/run
+// モデルを grid_search / nelder_mead / simulated_annealing で実行し、結果を process_optimization として記録する
+func (ctl *OptimizationModelController) RunOptimizationModel(c *gin.Context) {
+	var req model.OptimizationRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	processOptimization, err := ctl.Service.RunOptimizationModel(authcontext.ScopeUserID(c), actorID, c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to run optimization model")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"message":              "Optimization model executed",
+		"process_optimization": processOptimization,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddPhenomenologicalFramework: POST /api/phenomenological_framework
@@ -25,12 +26,9 @@ func (ctl *PhenomenologicalFrameworkController) AddPhenomenologicalFramework(c *
 		return
 	}
 
-	if err := ctl.Service.CreatePhenomenologicalFramework(&phenomenologicalFramework); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add phenomenological framework",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.CreatePhenomenologicalFramework(userID, &phenomenologicalFramework); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add phenomenological framework")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
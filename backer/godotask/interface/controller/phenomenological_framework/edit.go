@@ -6,11 +6,13 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/errors"
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditPhenomenologicalFramework: PUT /api/phenomenological_framework/:id
 func (ctl *PhenomenologicalFrameworkController) EditPhenomenologicalFramework(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var phenomenologicalFramework model.PhenomenologicalFramework
 	if err := c.ShouldBindJSON(&phenomenologicalFramework); err != nil {
 		appErr := errors.NewAppError(
@@ -25,12 +27,8 @@ func (ctl *PhenomenologicalFrameworkController) EditPhenomenologicalFramework(c
 		})
 		return
 	}
-	if err := ctl.Service.UpdatePhenomenologicalFramework(id, &phenomenologicalFramework); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to edit phenomenological framework",
-		)
+	if err := ctl.Service.UpdatePhenomenologicalFramework(userID, id, &phenomenologicalFramework); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to edit phenomenological framework")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -0,0 +1,48 @@
+package phenomenological_framework
+
+import (
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// EvaluatePhenomenologicalFramework: POST /api/phenomenological_framework/:id/evaluate
+// タスクの評価・ヒューリスティクス分析・定量化ラベルの指標で goal_function を評価し、結果を履歴に追記する
+// ボディは省略でき、task_id で評価するタスクを、variables で追加の変数を指定できる
+func (ctl *PhenomenologicalFrameworkController) EvaluatePhenomenologicalFramework(c *gin.Context) {
+	var req model.PhenomenologicalFrameworkEvaluationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !stderrors.Is(err, io.EOF) {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	framework, evaluation, err := ctl.Service.EvaluatePhenomenologicalFramework(authcontext.ScopeUserID(c), c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to evaluate phenomenological framework")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":                    true,
+		"message":                    "Phenomenological framework evaluated",
+		"evaluation":                 evaluation,
+		"phenomenological_framework": framework,
+	})
+}
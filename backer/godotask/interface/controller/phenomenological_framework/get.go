@@ -4,18 +4,16 @@ import (
 	"net/http"
 	"github.com/godotask/errors"
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // PhenomenologicalFramework: GET /api/phenomenological_framework/:id
 func (ctl *PhenomenologicalFrameworkController) GetPhenomenologicalFramework(c *gin.Context) {
 	id := c.Param("id")
-	phenomenologicalFramework, err := ctl.Service.GetPhenomenologicalFrameworkByID(id)
+	userID := authcontext.ScopeUserID(c)
+	phenomenologicalFramework, err := ctl.Service.GetPhenomenologicalFrameworkByID(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Phenomenological framework not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Phenomenological framework not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
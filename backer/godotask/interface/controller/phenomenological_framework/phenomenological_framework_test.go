@@ -2,6 +2,7 @@ package phenomenological_framework
 
 import (
   "bytes"
+  "encoding/json"
   "net/http"
   "net/http/httptest"
   "testing"
@@ -9,19 +10,42 @@ import (
 
   "github.com/gin-gonic/gin"
   "github.com/stretchr/testify/assert"
+  apperrors "github.com/godotask/errors"
   "github.com/godotask/infrastructure/db/model"
   "github.com/godotask/usecase/service"
 )
 
 // モックリポジトリ
-type MockPhenomenologicalFrameworkRepository struct{}
+// GoalFunction / LimitMin / LimitMax を指定すると FindByID が返すフレームワークの値を置き換える
+// Assessments / Analyses / Labels は FindTaskRecords が返すタスク 100 の記録
+type MockPhenomenologicalFrameworkRepository struct {
+  GoalFunction string
+  LimitMin     float64
+  LimitMax     float64
+  Assessments  []model.Assessment
+  Analyses     []model.HeuristicsAnalysis
+  Labels       []model.QuantificationLabel
+  Saved        *model.PhenomenologicalFramework
+}
+
+const mockOwnerID uint = 1
 
-func (m *MockPhenomenologicalFrameworkRepository) Create(po *model.PhenomenologicalFramework) error {
+func authorizeMock(userID uint) error {
+  if userID != 0 && userID != mockOwnerID {
+    return apperrors.ErrResourceAccessDenied
+  }
   return nil
 }
 
-func (m *MockPhenomenologicalFrameworkRepository) FindByID(id string) (*model.PhenomenologicalFramework, error) {
-  return &model.PhenomenologicalFramework{
+func (m *MockPhenomenologicalFrameworkRepository) Create(userID uint, po *model.PhenomenologicalFramework) error {
+  return authorizeMock(userID)
+}
+
+func (m *MockPhenomenologicalFrameworkRepository) FindByID(userID uint, id string) (*model.PhenomenologicalFramework, error) {
+  if err := authorizeMock(userID); err != nil {
+    return nil, err
+  }
+  framework := &model.PhenomenologicalFramework{
     ID:            "1",
     TaskID:        100,
     Name:          "Test Framework",
@@ -38,10 +62,20 @@ func (m *MockPhenomenologicalFrameworkRepository) FindByID(id string) (*model.Ph
     Domain:        "AI",
     CreatedAt:     time.Now(),
     UpdatedAt:     time.Now(),
-  }, nil
+  }
+  if m.GoalFunction != "" {
+    framework.GoalFunction = m.GoalFunction
+    framework.LimitMin = m.LimitMin
+    framework.LimitMax = m.LimitMax
+  }
+  if m.Saved != nil {
+    framework.Result = m.Saved.Result
+    framework.Feedback = m.Saved.Feedback
+  }
+  return framework, nil
 }
 
-func (m *MockPhenomenologicalFrameworkRepository) FindAll() ([]model.PhenomenologicalFramework, error) {
+func (m *MockPhenomenologicalFrameworkRepository) FindAll(userID uint) ([]model.PhenomenologicalFramework, error) {
   return []model.PhenomenologicalFramework{
     {
       ID:            "1",
@@ -64,24 +98,63 @@ func (m *MockPhenomenologicalFrameworkRepository) FindAll() ([]model.Phenomenolo
   }, nil
 }
 
-func (m *MockPhenomenologicalFrameworkRepository) Update(id string, po *model.PhenomenologicalFramework) error {
-  return nil
+func (m *MockPhenomenologicalFrameworkRepository) Update(userID uint, id string, po *model.PhenomenologicalFramework) error {
+  return authorizeMock(userID)
 }
 
-func (m *MockPhenomenologicalFrameworkRepository) Delete(id string) error {
-  return nil
+func (m *MockPhenomenologicalFrameworkRepository) Delete(userID uint, id string) error {
+  return authorizeMock(userID)
+}
+
+func (m *MockPhenomenologicalFrameworkRepository) Modify(userID uint, id string, update func(*model.PhenomenologicalFramework) error) (*model.PhenomenologicalFramework, error) {
+  framework, err := m.FindByID(userID, id)
+  if err != nil {
+    return nil, err
+  }
+  if err := update(framework); err != nil {
+    return nil, err
+  }
+  // 保存時の JSON 変換を再現する
+  raw, _ := json.Marshal(framework)
+  saved := &model.PhenomenologicalFramework{}
+  _ = json.Unmarshal(raw, saved)
+  m.Saved = saved
+  return saved, nil
+}
+
+func (m *MockPhenomenologicalFrameworkRepository) FindTaskRecords(userID uint, taskID int) ([]model.Assessment, []model.HeuristicsAnalysis, []model.QuantificationLabel, error) {
+  if err := authorizeMock(userID); err != nil {
+    return nil, nil, nil, err
+  }
+  if taskID != 100 {
+    return nil, nil, nil, apperrors.ErrResourceNotFound
+  }
+  return m.Assessments, m.Analyses, m.Labels, nil
 }
 
 // テスト用ルーター
 func setupRouter() *gin.Engine {
+  return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
+  return setupRouterWithRepo(userID, role, &MockPhenomenologicalFrameworkRepository{})
+}
+
+func setupRouterWithRepo(userID uint, role string, mockRepo *MockPhenomenologicalFrameworkRepository) *gin.Engine {
   r := gin.Default()
-  mockRepo := &MockPhenomenologicalFrameworkRepository{}
+  r.Use(func(c *gin.Context) {
+    c.Set("user_id", userID)
+    c.Set("role", role)
+  })
   mockService := &service.PhenomenologicalFrameworkService{Repo: mockRepo}
   ctl := &PhenomenologicalFrameworkController{Service: mockService}
 
   r.POST("/api/phenomenologicalframework", ctl.AddPhenomenologicalFramework)
   r.PUT("/api/phenomenologicalframework/:id", ctl.EditPhenomenologicalFramework)
   r.DELETE("/api/phenomenologicalframework/:id", ctl.DeletePhenomenologicalFramework)
+  r.POST("/api/phenomenologicalframework/:id/evaluate", ctl.EvaluatePhenomenologicalFramework)
   return r
 }
 
@@ -100,7 +173,7 @@ func TestAddPhenomenologicalFramework(t *testing.T) {
     "feedback": {"note": "stable"},
     "limit_min": 0.5,
     "limit_max": 99.5,
-    "goal_function": "f(x)=x^2",
+    "goal_function": "x ^ 2",
     "abstract_level": "Medium",
     "domain": "AI"
   }`
@@ -132,6 +205,29 @@ func TestUpdatePhenomenologicalFramework(t *testing.T) {
   assert.Contains(t, w.Body.String(), "Phenomenological framework edited")
 }
 
+func TestSavePhenomenologicalFrameworkInvalidGoalFunction(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+  r := setupRouter()
+
+  cases := []struct{ method, path, goalFunction, want string }{
+    {http.MethodPost, "/api/phenomenologicalframework", "f(x)=x^2", "unexpected character '='"},
+    {http.MethodPost, "/api/phenomenologicalframework", "minimize(time) subject to time + 1", "expected a condition"},
+    {http.MethodPut, "/api/phenomenologicalframework/1", "accuracy > 0.9", "expected a number"},
+    {http.MethodPut, "/api/phenomenologicalframework/1", "exec(rm)", "unknown function"},
+  }
+  for _, tc := range cases {
+    body, _ := json.Marshal(map[string]interface{}{"task_id": 100, "name": "Framework", "goal_function": tc.goalFunction})
+    req, _ := http.NewRequest(tc.method, tc.path, bytes.NewBuffer(body))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusBadRequest, w.Code, tc.goalFunction)
+    assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_FORMAT), tc.goalFunction)
+    assert.Contains(t, w.Body.String(), tc.want, tc.goalFunction)
+  }
+}
+
 func TestDeletePhenomenologicalFramework(t *testing.T) {
   gin.SetMode(gin.TestMode)
   r := setupRouter()
@@ -143,3 +239,123 @@ func TestDeletePhenomenologicalFramework(t *testing.T) {
   assert.Equal(t, http.StatusOK, w.Code)
   assert.Contains(t, w.Body.String(), "Phenomenological framework deleted")
 }
+
+func TestPhenomenologicalFrameworkAccessDeniedForOtherUser(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+  r := setupRouterAs(2, "editor")
+
+  for _, method := range []string{http.MethodPut, http.MethodDelete} {
+    req, _ := http.NewRequest(method, "/api/phenomenologicalframework/1", bytes.NewBuffer([]byte(`{"task_id": 1}`)))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusForbidden, w.Code, method)
+    assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+  }
+}
+
+func TestEvaluatePhenomenologicalFramework(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+  burr := 0.12
+  repo := &MockPhenomenologicalFrameworkRepository{
+    GoalFunction: "assessment.effectiveness_score * 10 - heuristics.error_count * 5 - label.burr_height * 100",
+    LimitMin:     500,
+    LimitMax:     900,
+    Assessments: []model.Assessment{
+      {TaskID: 100, EffectivenessScore: 80},
+      {TaskID: 100, EffectivenessScore: 90},
+    },
+    Analyses: []model.HeuristicsAnalysis{{TaskID: 100, ErrorCount: 4}},
+    Labels:   []model.QuantificationLabel{{ID: "burr-height", TaskID: 100, Value: &burr}, {ID: "no_value", TaskID: 100}},
+  }
+  r := setupRouterWithRepo(mockOwnerID, "editor", repo)
+
+  evaluate := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+    req, _ := http.NewRequest(http.MethodPost, "/api/phenomenologicalframework/1/evaluate", bytes.NewBufferString(body))
+    if body != "" {
+      req.Header.Set("Content-Type", "application/json")
+    }
+    w := httptest.NewRecorder()
+    router.ServeHTTP(w, req)
+    return w
+  }
+  type response struct {
+    Evaluation service.FrameworkEvaluation   `json:"evaluation"`
+    Framework  model.PhenomenologicalFramework `json:"phenomenological_framework"`
+  }
+
+  // 850 - 20 - 12 = 818 は範囲内
+  w := evaluate(r, "")
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  var res response
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+  assert.InDelta(t, 818, res.Evaluation.Value, 1e-9)
+  assert.Equal(t, "within_limits", res.Evaluation.Status)
+  assert.False(t, res.Evaluation.Flagged)
+  assert.Equal(t, 100, res.Evaluation.TaskID)
+  assert.Equal(t, 85.0, res.Evaluation.Variables["assessment.effectiveness_score"])
+  assert.NotContains(t, res.Evaluation.Variables, "assessment.count")
+
+  // 既存の result / feedback のキーを残したまま履歴に追記する
+  assert.Equal(t, "success", res.Framework.Result["output"])
+  assert.Equal(t, "good", res.Framework.Feedback["quality"])
+  assert.Len(t, res.Framework.Result["evaluations"], 1)
+
+  // 追加の変数はタスクの指標より優先し、上限を超えた結果は flagged になる
+  w = evaluate(r, `{"variables": {"heuristics.error_count": -20}}`)
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+  assert.Equal(t, "above_max", res.Evaluation.Status)
+  assert.True(t, res.Evaluation.Flagged)
+  assert.InDelta(t, 38, res.Evaluation.Deviation, 1e-9)
+  if assert.Len(t, res.Framework.Feedback["evaluations"], 2) {
+    latest := res.Framework.Feedback["evaluations"].([]interface{})[1].(map[string]interface{})
+    assert.Equal(t, true, latest["flagged"])
+    assert.Contains(t, latest["message"], "above limit_max 900 by 38")
+  }
+  assert.Len(t, res.Framework.Result["evaluations"], 2)
+}
+
+func TestEvaluatePhenomenologicalFrameworkErrors(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+
+  evaluate := func(repo *MockPhenomenologicalFrameworkRepository, userID uint, body string) *httptest.ResponseRecorder {
+    req, _ := http.NewRequest(http.MethodPost, "/api/phenomenologicalframework/1/evaluate", bytes.NewBufferString(body))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    setupRouterWithRepo(userID, "editor", repo).ServeHTTP(w, req)
+    return w
+  }
+
+  // 式として解釈できない目標関数
+  w := evaluate(&MockPhenomenologicalFrameworkRepository{}, mockOwnerID, `{}`)
+  assert.Equal(t, http.StatusBadRequest, w.Code)
+  assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_FORMAT))
+
+  // タスクに無い指標を参照する
+  repo := &MockPhenomenologicalFrameworkRepository{GoalFunction: "min_defect"}
+  w = evaluate(repo, mockOwnerID, `{}`)
+  assert.Equal(t, http.StatusBadRequest, w.Code)
+  assert.Contains(t, w.Body.String(), string(apperrors.BIZ_INVALID_STATE))
+  assert.Contains(t, w.Body.String(), "unbound variables: min_defect")
+  assert.Nil(t, repo.Saved)
+
+  // 変数を与えれば評価できる
+  w = evaluate(repo, mockOwnerID, `{"variables": {"min_defect": 3}}`)
+  assert.Equal(t, http.StatusOK, w.Code)
+  assert.Contains(t, w.Body.String(), `"status":"unbounded"`)
+
+  // subject to の制約を満たしていない結果は flagged になる
+  repo = &MockPhenomenologicalFrameworkRepository{GoalFunction: "minimize(time) subject to collision_free"}
+  w = evaluate(repo, mockOwnerID, `{"variables": {"time": 5, "collision_free": 0}}`)
+  assert.Equal(t, http.StatusOK, w.Code)
+  assert.Contains(t, w.Body.String(), `"flagged":true`)
+  assert.Contains(t, w.Body.String(), "violates collision_free")
+
+  w = evaluate(repo, mockOwnerID, `{"task_id": 7}`)
+  assert.Equal(t, http.StatusNotFound, w.Code)
+
+  w = evaluate(repo, 2, `{}`)
+  assert.Equal(t, http.StatusForbidden, w.Code)
+}
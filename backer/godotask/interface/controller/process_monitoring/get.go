@@ -0,0 +1,28 @@
+package process_monitoring
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetProcessMonitoring: GET /api/process_monitoring/:id
+func (ctl *ProcessMonitoringController) GetProcessMonitoring(c *gin.Context) {
+	monitoring, err := ctl.Service.GetProcessMonitoringByID(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Process monitoring not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Process monitoring retrieved",
+		"process_monitoring": monitoring,
+	})
+}
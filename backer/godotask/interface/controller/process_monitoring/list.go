@@ -0,0 +1,42 @@
+package process_monitoring
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListProcessMonitorings: GET /api/process_monitoring?state_evaluation_id=...
+func (ctl *ProcessMonitoringController) ListProcessMonitorings(c *gin.Context) {
+	stateEvaluationID := c.Query("state_evaluation_id")
+	if stateEvaluationID == "" {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"state_evaluation_id is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	monitorings, err := ctl.Service.ListProcessMonitorings(authcontext.ScopeUserID(c), stateEvaluationID)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list process monitorings")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":             true,
+		"message":             "Process monitorings retrieved",
+		"process_monitorings": monitorings,
+	})
+}
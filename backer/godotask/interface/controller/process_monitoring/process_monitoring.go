@@ -0,0 +1,7 @@
+package process_monitoring
+
+import "github.com/godotask/usecase/service"
+
+type ProcessMonitoringController struct {
+	Service *service.ProcessMonitoringService
+}
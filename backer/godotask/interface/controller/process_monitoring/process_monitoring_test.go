@@ -0,0 +1,193 @@
+package process_monitoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+	if userID != 0 && userID != mockOwnerID {
+		return apperrors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+// モックリポジトリ: セッションとサンプルをメモリに保持する
+type MockProcessMonitoringRepository struct {
+	Sessions map[string]*model.ProcessMonitoring
+	Samples  []model.ProcessMonitoringSample
+}
+
+func (m *MockProcessMonitoringRepository) Create(userID uint, pm *model.ProcessMonitoring) error {
+	if err := authorizeMock(userID); err != nil {
+		return err
+	}
+	m.Sessions[pm.ID] = pm
+	return nil
+}
+
+func (m *MockProcessMonitoringRepository) FindByID(userID uint, id string) (*model.ProcessMonitoring, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	pm, ok := m.Sessions[id]
+	if !ok {
+		return nil, apperrors.ErrResourceNotFound
+	}
+	return pm, nil
+}
+
+func (m *MockProcessMonitoringRepository) FindByEvaluation(userID uint, id string) ([]model.ProcessMonitoring, error) {
+	return nil, authorizeMock(userID)
+}
+
+func (m *MockProcessMonitoringRepository) Modify(userID uint, id string, update func(*model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error)) (*model.ProcessMonitoring, error) {
+	pm, err := m.FindByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	copied := *pm
+	samples, err := update(&copied)
+	if err != nil {
+		return nil, err
+	}
+	m.Sessions[id] = &copied
+	m.Samples = append(m.Samples, samples...)
+	return &copied, nil
+}
+
+func (m *MockProcessMonitoringRepository) FindSamples(userID uint, id string, metric string) ([]model.ProcessMonitoringSample, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	return m.Samples, nil
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, repo *MockProcessMonitoringRepository) *gin.Engine {
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	ctl := &ProcessMonitoringController{Service: &service.ProcessMonitoringService{Repo: repo}}
+	r.POST("/api/process_monitoring", ctl.AddProcessMonitoring)
+	r.GET("/api/process_monitoring/:id", ctl.GetProcessMonitoring)
+	r.POST("/api/process_monitoring/:id/samples", ctl.AddSamples)
+	r.GET("/api/process_monitoring/:id/samples", ctl.ListSamples)
+	r.POST("/api/process_monitoring/:id/stop", ctl.StopProcessMonitoring)
+	return r
+}
+
+func post(r *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func samplesBody(metric string, values ...float64) string {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, fmt.Sprintf(`{"metric": %q, "value": %g}`, metric, v))
+	}
+	return `{"samples": [` + strings.Join(items, ",") + `]}`
+}
+
+func TestProcessMonitoringLifecycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockProcessMonitoringRepository{Sessions: map[string]*model.ProcessMonitoring{}}
+	r := setupRouterAs(mockOwnerID, repo)
+
+	w := post(r, "/api/process_monitoring", `{"state_evaluation_id": "se-1", "process_type": "cnc_turning", "detector_config": {"z_threshold": 3}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var started struct {
+		ProcessMonitoring model.ProcessMonitoring `json:"process_monitoring"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	id := started.ProcessMonitoring.ID
+	assert.Equal(t, "running", started.ProcessMonitoring.Status)
+
+	// 安定したサンプルでは異常を検知しない
+	w = post(r, "/api/process_monitoring/"+id+"/samples", samplesBody("spindle_temperature", 40, 40.4, 39.6, 40.2, 39.8, 40.1))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingest struct {
+		Accepted  int                         `json:"accepted"`
+		Anomalies []service.MonitoringAnomaly `json:"anomalies"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingest))
+	assert.Equal(t, 6, ingest.Accepted)
+	assert.Empty(t, ingest.Anomalies)
+
+	// 急上昇は次のリクエストでも保存された状態を元に検知される
+	w = post(r, "/api/process_monitoring/"+id+"/samples", samplesBody("spindle_temperature", 47.5))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingest))
+	if assert.Len(t, ingest.Anomalies, 1) {
+		assert.Equal(t, "spindle_temperature", ingest.Anomalies[0].Metric)
+		assert.Contains(t, ingest.Anomalies[0].Detectors, "z_score")
+	}
+	assert.Len(t, repo.Samples, 7)
+	assert.True(t, repo.Samples[6].Anomalous)
+
+	w = post(r, "/api/process_monitoring/"+id+"/stop", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stopped struct {
+		ProcessMonitoring model.ProcessMonitoring `json:"process_monitoring"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stopped))
+	assert.Equal(t, "stopped", stopped.ProcessMonitoring.Status)
+	assert.NotNil(t, stopped.ProcessMonitoring.EndTime)
+	var summary struct {
+		SampleCount  int `json:"sample_count"`
+		AnomalyCount int `json:"anomaly_count"`
+	}
+	assert.NoError(t, json.Unmarshal(stopped.ProcessMonitoring.Summary, &summary))
+	assert.Equal(t, 7, summary.SampleCount)
+	assert.Equal(t, 1, summary.AnomalyCount)
+
+	// 停止後はサンプルを受け付けず、二重停止もできない
+	w = post(r, "/api/process_monitoring/"+id+"/samples", samplesBody("spindle_temperature", 40))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.BIZ_INVALID_STATE))
+	w = post(r, "/api/process_monitoring/"+id+"/stop", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAddProcessMonitoringInvalidInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockProcessMonitoringRepository{Sessions: map[string]*model.ProcessMonitoring{}}
+	r := setupRouterAs(mockOwnerID, repo)
+
+	w := post(r, "/api/process_monitoring", `{"state_evaluation_id": "se-1", "process_type": "x", "detector_config": {"ewma_lambda": 2}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	repo.Sessions["pm"] = &model.ProcessMonitoring{ID: "pm", Status: "running"}
+	w = post(r, "/api/process_monitoring/pm/samples", `{"samples": [{"metric": "temp"}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProcessMonitoringAccessDeniedForOtherUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockProcessMonitoringRepository{Sessions: map[string]*model.ProcessMonitoring{
+		"pm": {ID: "pm", Status: "running"},
+	}}
+	r := setupRouterAs(2, repo)
+
+	w := post(r, "/api/process_monitoring/pm/samples", samplesBody("temp", 1))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+}
@@ -0,0 +1,64 @@
+package process_monitoring
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddSamples: POST /api/process_monitoring/:id/samples
+// サンプルを検知器に通し、今回検知した異常を返す
+func (ctl *ProcessMonitoringController) AddSamples(c *gin.Context) {
+	var req model.ProcessMonitoringSamplesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	result, err := ctl.Service.IngestSamples(authcontext.ScopeUserID(c), c.Param("id"), req.Samples)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add samples")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Samples added",
+		"accepted":  result.Accepted,
+		"anomalies": result.Anomalies,
+	})
+}
+
+// ListSamples: GET /api/process_monitoring/:id/samples?metric=...
+func (ctl *ProcessMonitoringController) ListSamples(c *gin.Context) {
+	samples, err := ctl.Service.ListSamples(authcontext.ScopeUserID(c), c.Param("id"), c.Query("metric"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list samples")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Samples retrieved",
+		"samples": samples,
+	})
+}
@@ -0,0 +1,28 @@
+package process_monitoring
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// StopProcessMonitoring: POST /api/process_monitoring/:id/stop
+func (ctl *ProcessMonitoringController) StopProcessMonitoring(c *gin.Context) {
+	monitoring, err := ctl.Service.StopMonitoring(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to stop process monitoring")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":            true,
+		"message":            "Process monitoring stopped",
+		"process_monitoring": monitoring,
+	})
+}
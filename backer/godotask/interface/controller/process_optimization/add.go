@@ -6,15 +6,12 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddProcessOptimization: POST /api/process_optimization
 func (ctl *ProcessOptimizationController) AddProcessOptimization(c *gin.Context) {
 	var processOptimization model.ProcessOptimization
-	if err := c.ShouldBindJSON(&processOptimization); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
 	if err := c.ShouldBindJSON(&processOptimization); err != nil {
 		appErr := errors.NewAppError(
 			errors.VAL_INVALID_INPUT,
@@ -28,12 +25,10 @@ func (ctl *ProcessOptimizationController) AddProcessOptimization(c *gin.Context)
 		})
 		return
 	}
-	if err := ctl.Service.CreateProcessOptimization(&processOptimization); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add process optimization",
-		)
+	userID := authcontext.ScopeUserID(c)
+	actorID, _ := authcontext.UserID(c)
+	if err := ctl.Service.CreateProcessOptimization(userID, actorID, &processOptimization); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add process optimization")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -5,17 +5,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteProcessOptimization: DELETE /api/process_optimization/:id
 func (ctl *ProcessOptimizationController) DeleteProcessOptimization(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteProcessOptimization(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Failed to delete process optimization",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteProcessOptimization(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Failed to delete process optimization")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
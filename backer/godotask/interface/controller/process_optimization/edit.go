@@ -6,11 +6,13 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditProcessOptimization: PUT /api/process_optimization/:id
 func (ctl *ProcessOptimizationController) EditProcessOptimization(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var processOptimization model.ProcessOptimization
 	if err := c.ShouldBindJSON(&processOptimization); err != nil {
 		appErr := errors.NewAppError(
@@ -25,12 +27,9 @@ func (ctl *ProcessOptimizationController) EditProcessOptimization(c *gin.Context
 		})
 		return
 	}
-	if err := ctl.Service.UpdateProcessOptimization(id, &processOptimization); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error()  + " | Failed to edit process optimization",
-		)
+	updated, err := ctl.Service.UpdateProcessOptimization(userID, id, &processOptimization)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()  + " | Failed to edit process optimization")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -41,6 +40,6 @@ func (ctl *ProcessOptimizationController) EditProcessOptimization(c *gin.Context
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "Process optimization edited",
-		"process_optimization": processOptimization,
+		"process_optimization": updated,
 	})
 }
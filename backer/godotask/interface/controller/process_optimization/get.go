@@ -4,22 +4,16 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetProcessOptimization: GET /api/process_optimization/:id
 func (ctl *ProcessOptimizationController) GetProcessOptimization(c *gin.Context) {
 	id := c.Param("id")
-	processOptimization, err := ctl.Service.GetProcessOptimizationByID(id)
+	userID := authcontext.ScopeUserID(c)
+	processOptimization, err := ctl.Service.GetProcessOptimizationByID(userID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Process optimization not found"})
-		return
-	}
-	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Process optimization not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Process optimization not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -2,6 +2,7 @@ package process_optimization
 
 import (
     "bytes"
+    "encoding/json"
     "net/http"
     "net/http/httptest"
     "testing"
@@ -9,18 +10,33 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    apperrors "github.com/godotask/errors"
     "github.com/godotask/infrastructure/db/model"
     "github.com/godotask/usecase/service"
 )
 
-// モックリポジトリ
-type MockProcessOptimizationRepository struct{}
+// モックリポジトリ。ValidationStatus は FindByID が返す記録の検証状態
+type MockProcessOptimizationRepository struct {
+    ValidationStatus string
+}
+
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
+    return nil
+}
 
-func (m *MockProcessOptimizationRepository) Create(po *model.ProcessOptimization) error {
-  return nil
+func (m *MockProcessOptimizationRepository) Create(userID uint, po *model.ProcessOptimization) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockProcessOptimizationRepository) FindByID(id string) (*model.ProcessOptimization, error) {
+func (m *MockProcessOptimizationRepository) FindByID(userID uint, id string) (*model.ProcessOptimization, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
   return &model.ProcessOptimization{
 		ID:              "1",
 		ProcessID:       "proc_001",
@@ -31,12 +47,12 @@ func (m *MockProcessOptimizationRepository) FindByID(id string) (*model.ProcessO
 		Method:          "GradientDescent",
 		Iterations:      5,
 		ConvergenceTime: 12.3,
-		ValidatedBy:     "tester",
-		ValidationDate:  time.Now(),
+		CreatedBy:       mockOwnerID,
+		ValidationStatus: m.ValidationStatus,
   }, nil
 }
 
-func (m *MockProcessOptimizationRepository) FindAll() ([]model.ProcessOptimization, error) {
+func (m *MockProcessOptimizationRepository) FindAll(userID uint) ([]model.ProcessOptimization, error) {
   return []model.ProcessOptimization{
     {
 			ID:              "1",
@@ -51,27 +67,55 @@ func (m *MockProcessOptimizationRepository) FindAll() ([]model.ProcessOptimizati
 			ValidatedBy:     "tester",
 			ValidationDate:  time.Now(),
     },
+    {ID: "2", ProcessID: "proc_001", OptimizationType: "speed", Improvement: 20, ValidationStatus: model.ValidationVerified, ValidationDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+    {ID: "3", ProcessID: "proc_002", OptimizationType: "speed", Improvement: 10, ValidationStatus: model.ValidationVerified, ValidationDate: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC)},
+    {ID: "4", ProcessID: "proc_002", OptimizationType: "accuracy", Improvement: 5, ValidationStatus: model.ValidationRejected},
   }, nil
 }
 
-func (m *MockProcessOptimizationRepository) Update(id string, po *model.ProcessOptimization) error {
-    return nil
+func (m *MockProcessOptimizationRepository) Update(userID uint, id string, po *model.ProcessOptimization) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockProcessOptimizationRepository) Delete(id string) error {
-    return nil
+func (m *MockProcessOptimizationRepository) Modify(userID uint, id string, update func(*model.ProcessOptimization) error) (*model.ProcessOptimization, error) {
+    po, err := m.FindByID(userID, id)
+    if err != nil {
+        return nil, err
+    }
+    if err := update(po); err != nil {
+        return nil, err
+    }
+    return po, nil
+}
+
+func (m *MockProcessOptimizationRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
 }
 
 // テスト用ルーター
 func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
+    return setupRouterWithRepo(userID, role, &MockProcessOptimizationRepository{ValidationStatus: model.ValidationPending})
+}
+
+func setupRouterWithRepo(userID uint, role string, mockRepo *MockProcessOptimizationRepository) *gin.Engine {
     r := gin.Default()
-    mockRepo := &MockProcessOptimizationRepository{}
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
     mockService := &service.ProcessOptimizationService{Repo: mockRepo}
     ctl := &ProcessOptimizationController{Service: mockService}
 
     r.POST("/api/processoptimization", ctl.AddProcessOptimization)
     r.PUT("/api/processoptimization/:id", ctl.EditProcessOptimization)
     r.DELETE("/api/processoptimization/:id", ctl.DeleteProcessOptimization)
+    r.POST("/api/processoptimization/:id/validate", ctl.ValidateProcessOptimization)
+    r.GET("/api/processoptimization_summary", ctl.GetProcessOptimizationSummary)
     return r
 }
 
@@ -130,3 +174,185 @@ func TestDeleteProcessOptimization(t *testing.T) {
     assert.Equal(t, http.StatusOK, w.Code)
     assert.Contains(t, w.Body.String(), "Process optimization deleted")
 }
+
+func TestProcessOptimizationAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "editor")
+
+    for _, method := range []string{http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/processoptimization/1", bytes.NewBuffer([]byte(`{"task_id": 1}`)))
+        req.Header.Set("Content-Type", "application/json")
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
+}
+
+func TestAddProcessOptimizationComputesImprovement(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    body := `{
+      "process_id": "proc_001",
+      "optimization_type": "speed",
+      "initial_state": {"cycle_time": 50, "quality": {"yield": 0.8}},
+      "optimized_state": {"cycle_time": 40, "quality": {"yield": 0.9}},
+      "metric": "cycle_time",
+      "improvement": 99,
+      "validation_status": "verified",
+      "validated_by": "someone"
+    }`
+    req, _ := http.NewRequest(http.MethodPost, "/api/processoptimization", bytes.NewBufferString(body))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var res struct {
+        ProcessOptimization model.ProcessOptimization `json:"process_optimization"`
+    }
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Equal(t, 20.0, res.ProcessOptimization.Improvement)
+    assert.Equal(t, "minimize", res.ProcessOptimization.MetricDirection)
+    assert.Equal(t, model.ValidationPending, res.ProcessOptimization.ValidationStatus)
+    assert.Equal(t, "", res.ProcessOptimization.ValidatedBy)
+    assert.Equal(t, mockOwnerID, res.ProcessOptimization.CreatedBy)
+
+    // 入れ子の指標と maximize
+    body = `{
+      "initial_state": {"quality": {"yield": 0.8}},
+      "optimized_state": {"quality": {"yield": 0.9}},
+      "metric": "quality.yield",
+      "metric_direction": "maximize"
+    }`
+    req, _ = http.NewRequest(http.MethodPost, "/api/processoptimization", bytes.NewBufferString(body))
+    req.Header.Set("Content-Type", "application/json")
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Equal(t, 12.5, res.ProcessOptimization.Improvement)
+}
+
+func TestAddProcessOptimizationInvalidMetric(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    body := `{"initial_state": {"cycle_time": 50}, "optimized_state": {}, "metric": "cycle_time"}`
+    req, _ := http.NewRequest(http.MethodPost, "/api/processoptimization", bytes.NewBufferString(body))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+    assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_INPUT))
+    assert.Contains(t, w.Body.String(), "optimized_state")
+}
+
+func TestValidateProcessOptimization(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+
+    validate := func(r *gin.Engine, body string) *httptest.ResponseRecorder {
+        req, _ := http.NewRequest(http.MethodPost, "/api/processoptimization/1/validate", bytes.NewBufferString(body))
+        req.Header.Set("Content-Type", "application/json")
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+        return w
+    }
+
+    // 記録したユーザー本人は検証できない
+    w := validate(setupRouter(), `{"decision": "verify"}`)
+    assert.Equal(t, http.StatusForbidden, w.Code)
+    assert.Contains(t, w.Body.String(), string(apperrors.BIZ_OPERATION_NOT_ALLOWED))
+
+    // 別のユーザー（管理者）が承認する
+    w = validate(setupRouterAs(3, "admin"), `{"decision": "verify", "comment": "reproduced on line 2"}`)
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var res struct {
+        ProcessOptimization model.ProcessOptimization `json:"process_optimization"`
+    }
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Equal(t, model.ValidationVerified, res.ProcessOptimization.ValidationStatus)
+    assert.Equal(t, "3", res.ProcessOptimization.ValidatedBy)
+    assert.Equal(t, "reproduced on line 2", res.ProcessOptimization.ValidationComment)
+    assert.False(t, res.ProcessOptimization.ValidationDate.IsZero())
+
+    // 却下
+    w = validate(setupRouterAs(3, "admin"), `{"decision": "reject"}`)
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), "Process optimization rejected")
+
+    // 検証済みの記録は再検証できない
+    w = validate(setupRouterWithRepo(3, "admin", &MockProcessOptimizationRepository{ValidationStatus: model.ValidationVerified}), `{"decision": "reject"}`)
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+    assert.Contains(t, w.Body.String(), string(apperrors.BIZ_INVALID_STATE))
+
+    // 不正な decision
+    w = validate(setupRouterAs(3, "admin"), `{"decision": "maybe"}`)
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+
+    // 参照できない記録
+    w = validate(setupRouterAs(2, "editor"), `{"decision": "verify"}`)
+    assert.Equal(t, http.StatusForbidden, w.Code)
+    assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+}
+
+func TestUpdateProcessOptimizationResetsValidation(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterWithRepo(mockOwnerID, "editor", &MockProcessOptimizationRepository{ValidationStatus: model.ValidationVerified})
+
+    req, _ := http.NewRequest(http.MethodPut, "/api/processoptimization/1", bytes.NewBufferString(`{"metric": "step", "metric_direction": "maximize"}`))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var res struct {
+        ProcessOptimization model.ProcessOptimization `json:"process_optimization"`
+    }
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Equal(t, 100.0, res.ProcessOptimization.Improvement)
+    assert.Equal(t, model.ValidationPending, res.ProcessOptimization.ValidationStatus)
+}
+
+func TestGetProcessOptimizationSummary(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouter()
+
+    req, _ := http.NewRequest(http.MethodGet, "/api/processoptimization_summary", nil)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var res struct {
+        Summary []service.OptimizationSummary `json:"summary"`
+    }
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Len(t, res.Summary, 2)
+    assert.Equal(t, "accuracy", res.Summary[0].Key)
+    assert.Equal(t, 1, res.Summary[0].Rejected)
+    assert.Equal(t, 0, res.Summary[0].Verified)
+    speed := res.Summary[1]
+    assert.Equal(t, "speed", speed.Key)
+    assert.Equal(t, 2, speed.Verified)
+    assert.Equal(t, 1, speed.Pending)
+    assert.Equal(t, 15.0, speed.MeanImprovement)
+    assert.Equal(t, 10.0, speed.MinImprovement)
+    assert.Equal(t, 20.0, speed.MaxImprovement)
+    assert.Equal(t, time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC), speed.LastValidatedAt.UTC())
+
+    req, _ = http.NewRequest(http.MethodGet, "/api/processoptimization_summary?group_by=process_id", nil)
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+    assert.Len(t, res.Summary, 2)
+    assert.Equal(t, "proc_001", res.Summary[0].Key)
+    assert.Equal(t, 20.0, res.Summary[0].MeanImprovement)
+
+    req, _ = http.NewRequest(http.MethodGet, "/api/processoptimization_summary?group_by=robot", nil)
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+}
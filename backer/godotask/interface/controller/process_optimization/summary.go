@@ -0,0 +1,31 @@
+package process_optimization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetProcessOptimizationSummary: GET /api/process_optimization_summary?group_by=optimization_type|process_id
+// 検証済みの改善率を最適化種別またはプロセスごとに集計する
+func (ctl *ProcessOptimizationController) GetProcessOptimizationSummary(c *gin.Context) {
+	groupBy := c.DefaultQuery("group_by", "optimization_type")
+	summaries, err := ctl.Service.Summary(authcontext.ScopeUserID(c), groupBy)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to summarize process optimizations")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"message":  "Process optimization summary retrieved",
+		"group_by": groupBy,
+		"summary":  summaries,
+	})
+}
@@ -0,0 +1,45 @@
+package process_optimization
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ValidateProcessOptimization: POST /api/process_optimization/:id/validate
+// 記録したユーザー以外が decision（verify / reject）で最適化結果を検証する
+func (ctl *ProcessOptimizationController) ValidateProcessOptimization(c *gin.Context) {
+	var req model.ProcessOptimizationValidationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	processOptimization, err := ctl.Service.ValidateProcessOptimization(authcontext.ScopeUserID(c), actorID, c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to validate process optimization")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"message":              "Process optimization " + processOptimization.ValidationStatus,
+		"process_optimization": processOptimization,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddQualitativeLabel: POST /api/qualitative_label
@@ -25,12 +26,9 @@ func (ctl *QualitativeLabelController) AddQualitativeLabel(c *gin.Context) {
 		return
 	}
 
-	if err := ctl.Service.CreateQualitativeLabel(&qualitativeLabel); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error(),
-		)
+	userID, _ := authcontext.UserID(c)
+	if err := ctl.Service.CreateQualitativeLabel(userID, &qualitativeLabel); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
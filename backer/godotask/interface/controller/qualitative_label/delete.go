@@ -5,17 +5,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteQualitativeLabel: DELETE /api/process_optimization/:id
 func (ctl *QualitativeLabelController) DeleteQualitativeLabel(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteQualitativeLabel(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			"Qualitative label not found",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteQualitativeLabel(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, "Qualitative label not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
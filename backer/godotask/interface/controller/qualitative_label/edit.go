@@ -6,11 +6,13 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditQualitativeLabel: PUT /api/qualitative_label/:id
 func (ctl *QualitativeLabelController) EditQualitativeLabel(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var qualitativeLabel model.QualitativeLabel
 	if err := c.ShouldBindJSON(&qualitativeLabel); err != nil {
 		appErr := errors.NewAppError(
@@ -25,12 +27,8 @@ func (ctl *QualitativeLabelController) EditQualitativeLabel(c *gin.Context) {
 		})
 		return
 	}
-	if err := ctl.Service.UpdateQualitativeLabel(id, &qualitativeLabel); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error(),
-		)
+	if err := ctl.Service.UpdateQualitativeLabel(userID, id, &qualitativeLabel); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -4,18 +4,16 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetQualitativeLabel: GET /api/qualitative_label/:id
 func (ctl *QualitativeLabelController) GetQualitativeLabel(c *gin.Context) {
 	id := c.Param("id")
-	qualitativeLabel, err := ctl.Service.GetQualitativeLabelByID(id)
+	userID := authcontext.ScopeUserID(c)
+	qualitativeLabel, err := ctl.Service.GetQualitativeLabelByID(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			"Memory not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, "Memory not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -9,6 +9,7 @@ import (
 
     "github.com/gin-gonic/gin"
     "github.com/stretchr/testify/assert"
+    apperrors "github.com/godotask/errors"
     "github.com/godotask/infrastructure/db/model"
     "github.com/godotask/usecase/service"
 )
@@ -16,13 +17,25 @@ import (
 // モックリポジトリ
 type MockQualitativeLabelRepository struct{}
 
-func (m *MockQualitativeLabelRepository) Create(ql *model.QualitativeLabel) error {
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+    if userID != 0 && userID != mockOwnerID {
+        return apperrors.ErrResourceAccessDenied
+    }
+    return nil
+}
+
+func (m *MockQualitativeLabelRepository) Create(userID uint, ql *model.QualitativeLabel) error {
   return nil
 }
 
-func (m *MockQualitativeLabelRepository) FindByID(id string) (*model.QualitativeLabel, error) {
+func (m *MockQualitativeLabelRepository) FindByID(userID uint, id string) (*model.QualitativeLabel, error) {
+    if err := authorizeMock(userID); err != nil {
+        return nil, err
+    }
   return &model.QualitativeLabel{
-		ID:        "1",
+		ID:        1,
 		TaskID:    100,
 		UserID:    1,
 		Content:   "Test label content",
@@ -32,10 +45,10 @@ func (m *MockQualitativeLabelRepository) FindByID(id string) (*model.Qualitative
   }, nil
 }
 
-func (m *MockQualitativeLabelRepository) FindAll() ([]model.QualitativeLabel, error) {
+func (m *MockQualitativeLabelRepository) FindAll(userID uint) ([]model.QualitativeLabel, error) {
   return []model.QualitativeLabel{
 		{
-			ID:        "1",
+			ID:        1,
 			TaskID:    100,
 			UserID:    1,
 			Content:   "Test label content",
@@ -46,17 +59,26 @@ func (m *MockQualitativeLabelRepository) FindAll() ([]model.QualitativeLabel, er
   }, nil
 }
 
-func (m *MockQualitativeLabelRepository) Update(id string, ql *model.QualitativeLabel) error {
-    return nil
+func (m *MockQualitativeLabelRepository) Update(userID uint, id string, ql *model.QualitativeLabel) error {
+    return authorizeMock(userID)
 }
 
-func (m *MockQualitativeLabelRepository) Delete(id string) error {
-    return nil
+func (m *MockQualitativeLabelRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
 }
 
 // テスト用ルーター
 func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
     r := gin.Default()
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
     mockRepo := &MockQualitativeLabelRepository{}
     mockService := &service.QualitativeLabelService{Repo: mockRepo}
     ctl := &QualitativeLabelController{Service: mockService}
@@ -116,3 +138,18 @@ func TestDeleteQualitativeLabel(t *testing.T) {
     assert.Equal(t, http.StatusOK, w.Code)
     assert.Contains(t, w.Body.String(), "Qualitative label deleted")
 }
+
+func TestQualitativeLabelAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "editor")
+
+    for _, method := range []string{http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/qualitative_label/1", bytes.NewBuffer([]byte(`{"task_id": 1}`)))
+        req.Header.Set("Content-Type", "application/json")
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
+}
@@ -0,0 +1,27 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// DeleteQuantificationLabel: DELETE /api/quantification_label/:id
+// 改訂履歴と他のラベルとの関係も削除する
+func (ctl *QuantificationLabelController) DeleteQuantificationLabel(c *gin.Context) {
+	if err := ctl.Service.DeleteLabel(authcontext.ScopeUserID(c), c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete quantification label")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Quantification label deleted",
+	})
+}
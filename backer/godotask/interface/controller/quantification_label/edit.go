@@ -0,0 +1,45 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// EditQuantificationLabel: PUT /api/quantification_label/:id
+// body は UpdateLabelRequest（updates と reason）。変更は改訂履歴に記録され、版が上がる
+func (ctl *QuantificationLabelController) EditQuantificationLabel(c *gin.Context) {
+	var req model.UpdateLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	label, err := ctl.Service.UpdateLabel(authcontext.ScopeUserID(c), actorID, c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to edit quantification label")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"message":              "Quantification label edited",
+		"quantification_label": label,
+	})
+}
@@ -0,0 +1,28 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetQuantificationLabel: GET /api/quantification_label/:id
+func (ctl *QuantificationLabelController) GetQuantificationLabel(c *gin.Context) {
+	label, err := ctl.Service.GetLabel(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Quantification label not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"message":              "Quantification label retrieved",
+		"quantification_label": label,
+	})
+}
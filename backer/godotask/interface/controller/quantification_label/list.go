@@ -0,0 +1,46 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListQuantificationLabels: GET /api/quantification_label
+// クエリは LabelSearchQuery（text, domain, category, unit, min_value, max_value, concepts, min_confidence,
+// verified, from, to, limit, offset, sort_by, sort_order）。total はページングしない場合の件数
+func (ctl *QuantificationLabelController) ListQuantificationLabels(c *gin.Context) {
+	var query model.LabelSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	labels, total, err := ctl.Service.SearchLabels(authcontext.ScopeUserID(c), &query)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list quantification labels")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":               true,
+		"message":               "Quantification labels retrieved",
+		"quantification_labels": labels,
+		"total":                 total,
+	})
+}
@@ -0,0 +1,7 @@
+package quantification_label
+
+import "github.com/godotask/usecase/service"
+
+type QuantificationLabelController struct {
+	Service *service.QuantificationLabelService
+}
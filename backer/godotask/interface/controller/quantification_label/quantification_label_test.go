@@ -0,0 +1,472 @@
+package quantification_label
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockQuantificationLabelRepository struct {
+	Labels        map[string]model.QuantificationLabel
+	Revisions     []model.LabelRevision
+	Relations     []model.LabelRelation
+	Verifications []model.LabelVerification
+}
+
+func (m *MockQuantificationLabelRepository) owned(userID uint, l model.QuantificationLabel) bool {
+	return userID == 0 || uint(l.UserID) == userID
+}
+
+func (m *MockQuantificationLabelRepository) Create(userID uint, label *model.QuantificationLabel) error {
+	m.Labels[label.ID] = *label
+	return nil
+}
+func (m *MockQuantificationLabelRepository) FindByID(userID uint, id string) (*model.QuantificationLabel, error) {
+	l, ok := m.Labels[id]
+	if !ok {
+		return nil, errors.ErrResourceNotFound
+	}
+	if !m.owned(userID, l) {
+		return nil, errors.ErrResourceAccessDenied
+	}
+	return &l, nil
+}
+func (m *MockQuantificationLabelRepository) Search(userID uint, query *model.LabelSearchQuery) ([]model.QuantificationLabel, int64, error) {
+	var out []model.QuantificationLabel
+	for _, l := range m.Labels {
+		if !m.owned(userID, l) || (query.Domain != "" && l.Domain != query.Domain) {
+			continue
+		}
+		if query.Text != "" && !strings.Contains(l.OriginalText, query.Text) {
+			continue
+		}
+		out = append(out, l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	total := int64(len(out))
+	if len(out) > query.Limit {
+		out = out[:query.Limit]
+	}
+	return out, total, nil
+}
+func (m *MockQuantificationLabelRepository) FindAll(userID uint, domain string) ([]model.QuantificationLabel, error) {
+	out, _, err := m.Search(userID, &model.LabelSearchQuery{Domain: domain, Limit: len(m.Labels)})
+	return out, err
+}
+func (m *MockQuantificationLabelRepository) Modify(userID uint, id string, update func(label *model.QuantificationLabel) (*model.LabelRevision, error)) (*model.QuantificationLabel, error) {
+	l, err := m.FindByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := update(l)
+	if err != nil {
+		return nil, err
+	}
+	if rev == nil {
+		return l, nil
+	}
+	m.Labels[id] = *l
+	m.Revisions = append(m.Revisions, *rev)
+	return l, nil
+}
+func (m *MockQuantificationLabelRepository) Delete(userID uint, id string) error {
+	if _, err := m.FindByID(userID, id); err != nil {
+		return err
+	}
+	delete(m.Labels, id)
+	return nil
+}
+func (m *MockQuantificationLabelRepository) FindRevisions(userID uint, id string) ([]model.LabelRevision, error) {
+	if _, err := m.FindByID(userID, id); err != nil {
+		return nil, err
+	}
+	var out []model.LabelRevision
+	for _, r := range m.Revisions {
+		if r.LabelID == id {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+func (m *MockQuantificationLabelRepository) FindSuggestionCandidates(userID uint, domain string) ([]model.QuantificationLabel, error) {
+	var out []model.QuantificationLabel
+	for _, l := range m.Labels {
+		public := l.PublicVisibility == nil || *l.PublicVisibility
+		if l.Validated && (m.owned(userID, l) || public) && (domain == "" || l.Domain == domain) {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+func (m *MockQuantificationLabelRepository) FindRelations(labelIDs []string, relationType string) ([]model.LabelRelation, error) {
+	var out []model.LabelRelation
+	for _, r := range m.Relations {
+		if r.RelationType == relationType {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *MockQuantificationLabelRepository) Verify(id string, verify func(label *model.QuantificationLabel, verifications []model.LabelVerification) (*model.LabelVerification, *model.LabelRevision, error)) (*model.QuantificationLabel, error) {
+	l, err := m.FindByID(0, id)
+	if err != nil {
+		return nil, err
+	}
+	var current []model.LabelVerification
+	for _, v := range m.Verifications {
+		if v.LabelID == id && v.Round == l.ReviewRound {
+			current = append(current, v)
+		}
+	}
+	v, rev, err := verify(l, current)
+	if err != nil {
+		return nil, err
+	}
+	m.Verifications = append(m.Verifications, *v)
+	m.Labels[id] = *l
+	if rev != nil {
+		m.Revisions = append(m.Revisions, *rev)
+	}
+	return l, nil
+}
+func (m *MockQuantificationLabelRepository) FindVerifications(userID uint, id string) ([]model.LabelVerification, error) {
+	if _, err := m.FindByID(userID, id); err != nil {
+		return nil, err
+	}
+	var out []model.LabelVerification
+	for _, v := range m.Verifications {
+		if v.LabelID == id {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+func (m *MockQuantificationLabelRepository) FindReviewQueue(verifierID uint, domain string) ([]model.QuantificationLabel, error) {
+	var out []model.QuantificationLabel
+	for _, l := range m.Labels {
+		if l.Status != "submitted" || uint(l.UserID) == verifierID {
+			continue
+		}
+		verified := false
+		for _, v := range m.Verifications {
+			verified = verified || (v.LabelID == l.ID && v.Round == l.ReviewRound && v.VerifierID == verifierID)
+		}
+		if !verified {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// ユーザー 1 の検証済みラベル（バリ・速度）とユーザー 2 の非公開ラベル
+func seedLabels() *MockQuantificationLabelRepository {
+	created := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	return &MockQuantificationLabelRepository{
+		Labels: map[string]model.QuantificationLabel{
+			"burr": {ID: "burr", UserID: 1, OriginalText: "バリの高さ", Category: "バリ", Domain: "machining",
+				Value: ptr(0.1), TypicalValue: ptr(0.1), MinRange: ptr(0.05), MaxRange: ptr(0.2), Unit: ptr("mm"),
+				Confidence: ptr(0.9), Validated: true, AbstractLevel: "concrete", Version: 1, CreatedAt: created},
+			"flash": {ID: "flash", UserID: 1, OriginalText: "flash height", Category: "flash", Domain: "machining",
+				Value: ptr(0.15), Unit: ptr("mm"), Confidence: ptr(0.8), Validated: true, Version: 1, CreatedAt: created},
+			"speed": {ID: "speed", UserID: 1, OriginalText: "feed speed", Category: "speed", Domain: "machining",
+				Value: ptr(100.0), MinRange: ptr(80.0), MaxRange: ptr(120.0), Unit: ptr("mm/min"),
+				Confidence: ptr(0.7), Validated: true, Version: 1, CreatedAt: created},
+			"private": {ID: "private", UserID: 2, OriginalText: "バリの量", Category: "バリ", Domain: "machining",
+				Value: ptr(3.0), Unit: ptr("個"), Confidence: ptr(0.4), Validated: true, PublicVisibility: ptr(false), Version: 1, CreatedAt: created},
+		},
+		Relations: []model.LabelRelation{
+			{ID: "r1", SourceID: "burr", TargetID: "flash", RelationType: "synonym", Strength: 0.9, Bidirectional: true},
+		},
+	}
+}
+
+func setupRouter(repo *MockQuantificationLabelRepository, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ctl := &QuantificationLabelController{Service: &service.QuantificationLabelService{
+		Repo: repo,
+		Now:  func() time.Time { return time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC) },
+	}}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	r.POST("/api/quantification_label", ctl.AddQuantificationLabel)
+	r.GET("/api/quantification_label", ctl.ListQuantificationLabels)
+	r.GET("/api/quantification_label/:id", ctl.GetQuantificationLabel)
+	r.PUT("/api/quantification_label/:id", ctl.EditQuantificationLabel)
+	r.DELETE("/api/quantification_label/:id", ctl.DeleteQuantificationLabel)
+	r.GET("/api/quantification_label/:id/revision", ctl.ListLabelRevisions)
+	r.GET("/api/quantification_label_statistics", ctl.GetLabelStatistics)
+	r.GET("/api/quantification_label_suggestion", ctl.SuggestLabelValues)
+	r.POST("/api/quantification_label/:id/submit", ctl.SubmitQuantificationLabel)
+	r.POST("/api/quantification_label/:id/verification", ctl.VerifyQuantificationLabel)
+	r.GET("/api/quantification_label/:id/verification", ctl.ListLabelVerifications)
+	r.GET("/api/label_review_queue", ctl.ListReviewQueue)
+	return r
+}
+
+func do(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder, key string) T {
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	var v T
+	require.NoError(t, json.Unmarshal(res[key], &v))
+	return v
+}
+
+func TestQuantificationLabelCRUD(t *testing.T) {
+	repo := seedLabels()
+	r := setupRouter(repo, 1)
+
+	rec := do(r, http.MethodPost, "/api/quantification_label",
+		`{"text":"少し粗い面","description":"Ra で表す","value":3.2,"unit":"µm","domain":"machining","category":"surface","confidence":0.6,"concepts":["粗さ"]}`)
+	created := decode[model.QuantificationLabel](t, rec, "quantification_label")
+	assert.Equal(t, 1, created.UserID)
+	assert.False(t, created.Validated)
+	assert.Equal(t, "manual", created.Source)
+	assert.Equal(t, 1, created.Version)
+	assert.Equal(t, "少し粗い面", created.NormalizedText)
+
+	// 正規化した語句は元の語句から作ったものであれば元の語句の変更に追従する
+	rec = do(r, http.MethodPut, "/api/quantification_label/"+created.ID, `{"updates":{"original_text":"少し粗い面（Ｒａ）"},"reason":"表記"}`)
+	assert.Equal(t, "少し粗い面 ra", decode[model.QuantificationLabel](t, rec, "quantification_label").NormalizedText)
+	rec = do(r, http.MethodPut, "/api/quantification_label/"+created.ID, `{"updates":{"normalized_text":"粗い面"},"reason":"代表語"}`)
+	assert.Equal(t, "粗い面", decode[model.QuantificationLabel](t, rec, "quantification_label").NormalizedText)
+	rec = do(r, http.MethodPut, "/api/quantification_label/"+created.ID, `{"updates":{"original_text":"やや粗い面"},"reason":"表記"}`)
+	assert.Equal(t, "粗い面", decode[model.QuantificationLabel](t, rec, "quantification_label").NormalizedText)
+
+	// 範囲が逆転している・値が無い
+	rec = do(r, http.MethodPost, "/api/quantification_label",
+		`{"text":"x","description":"d","value":1,"unit":"mm","domain":"d","category":"c","min_range":2,"max_range":1}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodPost, "/api/quantification_label", `{"text":"x","description":"d","unit":"mm","domain":"d","category":"c"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	rec = do(r, http.MethodGet, "/api/quantification_label?domain=machining&limit=2", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list struct {
+		Labels []model.QuantificationLabel `json:"quantification_labels"`
+		Total  int64                       `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Labels, 2)
+	assert.EqualValues(t, 4, list.Total)
+
+	rec = do(r, http.MethodGet, "/api/quantification_label?sort_by=user_id", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodGet, "/api/quantification_label?from=yesterday", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// 他のユーザーのラベルは参照・削除できない
+	rec = do(r, http.MethodGet, "/api/quantification_label/private", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodDelete, "/api/quantification_label/private", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = do(r, http.MethodDelete, "/api/quantification_label/"+created.ID, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodGet, "/api/quantification_label/"+created.ID, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
+
+func TestQuantificationLabelRevisions(t *testing.T) {
+	repo := seedLabels()
+	r := setupRouter(repo, 1)
+
+	rec := do(r, http.MethodPut, "/api/quantification_label/burr", `{"updates":{"value":0.12,"notes":"再測定"},"reason":"再測定の結果"}`)
+	updated := decode[model.QuantificationLabel](t, rec, "quantification_label")
+	assert.Equal(t, 0.12, *updated.Value)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, "1", updated.UpdatedBy)
+	assert.True(t, updated.Validated)
+
+	// 値が変わらない場合は版も履歴も増えない
+	rec = do(r, http.MethodPut, "/api/quantification_label/burr", `{"updates":{"value":0.12},"reason":"確認"}`)
+	assert.Equal(t, 2, decode[model.QuantificationLabel](t, rec, "quantification_label").Version)
+
+	revisions := decode[[]model.LabelRevision](t, do(r, http.MethodGet, "/api/quantification_label/burr/revision", ""), "revisions")
+	require.Len(t, revisions, 1)
+	assert.Equal(t, 2, revisions[0].Version)
+	assert.Equal(t, "再測定の結果", revisions[0].Comment)
+	assert.Equal(t, map[string]interface{}{"old": 0.1, "new": 0.12}, revisions[0].Changes["value"])
+	assert.Contains(t, revisions[0].Changes, "notes")
+
+	// 検証状態・所有者は変更できず、理由は必須
+	rec = do(r, http.MethodPut, "/api/quantification_label/burr", `{"updates":{"validated":false,"user_id":2},"reason":"x"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "user_id, validated")
+	rec = do(r, http.MethodPut, "/api/quantification_label/burr", `{"updates":{"value":1},"reason":" "}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodPut, "/api/quantification_label/burr", `{"updates":{"confidence":1.5},"reason":"x"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(r, http.MethodPut, "/api/quantification_label/private", `{"updates":{"value":1},"reason":"x"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+}
+
+func TestLabelStatistics(t *testing.T) {
+	r := setupRouter(seedLabels(), 1)
+	stats := decode[model.LabelStatistics](t, do(r, http.MethodGet, "/api/quantification_label_statistics?domain=machining", ""), "statistics")
+	assert.Equal(t, 3, stats.TotalLabels)
+	assert.Equal(t, 2, stats.UnitDistribution["mm"])
+	assert.Equal(t, 3, stats.Temporal["2026-03"])
+	assert.Equal(t, 3, stats.Quality["validated"])
+	assert.InDelta(t, 0.8, stats.AverageMetrics["confidence"], 1e-9)
+	require.Len(t, stats.ValueDistribution, 2)
+	assert.Equal(t, "mm", stats.ValueDistribution[0]["unit"])
+	assert.InDelta(t, 0.125, stats.ValueDistribution[0]["mean"], 1e-9)
+}
+
+func TestSuggestLabelValues(t *testing.T) {
+	r := setupRouter(seedLabels(), 1)
+
+	suggestions := decode[[]model.LabelSuggestion](t, do(r, http.MethodGet, "/api/quantification_label_suggestion?text=バリの発生程度&domain=machining", ""), "suggestions")
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "burr", suggestions[0].LabelID)
+	assert.Equal(t, 0.1, suggestions[0].Value)
+	assert.Equal(t, "mm", suggestions[0].Unit)
+	// 同義語のラベルも提案され、他のユーザーの非公開ラベルは使われない
+	var ids []string
+	for _, s := range suggestions {
+		ids = append(ids, s.LabelID)
+		if s.LabelID == "flash" {
+			assert.Equal(t, "synonym", s.Source)
+			assert.Equal(t, "burr", s.Via)
+		}
+	}
+	assert.Contains(t, ids, "flash")
+	assert.NotContains(t, ids, "private")
+
+	// 比較表現は基準値を増減する
+	suggestions = decode[[]model.LabelSuggestion](t, do(r, http.MethodGet, "/api/quantification_label_suggestion?text=a+bit+faster", ""), "suggestions")
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "speed", suggestions[0].LabelID)
+	assert.InDelta(t, 110, suggestions[0].Value, 1e-9)
+	assert.InDelta(t, 132, *suggestions[0].MaxRange, 1e-9)
+
+	rec := do(r, http.MethodGet, "/api/quantification_label_suggestion", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+}
+
+func TestLabelVerificationWorkflow(t *testing.T) {
+	repo := seedLabels()
+	owner := setupRouter(repo, 1)
+
+	rec := do(owner, http.MethodPost, "/api/quantification_label",
+		`{"text":"バリの発生程度","description":"エッジのバリ","value":0.1,"unit":"mm","domain":"machining","category":"バリ"}`)
+	label := decode[model.QuantificationLabel](t, rec, "quantification_label")
+	assert.Equal(t, "draft", label.Status)
+	path := "/api/quantification_label/" + label.ID
+
+	// 下書きは検証できない
+	rec = do(setupRouter(repo, 2), http.MethodPost, path+"/verification", `{"decision":"accept"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	label = decode[model.QuantificationLabel](t, do(owner, http.MethodPost, path+"/submit", ""), "quantification_label")
+	assert.Equal(t, "submitted", label.Status)
+	assert.Equal(t, 1, label.ReviewRound)
+	rec = do(owner, http.MethodPost, path+"/submit", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	// 検証中は変更できず、自分では検証できない
+	rec = do(owner, http.MethodPut, path, `{"updates":{"value":0.2},"reason":"x"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(owner, http.MethodPost, path+"/verification", `{"decision":"accept"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	queue := decode[[]model.QuantificationLabel](t, do(setupRouter(repo, 2), http.MethodGet, "/api/label_review_queue", ""), "quantification_labels")
+	require.Len(t, queue, 1)
+	assert.Equal(t, label.ID, queue[0].ID)
+
+	rec = do(setupRouter(repo, 2), http.MethodPost, path+"/verification", `{"decision":"maybe"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+	rec = do(setupRouter(repo, 2), http.MethodPost, path+"/verification", `{"decision":"accept","criteria":{"color":true}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+
+	// 1 人目の採用では決まらない
+	label = decode[model.QuantificationLabel](t, do(setupRouter(repo, 2), http.MethodPost, path+"/verification", `{"decision":"accept"}`), "quantification_label")
+	assert.Equal(t, "submitted", label.Status)
+	assert.Equal(t, 1, label.VerificationCount)
+	assert.Nil(t, label.Agreement)
+	rec = do(setupRouter(repo, 2), http.MethodPost, path+"/verification", `{"decision":"accept"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Empty(t, decode[[]model.QuantificationLabel](t, do(setupRouter(repo, 2), http.MethodGet, "/api/label_review_queue", ""), "quantification_labels"))
+
+	// 2 人目が却下すると同数のため検証待ちのまま、3 人目の採用で採用になる
+	label = decode[model.QuantificationLabel](t, do(setupRouter(repo, 3), http.MethodPost, path+"/verification", `{"decision":"reject","comment":"範囲が無い"}`), "quantification_label")
+	assert.Equal(t, "submitted", label.Status)
+	// 全ての判定項目で意見が分かれると一致度は 0
+	require.NotNil(t, label.Agreement)
+	assert.Equal(t, 0.0, *label.Agreement)
+	label = decode[model.QuantificationLabel](t, do(setupRouter(repo, 4), http.MethodPost, path+"/verification", `{"decision":"accept","criteria":{"range":false}}`), "quantification_label")
+	assert.Equal(t, "accepted", label.Status)
+	assert.True(t, label.Validated)
+	assert.Equal(t, 3, label.VerificationCount)
+	assert.InDelta(t, 7.0/12, *label.Accuracy, 1e-9)
+	assert.NotNil(t, label.LastVerified)
+
+	rec = do(owner, http.MethodGet, path+"/verification", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res struct {
+		Verifications []model.LabelVerification `json:"verifications"`
+		Agreement     model.LabelAgreement      `json:"agreement"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res.Verifications, 3)
+	assert.Equal(t, "fleiss", res.Agreement.Method)
+	assert.Equal(t, 3, res.Agreement.Verifiers)
+	assert.Equal(t, "incorrect", res.Verifications[2].Criteria["range"])
+	rec = do(setupRouter(repo, 2), http.MethodGet, path+"/verification", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	// 採用されたラベルは値の提案に使われ、変更すると下書きに戻る
+	suggestions := decode[[]model.LabelSuggestion](t, do(setupRouter(repo, 2), http.MethodGet, "/api/quantification_label_suggestion?text=バリの発生程度", ""), "suggestions")
+	var ids []string
+	for _, sg := range suggestions {
+		ids = append(ids, sg.LabelID)
+	}
+	assert.Contains(t, ids, label.ID)
+	label = decode[model.QuantificationLabel](t, do(owner, http.MethodPut, path, `{"updates":{"min_range":0.05,"max_range":0.2},"reason":"範囲を追加"}`), "quantification_label")
+	assert.Equal(t, "draft", label.Status)
+	assert.False(t, label.Validated)
+
+	// 再提出すると次の回になり、前の回の検証者も再び検証できる
+	label = decode[model.QuantificationLabel](t, do(owner, http.MethodPost, path+"/submit", ""), "quantification_label")
+	assert.Equal(t, 2, label.ReviewRound)
+	assert.Zero(t, label.VerificationCount)
+	decode[model.QuantificationLabel](t, do(setupRouter(repo, 2), http.MethodPost, path+"/verification", `{"decision":"reject"}`), "quantification_label")
+	label = decode[model.QuantificationLabel](t, do(setupRouter(repo, 3), http.MethodPost, path+"/verification", `{"decision":"reject"}`), "quantification_label")
+	assert.Equal(t, "rejected", label.Status)
+	require.NotNil(t, label.Agreement)
+	assert.Equal(t, 1.0, *label.Agreement)
+
+	revisions := decode[[]model.LabelRevision](t, do(owner, http.MethodGet, path+"/revision", ""), "revisions")
+	var statuses []interface{}
+	for _, r := range revisions {
+		if change, ok := r.Changes["status"].(map[string]interface{}); ok {
+			statuses = append(statuses, change["new"])
+		}
+	}
+	assert.Equal(t, []interface{}{"submitted", "accepted", "draft", "submitted", "rejected"}, statuses)
+}
@@ -0,0 +1,28 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListLabelRevisions: GET /api/quantification_label/:id/revision
+func (ctl *QuantificationLabelController) ListLabelRevisions(c *gin.Context) {
+	revisions, err := ctl.Service.ListRevisions(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list label revisions")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Label revisions retrieved",
+		"revisions": revisions,
+	})
+}
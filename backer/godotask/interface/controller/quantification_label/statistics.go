@@ -0,0 +1,28 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetLabelStatistics: GET /api/quantification_label_statistics?domain=
+func (ctl *QuantificationLabelController) GetLabelStatistics(c *gin.Context) {
+	stats, err := ctl.Service.GetStatistics(authcontext.ScopeUserID(c), c.Query("domain"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to get label statistics")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Label statistics retrieved",
+		"statistics": stats,
+	})
+}
@@ -0,0 +1,44 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// SuggestLabelValues: GET /api/quantification_label_suggestion?text=&domain=&limit=
+// 曖昧な語句（「バリの発生程度」「a bit faster」）に対する単位付きの値を、検証済みラベルとその同義語から提案する
+func (ctl *QuantificationLabelController) SuggestLabelValues(c *gin.Context) {
+	var req model.SuggestionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	res, err := ctl.Service.Suggest(authcontext.ScopeUserID(c), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to suggest values")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     "Values suggested",
+		"suggestions": res.Suggestions,
+	})
+}
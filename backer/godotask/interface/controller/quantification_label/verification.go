@@ -0,0 +1,108 @@
+package quantification_label
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// SubmitQuantificationLabel: POST /api/quantification_label/:id/submit
+// 下書き・差し戻しのラベルを検証に提出する
+func (ctl *QuantificationLabelController) SubmitQuantificationLabel(c *gin.Context) {
+	actorID, _ := authcontext.UserID(c)
+	label, err := ctl.Service.SubmitLabel(authcontext.ScopeUserID(c), actorID, c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to submit quantification label")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"message":              "Quantification label submitted for verification",
+		"quantification_label": label,
+	})
+}
+
+// VerifyQuantificationLabel: POST /api/quantification_label/:id/verification
+// label:verify 権限が必要。body は VerifyLabelRequest（decision, criteria, comment）
+func (ctl *QuantificationLabelController) VerifyQuantificationLabel(c *gin.Context) {
+	var req model.VerifyLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	actorID, _ := authcontext.UserID(c)
+	label, err := ctl.Service.VerifyLabel(actorID, c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to verify quantification label")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":              true,
+		"message":              "Verification recorded",
+		"quantification_label": label,
+	})
+}
+
+// ListLabelVerifications: GET /api/quantification_label/:id/verification
+// ラベルの所有者が参照できる。agreement は現在の提出の回の検証者間の一致度
+func (ctl *QuantificationLabelController) ListLabelVerifications(c *gin.Context) {
+	verifications, agreement, err := ctl.Service.ListVerifications(authcontext.ScopeUserID(c), c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list label verifications")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Label verifications retrieved",
+		"verifications": verifications,
+		"agreement":     agreement,
+	})
+}
+
+// ListReviewQueue: GET /api/label_review_queue?domain=
+// label:verify 権限が必要。自分が検証できる検証待ちのラベルを返す
+func (ctl *QuantificationLabelController) ListReviewQueue(c *gin.Context) {
+	actorID, _ := authcontext.UserID(c)
+	labels, err := ctl.Service.ReviewQueue(actorID, c.Query("domain"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list review queue")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":               true,
+		"message":               "Review queue retrieved",
+		"quantification_labels": labels,
+	})
+}
@@ -0,0 +1,25 @@
+package robot
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// DeleteRobot: DELETE /api/robot/:id
+func (ctl *RobotController) DeleteRobot(c *gin.Context) {
+	if err := ctl.Service.DeleteRobotSpecification(c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete robot")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Robot deleted",
+	})
+}
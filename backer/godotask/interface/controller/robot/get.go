@@ -0,0 +1,27 @@
+package robot
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// GetRobot: GET /api/robot/:id
+func (ctl *RobotController) GetRobot(c *gin.Context) {
+	robot, err := ctl.Service.GetRobotSpecificationByID(c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Robot not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Robot retrieved",
+		"robot":   robot,
+	})
+}
@@ -0,0 +1,31 @@
+package robot
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// ListRobots: GET /api/robot
+func (ctl *RobotController) ListRobots(c *gin.Context) {
+	robots, err := ctl.Service.ListRobotSpecifications()
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error()+" | Failed to list robots",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Robots retrieved",
+		"robots":  robots,
+	})
+}
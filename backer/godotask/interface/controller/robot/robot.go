@@ -0,0 +1,7 @@
+package robot
+
+import "github.com/godotask/usecase/service"
+
+type RobotController struct {
+	Service *service.RobotSpecificationService
+}
@@ -0,0 +1,120 @@
+package robot
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// モックリポジトリ
+type MockRobotRepository struct {
+	Robots map[string]model.RobotSpecification
+}
+
+func (m *MockRobotRepository) Create(robot *model.RobotSpecification) error {
+	m.Robots[robot.ID] = *robot
+	return nil
+}
+
+func (m *MockRobotRepository) FindByID(id string) (*model.RobotSpecification, error) {
+	robot, ok := m.Robots[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &robot, nil
+}
+
+func (m *MockRobotRepository) FindAll() ([]model.RobotSpecification, error) {
+	var robots []model.RobotSpecification
+	for _, r := range m.Robots {
+		robots = append(robots, r)
+	}
+	return robots, nil
+}
+
+func (m *MockRobotRepository) Update(id string, robot *model.RobotSpecification) error {
+	if _, ok := m.Robots[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.Robots[id] = *robot
+	return nil
+}
+
+func (m *MockRobotRepository) Delete(id string) error {
+	if _, ok := m.Robots[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.Robots, id)
+	return nil
+}
+
+func setupRouter() *gin.Engine {
+	r := gin.Default()
+	repo := &MockRobotRepository{Robots: map[string]model.RobotSpecification{
+		"industrial_arm_std": {ID: "industrial_arm_std", ModelName: "IND-ARM-500", DOF: 6},
+	}}
+	ctl := &RobotController{Service: &service.RobotSpecificationService{Repo: repo}}
+
+	r.POST("/api/robot", ctl.AddRobot)
+	r.GET("/api/robot", ctl.ListRobots)
+	r.GET("/api/robot/:id", ctl.GetRobot)
+	r.PUT("/api/robot/:id", ctl.EditRobot)
+	r.DELETE("/api/robot/:id", ctl.DeleteRobot)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddRobot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouter()
+
+	w := serve(r, http.MethodPost, "/api/robot", `{"id": "cobot", "model_name": "COBOT-2024", "dof": 7, "safety_features": ["human_detection"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Robot added")
+
+	w = serve(r, http.MethodPost, "/api/robot", `{"dof": 7}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.VAL_MISSING_FIELD))
+}
+
+func TestGetRobot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouter()
+
+	w := serve(r, http.MethodGet, "/api/robot/industrial_arm_std", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "IND-ARM-500")
+
+	w = serve(r, http.MethodGet, "/api/robot/unknown", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEditAndDeleteRobot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouter()
+
+	w := serve(r, http.MethodPut, "/api/robot/industrial_arm_std", `{"model_name": "IND-ARM-600"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Robot edited")
+
+	w = serve(r, http.MethodDelete, "/api/robot/industrial_arm_std", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(r, http.MethodDelete, "/api/robot/industrial_arm_std", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
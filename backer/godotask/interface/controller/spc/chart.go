@@ -0,0 +1,76 @@
+package spc
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase/service"
+)
+
+// GetChart: GET /api/spc/chart?task_id=...&label_id=...&type=individuals|xbar_r
+// 任意: source=measurement|multimodal, subgroup_size, unit, lsl, usl, limit
+func (ctl *SPCController) GetChart(c *gin.Context) {
+	q, err := parseChartQuery(c)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	result, err := ctl.Service.Chart(authcontext.ScopeUserID(c), q)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to compute SPC chart")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "SPC chart computed",
+		"spc":     result,
+	})
+}
+
+func parseChartQuery(c *gin.Context) (service.SPCChartQuery, error) {
+	q := service.SPCChartQuery{
+		LabelID: c.Query("label_id"),
+		Source:  c.Query("source"),
+		Type:    c.Query("type"),
+		Unit:    c.Query("unit"),
+	}
+	var err error
+	if q.TaskID, err = strconv.Atoi(c.Query("task_id")); err != nil {
+		return q, fmt.Errorf("task_id must be an integer")
+	}
+	for name, dst := range map[string]*int{"subgroup_size": &q.SubgroupSize, "limit": &q.Limit} {
+		if v := c.Query(name); v != "" {
+			if *dst, err = strconv.Atoi(v); err != nil {
+				return q, fmt.Errorf("%s must be an integer", name)
+			}
+		}
+	}
+	for name, dst := range map[string]**float64{"lsl": &q.LSL, "usl": &q.USL} {
+		if v := c.Query(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return q, fmt.Errorf("%s must be a number", name)
+			}
+			*dst = &f
+		}
+	}
+	return q, nil
+}
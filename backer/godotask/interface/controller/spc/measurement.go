@@ -0,0 +1,78 @@
+package spc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddMeasurements: POST /api/spc/measurement
+func (ctl *SPCController) AddMeasurements(c *gin.Context) {
+	var req model.SPCMeasurementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	measurements, err := ctl.Service.AddMeasurements(authcontext.ScopeUserID(c), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add measurements")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Measurements added",
+		"measurements": measurements,
+	})
+}
+
+// ListMeasurements: GET /api/spc/measurement?task_id=...&label_id=...&limit=...
+func (ctl *SPCController) ListMeasurements(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Query("task_id"))
+	if err != nil || c.Query("label_id") == "" {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"task_id and label_id are required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	measurements, err := ctl.Service.ListMeasurements(authcontext.ScopeUserID(c), taskID, c.Query("label_id"), limit)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list measurements")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Measurements retrieved",
+		"measurements": measurements,
+	})
+}
@@ -0,0 +1,7 @@
+package spc
+
+import "github.com/godotask/usecase/service"
+
+type SPCController struct {
+	Service *service.SPCService
+}
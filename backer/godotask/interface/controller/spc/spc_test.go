@@ -0,0 +1,228 @@
+package spc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+	if userID != 0 && userID != mockOwnerID {
+		return apperrors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+// モックリポジトリ: 測定値とラベルをメモリに保持する
+type MockSPCRepository struct {
+	Labels       map[string]*model.QuantificationLabel
+	Measurements []model.SPCMeasurement
+	Multimodal   []model.MultimodalData
+}
+
+func (m *MockSPCRepository) CreateMeasurements(userID uint, taskID int, measurements []model.SPCMeasurement) error {
+	if err := authorizeMock(userID); err != nil {
+		return err
+	}
+	m.Measurements = append(m.Measurements, measurements...)
+	return nil
+}
+
+func (m *MockSPCRepository) FindMeasurements(userID uint, taskID int, labelID string, limit int) ([]model.SPCMeasurement, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	var found []model.SPCMeasurement
+	for _, x := range m.Measurements {
+		if x.TaskID == taskID && x.LabelID == labelID {
+			found = append(found, x)
+		}
+	}
+	return found, nil
+}
+
+func (m *MockSPCRepository) FindMultimodal(userID uint, taskID int, unit string, limit int) ([]model.MultimodalData, error) {
+	return m.Multimodal, authorizeMock(userID)
+}
+
+func (m *MockSPCRepository) FindLabel(userID uint, taskID int, labelID string) (*model.QuantificationLabel, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	label, ok := m.Labels[labelID]
+	if !ok {
+		return nil, apperrors.ErrResourceNotFound
+	}
+	return label, nil
+}
+
+func newMockRepo() *MockSPCRepository {
+	unit := "mm"
+	lo, hi := 0.0, 0.3
+	return &MockSPCRepository{Labels: map[string]*model.QuantificationLabel{
+		"burr": {ID: "burr", UserID: 1, TaskID: 10, Unit: &unit, MinRange: &lo, MaxRange: &hi},
+	}}
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, repo *MockSPCRepository) *gin.Engine {
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	ctl := &SPCController{Service: &service.SPCService{Repo: repo}}
+	r.POST("/api/spc/measurement", ctl.AddMeasurements)
+	r.GET("/api/spc/measurement", ctl.ListMeasurements)
+	r.GET("/api/spc/chart", ctl.GetChart)
+	return r
+}
+
+func request(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func measurementsBody(subgroupSize int, values ...float64) string {
+	items := make([]string, 0, len(values))
+	for i, v := range values {
+		items = append(items, fmt.Sprintf(`{"value": %g, "subgroup": "lot%d"}`, v, i/subgroupSize+1))
+	}
+	return `{"task_id": 10, "label_id": "burr", "measurements": [` + strings.Join(items, ",") + `]}`
+}
+
+type chartResponse struct {
+	SPC struct {
+		Unit       string `json:"unit"`
+		Count      int    `json:"count"`
+		SpecSource string `json:"spec_source"`
+		Chart      struct {
+			Type   string `json:"type"`
+			Limits struct {
+				CenterLine float64 `json:"center_line"`
+			} `json:"limits"`
+			Points     []json.RawMessage `json:"points"`
+			Violations []struct {
+				Rule  int `json:"rule"`
+				Index int `json:"index"`
+			} `json:"violations"`
+		} `json:"chart"`
+		Capability struct {
+			Cp  *float64 `json:"cp"`
+			Cpk *float64 `json:"cpk"`
+		} `json:"capability"`
+	} `json:"spc"`
+}
+
+func TestSPCChartFromMeasurements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := newMockRepo()
+	r := setupRouterAs(mockOwnerID, repo)
+
+	w := request(r, http.MethodPost, "/api/spc/measurement", measurementsBody(3,
+		0.10, 0.12, 0.11, 0.11, 0.13, 0.12, 0.10, 0.11, 0.12, 0.12, 0.11, 0.10))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, repo.Measurements, 12)
+	assert.Equal(t, "mm", repo.Measurements[0].Unit)
+
+	// サブグループごとの X-bar/R 管理図。規格限界はラベルの範囲
+	w = request(r, http.MethodGet, "/api/spc/chart?task_id=10&label_id=burr&type=xbar_r", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res chartResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "xbar_r", res.SPC.Chart.Type)
+	assert.Len(t, res.SPC.Chart.Points, 4)
+	assert.Equal(t, "label", res.SPC.SpecSource)
+	assert.Equal(t, "mm", res.SPC.Unit)
+	if assert.NotNil(t, res.SPC.Capability.Cp) {
+		assert.Greater(t, *res.SPC.Capability.Cpk, 1.0)
+	}
+
+	// 規格限界の指定はラベルより優先する。外れ値はルール1で検知される
+	w = request(r, http.MethodPost, "/api/spc/measurement", `{"task_id": 10, "label_id": "burr", "measurements": [{"value": 0.4}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = request(r, http.MethodGet, "/api/spc/chart?task_id=10&label_id=burr&usl=0.2", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	res = chartResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, "individuals", res.SPC.Chart.Type)
+	assert.Equal(t, "request", res.SPC.SpecSource)
+	assert.Nil(t, res.SPC.Capability.Cp)
+	beyond := []int{}
+	for _, v := range res.SPC.Chart.Violations {
+		if v.Rule == 1 {
+			beyond = append(beyond, v.Index)
+		}
+	}
+	assert.Equal(t, []int{12}, beyond)
+}
+
+func TestSPCChartFromMultimodal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := newMockRepo()
+	for _, v := range []float64{120, 118, 121, 119, 122} {
+		repo.Multimodal = append(repo.Multimodal, model.MultimodalData{TaskID: 10, Value: v, Unit: "m/min", MinRange: 100, MaxRange: 140})
+	}
+	r := setupRouterAs(mockOwnerID, repo)
+
+	w := request(r, http.MethodGet, "/api/spc/chart?task_id=10&source=multimodal", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res chartResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 5, res.SPC.Count)
+	assert.Equal(t, "multimodal", res.SPC.SpecSource)
+	assert.InDelta(t, 120, res.SPC.Chart.Limits.CenterLine, 1e-6)
+}
+
+func TestSPCChartInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := newMockRepo()
+	r := setupRouterAs(mockOwnerID, repo)
+
+	cases := []struct {
+		path string
+		code int
+	}{
+		{"/api/spc/chart?label_id=burr", http.StatusBadRequest},
+		{"/api/spc/chart?task_id=10", http.StatusBadRequest},
+		{"/api/spc/chart?task_id=10&label_id=burr&type=pareto", http.StatusBadRequest},
+		{"/api/spc/chart?task_id=10&label_id=burr&subgroup_size=20", http.StatusBadRequest},
+		{"/api/spc/chart?task_id=10&label_id=burr&lsl=1&usl=0", http.StatusBadRequest},
+		{"/api/spc/chart?task_id=10&label_id=missing", http.StatusNotFound},
+		// 測定値が無い場合は管理限界を計算できない
+		{"/api/spc/chart?task_id=10&label_id=burr", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		w := request(r, http.MethodGet, tc.path, "")
+		assert.Equal(t, tc.code, w.Code, tc.path)
+	}
+
+	w := request(r, http.MethodPost, "/api/spc/measurement", `{"task_id": 10, "label_id": "burr", "measurements": [{"subgroup": "a"}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSPCAccessDeniedForOtherUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(2, newMockRepo())
+
+	w := request(r, http.MethodPost, "/api/spc/measurement", measurementsBody(1, 0.1))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+	w = request(r, http.MethodGet, "/api/spc/chart?task_id=10&label_id=burr", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
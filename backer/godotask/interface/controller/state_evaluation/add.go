@@ -0,0 +1,51 @@
+package state_evaluation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddStateEvaluation: POST /api/state_evaluation
+func (ctl *StateEvaluationController) AddStateEvaluation(c *gin.Context) {
+	var req model.EvaluationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	// 作業者の指定が無ければ自分自身の評価として扱う
+	if req.UserID == "" {
+		userID, _ := authcontext.UserID(c)
+		req.UserID = strconv.FormatUint(uint64(userID), 10)
+	}
+
+	stateEvaluation, err := ctl.Service.Evaluate(authcontext.ScopeUserID(c), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add state evaluation")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"message":          "State evaluation added",
+		"state_evaluation": stateEvaluation,
+	})
+}
@@ -0,0 +1,29 @@
+package state_evaluation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetStateEvaluation: GET /api/state_evaluation/:id
+func (ctl *StateEvaluationController) GetStateEvaluation(c *gin.Context) {
+	id := c.Param("id")
+	stateEvaluation, err := ctl.Service.GetStateEvaluationByID(authcontext.ScopeUserID(c), id)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | State evaluation not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"message":          "State evaluation retrieved",
+		"state_evaluation": stateEvaluation,
+	})
+}
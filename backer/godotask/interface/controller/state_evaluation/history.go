@@ -0,0 +1,28 @@
+package state_evaluation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetStateEvaluationHistory: GET /api/state_evaluation_history?work_target=...&user_id=...
+func (ctl *StateEvaluationController) GetStateEvaluationHistory(c *gin.Context) {
+	history, err := ctl.Service.GetHistory(authcontext.ScopeUserID(c), c.Query("user_id"), c.Query("work_target"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to get state evaluation history")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "State evaluation history retrieved",
+		"history": history,
+	})
+}
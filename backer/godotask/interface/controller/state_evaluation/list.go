@@ -0,0 +1,32 @@
+package state_evaluation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListStateEvaluations: GET /api/state_evaluation
+func (ctl *StateEvaluationController) ListStateEvaluations(c *gin.Context) {
+	stateEvaluations, err := ctl.Service.ListStateEvaluations(authcontext.ScopeUserID(c))
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error()+" | Failed to list state evaluations",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":           true,
+		"message":           "State evaluations retrieved",
+		"state_evaluations": stateEvaluations,
+	})
+}
@@ -0,0 +1,7 @@
+package state_evaluation
+
+import "github.com/godotask/usecase/service"
+
+type StateEvaluationController struct {
+	Service *service.StateEvaluationService
+}
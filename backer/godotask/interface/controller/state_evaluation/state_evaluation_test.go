@@ -0,0 +1,170 @@
+package state_evaluation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// モックリポジトリ
+type MockStateEvaluationRepository struct {
+	Created *model.StateEvaluation
+}
+
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+	if userID != 0 && userID != mockOwnerID {
+		return apperrors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+func (m *MockStateEvaluationRepository) Create(userID uint, se *model.StateEvaluation) error {
+	if err := authorizeMock(userID); err != nil {
+		return err
+	}
+	m.Created = se
+	return nil
+}
+
+func (m *MockStateEvaluationRepository) FindByID(userID uint, id string) (*model.StateEvaluation, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	return &model.StateEvaluation{ID: id, UserID: "user_001", TaskID: 1, Level: 1, WorkTarget: "welding", EvaluationScore: 75}, nil
+}
+
+func (m *MockStateEvaluationRepository) FindAll(userID uint) ([]model.StateEvaluation, error) {
+	return []model.StateEvaluation{{ID: "1", UserID: "user_001", TaskID: 1, WorkTarget: "welding"}}, nil
+}
+
+func (m *MockStateEvaluationRepository) History(userID uint, workerID string, workTarget string) ([]model.StateEvaluation, error) {
+	base := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	return []model.StateEvaluation{
+		{ID: "1", UserID: workerID, WorkTarget: workTarget, EvaluationScore: 60, CreatedAt: base},
+		{ID: "2", UserID: workerID, WorkTarget: workTarget, EvaluationScore: 79, CreatedAt: base.AddDate(0, 0, 1)},
+		{ID: "3", UserID: workerID, WorkTarget: workTarget, EvaluationScore: 80, CreatedAt: base.AddDate(0, 0, 2)},
+	}, nil
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string, repo *MockStateEvaluationRepository) *gin.Engine {
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", role)
+	})
+	ctl := &StateEvaluationController{Service: &service.StateEvaluationService{Repo: repo}}
+
+	r.POST("/api/state_evaluation", ctl.AddStateEvaluation)
+	r.GET("/api/state_evaluation", ctl.ListStateEvaluations)
+	r.GET("/api/state_evaluation/:id", ctl.GetStateEvaluation)
+	r.GET("/api/state_evaluation_history", ctl.GetStateEvaluationHistory)
+	return r
+}
+
+func TestAddStateEvaluation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &MockStateEvaluationRepository{}
+	r := setupRouterAs(mockOwnerID, "editor", repo)
+
+	body := `{
+	  "task_id": 1,
+	  "level": 2,
+	  "work_target": "welding",
+	  "current_state": {"accuracy": 0.6, "efficiency": 0.9},
+	  "target_state": {"accuracy": 0.8, "efficiency": 0.75}
+	}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/state_evaluation", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "State evaluation added")
+	if assert.NotNil(t, repo.Created) {
+		assert.Equal(t, "1", repo.Created.UserID)
+		assert.Equal(t, 87.5, repo.Created.EvaluationScore)
+		assert.NotEmpty(t, repo.Created.ID)
+
+		var results struct {
+			Gap map[string]float64 `json:"gap"`
+		}
+		assert.NoError(t, json.Unmarshal(repo.Created.Results, &results))
+		assert.InDelta(t, 0.2, results.Gap["accuracy"], 1e-9)
+		assert.InDelta(t, -0.15, results.Gap["efficiency"], 1e-9)
+	}
+}
+
+func TestAddStateEvaluationWithoutNumericTarget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(mockOwnerID, "editor", &MockStateEvaluationRepository{})
+
+	body := `{"task_id": 1, "level": 1, "work_target": "welding", "current_state": {}, "target_state": {"grade": "A"}}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/state_evaluation", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_INPUT))
+}
+
+func TestAddStateEvaluationWithInvalidDirection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(mockOwnerID, "editor", &MockStateEvaluationRepository{})
+
+	body := `{"task_id": 1, "level": 1, "work_target": "deburring", "current_state": {"burr_height": 0.1}, "target_state": {"burr_height": 0.05}, "directions": {"burr_height": "lower"}}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/state_evaluation", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "direction must be maximize or minimize")
+}
+
+func TestStateEvaluationHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(mockOwnerID, "viewer", &MockStateEvaluationRepository{})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/state_evaluation_history?user_id=user_001&work_target=welding", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		History service.StateEvaluationHistory `json:"history"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Len(t, res.History.Points, 3)
+	assert.Equal(t, 20.0, res.History.Convergence.Improvement)
+	assert.False(t, res.History.Convergence.Converged)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/state_evaluation_history", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStateEvaluationAccessDeniedForOtherUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(2, "editor", &MockStateEvaluationRepository{})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/state_evaluation/abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/google/uuid"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddTask: POST /api/task
@@ -41,7 +42,8 @@ func (ctl *TaskController) AddTask(c *gin.Context) {
 		return
 	}
 
-	if err := ctl.Service.CreateTask(&task); err != nil {
+	userID, _ := authcontext.UserID(c)
+	if err := ctl.Service.CreateTask(userID, &task); err != nil {
 		var appErr *errors.AppError
 
 		// エラー内容に応じた適切なエラーコードを設定
@@ -84,11 +86,7 @@ func (ctl *TaskController) AddTask(c *gin.Context) {
     Source:        "auto",
   }
   if err := ctl.KnowledgeEntityService.CreateKnowledgeEntity(ke); err != nil {
-		appErr := errors.NewAppError(
-			errors.VAL_INVALID_INPUT,
-			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
-			err.Error(),
-		)
+		appErr := errors.ToAppError(err, errors.VAL_INVALID_INPUT, err.Error())
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -3,13 +3,21 @@ package task
 import (
 	"net/http"
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteTask: DELETE /api/task/:id
 func (ctl *TaskController) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteTask(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteTask(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to delete task")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Task deleted"})
@@ -3,19 +3,27 @@ package task
 import (
 	"net/http"
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditTask: PUT /api/task/:id
 func (ctl *TaskController) EditTask(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var task model.Task
 	if err := c.ShouldBindJSON(&task); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := ctl.Service.UpdateTask(id, &task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to edit task"})
+	if err := ctl.Service.UpdateTask(userID, id, &task); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to edit task")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Task edited", "task": task})
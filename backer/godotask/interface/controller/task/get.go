@@ -3,14 +3,22 @@ package task
 import (
 	"net/http"
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetTask: GET /api/task/:id
 func (ctl *TaskController) GetTask(c *gin.Context) {
 	id := c.Param("id")
-	task, err := ctl.Service.GetTaskByID(id)
+	userID := authcontext.ScopeUserID(c)
+	task, err := ctl.Service.GetTaskByID(userID, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, "Task not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"task": task})
@@ -37,14 +37,15 @@ func (ctl *TaskController) ListTasks(c *gin.Context) {
 	})
 }
 
-// データの取得内容で変更予定
-// ListTotalTasks: GET /api/totalTask
+// ListTotalTasksPager: GET /api/task/total/pager
+// 自分のタスクと所属するワークスペースに共有されたタスクをまとめて返す（admin は全ユーザー分）
+// workspace_id を指定した場合はそのワークスペースのタスクのみ
 func (ctl *TaskController) ListTotalTasksPager(c *gin.Context) {
-	// userID, _ := authcontext.UserID(c)
-	// userID でフィルタしてタスク取得
   pager := tools.ParsePagerQuery(c)
+  scopeUserID := authcontext.ScopeUserID(c)
   filter := dtoquery.QueryFilter{
-    UserID: nil,
+    UserID:  &scopeUserID,
+    WorkspaceID: pager.WorkspaceID,
     TaskID:  pager.TaskID,
     Include: helperquery.ParseIncludeParam(c.Query("include")),
   }
@@ -89,6 +90,7 @@ func (ctl *TaskController) ListTasksPager(c *gin.Context) {
 
   filter := dtoquery.QueryFilter{
     UserID:  &pager.UserID,
+    WorkspaceID: pager.WorkspaceID,
     TaskID:  pager.TaskID,
     Include: helperquery.ParseIncludeParam(c.Query("include")),
   }
@@ -134,6 +136,7 @@ func (ctl *TaskController) ListSearchTasksPager(c *gin.Context) {
 
   filter := dtoquery.QueryFilter{
     UserID:  &pager.UserID,
+    WorkspaceID: pager.WorkspaceID,
     TaskID:  pager.TaskID,
     Include: helperquery.ParseIncludeParam(c.Query("include")),
     Search: pager.Search,
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddTaskWithErrorCode: エラーコードを使用したタスク追加の実装例
@@ -41,7 +42,8 @@ func (ctl *TaskController) AddTaskWithErrorCode(c *gin.Context) {
 	}
 
 	// タスクの作成
-	if err := ctl.Service.CreateTask(&task); err != nil {
+	userID, _ := authcontext.UserID(c)
+	if err := ctl.Service.CreateTask(userID, &task); err != nil {
 		// エラーの種類に応じて適切なエラーコードを設定
 		var appErr *errors.AppError
 
@@ -10,22 +10,31 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 
+	dtoquery "github.com/godotask/dto/query"
+	apperrors "github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
 	"github.com/godotask/usecase/service"
 )
 
 // モックリポジトリ
+// ID 1 の Task はユーザー 1 が所有している
 type MockTaskRepository struct{}
 
-func (m *MockTaskRepository) Create(task *model.Task) error {
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+	if userID != 0 && userID != mockOwnerID {
+		return apperrors.ErrResourceAccessDenied
+	}
 	return nil
 }
 
-func (m *MockTaskRepository) FindByID(id string) (*model.Task, error) {
+func mockTask() *model.Task {
 	now := time.Now()
 	return &model.Task{
 		ID:          1,
-		UserID:      1,
+		UserID:      int(mockOwnerID),
 		Title:       "Test Task Title",
 		Description: "Test Description",
 		Date:        &now,
@@ -33,28 +42,68 @@ func (m *MockTaskRepository) FindByID(id string) (*model.Task, error) {
 		Priority:    3,
 		CreatedAt:   now,
 		UpdatedAt:   now,
-	}, nil
+	}
 }
 
-func (m *MockTaskRepository) FindAll() ([]model.Task, error) {
-	task, _ := m.FindByID("1")
-	return []model.Task{*task}, nil
+func (m *MockTaskRepository) Create(task *model.Task) error {
+	return nil
 }
 
-func (m *MockTaskRepository) Update(id string, task *model.Task) error {
-	return nil
+func (m *MockTaskRepository) FindByID(userID uint, id string) (*model.Task, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	return mockTask(), nil
+}
+
+func (m *MockTaskRepository) FindAll(userID uint) ([]model.Task, error) {
+	return []model.Task{*mockTask()}, nil
+}
+
+func (m *MockTaskRepository) ListTasksPager(filter dtoquery.QueryFilter, offset int, perPage int) ([]model.Task, int64, error) {
+	return []model.Task{*mockTask()}, 1, nil
+}
+
+func (m *MockTaskRepository) ListSearchTasksPager(filter dtoquery.QueryFilter, offset int, perPage int) ([]model.Task, int64, error) {
+	return []model.Task{*mockTask()}, 1, nil
+}
+
+func (m *MockTaskRepository) ListTasksByUserPager(userID uint, offset int, perPage int) ([]model.Task, int64, error) {
+	return []model.Task{*mockTask()}, 1, nil
+}
+
+func (m *MockTaskRepository) Update(userID uint, id string, task *model.Task) error {
+	return authorizeMock(userID)
+}
+
+func (m *MockTaskRepository) Delete(userID uint, id string) error {
+	return authorizeMock(userID)
 }
 
-func (m *MockTaskRepository) Delete(id string) error {
+// タスク作成時に登録される KnowledgeEntity のモック
+type MockKnowledgeEntityRepository struct {
+	repository.KnowledgeEntityRepositoryInterface
+}
+
+func (m *MockKnowledgeEntityRepository) Create(entity *model.KnowledgeEntity) error {
 	return nil
 }
 
 // Router + Controller setup
 func setupRouter() *gin.Engine {
+	return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
 	r := gin.Default()
-	mockRepo := &MockTaskRepository{}
-	mockService := &service.TaskService{Repo: mockRepo}
-	ctl := &TaskController{Service: mockService}
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", role)
+	})
+	mockService := &service.TaskService{Repo: &MockTaskRepository{}}
+	keService := &service.KnowledgeEntityService{Repo: &MockKnowledgeEntityRepository{}}
+	ctl := &TaskController{Service: mockService, KnowledgeEntityService: keService}
 
 	r.POST("/api/task", ctl.AddTask)
 	r.GET("/api/task", ctl.ListTasks)
@@ -133,3 +182,27 @@ func TestGetTask(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "Test Task Title")
 }
+
+func TestTaskAccessDeniedForOtherUser(t *testing.T) {
+	r := setupRouterAs(2, "editor")
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		req, _ := http.NewRequest(method, "/api/task/1", bytes.NewBuffer([]byte(`{"title": "Hijacked"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, method)
+		assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+	}
+}
+
+func TestTaskAdminCanAccessOthers(t *testing.T) {
+	r := setupRouterAs(2, "admin")
+	req, _ := http.NewRequest(http.MethodGet, "/api/task/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Test Task Title")
+}
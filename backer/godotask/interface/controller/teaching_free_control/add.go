@@ -6,6 +6,7 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // AddTeachingFreeControl: POST /api/teaching_free_control
@@ -25,12 +26,9 @@ func (ctl *TeachingFreeControlController) AddTeachingFreeControl(c *gin.Context)
 		return
 	}
 
-	if err := ctl.Service.CreateTeachingFreeControl(&teachingFreeControl); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to add teaching free control",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.CreateTeachingFreeControl(userID, &teachingFreeControl); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to add teaching free control")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -0,0 +1,52 @@
+package teaching_free_control
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetAnalytics: GET /api/teaching_free_control/:id/analytics?window=...
+// 成功率・適応時間の 95% 信頼区間、エラー種別ごとの復帰率、window 回ごとの学習曲線を返す
+func (ctl *TeachingFreeControlController) GetAnalytics(c *gin.Context) {
+	window, _ := strconv.Atoi(c.Query("window"))
+	analytics, err := ctl.Service.Analytics(authcontext.ScopeUserID(c), c.Param("id"), window)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to compute analytics")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Analytics retrieved",
+		"analytics": analytics,
+	})
+}
+
+// GetLearningCurve: GET /api/teaching_free_control_learning_curve?robot_id=...&task_type=...&window=...
+// 参照できる制御設定の試行をロボット・タスク種別でまとめた学習曲線を返す
+func (ctl *TeachingFreeControlController) GetLearningCurve(c *gin.Context) {
+	window, _ := strconv.Atoi(c.Query("window"))
+	analytics, err := ctl.Service.LearningCurve(authcontext.ScopeUserID(c), c.Query("robot_id"), c.Query("task_type"), window)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to compute learning curve")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Learning curve retrieved",
+		"analytics": analytics,
+	})
+}
@@ -0,0 +1,66 @@
+package teaching_free_control
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// RecordAttempts: POST /api/teaching_free_control/:id/attempt
+// ロボットの実行試行を登録し、success_rate / adaptation_time を計算し直す
+func (ctl *TeachingFreeControlController) RecordAttempts(c *gin.Context) {
+	var req model.TeachingFreeControlAttemptsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	teachingFreeControl, attempts, err := ctl.Service.RecordAttempts(authcontext.ScopeUserID(c), c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to record attempts")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":               true,
+		"message":               "Attempts recorded",
+		"teaching_free_control": teachingFreeControl,
+		"attempts":              attempts,
+	})
+}
+
+// ListAttempts: GET /api/teaching_free_control/:id/attempt?limit=...
+func (ctl *TeachingFreeControlController) ListAttempts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	attempts, err := ctl.Service.ListAttempts(authcontext.ScopeUserID(c), c.Param("id"), limit)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list attempts")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"message":  "Attempts retrieved",
+		"attempts": attempts,
+	})
+}
@@ -5,17 +5,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // DeleteTeachingFreeControl: DELETE /api/teaching_free_control/:id
 func (ctl *TeachingFreeControlController) DeleteTeachingFreeControl(c *gin.Context) {
 	id := c.Param("id")
-	if err := ctl.Service.DeleteTeachingFreeControl(id); err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Failed to delete teaching free control",
-		)
+	userID := authcontext.ScopeUserID(c)
+	if err := ctl.Service.DeleteTeachingFreeControl(userID, id); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Failed to delete teaching free control")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
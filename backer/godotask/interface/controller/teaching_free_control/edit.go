@@ -6,11 +6,13 @@ import (
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // EditTeachingFreeControl: PUT /api/teaching_free_control/:id
 func (ctl *TeachingFreeControlController) EditTeachingFreeControl(c *gin.Context) {
 	id := c.Param("id")
+	userID := authcontext.ScopeUserID(c)
 	var teachingFreeControl model.TeachingFreeControl
 	if err := c.ShouldBindJSON(&teachingFreeControl); err != nil {
 		appErr := errors.NewAppError(
@@ -25,12 +27,8 @@ func (ctl *TeachingFreeControlController) EditTeachingFreeControl(c *gin.Context
 		})
 		return
 	}
-	if err := ctl.Service.UpdateTeachingFreeControl(id, &teachingFreeControl); err != nil {
-		appErr := errors.NewAppError(
-			errors.SYS_INTERNAL_ERROR,
-			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
-			err.Error() + " | Failed to edit teaching free control",
-		)
+	if err := ctl.Service.UpdateTeachingFreeControl(userID, id, &teachingFreeControl); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error() + " | Failed to edit teaching free control")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -4,18 +4,16 @@ import (
 	"net/http"
 	"github.com/gin-gonic/gin"
 	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
 )
 
 // GetTeachingFreeControl: GET /api/teaching_free_control/:id
 func (ctl *TeachingFreeControlController) GetTeachingFreeControl(c *gin.Context) {
 	id := c.Param("id")
-	teachingFreeControl, err := ctl.Service.GetTeachingFreeControlByID(id)
+	userID := authcontext.ScopeUserID(c)
+	teachingFreeControl, err := ctl.Service.GetTeachingFreeControlByID(userID, id)
 	if err != nil {
-		appErr := errors.NewAppError(
-			errors.RES_NOT_FOUND,
-			errors.GetErrorMessage(errors.RES_NOT_FOUND),
-			err.Error() + " | Teaching free control not found",
-		)
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error() + " | Teaching free control not found")
 		c.JSON(appErr.HTTPStatus, gin.H{
 			"code":    appErr.Code,
 			"message": appErr.Message,
@@ -0,0 +1,44 @@
+package teaching_free_control
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// Simulate: POST /api/teaching_free_control/:id/simulate
+// 実機の代わりに 2D ピックアンドプレースのシミュレータで制御設定を実行する。seed が同じなら同じ試行を返す
+func (ctl *TeachingFreeControlController) Simulate(c *gin.Context) {
+	var req model.TeachingFreeControlSimulationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	result, err := ctl.Service.Simulate(authcontext.ScopeUserID(c), c.Param("id"), &req)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to simulate teaching free control")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Simulation completed",
+		"simulation": result,
+	})
+}
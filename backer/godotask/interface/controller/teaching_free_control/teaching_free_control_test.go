@@ -2,6 +2,7 @@ package teaching_free_control
 
 import (
   "bytes"
+  "encoding/json"
   "net/http"
   "net/http/httptest"
   "testing"
@@ -9,18 +10,33 @@ import (
 
   "github.com/gin-gonic/gin"
   "github.com/stretchr/testify/assert"
+  apperrors "github.com/godotask/errors"
   "github.com/godotask/infrastructure/db/model"
   "github.com/godotask/usecase/service"
 )
 
-// モックリポジトリ
-type MockTeachingFreeControlRepository struct{}
+// モックリポジトリ。Attempts は制御設定 "1" に記録された試行
+type MockTeachingFreeControlRepository struct {
+  Attempts []model.TeachingFreeControlAttempt
+}
+
+const mockOwnerID uint = 1
 
-func (m *MockTeachingFreeControlRepository) Create(lo *model.TeachingFreeControl) error {
+func authorizeMock(userID uint) error {
+  if userID != 0 && userID != mockOwnerID {
+    return apperrors.ErrResourceAccessDenied
+  }
   return nil
 }
 
-func (m *MockTeachingFreeControlRepository) FindByID(id string) (*model.TeachingFreeControl, error) {
+func (m *MockTeachingFreeControlRepository) Create(userID uint, lo *model.TeachingFreeControl) error {
+  return authorizeMock(userID)
+}
+
+func (m *MockTeachingFreeControlRepository) FindByID(userID uint, id string) (*model.TeachingFreeControl, error) {
+  if err := authorizeMock(userID); err != nil {
+    return nil, err
+  }
   return &model.TeachingFreeControl{
 		ID:             "1",
 		TaskID:         100,
@@ -39,7 +55,7 @@ func (m *MockTeachingFreeControlRepository) FindByID(id string) (*model.Teaching
   }, nil
 }
 
-func (m *MockTeachingFreeControlRepository) FindAll() ([]model.TeachingFreeControl, error) {
+func (m *MockTeachingFreeControlRepository) FindAll(userID uint) ([]model.TeachingFreeControl, error) {
   return []model.TeachingFreeControl{
     {
 			ID:             "1",
@@ -60,24 +76,78 @@ func (m *MockTeachingFreeControlRepository) FindAll() ([]model.TeachingFreeContr
   }, nil
 }
 
-func (m *MockTeachingFreeControlRepository) Update(id string, po *model.TeachingFreeControl) error {
-    return nil
+func (m *MockTeachingFreeControlRepository) Update(userID uint, id string, po *model.TeachingFreeControl) error {
+    return authorizeMock(userID)
+}
+
+func (m *MockTeachingFreeControlRepository) Delete(userID uint, id string) error {
+    return authorizeMock(userID)
+}
+
+func (m *MockTeachingFreeControlRepository) RecordAttempts(userID uint, id string, attempts []model.TeachingFreeControlAttempt, update func(*model.TeachingFreeControl, []model.TeachingFreeControlAttempt) error) (*model.TeachingFreeControl, error) {
+  control, err := m.FindByID(userID, id)
+  if err != nil {
+    return nil, err
+  }
+  for i := range attempts {
+    attempts[i].TeachingFreeControlID = control.ID
+    attempts[i].RobotID = control.RobotID
+    attempts[i].TaskType = control.TaskType
+  }
+  m.Attempts = append(m.Attempts, attempts...)
+  if err := update(control, m.Attempts); err != nil {
+    return nil, err
+  }
+  return control, nil
+}
+
+func (m *MockTeachingFreeControlRepository) FindAttempts(userID uint, id string, limit int) ([]model.TeachingFreeControlAttempt, error) {
+  if err := authorizeMock(userID); err != nil {
+    return nil, err
+  }
+  return m.Attempts, nil
 }
 
-func (m *MockTeachingFreeControlRepository) Delete(id string) error {
-    return nil
+func (m *MockTeachingFreeControlRepository) FindAttemptsBy(userID uint, robotID string, taskType string, limit int) ([]model.TeachingFreeControlAttempt, error) {
+  var out []model.TeachingFreeControlAttempt
+  if authorizeMock(userID) != nil {
+    return out, nil
+  }
+  for _, a := range m.Attempts {
+    if (robotID == "" || a.RobotID == robotID) && (taskType == "" || a.TaskType == taskType) {
+      out = append(out, a)
+    }
+  }
+  return out, nil
 }
 
 // テスト用ルーター
 func setupRouter() *gin.Engine {
+    return setupRouterAs(mockOwnerID, "editor")
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, role string) *gin.Engine {
+    return setupRouterWithRepo(userID, role, &MockTeachingFreeControlRepository{})
+}
+
+func setupRouterWithRepo(userID uint, role string, mockRepo *MockTeachingFreeControlRepository) *gin.Engine {
     r := gin.Default()
-    mockRepo := &MockTeachingFreeControlRepository{}
+    r.Use(func(c *gin.Context) {
+        c.Set("user_id", userID)
+        c.Set("role", role)
+    })
     mockService := &service.TeachingFreeControlService{Repo: mockRepo}
     ctl := &TeachingFreeControlController{Service: mockService}
 
     r.POST("/api/teaching_free_control", ctl.AddTeachingFreeControl)
     r.PUT("/api/teaching_free_control/:id", ctl.EditTeachingFreeControl)
     r.DELETE("/api/teaching_free_control/:id", ctl.DeleteTeachingFreeControl)
+    r.POST("/api/teaching_free_control/:id/attempt", ctl.RecordAttempts)
+    r.GET("/api/teaching_free_control/:id/attempt", ctl.ListAttempts)
+    r.GET("/api/teaching_free_control/:id/analytics", ctl.GetAnalytics)
+    r.POST("/api/teaching_free_control/:id/simulate", ctl.Simulate)
+    r.GET("/api/teaching_free_control_learning_curve", ctl.GetLearningCurve)
     return r
 }
 
@@ -137,3 +207,189 @@ func TestDeleteTeachingFreeControl(t *testing.T) {
   assert.Equal(t, http.StatusOK, w.Code)
   assert.Contains(t, w.Body.String(), "Teaching free control deleted")
 }
+
+func TestTeachingFreeControlAccessDeniedForOtherUser(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    r := setupRouterAs(2, "editor")
+
+    for _, method := range []string{http.MethodPut, http.MethodDelete} {
+        req, _ := http.NewRequest(method, "/api/teaching_free_control/1", bytes.NewBuffer([]byte(`{"task_id": 1}`)))
+        req.Header.Set("Content-Type", "application/json")
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        assert.Equal(t, http.StatusForbidden, w.Code, method)
+        assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED), method)
+    }
+}
+
+func TestRecordAttemptsDerivesSuccessRate(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+  repo := &MockTeachingFreeControlRepository{}
+  r := setupRouterWithRepo(mockOwnerID, "editor", repo)
+
+  body := `{"attempts": [
+    {"outcome": "success", "duration": 2.0, "attempted_at": "2025-09-01T09:00:00Z"},
+    {"outcome": "failure", "duration": 5.0, "error_type": "grasp_slip", "recovery_action": "regrasp", "recovered": true, "attempted_at": "2025-09-01T09:01:00Z"},
+    {"outcome": "success", "duration": 4.0, "attempted_at": "2025-09-01T09:02:00Z"},
+    {"outcome": "success", "duration": 3.0, "source": "simulation", "attempted_at": "2025-09-01T09:03:00Z"}
+  ]}`
+  req, _ := http.NewRequest(http.MethodPost, "/api/teaching_free_control/1/attempt", bytes.NewBufferString(body))
+  req.Header.Set("Content-Type", "application/json")
+  w := httptest.NewRecorder()
+  r.ServeHTTP(w, req)
+
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  var res struct {
+    TeachingFreeControl model.TeachingFreeControl          `json:"teaching_free_control"`
+    Attempts            []model.TeachingFreeControlAttempt `json:"attempts"`
+  }
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+  assert.Equal(t, 0.75, res.TeachingFreeControl.SuccessRate)
+  assert.Equal(t, 3.0, res.TeachingFreeControl.AdaptationTime)
+  assert.Len(t, res.Attempts, 4)
+  assert.Equal(t, model.AttemptSourceRobot, res.Attempts[0].Source)
+  assert.Equal(t, model.AttemptSourceSimulation, res.Attempts[3].Source)
+  assert.Equal(t, "robot_001", res.Attempts[0].RobotID)
+
+  // 不正な outcome・duration の欠落は登録しない
+  for _, bad := range []string{
+    `{"attempts": [{"outcome": "partial", "duration": 1}]}`,
+    `{"attempts": [{"outcome": "success"}]}`,
+    `{"attempts": []}`,
+  } {
+    req, _ = http.NewRequest(http.MethodPost, "/api/teaching_free_control/1/attempt", bytes.NewBufferString(bad))
+    req.Header.Set("Content-Type", "application/json")
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Equal(t, http.StatusBadRequest, w.Code, bad)
+  }
+  assert.Len(t, repo.Attempts, 4)
+
+  // 他ユーザーは登録できない
+  other := setupRouterWithRepo(2, "editor", repo)
+  req, _ = http.NewRequest(http.MethodPost, "/api/teaching_free_control/1/attempt", bytes.NewBufferString(body))
+  req.Header.Set("Content-Type", "application/json")
+  w = httptest.NewRecorder()
+  other.ServeHTTP(w, req)
+  assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestTeachingFreeControlAnalytics(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+  base := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+  repo := &MockTeachingFreeControlRepository{}
+  for i := 0; i < 20; i++ {
+    a := model.TeachingFreeControlAttempt{TeachingFreeControlID: "1", RobotID: "robot_001", TaskType: "PickAndPlace", Outcome: model.AttemptSuccess, Duration: 3, AttemptedAt: base.Add(time.Duration(i) * time.Minute)}
+    // 前半の10回は半分失敗する
+    if i < 10 && i%2 == 0 {
+      a.Outcome = model.AttemptFailure
+      a.ErrorType = "collision"
+    }
+    repo.Attempts = append(repo.Attempts, a)
+  }
+  r := setupRouterWithRepo(mockOwnerID, "editor", repo)
+
+  req, _ := http.NewRequest(http.MethodGet, "/api/teaching_free_control/1/analytics?window=10", nil)
+  w := httptest.NewRecorder()
+  r.ServeHTTP(w, req)
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  var res struct {
+    Analytics service.TeachingFreeControlAnalytics `json:"analytics"`
+  }
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+  stats := res.Analytics.Stats
+  assert.Equal(t, 20, stats.Attempts)
+  assert.Equal(t, 0.75, stats.SuccessRate.Value)
+  assert.Less(t, stats.SuccessRate.Lower, 0.75)
+  assert.Greater(t, stats.SuccessRate.Upper, 0.75)
+  assert.Equal(t, 3.0, stats.AdaptationTime.Value)
+  assert.Equal(t, "collision", stats.Errors[0].ErrorType)
+  if assert.Len(t, res.Analytics.Curve, 2) {
+    assert.Equal(t, 0.5, res.Analytics.Curve[0].SuccessRate.Value)
+    assert.Equal(t, 1.0, res.Analytics.Curve[1].SuccessRate.Value)
+  }
+
+  req, _ = http.NewRequest(http.MethodGet, "/api/teaching_free_control_learning_curve?robot_id=robot_001&window=5", nil)
+  w = httptest.NewRecorder()
+  r.ServeHTTP(w, req)
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+  assert.Equal(t, "robot_001", res.Analytics.RobotID)
+  assert.Equal(t, 5, res.Analytics.Window)
+  assert.Len(t, res.Analytics.Curve, 4)
+
+  req, _ = http.NewRequest(http.MethodGet, "/api/teaching_free_control_learning_curve?task_type=Welding", nil)
+  w = httptest.NewRecorder()
+  r.ServeHTTP(w, req)
+  assert.Equal(t, http.StatusOK, w.Code)
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+  assert.Equal(t, 0, res.Analytics.Stats.Attempts)
+  assert.Empty(t, res.Analytics.Curve)
+
+  req, _ = http.NewRequest(http.MethodGet, "/api/teaching_free_control_learning_curve", nil)
+  w = httptest.NewRecorder()
+  r.ServeHTTP(w, req)
+  assert.Equal(t, http.StatusBadRequest, w.Code)
+  assert.Contains(t, w.Body.String(), string(apperrors.VAL_MISSING_FIELD))
+
+  req, _ = http.NewRequest(http.MethodGet, "/api/teaching_free_control/1/attempt", nil)
+  w = httptest.NewRecorder()
+  setupRouterWithRepo(2, "editor", repo).ServeHTTP(w, req)
+  assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestSimulateTeachingFreeControl(t *testing.T) {
+  gin.SetMode(gin.TestMode)
+  repo := &MockTeachingFreeControlRepository{}
+  r := setupRouterWithRepo(mockOwnerID, "editor", repo)
+
+  simulate := func(body string) *httptest.ResponseRecorder {
+    req, _ := http.NewRequest(http.MethodPost, "/api/teaching_free_control/1/simulate", bytes.NewBufferString(body))
+    req.Header.Set("Content-Type", "application/json")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    return w
+  }
+  type result struct {
+    Simulation service.TeachingFreeControlSimulation `json:"simulation"`
+  }
+
+  // 記録しない実行は同じ seed で同じ試行を返す
+  body := `{"trials": 30, "seed": 42, "start_at": "2025-09-01T09:00:00Z"}`
+  var first, second result
+  w := simulate(body)
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+  assert.NoError(t, json.Unmarshal(simulate(body).Body.Bytes(), &second))
+  assert.Equal(t, first.Simulation.Attempts, second.Simulation.Attempts)
+  assert.Empty(t, repo.Attempts)
+  assert.False(t, first.Simulation.Recorded)
+
+  sim := first.Simulation
+  assert.Len(t, sim.Attempts, 30)
+  assert.Equal(t, 10.0, sim.Config.ForceLimit)
+  assert.Equal(t, 30, sim.Stats.Attempts)
+  assert.Equal(t, model.AttemptSourceSimulation, sim.Attempts[0].Source)
+  assert.Equal(t, "robot_001", sim.Attempts[0].RobotID)
+  assert.Equal(t, time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC), sim.Attempts[0].AttemptedAt.UTC())
+  assert.True(t, sim.Attempts[1].AttemptedAt.After(sim.Attempts[0].AttemptedAt))
+
+  // 記録すると試行から success_rate が計算される
+  var recorded result
+  w = simulate(`{"trials": 30, "seed": 42, "record": true}`)
+  assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+  assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &recorded))
+  assert.True(t, recorded.Simulation.Recorded)
+  assert.Len(t, repo.Attempts, 30)
+  assert.Equal(t, recorded.Simulation.Stats.SuccessRate.Value, recorded.Simulation.TeachingFreeControl.SuccessRate)
+
+  w = simulate(`{"trials": 0}`)
+  assert.Equal(t, http.StatusBadRequest, w.Code)
+
+  req, _ := http.NewRequest(http.MethodPost, "/api/teaching_free_control/1/simulate", bytes.NewBufferString(body))
+  req.Header.Set("Content-Type", "application/json")
+  w = httptest.NewRecorder()
+  setupRouterAs(2, "editor").ServeHTTP(w, req)
+  assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,42 @@
+package tool_matching
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListToolMatchingResults: GET /api/tool_matching?state_evaluation_id=...
+func (ctl *ToolMatchingController) ListToolMatchingResults(c *gin.Context) {
+	stateEvaluationID := c.Query("state_evaluation_id")
+	if stateEvaluationID == "" {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"state_evaluation_id is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	results, err := ctl.Service.ListResults(authcontext.ScopeUserID(c), stateEvaluationID)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to list tool matching results")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":               true,
+		"message":               "Tool matching results retrieved",
+		"tool_matching_results": results,
+	})
+}
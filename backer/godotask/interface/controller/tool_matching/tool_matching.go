@@ -0,0 +1,7 @@
+package tool_matching
+
+import "github.com/godotask/usecase/service"
+
+type ToolMatchingController struct {
+	Service *service.ToolMatchingService
+}
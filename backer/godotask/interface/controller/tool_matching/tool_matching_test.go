@@ -0,0 +1,167 @@
+package tool_matching
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+const mockOwnerID uint = 1
+
+func authorizeMock(userID uint) error {
+	if userID != 0 && userID != mockOwnerID {
+		return apperrors.ErrResourceAccessDenied
+	}
+	return nil
+}
+
+// モックリポジトリ
+type MockRobotRepository struct{}
+
+func (m *MockRobotRepository) Create(robot *model.RobotSpecification) error { return nil }
+func (m *MockRobotRepository) FindByID(id string) (*model.RobotSpecification, error) {
+	return nil, apperrors.ErrResourceNotFound
+}
+func (m *MockRobotRepository) FindAll() ([]model.RobotSpecification, error) {
+	return []model.RobotSpecification{
+		{ID: "teaching_free_arm_v1", ModelName: "TF-ARM-001", DOF: 6, ReachMm: 850, PayloadKg: 5, RepeatAccuracyMm: 0.02, MaxSpeedMmS: 1000,
+			TeachingMethod: "vision", VisionSystem: "stereo_camera", SafetyFeatures: datatypes.JSON(`["collision_detection"]`)},
+		{ID: "collaborative_robot_v2", ModelName: "COBOT-2024", DOF: 7, ReachMm: 1200, PayloadKg: 10, RepeatAccuracyMm: 0.01, MaxSpeedMmS: 2000,
+			TeachingMethod: "ai", VisionSystem: "multi_camera", SafetyFeatures: datatypes.JSON(`["human_detection","emergency_stop"]`)},
+		{ID: "industrial_arm_std", ModelName: "IND-ARM-500", DOF: 6, ReachMm: 1500, PayloadKg: 20, RepeatAccuracyMm: 0.05, MaxSpeedMmS: 1500,
+			TeachingMethod: "manual", VisionSystem: "none", SafetyFeatures: datatypes.JSON(`["emergency_stop"]`)},
+	}, nil
+}
+func (m *MockRobotRepository) Update(id string, robot *model.RobotSpecification) error { return nil }
+func (m *MockRobotRepository) Delete(id string) error                                  { return nil }
+
+type MockStateEvaluationRepository struct{}
+
+func (m *MockStateEvaluationRepository) Create(userID uint, se *model.StateEvaluation) error {
+	return authorizeMock(userID)
+}
+func (m *MockStateEvaluationRepository) FindByID(userID uint, id string) (*model.StateEvaluation, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	return &model.StateEvaluation{ID: id, TaskID: 1, Tools: datatypes.JSON(`{"robot_requirements": {"payload_kg": 8}}`)}, nil
+}
+func (m *MockStateEvaluationRepository) FindAll(userID uint) ([]model.StateEvaluation, error) {
+	return nil, nil
+}
+func (m *MockStateEvaluationRepository) History(userID uint, workerID string, workTarget string) ([]model.StateEvaluation, error) {
+	return nil, nil
+}
+
+type MockToolMatchingResultRepository struct {
+	Saved []model.ToolMatchingResult
+}
+
+func (m *MockToolMatchingResultRepository) ReplaceForEvaluation(userID uint, id string, results []model.ToolMatchingResult) error {
+	if err := authorizeMock(userID); err != nil {
+		return err
+	}
+	m.Saved = results
+	return nil
+}
+func (m *MockToolMatchingResultRepository) FindByEvaluation(userID uint, id string) ([]model.ToolMatchingResult, error) {
+	if err := authorizeMock(userID); err != nil {
+		return nil, err
+	}
+	return m.Saved, nil
+}
+
+// setupRouterAs は認証済みユーザーとして振る舞うルーターを返す
+func setupRouterAs(userID uint, results *MockToolMatchingResultRepository) *gin.Engine {
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	ctl := &ToolMatchingController{Service: &service.ToolMatchingService{
+		Robots:      &MockRobotRepository{},
+		Evaluations: &MockStateEvaluationRepository{},
+		Results:     results,
+	}}
+	r.POST("/api/tool_matching", ctl.AddToolMatching)
+	r.GET("/api/tool_matching", ctl.ListToolMatchingResults)
+	return r
+}
+
+func postMatching(r *gin.Engine, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/api/tool_matching", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddToolMatching(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	results := &MockToolMatchingResultRepository{}
+	r := setupRouterAs(mockOwnerID, results)
+
+	w := postMatching(r, `{
+	  "state_evaluation_id": "se-1",
+	  "requirements": {"repeat_accuracy_mm": 0.02, "features": ["human_detection"]},
+	  "constraints": {"reach_mm": 1000},
+	  "preferences": {"features": 2}
+	}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		ToolMatching service.ToolMatchingOutcome `json:"tool_matching"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	// reach 850mm のアームは制約で除外される
+	if assert.Len(t, res.ToolMatching.Excluded, 1) {
+		assert.Equal(t, "teaching_free_arm_v1", res.ToolMatching.Excluded[0].RobotID)
+	}
+	if assert.Len(t, results.Saved, 2) {
+		assert.Equal(t, "collaborative_robot_v2", results.Saved[0].RobotID)
+		assert.Equal(t, 1, results.Saved[0].Rank)
+		assert.Equal(t, 1.0, results.Saved[0].MatchingScore)
+		assert.Equal(t, "industrial_arm_std", results.Saved[1].RobotID)
+		assert.Less(t, results.Saved[1].MatchingScore, results.Saved[0].MatchingScore)
+
+		// 状態評価の robot_requirements (payload_kg: 8) が推奨パラメータに反映される
+		var params struct {
+			Robot map[string]interface{} `json:"robot"`
+		}
+		assert.NoError(t, json.Unmarshal(results.Saved[0].Parameters, &params))
+		assert.Equal(t, 8.0, params.Robot["max_payload_kg"])
+	}
+}
+
+func TestAddToolMatchingInvalidCriteria(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(mockOwnerID, &MockToolMatchingResultRepository{})
+
+	w := postMatching(r, `{"state_evaluation_id": "se-1", "requirements": {"color": "red"}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_INPUT))
+}
+
+func TestToolMatchingAccessDeniedForOtherUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouterAs(2, &MockToolMatchingResultRepository{})
+
+	w := postMatching(r, `{"state_evaluation_id": "se-1"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/tool_matching?state_evaluation_id=se-1", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+}
@@ -0,0 +1,41 @@
+package visual_metaphor
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// AddVisualMetaphor: POST /api/visual_metaphor
+func (ctl *VisualMetaphorController) AddVisualMetaphor(c *gin.Context) {
+	var metaphor model.VisualMetaphor
+	if err := c.ShouldBindJSON(&metaphor); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	if err := ctl.Service.CreateVisualMetaphor(&metaphor); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error()+" | Failed to add visual metaphor")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Visual metaphor added",
+		"visual_metaphor": metaphor,
+	})
+}
@@ -0,0 +1,25 @@
+package visual_metaphor
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// DeleteVisualMetaphor: DELETE /api/visual_metaphor/:id
+func (ctl *VisualMetaphorController) DeleteVisualMetaphor(c *gin.Context) {
+	if err := ctl.Service.DeleteVisualMetaphor(c.Param("id")); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to delete visual metaphor")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Visual metaphor deleted",
+	})
+}
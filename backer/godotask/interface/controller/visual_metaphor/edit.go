@@ -0,0 +1,42 @@
+package visual_metaphor
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+)
+
+// EditVisualMetaphor: PUT /api/visual_metaphor/:id
+func (ctl *VisualMetaphorController) EditVisualMetaphor(c *gin.Context) {
+	id := c.Param("id")
+	var metaphor model.VisualMetaphor
+	if err := c.ShouldBindJSON(&metaphor); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	if err := ctl.Service.UpdateVisualMetaphor(id, &metaphor); err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Failed to edit visual metaphor")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Visual metaphor edited",
+		"visual_metaphor": metaphor,
+	})
+}
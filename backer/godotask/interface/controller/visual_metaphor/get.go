@@ -0,0 +1,27 @@
+package visual_metaphor
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// GetVisualMetaphor: GET /api/visual_metaphor/:id
+func (ctl *VisualMetaphorController) GetVisualMetaphor(c *gin.Context) {
+	metaphor, err := ctl.Service.GetVisualMetaphorByID(c.Param("id"))
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, err.Error()+" | Visual metaphor not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":         true,
+		"message":         "Visual metaphor retrieved",
+		"visual_metaphor": metaphor,
+	})
+}
@@ -0,0 +1,31 @@
+package visual_metaphor
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+)
+
+// ListVisualMetaphors: GET /api/visual_metaphor
+func (ctl *VisualMetaphorController) ListVisualMetaphors(c *gin.Context) {
+	metaphors, err := ctl.Service.ListVisualMetaphors()
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error()+" | Failed to list visual metaphors",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success":          true,
+		"message":          "Visual metaphors retrieved",
+		"visual_metaphors": metaphors,
+	})
+}
@@ -0,0 +1,7 @@
+package visual_metaphor
+
+import "github.com/godotask/usecase/service"
+
+type VisualMetaphorController struct {
+	Service *service.VisualMetaphorService
+}
@@ -0,0 +1,130 @@
+package visual_metaphor
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// モックリポジトリ
+type MockVisualMetaphorRepository struct {
+	Metaphors map[string]model.VisualMetaphor
+}
+
+func (m *MockVisualMetaphorRepository) Create(metaphor *model.VisualMetaphor) error {
+	m.Metaphors[metaphor.ID] = *metaphor
+	return nil
+}
+
+func (m *MockVisualMetaphorRepository) FindByID(id string) (*model.VisualMetaphor, error) {
+	metaphor, ok := m.Metaphors[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &metaphor, nil
+}
+
+func (m *MockVisualMetaphorRepository) FindAll() ([]model.VisualMetaphor, error) {
+	var metaphors []model.VisualMetaphor
+	for _, r := range m.Metaphors {
+		metaphors = append(metaphors, r)
+	}
+	return metaphors, nil
+}
+
+func (m *MockVisualMetaphorRepository) Update(id string, metaphor *model.VisualMetaphor) error {
+	if _, ok := m.Metaphors[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.Metaphors[id] = *metaphor
+	return nil
+}
+
+func (m *MockVisualMetaphorRepository) Delete(id string) error {
+	if _, ok := m.Metaphors[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.Metaphors, id)
+	return nil
+}
+
+func setupRouter() *gin.Engine {
+	r := gin.Default()
+	repo := &MockVisualMetaphorRepository{Metaphors: map[string]model.VisualMetaphor{
+		"id-1-card": {ID: "id-1-card", Metaphor: "credit card", ReferenceObject: "ID-1 card", Width: 85.6, Height: 53.98, Depth: 0.76},
+	}}
+	ctl := &VisualMetaphorController{Service: &service.VisualMetaphorService{Repo: repo}}
+
+	r.POST("/api/visual_metaphor", ctl.AddVisualMetaphor)
+	r.GET("/api/visual_metaphor", ctl.ListVisualMetaphors)
+	r.GET("/api/visual_metaphor/:id", ctl.GetVisualMetaphor)
+	r.PUT("/api/visual_metaphor/:id", ctl.EditVisualMetaphor)
+	r.DELETE("/api/visual_metaphor/:id", ctl.DeleteVisualMetaphor)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddVisualMetaphor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouter()
+
+	w := serve(r, http.MethodPost, "/api/visual_metaphor", `{"id": "coin-100", "reference_object": "100 yen coin", "width": 22.6, "depth": 1.7, "min_variability": -0.005, "max_variability": 0.005}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Visual metaphor added")
+
+	w = serve(r, http.MethodPost, "/api/visual_metaphor", `{"width": 10}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.VAL_MISSING_FIELD))
+
+	for _, body := range []string{
+		`{"id": "none", "reference_object": "no size"}`,
+		`{"id": "neg", "reference_object": "negative", "width": -1}`,
+		`{"id": "var", "reference_object": "variability", "width": 1, "min_variability": 0.1, "max_variability": -0.1}`,
+	} {
+		w = serve(r, http.MethodPost, "/api/visual_metaphor", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.Contains(t, w.Body.String(), string(apperrors.VAL_INVALID_INPUT))
+	}
+}
+
+func TestGetVisualMetaphor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouter()
+
+	w := serve(r, http.MethodGet, "/api/visual_metaphor/id-1-card", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "ID-1 card")
+
+	w = serve(r, http.MethodGet, "/api/visual_metaphor/unknown", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEditAndDeleteVisualMetaphor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := setupRouter()
+
+	w := serve(r, http.MethodPut, "/api/visual_metaphor/id-1-card", `{"min_variability": -0.001, "max_variability": 0.001}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Visual metaphor edited")
+
+	w = serve(r, http.MethodDelete, "/api/visual_metaphor/id-1-card", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(r, http.MethodDelete, "/api/visual_metaphor/id-1-card", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,34 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// DeleteWorkspace: DELETE /api/workspace/:id
+// 共有されていたタスク・メモリは削除せず、作成者個人のものに戻る（owner のみ）
+func (ctl *WorkspaceController) DeleteWorkspace(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := ctl.Service.DeleteWorkspace(authcontext.ScopeUserID(c), id); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Workspace deleted",
+		"workspace_id": id,
+	})
+}
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// GetWorkspace: GET /api/workspace/:id
+// メンバー一覧を含めて返す
+func (ctl *WorkspaceController) GetWorkspace(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	workspace, err := ctl.Service.GetWorkspace(authcontext.ScopeUserID(c), id)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.RES_NOT_FOUND, "Workspace not found")
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"message":   "Workspace retrieved",
+		"workspace": workspace,
+	})
+}
@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// AddInvitation: POST /api/workspace/:id/invitations
+// 招待トークンはこのレスポンスでのみ返す（DB にはハッシュのみ保存）
+func (ctl *WorkspaceController) AddInvitation(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	token, invitation, err := ctl.Service.InviteMember(authcontext.ScopeUserID(c), id, req.Email, req.Role)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Workspace invitation created",
+		"invitation": invitation,
+		"token":      token,
+	})
+}
+
+// ListInvitations: GET /api/workspace/:id/invitations
+// 未承諾かつ有効期限内の招待一覧（owner のみ）
+func (ctl *WorkspaceController) ListInvitations(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	invitations, err := ctl.Service.ListInvitations(authcontext.ScopeUserID(c), id)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     "Workspace invitations retrieved",
+		"invitations": invitations,
+	})
+}
+
+// DeleteInvitation: DELETE /api/workspace/:id/invitations/:invitation_id
+func (ctl *WorkspaceController) DeleteInvitation(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	invitationID, ok := parseIDParam(c, "invitation_id")
+	if !ok {
+		return
+	}
+
+	if err := ctl.Service.RevokeInvitation(authcontext.ScopeUserID(c), id, invitationID); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       "Workspace invitation revoked",
+		"invitation_id": invitationID,
+	})
+}
+
+// AcceptInvitation: POST /api/workspace_invitation/accept
+// 招待先メールアドレスのユーザーとしてログインしている必要がある
+func (ctl *WorkspaceController) AcceptInvitation(c *gin.Context) {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		appErr := errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"token is required",
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	userID, _ := authcontext.UserID(c)
+	member, err := ctl.Service.AcceptInvitation(userID, req.Token)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Workspace invitation accepted",
+		"member":  member,
+	})
+}
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// ListWorkspaces: GET /api/workspace
+// 所属するワークスペースの一覧
+func (ctl *WorkspaceController) ListWorkspaces(c *gin.Context) {
+	userID, _ := authcontext.UserID(c)
+	workspaces, err := ctl.Service.ListWorkspaces(userID)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"message":    "Workspaces retrieved",
+		"workspaces": workspaces,
+	})
+}
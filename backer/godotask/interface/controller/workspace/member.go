@@ -0,0 +1,88 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+)
+
+// EditMemberRole: PUT /api/workspace/:id/members/:user_id
+func (ctl *WorkspaceController) EditMemberRole(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	memberUserID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	if err := ctl.Service.UpdateMemberRole(authcontext.ScopeUserID(c), id, memberUserID, req.Role); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Workspace member role updated",
+		"user_id": memberUserID,
+		"role":    req.Role,
+	})
+}
+
+// DeleteMember: DELETE /api/workspace/:id/members/:user_id
+// 自分自身を指定した場合はワークスペースからの脱退
+func (ctl *WorkspaceController) DeleteMember(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	memberUserID, ok := parseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	userID := authcontext.ScopeUserID(c)
+	if self, _ := authcontext.UserID(c); self == memberUserID {
+		userID = self
+	}
+	if err := ctl.Service.RemoveMember(userID, id, memberUserID); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Workspace member removed",
+		"user_id": memberUserID,
+	})
+}
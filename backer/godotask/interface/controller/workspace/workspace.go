@@ -0,0 +1,32 @@
+package workspace
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
+	"github.com/godotask/usecase/service"
+)
+
+type WorkspaceController struct {
+	Service *service.WorkspaceService
+}
+
+// parseIDParam はパスパラメータを uint の ID として取得する
+func parseIDParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			"invalid "+name,
+		)
+		c.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
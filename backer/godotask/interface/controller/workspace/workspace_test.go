@@ -0,0 +1,211 @@
+package workspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/usecase/service"
+)
+
+// モックリポジトリ
+// ワークスペース 1 にユーザー 1（owner）と 2（viewer）が所属している
+type MockWorkspaceRepository struct {
+	Members     map[uint]string // userID → role
+	Emails      map[uint]string
+	Invitations []model.WorkspaceInvitation
+}
+
+func newMockWorkspaceRepository() *MockWorkspaceRepository {
+	return &MockWorkspaceRepository{
+		Members: map[uint]string{1: "owner", 2: "viewer"},
+		Emails:  map[uint]string{1: "owner@example.com", 2: "viewer@example.com", 3: "new@example.com"},
+	}
+}
+
+func (m *MockWorkspaceRepository) Create(workspace *model.Workspace, ownerID uint) error {
+	workspace.ID = 2
+	workspace.CreatedBy = ownerID
+	return nil
+}
+
+func (m *MockWorkspaceRepository) FindByID(id uint) (*model.Workspace, error) {
+	if id != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &model.Workspace{ID: 1, Name: "Team"}, nil
+}
+
+func (m *MockWorkspaceRepository) ListByUser(userID uint) ([]model.Workspace, error) {
+	if _, ok := m.Members[userID]; !ok {
+		return []model.Workspace{}, nil
+	}
+	return []model.Workspace{{ID: 1, Name: "Team"}}, nil
+}
+
+func (m *MockWorkspaceRepository) Update(id uint, workspace *model.Workspace) error {
+	return nil
+}
+
+func (m *MockWorkspaceRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *MockWorkspaceRepository) FindMember(workspaceID, userID uint) (*model.WorkspaceMember, error) {
+	role, ok := m.Members[userID]
+	if workspaceID != 1 || !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &model.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: role}, nil
+}
+
+func (m *MockWorkspaceRepository) UpdateMemberRole(workspaceID, userID uint, role string) error {
+	m.Members[userID] = role
+	return nil
+}
+
+func (m *MockWorkspaceRepository) RemoveMember(workspaceID, userID uint) error {
+	delete(m.Members, userID)
+	return nil
+}
+
+func (m *MockWorkspaceRepository) CountOwners(workspaceID uint) (int64, error) {
+	var count int64
+	for _, role := range m.Members {
+		if role == "owner" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockWorkspaceRepository) CreateInvitation(invitation *model.WorkspaceInvitation) error {
+	invitation.ID = uint(len(m.Invitations) + 1)
+	m.Invitations = append(m.Invitations, *invitation)
+	return nil
+}
+
+func (m *MockWorkspaceRepository) ListPendingInvitations(workspaceID uint, now time.Time) ([]model.WorkspaceInvitation, error) {
+	return m.Invitations, nil
+}
+
+func (m *MockWorkspaceRepository) FindInvitationByHash(hash string) (*model.WorkspaceInvitation, error) {
+	for i := range m.Invitations {
+		if m.Invitations[i].TokenHash == hash {
+			return &m.Invitations[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockWorkspaceRepository) AcceptInvitation(invitation *model.WorkspaceInvitation, userID uint, at time.Time) (*model.WorkspaceMember, error) {
+	invitation.AcceptedAt = &at
+	m.Members[userID] = invitation.Role
+	return &model.WorkspaceMember{WorkspaceID: invitation.WorkspaceID, UserID: userID, Role: invitation.Role}, nil
+}
+
+func (m *MockWorkspaceRepository) DeleteInvitation(workspaceID, id uint) error {
+	return nil
+}
+
+func (m *MockWorkspaceRepository) FindUserEmail(userID uint) (string, error) {
+	return m.Emails[userID], nil
+}
+
+// テスト用ルーター
+func setupRouterAs(repo *MockWorkspaceRepository, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", "editor")
+	})
+	ctl := &WorkspaceController{Service: &service.WorkspaceService{Repo: repo}}
+
+	r.POST("/api/workspace", ctl.AddWorkspace)
+	r.GET("/api/workspace/:id", ctl.GetWorkspace)
+	r.PUT("/api/workspace/:id", ctl.EditWorkspace)
+	r.PUT("/api/workspace/:id/members/:user_id", ctl.EditMemberRole)
+	r.DELETE("/api/workspace/:id/members/:user_id", ctl.DeleteMember)
+	r.POST("/api/workspace/:id/invitations", ctl.AddInvitation)
+	r.POST("/api/workspace_invitation/accept", ctl.AcceptInvitation)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddWorkspace(t *testing.T) {
+	r := setupRouterAs(newMockWorkspaceRepository(), 1)
+
+	w := serve(r, http.MethodPost, "/api/workspace", `{"name": "Line A"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Workspace added")
+
+	w = serve(r, http.MethodPost, "/api/workspace", `{"name": ""}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetWorkspaceRequiresMembership(t *testing.T) {
+	repo := newMockWorkspaceRepository()
+
+	assert.Equal(t, http.StatusOK, serve(setupRouterAs(repo, 2), http.MethodGet, "/api/workspace/1", "").Code)
+
+	w := serve(setupRouterAs(repo, 9), http.MethodGet, "/api/workspace/1", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.RES_ACCESS_DENIED))
+}
+
+func TestWorkspaceManagementRequiresOwner(t *testing.T) {
+	repo := newMockWorkspaceRepository()
+	viewer := setupRouterAs(repo, 2)
+
+	assert.Equal(t, http.StatusForbidden, serve(viewer, http.MethodPut, "/api/workspace/1", `{"name": "x"}`).Code)
+	assert.Equal(t, http.StatusForbidden, serve(viewer, http.MethodPost, "/api/workspace/1/invitations", `{"email": "a@example.com"}`).Code)
+
+	// 最後の owner は降格できない
+	owner := setupRouterAs(repo, 1)
+	w := serve(owner, http.MethodPut, "/api/workspace/1/members/1", `{"role": "viewer"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), string(apperrors.BIZ_OPERATION_NOT_ALLOWED))
+
+	// viewer は自分で脱退できる
+	assert.Equal(t, http.StatusOK, serve(viewer, http.MethodDelete, "/api/workspace/1/members/2", "").Code)
+}
+
+func TestWorkspaceInvitationFlow(t *testing.T) {
+	repo := newMockWorkspaceRepository()
+
+	w := serve(setupRouterAs(repo, 1), http.MethodPost, "/api/workspace/1/invitations", `{"email": "New@Example.com", "role": "editor"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res struct {
+		Token string `json:"token"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.NotEmpty(t, res.Token)
+
+	// 招待先と異なるユーザーは承諾できない
+	body := `{"token": "` + res.Token + `"}`
+	assert.Equal(t, http.StatusForbidden, serve(setupRouterAs(repo, 2), http.MethodPost, "/api/workspace_invitation/accept", body).Code)
+
+	w = serve(setupRouterAs(repo, 3), http.MethodPost, "/api/workspace_invitation/accept", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "editor", repo.Members[3])
+
+	// 同じ招待は再利用できない
+	assert.NotEqual(t, http.StatusOK, serve(setupRouterAs(repo, 3), http.MethodPost, "/api/workspace_invitation/accept", body).Code)
+}
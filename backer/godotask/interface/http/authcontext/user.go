@@ -1,6 +1,9 @@
 package authcontext
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/domain/authz"
+)
 
 func UserID(c *gin.Context) (uint, bool) {
 	v, exists := c.Get("user_id")
@@ -15,3 +18,43 @@ func UserID(c *gin.Context) (uint, bool) {
 
 	return userID, true
 }
+
+func Role(c *gin.Context) (string, bool) {
+	v, exists := c.Get("role")
+	if !exists {
+		return "", false
+	}
+
+	role, ok := v.(string)
+	if !ok {
+		return "", false
+	}
+
+	return role, true
+}
+
+// SessionID はアクセストークンに含まれるセッション ID を返す
+func SessionID(c *gin.Context) (uint, bool) {
+	v, exists := c.Get("session_id")
+	if !exists {
+		return 0, false
+	}
+
+	sessionID, ok := v.(uint)
+	if !ok || sessionID == 0 {
+		return 0, false
+	}
+
+	return sessionID, true
+}
+
+// ScopeUserID はリポジトリでの所有者絞り込みに使うユーザーIDを返す
+// 全リソースへの権限を持つロール（admin）の場合は 0（絞り込みなし）を返す
+func ScopeUserID(c *gin.Context) uint {
+	role, _ := Role(c)
+	if authz.HasPermission(role, authz.PermResourceAny) {
+		return 0
+	}
+	userID, _ := UserID(c)
+	return userID
+}
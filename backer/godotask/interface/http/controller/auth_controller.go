@@ -1,9 +1,12 @@
 package controller
 
 import (
+	stderrors "errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/godotask/errors"
 	"github.com/godotask/usecase"
 	"github.com/godotask/infrastructure/db/model"
 )
@@ -24,8 +27,9 @@ func NewAuthController(u *usecase.AuthUsecase) *AuthController {
 
 func (c *AuthController) Login(ctx *gin.Context) {
 	var req struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email      string `json:"email"`
+		Password   string `json:"password"`
+		DeviceName string `json:"device_name"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -33,19 +37,74 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.usecase.Login(req.Email, req.Password)
+	pair, err := c.usecase.Login(req.Email, req.Password, clientInfo(ctx, req.DeviceName))
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"token": token})
+	ctx.JSON(http.StatusOK, tokenPairResponse(pair))
 }
 
-// Logoutの実装についての評価を考慮する
+// Refresh: POST /api/refresh
+// リフレッシュトークンをローテーションし、新しいアクセストークンとリフレッシュトークンを返す
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.RefreshToken == "" {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"refresh_token is required",
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	pair, err := c.usecase.Refresh(req.RefreshToken)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokenPairResponse(pair))
+}
+
+// Logout: POST /api/logout
+// リフレッシュトークン、または Authorization ヘッダーのアクセストークンが属するセッションを失効させる
+// 失効後はそのセッションのアクセストークンも AuthMiddleware で拒否される
 func (c *AuthController) Logout(ctx *gin.Context) {
-	// JWT方式ではサーバ側で破棄する状態は持たない
-	// クライアント側でトークンを削除させる
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	_ = ctx.ShouldBindJSON(&req)
+
+	var err error
+	if req.RefreshToken != "" {
+		err = c.usecase.Logout(req.RefreshToken)
+	}
+	if accessToken := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer "); err == nil && accessToken != "" {
+		err = c.usecase.LogoutAccessToken(accessToken)
+	}
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
 
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "logged out successfully",
@@ -54,10 +113,11 @@ func (c *AuthController) Logout(ctx *gin.Context) {
 
 func (c *AuthController) Register(ctx *gin.Context) {
 	var req struct {
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Role     string `json:"role"`
-		Password string `json:"password"`
+		Username   string `json:"username"`
+		Email      string `json:"email"`
+		Role       string `json:"role"`
+		Password   string `json:"password"`
+		DeviceName string `json:"device_name"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -65,21 +125,51 @@ func (c *AuthController) Register(ctx *gin.Context) {
 		return
 	}
 
-	token, user, err := c.usecase.Register(
+	pair, user, err := c.usecase.Register(
 		req.Username,
 		req.Email,
 		req.Role,
 		req.Password,
+		clientInfo(ctx, req.DeviceName),
 	)
 	if err != nil {
+		var appErr *errors.AppError
+		if stderrors.As(err, &appErr) {
+			ctx.JSON(appErr.HTTPStatus, gin.H{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"detail":  appErr.Detail,
+			})
+			return
+		}
 		ctx.JSON(500, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(200, gin.H{
-		"token": token,
-		"user":  user,
-	})
+	res := tokenPairResponse(pair)
+	res["user"] = user
+	ctx.JSON(200, res)
+}
+
+// clientInfo はセッション一覧に表示するデバイス情報をリクエストから取得する
+func clientInfo(ctx *gin.Context, deviceName string) usecase.ClientInfo {
+	if deviceName == "" {
+		deviceName = ctx.GetHeader("X-Device-Name")
+	}
+	return usecase.ClientInfo{
+		DeviceName: deviceName,
+		UserAgent:  ctx.Request.UserAgent(),
+		IP:         ctx.ClientIP(),
+	}
+}
+
+// tokenPairResponse は従来の "token" キーを維持したままリフレッシュトークンを返す
+func tokenPairResponse(pair *usecase.TokenPair) gin.H {
+	return gin.H{
+		"token":         pair.AccessToken,
+		"refresh_token": pair.RefreshToken,
+		"session_id":    pair.SessionID,
+	}
 }
 
 
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/domain/entity"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/usecase"
+)
+
+type SessionController struct {
+	usecase *usecase.SessionUsecase
+}
+
+func NewSessionController(u *usecase.SessionUsecase) *SessionController {
+	return &SessionController{usecase: u}
+}
+
+// ListSessions: GET /api/sessions
+// ログイン中ユーザーの有効なセッションをデバイスごとに返す
+func (c *SessionController) ListSessions(ctx *gin.Context) {
+	userID, _ := authcontext.UserID(ctx)
+	currentID, _ := authcontext.SessionID(ctx)
+
+	sessions, err := c.usecase.ListSessions(userID)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error(),
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	list := make([]gin.H, 0, len(sessions))
+	for i := range sessions {
+		list = append(list, sessionResponse(&sessions[i], currentID))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"message":  "Sessions retrieved",
+		"sessions": list,
+	})
+}
+
+// DeleteSession: DELETE /api/sessions/:id
+func (c *SessionController) DeleteSession(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			"invalid session id",
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	userID, _ := authcontext.UserID(ctx)
+	if err := c.usecase.RevokeSession(userID, uint(id)); err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Session revoked",
+	})
+}
+
+// DeleteSessions: DELETE /api/sessions
+// 現在のセッションを含む全デバイスからログアウトする
+func (c *SessionController) DeleteSessions(ctx *gin.Context) {
+	userID, _ := authcontext.UserID(ctx)
+	revoked, err := c.usecase.RevokeAllSessions(userID)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error(),
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Sessions revoked",
+		"revoked": revoked,
+	})
+}
+
+func sessionResponse(s *entity.Session, currentID uint) gin.H {
+	return gin.H{
+		"id":           s.ID,
+		"device_name":  s.DeviceName,
+		"user_agent":   s.UserAgent,
+		"ip":           s.IP,
+		"created_at":   s.CreatedAt,
+		"last_used_at": s.LastUsedAt,
+		"expires_at":   s.ExpiresAt,
+		"current":      s.ID == currentID,
+	}
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/domain/entity"
+	"github.com/godotask/errors"
+	"github.com/godotask/usecase"
+)
+
+type UserRoleController struct {
+	usecase *usecase.UserRoleUsecase
+}
+
+func NewUserRoleController(u *usecase.UserRoleUsecase) *UserRoleController {
+	return &UserRoleController{usecase: u}
+}
+
+// ListUsers: GET /api/admin/users
+func (c *UserRoleController) ListUsers(ctx *gin.Context) {
+	users, err := c.usecase.ListUsers()
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.SYS_INTERNAL_ERROR,
+			errors.GetErrorMessage(errors.SYS_INTERNAL_ERROR),
+			err.Error(),
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	list := make([]gin.H, 0, len(users))
+	for i := range users {
+		list = append(list, userRoleResponse(&users[i]))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Users retrieved",
+		"users":   list,
+		"roles":   authz.Roles(),
+	})
+}
+
+// UpdateUserRole: PUT /api/admin/users/:id/role
+func (c *UserRoleController) UpdateUserRole(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			"invalid user id",
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	user, err := c.usecase.ChangeRole(uint(id), req.Role)
+	if err != nil {
+		appErr := errors.ToAppError(err, errors.SYS_INTERNAL_ERROR, err.Error())
+		ctx.JSON(appErr.HTTPStatus, gin.H{
+			"code":    appErr.Code,
+			"message": appErr.Message,
+			"detail":  appErr.Detail,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "User role updated",
+		"user":    userRoleResponse(user),
+	})
+}
+
+func userRoleResponse(u *entity.User) gin.H {
+	return gin.H{
+		"id":       u.ID,
+		"username": u.Username,
+		"email":    u.Email,
+		"role":     u.Role,
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/interface/tools"
+	"github.com/godotask/usecase/service"
+	"github.com/rs/zerolog/log"
+)
+
+// auditResponseWriter はレスポンスボディを記録するための ResponseWriter
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *auditResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// AuditMiddleware は /api 配下の POST/PUT/PATCH/DELETE を監査ログに記録するミドルウェア
+// AuthMiddleware の後に適用すること
+func AuditMiddleware(auditSvc *service.AuditLogService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case "POST", "PUT", "PATCH", "DELETE":
+		default:
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		resourceType := tools.ResourceType(route)
+		resourceID := c.Param("id")
+
+		beforeHash, err := auditSvc.SnapshotHash(resourceType, resourceID)
+		if err != nil {
+			log.Error().Err(err).Msgf("audit: failed to snapshot %s/%s", resourceType, resourceID)
+		}
+
+		writer := &auditResponseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		c.Next()
+
+		// 作成系は ID がレスポンスにしか無いため、レスポンスボディから取得する
+		if resourceID == "" {
+			resourceID = resourceIDFromResponse(writer.body.Bytes())
+		}
+
+		afterHash, err := auditSvc.SnapshotHash(resourceType, resourceID)
+		if err != nil {
+			log.Error().Err(err).Msgf("audit: failed to snapshot %s/%s", resourceType, resourceID)
+		}
+
+		userID, _ := authcontext.UserID(c)
+		entry := &model.AuditLog{
+			UserID:       userID,
+			Username:     c.GetString("username"),
+			Role:         c.GetString("role"),
+			Method:       c.Request.Method,
+			Route:        route,
+			Path:         c.Request.URL.Path,
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			BeforeHash:   beforeHash,
+			AfterHash:    afterHash,
+			StatusCode:   writer.Status(),
+			IP:           c.ClientIP(),
+		}
+		if err := auditSvc.RecordAuditLog(entry); err != nil {
+			log.Error().Err(err).Msgf("audit: failed to record %s %s", entry.Method, entry.Path)
+		}
+	}
+}
+
+// resourceIDFromResponse はレスポンス JSON のトップレベルにあるオブジェクトから id を探す
+// 例: {"success":true,"task":{"id":12,...}} → "12"
+func resourceIDFromResponse(body []byte) string {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	for _, v := range payload {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch id := obj["id"].(type) {
+		case string:
+			return id
+		case float64:
+			return fmt.Sprintf("%.0f", id)
+		}
+	}
+	return ""
+}
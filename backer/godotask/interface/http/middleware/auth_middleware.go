@@ -10,7 +10,14 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func AuthMiddleware(tokenSvc auth.TokenService) gin.HandlerFunc {
+// SessionChecker はアクセストークンに含まれるセッションが有効かを確認する
+type SessionChecker interface {
+	IsActive(sessionID uint) bool
+}
+
+// AuthMiddleware はアクセストークンを検証し、ユーザー情報を context に詰める
+// sessions が指定されている場合、ログアウト・失効済みセッションのトークンは有効期限内でも拒否する
+func AuthMiddleware(tokenSvc auth.TokenService, sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		claims, err := GetClaimsFromAuthorizationHeader(c, tokenSvc)
 		if err != nil {
@@ -27,10 +34,21 @@ func AuthMiddleware(tokenSvc auth.TokenService) gin.HandlerFunc {
 			return
 		}
 
+		if sessions != nil && claims.SessionID != 0 && !sessions.IsActive(claims.SessionID) {
+			appErr := errors.ErrAuthSessionRevoked
+			c.AbortWithStatusJSON(appErr.HTTPStatus, gin.H{
+				"code":    appErr.Code,
+				"message": appErr.Message,
+				"detail":  appErr.Detail,
+			})
+			return
+		}
+
 		// context に詰める（controller / usecase で利用）
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
 		c.Set("role", claims.Role)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/godotask/domain/entity"
+	"github.com/godotask/infrastructure/security"
+)
+
+// モックセッション: 有効なセッション ID の集合
+type MockSessionChecker struct {
+	Active map[uint]bool
+}
+
+func (m *MockSessionChecker) IsActive(sessionID uint) bool {
+	return m.Active[sessionID]
+}
+
+func TestAuthMiddlewareRejectsRevokedSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenSvc := security.NewJWTService([]byte("test-secret"), time.Minute)
+	sessions := &MockSessionChecker{Active: map[uint]bool{1: true}}
+
+	r := gin.New()
+	r.GET("/api/ping", AuthMiddleware(tokenSvc, sessions), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"session_id": c.GetUint("session_id")})
+	})
+
+	user := &entity.User{ID: 7, Username: "alice", Role: "editor"}
+	tests := []struct {
+		name      string
+		sessionID uint
+		expected  int
+	}{
+		{"active session", 1, http.StatusOK},
+		{"revoked session", 2, http.StatusUnauthorized},
+		{"token without session", 0, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tokenSvc.Generate(user, tt.sessionID)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/ping", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/errors"
+	"github.com/godotask/interface/http/authcontext"
+	"github.com/godotask/interface/tools"
+	"github.com/godotask/usecase/service"
+)
+
+// RequirePermission は指定した権限を持つロールのみ通すミドルウェア
+// AuthMiddleware の後に適用すること
+func RequirePermission(perm authz.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := authcontext.Role(c)
+		if !authz.HasPermission(role, perm) {
+			abortWithAppError(c, errors.NewAppError(
+				errors.AUTH_UNAUTHORIZED,
+				errors.GetErrorMessage(errors.AUTH_UNAUTHORIZED),
+				"required permission: "+string(perm),
+			))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireMethodPermission は HTTP メソッドに応じた権限をルート単位で確認するミドルウェア
+// GET → resource:read, POST/PUT/PATCH → resource:write, DELETE → resource:delete
+func RequireMethodPermission() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var perm authz.Permission
+		switch c.Request.Method {
+		case "POST", "PUT", "PATCH":
+			perm = authz.PermResourceWrite
+		case "DELETE":
+			perm = authz.PermResourceDelete
+		default:
+			perm = authz.PermResourceRead
+		}
+
+		role, _ := authcontext.Role(c)
+		if !authz.HasPermission(role, perm) {
+			abortWithAppError(c, errors.NewAppError(
+				errors.AUTH_UNAUTHORIZED,
+				errors.GetErrorMessage(errors.AUTH_UNAUTHORIZED),
+				"required permission: "+string(perm),
+			))
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuthorizeResourceOwner は :id を持つルートへの変更操作（PUT/PATCH/DELETE）について
+// リクエストユーザーがリソースの所有者であるかを確認するミドルウェア
+func AuthorizeResourceOwner(authzSvc *service.AuthorizationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		switch c.Request.Method {
+		case "PUT", "PATCH", "DELETE":
+		default:
+			c.Next()
+			return
+		}
+		if id == "" {
+			c.Next()
+			return
+		}
+
+		userID, _ := authcontext.UserID(c)
+		role, _ := authcontext.Role(c)
+		err := authzSvc.AuthorizeResource(userID, role, tools.ResourceType(c.FullPath()), id)
+		if err != nil {
+			abortWithAppError(c, errors.ToAppError(err, errors.DB_QUERY_FAILED, err.Error()))
+			return
+		}
+		c.Next()
+	}
+}
+
+func abortWithAppError(c *gin.Context, appErr *errors.AppError) {
+	c.AbortWithStatusJSON(appErr.HTTPStatus, gin.H{
+		"code":    appErr.Code,
+		"message": appErr.Message,
+		"detail":  appErr.Detail,
+	})
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/usecase/service"
+)
+
+// モックリポジトリ: resourceType/id → 所有者ID、Editors: resourceType/id → 共有先で編集できるユーザーID
+type MockResourceRepository struct {
+	Owners  map[string]uint
+	Editors map[string]uint
+}
+
+func (m *MockResourceRepository) FindOwnerID(resourceType string, id string) (uint, error) {
+	owner, ok := m.Owners[resourceType+"/"+id]
+	if !ok {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return owner, nil
+}
+
+func (m *MockResourceRepository) HasWorkspaceAccess(resourceType string, id string, userID uint, write bool) (bool, error) {
+	editor, ok := m.Editors[resourceType+"/"+id]
+	return ok && editor == userID, nil
+}
+
+func setupAuthzRouter(userID uint, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	repo := &MockResourceRepository{
+		Owners: map[string]uint{
+			"heuristics/modeler/1":   10,
+			"process_optimization/a": 20,
+			"book/1":                 10,
+		},
+		Editors: map[string]uint{
+			"process_optimization/a": 30,
+		},
+	}
+	authzSvc := &service.AuthorizationService{Repo: repo}
+
+	r := gin.New()
+	api := r.Group("/api")
+	api.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", role)
+	}, RequireMethodPermission(), AuthorizeResourceOwner(authzSvc))
+
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"success": true}) }
+	api.GET("/heuristics/modeler/:id", ok)
+	api.DELETE("/heuristics/modeler/:id", ok)
+	api.PUT("/process_optimization/:id", ok)
+	api.GET("/admin/users", RequirePermission(authz.PermUserManage), ok)
+
+	// 旧ルート（/api なし）も同じリソースとして所有者を確認する
+	legacy := r.Group("")
+	legacy.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("role", role)
+	}, RequireMethodPermission(), AuthorizeResourceOwner(authzSvc))
+	legacy.DELETE("/deletebook/:id", ok)
+	return r
+}
+
+func serve(r *gin.Engine, method, path string) int {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w.Code
+}
+
+func TestAuthorizeResourceOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   uint
+		role     string
+		method   string
+		path     string
+		expected int
+	}{
+		{"owner can delete", 10, "editor", http.MethodDelete, "/api/heuristics/modeler/1", http.StatusOK},
+		{"other user cannot delete", 11, "editor", http.MethodDelete, "/api/heuristics/modeler/1", http.StatusForbidden},
+		{"legacy user role cannot edit others", 11, "user", http.MethodPut, "/api/process_optimization/a", http.StatusForbidden},
+		{"owner via task can edit", 20, "user", http.MethodPut, "/api/process_optimization/a", http.StatusOK},
+		{"workspace editor can edit shared", 30, "editor", http.MethodPut, "/api/process_optimization/a", http.StatusOK},
+		{"admin can delete any", 1, "admin", http.MethodDelete, "/api/heuristics/modeler/1", http.StatusOK},
+		{"missing resource", 10, "editor", http.MethodDelete, "/api/heuristics/modeler/999", http.StatusNotFound},
+		{"viewer cannot delete own", 10, "viewer", http.MethodDelete, "/api/heuristics/modeler/1", http.StatusForbidden},
+		{"viewer can read", 10, "viewer", http.MethodGet, "/api/heuristics/modeler/1", http.StatusOK},
+		{"owner can delete via legacy route", 10, "editor", http.MethodDelete, "/deletebook/1", http.StatusOK},
+		{"other user cannot delete via legacy route", 11, "editor", http.MethodDelete, "/deletebook/1", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupAuthzRouter(tt.userID, tt.role)
+			assert.Equal(t, tt.expected, serve(r, tt.method, tt.path))
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	assert.Equal(t, http.StatusOK, serve(setupAuthzRouter(1, "admin"), http.MethodGet, "/api/admin/users"))
+	assert.Equal(t, http.StatusForbidden, serve(setupAuthzRouter(2, "editor"), http.MethodGet, "/api/admin/users"))
+}
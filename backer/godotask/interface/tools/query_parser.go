@@ -16,6 +16,7 @@ func ParsePagerQuery(c *gin.Context) dtoquery.PagerQuery {
 	)
 
 	var (
+		workspaceID *uint
 		taskID  *int
 		search  *string
 	)
@@ -34,6 +35,13 @@ func ParsePagerQuery(c *gin.Context) dtoquery.PagerQuery {
 		}
 	}
 
+	if w := c.Query("workspace_id"); w != "" {
+		if v, err := strconv.ParseUint(w, 10, 64); err == nil && v > 0 {
+			id := uint(v)
+			workspaceID = &id
+		}
+	}
+
 	if s := c.Query("search"); s != "" {
 		search = &s
 	}
@@ -43,6 +51,7 @@ func ParsePagerQuery(c *gin.Context) dtoquery.PagerQuery {
 		Limit:  limit,
 		Offset: (page - 1) * limit,
 		UserID: userID,
+		WorkspaceID: workspaceID,
 		TaskID: taskID,
 		Search: search,
 	}
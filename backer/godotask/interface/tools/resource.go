@@ -0,0 +1,28 @@
+package tools
+
+import "strings"
+
+// legacyResourceTypes は /api を付けずに公開していた旧ルートのリソース種別
+var legacyResourceTypes = map[string]string{
+	"updatebook": "book",
+	"deletebook": "book",
+	"file":       "book",
+}
+
+// ResourceType: ルート定義からリソース種別を求める
+// 例: /api/heuristics/modeler/:id → heuristics/modeler、旧ルート /deletebook/:id → book
+func ResourceType(route string) string {
+	route = strings.TrimPrefix(route, "/api/")
+	parts := make([]string, 0, 3)
+	for _, p := range strings.Split(route, "/") {
+		if p == "" || strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+			continue
+		}
+		parts = append(parts, p)
+	}
+	resourceType := strings.Join(parts, "/")
+	if legacy, ok := legacyResourceTypes[resourceType]; ok {
+		return legacy
+	}
+	return resourceType
+}
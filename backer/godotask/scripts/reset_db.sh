@@ -37,7 +37,7 @@ fi
 
 # 2. マイグレーション実行
 echo -e "${YELLOW}マイグレーションを実行中...${NC}"
-go run ./cmd/migrate/main.go
+go run ./cmd/migrate up
 if [ $? -ne 0 ]; then
     echo -e "${RED}❌ マイグレーションに失敗しました${NC}"
     exit 1
@@ -47,24 +47,9 @@ echo -e "${GREEN}✓ マイグレーション完了${NC}"
 # 3. 全シードデータ投入
 echo -e "${YELLOW}シードデータを投入中...${NC}"
 
-echo "[1/3] Memory contexts..."
-go run ./seed/seed.go
+go run ./seed/cmd --only data,meandata,operation
 if [ $? -ne 0 ]; then
-    echo -e "${RED}❌ Memory contextsのシードに失敗しました${NC}"
-    exit 1
-fi
-
-echo "[2/3] Books, Tasks, Assessments..."
-go run ./seed/seedModel.go
-if [ $? -ne 0 ]; then
-    echo -e "${RED}❌ Books/Tasks/Assessmentsのシードに失敗しました${NC}"
-    exit 1
-fi
-
-echo "[3/3] Heuristics..."
-go run ./cmd/seed/main.go -only heuristics
-if [ $? -ne 0 ]; then
-    echo -e "${RED}❌ Heuristicsのシードに失敗しました${NC}"
+    echo -e "${RED}❌ シードデータの投入に失敗しました${NC}"
     exit 1
 fi
 
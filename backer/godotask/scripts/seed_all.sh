@@ -23,22 +23,10 @@ echo ""
 set -e
 trap 'echo -e "${RED}❌ エラーが発生しました${NC}"' ERR
 
-# 1. Memory関連データのシード
-echo -e "${YELLOW}[1/3] Memory関連データ（製造工程Level1-3）をシード中...${NC}"
-go run ./seed/seed.go
-echo -e "${GREEN}✓ Memory関連データのシード完了${NC}"
-echo ""
-
-# 2. Book/Task/Assessmentデータのシード
-echo -e "${YELLOW}[2/3] Book, Memory, Task, Assessmentデータをシード中...${NC}"
-go run ./seed/seedModel.go
-echo -e "${GREEN}✓ Book/Task/Assessmentデータのシード完了${NC}"
-echo ""
-
-# 3. Heuristicsデータのシード
-echo -e "${YELLOW}[3/3] Heuristicsデータをシード中...${NC}"
-go run ./cmd/seed/main.go -only heuristics
-echo -e "${GREEN}✓ Heuristicsデータのシード完了${NC}"
+# シードセット（data, meandata, operation）を順に投入。CSV が変わっていないセットは飛ばす
+echo -e "${YELLOW}シードセットを投入中...${NC}"
+go run ./seed/cmd --only data,meandata,operation
+echo -e "${GREEN}✓ シードセットの投入完了${NC}"
 echo ""
 
 echo -e "${GREEN}========================================${NC}"
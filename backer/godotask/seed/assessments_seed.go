@@ -14,8 +14,7 @@ import (
 )
 
 func SeedAssessmentsModelsFromCSV(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/assessments.csv", path)
+	filePath := utils.SeedFile("assessments.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -71,7 +70,7 @@ func SeedAssessmentsModelsFromCSV(db *gorm.DB) error {
 
 	// バッチインサート
 	if len(models) > 0 {
-		if err := db.CreateInBatches(&models, 1000).Error; err != nil {
+		if err := upsert(db).CreateInBatches(&models, 1000).Error; err != nil {
 			return fmt.Errorf("failed to insert assessment models: %w", err)
 		}
 		fmt.Printf("Successfully seeded %d assessment models\n", len(models))
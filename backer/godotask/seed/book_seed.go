@@ -16,8 +16,7 @@ import (
 
 
 func SeedBookModelsFromCSV(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/book.csv", path)
+	filePath := utils.SeedFile("book.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -67,7 +66,7 @@ func SeedBookModelsFromCSV(db *gorm.DB) error {
 
 	// バッチインサート
 	if len(models) > 0 {
-		if err := db.Create(&models).Error; err != nil {
+		if err := upsert(db).Create(&models).Error; err != nil {
 			return fmt.Errorf("failed to insert optimization models: %w", err)
 		}
 		fmt.Printf("Successfully seeded %d optimization models\n", len(models))
@@ -0,0 +1,33 @@
+package seed
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/godotask/seed/parser"
+	"github.com/godotask/seed/utils"
+)
+
+// readSeedCSV はシードのディレクトリの CSV を列名付きの行として読む
+func readSeedCSV(name string) ([]parser.Row, error) {
+	filePath := utils.SeedFile(name)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s: %v", name, err)
+	}
+	defer file.Close()
+	return parser.ReadCSV(file)
+}
+
+// parseSeedRows は行を parse でモデルにする。読めない行があればエラーを返す（セットをロールバックさせる）
+func parseSeedRows[T any](name string, rows []parser.Row, parse func(parser.Row) (T, error)) ([]T, error) {
+	models := make([]T, 0, len(rows))
+	for _, row := range rows {
+		m, err := parse(row)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", name, row.Line, err)
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
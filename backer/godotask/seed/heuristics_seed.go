@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"os"
 
+	"github.com/godotask/seed/parser"
 	"github.com/godotask/seed/utils"
 	"github.com/godotask/infrastructure/db/model"
 	"gorm.io/gorm"
@@ -43,64 +44,13 @@ func SeedHeuristics(db *gorm.DB) error {
 }
 
 func seedHeuristicsAnalysis(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/heuristics_analysis.csv", path)
-
-	file, err := os.Open(filePath)
+	rows, err := readSeedCSV("heuristics_analysis.csv")
 	if err != nil {
-		fmt.Errorf("could not open heuristics_analysis.csv: %v", err)
+		return err
 	}
-
-	reader := csv.NewReader(file)
-	// records, err := reader.ReadAll()
-	// if err != nil {
-	// 	return fmt.Errorf("could not read CSV: %v", err)
-	// }
-	defer file.Close()
-	_, err = reader.Read()
+	models, err := parseSeedRows("heuristics_analysis.csv", rows, parser.HeuristicsAnalysis)
 	if err != nil {
-		return fmt.Errorf("failed to read CSV header: %w", err)
-	}
-
-	var models []model.HeuristicsAnalysis
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("failed to read CSV record: %w", err)
-		}
-
-    id, _ := strconv.Atoi(record[0])
-    userID, _ := strconv.Atoi(record[1])
-    taskID, _ := strconv.Atoi(record[2])
-		timeSpent, _ := strconv.Atoi(record[5])
-		difficulty, _ := strconv.ParseFloat(record[6], 64)
-		efficiency, _ := strconv.ParseFloat(record[7], 64)
-		errorCount, _ := strconv.Atoi(record[8])
-		confidence, _ := strconv.ParseFloat(record[9], 64)
-		score, _ := strconv.ParseFloat(record[10], 64)
-
-		createdAt, _ := time.Parse(time.RFC3339, record[12])
-		updatedAt, _ := time.Parse(time.RFC3339, record[13])
-
-		models = append(models, model.HeuristicsAnalysis{
-			ID:                id,
-			UserID:            userID,
-			TaskID:            taskID,
-			AnalysisType:      record[3],
-			Result:            record[4],
-			TimeSpentMinutes:  timeSpent,
-			DifficultyScore:   difficulty,
-			EfficiencyScore:   efficiency,
-			ErrorCount:        errorCount,
-			Confidence:        confidence,
-			Score:             score,
-			Status:            record[11],
-			CreatedAt:         createdAt,
-			UpdatedAt:         updatedAt,
-		})
+		return err
 	}
 
 	// バッチインサート
@@ -110,7 +60,7 @@ func seedHeuristicsAnalysis(db *gorm.DB) error {
 		})
 
 		err := seedDB.Transaction(func(tx *gorm.DB) error {
-			return tx.CreateInBatches(models, 1000).Error
+			return upsert(tx).CreateInBatches(models, 1000).Error
 		})
 		if err != nil {
 			return fmt.Errorf("failed to insert heuristics analysis models: %w", err)
@@ -122,8 +72,7 @@ func seedHeuristicsAnalysis(db *gorm.DB) error {
 }
 
 func seedHeuristicsTracking(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/heuristics_tracking.csv", path)
+	filePath := utils.SeedFile("heuristics_tracking.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -187,7 +136,7 @@ func seedHeuristicsTracking(db *gorm.DB) error {
 		})
 
 		err := seedDB.Transaction(func(tx *gorm.DB) error {
-			return tx.CreateInBatches(trackings, 500).Error
+			return upsert(tx).CreateInBatches(trackings, 500).Error
 		})
 		if err != nil {
 			return fmt.Errorf("failed to insert heuristics tracking data: %w", err)
@@ -199,8 +148,7 @@ func seedHeuristicsTracking(db *gorm.DB) error {
 }
 
 func seedHeuristicsInsights(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/heuristics_insights.csv", path)
+	filePath := utils.SeedFile("heuristics_insights.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -266,7 +214,7 @@ func seedHeuristicsInsights(db *gorm.DB) error {
 		})
 
 		err := seedDB.Transaction(func(tx *gorm.DB) error {
-			return tx.CreateInBatches(insights, 500).Error
+			return upsert(tx).CreateInBatches(insights, 500).Error
 		})
 		if err != nil {
 			return fmt.Errorf("failed to insert heuristics insights data: %w", err)
@@ -278,8 +226,7 @@ func seedHeuristicsInsights(db *gorm.DB) error {
 }
 
 func seedHeuristicsPatterns(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/heuristics_patterns.csv", path)
+	filePath := utils.SeedFile("heuristics_patterns.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -352,7 +299,7 @@ func seedHeuristicsPatterns(db *gorm.DB) error {
 	})
 
 	return seedDB.Transaction(func(tx *gorm.DB) error {
-		if err := tx.CreateInBatches(patterns, 500).Error; err != nil {
+		if err := upsert(tx).CreateInBatches(patterns, 500).Error; err != nil {
 			return fmt.Errorf("failed to insert heuristics patterns: %w", err)
 		}
 		fmt.Printf("Successfully seeded %d heuristics patterns records\n", len(patterns))
@@ -361,8 +308,7 @@ func seedHeuristicsPatterns(db *gorm.DB) error {
 }
 
 func seedHeuristicsModelers(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/heuristics_models.csv", path)
+	filePath := utils.SeedFile("heuristics_models.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -430,7 +376,7 @@ func seedHeuristicsModelers(db *gorm.DB) error {
 	})
 
 	return seedDB.Transaction(func(tx *gorm.DB) error {
-		if err := tx.CreateInBatches(models, 500).Error; err != nil {
+		if err := upsert(tx).CreateInBatches(models, 500).Error; err != nil {
 			return fmt.Errorf("failed to insert heuristics models: %w", err)
 		}
 		fmt.Printf("Successfully seeded %d heuristics models records\n", len(models))
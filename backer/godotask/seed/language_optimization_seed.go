@@ -16,8 +16,7 @@ import (
 )
 
 func SeedLanguageOptimizationFromCSV(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/language_optimization.csv", path)
+	filePath := utils.SeedFile("language_optimization.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -48,26 +47,22 @@ func SeedLanguageOptimizationFromCSV(db *gorm.DB) error {
 
 		createdAt, err := time.Parse(time.RFC3339, record[12])
 		if err != nil {
-			log.Printf("⚠️ Invalid created_at at line %d: %v", i+1, err)
-			continue
+			return fmt.Errorf("language_optimization.csv line %d: invalid created_at: %w", i+1, err)
 		}
 		updatedAt, err := time.Parse(time.RFC3339, record[13])
 		if err != nil {
-			log.Printf("⚠️ Invalid updated_at at line %d: %v", i+1, err)
-			continue
+			return fmt.Errorf("language_optimization.csv line %d: invalid updated_at: %w", i+1, err)
 		}
 
 		// JSON デコード
 		var context model.JSON
 		if err := json.Unmarshal([]byte(record[9]), &context); err != nil {
-				log.Printf("Invalid context JSON at line %d: %v", i+2, err)
-				continue
+			return fmt.Errorf("language_optimization.csv line %d: invalid context JSON: %w", i+1, err)
 		}
 
 		var transformation model.JSON
 		if err := json.Unmarshal([]byte(record[10]), &transformation); err != nil {
-				log.Printf("Invalid transformation JSON at line %d: %v", i+2, err)
-				continue
+			return fmt.Errorf("language_optimization.csv line %d: invalid transformation JSON: %w", i+1, err)
 		}
 
 		// build the record
@@ -89,12 +84,11 @@ func SeedLanguageOptimizationFromCSV(db *gorm.DB) error {
 		}
 
 		if err := db.Create(&data).Error; err != nil {
-			log.Printf("❌ Failed to insert record at line %d: %v", i+1, err)
-		} else {
-			log.Printf("✅ Inserted record #%s successfully", id)
+			return fmt.Errorf("language_optimization.csv line %d: failed to insert record: %w", i+1, err)
 		}
+		count++
 	}
 
-	log.Printf("✓ Successfully seeded %d qualitative labels", count)
+	log.Printf("✓ Successfully seeded %d language optimizations", count)
 	return nil
 }
\ No newline at end of file
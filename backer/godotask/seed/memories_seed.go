@@ -3,16 +3,10 @@ package seed
 import (
 	"log"
 	"fmt"
-	"strconv"
-	"time"
-	"encoding/csv"
-	"io"
-	"os"
-	"strings" 
+	"strings"
 	"math/rand"
 
-	"github.com/godotask/seed/utils"
-	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/seed/parser"
 	"gorm.io/gorm"
 )
 
@@ -170,82 +164,40 @@ func generateInformationAmount(scoreClass string) string {
 
 
 func SeedMemoriesModelsFromCSV(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/memories.csv", path)
-
-	file, err := os.Open(filePath)
+	rows, err := readSeedCSV("memories.csv")
 	if err != nil {
-		return fmt.Errorf("could not open memories_models.csv: %v", err)
+		return err
 	}
-
-	reader := csv.NewReader(file)
-	// records, err := reader.ReadAll()
-	// if err != nil {
-	// 	return fmt.Errorf("could not read CSV: %v", err)
-	// }
-	defer file.Close()
-	_, err = reader.Read()
+	models, err := parseSeedRows("memories.csv", rows, parser.Memory)
 	if err != nil {
-		return fmt.Errorf("failed to read CSV header: %w", err)
+		return err
 	}
 
-	var models []model.Memory
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("failed to read CSV record: %w", err)
-		}
-
-    id, _ := strconv.Atoi(record[0])
-    userID, _ := strconv.Atoi(record[1])
-
+	tags := []string{"3Dプリント", "材料", "Mg合金"}
+	for i := range models {
 		// スコア生成と分類
 		effectiveness := rand.Intn(41) + 60
 		scoreClass := classifyScore(effectiveness) // メインの指標に応じて分類
 
-		tags := []string{"3Dプリント", "材料", "Mg合金"}
-		// noteText := generateNoteText(scoreClass, tags)
-    createdAt, _ := time.Parse("2006-01-02", record[5])
-    updatedAt, _ := time.Parse("2006-01-02", record[6])
-		t, err := time.Parse(time.RFC3339, record[4])
-		var readDate *time.Time
-		if err != nil {
-				// パースできない場合は nil にする or スキップ
-				readDate = nil
-		} else {
-				readDate = &t
-		}
-
-		models = append(models, model.Memory{
-			ID:        id,
-			UserID:     userID,
-			SourceType: "book",
-			Title:      record[4],
-			Author:     "Researcher",
-			Notes:      record[5],
-			Tags:       strings.Join(tags, ","),
-			ReadStatus: "finished",
-			ReadDate:   readDate,
-			Factor:            generateFactor(scoreClass),
-			Process:           generateProcess(scoreClass),
-			EvaluationAxis:    generateEvaluationAxis(scoreClass),
-			InformationAmount: generateInformationAmount(scoreClass),
-			CreatedAt:  createdAt,
-			UpdatedAt:  updatedAt,
-		})
+		m := &models[i]
+		m.SourceType = "book"
+		m.Author = "Researcher"
+		m.Tags = strings.Join(tags, ",")
+		m.ReadStatus = "finished"
+		m.Factor = generateFactor(scoreClass)
+		m.Process = generateProcess(scoreClass)
+		m.EvaluationAxis = generateEvaluationAxis(scoreClass)
+		m.InformationAmount = generateInformationAmount(scoreClass)
 	}
 
 	// バッチインサート
 	if len(models) > 0 {
-		if err := db.Create(&models).Error; err != nil {
-			return fmt.Errorf("failed to insert optimization models: %w", err)
+		if err := upsert(db).Create(&models).Error; err != nil {
+			return fmt.Errorf("failed to insert memories: %w", err)
 		}
-		fmt.Printf("Successfully seeded %d optimization models\n", len(models))
+		fmt.Printf("Successfully seeded %d memories\n", len(models))
 	}
 
-	log.Printf("✓ Successfully seeded %d optimization models", len(models))
+	log.Printf("✓ Successfully seeded %d memories", len(models))
 	return nil
 }
@@ -0,0 +1,240 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/infrastructure/db/model"
+	"gorm.io/datatypes"
+)
+
+// Task は task.csv の行（id,user_id,memory_id,title,description,date,status,priority,created_at,updated_at）を読む
+func Task(row Row) (model.Task, error) {
+	f := newFields(row)
+	task := model.Task{
+		ID:          f.integer("id"),
+		UserID:      f.integer("user_id"),
+		MemoryID:    f.integer("memory_id"),
+		Title:       f.required("title"),
+		Description: f.text("description"),
+		Date:        f.timestamp("date"),
+		Status:      f.text("status"),
+		Priority:    f.integer("priority"),
+		CreatedAt:   f.time("created_at"),
+		UpdatedAt:   f.time("updated_at"),
+	}
+	return task, f.err()
+}
+
+// Memory は memories.csv の行（id,user_id,book_id,title,notes,date,created_at,updated_at）を読む
+// date は読了日。モデルの列名（source_type, author, tags, read_status, read_date など）も読める
+// title / notes の代わりに content だけを持つ古い形式は content を題名とメモにする
+func Memory(row Row) (model.Memory, error) {
+	f := newFields(row)
+	readDate := f.timestamp("read_date")
+	if readDate == nil {
+		readDate = f.timestamp("date")
+	}
+	title, notes := f.text("title"), f.text("notes")
+	if content := f.text("content"); content != "" {
+		if title == "" {
+			title = content
+		}
+		if notes == "" {
+			notes = content
+		}
+	}
+	if title == "" {
+		f.fail("title", "is required")
+	}
+	memory := model.Memory{
+		ID:                f.integer("id"),
+		UserID:            f.integer("user_id"),
+		SourceType:        f.text("source_type"),
+		Title:             title,
+		Author:            f.text("author"),
+		Notes:             notes,
+		Tags:              f.text("tags"),
+		ReadStatus:        f.text("read_status"),
+		ReadDate:          readDate,
+		Factor:            f.text("factor"),
+		Process:           f.text("process"),
+		EvaluationAxis:    f.text("evaluation_axis"),
+		InformationAmount: f.text("information_amount"),
+		CreatedAt:         f.time("created_at"),
+		UpdatedAt:         f.time("updated_at"),
+	}
+	return memory, f.err()
+}
+
+// KnowledgePattern は knowledge_patterns.csv の行を読む
+// conversion_path は JSON のオブジェクト、または SECI の段階の配列・, / | 区切りの文字列（{"stages": [...]} として保存する）
+func KnowledgePattern(row Row) (model.KnowledgePattern, error) {
+	f := newFields(row)
+	pattern := model.KnowledgePattern{
+		ID:             f.text("id"),
+		TaskID:         f.integer("task_id"),
+		Type:           f.text("type"),
+		Domain:         f.text("domain"),
+		TacitKnowledge: f.required("tacit_knowledge"),
+		ExplicitForm:   f.text("explicit_form"),
+		ConversionPath: f.stages("conversion_path"),
+		Accuracy:       f.float("accuracy"),
+		Coverage:       f.float("coverage"),
+		Consistency:    f.float("consistency"),
+		AbstractLevel:  f.text("abstract_level"),
+		CreatedAt:      f.time("created_at"),
+		UpdatedAt:      f.time("updated_at"),
+	}
+	if pattern.TaskID == 0 && f.text("task_id") == "" {
+		f.fail("task_id", "is required")
+	}
+	switch pattern.Type {
+	case "", "tacit", "explicit", "hybrid":
+	default:
+		f.fail("type", "must be tacit, explicit or hybrid")
+	}
+	return pattern, f.err()
+}
+
+// QuantificationLabel は quantification_labels.csv の行を読む
+// normalized_text は labeling.Normalize で揃え、省略した場合は original_text から作る
+// related_concepts / tags は JSON の配列または | 区切り
+func QuantificationLabel(row Row) (model.QuantificationLabel, error) {
+	f := newFields(row)
+	unit := f.text("unit")
+	original := f.required("original_text")
+	normalized := f.text("normalized_text")
+	if normalized == "" {
+		normalized = original
+	}
+	label := model.QuantificationLabel{
+		ID:              f.text("id"),
+		UserID:          f.integer("user_id"),
+		TaskID:          f.integer("task_id"),
+		OriginalText:    original,
+		NormalizedText:  labeling.Normalize(normalized),
+		Category:        f.text("category"),
+		Domain:          f.text("domain"),
+		Context:         f.text("context"),
+		Value:           f.number("value"),
+		Unit:            &unit,
+		MinRange:        f.number("min_range"),
+		MaxRange:        f.number("max_range"),
+		TypicalValue:    f.number("typical_value"),
+		Confidence:      f.number("confidence"),
+		AbstractLevel:   f.text("abstract_level"),
+		RelatedConcepts: stringList(f.list("related_concepts")),
+		Source:          f.text("source"),
+		Validated:       f.boolean("validated"),
+		Tags:            stringList(f.list("tags")),
+		Notes:           f.text("notes"),
+		Version:         1,
+		CreatedBy:       f.text("created_by"),
+		UpdatedBy:       f.text("updated_by"),
+		CreatedAt:       f.time("created_at"),
+		UpdatedAt:       f.time("updated_at"),
+	}
+	if f.text("precision") != "" {
+		precision := f.integer("precision")
+		label.Precision = &precision
+	}
+	if label.MinRange != nil && label.MaxRange != nil && *label.MinRange > *label.MaxRange {
+		f.fail("min_range", "must not exceed max_range")
+	}
+	if label.Confidence != nil && (*label.Confidence < 0 || *label.Confidence > 1) {
+		f.fail("confidence", "must be between 0 and 1")
+	}
+	return label, f.err()
+}
+
+// HeuristicsAnalysis は heuristics_analysis.csv の行を読む
+// 学習指標の列（time_spent_minutes, difficulty_score など）の無い古い形式も読める
+// result が JSON でない場合は説明の文字列として {"description": ...} にする
+func HeuristicsAnalysis(row Row) (model.HeuristicsAnalysis, error) {
+	f := newFields(row)
+	analysis := model.HeuristicsAnalysis{
+		ID:               f.integer("id"),
+		UserID:           f.integer("user_id"),
+		TaskID:           f.integer("task_id"),
+		AnalysisType:     f.required("analysis_type"),
+		Result:           f.text("result"),
+		TimeSpentMinutes: f.integer("time_spent_minutes"),
+		DifficultyScore:  f.float("difficulty_score"),
+		EfficiencyScore:  f.float("efficiency_score"),
+		ErrorCount:       f.integer("error_count"),
+		Confidence:       f.float("confidence"),
+		Score:            f.float("score"),
+		Status:           f.text("status"),
+		CreatedAt:        f.time("created_at"),
+		UpdatedAt:        f.time("updated_at"),
+	}
+	if analysis.Result != "" && !json.Valid([]byte(analysis.Result)) {
+		if strings.HasPrefix(analysis.Result, "{") || strings.HasPrefix(analysis.Result, "[") {
+			f.fail("result", "must be JSON")
+		} else {
+			raw, _ := json.Marshal(map[string]string{"description": analysis.Result})
+			analysis.Result = string(raw)
+		}
+	}
+	return analysis, f.err()
+}
+
+// PhenomenologicalFramework は phenomenological_frameworks.csv の行を読む
+// process / result / feedback は JSON のオブジェクト。文字列だけの場合は {"description": ...} として保存する
+func PhenomenologicalFramework(row Row) (model.PhenomenologicalFramework, error) {
+	f := newFields(row)
+	framework := model.PhenomenologicalFramework{
+		ID:            f.required("id"),
+		TaskID:        f.integer("task_id"),
+		Name:          f.required("name"),
+		Description:   f.text("description"),
+		Goal:          f.text("goal"),
+		Scope:         f.text("scope"),
+		Process:       f.described("process"),
+		Result:        f.described("result"),
+		Feedback:      f.described("feedback"),
+		LimitMin:      f.float("limit_min"),
+		LimitMax:      f.float("limit_max"),
+		GoalFunction:  f.text("goal_function"),
+		AbstractLevel: f.text("abstract_level"),
+		Domain:        f.text("domain"),
+		CreatedAt:     f.time("created_at"),
+		UpdatedAt:     f.time("updated_at"),
+	}
+	if framework.LimitMin > framework.LimitMax {
+		f.fail("limit_min", "must not exceed limit_max")
+	}
+	return framework, f.err()
+}
+
+// OptimizationModel は optimization_models.csv の行
+// （id,name,type,objective_function,constraints,parameters,performance_metric,iteration_count,convergence_rate,domain,application,task_id,created_at,updated_at）を読む
+func OptimizationModel(row Row) (model.OptimizationModel, error) {
+	f := newFields(row)
+	optimization := model.OptimizationModel{
+		ID:                f.required("id"),
+		Name:              f.required("name"),
+		Type:              f.text("type"),
+		ObjectiveFunction: f.text("objective_function"),
+		Constraints:       f.text("constraints"),
+		Parameters:        f.text("parameters"),
+		PerformanceMetric: f.text("performance_metric"),
+		IterationCount:    f.float("iteration_count"),
+		ConvergenceRate:   f.float("convergence_rate"),
+		Domain:            f.text("domain"),
+		Application:       f.text("application"),
+		CreatedAt:         f.time("created_at"),
+		UpdatedAt:         f.time("updated_at"),
+	}
+	return optimization, f.err()
+}
+
+func stringList(list []string) datatypes.JSON {
+	if len(list) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(list)
+	return datatypes.JSON(raw)
+}
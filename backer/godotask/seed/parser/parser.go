@@ -0,0 +1,287 @@
+// Package parser はシードの CSV（と同じ列を持つ JSON）の行をモデルに変換する
+// シードの投入と一括インポート API の両方で使う。列はヘッダーの名前で対応付ける
+package parser
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Row は 1 行分の列名と値
+// Line は CSV ではヘッダーを 1 行目とした行番号、JSON では配列の 1 始まりの位置
+type Row struct {
+	Line   int
+	Values map[string]string
+}
+
+// FieldError は列の値の誤り
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// FieldErrors は 1 行の列の誤りの一覧
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fe := range e {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ReadCSV はヘッダー付きの CSV を読む。列数がヘッダーと異なる行は Values を nil にする
+func ReadCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, stderrors.New("csv has no header")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV record: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		if len(record) == len(header) {
+			row.Values = make(map[string]string, len(header))
+			for i, name := range header {
+				row.Values[name] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ReadJSON はオブジェクトの配列を読む。数値・真偽値は文字列に、オブジェクト・配列は JSON の文字列にする
+func ReadJSON(r io.Reader) ([]Row, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var objects []map[string]interface{}
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("json must be an array of objects: %w", err)
+	}
+	rows := make([]Row, 0, len(objects))
+	for i, object := range objects {
+		row := Row{Line: i + 1, Values: make(map[string]string, len(object))}
+		for name, v := range object {
+			switch v := v.(type) {
+			case nil:
+				row.Values[name] = ""
+			case string:
+				row.Values[name] = v
+			case json.Number:
+				row.Values[name] = v.String()
+			case bool:
+				row.Values[name] = strconv.FormatBool(v)
+			default:
+				raw, _ := json.Marshal(v)
+				row.Values[name] = string(raw)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// fields は列の値を型に変換し、誤りを集める
+type fields struct {
+	row  Row
+	errs FieldErrors
+}
+
+func (f *fields) fail(field, message string) {
+	// 列数の合わない行は列の値を対応付けられないため、その誤りだけを返す
+	if f.row.Values == nil && len(f.errs) > 0 {
+		return
+	}
+	f.errs = append(f.errs, FieldError{Field: field, Message: message})
+}
+
+func (f *fields) err() error {
+	if len(f.errs) == 0 {
+		return nil
+	}
+	return f.errs
+}
+
+func (f *fields) text(name string) string {
+	return strings.TrimSpace(f.row.Values[name])
+}
+
+func (f *fields) required(name string) string {
+	v := f.text(name)
+	if v == "" {
+		f.fail(name, "is required")
+	}
+	return v
+}
+
+func (f *fields) integer(name string) int {
+	v := f.text(name)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		f.fail(name, "must be an integer")
+	}
+	return n
+}
+
+func (f *fields) number(name string) *float64 {
+	v := f.text(name)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		f.fail(name, "must be a number")
+		return nil
+	}
+	return &n
+}
+
+func (f *fields) float(name string) float64 {
+	if n := f.number(name); n != nil {
+		return *n
+	}
+	return 0
+}
+
+func (f *fields) boolean(name string) bool {
+	v := f.text(name)
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		f.fail(name, "must be true or false")
+	}
+	return b
+}
+
+// timeLayouts はシードの CSV で使われている日時の書式
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+func (f *fields) timestamp(name string) *time.Time {
+	v := f.text(name)
+	if v == "" {
+		return nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return &t
+		}
+	}
+	f.fail(name, "must be RFC3339, YYYY-MM-DD HH:MM:SS or YYYY-MM-DD")
+	return nil
+}
+
+func (f *fields) time(name string) time.Time {
+	if t := f.timestamp(name); t != nil {
+		return *t
+	}
+	return time.Time{}
+}
+
+// object は JSON のオブジェクトを読む。配列は {"stages": [...]} として読む
+func (f *fields) object(name string) map[string]interface{} {
+	v := f.text(name)
+	if v == "" {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(v), &decoded); err != nil {
+		f.fail(name, "must be JSON")
+		return nil
+	}
+	switch decoded := decoded.(type) {
+	case map[string]interface{}:
+		return decoded
+	case []interface{}:
+		return map[string]interface{}{"stages": decoded}
+	}
+	f.fail(name, "must be a JSON object or array")
+	return nil
+}
+
+// described は JSON のオブジェクト、または説明の文字列（{"description": ...} とする）を読む
+func (f *fields) described(name string) map[string]interface{} {
+	v := f.text(name)
+	if v == "" || strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[") {
+		return f.object(name)
+	}
+	return map[string]interface{}{"description": v}
+}
+
+// stages は JSON のオブジェクト・配列、または , / | 区切りの段階の文字列（{"stages": [...]} とする）を読む
+func (f *fields) stages(name string) map[string]interface{} {
+	v := f.text(name)
+	if v == "" || strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[") {
+		return f.object(name)
+	}
+	var stages []interface{}
+	for _, item := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '|' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			stages = append(stages, item)
+		}
+	}
+	return map[string]interface{}{"stages": stages}
+}
+
+// list は JSON の文字列の配列、または | 区切りの文字列を読む
+func (f *fields) list(name string) []string {
+	v := f.text(name)
+	if v == "" {
+		return nil
+	}
+	if strings.HasPrefix(v, "[") {
+		var list []string
+		if err := json.Unmarshal([]byte(v), &list); err != nil {
+			f.fail(name, "must be an array of strings")
+		}
+		return list
+	}
+	var list []string
+	for _, item := range strings.Split(v, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// newFields は列数の合わない行を誤りにする
+func newFields(row Row) *fields {
+	f := &fields{row: row}
+	if row.Values == nil {
+		f.fail("row", "column count does not match the header")
+	}
+	return f
+}
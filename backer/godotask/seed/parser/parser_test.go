@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readSeed(t *testing.T, name string) []Row {
+	t.Helper()
+	file, err := os.Open("../data/" + name)
+	require.NoError(t, err)
+	defer file.Close()
+	rows, err := ReadCSV(file)
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+	return rows
+}
+
+// シードの CSV はすべての行を誤りなく読める
+func TestSeedCSVs(t *testing.T) {
+	cases := map[string]func(Row) error{
+		"task.csv":                  func(r Row) error { _, err := Task(r); return err },
+		"memories.csv":              func(r Row) error { _, err := Memory(r); return err },
+		"knowledge_patterns.csv":    func(r Row) error { _, err := KnowledgePattern(r); return err },
+		"quantification_labels.csv": func(r Row) error { _, err := QuantificationLabel(r); return err },
+		"heuristics_analysis.csv": func(r Row) error {
+			_, err := HeuristicsAnalysis(r)
+			return err
+		},
+		"phenomenological_frameworks.csv": func(r Row) error {
+			_, err := PhenomenologicalFramework(r)
+			return err
+		},
+		"optimization_models.csv": func(r Row) error {
+			_, err := OptimizationModel(r)
+			return err
+		},
+	}
+	for name, parse := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, row := range readSeed(t, name) {
+				assert.NoError(t, parse(row), "line %d", row.Line)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	rows, err := ReadCSV(strings.NewReader("\ufefftitle, priority\nA,1\n\"B\nC\",2\nD\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, Row{Line: 2, Values: map[string]string{"title": "A", "priority": "1"}}, rows[0])
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, "B\nC", rows[1].Values["title"])
+	// 列数がヘッダーと合わない行
+	assert.Equal(t, 5, rows[2].Line)
+	assert.Nil(t, rows[2].Values)
+
+	_, err = Task(rows[2])
+	assert.EqualError(t, err, "row: column count does not match the header")
+
+	_, err = ReadCSV(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestReadJSON(t *testing.T) {
+	rows, err := ReadJSON(strings.NewReader(`[{"title":"A","priority":3,"done":true,"tags":["x","y"],"memo":null}]`))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, map[string]string{"title": "A", "priority": "3", "done": "true", "tags": `["x","y"]`, "memo": ""}, rows[0].Values)
+
+	_, err = ReadJSON(strings.NewReader(`{"title":"A"}`))
+	assert.Error(t, err)
+}
+
+func TestFieldErrors(t *testing.T) {
+	_, err := KnowledgePattern(Row{Line: 2, Values: map[string]string{"task_id": "x", "type": "other", "conversion_path": "{"}})
+	var errs FieldErrors
+	require.ErrorAs(t, err, &errs)
+	fields := []string{}
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	assert.ElementsMatch(t, []string{"task_id", "tacit_knowledge", "type", "conversion_path"}, fields)
+
+	label, err := QuantificationLabel(Row{Line: 2, Values: map[string]string{"original_text": " Ｈｅｉｇｈｔ ", "confidence": "0.5"}})
+	require.NoError(t, err)
+	assert.Equal(t, "height", label.NormalizedText)
+	assert.Nil(t, label.Precision)
+
+	_, err = QuantificationLabel(Row{Line: 3, Values: map[string]string{"original_text": "a", "min_value": "5", "max_value": "1", "confidence": "2"}})
+	assert.Error(t, err)
+}
+
+// 古い形式の列（content、区切り文字列の段階、文字列の result）も読める
+func TestLegacyColumns(t *testing.T) {
+	memory, err := Memory(Row{Line: 2, Values: map[string]string{"id": "1", "content": "測定精度のばらつき要因を整理した"}})
+	require.NoError(t, err)
+	assert.Equal(t, "測定精度のばらつき要因を整理した", memory.Title)
+	assert.Equal(t, "測定精度のばらつき要因を整理した", memory.Notes)
+	_, err = Memory(Row{Line: 3, Values: map[string]string{"id": "2"}})
+	assert.EqualError(t, err, "title: is required")
+
+	pattern, err := KnowledgePattern(Row{Line: 2, Values: map[string]string{
+		"task_id": "1", "tacit_knowledge": "感覚", "conversion_path": "socialization->観察, externalization->記録|combination->分析",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"socialization->観察", "externalization->記録", "combination->分析"}, pattern.ConversionPath["stages"])
+
+	analysis, err := HeuristicsAnalysis(Row{Line: 2, Values: map[string]string{"analysis_type": "heuristics", "result": "保持治具問題"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"description":"保持治具問題"}`, analysis.Result)
+	_, err = HeuristicsAnalysis(Row{Line: 3, Values: map[string]string{"analysis_type": "heuristics", "result": "{broken"}})
+	assert.EqualError(t, err, "result: must be JSON")
+}
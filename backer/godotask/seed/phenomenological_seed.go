@@ -116,62 +116,5 @@ func seedOptimizationModelsFixed(db *gorm.DB) error {
 }
 
 func seedRobotArmSpecifications(db *gorm.DB) error {
-	// specs := []model.RobotSpecification{
-	// 	{
-	// 		ID:                      "teaching_free_arm_v1",
-	// 		ModelName:              "教示フリーアーム V1",
-	// 		DOF:                    6,
-	// 		ReachMm:                850.0,
-	// 		PayloadKg:              5.0,
-	// 		RepeatAccuracyMm:       0.02,
-	// 		MaxSpeedMmS:            1000.0,
-	// 		WorkEnvelopeShape:      "spherical",
-	// 		TeachingMethod:         "vision",
-	// 		ControlType:            "hybrid",
-	// 		VisionSystem:           nil,
-	// 		ForceSensor:            nil,
-	// 		SafetyFeatures:         nil,
-	// 		MaintenanceIntervalHours: 2000,
-	// 		CreatedAt:              time.Now(),
-	// 		UpdatedAt:              time.Now(),
-	// 	},
-	// 	{
-	// 		ID:                      "collaborative_robot_v2",
-	// 		ModelName:              "協働ロボット V2",
-	// 		DOF:                    7,
-	// 		ReachMm:                1200.0,
-	// 		PayloadKg:              10.0,
-	// 		RepeatAccuracyMm:       0.01,
-	// 		MaxSpeedMmS:            2000.0,
-	// 		WorkEnvelopeShape:      "spherical",
-	// 		TeachingMethod:         "ai",
-	// 		ControlType:            "adaptive",
-	// 		VisionSystem:           nil,
-	// 		ForceSensor:            nil,
-	// 		AICapability:           nil,
-	// 		SafetyFeatures:         nil,
-	// 		MaintenanceIntervalHours: 1500,
-	// 		CreatedAt:              time.Now(),
-	// 		UpdatedAt:              time.Now(),
-	// 	},
-	// }
-
-	// for _, spec := range specs {
-	// 	var existingSpec model.RobotSpecification
-	// 	if err := db.Where("id = ?", spec.ID).First(&existingSpec).Error; err != nil {
-	// 		if err == gorm.ErrRecordNotFound {
-	// 			if err := db.Create(&spec).Error; err != nil {
-	// 				log.Printf("Error creating robot specification %s: %v", spec.ID, err)
-	// 				return err
-	// 			}
-	// 		} else {
-	// 			return err
-	// 		}
-	// 	} else {
-	// 		log.Printf("Robot specification %s already exists, skipping", spec.ID)
-	// 	}
-	// }
-
-	log.Printf("✓ Successfully seeded %d robot specifications")
-	return nil
+	return SeedRobotSpecificationsFromCSV(db)
 }
\ No newline at end of file
@@ -15,8 +15,7 @@ import (
 )
 
 func SeedQualitativeLabelsFromCSV(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/qualitative_label.csv", path)
+	filePath := utils.SeedFile("qualitative_label.csv")
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -29,13 +28,7 @@ func SeedQualitativeLabelsFromCSV(db *gorm.DB) error {
 	}
 	defer file.Close()
 
-	tx := db.Begin()
-	if tx.Error != nil {
-		return fmt.Errorf("failed to start transaction: %v", tx.Error)
-	}
-
-	var count int
-
+	var labels []model.QualitativeLabel
 	for i, record := range records {
 		if i == 0 { // skip header
 			continue
@@ -48,7 +41,7 @@ func SeedQualitativeLabelsFromCSV(db *gorm.DB) error {
     createdAt, _ := time.Parse("2006-01-02", record[5])
     updatedAt, _ := time.Parse("2006-01-02", record[6])
 
-		label := model.QualitativeLabel{
+		labels = append(labels, model.QualitativeLabel{
 			ID:              id,
 			UserID:          userID,
 			TaskID:          taskID,
@@ -56,32 +49,22 @@ func SeedQualitativeLabelsFromCSV(db *gorm.DB) error {
 			Category:        record[4],
 			CreatedAt:       createdAt,
 			UpdatedAt:       updatedAt,
-		}
-
-		// 既存チェック
-		var existing model.QualitativeLabel
-		result := tx.Where("id = ?", label.ID).First(&existing)
+		})
+	}
 
-		// 存在しない場合のみ作成
-		if result.RowsAffected == 0 {
-			if err := tx.Create(&label).Error; err != nil {
-				tx.Rollback()
-				return fmt.Errorf("failed to insert qualitative label %s: %w", label.ID, err)
+	// 同じ ID のラベルは更新する
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, label := range labels {
+			if err := upsert(tx).Create(&label).Error; err != nil {
+				return fmt.Errorf("failed to upsert qualitative label %d: %w", label.ID, err)
 			}
-			count++
-		} else if result.Error != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to query qualitative label %s: %w", label.ID, result.Error)
-		} else {
-			log.Printf("qualitative label '%s' already exists, skipping", label.ID)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// コミット
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	log.Printf("✓ Successfully seeded %d qualitative labels", count)
+	log.Printf("✓ Successfully seeded %d qualitative labels", len(labels))
 	return nil
-}
\ No newline at end of file
+}
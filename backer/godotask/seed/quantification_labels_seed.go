@@ -1,68 +1,30 @@
 package seed
 
 import (
-	"encoding/csv"
 	"fmt"
-	"os"
-	"io"
-	"strconv"
-	// "strings"
-	"time"
-	// "encoding/json"
 
-	"github.com/godotask/seed/utils"
-	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/seed/parser"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// SeedQuantificationLabelsFromCSV は quantification_labels.csv から定量化ラベルを投入する
 func SeedQuantificationLabelsFromCSV(db *gorm.DB) error {
-	path := utils.GetSeedPath()
-	filePath := fmt.Sprintf("seed/%s/qualitative_label.csv", path)
-
-	file, err := os.Open(filePath)
+	rows, err := readSeedCSV("quantification_labels.csv")
 	if err != nil {
-		return fmt.Errorf("could not open qualitative_label.csv: %v", err)
+		return err
 	}
-
-	reader := csv.NewReader(file)
-	defer file.Close()
-
-	// header skip
-	_, err = reader.Read()
+	models, err := parseSeedRows("quantification_labels.csv", rows, parser.QuantificationLabel)
 	if err != nil {
-		return fmt.Errorf("failed to read CSV header: %w", err)
+		return err
 	}
-
-	var models []model.QualitativeLabel
-
-	for {
-		record, err := reader.Read()
-
-		if err == io.EOF {
-			break
+	for i := range models {
+		// シードの検証済みラベルは検証を経て採用されたものとして扱う
+		models[i].Status = labeling.StatusDraft
+		if models[i].Validated {
+			models[i].Status = labeling.StatusAccepted
 		}
-
-		if err != nil {
-			return fmt.Errorf("failed to read CSV record: %w", err)
-		}
-
-		id, _ := strconv.Atoi(record[0])
-		userID, _ := strconv.Atoi(record[1])
-		taskID, _ := strconv.Atoi(record[2])
-
-		createdAt, _ := time.Parse(time.RFC3339, record[5])
-		updatedAt, _ := time.Parse(time.RFC3339, record[6])
-
-		models = append(models, model.QualitativeLabel{
-			ID:        id,
-			UserID:    userID,
-			TaskID:    taskID,
-			Content:   record[3],
-			Category:  record[4],
-			CreatedAt: createdAt,
-			UpdatedAt: updatedAt,
-		})
 	}
 
 	// batch insert
@@ -73,15 +35,15 @@ func SeedQuantificationLabelsFromCSV(db *gorm.DB) error {
 		})
 
 		err := seedDB.Transaction(func(tx *gorm.DB) error {
-			return tx.CreateInBatches(models, 1000).Error
+			return upsert(tx).CreateInBatches(models, 1000).Error
 		})
 
 		if err != nil {
-			return fmt.Errorf("failed to insert qualitative labels: %w", err)
+			return fmt.Errorf("failed to insert quantification labels: %w", err)
 		}
 
-		fmt.Printf("Successfully seeded %d qualitative labels\n", len(models))
+		fmt.Printf("Successfully seeded %d quantification labels\n", len(models))
 	}
 
 	return nil
-}
\ No newline at end of file
+}
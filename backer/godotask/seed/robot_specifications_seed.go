@@ -0,0 +1,113 @@
+package seed
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/seed/utils"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// SeedRobotSpecificationsFromCSV は robot_specifications.csv からロボット仕様を登録する
+// 既に登録済みの ID は CSV の値で更新する
+func SeedRobotSpecificationsFromCSV(db *gorm.DB) error {
+	filePath := utils.SeedFile("robot_specifications.csv")
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("could not open robot_specifications.csv: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	if _, err := reader.Read(); err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("could not read CSV: %v", err)
+	}
+
+	created := 0
+	for i, record := range records {
+		spec, err := parseRobotSpecification(record)
+		if err != nil {
+			return fmt.Errorf("robot_specifications.csv line %d: %w", i+2, err)
+		}
+		if err := upsert(db).Create(spec).Error; err != nil {
+			return fmt.Errorf("failed to create robot specification %s: %w", spec.ID, err)
+		}
+		created++
+	}
+
+	log.Printf("✓ Successfully seeded %d robot specifications", created)
+	return nil
+}
+
+func parseRobotSpecification(record []string) (*model.RobotSpecification, error) {
+	if len(record) < 15 {
+		return nil, fmt.Errorf("expected 15 columns, got %d", len(record))
+	}
+	dof, err := strconv.Atoi(record[2])
+	if err != nil {
+		return nil, fmt.Errorf("dof: %w", err)
+	}
+	floats := make([]float64, 4)
+	for i, col := range []int{3, 4, 5, 6} {
+		if floats[i], err = strconv.ParseFloat(record[col], 64); err != nil {
+			return nil, fmt.Errorf("column %d: %w", col+1, err)
+		}
+	}
+	maintenance, err := strconv.Atoi(record[14])
+	if err != nil {
+		return nil, fmt.Errorf("maintenance_interval_hours: %w", err)
+	}
+	aiCapability, err := pipeListJSON(record[12])
+	if err != nil {
+		return nil, err
+	}
+	safetyFeatures, err := pipeListJSON(record[13])
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RobotSpecification{
+		ID:                       record[0],
+		ModelName:                record[1],
+		DOF:                      dof,
+		ReachMm:                  floats[0],
+		PayloadKg:                floats[1],
+		RepeatAccuracyMm:         floats[2],
+		MaxSpeedMmS:              floats[3],
+		WorkEnvelopeShape:        record[7],
+		TeachingMethod:           record[8],
+		ControlType:              record[9],
+		VisionSystem:             record[10],
+		ForceSensor:              record[11],
+		AICapability:             aiCapability,
+		SafetyFeatures:           safetyFeatures,
+		MaintenanceIntervalHours: maintenance,
+	}, nil
+}
+
+// pipeListJSON は "a|b|none" 形式の列を JSON 配列に変換する（none は空配列）
+func pipeListJSON(s string) (datatypes.JSON, error) {
+	items := []string{}
+	for _, item := range strings.Split(s, "|") {
+		if item = strings.TrimSpace(item); item != "" && item != "none" {
+			items = append(items, item)
+		}
+	}
+	b, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(b), nil
+}
@@ -0,0 +1,361 @@
+package seed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/seed/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedVersion はシードの読み方の版。ステップや CSV の読み方を変えたら上げる（チェックサムが変わり、全セットを再投入する）
+const SeedVersion = 2
+
+// SeedStep はシードセットの中で順に実行する 1 つの投入処理
+type SeedStep struct {
+	Name string
+	// Files はステップが読む CSV。いずれもセットのディレクトリに無い場合はステップを飛ばす（空なら常に実行する）
+	Files []string
+	Run   func(db *gorm.DB) error
+}
+
+// SeedSet は seed/<Name> の CSV を Steps の順に投入する名前付きのセット
+type SeedSet struct {
+	Name  string
+	Steps []SeedStep
+}
+
+// Steps は各シードセットで実行する投入処理（参照される側から並べる）
+var Steps = []SeedStep{
+	{Name: "users", Run: SeedAllUsers},
+	{Name: "memory_contexts", Files: []string{"memory_contexts.csv"}, Run: SeedMemoryContexts},
+	{Name: "memories", Files: []string{"memories.csv"}, Run: SeedMemoriesModelsFromCSV},
+	{Name: "tasks", Files: []string{"task.csv"}, Run: SeedTaskModelsFromCSV},
+	{Name: "knowledge_patterns", Files: []string{"knowledge_patterns.csv"}, Run: SeedKnowledgePattern},
+	{Name: "assessments", Files: []string{"assessments.csv"}, Run: SeedAssessmentsModelsFromCSV},
+	{Name: "books", Files: []string{"book.csv"}, Run: SeedBookModelsFromCSV},
+	{Name: "optimization_models", Files: []string{"optimization_models.csv"}, Run: SeedOptimizationModelsFromCSV},
+	{Name: "qualitative_labels", Files: []string{"qualitative_label.csv"}, Run: SeedQualitativeLabelsFromCSV},
+	{Name: "phenomenological_frameworks", Files: []string{"phenomenological_frameworks.csv"}, Run: SeedPhenomenologicalFrameworksFromCSV},
+	{Name: "quantification_labels", Files: []string{"quantification_labels.csv"}, Run: SeedQuantificationLabelsFromCSV},
+	{Name: "heuristics_analyses", Files: []string{"heuristics_analysis.csv"}, Run: seedHeuristicsAnalysis},
+	{Name: "heuristics_trackings", Files: []string{"heuristics_tracking.csv"}, Run: seedHeuristicsTracking},
+	{Name: "heuristics_insights", Files: []string{"heuristics_insights.csv"}, Run: seedHeuristicsInsights},
+	{Name: "heuristics_patterns", Files: []string{"heuristics_patterns.csv"}, Run: seedHeuristicsPatterns},
+	{Name: "heuristics_models", Files: []string{"heuristics_models.csv"}, Run: seedHeuristicsModelers},
+	{Name: "robot_specifications", Files: []string{"robot_specifications.csv"}, Run: SeedRobotSpecificationsFromCSV},
+}
+
+// Sets は既定のシードセット。同じ ID を持つ行は後のセットで上書きされる
+var Sets = []SeedSet{
+	{Name: "data", Steps: Steps},
+	{Name: "meandata", Steps: Steps},
+	{Name: "operation", Steps: Steps},
+}
+
+// SetResult は Run で 1 つのシードセットをどう扱ったか
+type SetResult struct {
+	Name     string
+	Checksum string
+	// Skipped は記録済みのチェックサムと同じで投入しなかったこと
+	Skipped bool
+	// SkippedSteps は CSV が無くて飛ばしたステップ
+	SkippedSteps []string
+}
+
+// Runner はシードセットを順に投入し、セットごとのチェックサムを seed_runs に記録する
+type Runner struct {
+	DB *gorm.DB
+	// Root はシードセットのディレクトリを置く場所（既定は seed）
+	Root string
+	// Sets が空の場合は既定の Sets を使う
+	Sets []SeedSet
+	Now  func() time.Time
+}
+
+func (r *Runner) root() string {
+	if r.Root != "" {
+		return r.Root
+	}
+	return "seed"
+}
+
+func (r *Runner) sets() []SeedSet {
+	if len(r.Sets) > 0 {
+		return r.Sets
+	}
+	return Sets
+}
+
+func (r *Runner) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// Select は only に挙げたセットを定義順に返す（空ならすべて）。知らない名前はエラー
+func (r *Runner) Select(only []string) ([]SeedSet, error) {
+	sets := r.sets()
+	if len(only) == 0 {
+		return sets, nil
+	}
+	wanted := make(map[string]bool, len(only))
+	for _, name := range only {
+		wanted[name] = true
+	}
+	var selected []SeedSet
+	for _, set := range sets {
+		if wanted[set.Name] {
+			selected = append(selected, set)
+			delete(wanted, set.Name)
+		}
+	}
+	if len(wanted) > 0 {
+		var unknown []string
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown seed set: %s", strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// Run は only のセット（空ならすべて）を順に投入する
+// チェックサムが記録と同じセットは飛ばすが、前のセットを投入した後のセットは同じ ID を上書きし直すため投入する
+// reset の場合は先にシードのテーブルと記録を空にする。失敗したセットはロールバックし、そこで止める
+func (r *Runner) Run(only []string, reset bool) ([]SetResult, error) {
+	selected, err := r.Select(only)
+	if err != nil {
+		return nil, err
+	}
+	if reset {
+		if err := Reset(r.DB); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []SetResult
+	applied := false
+	for _, set := range selected {
+		checksum, err := Checksum(filepath.Join(r.root(), set.Name))
+		if err != nil {
+			return results, fmt.Errorf("seed set %s: %w", set.Name, err)
+		}
+		result := SetResult{Name: set.Name, Checksum: checksum}
+
+		var run model.SeedRun
+		err = r.DB.Where("name = ?", set.Name).Take(&run).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return results, fmt.Errorf("seed set %s: %w", set.Name, err)
+		}
+		if err == nil && run.Checksum == checksum && !applied {
+			result.Skipped = true
+			results = append(results, result)
+			log.Printf("seed set %s is up to date, skipping", set.Name)
+			continue
+		}
+
+		log.Printf("Seeding set %s...", set.Name)
+		err = r.DB.Transaction(func(tx *gorm.DB) error {
+			skipped, err := r.apply(tx, set)
+			if err != nil {
+				return err
+			}
+			result.SkippedSteps = skipped
+			if err := syncSequences(tx); err != nil {
+				return err
+			}
+			return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&model.SeedRun{
+				Name:      set.Name,
+				Checksum:  checksum,
+				AppliedAt: r.now(),
+			}).Error
+		})
+		if err != nil {
+			return results, fmt.Errorf("seed set %s: %w", set.Name, err)
+		}
+		applied = true
+		results = append(results, result)
+		log.Printf("✓ Seed set %s applied", set.Name)
+	}
+	return results, nil
+}
+
+// apply はセットのステップを順に実行し、CSV が無くて飛ばしたステップを返す
+func (r *Runner) apply(tx *gorm.DB, set SeedSet) ([]string, error) {
+	utils.SetSeedPath(filepath.Join(r.root(), set.Name))
+	var skipped []string
+	for _, step := range set.Steps {
+		if !r.hasFiles(set.Name, step.Files) {
+			skipped = append(skipped, step.Name)
+			log.Printf("skip step %s: no %s in %s", step.Name, strings.Join(step.Files, ", "), set.Name)
+			continue
+		}
+		log.Printf("Seeding %s...", step.Name)
+		if err := runStep(tx, step); err != nil {
+			return nil, fmt.Errorf("step %s: %w", step.Name, err)
+		}
+	}
+	return skipped, nil
+}
+
+// runStep は CSV の列が足りない場合などの panic もエラーとして返す
+func runStep(tx *gorm.DB, step SeedStep) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return step.Run(tx)
+}
+
+func (r *Runner) hasFiles(set string, files []string) bool {
+	if len(files) == 0 {
+		return true
+	}
+	for _, name := range files {
+		if _, err := os.Stat(filepath.Join(r.root(), set, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Checksum は SeedVersion とディレクトリ直下の CSV の名前・内容から SHA-256 を作る
+func Checksum(dir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no CSV files in %s", dir)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "seed version %d\n", SeedVersion)
+	for _, path := range paths {
+		fmt.Fprintf(h, "%s\n", filepath.Base(path))
+		file, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, file)
+		file.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// seededTables はシードで投入するテーブル（Reset は参照する側から順に空にする）
+var seededTables = []string{
+	"attachments",
+	"attachment_blobs",
+	"image_annotations",
+	"user_calibrations",
+	"visual_metaphors",
+	"multimodal_data",
+	"learning_patterns",
+	"tool_matching_results",
+	"state_evaluations",
+	"spc_measurements",
+	"teaching_free_control_attempts",
+	"label_dataset_snapshots",
+	"dataset_labels",
+	"label_datasets",
+	"label_verifications",
+	"quantification_labels",
+	"label_revisions",
+	"label_relations",
+	"qualitative_labels",
+	"phenomenological_frameworks",
+	"optimization_models",
+	"process_monitoring_samples",
+	"process_monitorings",
+	"robot_specifications",
+	"heuristics_modelers",
+	"heuristics_patterns",
+	"heuristics_insights",
+	"heuristics_trackings",
+	"heuristics_analyses",
+	"knowledge_patterns",
+	"memory_contexts",
+	"tasks",
+	"memories",
+	"assessments",
+	"book",
+	"users",
+	"seed_runs",
+}
+
+// Reset はシードのテーブルと seed_runs を空にする（PostgreSQL では ID の採番も戻す）
+func Reset(db *gorm.DB) error {
+	var tables []string
+	for _, table := range seededTables {
+		if db.Migrator().HasTable(table) {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	log.Println("Cleaning database tables...")
+	if db.Dialector.Name() == "postgres" {
+		if err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))).Error; err != nil {
+			return fmt.Errorf("failed to truncate seed tables: %w", err)
+		}
+	} else {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, table := range tables {
+				if err := tx.Exec(fmt.Sprintf("DELETE FROM %s", table)).Error; err != nil {
+					return fmt.Errorf("failed to clean %s: %w", table, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Println("✓ Database cleaned successfully")
+	return nil
+}
+
+// syncSequences は ID を指定して投入したテーブルの採番を最大の ID に合わせる（PostgreSQL のみ）
+func syncSequences(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	for _, table := range seededTables {
+		if !db.Migrator().HasTable(table) {
+			continue
+		}
+		var sequence *string
+		if err := db.Raw("SELECT pg_get_serial_sequence(?, 'id')", table).Scan(&sequence).Error; err != nil {
+			return fmt.Errorf("failed to find sequence of %s: %w", table, err)
+		}
+		if sequence == nil || *sequence == "" {
+			continue
+		}
+		sql := fmt.Sprintf("SELECT setval(?, COALESCE((SELECT MAX(id) FROM %s), 0) + 1, false)", table)
+		if err := db.Exec(sql, *sequence).Error; err != nil {
+			return fmt.Errorf("failed to sync sequence of %s: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package seed
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godotask/infrastructure/db/model"
+)
+
+func setupSeedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(model.Models()...))
+	return db
+}
+
+func writeSeedCSV(t *testing.T, root, set, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, set), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, set, name), []byte(content), 0o644))
+}
+
+// testRunner は CSV を読まずに、セットごとの実行回数を数えるステップで Runner を作る
+func testRunner(t *testing.T, db *gorm.DB, calls map[string]int) *Runner {
+	root := t.TempDir()
+	var sets []SeedSet
+	for i, name := range []string{"data", "meandata", "operation"} {
+		writeSeedCSV(t, root, name, "task.csv", "id,title\n1,t\n")
+		id := i + 1
+		sets = append(sets, SeedSet{Name: name, Steps: []SeedStep{{
+			Name:  "tasks",
+			Files: []string{"task.csv"},
+			Run: func(tx *gorm.DB) error {
+				calls[name]++
+				return upsert(tx).Create(&model.Task{ID: id, Title: name}).Error
+			},
+		}}})
+	}
+	return &Runner{DB: db, Root: root, Sets: sets}
+}
+
+func TestRunnerSkipsUnchangedSets(t *testing.T) {
+	db := setupSeedDB(t)
+	calls := map[string]int{}
+	r := testRunner(t, db, calls)
+
+	results, err := r.Run(nil, false)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.False(t, result.Skipped, result.Name)
+	}
+	var runs int64
+	db.Model(&model.SeedRun{}).Count(&runs)
+	assert.Equal(t, int64(3), runs)
+
+	// 変わっていないセットは投入しない
+	results, err = r.Run(nil, false)
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.True(t, result.Skipped, result.Name)
+	}
+	assert.Equal(t, map[string]int{"data": 1, "meandata": 1, "operation": 1}, calls)
+
+	// 変わったセットと、その後のセットだけを投入し直す
+	writeSeedCSV(t, r.Root, "meandata", "task.csv", "id,title\n1,changed\n")
+	results, err = r.Run(nil, false)
+	require.NoError(t, err)
+	assert.True(t, results[0].Skipped)
+	assert.False(t, results[1].Skipped)
+	assert.False(t, results[2].Skipped)
+	assert.Equal(t, map[string]int{"data": 1, "meandata": 2, "operation": 2}, calls)
+}
+
+func TestRunnerOnly(t *testing.T) {
+	db := setupSeedDB(t)
+	calls := map[string]int{}
+	r := testRunner(t, db, calls)
+
+	results, err := r.Run([]string{"operation", "data"}, false)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "data", results[0].Name, "sets run in their defined order")
+	assert.Equal(t, "operation", results[1].Name)
+	assert.Equal(t, map[string]int{"data": 1, "operation": 1}, calls)
+
+	_, err = r.Run([]string{"data", "unknown"}, false)
+	assert.EqualError(t, err, "unknown seed set: unknown")
+	assert.Equal(t, 1, calls["data"])
+}
+
+func TestRunnerReset(t *testing.T) {
+	db := setupSeedDB(t)
+	calls := map[string]int{}
+	r := testRunner(t, db, calls)
+	_, err := r.Run(nil, false)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&model.Task{ID: 10, Title: "added by a user"}).Error)
+
+	results, err := r.Run([]string{"data"}, true)
+	require.NoError(t, err)
+	assert.False(t, results[0].Skipped)
+	assert.Equal(t, 2, calls["data"])
+
+	var ids []int
+	db.Model(&model.Task{}).Order("id").Pluck("id", &ids)
+	assert.Equal(t, []int{1}, ids)
+	var names []string
+	db.Model(&model.SeedRun{}).Pluck("name", &names)
+	assert.Equal(t, []string{"data"}, names)
+}
+
+func TestRunnerRollsBackFailedSet(t *testing.T) {
+	db := setupSeedDB(t)
+	root := t.TempDir()
+	writeSeedCSV(t, root, "data", "task.csv", "id,title\n1,t\n")
+	insert := SeedStep{Name: "tasks", Run: func(tx *gorm.DB) error {
+		return tx.Create(&model.Task{ID: 1, Title: "t"}).Error
+	}}
+
+	for name, failing := range map[string]SeedStep{
+		"error": {Name: "broken", Run: func(*gorm.DB) error { return errors.New("boom") }},
+		"panic": {Name: "broken", Run: func(*gorm.DB) error { var row []string; _ = row[3]; return nil }},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &Runner{DB: db, Root: root, Sets: []SeedSet{
+				{Name: "data", Steps: []SeedStep{insert, failing}},
+			}}
+			_, err := r.Run(nil, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "seed set data: step broken")
+
+			var tasks, runs int64
+			db.Model(&model.Task{}).Count(&tasks)
+			db.Model(&model.SeedRun{}).Count(&runs)
+			assert.Zero(t, tasks)
+			assert.Zero(t, runs)
+		})
+	}
+}
+
+func TestRunnerSkipsStepsWithoutCSV(t *testing.T) {
+	db := setupSeedDB(t)
+	root := t.TempDir()
+	writeSeedCSV(t, root, "data", "task.csv", "id,title\n1,t\n")
+	ran := false
+	r := &Runner{DB: db, Root: root, Sets: []SeedSet{{Name: "data", Steps: []SeedStep{
+		{Name: "books", Files: []string{"book.csv"}, Run: func(*gorm.DB) error { ran = true; return nil }},
+	}}}}
+
+	results, err := r.Run(nil, false)
+	require.NoError(t, err)
+	assert.False(t, ran)
+	assert.Equal(t, []string{"books"}, results[0].SkippedSteps)
+
+	_, err = (&Runner{DB: db, Root: root, Sets: []SeedSet{{Name: "meandata"}}}).Run(nil, false)
+	assert.ErrorContains(t, err, "no CSV files")
+}
+
+// ステップは Root のシードセットの CSV を読む
+func TestRunnerReadsStepsFromRoot(t *testing.T) {
+	db := setupSeedDB(t)
+	root := t.TempDir()
+	writeSeedCSV(t, root, "data", "task.csv", "id,user_id,title,status\n7,1,From root,todo\n")
+	r := &Runner{DB: db, Root: root, Sets: []SeedSet{{Name: "data", Steps: []SeedStep{
+		{Name: "tasks", Files: []string{"task.csv"}, Run: SeedTaskModelsFromCSV},
+	}}}}
+
+	_, err := r.Run(nil, false)
+	require.NoError(t, err)
+	var task model.Task
+	require.NoError(t, db.First(&task, 7).Error)
+	assert.Equal(t, "From root", task.Title)
+}
+
+// 読めない行があるセットはロールバックし、seed_runs に記録しない
+func TestRunnerFailsOnMalformedRow(t *testing.T) {
+	db := setupSeedDB(t)
+	root := t.TempDir()
+	writeSeedCSV(t, root, "data", "task.csv", "id,user_id,title,priority\n7,1,Deburring,1\n8,1,Polishing,high\n")
+	r := &Runner{DB: db, Root: root, Sets: []SeedSet{{Name: "data", Steps: []SeedStep{
+		{Name: "tasks", Files: []string{"task.csv"}, Run: SeedTaskModelsFromCSV},
+	}}}}
+
+	_, err := r.Run(nil, false)
+	assert.ErrorContains(t, err, "task.csv line 3: priority: must be an integer")
+	var runs, tasks int64
+	require.NoError(t, db.Model(&model.SeedRun{}).Count(&runs).Error)
+	require.NoError(t, db.Model(&model.Task{}).Count(&tasks).Error)
+	assert.Zero(t, runs)
+	assert.Zero(t, tasks)
+}
+
+// リポジトリのシードセットは投入し直しても行が増えない
+func TestSeedSetsAreRerunnable(t *testing.T) {
+	t.Chdir("..")
+	db := setupSeedDB(t)
+	r := &Runner{DB: db}
+	_, err := r.Run(nil, false)
+	require.NoError(t, err)
+
+	counts := func() map[string]int64 {
+		counts := map[string]int64{}
+		for _, table := range seededTables {
+			if db.Migrator().HasTable(table) {
+				var n int64
+				db.Table(table).Count(&n)
+				counts[table] = n
+			}
+		}
+		return counts
+	}
+	before := counts()
+	assert.NotZero(t, before["tasks"])
+	assert.NotZero(t, before["phenomenological_frameworks"])
+
+	for _, set := range Sets {
+		require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+			_, err := r.apply(tx, set)
+			return err
+		}))
+	}
+	assert.Equal(t, before, counts())
+}
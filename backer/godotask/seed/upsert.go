@@ -0,0 +1,16 @@
+package seed
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// upsert は主キーが重なる行を CSV の値で上書きする。シードを再実行しても行が増えない
+func upsert(db *gorm.DB) *gorm.DB {
+	return db.Clauses(clause.OnConflict{UpdateAll: true})
+}
+
+// insertMissing は主キーが重なる行を書き換えずに残す（利用者が変えた値を保つ）
+func insertMissing(db *gorm.DB) *gorm.DB {
+	return db.Clauses(clause.OnConflict{DoNothing: true})
+}
@@ -170,7 +170,7 @@ func SeedAdminUser(db *gorm.DB) error {
 
 	hashedPassword, err := hashPassword(adminPassword)
 	if err != nil {
-		fmt.Errorf("failed to hash admin password: %w", err)
+		return fmt.Errorf("failed to hash admin password: %w", err)
 	}
 
 	adminUser := model.User{
@@ -194,7 +194,7 @@ func SeedAdminUser(db *gorm.DB) error {
 		if err == gorm.ErrRecordNotFound {
 			// 管理者が存在しない場合は作成
 			if err := db.Create(&adminUser).Error; err != nil {
-				fmt.Errorf("failed to create admin user: %w", err)
+				return fmt.Errorf("failed to create admin user: %w", err)
 			}
 			fmt.Println("✓ Successfully created admin user")
 			fmt.Printf("  Username: %s\n", adminUser.Username)
@@ -202,7 +202,7 @@ func SeedAdminUser(db *gorm.DB) error {
 			fmt.Printf("  Password: %s\n", adminPassword)
 			fmt.Println("  ⚠️  Please change the password after first login!")
 		} else {
-			fmt.Errorf("failed to check existing admin: %w", err)
+			return fmt.Errorf("failed to check existing admin: %w", err)
 		}
 	} else {
 		fmt.Println("⚠️  Admin user already exists, skipping creation")
@@ -291,9 +291,9 @@ func SeedUsers(db *gorm.DB) error {
 		users = append(users, user)
 	}
 
-	// バッチインサート
+	// バッチインサート（登録済みのユーザーはパスワードを変えている場合があるので書き換えない）
 	if len(users) > 0 {
-		if err := db.CreateInBatches(&users, 100).Error; err != nil {
+		if err := insertMissing(db).CreateInBatches(&users, 100).Error; err != nil {
 			return fmt.Errorf("failed to insert users: %w", err)
 		}
 		fmt.Printf("✓ Successfully seeded %d regular users (ID: 2-100)\n", len(users))
@@ -358,7 +358,7 @@ func printUserStatistics(users []model.User) {
 		}
 	}
 	
-	fmt.Println("======================\n")
+	fmt.Print("======================\n\n")
 }
 
 // SeedAllUsers - 管理者と一般ユーザーを一括でシード
@@ -1,6 +1,9 @@
 package utils
 
-var seedPath = "data"
+import "path/filepath"
+
+// seedPath は CSV を読むシードセットのディレクトリ（Runner がセットごとに設定する）
+var seedPath = filepath.Join("seed", "data")
 
 func SetSeedPath(p string) {
 	seedPath = p
@@ -8,4 +11,9 @@ func SetSeedPath(p string) {
 
 func GetSeedPath() string {
 	return seedPath
-}
\ No newline at end of file
+}
+
+// SeedFile はシードセットのディレクトリにある CSV のパスを返す
+func SeedFile(name string) string {
+	return filepath.Join(seedPath, name)
+}
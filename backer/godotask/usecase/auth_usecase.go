@@ -2,50 +2,86 @@ package usecase
 
 import (
 	"errors"
+	"time"
 
+	"github.com/godotask/domain/authz"
 	"github.com/godotask/domain/entity"
+	apperrors "github.com/godotask/errors"
 	domainRepo "github.com/godotask/domain/repository"
 	domainService "github.com/godotask/domain/auth"
+	"gorm.io/gorm"
 )
 
+// ClientInfo はセッションを作成したデバイスの情報
+type ClientInfo struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+}
+
+// TokenPair はログイン・リフレッシュ時に返すトークンの組
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	SessionID    uint
+}
+
 type AuthUsecase struct {
-	userRepo domainRepo.UserRepository
-	password domainService.PasswordService
-	token    domainService.TokenService
+	userRepo    domainRepo.UserRepository
+	sessionRepo domainRepo.SessionRepository
+	password    domainService.PasswordService
+	token       domainService.TokenService
+	refresh     domainService.RefreshTokenService
+	refreshTTL  time.Duration
 }
 
 func NewAuthUsecase(
 	userRepo domainRepo.UserRepository,
+	sessionRepo domainRepo.SessionRepository,
 	password domainService.PasswordService,
 	token domainService.TokenService,
+	refresh domainService.RefreshTokenService,
+	refreshTTL time.Duration,
 ) *AuthUsecase {
 	return &AuthUsecase{
-		userRepo: userRepo,
-		password: password,
-		token:    token,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		password:    password,
+		token:       token,
+		refresh:     refresh,
+		refreshTTL:  refreshTTL,
 	}
 }
 
-func (u *AuthUsecase) Login(email, password string) (string, error) {
+func (u *AuthUsecase) Login(email, password string, client ClientInfo) (*TokenPair, error) {
 	user, err := u.userRepo.FindByEmail(email)
 	if err != nil {
-		return "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
 	if err := u.password.Compare(user.PasswordHash, password); err != nil {
-		return "", errors.New("invalid email or password")
+		return nil, errors.New("invalid email or password")
 	}
 
-	return u.token.Generate(user)
+	return u.startSession(user, client)
 }
 
 func (u *AuthUsecase) Register(
-	username, email, role, password string,
-) (string, *entity.User, error) {
+	username, email, role, password string, client ClientInfo,
+) (*TokenPair, *entity.User, error) {
+
+	// 管理者ロールは管理 API からのみ付与できる
+	if authz.NormalizeRole(role) == authz.RoleAdmin {
+		return nil, nil, apperrors.NewAppError(
+			apperrors.AUTH_UNAUTHORIZED,
+			apperrors.GetErrorMessage(apperrors.AUTH_UNAUTHORIZED),
+			"admin role cannot be self-assigned",
+		)
+	}
 
 	hashed, err := u.password.Hash(password)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
 	user := &entity.User{
@@ -56,9 +92,144 @@ func (u *AuthUsecase) Register(
 	}
 
 	if err := u.userRepo.Create(user); err != nil {
-		return "", nil, err
+		return nil, nil, err
+	}
+
+	pair, err := u.startSession(user, client)
+	return pair, user, err
+}
+
+// Refresh はリフレッシュトークンをローテーションし、新しいトークンの組を返す
+// 使用済みのトークンが再提示された場合は漏洩とみなし、セッション（トークンファミリー）ごと失効させる
+func (u *AuthUsecase) Refresh(refreshToken string) (*TokenPair, error) {
+	now := time.Now()
+
+	stored, err := u.refreshTokenOf(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.sessionRepo.FindByID(stored.SessionID)
+	if err != nil {
+		return nil, apperrors.ErrAuthTokenInvalid
+	}
+	if session.RevokedAt != nil {
+		return nil, apperrors.ErrAuthSessionRevoked
+	}
+
+	if stored.UsedAt != nil {
+		return nil, u.revokeFamily(session.ID, now)
+	}
+	if !now.Before(stored.ExpiresAt) || !session.Active(now) {
+		return nil, apperrors.ErrAuthTokenExpired
+	}
+
+	marked, err := u.sessionRepo.MarkRefreshTokenUsed(stored.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		// 同じトークンで同時にリフレッシュされた
+		return nil, u.revokeFamily(session.ID, now)
+	}
+
+	user, err := u.userRepo.FindByID(session.UserID)
+	if err != nil {
+		return nil, apperrors.ErrAuthTokenInvalid
+	}
+
+	return u.issue(user, session.ID, now)
+}
+
+// Logout はリフレッシュトークンが属するセッションを失効させる
+// 不明・失効済みのトークンでもエラーにはしない
+func (u *AuthUsecase) Logout(refreshToken string) error {
+	stored, err := u.refreshTokenOf(refreshToken)
+	if err != nil {
+		return nil
+	}
+	return u.sessionRepo.Revoke(stored.SessionID, time.Now())
+}
+
+// LogoutAccessToken はアクセストークンに含まれるセッションを失効させる
+// リフレッシュトークンを持たないクライアントのログアウト用。不正・期限切れのトークンは何もしない
+func (u *AuthUsecase) LogoutAccessToken(accessToken string) error {
+	claims, err := u.token.Parse(accessToken)
+	if err != nil || claims.SessionID == 0 {
+		return nil
+	}
+	session, err := u.sessionRepo.FindByID(claims.SessionID)
+	if err != nil || session.UserID != claims.UserID || session.RevokedAt != nil {
+		return nil
 	}
+	return u.sessionRepo.Revoke(session.ID, time.Now())
+}
 
-	token, err := u.token.Generate(user)
-	return token, user, err
+func (u *AuthUsecase) startSession(user *entity.User, client ClientInfo) (*TokenPair, error) {
+	now := time.Now()
+	session := &entity.Session{
+		UserID:     user.ID,
+		DeviceName: client.DeviceName,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(u.refreshTTL),
+	}
+	if err := u.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+	return u.issue(user, session.ID, now)
+}
+
+func (u *AuthUsecase) issue(user *entity.User, sessionID uint, now time.Time) (*TokenPair, error) {
+	raw, hash, err := u.refresh.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(u.refreshTTL)
+	if err := u.sessionRepo.CreateRefreshToken(&entity.RefreshToken{
+		SessionID: sessionID,
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+	if err := u.sessionRepo.Touch(sessionID, now, expiresAt); err != nil {
+		return nil, err
+	}
+
+	access, err := u.token.Generate(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  access,
+		RefreshToken: raw,
+		SessionID:    sessionID,
+	}, nil
+}
+
+func (u *AuthUsecase) refreshTokenOf(refreshToken string) (*entity.RefreshToken, error) {
+	if refreshToken == "" {
+		return nil, apperrors.ErrAuthTokenInvalid
+	}
+	stored, err := u.sessionRepo.FindRefreshTokenByHash(u.refresh.Hash(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.ErrAuthTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (u *AuthUsecase) revokeFamily(sessionID uint, now time.Time) error {
+	if err := u.sessionRepo.Revoke(sessionID, now); err != nil {
+		return err
+	}
+	return apperrors.ErrAuthRefreshTokenReused
 }
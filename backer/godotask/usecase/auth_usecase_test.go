@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/godotask/domain/entity"
+	apperrors "github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/godotask/infrastructure/security"
+	"github.com/godotask/usecase"
+)
+
+func setupAuthUsecase(t *testing.T) (*usecase.AuthUsecase, *usecase.SessionUsecase) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to test DB: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.User{}, &entity.Session{}, &entity.RefreshToken{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	sessionRepo := repository.NewGormSessionRepository(db)
+	authUsecase := usecase.NewAuthUsecase(
+		repository.NewGormUserRepository(db),
+		sessionRepo,
+		security.NewBcryptPasswordService(),
+		security.NewJWTService([]byte("test-secret"), 15*time.Minute),
+		security.NewRefreshTokenService(),
+		24*time.Hour,
+	)
+	return authUsecase, usecase.NewSessionUsecase(sessionRepo)
+}
+
+func TestAuthUsecase_RefreshRotatesToken(t *testing.T) {
+	auth, sessions := setupAuthUsecase(t)
+
+	first, user, err := auth.Register("alice", "alice@example.com", "editor", "password", usecase.ClientInfo{DeviceName: "laptop"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.AccessToken)
+	assert.NotEmpty(t, first.RefreshToken)
+
+	second, err := auth.Refresh(first.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, first.SessionID, second.SessionID)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	list, err := sessions.ListSessions(user.ID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, "laptop", list[0].DeviceName)
+}
+
+func TestAuthUsecase_RefreshReuseRevokesFamily(t *testing.T) {
+	auth, sessions := setupAuthUsecase(t)
+
+	first, _, err := auth.Register("bob", "bob@example.com", "editor", "password", usecase.ClientInfo{})
+	require.NoError(t, err)
+	second, err := auth.Refresh(first.RefreshToken)
+	require.NoError(t, err)
+
+	// ローテーション済みのトークンを再利用するとセッションごと失効する
+	_, err = auth.Refresh(first.RefreshToken)
+	assert.True(t, stderrors.Is(err, apperrors.ErrAuthRefreshTokenReused))
+	assert.False(t, sessions.IsActive(first.SessionID))
+
+	// 最新のトークンも使えなくなる
+	_, err = auth.Refresh(second.RefreshToken)
+	assert.True(t, stderrors.Is(err, apperrors.ErrAuthSessionRevoked))
+}
+
+func TestAuthUsecase_LogoutRevokesSession(t *testing.T) {
+	auth, sessions := setupAuthUsecase(t)
+
+	_, _, err := auth.Register("carol", "carol@example.com", "editor", "password", usecase.ClientInfo{})
+	require.NoError(t, err)
+	pair, err := auth.Login("carol@example.com", "password", usecase.ClientInfo{DeviceName: "phone"})
+	require.NoError(t, err)
+
+	require.NoError(t, auth.Logout(pair.RefreshToken))
+	assert.False(t, sessions.IsActive(pair.SessionID))
+
+	_, err = auth.Refresh(pair.RefreshToken)
+	assert.Error(t, err)
+}
+
+func TestAuthUsecase_LogoutAccessTokenRevokesSession(t *testing.T) {
+	auth, sessions := setupAuthUsecase(t)
+
+	_, _, err := auth.Register("dave", "dave@example.com", "editor", "password", usecase.ClientInfo{})
+	require.NoError(t, err)
+	pair, err := auth.Login("dave@example.com", "password", usecase.ClientInfo{DeviceName: "browser"})
+	require.NoError(t, err)
+
+	// 不正なトークンは無視する
+	require.NoError(t, auth.LogoutAccessToken("not-a-token"))
+	assert.True(t, sessions.IsActive(pair.SessionID))
+
+	require.NoError(t, auth.LogoutAccessToken(pair.AccessToken))
+	assert.False(t, sessions.IsActive(pair.SessionID))
+	_, err = auth.Refresh(pair.RefreshToken)
+	assert.Error(t, err)
+}
+
+func TestSessionUsecase_RevokeSession(t *testing.T) {
+	auth, sessions := setupAuthUsecase(t)
+
+	_, alice, err := auth.Register("alice", "alice@example.com", "editor", "password", usecase.ClientInfo{})
+	require.NoError(t, err)
+	_, bob, err := auth.Register("bob", "bob@example.com", "editor", "password", usecase.ClientInfo{})
+	require.NoError(t, err)
+	phone, err := auth.Login("alice@example.com", "password", usecase.ClientInfo{DeviceName: "phone"})
+	require.NoError(t, err)
+
+	// 他ユーザーのセッションは失効できない
+	err = sessions.RevokeSession(bob.ID, phone.SessionID)
+	assert.True(t, stderrors.Is(err, apperrors.ErrResourceNotFound))
+
+	require.NoError(t, sessions.RevokeSession(alice.ID, phone.SessionID))
+	list, err := sessions.ListSessions(alice.ID)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	revoked, err := sessions.RevokeAllSessions(alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+}
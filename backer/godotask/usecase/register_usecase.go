@@ -35,7 +35,8 @@ func (u *RegisterUsecase) Register(
 		return "", nil, err
 	}
 
-	token, err := u.token.Generate(user)
+	// セッションを伴わない単発のトークン
+	token, err := u.token.Generate(user, 0)
 
 	return token, user, err
 }
@@ -0,0 +1,464 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/godotask/seed/parser"
+)
+
+// MaxAccountFileBytes はアーカイブの 1 ファイルを展開した後の上限（100MB）
+const MaxAccountFileBytes = 100 << 20
+
+// MaxAccountArchiveBytes はアーカイブの全ファイルを展開した後の合計の上限（200MB）
+const MaxAccountArchiveBytes = 200 << 20
+
+// accountManifestName はアーカイブの manifest のファイル名
+const accountManifestName = "manifest.json"
+
+// AccountExportService はユーザーのレコードを zip にエクスポートし、別のインスタンスやアカウントにインポートする
+// zip は manifest.json と、リソースごとの JSON（オブジェクトの配列）または CSV（ヘッダー付き）を持つ
+type AccountExportService struct {
+	Repo repository.AccountDataRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+	// MaxExtractedBytes はインポートで展開できる合計のバイト数。0 の場合は MaxAccountArchiveBytes
+	MaxExtractedBytes int64
+}
+
+func (s *AccountExportService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *AccountExportService) maxExtractedBytes() int64 {
+	if s.MaxExtractedBytes > 0 {
+		return s.MaxExtractedBytes
+	}
+	return MaxAccountArchiveBytes
+}
+
+// accountResource はアーカイブのリソースと AccountData のスライス（へのポインタ）の対応
+type accountResource struct {
+	name    string
+	records func(d *model.AccountData) interface{}
+}
+
+// accountResources はアーカイブのリソース（インポートで作成する順ではない）
+var accountResources = []accountResource{
+	{"tasks", func(d *model.AccountData) interface{} { return &d.Tasks }},
+	{"memories", func(d *model.AccountData) interface{} { return &d.Memories }},
+	{"memory_contexts", func(d *model.AccountData) interface{} { return &d.MemoryContexts }},
+	{"technical_factors", func(d *model.AccountData) interface{} { return &d.TechnicalFactors }},
+	{"knowledge_transformations", func(d *model.AccountData) interface{} { return &d.KnowledgeTransformations }},
+	{"assessments", func(d *model.AccountData) interface{} { return &d.Assessments }},
+	{"books", func(d *model.AccountData) interface{} { return &d.Books }},
+	{"heuristics_analyses", func(d *model.AccountData) interface{} { return &d.HeuristicsAnalyses }},
+	{"heuristics_insights", func(d *model.AccountData) interface{} { return &d.HeuristicsInsights }},
+	{"heuristics_patterns", func(d *model.AccountData) interface{} { return &d.HeuristicsPatterns }},
+	{"heuristics_trackings", func(d *model.AccountData) interface{} { return &d.HeuristicsTrackings }},
+	{"heuristics_modelers", func(d *model.AccountData) interface{} { return &d.HeuristicsModelers }},
+	{"knowledge_patterns", func(d *model.AccountData) interface{} { return &d.KnowledgePatterns }},
+	{"quantification_labels", func(d *model.AccountData) interface{} { return &d.QuantificationLabels }},
+	{"qualitative_labels", func(d *model.AccountData) interface{} { return &d.QualitativeLabels }},
+	{"label_relations", func(d *model.AccountData) interface{} { return &d.LabelRelations }},
+}
+
+// 列の値の種類
+const (
+	columnString = iota
+	columnNumber
+	columnBool
+	columnTime
+	columnJSON
+)
+
+type exportColumn struct {
+	name     string
+	kind     int
+	nullable bool
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// exportColumns はモデルの JSON のキーのうちテーブルの列にあたるものを、フィールドの順に返す
+// 関連（foreignKey のフィールドや構造体のスライス）と gorm:"-" のフィールドは含めない
+func exportColumns(t reflect.Type) []exportColumn {
+	var columns []exportColumn
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		gormTag := f.Tag.Get("gorm")
+		if gormTag == "-" || strings.Contains(gormTag, "foreignKey") {
+			continue
+		}
+		ft, nullable := f.Type, false
+		if ft.Kind() == reflect.Ptr {
+			ft, nullable = ft.Elem(), true
+		}
+		column := exportColumn{name: name, nullable: nullable}
+		switch {
+		case ft == timeType:
+			column.kind = columnTime
+		case ft.Kind() == reflect.String:
+			column.kind = columnString
+		case ft.Kind() == reflect.Bool:
+			column.kind = columnBool
+		case ft.Kind() >= reflect.Int && ft.Kind() <= reflect.Float64:
+			column.kind = columnNumber
+		case ft.Kind() == reflect.Struct, ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
+			continue
+		default:
+			column.kind, column.nullable = columnJSON, true
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// recordObjects は records（モデルのスライスへのポインタ）を列だけのオブジェクトにする
+func recordObjects(records interface{}, columns []exportColumn) ([]map[string]interface{}, error) {
+	v := reflect.ValueOf(records).Elem()
+	objects := make([]map[string]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		raw, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var all map[string]interface{}
+		if err := decoder.Decode(&all); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{}, len(columns))
+		for _, c := range columns {
+			object[c.name] = all[c.name]
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// csvValue は値を CSV の文字列にする。null は空、オブジェクト・配列は JSON にする
+func csvValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	raw, err := json.Marshal(v)
+	return string(raw), err
+}
+
+func encodeRecords(records interface{}, format string) ([]byte, error) {
+	columns := exportColumns(reflect.TypeOf(records).Elem().Elem())
+	objects, err := recordObjects(records, columns)
+	if err != nil {
+		return nil, err
+	}
+	if format == ImportFormatJSON {
+		return json.Marshal(objects)
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, 0, len(columns))
+	for _, c := range columns {
+		header = append(header, c.name)
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		record := make([]string, 0, len(columns))
+		for _, c := range columns {
+			value, err := csvValue(object[c.name])
+			if err != nil {
+				return nil, err
+			}
+			record = append(record, value)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvObject は CSV の行を列の種類に従って JSON のオブジェクトにする
+func csvObject(row parser.Row, columns []exportColumn) (map[string]interface{}, error) {
+	if row.Values == nil {
+		return nil, stderrors.New("column count does not match the header")
+	}
+	object := map[string]interface{}{}
+	for _, c := range columns {
+		v, ok := row.Values[c.name]
+		if !ok || (v == "" && (c.nullable || c.kind != columnString)) {
+			continue
+		}
+		switch c.kind {
+		case columnString, columnTime:
+			object[c.name] = v
+		case columnNumber:
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("%s must be a number", c.name)
+			}
+			object[c.name] = json.Number(v)
+		case columnBool:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be true or false", c.name)
+			}
+			object[c.name] = b
+		case columnJSON:
+			if !json.Valid([]byte(v)) {
+				return nil, fmt.Errorf("%s must be JSON", c.name)
+			}
+			object[c.name] = json.RawMessage(v)
+		}
+	}
+	return object, nil
+}
+
+// decodeRecords は content を records（モデルのスライスへのポインタ）に読み込み、件数を返す
+// null と空の値は読み込まず、モデルのゼロ値（NULL）のままにする
+func decodeRecords(content []byte, format string, records interface{}) (int, error) {
+	slice := reflect.ValueOf(records).Elem()
+	columns := exportColumns(slice.Type().Elem())
+	var objects []map[string]interface{}
+	var lines []string
+	if format == ImportFormatJSON {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&objects); err != nil {
+			return 0, err
+		}
+		for i, object := range objects {
+			for name, v := range object {
+				if v == nil {
+					delete(object, name)
+				}
+			}
+			lines = append(lines, "record "+strconv.Itoa(i+1))
+		}
+	} else {
+		rows, err := parser.ReadCSV(bytes.NewReader(content))
+		if err != nil {
+			return 0, err
+		}
+		for _, row := range rows {
+			object, err := csvObject(row, columns)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %w", row.Line, err)
+			}
+			objects = append(objects, object)
+			lines = append(lines, "line "+strconv.Itoa(row.Line))
+		}
+	}
+	for i, object := range objects {
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", lines[i], err)
+		}
+		record := reflect.New(slice.Type().Elem())
+		if err := json.Unmarshal(raw, record.Interface()); err != nil {
+			return 0, fmt.Errorf("%s: %w", lines[i], err)
+		}
+		slice.Set(reflect.Append(slice, record.Elem()))
+	}
+	return slice.Len(), nil
+}
+
+// Export は userID のレコードを format（json / csv）のファイルにした zip を返す
+func (s *AccountExportService) Export(userID uint, format string) ([]byte, *model.ExportManifest, error) {
+	if format != ImportFormatJSON && format != ImportFormatCSV {
+		return nil, nil, errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), "format must be json or csv")
+	}
+	data, err := s.Repo.FindAccountData(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := &model.ExportManifest{
+		SchemaVersion: model.ExportSchemaVersion,
+		Format:        format,
+		ExportedAt:    s.now().UTC(),
+		UserID:        int(userID),
+		Files:         make([]model.ExportFile, 0, len(accountResources)),
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	write := func(name string, content []byte) error {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: manifest.ExportedAt})
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(content)
+		return err
+	}
+	for _, r := range accountResources {
+		records := r.records(data)
+		content, err := encodeRecords(records, format)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode %s: %w", r.name, err)
+		}
+		file := model.ExportFile{Resource: r.name, Name: r.name + "." + format, Count: reflect.ValueOf(records).Elem().Len()}
+		if err := write(file.Name, content); err != nil {
+			return nil, nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := write(accountManifestName, content); err != nil {
+		return nil, nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), manifest, nil
+}
+
+func invalidArchive(detail string) error {
+	return errors.NewAppError(errors.VAL_INVALID_FORMAT, errors.GetErrorMessage(errors.VAL_INVALID_FORMAT), detail)
+}
+
+// archiveBudget はアーカイブから展開できる残りのバイト数
+type archiveBudget struct {
+	total     int64
+	remaining int64
+}
+
+// readArchiveFile は zip のファイルを MaxAccountFileBytes と残りの予算まで読む
+// ヘッダーの展開後のサイズが上限を超えるファイルは開かずに拒否し、ヘッダーを偽ったファイルも読んだ量で止める
+func (b *archiveBudget) readArchiveFile(f *zip.File) ([]byte, error) {
+	limit := int64(MaxAccountFileBytes)
+	if b.remaining < limit {
+		limit = b.remaining
+	}
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, b.exceeded(f.Name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, invalidArchive(f.Name + ": " + err.Error())
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, invalidArchive(f.Name + ": " + err.Error())
+	}
+	if int64(len(content)) > limit {
+		return nil, b.exceeded(f.Name)
+	}
+	b.remaining -= int64(len(content))
+	return content, nil
+}
+
+func (b *archiveBudget) exceeded(name string) error {
+	return errors.NewAppError(errors.VAL_CONSTRAINT_FAILED, errors.GetErrorMessage(errors.VAL_CONSTRAINT_FAILED),
+		fmt.Sprintf("%s exceeds %d bytes per file or %d bytes in total when extracted", name, MaxAccountFileBytes, b.total))
+}
+
+// Import は Export の zip を userID のレコードとして 1 つのトランザクションで作成する
+// ID は作成し直し、レコード間の参照は新しい ID に付け替える。manifest に無いリソースは空として扱う
+func (s *AccountExportService) Import(userID uint, archive io.ReaderAt, size int64) (*model.AccountImportResult, error) {
+	zr, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, invalidArchive("archive must be a zip file: " + err.Error())
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	manifestFile, ok := files[accountManifestName]
+	if !ok {
+		return nil, invalidArchive(accountManifestName + " is missing")
+	}
+	limit := s.maxExtractedBytes()
+	budget := &archiveBudget{total: limit, remaining: limit}
+	content, err := budget.readArchiveFile(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var manifest model.ExportManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, invalidArchive(accountManifestName + ": " + err.Error())
+	}
+	if manifest.SchemaVersion < 1 || manifest.SchemaVersion > model.ExportSchemaVersion {
+		return nil, errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("schema version %d is not supported (supported: 1-%d)", manifest.SchemaVersion, model.ExportSchemaVersion))
+	}
+	if manifest.Format != ImportFormatJSON && manifest.Format != ImportFormatCSV {
+		return nil, invalidArchive("format must be json or csv")
+	}
+
+	resources := map[string]accountResource{}
+	for _, r := range accountResources {
+		resources[r.name] = r
+	}
+	data := &model.AccountData{}
+	seen := map[string]bool{}
+	for _, file := range manifest.Files {
+		r, ok := resources[file.Resource]
+		if !ok {
+			return nil, invalidArchive("unknown resource " + file.Resource)
+		}
+		if seen[file.Resource] {
+			return nil, invalidArchive("duplicate resource " + file.Resource)
+		}
+		seen[file.Resource] = true
+		f, ok := files[file.Name]
+		if !ok {
+			return nil, invalidArchive(file.Name + " is missing")
+		}
+		content, err := budget.readArchiveFile(f)
+		if err != nil {
+			return nil, err
+		}
+		count, err := decodeRecords(content, manifest.Format, r.records(data))
+		if err != nil {
+			return nil, invalidArchive(file.Name + ": " + err.Error())
+		}
+		if count != file.Count {
+			return nil, invalidArchive(fmt.Sprintf("%s has %d records but the manifest lists %d", file.Name, count, file.Count))
+		}
+	}
+
+	result, err := s.Repo.RestoreAccountData(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	result.SchemaVersion = manifest.SchemaVersion
+	return result, nil
+}
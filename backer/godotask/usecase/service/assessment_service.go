@@ -14,11 +14,12 @@ func NewAssessmentService(repo repository.AssessmentRepositoryInterface) *Assess
 	return &AssessmentService{Repo: repo}
 }
 
-func (s *AssessmentService) CreateAssessment(task *model.Assessment) error {
-	return s.Repo.Create(task)
+func (s *AssessmentService) CreateAssessment(userID uint, task *model.Assessment) error {
+	task.UserID = int(userID)
+	return s.Repo.Create(userID, task)
 }
-func (s *AssessmentService) GetAssessmentByID(id string) (*model.Assessment, error) {
-	return s.Repo.FindByID(id)
+func (s *AssessmentService) GetAssessmentByID(userID uint, id string) (*model.Assessment, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *AssessmentService) ListAssessments(userID uint) ([]model.Assessment, int64, error) {
 	return s.Repo.FindAll(userID)
@@ -29,9 +30,9 @@ func (s *AssessmentService) ListAssessmentsPager(userID uint, pager dtoquery.Pag
 func (s *AssessmentService) ListAssessmentsForTaskUserPager(filter dtoquery.QueryFilter, pager dtoquery.PagerQuery) ([]model.Assessment, int64, error) {
   return s.Repo.ListAssessmentsForTaskUserPager(filter, pager.Offset, pager.Limit)
 }
-func (s *AssessmentService) UpdateAssessment(id string, task *model.Assessment) error {
-	return s.Repo.Update(id, task)
+func (s *AssessmentService) UpdateAssessment(userID uint, id string, task *model.Assessment) error {
+	return s.Repo.Update(userID, id, task)
 }
-func (s *AssessmentService) DeleteAssessment(id string) error {	
-	return s.Repo.Delete(id)
+func (s *AssessmentService) DeleteAssessment(userID uint, id string) error {	
+	return s.Repo.Delete(userID, id)
 }
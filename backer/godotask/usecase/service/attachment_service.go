@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/godotask/infrastructure/storage"
+)
+
+const (
+	// MaxAttachmentSize は1ファイルの上限（バイト）
+	MaxAttachmentSize = 20 << 20
+	// DefaultDownloadURLTTL / MaxDownloadURLTTL は署名付きダウンロード URL の有効期間
+	DefaultDownloadURLTTL = 15 * time.Minute
+	MaxDownloadURLTTL     = 24 * time.Hour
+	// DownloadPath は署名付きダウンロード URL のパス（末尾に添付 ID を付ける）
+	DownloadPath = "/api/attachment_download/"
+)
+
+type AttachmentService struct {
+	Repo   repository.AttachmentRepositoryInterface
+	Blobs  storage.BlobStore
+	Signer *storage.URLSigner
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+// AttachmentUpload はアップロードされたファイルと添付先
+type AttachmentUpload struct {
+	ResourceType string
+	ResourceID   string
+	FileName     string
+	// ContentType はクライアントが申告した MIME タイプ。内容から判定できない場合に使う
+	ContentType string
+	Description string
+	Body        io.Reader
+}
+
+// AttachmentDownload は添付と署名付きダウンロード URL
+type AttachmentDownload struct {
+	model.Attachment
+	DownloadURL string    `json:"download_url"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (s *AttachmentService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// Upload はファイルを BlobStore に保存し、actorID がアップロードした添付としてリソースに紐付ける
+// userID は添付先のリソースの認可に使う（全リソースへの権限を持つロールは 0）
+func (s *AttachmentService) Upload(userID, actorID uint, in *AttachmentUpload) (*AttachmentDownload, error) {
+	if in.ResourceType == "" || in.ResourceID == "" {
+		return nil, errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"resource_type and resource_id are required",
+		)
+	}
+	if _, ok := repository.ResourceTable(in.ResourceType); !ok || in.ResourceType == "attachment" {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("files cannot be attached to resource type %q", in.ResourceType),
+		)
+	}
+
+	spooled, err := storage.Spool(in.Body, MaxAttachmentSize)
+	if stderrors.Is(err, storage.ErrTooLarge) {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("file must be at most %d bytes", MaxAttachmentSize),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer spooled.Close()
+
+	attachment := &model.Attachment{
+		ID:           uuid.New().String(),
+		UserID:       actorID,
+		ResourceType: in.ResourceType,
+		ResourceID:   in.ResourceID,
+		FileName:     sanitizeFileName(in.FileName),
+		ContentType:  contentType(spooled.ContentType, in.ContentType),
+		Size:         spooled.Size,
+		SHA256:       spooled.Digest,
+		Description:  in.Description,
+		CreatedAt:    s.now(),
+	}
+	err = s.Repo.Create(userID, attachment, func() error {
+		exists, err := s.Blobs.Exists(context.Background(), spooled.Digest)
+		if err != nil || exists {
+			return err
+		}
+		body, err := spooled.Reader()
+		if err != nil {
+			return err
+		}
+		return s.Blobs.Put(context.Background(), spooled.Digest, body, spooled.Size)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.withURL(attachment, DefaultDownloadURLTTL), nil
+}
+
+// GetAttachment は添付と ttl の間有効なダウンロード URL を返す（ttl が 0 以下なら既定値）
+func (s *AttachmentService) GetAttachment(userID uint, id string, ttl time.Duration) (*AttachmentDownload, error) {
+	if ttl <= 0 {
+		ttl = DefaultDownloadURLTTL
+	}
+	if ttl > MaxDownloadURLTTL {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("ttl must be at most %s", MaxDownloadURLTTL),
+		)
+	}
+	attachment, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withURL(attachment, ttl), nil
+}
+
+// ListAttachments はリソースの添付を返す。リソースを指定しない場合は自分がアップロードした添付を返す
+func (s *AttachmentService) ListAttachments(userID, actorID uint, resourceType, resourceID string) ([]AttachmentDownload, error) {
+	var attachments []model.Attachment
+	var err error
+	switch {
+	case resourceType != "" && resourceID != "":
+		attachments, err = s.Repo.FindByResource(userID, resourceType, resourceID)
+	case resourceType == "" && resourceID == "":
+		attachments, err = s.Repo.FindByUploader(actorID)
+	default:
+		return nil, errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"resource_type and resource_id must be given together",
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AttachmentDownload, 0, len(attachments))
+	for i := range attachments {
+		out = append(out, *s.withURL(&attachments[i], DefaultDownloadURLTTL))
+	}
+	return out, nil
+}
+
+// DeleteAttachment は添付を削除し、どの添付からも参照されなくなったブロブを BlobStore から削除する
+func (s *AttachmentService) DeleteAttachment(userID uint, id string) error {
+	return s.Repo.Delete(userID, id, func(digest string) error {
+		return s.Blobs.Delete(context.Background(), digest)
+	})
+}
+
+// OpenSigned は署名付き URL の id・expires・signature を確認して添付の内容を開く
+func (s *AttachmentService) OpenSigned(id, expires, signature string) (*model.Attachment, io.ReadCloser, error) {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err == nil {
+		err = s.Signer.Verify(id, exp, signature, s.now())
+	}
+	if err != nil {
+		code := errors.AUTH_TOKEN_INVALID
+		if stderrors.Is(err, storage.ErrSignatureExpired) {
+			code = errors.AUTH_TOKEN_EXPIRED
+		}
+		return nil, nil, errors.NewAppError(code, errors.GetErrorMessage(code), "download url: "+err.Error())
+	}
+	// 署名が認可の代わりになる
+	attachment, err := s.Repo.FindByID(0, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.Blobs.Open(context.Background(), attachment.SHA256)
+	if stderrors.Is(err, storage.ErrNotFound) {
+		return nil, nil, errors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+func (s *AttachmentService) withURL(a *model.Attachment, ttl time.Duration) *AttachmentDownload {
+	expiresAt := s.now().Add(ttl).Truncate(time.Second)
+	expires := expiresAt.Unix()
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("signature", s.Signer.Sign(a.ID, expires))
+	return &AttachmentDownload{
+		Attachment:  *a,
+		DownloadURL: DownloadPath + url.PathEscape(a.ID) + "?" + q.Encode(),
+		ExpiresAt:   expiresAt,
+	}
+}
+
+// sanitizeFileName はパスを取り除いたファイル名を返す
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+// contentType は内容から判定した MIME タイプを優先し、判定できない場合は申告された値を使う
+func contentType(detected, declared string) string {
+	if detected != "application/octet-stream" && !strings.HasPrefix(detected, "text/plain") {
+		return detected
+	}
+	if declared != "" {
+		if mediaType, _, err := mime.ParseMediaType(declared); err == nil {
+			return mediaType
+		}
+	}
+	return detected
+}
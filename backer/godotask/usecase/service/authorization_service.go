@@ -0,0 +1,48 @@
+package service
+
+import (
+	stderrors "errors"
+
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/repository"
+	"gorm.io/gorm"
+)
+
+type AuthorizationService struct {
+	Repo repository.ResourceRepositoryInterface
+}
+
+// AuthorizeResource: userID が対象リソースを変更できるかを判定する
+// PermResourceAny を持つロールは所有者に関係なく許可する
+// 所有者でない場合も、共有先ワークスペースの owner / editor であれば許可する
+func (s *AuthorizationService) AuthorizeResource(userID uint, role string, resourceType string, id string) error {
+	if authz.HasPermission(role, authz.PermResourceAny) {
+		return nil
+	}
+
+	ownerID, err := s.Repo.FindOwnerID(resourceType, id)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.ErrResourceNotFound
+	}
+	if stderrors.Is(err, repository.ErrUnknownResourceType) {
+		// 所有者を持たないリソースはロール単位の認可のみ
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if ownerID == userID {
+		return nil
+	}
+
+	shared, err := s.Repo.HasWorkspaceAccess(resourceType, id, userID, true)
+	if err != nil {
+		return err
+	}
+	if !shared {
+		return errors.ErrResourceAccessDenied
+	}
+	return nil
+}
@@ -9,18 +9,18 @@ type BookService struct {
   Repo repository.BookRepositoryInterface
 }
 
-func (s *BookService) CreateBook(book *model.Book) error {
-	return s.Repo.Create(book)
+func (s *BookService) CreateBook(userID uint, book *model.Book) error {
+	return s.Repo.Create(userID, book)
 }
-func (s *BookService) GetBookByID(id string) (*model.Book, error) {
-	return s.Repo.FindByID(id)
+func (s *BookService) GetBookByID(userID uint, id string) (*model.Book, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *BookService) ListBooks(userID uint) ([]model.Book, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *BookService) UpdateBook(id string, book *model.Book) error {
-	return s.Repo.Update(id, book)
+func (s *BookService) UpdateBook(userID uint, id string, book *model.Book) error {
+	return s.Repo.Update(userID, id, book)
 }
-func (s *BookService) DeleteBook(id string) error {
-	return s.Repo.Delete(id)
+func (s *BookService) DeleteBook(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
@@ -10,9 +10,9 @@ type HeuristicsAnalysisService struct {
 	Repo repository.HeuristicsAnalysisRepositoryInterface
 }
 
-func (s *HeuristicsAnalysisService) CreateAnalyzeData(request *model.HeuristicsAnalysisRequest) (*model.HeuristicsAnalysis, error) {
+func (s *HeuristicsAnalysisService) CreateAnalyzeData(userID uint, request *model.HeuristicsAnalysisRequest) (*model.HeuristicsAnalysis, error) {
 	analysis := &model.HeuristicsAnalysis{
-		UserID:       request.UserID,
+		UserID:       int(userID),
 		TaskID:       request.TaskID,
 		AnalysisType: request.AnalysisType,
 		Result:       toJSONString(request.Data),
@@ -20,15 +20,15 @@ func (s *HeuristicsAnalysisService) CreateAnalyzeData(request *model.HeuristicsA
 		Status:       "completed",
 	}
 
-	if err := s.Repo.CreateAnalysis(analysis); err != nil {
+	if err := s.Repo.CreateAnalysis(userID, analysis); err != nil {
 		return nil, err
 	}
 
 	return analysis, nil
 }
 
-func (s *HeuristicsAnalysisService) GetAnalysisById(id string) (*model.HeuristicsAnalysis, error) {
-  return s.Repo.GetAnalysisById(id)
+func (s *HeuristicsAnalysisService) GetAnalysisById(userID uint, id string) (*model.HeuristicsAnalysis, error) {
+  return s.Repo.GetAnalysisById(userID, id)
 }
 
 func (s *HeuristicsAnalysisService) ListAnalyze() ([]model.HeuristicsAnalysis, error) {
@@ -39,12 +39,12 @@ func (s *HeuristicsAnalysisService) ListAnalysesPager(filter dtoquery.QueryFilte
   return s.Repo.ListAnalysesPager(filter, pager.Offset, pager.Limit)
 }
 
-func (s *HeuristicsAnalysisService) UpdateAnalyzeData(id string, analyze *model.HeuristicsAnalysis) error {
-	return s.Repo.UpdateAnalysis(id, analyze)
+func (s *HeuristicsAnalysisService) UpdateAnalyzeData(userID uint, id string, analyze *model.HeuristicsAnalysis) error {
+	return s.Repo.UpdateAnalysis(userID, id, analyze)
 }
 
-func (s *HeuristicsAnalysisService) DeleteAnalyzeData(id string) error {
-	return s.Repo.DeleteAnalysis(id)
+func (s *HeuristicsAnalysisService) DeleteAnalyzeData(userID uint, id string) error {
+	return s.Repo.DeleteAnalysis(userID, id)
 }
 
 // Helper functions
@@ -10,15 +10,16 @@ type HeuristicsInsightService struct {
 	Repo repository.HeuristicsInsightRepositoryInterface
 }
 
-func (s *HeuristicsInsightService) CreateInsightData(insight *model.HeuristicsInsight) (*model.HeuristicsInsight, error) {
-	if err := s.Repo.CreateInsight(insight); err != nil {
+func (s *HeuristicsInsightService) CreateInsightData(userID uint, insight *model.HeuristicsInsight) (*model.HeuristicsInsight, error) {
+	insight.UserID = int(userID)
+	if err := s.Repo.CreateInsight(userID, insight); err != nil {
 		return nil, err
 	}
 	return insight, nil
 }
 
-func (s *HeuristicsInsightService) GetInsightById(id string) (*model.HeuristicsInsight, error) {
-  return s.Repo.GetInsightById(id)
+func (s *HeuristicsInsightService) GetInsightById(userID uint, id string) (*model.HeuristicsInsight, error) {
+  return s.Repo.GetInsightById(userID, id)
 }
 
 func (s *HeuristicsInsightService) ListInsight() ([]model.HeuristicsInsight, error) {
@@ -29,10 +30,10 @@ func (s *HeuristicsInsightService) ListInsightPager(filter dtoquery.QueryFilter,
   return s.Repo.ListInsightPager(filter, pager.Offset, pager.Limit)
 }
 
-func (s *HeuristicsInsightService) UpdateInsightData(id string, insight *model.HeuristicsInsight) error {
-	return s.Repo.UpdateInsight(id, insight)
+func (s *HeuristicsInsightService) UpdateInsightData(userID uint, id string, insight *model.HeuristicsInsight) error {
+	return s.Repo.UpdateInsight(userID, id, insight)
 }
 
-func (s *HeuristicsInsightService) DeleteInsightData(id string) error {
-	return s.Repo.DeleteInsight(id)
+func (s *HeuristicsInsightService) DeleteInsightData(userID uint, id string) error {
+	return s.Repo.DeleteInsight(userID, id)
 }
@@ -10,15 +10,16 @@ type HeuristicsModelerService struct {
 	Repo repository.HeuristicsModelerRepositoryInterface
 }
 
-func (s *HeuristicsModelerService) CreateModelerData(modeler *model.HeuristicsModeler) (*model.HeuristicsModeler, error) {
-  if err := s.Repo.CreateModeler(modeler); err != nil {
+func (s *HeuristicsModelerService) CreateModelerData(userID uint, modeler *model.HeuristicsModeler) (*model.HeuristicsModeler, error) {
+	modeler.UserID = int(userID)
+  if err := s.Repo.CreateModeler(userID, modeler); err != nil {
     return nil, err
   }
   return modeler, nil
 }
 
-func (s *HeuristicsModelerService) GetModelerById(id string) (*model.HeuristicsModeler, error) {
-  return s.Repo.GetModelerById(id)
+func (s *HeuristicsModelerService) GetModelerById(userID uint, id string) (*model.HeuristicsModeler, error) {
+  return s.Repo.GetModelerById(userID, id)
 }
 
 func (s *HeuristicsModelerService) ListModeler(userID uint) ([]model.HeuristicsModeler, error) {
@@ -29,10 +30,10 @@ func (s *HeuristicsModelerService) ListModelerPager(filter dtoquery.QueryFilter,
   return s.Repo.ListModelerPager(filter, pager.Offset, pager.Limit)
 }
 
-func (s *HeuristicsModelerService) UpdateModelerData(id string, modeler *model.HeuristicsModeler) error {
-	return s.Repo.UpdateModeler(id, modeler)
+func (s *HeuristicsModelerService) UpdateModelerData(userID uint, id string, modeler *model.HeuristicsModeler) error {
+	return s.Repo.UpdateModeler(userID, id, modeler)
 }
 
-func (s *HeuristicsModelerService) DeleteModelerData(id string) error {
-	return s.Repo.DeleteModeler(id)
+func (s *HeuristicsModelerService) DeleteModelerData(userID uint, id string) error {
+	return s.Repo.DeleteModeler(userID, id)
 }
@@ -10,15 +10,16 @@ type HeuristicsPatternService struct {
 	Repo repository.HeuristicsPatternRepositoryInterface
 }
 
-func (s *HeuristicsPatternService) CreatePatternData(pattern *model.HeuristicsPattern) (*model.HeuristicsPattern, error) {
-  if err := s.Repo.CreatePattern(pattern); err != nil {
+func (s *HeuristicsPatternService) CreatePatternData(userID uint, pattern *model.HeuristicsPattern) (*model.HeuristicsPattern, error) {
+	pattern.UserID = int(userID)
+  if err := s.Repo.CreatePattern(userID, pattern); err != nil {
     return nil, err
   }
   return pattern, nil
 }
 
-func (s *HeuristicsPatternService) GetPatternById(id string) (*model.HeuristicsPattern, error) {
-  return s.Repo.GetPatternById(id)
+func (s *HeuristicsPatternService) GetPatternById(userID uint, id string) (*model.HeuristicsPattern, error) {
+  return s.Repo.GetPatternById(userID, id)
 }
 
 func (s *HeuristicsPatternService) ListPattern(userID uint) ([]model.HeuristicsPattern, error) {
@@ -29,10 +30,10 @@ func (s *HeuristicsPatternService) ListPatternPager(filter dtoquery.QueryFilter,
   return s.Repo.ListPatternPager(filter, pager.Offset, pager.Limit)
 }
 
-func (s *HeuristicsPatternService) UpdatePatternData(id string, pattern *model.HeuristicsPattern) error {
-	return s.Repo.UpdatePattern(id, pattern)
+func (s *HeuristicsPatternService) UpdatePatternData(userID uint, id string, pattern *model.HeuristicsPattern) error {
+	return s.Repo.UpdatePattern(userID, id, pattern)
 }
 
-func (s *HeuristicsPatternService) DeletePatternData(id string) error {
-	return s.Repo.DeletePattern(id)
+func (s *HeuristicsPatternService) DeletePatternData(userID uint, id string) error {
+	return s.Repo.DeletePattern(userID, id)
 }
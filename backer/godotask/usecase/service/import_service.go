@@ -0,0 +1,396 @@
+package service
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"github.com/godotask/seed/parser"
+)
+
+// MaxImportRows は 1 回のインポートで受け付ける行数の上限
+const MaxImportRows = 5000
+
+// インポートの形式
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+// ImportService はシードと同じ列の CSV / JSON を現在のユーザーのレコードとして一括で取り込む
+// 行は自然キーで既存のレコードと照合し、一致すれば更新、無ければ作成する
+type ImportService struct {
+	Repo repository.ImportRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+func (s *ImportService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// importRef は行が参照するレコード
+type importRef struct {
+	Field    string
+	Resource string
+	ID       interface{}
+}
+
+// importSpec はリソースごとの取り込み方
+type importSpec[T any] struct {
+	// resource は resourceTables の種別
+	resource string
+	parse    func(parser.Row) (T, error)
+	// key は自然キー（ユーザーのレコードの中で一意）
+	key  func(m *T) string
+	refs func(m *T) []importRef
+	// prepare は所有者を付け、既存のレコードがあれば ID と作成日時を引き継ぐ
+	prepare func(m, existing *T, userID uint, now time.Time)
+	// validate は既存のレコードを更新できない場合に誤りを返す（省略可）
+	validate func(existing *T) error
+	// touch は内容が変わった既存のレコードの更新日時を進め、併せて作成するレコード（改訂履歴など）を返す
+	touch func(m, existing *T, userID uint, now time.Time) []interface{}
+}
+
+// importResources は取り込めるリソースの一覧（エラーメッセージ用）
+var importResources = []string{"task", "memory", "knowledge_pattern", "quantification_label"}
+
+// Import は body を format（csv / json）として読み、resource のレコードとして取り込む
+// commit が false の場合は検証のみ行う。commit の場合に誤りのある行があれば何も保存せず、結果とともにエラーを返す
+func (s *ImportService) Import(userID uint, resource, format string, body io.Reader, commit bool) (*model.ImportResult, error) {
+	var rows []parser.Row
+	var err error
+	switch format {
+	case ImportFormatCSV:
+		rows, err = parser.ReadCSV(body)
+	case ImportFormatJSON:
+		rows, err = parser.ReadJSON(body)
+	default:
+		return nil, errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), "format must be csv or json")
+	}
+	if err != nil {
+		return nil, errors.NewAppError(errors.VAL_INVALID_FORMAT, errors.GetErrorMessage(errors.VAL_INVALID_FORMAT), err.Error())
+	}
+	if len(rows) > MaxImportRows {
+		return nil, errors.NewAppError(errors.VAL_CONSTRAINT_FAILED, errors.GetErrorMessage(errors.VAL_CONSTRAINT_FAILED),
+			fmt.Sprintf("at most %d rows can be imported at once", MaxImportRows))
+	}
+
+	result := &model.ImportResult{Resource: resource, Format: format, DryRun: !commit, Total: len(rows), Errors: []model.ImportRowError{}}
+	switch resource {
+	case "task":
+		err = runImport(s, taskImport, userID, rows, commit, result)
+	case "memory":
+		err = runImport(s, memoryImport, userID, rows, commit, result)
+	case "knowledge_pattern":
+		err = runImport(s, knowledgePatternImport, userID, rows, commit, result)
+	case "quantification_label":
+		err = runImport(s, quantificationLabelImport, userID, rows, commit, result)
+	default:
+		return nil, errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"resource must be one of "+strings.Join(importResources, ", "))
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// rowErrors は parse のエラーを行の誤りにする
+func rowErrors(line int, err error) []model.ImportRowError {
+	var fieldErrs parser.FieldErrors
+	if !stderrors.As(err, &fieldErrs) {
+		return []model.ImportRowError{{Row: line, Message: err.Error()}}
+	}
+	out := make([]model.ImportRowError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		out = append(out, model.ImportRowError{Row: line, Field: fe.Field, Message: fe.Message})
+	}
+	return out
+}
+
+// sameRecord は更新日時を除いてレコードが同じかを返す
+func sameRecord(a, b interface{}) bool {
+	decode := func(v interface{}) map[string]interface{} {
+		raw, _ := json.Marshal(v)
+		m := map[string]interface{}{}
+		_ = json.Unmarshal(raw, &m)
+		delete(m, "updated_at")
+		return m
+	}
+	return reflect.DeepEqual(decode(a), decode(b))
+}
+
+func runImport[T any](s *ImportService, spec importSpec[T], userID uint, rows []parser.Row, commit bool, result *model.ImportResult) error {
+	var existing []T
+	if err := s.Repo.FindOwned(userID, spec.resource, &existing); err != nil {
+		return err
+	}
+	byKey := map[string]*T{}
+	for i := range existing {
+		byKey[spec.key(&existing[i])] = &existing[i]
+	}
+
+	now := s.now()
+	seen := map[string]int{}
+	refs := map[string]error{}
+	failed := map[int]bool{}
+	fail := func(errs ...model.ImportRowError) {
+		for _, e := range errs {
+			failed[e.Row] = true
+		}
+		result.Errors = append(result.Errors, errs...)
+	}
+	var creates, updates []interface{}
+	for _, row := range rows {
+		m, err := spec.parse(row)
+		if err != nil {
+			fail(rowErrors(row.Line, err)...)
+			continue
+		}
+		key := spec.key(&m)
+		if line, ok := seen[key]; ok {
+			fail(model.ImportRowError{Row: row.Line, Message: fmt.Sprintf("duplicates the record on row %d", line)})
+			continue
+		}
+		seen[key] = row.Line
+
+		ok := true
+		for _, ref := range spec.refs(&m) {
+			cacheKey := ref.Resource + "/" + fmt.Sprint(ref.ID)
+			refErr, checked := refs[cacheKey]
+			if !checked {
+				refErr = s.Repo.AuthorizeReference(userID, ref.Resource, ref.ID)
+				if refErr != nil && !stderrors.Is(refErr, errors.ErrResourceNotFound) && !stderrors.Is(refErr, errors.ErrResourceAccessDenied) {
+					return refErr
+				}
+				refs[cacheKey] = refErr
+			}
+			if refErr != nil {
+				fail(model.ImportRowError{Row: row.Line, Field: ref.Field, Message: fmt.Sprintf("%s %v does not exist or cannot be edited", ref.Resource, ref.ID)})
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		old := byKey[key]
+		if old != nil && spec.validate != nil {
+			if err := spec.validate(old); err != nil {
+				fail(model.ImportRowError{Row: row.Line, Message: err.Error()})
+				continue
+			}
+		}
+		spec.prepare(&m, old, userID, now)
+		switch {
+		case old == nil:
+			creates = append(creates, &m)
+			result.Created++
+		case sameRecord(&m, old):
+			result.Unchanged++
+		default:
+			creates = append(creates, spec.touch(&m, old, userID, now)...)
+			updates = append(updates, &m)
+			result.Updated++
+		}
+	}
+	result.Failed = len(failed)
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+
+	if !commit {
+		return nil
+	}
+	if result.Failed > 0 {
+		return errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("%d of %d rows have errors; nothing was imported", result.Failed, result.Total))
+	}
+	if err := s.Repo.Apply(creates, updates); err != nil {
+		return err
+	}
+	result.Committed = true
+	return nil
+}
+
+// keepTimes は作成日時を引き継ぎ（新規で未指定なら now）、更新日時を既存のレコードに揃える
+func keepTimes(created, updated *time.Time, existingCreated, existingUpdated *time.Time, now time.Time) {
+	switch {
+	case existingCreated != nil:
+		*created, *updated = *existingCreated, *existingUpdated
+	case created.IsZero():
+		*created = now
+	}
+	if updated.IsZero() {
+		*updated = *created
+	}
+}
+
+// taskImport の自然キーはタイトル
+var taskImport = importSpec[model.Task]{
+	resource: "task",
+	parse:    parser.Task,
+	key:      func(m *model.Task) string { return m.Title },
+	refs: func(m *model.Task) []importRef {
+		if m.MemoryID == 0 {
+			return nil
+		}
+		return []importRef{{Field: "memory_id", Resource: "memory", ID: m.MemoryID}}
+	},
+	prepare: func(m, existing *model.Task, userID uint, now time.Time) {
+		m.ID, m.UserID, m.WorkspaceID = 0, int(userID), nil
+		if existing == nil {
+			keepTimes(&m.CreatedAt, &m.UpdatedAt, nil, nil, now)
+			return
+		}
+		m.ID, m.WorkspaceID = existing.ID, existing.WorkspaceID
+		keepTimes(&m.CreatedAt, &m.UpdatedAt, &existing.CreatedAt, &existing.UpdatedAt, now)
+	},
+	touch: func(m, existing *model.Task, userID uint, now time.Time) []interface{} {
+		m.UpdatedAt = now
+		return nil
+	},
+}
+
+// memoryImport の自然キーはタイトル
+var memoryImport = importSpec[model.Memory]{
+	resource: "memory",
+	parse:    parser.Memory,
+	key:      func(m *model.Memory) string { return m.Title },
+	refs:     func(m *model.Memory) []importRef { return nil },
+	prepare: func(m, existing *model.Memory, userID uint, now time.Time) {
+		m.ID, m.UserID, m.WorkspaceID = 0, int(userID), nil
+		if m.SourceType == "" {
+			m.SourceType = "book"
+		}
+		if m.ReadStatus == "" {
+			m.ReadStatus = "unread"
+		}
+		if existing == nil {
+			keepTimes(&m.CreatedAt, &m.UpdatedAt, nil, nil, now)
+			return
+		}
+		m.ID, m.WorkspaceID = existing.ID, existing.WorkspaceID
+		keepTimes(&m.CreatedAt, &m.UpdatedAt, &existing.CreatedAt, &existing.UpdatedAt, now)
+	},
+	touch: func(m, existing *model.Memory, userID uint, now time.Time) []interface{} {
+		m.UpdatedAt = now
+		return nil
+	},
+}
+
+// knowledgePatternImport の自然キーはタスクと暗黙知の組
+var knowledgePatternImport = importSpec[model.KnowledgePattern]{
+	resource: "knowledge_pattern",
+	parse:    parser.KnowledgePattern,
+	key: func(m *model.KnowledgePattern) string {
+		return strconv.Itoa(m.TaskID) + "\x00" + m.TacitKnowledge
+	},
+	refs: func(m *model.KnowledgePattern) []importRef {
+		return []importRef{{Field: "task_id", Resource: "task", ID: m.TaskID}}
+	},
+	prepare: func(m, existing *model.KnowledgePattern, userID uint, now time.Time) {
+		if existing == nil {
+			m.ID = uuid.New().String()
+			keepTimes(&m.CreatedAt, &m.UpdatedAt, nil, nil, now)
+			return
+		}
+		m.ID = existing.ID
+		keepTimes(&m.CreatedAt, &m.UpdatedAt, &existing.CreatedAt, &existing.UpdatedAt, now)
+	},
+	touch: func(m, existing *model.KnowledgePattern, userID uint, now time.Time) []interface{} {
+		m.UpdatedAt = now
+		return nil
+	},
+}
+
+// quantificationLabelImport の自然キーは分野と元の語句
+// 取り込んだラベルは下書きになり（検証は API で行う）、更新した場合は版を上げて改訂履歴を残す
+var quantificationLabelImport = importSpec[model.QuantificationLabel]{
+	resource: "quantification_label",
+	parse:    parser.QuantificationLabel,
+	key: func(m *model.QuantificationLabel) string {
+		return m.Domain + "\x00" + m.OriginalText
+	},
+	refs: func(m *model.QuantificationLabel) []importRef {
+		if m.TaskID == 0 {
+			return nil
+		}
+		return []importRef{{Field: "task_id", Resource: "task", ID: m.TaskID}}
+	},
+	validate: func(existing *model.QuantificationLabel) error {
+		if existing.Status == labeling.StatusSubmitted {
+			return stderrors.New("label is under verification")
+		}
+		return nil
+	},
+	prepare: func(m, existing *model.QuantificationLabel, userID uint, now time.Time) {
+		actor := strconv.FormatUint(uint64(userID), 10)
+		m.UserID = int(userID)
+		if m.Source == "" {
+			m.Source = "import"
+		}
+		if existing == nil {
+			m.ID = uuid.New().String()
+			m.Status, m.Validated, m.Version = labeling.StatusDraft, false, 1
+			m.CreatedBy, m.UpdatedBy = actor, actor
+			keepTimes(&m.CreatedAt, &m.UpdatedAt, nil, nil, now)
+			return
+		}
+		// 検証・履歴の項目は既存のラベルのものを引き継ぐ
+		m.ID, m.PublicVisibility, m.ImageURL = existing.ID, existing.PublicVisibility, existing.ImageURL
+		m.Status, m.Validated, m.ReviewRound = existing.Status, existing.Validated, existing.ReviewRound
+		m.Accuracy, m.Agreement = existing.Accuracy, existing.Agreement
+		m.VerificationCount, m.LastVerified = existing.VerificationCount, existing.LastVerified
+		m.Version, m.CreatedBy, m.UpdatedBy = existing.Version, existing.CreatedBy, existing.UpdatedBy
+		keepTimes(&m.CreatedAt, &m.UpdatedAt, &existing.CreatedAt, &existing.UpdatedAt, now)
+	},
+	touch: func(m, existing *model.QuantificationLabel, userID uint, now time.Time) []interface{} {
+		before, after := map[string]interface{}{}, map[string]interface{}{}
+		raw, _ := json.Marshal(existing)
+		_ = json.Unmarshal(raw, &before)
+		raw, _ = json.Marshal(m)
+		_ = json.Unmarshal(raw, &after)
+		changes := model.JSON{}
+		for field := range labelUpdatableFields {
+			if !reflect.DeepEqual(before[field], after[field]) {
+				changes[field] = map[string]interface{}{"old": before[field], "new": after[field]}
+			}
+		}
+		// 内容が変わったラベルは下書きに戻し、検証をやり直す
+		if m.Status != labeling.StatusDraft {
+			changes["status"] = map[string]interface{}{"old": m.Status, "new": labeling.StatusDraft}
+		}
+		if m.Validated {
+			changes["validated"] = map[string]interface{}{"old": true, "new": false}
+		}
+		m.Status = labeling.StatusDraft
+		m.Validated = false
+		m.Version = existing.Version + 1
+		m.UpdatedBy = strconv.FormatUint(uint64(userID), 10)
+		m.UpdatedAt = now
+		return []interface{}{&model.LabelRevision{
+			ID:        uuid.New().String(),
+			LabelID:   m.ID,
+			Version:   m.Version,
+			Changes:   changes,
+			Comment:   "import",
+			UserID:    m.UpdatedBy,
+			Timestamp: now,
+		}}
+	},
+}
@@ -9,18 +9,18 @@ type KnowledgePatternService struct {
   Repo repository.KnowledgePatternRepositoryInterface
 }
 
-func (s *KnowledgePatternService) CreateKnowledgePattern(knowledgePattern *model.KnowledgePattern) error {
-	return s.Repo.Create(knowledgePattern)
+func (s *KnowledgePatternService) CreateKnowledgePattern(userID uint, knowledgePattern *model.KnowledgePattern) error {
+	return s.Repo.Create(userID, knowledgePattern)
 }
-func (s *KnowledgePatternService) GetKnowledgePatternByID(id string) (*model.KnowledgePattern, error) {
-	return s.Repo.FindByID(id)
+func (s *KnowledgePatternService) GetKnowledgePatternByID(userID uint, id string) (*model.KnowledgePattern, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *KnowledgePatternService) ListKnowledgePatterns(userID uint) ([]model.KnowledgePattern, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *KnowledgePatternService) UpdateKnowledgePattern(id string, knowledgePattern *model.KnowledgePattern) error {
-	return s.Repo.Update(id, knowledgePattern)
+func (s *KnowledgePatternService) UpdateKnowledgePattern(userID uint, id string, knowledgePattern *model.KnowledgePattern) error {
+	return s.Repo.Update(userID, id, knowledgePattern)
 }
-func (s *KnowledgePatternService) DeleteKnowledgePattern(id string) error {
-	return s.Repo.Delete(id)
+func (s *KnowledgePatternService) DeleteKnowledgePattern(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+type LabelDatasetService struct {
+	Repo repository.LabelDatasetRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+func (s *LabelDatasetService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// labels は ids のラベルを読み込む。見つからない・参照できないラベルがあればエラーにする
+func (s *LabelDatasetService) labels(userID uint, ids []string) ([]model.QuantificationLabel, error) {
+	unique := make([]string, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	labels, err := s.Repo.FindLabels(userID, unique)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range labels {
+		delete(seen, l.ID)
+	}
+	if len(seen) > 0 {
+		missing := make([]string, 0, len(seen))
+		for id := range seen {
+			missing = append(missing, id)
+		}
+		sort.Strings(missing)
+		return nil, invalidLabel("labels not found or not accessible: " + strings.Join(missing, ", "))
+	}
+	return labels, nil
+}
+
+// labelCompleteness はラベルの値・単位・範囲などの項目のうち入力されたものの割合を返す
+func labelCompleteness(l model.QuantificationLabel) float64 {
+	filled := []bool{
+		l.Value != nil,
+		l.Unit != nil && *l.Unit != "",
+		l.MinRange != nil && l.MaxRange != nil,
+		l.TypicalValue != nil,
+		l.Precision != nil,
+		l.Confidence != nil,
+		l.NormalizedText != "",
+		l.Category != "",
+		l.Context != "",
+	}
+	n := 0
+	for _, f := range filled {
+		if f {
+			n++
+		}
+	}
+	return float64(n) / float64(len(filled))
+}
+
+// measure はデータセットのラベルと現在の提出の回の検証から統計情報と品質メトリクスを求めて dataset に設定する
+func (s *LabelDatasetService) measure(dataset *model.LabelDataset) error {
+	ids := make([]string, 0, len(dataset.Labels))
+	for _, l := range dataset.Labels {
+		ids = append(ids, l.ID)
+	}
+	verifications, err := s.Repo.FindVerifications(ids)
+	if err != nil {
+		return err
+	}
+	decisions := map[string]map[string]int{}
+	for _, v := range verifications {
+		if decisions[v.LabelID] == nil {
+			decisions[v.LabelID] = map[string]int{}
+		}
+		decisions[v.LabelID][v.Decision]++
+	}
+	qualities := make([]labeling.LabelQuality, 0, len(dataset.Labels))
+	for _, l := range dataset.Labels {
+		qualities = append(qualities, labeling.LabelQuality{
+			Category:     l.Category,
+			Validated:    l.Validated,
+			Accuracy:     l.Accuracy,
+			Completeness: labelCompleteness(l),
+			Decisions:    decisions[l.ID],
+		})
+	}
+	q := labeling.Quality(qualities)
+	dataset.TotalLabels = q.TotalLabels
+	dataset.VerifiedLabels = q.VerifiedLabels
+	dataset.AverageAccuracy = q.AverageAccuracy
+	dataset.Completeness = q.Completeness
+	dataset.Consistency = q.Consistency
+	dataset.Diversity = q.Diversity
+	dataset.Balance = q.Balance
+	return nil
+}
+
+func datasetNotFound(err error) error {
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.ErrResourceNotFound
+	}
+	return err
+}
+
+// CreateDataset はラベルを集めたデータセットを作成し、品質メトリクスを求める。actorID が所有者になる
+// ラベルは自分のもの、または検証済みで公開されたものを指定できる
+func (s *LabelDatasetService) CreateDataset(userID, actorID uint, req *model.DatasetRequest) (*model.LabelDataset, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, invalidLabel("name is required")
+	}
+	labels, err := s.labels(userID, req.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	dataset := &model.LabelDataset{
+		ID:          uuid.New().String(),
+		UserID:      int(actorID),
+		Name:        req.Name,
+		Description: req.Description,
+		Domain:      req.Domain,
+		License:     req.License,
+		Citation:    req.Citation,
+		CreatedBy:   strconv.FormatUint(uint64(actorID), 10),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Labels:      labels,
+	}
+	if err := s.measure(dataset); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.Create(userID, dataset); err != nil {
+		return nil, err
+	}
+	return dataset, nil
+}
+
+func (s *LabelDatasetService) GetDataset(userID uint, id string) (*model.LabelDataset, error) {
+	dataset, err := s.Repo.FindByID(userID, id)
+	return dataset, datasetNotFound(err)
+}
+
+func (s *LabelDatasetService) ListDatasets(userID uint) ([]model.LabelDataset, error) {
+	return s.Repo.FindAll(userID)
+}
+
+// UpdateDataset はデータセットの名前などを更新し、LabelIDs を指定した場合はラベルを置き換えて品質メトリクスを求め直す
+func (s *LabelDatasetService) UpdateDataset(userID uint, id string, req *model.DatasetRequest) (*model.LabelDataset, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, invalidLabel("name is required")
+	}
+	var labels []model.QuantificationLabel
+	if req.LabelIDs != nil {
+		var err error
+		if labels, err = s.labels(userID, req.LabelIDs); err != nil {
+			return nil, err
+		}
+	}
+	dataset, err := s.Repo.Modify(userID, id, func(d *model.LabelDataset) error {
+		d.Name = req.Name
+		d.Description = req.Description
+		d.Domain = req.Domain
+		d.License = req.License
+		d.Citation = req.Citation
+		d.UpdatedAt = s.now()
+		if req.LabelIDs != nil {
+			d.Labels = labels
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, datasetNotFound(err)
+	}
+	if err := s.measure(dataset); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.UpdateMetrics(dataset); err != nil {
+		return nil, err
+	}
+	return dataset, nil
+}
+
+func (s *LabelDatasetService) DeleteDataset(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
+}
+
+// datasetRecord はスナップショットの 1 行（1 ラベル）
+type datasetRecord struct {
+	ID                string         `json:"id"`
+	Text              string         `json:"text"`
+	NormalizedText    string         `json:"normalized_text"`
+	Category          string         `json:"category"`
+	Domain            string         `json:"domain"`
+	Context           string         `json:"context"`
+	Value             *float64       `json:"value"`
+	Unit              *string        `json:"unit"`
+	MinRange          *float64       `json:"min_range"`
+	MaxRange          *float64       `json:"max_range"`
+	TypicalValue      *float64       `json:"typical_value"`
+	Precision         *int           `json:"precision"`
+	Confidence        *float64       `json:"confidence"`
+	AbstractLevel     string         `json:"abstract_level"`
+	RelatedConcepts   datatypes.JSON `json:"related_concepts"`
+	Status            string         `json:"status"`
+	Validated         bool           `json:"validated"`
+	Accuracy          *float64       `json:"accuracy"`
+	Agreement         *float64       `json:"agreement"`
+	VerificationCount int            `json:"verification_count"`
+	LabelVersion      int            `json:"label_version"`
+}
+
+// CreateSnapshot はデータセットの現在のラベルを JSONL にした次の版のスナップショットを作成する
+// 品質メトリクスも求め直してデータセットとスナップショットに保存する
+func (s *LabelDatasetService) CreateSnapshot(userID, actorID uint, id string) (*model.LabelDatasetSnapshot, error) {
+	dataset, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return nil, datasetNotFound(err)
+	}
+	if len(dataset.Labels) == 0 {
+		return nil, errors.NewAppError(errors.BIZ_INVALID_STATE, errors.GetErrorMessage(errors.BIZ_INVALID_STATE), "dataset has no labels")
+	}
+	if err := s.measure(dataset); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, l := range dataset.Labels {
+		related := l.RelatedConcepts
+		if len(related) == 0 {
+			related = datatypes.JSON("null")
+		}
+		err := encoder.Encode(datasetRecord{
+			ID:                l.ID,
+			Text:              l.OriginalText,
+			NormalizedText:    l.NormalizedText,
+			Category:          l.Category,
+			Domain:            l.Domain,
+			Context:           l.Context,
+			Value:             l.Value,
+			Unit:              l.Unit,
+			MinRange:          l.MinRange,
+			MaxRange:          l.MaxRange,
+			TypicalValue:      l.TypicalValue,
+			Precision:         l.Precision,
+			Confidence:        l.Confidence,
+			AbstractLevel:     l.AbstractLevel,
+			RelatedConcepts:   related,
+			Status:            l.Status,
+			Validated:         l.Validated,
+			Accuracy:          l.Accuracy,
+			Agreement:         l.Agreement,
+			VerificationCount: l.VerificationCount,
+			LabelVersion:      l.Version,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	snapshot := &model.LabelDatasetSnapshot{
+		ID:         uuid.New().String(),
+		DatasetID:  dataset.ID,
+		LabelCount: len(dataset.Labels),
+		Metrics: model.JSON{
+			"total_labels":     dataset.TotalLabels,
+			"verified_labels":  dataset.VerifiedLabels,
+			"average_accuracy": dataset.AverageAccuracy,
+			"completeness":     dataset.Completeness,
+			"consistency":      dataset.Consistency,
+			"diversity":        dataset.Diversity,
+			"balance":          dataset.Balance,
+		},
+		Checksum:  hex.EncodeToString(sum[:]),
+		Content:   buf.String(),
+		CreatedBy: strconv.FormatUint(uint64(actorID), 10),
+		CreatedAt: s.now(),
+	}
+	if err := s.Repo.CreateSnapshot(userID, dataset, snapshot); err != nil {
+		return nil, datasetNotFound(err)
+	}
+	return snapshot, nil
+}
+
+func (s *LabelDatasetService) ListSnapshots(userID uint, id string) ([]model.LabelDatasetSnapshot, error) {
+	return s.Repo.FindSnapshots(userID, id)
+}
+
+// GetSnapshot は版 version のスナップショット（JSONL の内容を含む）を返す
+func (s *LabelDatasetService) GetSnapshot(userID uint, id, version string) (*model.LabelDatasetSnapshot, error) {
+	v, err := strconv.Atoi(version)
+	if err != nil || v <= 0 {
+		return nil, invalidLabel("version must be a positive integer")
+	}
+	snapshot, err := s.Repo.FindSnapshot(userID, id, v)
+	return snapshot, datasetNotFound(err)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	stderrors "errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+// LabelOntologyService はラベルの同義語・上位下位の関係（オントロジー）を扱う
+type LabelOntologyService struct {
+	Repo repository.LabelOntologyRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+func (s *LabelOntologyService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func ontologyRelation(r model.LabelRelation) labeling.Relation {
+	return labeling.Relation{
+		Source:        r.SourceID,
+		Target:        r.TargetID,
+		Type:          r.RelationType,
+		Strength:      r.Strength,
+		Bidirectional: r.Bidirectional,
+	}
+}
+
+func newOntology(relations []model.LabelRelation) *labeling.Ontology {
+	list := make([]labeling.Relation, 0, len(relations))
+	for _, r := range relations {
+		list = append(list, ontologyRelation(r))
+	}
+	return labeling.NewOntology(list)
+}
+
+// relationError は整合性の確認のエラーを AppError にする
+// 同じ関係がある場合は 409、循環・同義語との矛盾は制約違反、それ以外は入力エラー
+func relationError(err error) error {
+	code := errors.VAL_INVALID_INPUT
+	switch {
+	case stderrors.Is(err, labeling.ErrDuplicateRelation):
+		code = errors.RES_ALREADY_EXISTS
+	case stderrors.Is(err, labeling.ErrHypernymCycle), stderrors.Is(err, labeling.ErrSynonymConflict):
+		code = errors.VAL_CONSTRAINT_FAILED
+	}
+	return errors.NewAppError(code, errors.GetErrorMessage(code), err.Error())
+}
+
+// unitInterval は 0〜1 の値を返す。nil の場合は 1
+func unitInterval(name string, v *float64) (float64, error) {
+	if v == nil {
+		return 1, nil
+	}
+	if math.IsNaN(*v) || *v < 0 || *v > 1 {
+		return 0, invalidLabel(name + " must be between 0 and 1")
+	}
+	return *v, nil
+}
+
+// CreateRelation はラベル間の関係を作成する。actorID が関係の作成者になる
+// 同義語は双方向、上位・下位の関係は単方向で、上位下位の関係が循環する場合や、
+// 同義語の間に上位下位の関係ができる場合は作成しない
+func (s *LabelOntologyService) CreateRelation(userID, actorID uint, req *model.CreateRelationRequest) (*model.LabelRelation, error) {
+	strength, err := unitInterval("strength", req.Strength)
+	if err != nil {
+		return nil, err
+	}
+	confidence, err := unitInterval("confidence", req.Confidence)
+	if err != nil {
+		return nil, err
+	}
+	bidirectional := req.RelationType == labeling.RelationSynonym
+	if req.Bidirectional != nil {
+		bidirectional = *req.Bidirectional
+	}
+	relation := &model.LabelRelation{
+		ID:            uuid.New().String(),
+		UserID:        int(actorID),
+		SourceID:      req.SourceID,
+		TargetID:      req.TargetID,
+		RelationType:  req.RelationType,
+		Strength:      strength,
+		Bidirectional: bidirectional,
+		Context:       req.Context,
+		Confidence:    confidence,
+		CreatedAt:     s.now(),
+	}
+	r := ontologyRelation(*relation)
+	// 既存の関係によらない誤りは読み込む前に返す
+	if err := labeling.NewOntology(nil).Check(r); err != nil {
+		return nil, relationError(err)
+	}
+	err = s.Repo.CreateRelation(userID, relation, func(relations []model.LabelRelation) error {
+		if err := newOntology(relations).Check(r); err != nil {
+			return relationError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return relation, nil
+}
+
+func (s *LabelOntologyService) DeleteRelation(userID uint, id string) error {
+	return s.Repo.DeleteRelation(userID, id)
+}
+
+// visibleLabel は userID がラベルを参照できることを確認する
+func (s *LabelOntologyService) visibleLabel(userID uint, id string) error {
+	labels, err := s.Repo.FindLabels(userID, []string{id})
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		return errors.ErrResourceNotFound
+	}
+	return nil
+}
+
+// ListRelations はラベルを起点または終点とする関係を返す
+func (s *LabelOntologyService) ListRelations(userID uint, id string) ([]model.LabelRelation, error) {
+	if err := s.visibleLabel(userID, id); err != nil {
+		return nil, err
+	}
+	return s.Repo.FindLabelRelations(id)
+}
+
+// visibleNodes は見つかったラベルのうち userID が参照できるものを、語句を付けて順序を保って返す
+func (s *LabelOntologyService) visibleNodes(userID uint, found []labeling.Node) ([]model.OntologyNode, map[string]model.QuantificationLabel, error) {
+	ids := make([]string, 0, len(found))
+	for _, n := range found {
+		ids = append(ids, n.ID)
+	}
+	labels, err := s.Repo.FindLabels(userID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := map[string]model.QuantificationLabel{}
+	for _, l := range labels {
+		byID[l.ID] = l
+	}
+	nodes := []model.OntologyNode{}
+	for _, n := range found {
+		l, ok := byID[n.ID]
+		if !ok {
+			continue
+		}
+		nodes = append(nodes, model.OntologyNode{LabelID: n.ID, Text: l.OriginalText, Depth: n.Depth, Strength: n.Strength})
+	}
+	return nodes, byID, nil
+}
+
+func (s *LabelOntologyService) hierarchy(userID uint, id string, walk func(o *labeling.Ontology) []labeling.Node) ([]model.OntologyNode, error) {
+	if err := s.visibleLabel(userID, id); err != nil {
+		return nil, err
+	}
+	relations, err := s.Repo.FindRelations()
+	if err != nil {
+		return nil, err
+	}
+	nodes, _, err := s.visibleNodes(userID, walk(newOntology(relations)))
+	return nodes, err
+}
+
+// Ancestors はラベルの上位概念を近い順に返す（maxDepth が 0 以下なら無制限）
+// 同義語の上位概念も含み、参照できないラベルは除く
+func (s *LabelOntologyService) Ancestors(userID uint, id string, maxDepth int) ([]model.OntologyNode, error) {
+	return s.hierarchy(userID, id, func(o *labeling.Ontology) []labeling.Node { return o.Ancestors(id, maxDepth) })
+}
+
+// Descendants はラベルの下位概念を近い順に返す（maxDepth が 0 以下なら無制限）
+func (s *LabelOntologyService) Descendants(userID uint, id string, maxDepth int) ([]model.OntologyNode, error) {
+	return s.hierarchy(userID, id, func(o *labeling.Ontology) []labeling.Node { return o.Descendants(id, maxDepth) })
+}
+
+// ExpandQuery は語句を正規化し、正規化した語句が一致するラベルとその同義語のラベルの語句に展開する
+// Terms は正規化した語句を先頭に、ラベルの元の語句と正規化した語句を重複なく並べる
+func (s *LabelOntologyService) ExpandQuery(userID uint, text string) (*model.QueryExpansion, error) {
+	normalized := labeling.Normalize(text)
+	if normalized == "" {
+		return nil, errors.NewAppError(errors.VAL_MISSING_FIELD, errors.GetErrorMessage(errors.VAL_MISSING_FIELD), "text is required")
+	}
+	seeds, err := s.Repo.FindLabelsByText(userID, normalized)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]labeling.Node, 0, len(seeds))
+	seen := map[string]bool{}
+	for _, l := range seeds {
+		found = append(found, labeling.Node{ID: l.ID, Strength: 1})
+		seen[l.ID] = true
+	}
+	if len(seeds) > 0 {
+		relations, err := s.Repo.FindRelations()
+		if err != nil {
+			return nil, err
+		}
+		o := newOntology(relations)
+		var synonyms []labeling.Node
+		for _, l := range seeds {
+			for _, n := range o.Synonyms(l.ID) {
+				if !seen[n.ID] {
+					seen[n.ID] = true
+					synonyms = append(synonyms, n)
+				}
+			}
+		}
+		found = append(found, synonyms...)
+	}
+	nodes, byID, err := s.visibleNodes(userID, found)
+	if err != nil {
+		return nil, err
+	}
+	terms := []string{normalized}
+	known := map[string]bool{normalized: true}
+	for _, n := range nodes {
+		for _, term := range []string{byID[n.LabelID].OriginalText, byID[n.LabelID].NormalizedText} {
+			if term = strings.TrimSpace(term); term != "" && !known[term] {
+				known[term] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+	return &model.QueryExpansion{Text: text, Terms: terms, Labels: nodes}, nil
+}
+
+// NormalizeLabels は自分のラベルの正規化した語句を Normalize で揃え、変更した件数を返す
+// 正規化した語句が無いラベルは元の語句から作る
+func (s *LabelOntologyService) NormalizeLabels(userID uint) (int, error) {
+	return s.Repo.NormalizeTexts(userID, func(label *model.QuantificationLabel) string {
+		return normalizedText(label.NormalizedText, label.OriginalText)
+	})
+}
+
+// normalizedText は正規化した語句を返す。normalized が空の場合は original から作る
+func normalizedText(normalized, original string) string {
+	if strings.TrimSpace(normalized) == "" {
+		return labeling.Normalize(original)
+	}
+	return labeling.Normalize(normalized)
+}
@@ -9,18 +9,18 @@ type LanguageOptimizationService struct {
   Repo repository.LanguageOptimizationRepositoryInterface
 }
 
-func (s *LanguageOptimizationService) CreateLanguageOptimization(languageOptimization *model.LanguageOptimization) error {
-	return s.Repo.Create(languageOptimization)
+func (s *LanguageOptimizationService) CreateLanguageOptimization(userID uint, languageOptimization *model.LanguageOptimization) error {
+	return s.Repo.Create(userID, languageOptimization)
 }
-func (s *LanguageOptimizationService) GetLanguageOptimizationByID(id string) (*model.LanguageOptimization, error) {
-	return s.Repo.FindByID(id)
+func (s *LanguageOptimizationService) GetLanguageOptimizationByID(userID uint, id string) (*model.LanguageOptimization, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *LanguageOptimizationService) ListLanguageOptimizations(userID uint) ([]model.LanguageOptimization, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *LanguageOptimizationService) UpdateLanguageOptimization(id string, languageOptimization *model.LanguageOptimization) error {
-	return s.Repo.Update(id, languageOptimization)
+func (s *LanguageOptimizationService) UpdateLanguageOptimization(userID uint, id string, languageOptimization *model.LanguageOptimization) error {
+	return s.Repo.Update(userID, id, languageOptimization)
 }
-func (s *LanguageOptimizationService) DeleteLanguageOptimization(id string) error {
-	return s.Repo.Delete(id)
+func (s *LanguageOptimizationService) DeleteLanguageOptimization(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
@@ -10,12 +10,13 @@ type MemoryService struct {
 	ContextRepo repository.MemoryContextRepositoryInterface
 }
 
-func (s *MemoryService) CreateMemory(memory *model.Memory) error {
+func (s *MemoryService) CreateMemory(userID uint, memory *model.Memory) error {
+	memory.UserID = int(userID)
 	return s.Repo.Create(memory)
 }
 
-func (s *MemoryService) GetMemoryByID(id string) (*model.Memory, error) {
-	return s.Repo.FindByID(id)
+func (s *MemoryService) GetMemoryByID(userID uint, id string) (*model.Memory, error) {
+	return s.Repo.FindByID(userID, id)
 }
 
 func (s *MemoryService) ListMemories(userID uint) ([]model.Memory, error) {
@@ -26,12 +27,12 @@ func (s *MemoryService) ListMemoriesPager(userID uint, perPage int, offset int)
   return s.Repo.ListMemoriesPager(userID, offset, perPage)
 }
 
-func (s *MemoryService) UpdateMemory(id string, memory *model.Memory) error {
-	return s.Repo.Update(id, memory)
+func (s *MemoryService) UpdateMemory(userID uint, id string, memory *model.Memory) error {
+	return s.Repo.Update(userID, id, memory)
 }
 
-func (s *MemoryService) DeleteMemory(id string) error {
-	return s.Repo.Delete(id)
+func (s *MemoryService) DeleteMemory(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
 
 func (s *MemoryService) FindMemoryContextsByCode(code string, contexts *[]model.MemoryContext) error {
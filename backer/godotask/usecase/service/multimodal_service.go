@@ -0,0 +1,527 @@
+package service
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/godotask/domain/imaging"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+const (
+	// DefaultSimilarImageDistance は類似画像とみなす知覚ハッシュのハミング距離の既定値
+	DefaultSimilarImageDistance = 10
+	// AttachmentPath は添付の取得パス（末尾に添付 ID を付ける）
+	AttachmentPath = "/api/attachment/"
+)
+
+type MultimodalService struct {
+	Repo        repository.MultimodalRepositoryInterface
+	Attachments *AttachmentService
+	// References は校正の基準物のカタログ
+	References repository.VisualMetaphorRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+// ImageUpload はタスクにアップロードされた画像
+type ImageUpload struct {
+	TaskID      uint
+	FileName    string
+	ContentType string
+	Text        string
+	// Session は撮影セッション。同じセッションの画像は校正を共有する
+	Session string
+	Body    io.Reader
+}
+
+// CalibrationRequest は画像上の基準物に引いた線分 (X, Y)-(X+Width, Y+Height) による校正の指定
+// 基準物はカタログ（ReferenceObject と Dimension）か、既知の長さ（KnownLength と標準不確かさ KnownUncertainty、mm）で指定する
+type CalibrationRequest struct {
+	ReferenceObject  string  `json:"reference_object"`
+	Dimension        string  `json:"dimension"`
+	KnownLength      float64 `json:"known_length"`
+	KnownUncertainty float64 `json:"known_uncertainty"`
+	X                float64 `json:"x"`
+	Y                float64 `json:"y"`
+	Width            float64 `json:"width"`
+	Height           float64 `json:"height"`
+	// PixelUncertainty は線分の長さの標準不確かさ（px）。0 の場合は imaging.DefaultPixelUncertainty
+	PixelUncertainty float64 `json:"pixel_uncertainty"`
+	// Session は校正を共有する撮影セッション。空の場合は画像のセッション
+	Session string `json:"session"`
+}
+
+// SimilarImage は知覚ハッシュが近い画像とそのハミング距離
+type SimilarImage struct {
+	model.MultimodalData
+	Distance int `json:"distance"`
+}
+
+func (s *MultimodalService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// UploadImage は画像を解析して元画像とサムネイルをタスクの添付として保存し、
+// 寸法・EXIF・知覚ハッシュを持つ MultimodalData を作成する
+func (s *MultimodalService) UploadImage(userID, actorID uint, in *ImageUpload) (*model.MultimodalData, error) {
+	if in.TaskID == 0 {
+		return nil, errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"task_id is required",
+		)
+	}
+	data, err := io.ReadAll(io.LimitReader(in.Body, MaxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAttachmentSize {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("image must be at most %d bytes", MaxAttachmentSize),
+		)
+	}
+	analysis, err := imaging.Analyze(data)
+	if err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"failed to process image: "+err.Error(),
+		)
+	}
+	thumbs, err := imaging.Thumbnails(analysis, imaging.ThumbnailSizes)
+	if err != nil {
+		return nil, err
+	}
+	return s.storeImage(userID, actorID, in, data, analysis, thumbs)
+}
+
+// storeImage は元画像とサムネイルを添付として保存して MultimodalData を作成する
+// 途中で失敗した場合は保存済みの添付を削除する
+func (s *MultimodalService) storeImage(userID, actorID uint, in *ImageUpload, data []byte, analysis *imaging.Analysis, thumbs []imaging.Thumbnail) (*model.MultimodalData, error) {
+	taskID := strconv.FormatUint(uint64(in.TaskID), 10)
+	var stored []string
+	cleanup := func() {
+		for _, id := range stored {
+			_ = s.Attachments.DeleteAttachment(userID, id)
+		}
+	}
+
+	image, err := s.Attachments.Upload(userID, actorID, &AttachmentUpload{
+		ResourceType: "task",
+		ResourceID:   taskID,
+		FileName:     in.FileName,
+		ContentType:  analysis.ContentType,
+		Description:  "multimodal image",
+		Body:         bytes.NewReader(data),
+	})
+	if err != nil {
+		return nil, err
+	}
+	stored = append(stored, image.ID)
+
+	base := strings.TrimSuffix(sanitizeFileName(in.FileName), filepath.Ext(in.FileName))
+	thumbnails := model.JSON{}
+	for _, t := range thumbs {
+		ext := ".jpg"
+		if t.ContentType == "image/png" {
+			ext = ".png"
+		}
+		thumb, err := s.Attachments.Upload(userID, actorID, &AttachmentUpload{
+			ResourceType: "task",
+			ResourceID:   taskID,
+			FileName:     fmt.Sprintf("%s_%d%s", base, t.Size, ext),
+			ContentType:  t.ContentType,
+			Description:  fmt.Sprintf("thumbnail %dpx", t.Size),
+			Body:         bytes.NewReader(t.Data),
+		})
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		stored = append(stored, thumb.ID)
+		thumbnails[strconv.Itoa(t.Size)] = map[string]interface{}{
+			"attachment_id": thumb.ID,
+			"url":           AttachmentPath + thumb.ID,
+			"width":         t.Width,
+			"height":        t.Height,
+		}
+	}
+
+	var metadata model.JSON
+	if analysis.EXIF != nil {
+		metadata = model.JSON(analysis.EXIF)
+	}
+	now := s.now()
+	record := &model.MultimodalData{
+		ID:                uuid.New().String(),
+		UserID:            actorID,
+		TaskID:            in.TaskID,
+		Text:              in.Text,
+		CaptureSession:    strings.TrimSpace(in.Session),
+		ImageURL:          AttachmentPath + image.ID,
+		ImageAttachmentID: image.ID,
+		ImageFormat:       analysis.Format,
+		ImageWidth:        analysis.Width,
+		ImageHeight:       analysis.Height,
+		ImageHash:         analysis.Hash,
+		ImageMetadata:     metadata,
+		Thumbnails:        thumbnails,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.Repo.Create(userID, record); err != nil {
+		cleanup()
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *MultimodalService) GetMultimodalData(userID uint, id string) (*model.MultimodalData, error) {
+	return s.Repo.FindByID(userID, id)
+}
+
+func (s *MultimodalService) ListMultimodalData(userID uint, taskID int) ([]model.MultimodalData, error) {
+	return s.Repo.FindByTask(userID, taskID)
+}
+
+// DeleteMultimodalData はデータとアノテーションを削除し、元画像とサムネイルの添付も削除する
+func (s *MultimodalService) DeleteMultimodalData(userID uint, id string) error {
+	data, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.Delete(userID, id); err != nil {
+		return err
+	}
+	for _, attachmentID := range imageAttachmentIDs(data) {
+		// 削除済みの添付と、他のユーザーがアップロードした添付（タスクの添付として残る）は無視する
+		err := s.Attachments.DeleteAttachment(userID, attachmentID)
+		if err != nil && !stderrors.Is(err, errors.ErrResourceNotFound) && !stderrors.Is(err, errors.ErrResourceAccessDenied) {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindSimilarImages は参照できる画像のうち知覚ハッシュのハミング距離が maxDistance 以下のものを近い順に返す
+func (s *MultimodalService) FindSimilarImages(userID uint, id string, maxDistance int) ([]SimilarImage, error) {
+	if maxDistance <= 0 {
+		maxDistance = DefaultSimilarImageDistance
+	}
+	data, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := imaging.ParseHash(data.ImageHash)
+	if err != nil {
+		return nil, errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			"multimodal data has no image hash",
+		)
+	}
+	candidates, err := s.Repo.FindImages(userID)
+	if err != nil {
+		return nil, err
+	}
+	similar := []SimilarImage{}
+	for _, c := range candidates {
+		h, err := imaging.ParseHash(c.ImageHash)
+		if err != nil || c.ID == data.ID {
+			continue
+		}
+		if d := imaging.HammingDistance(hash, h); d <= maxDistance {
+			similar = append(similar, SimilarImage{MultimodalData: c, Distance: d})
+		}
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Distance < similar[j].Distance })
+	return similar, nil
+}
+
+// AddAnnotation は画像 imageID にアノテーションを追加する
+func (s *MultimodalService) AddAnnotation(userID, actorID uint, imageID string, annotation *model.ImageAnnotation) error {
+	data, err := s.Repo.FindByID(userID, imageID)
+	if err != nil {
+		return err
+	}
+	calibration, err := s.Repo.FindEffectiveCalibration(userID, data)
+	if err != nil {
+		return err
+	}
+	if err := prepareAnnotation(data, calibration, annotation); err != nil {
+		return err
+	}
+	now := s.now()
+	annotation.ID = uuid.New().String()
+	annotation.ImageID = data.ID
+	annotation.UserID = actorID
+	annotation.CreatedBy = strconv.FormatUint(uint64(actorID), 10)
+	annotation.CreatedAt = now
+	annotation.UpdatedAt = now
+	return s.Repo.CreateAnnotation(userID, annotation)
+}
+
+func (s *MultimodalService) ListAnnotations(userID uint, imageID string) ([]model.ImageAnnotation, error) {
+	return s.Repo.FindAnnotations(userID, imageID)
+}
+
+// UpdateAnnotation はアノテーションの形状・ラベル・値を置き換え、更新後のアノテーションを返す
+func (s *MultimodalService) UpdateAnnotation(userID uint, id string, annotation *model.ImageAnnotation) (*model.ImageAnnotation, error) {
+	existing, err := s.Repo.FindAnnotation(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.Repo.FindByID(userID, existing.ImageID)
+	if err != nil {
+		return nil, err
+	}
+	calibration, err := s.Repo.FindEffectiveCalibration(userID, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepareAnnotation(data, calibration, annotation); err != nil {
+		return nil, err
+	}
+	annotation.UpdatedAt = s.now()
+	if err := s.Repo.UpdateAnnotation(userID, id, annotation); err != nil {
+		return nil, err
+	}
+	annotation.ID = existing.ID
+	annotation.ImageID = existing.ImageID
+	annotation.TaskID = existing.TaskID
+	annotation.UserID = existing.UserID
+	annotation.CreatedBy = existing.CreatedBy
+	annotation.CreatedAt = existing.CreatedAt
+	return annotation, nil
+}
+
+func (s *MultimodalService) DeleteAnnotation(userID uint, id string) error {
+	return s.Repo.DeleteAnnotation(userID, id)
+}
+
+// CalibrateImage は画像上の基準物から縮尺（mm/px）とその不確かさを求めて校正を記録する
+// 以降に追加・更新するアノテーションのうち単位を指定しない測定・矩形は mm / mm2 に換算される（既存のアノテーションは変わらない）
+func (s *MultimodalService) CalibrateImage(userID, actorID uint, imageID string, req *CalibrationRequest) (*model.UserCalibration, error) {
+	data, err := s.Repo.FindByID(userID, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if data.ImageWidth <= 0 || data.ImageHeight <= 0 {
+		return nil, errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			"multimodal data has no image to calibrate",
+		)
+	}
+	invalid := func(detail string) error {
+		return errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), detail)
+	}
+	segment := imaging.Shape{Type: imaging.AnnotationMeasurement, X: req.X, Y: req.Y, Width: req.Width, Height: req.Height}
+	if err := imaging.ValidateShape(segment, data.ImageWidth, data.ImageHeight); err != nil {
+		return nil, invalid(err.Error())
+	}
+
+	knownLength, knownRel := req.KnownLength, 0.0
+	dimension := req.Dimension
+	if req.ReferenceObject != "" {
+		if req.KnownLength != 0 {
+			return nil, invalid("specify either reference_object or known_length")
+		}
+		reference, err := s.References.FindByID(req.ReferenceObject)
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrResourceNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if dimension == "" {
+			dimension = "width"
+		}
+		switch dimension {
+		case "width":
+			knownLength = reference.Width
+		case "height":
+			knownLength = reference.Height
+		case "depth":
+			knownLength = reference.Depth
+		default:
+			return nil, invalid(fmt.Sprintf("unknown dimension %q (width, height or depth)", dimension))
+		}
+		if knownLength <= 0 {
+			return nil, invalid(fmt.Sprintf("reference object %s has no %s", reference.ID, dimension))
+		}
+		knownRel = imaging.VariabilityUncertainty(reference.MinVariability, reference.MaxVariability)
+	} else {
+		if req.KnownLength <= 0 {
+			return nil, errors.NewAppError(
+				errors.VAL_MISSING_FIELD,
+				errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+				"reference_object or a positive known_length is required",
+			)
+		}
+		knownRel = req.KnownUncertainty / req.KnownLength
+	}
+	pixelUncertainty := req.PixelUncertainty
+	if pixelUncertainty == 0 {
+		pixelUncertainty = imaging.DefaultPixelUncertainty
+	}
+	pixelLength, _, _ := imaging.PixelMeasure(segment)
+	scale, err := imaging.Calibrate(pixelLength, knownLength, knownRel, pixelUncertainty)
+	if err != nil {
+		return nil, invalid(err.Error())
+	}
+
+	session := strings.TrimSpace(req.Session)
+	if session == "" {
+		session = data.CaptureSession
+	}
+	now := s.now()
+	calibration := &model.UserCalibration{
+		ID:               uuid.New().String(),
+		UserID:           actorID,
+		ImageID:          data.ID,
+		Session:          session,
+		ReferenceObject:  req.ReferenceObject,
+		Dimension:        dimension,
+		KnownLength:      knownLength,
+		PixelLength:      pixelLength,
+		Scale:            scale.MillimetersPerPixel,
+		ScaleUncertainty: scale.RelativeUncertainty,
+		PixelUncertainty: scale.PixelUncertainty,
+		Measurements: model.JSON{
+			"x":      req.X,
+			"y":      req.Y,
+			"width":  req.Width,
+			"height": req.Height,
+		},
+		ImageURL:   data.ImageURL,
+		Confidence: imaging.Confidence(scale.RelativeUncertainty),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.Repo.CreateCalibration(userID, calibration); err != nil {
+		return nil, err
+	}
+	return calibration, nil
+}
+
+// ListCalibrations は画像に適用される校正（無ければ nil）と、画像上で行った校正を返す
+func (s *MultimodalService) ListCalibrations(userID uint, imageID string) (*model.UserCalibration, []model.UserCalibration, error) {
+	data, err := s.Repo.FindByID(userID, imageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	effective, err := s.Repo.FindEffectiveCalibration(userID, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	calibrations, err := s.Repo.FindCalibrations(userID, imageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return effective, calibrations, nil
+}
+
+func (s *MultimodalService) DeleteCalibration(userID uint, id string) error {
+	return s.Repo.DeleteCalibration(userID, id)
+}
+
+// prepareAnnotation は形状が画像内にあるかを確認し、単位が指定されていない測定・矩形に値を設定する
+// 校正がある場合は mm / mm2 に換算して標準不確かさと信頼度を伝播し、無い場合は画像上の大きさ（px / px2）にする
+func prepareAnnotation(data *model.MultimodalData, calibration *model.UserCalibration, a *model.ImageAnnotation) error {
+	if data.ImageWidth <= 0 || data.ImageHeight <= 0 {
+		return errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			"multimodal data has no image to annotate",
+		)
+	}
+	invalid := func(detail string) error {
+		return errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), detail)
+	}
+	shape := imaging.Shape{Type: a.Type, X: a.X, Y: a.Y, Width: a.Width, Height: a.Height}
+	if err := imaging.ValidateShape(shape, data.ImageWidth, data.ImageHeight); err != nil {
+		return invalid(err.Error())
+	}
+	if a.Type == imaging.AnnotationText && strings.TrimSpace(a.Label) == "" {
+		return invalid("text annotation requires a label")
+	}
+	if math.IsNaN(a.Confidence) || a.Confidence < 0 || a.Confidence > 1 {
+		return invalid("confidence must be between 0 and 1")
+	}
+	if math.IsNaN(a.Uncertainty) || math.IsInf(a.Uncertainty, 0) || a.Uncertainty < 0 {
+		return invalid("uncertainty must be a non-negative number")
+	}
+	// 校正の適用はサーバーが決める
+	a.CalibrationID = ""
+
+	pixelValue, pixelUnit, ok := imaging.PixelMeasure(shape)
+	if !ok {
+		return nil
+	}
+	if a.Unit == imaging.UnitMillimeter || a.Unit == imaging.UnitSquareMillimeter || (a.Unit == "" && calibration != nil) {
+		if calibration == nil {
+			return errors.NewAppError(
+				errors.BIZ_INVALID_STATE,
+				errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+				"image has no calibration to convert to "+a.Unit,
+			)
+		}
+		scale := imaging.Scale{
+			MillimetersPerPixel: calibration.Scale,
+			RelativeUncertainty: calibration.ScaleUncertainty,
+			PixelUncertainty:    calibration.PixelUncertainty,
+		}
+		value, uncertainty, unit, _ := scale.Convert(shape)
+		if a.Unit != "" && a.Unit != unit {
+			return invalid(fmt.Sprintf("%s is measured in %s", a.Type, unit))
+		}
+		a.Value = math.Round(value*1000) / 1000
+		a.Uncertainty = math.Round(uncertainty*1000) / 1000
+		a.Unit = unit
+		a.Confidence = imaging.Confidence(uncertainty / value)
+		a.CalibrationID = calibration.ID
+		return nil
+	}
+	if a.Unit == "" || a.Unit == pixelUnit {
+		a.Value = math.Round(pixelValue*1000) / 1000
+		a.Unit = pixelUnit
+		a.Uncertainty = 0
+	}
+	return nil
+}
+
+// imageAttachmentIDs は元画像とサムネイルの添付 ID を返す
+func imageAttachmentIDs(data *model.MultimodalData) []string {
+	var ids []string
+	if data.ImageAttachmentID != "" {
+		ids = append(ids, data.ImageAttachmentID)
+	}
+	for _, t := range data.Thumbnails {
+		if m, ok := t.(map[string]interface{}); ok {
+			if id, ok := m["attachment_id"].(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	stderrors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/godotask/domain/expression"
+	"github.com/godotask/domain/optimization"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+type OptimizationModelService struct {
+	Repo repository.OptimizationModelRepositoryInterface
+	// Optimizations は実行結果を ProcessOptimization として記録する
+	Optimizations repository.ProcessOptimizationRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+func (s *OptimizationModelService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *OptimizationModelService) CreateOptimizationModel(optimizationModel *model.OptimizationModel) error {
+	if optimizationModel.Name == "" {
+		return errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"name is required",
+		)
+	}
+	if err := validateOptimizationModel(optimizationModel); err != nil {
+		return err
+	}
+	if optimizationModel.ID == "" {
+		optimizationModel.ID = uuid.New().String()
+	}
+	return s.Repo.Create(optimizationModel)
+}
+func (s *OptimizationModelService) GetOptimizationModelByID(id string) (*model.OptimizationModel, error) {
+	return s.Repo.FindByID(id)
+}
+func (s *OptimizationModelService) ListOptimizationModels() ([]model.OptimizationModel, error) {
+	return s.Repo.FindAll()
+}
+func (s *OptimizationModelService) UpdateOptimizationModel(id string, optimizationModel *model.OptimizationModel) error {
+	if err := validateOptimizationModel(optimizationModel); err != nil {
+		return err
+	}
+	return s.Repo.Update(id, optimizationModel)
+}
+func (s *OptimizationModelService) DeleteOptimizationModel(id string) error {
+	return s.Repo.Delete(id)
+}
+
+// validateOptimizationModel は objective_function / constraints / parameters の書式を検査する
+func validateOptimizationModel(m *model.OptimizationModel) error {
+	if err := optimization.Validate(m.ObjectiveFunction, m.Constraints, m.Parameters); err != nil {
+		return errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			err.Error(),
+		)
+	}
+	return nil
+}
+
+// RunOptimizationModel はモデルを指定の手法で実行し、結果をタスクの ProcessOptimization として記録する
+// Improvement は初期点に対する目的関数値（state の objective）の改善率（%）。actorID は実行したユーザー
+func (s *OptimizationModelService) RunOptimizationModel(userID, actorID uint, id string, req *model.OptimizationRunRequest) (*model.ProcessOptimization, error) {
+	m, err := s.Repo.FindByID(id)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := func(err error) error {
+		code := errors.VAL_INVALID_INPUT
+		if expression.IsFormatError(err) {
+			code = errors.VAL_INVALID_FORMAT
+		}
+		return errors.NewAppError(code, errors.GetErrorMessage(code), err.Error())
+	}
+	spec, err := optimization.ParseSpec(m.ObjectiveFunction, m.Constraints, m.Parameters)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	problem, err := spec.Bind(req.Inputs)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	start := problem.Start(req.Initial)
+	initial, err := problem.Evaluate(start)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	began := time.Now()
+	result, err := optimization.Solve(req.Method, problem.Variables(), start, problem.Penalized, optimization.Options{
+		MaxIterations: req.MaxIterations,
+		Tolerance:     req.Tolerance,
+		Seed:          req.Seed,
+	})
+	if err != nil {
+		return nil, invalid(err)
+	}
+	elapsed := time.Since(began).Seconds()
+	optimized, err := problem.Evaluate(result.X)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	processID := req.ProcessID
+	if processID == "" {
+		processID = m.ID
+	}
+	now := s.now()
+	record := &model.ProcessOptimization{
+		ID:                  uuid.New().String(),
+		TaskID:              req.TaskID,
+		ProcessID:           processID,
+		OptimizationModelID: m.ID,
+		OptimizationType:    req.OptimizationType,
+		InitialState:        stateJSON(problem.Point(start), initial, nil),
+		OptimizedState:      stateJSON(problem.Point(result.X), optimized, result),
+		Metric:              "objective",
+		MetricDirection:     optimization.DirectionMinimize,
+		Method:              result.Method,
+		Iterations:          result.Iterations,
+		ConvergenceTime:     elapsed,
+		CreatedBy:           actorID,
+		ValidationStatus:    model.ValidationPending,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	// 初期値が 0 の場合は相対的な改善率を計算できないため 0 とする
+	if v, err := optimization.Improvement(record.InitialState, record.OptimizedState, record.Metric, record.MetricDirection); err == nil {
+		record.Improvement = v
+	}
+	if err := s.Optimizations.Create(userID, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func stateJSON(point map[string]float64, e *optimization.Evaluation, r *optimization.Result) model.JSON {
+	state := model.JSON{
+		"parameters": point,
+		"objective":  e.Objective,
+		"feasible":   e.Feasible,
+	}
+	if len(e.Violations) > 0 {
+		state["violations"] = e.Violations
+	}
+	if len(e.Derived) > 0 {
+		state["derived"] = e.Derived
+	}
+	if r != nil {
+		state["converged"] = r.Converged
+		state["evaluations"] = r.Evaluations
+		state["trace"] = r.Trace
+	}
+	return state
+}
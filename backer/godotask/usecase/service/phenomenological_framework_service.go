@@ -1,26 +1,236 @@
 package service
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/godotask/domain/expression"
+	"github.com/godotask/domain/goal"
+	"github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/infrastructure/db/repository"
 )
 
+// MaxEvaluationHistory は result / feedback に残す評価履歴の件数
+const MaxEvaluationHistory = 100
+
 type PhenomenologicalFrameworkService struct {
-  Repo repository.PhenomenologicalFrameworkRepositoryInterface
+	Repo repository.PhenomenologicalFrameworkRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+// FrameworkEvaluation は目標関数の1回の評価。result.evaluations に追記する
+type FrameworkEvaluation struct {
+	EvaluatedAt  time.Time `json:"evaluated_at"`
+	TaskID       int       `json:"task_id"`
+	GoalFunction string    `json:"goal_function"`
+	LimitMin     float64   `json:"limit_min"`
+	LimitMax     float64   `json:"limit_max"`
+	goal.Outcome
+	// Flagged は上下限の外にあるか、subject to の制約を満たしていない場合に true
+	Flagged bool `json:"flagged"`
+}
+
+func (s *PhenomenologicalFrameworkService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
 }
 
-func (s *PhenomenologicalFrameworkService) CreatePhenomenologicalFramework(phenomenologicalFramework *model.PhenomenologicalFramework) error {
-	return s.Repo.Create(phenomenologicalFramework)
+// CreatePhenomenologicalFramework はフレームワークを作成する。goal_function は式として解釈できなければならない
+func (s *PhenomenologicalFrameworkService) CreatePhenomenologicalFramework(userID uint, phenomenologicalFramework *model.PhenomenologicalFramework) error {
+	if err := validateGoalFunction(phenomenologicalFramework.GoalFunction); err != nil {
+		return err
+	}
+	return s.Repo.Create(userID, phenomenologicalFramework)
 }
-func (s *PhenomenologicalFrameworkService) GetPhenomenologicalFrameworkByID(id string) (*model.PhenomenologicalFramework, error) {
-	return s.Repo.FindByID(id)
+func (s *PhenomenologicalFrameworkService) GetPhenomenologicalFrameworkByID(userID uint, id string) (*model.PhenomenologicalFramework, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *PhenomenologicalFrameworkService) ListPhenomenologicalFrameworks(userID uint) ([]model.PhenomenologicalFramework, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *PhenomenologicalFrameworkService) UpdatePhenomenologicalFramework(id string, phenomenologicalFramework *model.PhenomenologicalFramework) error {
-	return s.Repo.Update(id, phenomenologicalFramework)
+func (s *PhenomenologicalFrameworkService) UpdatePhenomenologicalFramework(userID uint, id string, phenomenologicalFramework *model.PhenomenologicalFramework) error {
+	if err := validateGoalFunction(phenomenologicalFramework.GoalFunction); err != nil {
+		return err
+	}
+	return s.Repo.Update(userID, id, phenomenologicalFramework)
 }
-func (s *PhenomenologicalFrameworkService) DeletePhenomenologicalFramework(id string) error {
-	return s.Repo.Delete(id)
+func (s *PhenomenologicalFrameworkService) DeletePhenomenologicalFramework(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
+}
+
+// validateGoalFunction は goal_function の構文と型を検査する（空は未設定として許す）
+func validateGoalFunction(goalFunction string) error {
+	if goalFunction == "" {
+		return nil
+	}
+	if _, err := expression.ParseObjective(goalFunction); err != nil {
+		return errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			"goal_function: "+err.Error(),
+		)
+	}
+	return nil
+}
+
+// EvaluatePhenomenologicalFramework はタスクの指標で目標関数（goal_function）を評価し、
+// LimitMin / LimitMax の外にある結果を flagged として result / feedback の履歴に追記する
+//
+// 目標関数から参照できる変数
+//   - assessment.count, assessment.effectiveness_score, assessment.effort_score, assessment.impact_score（平均）
+//   - heuristics.count, heuristics.time_spent_minutes, heuristics.difficulty_score, heuristics.efficiency_score,
+//     heuristics.error_count, heuristics.confidence, heuristics.score（平均）
+//   - label.<ラベルID>（定量化ラベルの value）
+//   - process.<キー>, result.<キー>（フレームワークの process / result の数値・真偽値）
+func (s *PhenomenologicalFrameworkService) EvaluatePhenomenologicalFramework(userID uint, id string, req *model.PhenomenologicalFrameworkEvaluationRequest) (*model.PhenomenologicalFramework, *FrameworkEvaluation, error) {
+	framework, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if framework.GoalFunction == "" {
+		return nil, nil, errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			"goal_function is not set",
+		)
+	}
+	taskID := req.TaskID
+	if taskID == 0 {
+		taskID = framework.TaskID
+	}
+	if taskID == 0 {
+		return nil, nil, errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"task_id is required",
+		)
+	}
+	assessments, analyses, labels, err := s.Repo.FindTaskRecords(userID, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	vars := goalVariables(assessments, analyses, labels)
+	for prefix, doc := range map[string]model.JSON{"process": framework.Process, "result": framework.Result} {
+		if err := vars.Bind(prefix, doc); err != nil {
+			return nil, nil, err
+		}
+	}
+	for name, v := range req.Variables {
+		vars[name] = v
+	}
+
+	outcome, err := goal.Evaluate(framework.GoalFunction, vars, framework.LimitMin, framework.LimitMax)
+	if err != nil {
+		code := errors.BIZ_INVALID_STATE
+		if expression.IsFormatError(err) {
+			code = errors.VAL_INVALID_FORMAT
+		}
+		return nil, nil, errors.NewAppError(code, errors.GetErrorMessage(code), "goal_function: "+err.Error())
+	}
+	evaluation := &FrameworkEvaluation{
+		EvaluatedAt:  s.now(),
+		TaskID:       taskID,
+		GoalFunction: framework.GoalFunction,
+		LimitMin:     framework.LimitMin,
+		LimitMax:     framework.LimitMax,
+		Outcome:      *outcome,
+		Flagged:      outcome.OutOfLimits() || !outcome.Satisfied(),
+	}
+
+	updated, err := s.Repo.Modify(userID, id, func(f *model.PhenomenologicalFramework) error {
+		f.Result = appendHistory(f.Result, evaluation)
+		f.Feedback = appendHistory(f.Feedback, map[string]interface{}{
+			"evaluated_at": evaluation.EvaluatedAt,
+			"task_id":      taskID,
+			"status":       outcome.Status,
+			"flagged":      evaluation.Flagged,
+			"message":      evaluationMessage(outcome, framework.LimitMin, framework.LimitMax),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, evaluation, nil
+}
+
+// goalVariables はタスクの記録から目標関数の変数を作る
+func goalVariables(assessments []model.Assessment, analyses []model.HeuristicsAnalysis, labels []model.QuantificationLabel) expression.Vars {
+	vars := expression.Vars{}
+
+	a := goal.NewAggregate("assessment")
+	for _, r := range assessments {
+		a.Add(map[string]float64{
+			"effectiveness_score": float64(r.EffectivenessScore),
+			"effort_score":        float64(r.EffortScore),
+			"impact_score":        float64(r.ImpactScore),
+		})
+	}
+	a.Into(vars)
+
+	h := goal.NewAggregate("heuristics")
+	for _, r := range analyses {
+		h.Add(map[string]float64{
+			"time_spent_minutes": float64(r.TimeSpentMinutes),
+			"difficulty_score":   r.DifficultyScore,
+			"efficiency_score":   r.EfficiencyScore,
+			"error_count":        float64(r.ErrorCount),
+			"confidence":         r.Confidence,
+			"score":              r.Score,
+		})
+	}
+	h.Into(vars)
+
+	for _, l := range labels {
+		if l.Value != nil {
+			vars[expression.VariableName("label", l.ID)] = *l.Value
+		}
+	}
+	return vars
+}
+
+// appendHistory は JSON の "evaluations" 配列に entry を追記し、最新 MaxEvaluationHistory 件を残す
+func appendHistory(doc model.JSON, entry interface{}) model.JSON {
+	if doc == nil {
+		doc = model.JSON{}
+	}
+	history, _ := doc["evaluations"].([]interface{})
+	history = append(history, entry)
+	if len(history) > MaxEvaluationHistory {
+		history = history[len(history)-MaxEvaluationHistory:]
+	}
+	doc["evaluations"] = history
+	return doc
+}
+
+func evaluationMessage(o *goal.Outcome, limitMin, limitMax float64) string {
+	msg := limitMessage(o, limitMin, limitMax)
+	if !o.Satisfied() {
+		violated := make([]string, 0, len(o.Violations))
+		for c := range o.Violations {
+			violated = append(violated, c)
+		}
+		sort.Strings(violated)
+		msg += "; violates " + strings.Join(violated, ", ")
+	}
+	return msg
+}
+
+func limitMessage(o *goal.Outcome, limitMin, limitMax float64) string {
+	switch o.Status {
+	case goal.StatusBelowMin:
+		return fmt.Sprintf("value %g is below limit_min %g by %g", o.Value, limitMin, o.Deviation)
+	case goal.StatusAboveMax:
+		return fmt.Sprintf("value %g is above limit_max %g by %g", o.Value, limitMax, o.Deviation)
+	case goal.StatusUnbounded:
+		return fmt.Sprintf("value %g (no limits set)", o.Value)
+	default:
+		return fmt.Sprintf("value %g is within [%g, %g]", o.Value, limitMin, limitMax)
+	}
 }
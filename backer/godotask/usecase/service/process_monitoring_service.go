@@ -0,0 +1,266 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"github.com/godotask/domain/monitoring"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+const (
+	MonitoringStatusRunning = "running"
+	MonitoringStatusStopped = "stopped"
+
+	// MaxSamplesPerRequest は1回のリクエストで受け付けるサンプル数の上限
+	MaxSamplesPerRequest = 1000
+)
+
+type ProcessMonitoringService struct {
+	Repo repository.ProcessMonitoringRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+// MonitoringAnomaly は異常と判定されたサンプルの記録（ProcessMonitoring.Anomalies の要素）
+type MonitoringAnomaly struct {
+	Type        string    `json:"type"`
+	Metric      string    `json:"metric"`
+	Detectors   []string  `json:"detectors"`
+	Severity    string    `json:"severity"`
+	Description string    `json:"description"`
+	Value       float64   `json:"value"`
+	Expected    float64   `json:"expected"`
+	ZScore      float64   `json:"z_score"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// IngestResult はサンプル送信の結果。Anomalies は今回のサンプルで検知した異常のみ
+type IngestResult struct {
+	Monitoring *model.ProcessMonitoring `json:"process_monitoring"`
+	Accepted   int                      `json:"accepted"`
+	Anomalies  []MonitoringAnomaly      `json:"anomalies"`
+}
+
+func (s *ProcessMonitoringService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// StartMonitoring は状態評価に対する監視セッションを開始する
+func (s *ProcessMonitoringService) StartMonitoring(userID uint, req *model.ProcessMonitoringRequest) (*model.ProcessMonitoring, error) {
+	cfg, err := monitoring.ParseConfig(req.DetectorConfig)
+	if err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	initialData := req.InitialData
+	if initialData == nil {
+		initialData = map[string]interface{}{}
+	}
+	monitoringData, err := json.Marshal(initialData)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	m := &model.ProcessMonitoring{
+		ID:                uuid.New().String(),
+		StateEvaluationID: req.StateEvaluationID,
+		ProcessType:       req.ProcessType,
+		MonitoringData:    datatypes.JSON(monitoringData),
+		Metrics:           datatypes.JSON(`{}`),
+		Anomalies:         datatypes.JSON(`[]`),
+		DetectorConfig:    datatypes.JSON(configJSON),
+		Status:            MonitoringStatusRunning,
+		StartTime:         now,
+	}
+	if err := s.Repo.Create(userID, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IngestSamples はサンプルを到着順に検知器へ通し、異常を記録する
+// 停止済みのセッションにはサンプルを送れない
+func (s *ProcessMonitoringService) IngestSamples(userID uint, id string, inputs []model.MonitoringSampleInput) (*IngestResult, error) {
+	if err := validateSamples(inputs); err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+
+	detected := []MonitoringAnomaly{}
+	received := s.now()
+	updated, err := s.Repo.Modify(userID, id, func(m *model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error) {
+		if m.Status != MonitoringStatusRunning {
+			return nil, errors.NewAppError(
+				errors.BIZ_INVALID_STATE,
+				errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+				"monitoring session is not running",
+			)
+		}
+		cfg, states, err := loadDetectors(m)
+		if err != nil {
+			return nil, err
+		}
+		latest := map[string]interface{}{}
+		_ = json.Unmarshal(m.MonitoringData, &latest)
+		var anomalies []MonitoringAnomaly
+		_ = json.Unmarshal(m.Anomalies, &anomalies)
+
+		samples := make([]model.ProcessMonitoringSample, 0, len(inputs))
+		for _, in := range inputs {
+			state, ok := states[in.Metric]
+			if !ok {
+				state = &monitoring.State{}
+				states[in.Metric] = state
+			}
+			at := received
+			if in.Timestamp != nil {
+				at = *in.Timestamp
+			}
+			expected := state.Mean
+			flag := state.Observe(cfg, *in.Value)
+			if flag.Anomalous() {
+				anomaly := MonitoringAnomaly{
+					Type:        in.Metric + "_deviation",
+					Metric:      in.Metric,
+					Detectors:   flag.Detectors,
+					Severity:    flag.Severity,
+					Description: fmt.Sprintf("%s deviated from baseline %.4g (z=%.2f)", in.Metric, expected, flag.ZScore),
+					Value:       *in.Value,
+					Expected:    expected,
+					ZScore:      flag.ZScore,
+					Timestamp:   at,
+				}
+				anomalies = append(anomalies, anomaly)
+				detected = append(detected, anomaly)
+			}
+			latest[in.Metric] = *in.Value
+			samples = append(samples, model.ProcessMonitoringSample{
+				ProcessMonitoringID: m.ID,
+				Metric:              in.Metric,
+				Value:               *in.Value,
+				Anomalous:           flag.Anomalous(),
+				RecordedAt:          at,
+			})
+		}
+
+		if m.MonitoringData, err = marshalJSON(latest); err != nil {
+			return nil, err
+		}
+		if m.Metrics, err = marshalJSON(states); err != nil {
+			return nil, err
+		}
+		if m.Anomalies, err = marshalJSON(anomalies); err != nil {
+			return nil, err
+		}
+		return samples, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &IngestResult{Monitoring: updated, Accepted: len(inputs), Anomalies: detected}, nil
+}
+
+// StopMonitoring はセッションを終了し、指標ごとの集計をまとめとして保存する
+func (s *ProcessMonitoringService) StopMonitoring(userID uint, id string) (*model.ProcessMonitoring, error) {
+	end := s.now()
+	return s.Repo.Modify(userID, id, func(m *model.ProcessMonitoring) ([]model.ProcessMonitoringSample, error) {
+		if m.Status != MonitoringStatusRunning {
+			return nil, errors.NewAppError(
+				errors.BIZ_INVALID_STATE,
+				errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+				"monitoring session is already stopped",
+			)
+		}
+		_, states, err := loadDetectors(m)
+		if err != nil {
+			return nil, err
+		}
+		if m.Summary, err = marshalJSON(monitoring.Summarize(states, m.StartTime, end)); err != nil {
+			return nil, err
+		}
+		m.Status = MonitoringStatusStopped
+		m.EndTime = &end
+		return nil, nil
+	})
+}
+
+func (s *ProcessMonitoringService) GetProcessMonitoringByID(userID uint, id string) (*model.ProcessMonitoring, error) {
+	return s.Repo.FindByID(userID, id)
+}
+
+func (s *ProcessMonitoringService) ListProcessMonitorings(userID uint, stateEvaluationID string) ([]model.ProcessMonitoring, error) {
+	return s.Repo.FindByEvaluation(userID, stateEvaluationID)
+}
+
+func (s *ProcessMonitoringService) ListSamples(userID uint, id string, metric string) ([]model.ProcessMonitoringSample, error) {
+	return s.Repo.FindSamples(userID, id, metric)
+}
+
+func validateSamples(inputs []model.MonitoringSampleInput) error {
+	if len(inputs) == 0 {
+		return fmt.Errorf("samples must not be empty")
+	}
+	if len(inputs) > MaxSamplesPerRequest {
+		return fmt.Errorf("at most %d samples can be sent at once", MaxSamplesPerRequest)
+	}
+	for i, in := range inputs {
+		if in.Metric == "" || in.Value == nil {
+			return fmt.Errorf("samples[%d]: metric and value are required", i)
+		}
+		if math.IsNaN(*in.Value) || math.IsInf(*in.Value, 0) {
+			return fmt.Errorf("samples[%d]: value must be a finite number", i)
+		}
+	}
+	return nil
+}
+
+// loadDetectors はセッションに保存された検知器の設定と指標ごとの状態を読み込む
+func loadDetectors(m *model.ProcessMonitoring) (monitoring.Config, map[string]*monitoring.State, error) {
+	cfg := monitoring.DefaultConfig()
+	if len(m.DetectorConfig) > 0 {
+		if err := json.Unmarshal(m.DetectorConfig, &cfg); err != nil {
+			return cfg, nil, err
+		}
+	}
+	states := map[string]*monitoring.State{}
+	raw := map[string]json.RawMessage{}
+	// 検知器の状態でない値（シードの集計値など）は読み飛ばす
+	_ = json.Unmarshal(m.Metrics, &raw)
+	for name, v := range raw {
+		var state monitoring.State
+		if json.Unmarshal(v, &state) == nil {
+			states[name] = &state
+		}
+	}
+	return cfg, states, nil
+}
+
+func marshalJSON(v interface{}) (datatypes.JSON, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(b), nil
+}
@@ -1,26 +1,238 @@
 package service
 
 import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/godotask/domain/optimization"
+	"github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/infrastructure/db/repository"
 )
 
+// 改善率の集計単位
+const (
+	SummaryByOptimizationType = "optimization_type"
+	SummaryByProcessID        = "process_id"
+)
+
 type ProcessOptimizationService struct {
-  Repo repository.ProcessOptimizationRepositoryInterface
+	Repo repository.ProcessOptimizationRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
 }
 
-func (s *ProcessOptimizationService) CreateProcessOptimization(processOptimization *model.ProcessOptimization) error {
-	return s.Repo.Create(processOptimization)
+// OptimizationSummary は集計単位ごとの改善率。改善率の統計は検証済みの記録のみから計算する
+type OptimizationSummary struct {
+	Key             string     `json:"key"`
+	Verified        int        `json:"verified"`
+	Pending         int        `json:"pending"`
+	Rejected        int        `json:"rejected"`
+	MeanImprovement float64    `json:"mean_improvement"`
+	MinImprovement  float64    `json:"min_improvement"`
+	MaxImprovement  float64    `json:"max_improvement"`
+	LastValidatedAt *time.Time `json:"last_validated_at,omitempty"`
 }
-func (s *ProcessOptimizationService) GetProcessOptimizationByID(id string) (*model.ProcessOptimization, error) {
-	return s.Repo.FindByID(id)
+
+func (s *ProcessOptimizationService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// CreateProcessOptimization は記録を検証待ちとして作成する
+// 改善率は Metric から計算し、リクエストの improvement や検証欄は使わない。actorID は記録したユーザー
+func (s *ProcessOptimizationService) CreateProcessOptimization(userID, actorID uint, processOptimization *model.ProcessOptimization) error {
+	processOptimization.CreatedBy = actorID
+	resetValidation(processOptimization)
+	if err := computeImprovement(processOptimization); err != nil {
+		return err
+	}
+	return s.Repo.Create(userID, processOptimization)
+}
+func (s *ProcessOptimizationService) GetProcessOptimizationByID(userID uint, id string) (*model.ProcessOptimization, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *ProcessOptimizationService) ListProcessOptimizations(userID uint) ([]model.ProcessOptimization, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *ProcessOptimizationService) UpdateProcessOptimization(id string, processOptimization *model.ProcessOptimization) error {
-	return s.Repo.Update(id, processOptimization)
+
+// UpdateProcessOptimization は指定された項目のみを更新する
+// 状態や指標が変わった場合は改善率を計算し直し、検証をやり直すため検証待ちに戻す
+func (s *ProcessOptimizationService) UpdateProcessOptimization(userID uint, id string, input *model.ProcessOptimization) (*model.ProcessOptimization, error) {
+	return s.Repo.Modify(userID, id, func(po *model.ProcessOptimization) error {
+		if input.TaskID != 0 {
+			po.TaskID = input.TaskID
+		}
+		if input.ProcessID != "" {
+			po.ProcessID = input.ProcessID
+		}
+		if input.OptimizationType != "" {
+			po.OptimizationType = input.OptimizationType
+		}
+		if input.Method != "" {
+			po.Method = input.Method
+		}
+		if input.Iterations != 0 {
+			po.Iterations = input.Iterations
+		}
+		if input.ConvergenceTime != 0 {
+			po.ConvergenceTime = input.ConvergenceTime
+		}
+		evidenceChanged := false
+		if input.InitialState != nil {
+			po.InitialState = input.InitialState
+			evidenceChanged = true
+		}
+		if input.OptimizedState != nil {
+			po.OptimizedState = input.OptimizedState
+			evidenceChanged = true
+		}
+		if input.Metric != "" {
+			po.Metric = input.Metric
+			evidenceChanged = true
+		}
+		if input.MetricDirection != "" {
+			po.MetricDirection = input.MetricDirection
+			evidenceChanged = true
+		}
+		if !evidenceChanged {
+			return nil
+		}
+		if err := computeImprovement(po); err != nil {
+			return err
+		}
+		resetValidation(po)
+		return nil
+	})
+}
+func (s *ProcessOptimizationService) DeleteProcessOptimization(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
-func (s *ProcessOptimizationService) DeleteProcessOptimization(id string) error {
-	return s.Repo.Delete(id)
+
+// ValidateProcessOptimization は記録した本人以外のユーザーが結果を承認・却下する
+// actorID は操作したユーザー（管理者の場合も実際のユーザーID）
+func (s *ProcessOptimizationService) ValidateProcessOptimization(userID, actorID uint, id string, req *model.ProcessOptimizationValidationRequest) (*model.ProcessOptimization, error) {
+	now := s.now()
+	return s.Repo.Modify(userID, id, func(po *model.ProcessOptimization) error {
+		if po.CreatedBy != 0 && po.CreatedBy == actorID {
+			return errors.NewAppError(
+				errors.BIZ_OPERATION_NOT_ALLOWED,
+				errors.GetErrorMessage(errors.BIZ_OPERATION_NOT_ALLOWED),
+				"the result must be validated by a user other than its creator",
+			)
+		}
+		if po.ValidationStatus != "" && po.ValidationStatus != model.ValidationPending {
+			return errors.NewAppError(
+				errors.BIZ_INVALID_STATE,
+				errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+				"the result has already been "+po.ValidationStatus,
+			)
+		}
+		po.ValidationStatus = model.ValidationVerified
+		if req.Decision == "reject" {
+			po.ValidationStatus = model.ValidationRejected
+		}
+		po.ValidationComment = req.Comment
+		po.ValidatedBy = strconv.FormatUint(uint64(actorID), 10)
+		po.ValidationDate = now
+		return nil
+	})
+}
+
+// Summary は参照できる記録を groupBy（optimization_type / process_id）ごとに集計する
+func (s *ProcessOptimizationService) Summary(userID uint, groupBy string) ([]OptimizationSummary, error) {
+	if groupBy == "" {
+		groupBy = SummaryByOptimizationType
+	}
+	if groupBy != SummaryByOptimizationType && groupBy != SummaryByProcessID {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"group_by must be optimization_type or process_id",
+		)
+	}
+	records, err := s.Repo.FindAll(userID)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeOptimizations(records, groupBy), nil
+}
+
+func summarizeOptimizations(records []model.ProcessOptimization, groupBy string) []OptimizationSummary {
+	groups := map[string]*OptimizationSummary{}
+	sums := map[string]float64{}
+	for _, po := range records {
+		key := po.OptimizationType
+		if groupBy == SummaryByProcessID {
+			key = po.ProcessID
+		}
+		if key == "" {
+			key = "unspecified"
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &OptimizationSummary{Key: key}
+			groups[key] = g
+		}
+		switch po.ValidationStatus {
+		case model.ValidationVerified:
+			if g.Verified == 0 || po.Improvement < g.MinImprovement {
+				g.MinImprovement = po.Improvement
+			}
+			if g.Verified == 0 || po.Improvement > g.MaxImprovement {
+				g.MaxImprovement = po.Improvement
+			}
+			g.Verified++
+			sums[key] += po.Improvement
+			if g.LastValidatedAt == nil || po.ValidationDate.After(*g.LastValidatedAt) {
+				at := po.ValidationDate
+				g.LastValidatedAt = &at
+			}
+		case model.ValidationRejected:
+			g.Rejected++
+		default:
+			g.Pending++
+		}
+	}
+	summaries := make([]OptimizationSummary, 0, len(groups))
+	for key, g := range groups {
+		if g.Verified > 0 {
+			g.MeanImprovement = math.Round(sums[key]/float64(g.Verified)*100) / 100
+		}
+		summaries = append(summaries, *g)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Key < summaries[j].Key })
+	return summaries
+}
+
+// computeImprovement は Metric が指定されていれば状態から改善率を計算する。無ければ 0 とする
+func computeImprovement(po *model.ProcessOptimization) error {
+	if po.Metric == "" {
+		po.Improvement = 0
+		return nil
+	}
+	v, err := optimization.Improvement(po.InitialState, po.OptimizedState, po.Metric, po.MetricDirection)
+	if err != nil {
+		return errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+	if po.MetricDirection == "" {
+		po.MetricDirection = optimization.DirectionMinimize
+	}
+	po.Improvement = v
+	return nil
+}
+
+func resetValidation(po *model.ProcessOptimization) {
+	po.ValidationStatus = model.ValidationPending
+	po.ValidationComment = ""
+	po.ValidatedBy = ""
+	po.ValidationDate = time.Time{}
 }
@@ -9,18 +9,19 @@ type QualitativeLabelService struct {
   Repo repository.QualitativeLabelRepositoryInterface
 }
 
-func (s *QualitativeLabelService) CreateQualitativeLabel(qualitativeLabel *model.QualitativeLabel) error {
-	return s.Repo.Create(qualitativeLabel)
+func (s *QualitativeLabelService) CreateQualitativeLabel(userID uint, qualitativeLabel *model.QualitativeLabel) error {
+	qualitativeLabel.UserID = int(userID)
+	return s.Repo.Create(userID, qualitativeLabel)
 }
-func (s *QualitativeLabelService) GetQualitativeLabelByID(id string) (*model.QualitativeLabel, error) {
-	return s.Repo.FindByID(id)
+func (s *QualitativeLabelService) GetQualitativeLabelByID(userID uint, id string) (*model.QualitativeLabel, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *QualitativeLabelService) ListQualitativeLabels(userID uint) ([]model.QualitativeLabel, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *QualitativeLabelService) UpdateQualitativeLabel(id string, qualitativeLabel *model.QualitativeLabel) error {
-	return s.Repo.Update(id, qualitativeLabel)
+func (s *QualitativeLabelService) UpdateQualitativeLabel(userID uint, id string, qualitativeLabel *model.QualitativeLabel) error {
+	return s.Repo.Update(userID, id, qualitativeLabel)
 }
-func (s *QualitativeLabelService) DeleteQualitativeLabel(id string) error {
-	return s.Repo.Delete(id)
+func (s *QualitativeLabelService) DeleteQualitativeLabel(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
@@ -0,0 +1,687 @@
+package service
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/godotask/domain/labeling"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+const (
+	// DefaultLabelSearchLimit / MaxLabelSearchLimit はラベル検索の件数の既定値と上限
+	DefaultLabelSearchLimit = 50
+	MaxLabelSearchLimit     = 500
+)
+
+// labelSortColumns はラベル検索で並び替えに使える列
+var labelSortColumns = map[string]bool{
+	"created_at":    true,
+	"updated_at":    true,
+	"value":         true,
+	"confidence":    true,
+	"original_text": true,
+}
+
+// labelUpdatableFields は UpdateLabelRequest.Updates で変更できる項目
+// 所有者・タスク・検証状態・履歴の項目は変更できない
+var labelUpdatableFields = map[string]bool{
+	"original_text":     true,
+	"normalized_text":   true,
+	"category":          true,
+	"domain":            true,
+	"context":           true,
+	"image_url":         true,
+	"value":             true,
+	"unit":              true,
+	"min_range":         true,
+	"max_range":         true,
+	"typical_value":     true,
+	"precision":         true,
+	"confidence":        true,
+	"abstract_level":    true,
+	"source":            true,
+	"public_visibility": true,
+	"related_concepts":  true,
+	"tags":              true,
+	"notes":             true,
+}
+
+type QuantificationLabelService struct {
+	Repo repository.QuantificationLabelRepositoryInterface
+	// RequiredVerifications は採否を決めるのに必要な検証者数（0 の場合は labeling.DefaultRequiredVerifications）
+	RequiredVerifications int
+	// Ontology は検索語の同義語による展開に使う（nil の場合は展開しない）
+	Ontology *LabelOntologyService
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+func (s *QuantificationLabelService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func invalidLabel(detail string) error {
+	return errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), detail)
+}
+
+// validateLabel は数値が有限で、範囲・信頼度・精度が妥当であることを確認する
+func validateLabel(label *model.QuantificationLabel) error {
+	if strings.TrimSpace(label.OriginalText) == "" {
+		return invalidLabel("original_text is required")
+	}
+	for name, v := range map[string]*float64{
+		"value":         label.Value,
+		"min_range":     label.MinRange,
+		"max_range":     label.MaxRange,
+		"typical_value": label.TypicalValue,
+		"confidence":    label.Confidence,
+	} {
+		if v != nil && (math.IsNaN(*v) || math.IsInf(*v, 0)) {
+			return invalidLabel(name + " must be a finite number")
+		}
+	}
+	if label.MinRange != nil && label.MaxRange != nil && *label.MinRange > *label.MaxRange {
+		return invalidLabel("min_range must not exceed max_range")
+	}
+	if label.Confidence != nil && (*label.Confidence < 0 || *label.Confidence > 1) {
+		return invalidLabel("confidence must be between 0 and 1")
+	}
+	if label.Precision != nil && *label.Precision < 0 {
+		return invalidLabel("precision must not be negative")
+	}
+	for name, raw := range map[string]datatypes.JSON{"related_concepts": label.RelatedConcepts, "tags": label.Tags} {
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		var list []string
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return invalidLabel(name + " must be an array of strings")
+		}
+	}
+	return nil
+}
+
+// stringList は文字列の配列を JSON 列の値にする
+func stringList(list []string) datatypes.JSON {
+	if len(list) == 0 {
+		return nil
+	}
+	raw, _ := json.Marshal(list)
+	return datatypes.JSON(raw)
+}
+
+// CreateLabel はリクエストから未検証（下書き）のラベルを作成する。actorID が所有者になる
+// 正規化した語句は指定した語句（省略時は元の語句）を labeling.Normalize で揃える
+func (s *QuantificationLabelService) CreateLabel(userID, actorID uint, req *model.CreateLabelRequest) (*model.QuantificationLabel, error) {
+	unit := req.Unit
+	source := req.Source
+	if source == "" {
+		source = "manual"
+	}
+	now := s.now()
+	label := &model.QuantificationLabel{
+		ID:               uuid.New().String(),
+		UserID:           int(actorID),
+		TaskID:           req.TaskID,
+		OriginalText:     req.Text,
+		NormalizedText:   normalizedText(req.NormalizedText, req.Text),
+		Category:         req.Category,
+		Domain:           req.Domain,
+		Context:          req.Context,
+		ImageURL:         req.ImageURL,
+		Value:            req.Value,
+		Unit:             &unit,
+		MinRange:         req.MinRange,
+		MaxRange:         req.MaxRange,
+		TypicalValue:     req.TypicalValue,
+		Precision:        req.Precision,
+		Confidence:       req.Confidence,
+		AbstractLevel:    req.AbstractLevel,
+		RelatedConcepts:  stringList(req.Concepts),
+		Source:           source,
+		Status:           labeling.StatusDraft,
+		PublicVisibility: req.PublicVisibility,
+		Tags:             stringList(req.Tags),
+		Notes:            req.Description,
+		Version:          1,
+		CreatedBy:        strconv.FormatUint(uint64(actorID), 10),
+		UpdatedBy:        strconv.FormatUint(uint64(actorID), 10),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := validateLabel(label); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.Create(userID, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+func (s *QuantificationLabelService) GetLabel(userID uint, id string) (*model.QuantificationLabel, error) {
+	return s.Repo.FindByID(userID, id)
+}
+
+// parseLabelDate は RFC3339 または 2006-01-02 の日時を RFC3339 に揃える
+func parseLabelDate(name, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+	}
+	return "", invalidLabel(name + " must be RFC3339 or YYYY-MM-DD")
+}
+
+// SearchLabels は条件に一致するラベルと総件数を返す
+// 既定は作成日時の新しい順に 50 件（最大 500 件）。Expand の場合は Text の同義語の語句でも検索する
+func (s *QuantificationLabelService) SearchLabels(userID uint, query *model.LabelSearchQuery) ([]model.QuantificationLabel, int64, error) {
+	q := *query
+	if q.SortBy == "" {
+		q.SortBy = "created_at"
+	}
+	if !labelSortColumns[q.SortBy] {
+		return nil, 0, invalidLabel(fmt.Sprintf("unknown sort_by %q", q.SortBy))
+	}
+	switch q.SortOrder {
+	case "":
+		q.SortOrder = "desc"
+	case "asc", "desc":
+	default:
+		return nil, 0, invalidLabel("sort_order must be asc or desc")
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return nil, 0, invalidLabel("limit and offset must not be negative")
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultLabelSearchLimit
+	}
+	q.Limit = min(q.Limit, MaxLabelSearchLimit)
+	var err error
+	if q.From, err = parseLabelDate("from", q.From); err != nil {
+		return nil, 0, err
+	}
+	if q.To, err = parseLabelDate("to", q.To); err != nil {
+		return nil, 0, err
+	}
+	q.Terms = nil
+	if q.Expand && strings.TrimSpace(q.Text) != "" && s.Ontology != nil {
+		expansion, err := s.Ontology.ExpandQuery(userID, q.Text)
+		if err != nil {
+			return nil, 0, err
+		}
+		q.Terms = expansion.Terms
+	}
+	return s.Repo.Search(userID, &q)
+}
+
+// UpdateLabel は Updates の項目を書き換え、変更前後の値を Reason とともに改訂履歴に記録して版を上げる
+// 値が変わらない場合は改訂履歴を作らない。検証待ちのラベルは変更できず、採用済みのラベルは変更すると下書きに戻る
+// 正規化した語句は揃えて保存し、元の語句から作られていた場合は元の語句の変更に追従する
+func (s *QuantificationLabelService) UpdateLabel(userID, actorID uint, id string, req *model.UpdateLabelRequest) (*model.QuantificationLabel, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, errors.NewAppError(errors.VAL_MISSING_FIELD, errors.GetErrorMessage(errors.VAL_MISSING_FIELD), "reason is required")
+	}
+	var unknown []string
+	for field := range req.Updates {
+		if !labelUpdatableFields[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, invalidLabel("fields cannot be updated: " + strings.Join(unknown, ", "))
+	}
+
+	return s.Repo.Modify(userID, id, func(label *model.QuantificationLabel) (*model.LabelRevision, error) {
+		if label.Status == labeling.StatusSubmitted {
+			return nil, errors.NewAppError(errors.BIZ_INVALID_STATE, errors.GetErrorMessage(errors.BIZ_INVALID_STATE), "label is under verification")
+		}
+		before := map[string]interface{}{}
+		raw, _ := json.Marshal(label)
+		_ = json.Unmarshal(raw, &before)
+
+		merged := map[string]interface{}{}
+		_ = json.Unmarshal(raw, &merged)
+		for field, v := range req.Updates {
+			merged[field] = v
+		}
+		raw, err := json.Marshal(merged)
+		if err != nil {
+			return nil, invalidLabel(err.Error())
+		}
+		var updated model.QuantificationLabel
+		if err := json.Unmarshal(raw, &updated); err != nil {
+			return nil, invalidLabel(err.Error())
+		}
+		if err := validateLabel(&updated); err != nil {
+			return nil, err
+		}
+		fields := map[string]bool{}
+		for field := range req.Updates {
+			fields[field] = true
+		}
+		if _, ok := req.Updates["normalized_text"]; ok {
+			updated.NormalizedText = normalizedText(updated.NormalizedText, updated.OriginalText)
+		} else if label.NormalizedText == labeling.Normalize(label.OriginalText) {
+			updated.NormalizedText = labeling.Normalize(updated.OriginalText)
+			fields["normalized_text"] = true
+		}
+
+		after := map[string]interface{}{}
+		raw, _ = json.Marshal(&updated)
+		_ = json.Unmarshal(raw, &after)
+		changes := model.JSON{}
+		for field := range fields {
+			if !reflect.DeepEqual(before[field], after[field]) {
+				changes[field] = map[string]interface{}{"old": before[field], "new": after[field]}
+			}
+		}
+		if len(changes) == 0 {
+			return nil, nil
+		}
+		if updated.Status == labeling.StatusAccepted {
+			changes["status"] = map[string]interface{}{"old": updated.Status, "new": labeling.StatusDraft}
+			changes["validated"] = map[string]interface{}{"old": updated.Validated, "new": false}
+			updated.Status = labeling.StatusDraft
+			updated.Validated = false
+		}
+
+		now := s.now()
+		updated.Version = label.Version + 1
+		updated.UpdatedBy = strconv.FormatUint(uint64(actorID), 10)
+		updated.UpdatedAt = now
+		*label = updated
+		return &model.LabelRevision{
+			ID:        uuid.New().String(),
+			LabelID:   label.ID,
+			Version:   label.Version,
+			Changes:   changes,
+			Comment:   req.Reason,
+			UserID:    strconv.FormatUint(uint64(actorID), 10),
+			Timestamp: now,
+		}, nil
+	})
+}
+
+func (s *QuantificationLabelService) requiredVerifications() int {
+	if s.RequiredVerifications > 0 {
+		return s.RequiredVerifications
+	}
+	return labeling.DefaultRequiredVerifications
+}
+
+// SubmitLabel は下書き・差し戻しのラベルを検証に提出する
+// 提出の回を進め、前の回の検証結果（正確さ・一致度・検証者数）は消去する
+func (s *QuantificationLabelService) SubmitLabel(userID, actorID uint, id string) (*model.QuantificationLabel, error) {
+	return s.Repo.Modify(userID, id, func(label *model.QuantificationLabel) (*model.LabelRevision, error) {
+		if !labeling.CanSubmit(label.Status) {
+			return nil, errors.NewAppError(errors.BIZ_INVALID_STATE, errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+				fmt.Sprintf("label in status %q cannot be submitted", label.Status))
+		}
+		now := s.now()
+		changes := model.JSON{
+			"status":    map[string]interface{}{"old": label.Status, "new": labeling.StatusSubmitted},
+			"validated": map[string]interface{}{"old": label.Validated, "new": false},
+		}
+		label.Status = labeling.StatusSubmitted
+		label.Validated = false
+		label.ReviewRound++
+		label.VerificationCount = 0
+		label.Accuracy = nil
+		label.Agreement = nil
+		label.Version++
+		label.UpdatedBy = strconv.FormatUint(uint64(actorID), 10)
+		label.UpdatedAt = now
+		return &model.LabelRevision{
+			ID:        uuid.New().String(),
+			LabelID:   label.ID,
+			Version:   label.Version,
+			Changes:   changes,
+			Comment:   fmt.Sprintf("submitted for verification (round %d)", label.ReviewRound),
+			UserID:    strconv.FormatUint(uint64(actorID), 10),
+			Timestamp: now,
+		}, nil
+	})
+}
+
+// judgement は記録した検証を一致度の算出に使う判定にする
+func judgement(v model.LabelVerification) labeling.Judgement {
+	j := labeling.Judgement{labeling.DecisionItem: v.Decision}
+	for c, value := range v.Criteria {
+		if str, ok := value.(string); ok {
+			j[c] = str
+		}
+	}
+	return j
+}
+
+// agreement は検証の一覧から検証者間の一致度を求める
+func agreement(verifications []model.LabelVerification) model.LabelAgreement {
+	judgements := make([]labeling.Judgement, 0, len(verifications))
+	for _, v := range verifications {
+		judgements = append(judgements, judgement(v))
+	}
+	a := model.LabelAgreement{Verifiers: len(verifications)}
+	if kappa, method, ok := labeling.Agreement(judgements); ok {
+		a.Method = method
+		a.Kappa = &kappa
+	}
+	return a
+}
+
+// VerifyLabel は検証待ちのラベルに actorID の検証を記録し、正確さと検証者間の一致度を更新する
+// 必要な人数の検証がそろい、採用と却下の多数決が決まるとラベルを採用（検証済み）または差し戻しにする
+// 自分のラベルは検証できず、同じ提出の回を 2 回検証することもできない
+func (s *QuantificationLabelService) VerifyLabel(actorID uint, id string, req *model.VerifyLabelRequest) (*model.QuantificationLabel, error) {
+	if !labeling.IsDecision(req.Decision) {
+		return nil, invalidLabel("decision must be accept or reject")
+	}
+	for c := range req.Criteria {
+		known := false
+		for _, k := range labeling.VerificationCriteria {
+			known = known || c == k
+		}
+		if !known {
+			return nil, invalidLabel(fmt.Sprintf("unknown criterion %q (expected one of %s)", c, strings.Join(labeling.VerificationCriteria, ", ")))
+		}
+	}
+
+	label, err := s.Repo.Verify(id, func(label *model.QuantificationLabel, verifications []model.LabelVerification) (*model.LabelVerification, *model.LabelRevision, error) {
+		if label.Status != labeling.StatusSubmitted {
+			return nil, nil, errors.NewAppError(errors.BIZ_INVALID_STATE, errors.GetErrorMessage(errors.BIZ_INVALID_STATE), "label is not submitted for verification")
+		}
+		if uint(label.UserID) == actorID {
+			return nil, nil, errors.NewAppError(errors.BIZ_OPERATION_NOT_ALLOWED, errors.GetErrorMessage(errors.BIZ_OPERATION_NOT_ALLOWED), "cannot verify your own label")
+		}
+		for _, v := range verifications {
+			if v.VerifierID == actorID {
+				return nil, nil, errors.NewAppError(errors.RES_ALREADY_EXISTS, errors.GetErrorMessage(errors.RES_ALREADY_EXISTS), "already verified in this round")
+			}
+		}
+
+		now := s.now()
+		criteria := model.JSON{}
+		for c, v := range labeling.NewJudgement(req.Decision, req.Criteria) {
+			if c != labeling.DecisionItem {
+				criteria[c] = v
+			}
+		}
+		verification := &model.LabelVerification{
+			ID:         uuid.New().String(),
+			LabelID:    label.ID,
+			Round:      label.ReviewRound,
+			VerifierID: actorID,
+			Decision:   req.Decision,
+			Criteria:   criteria,
+			Comment:    req.Comment,
+			CreatedAt:  now,
+		}
+		all := append(verifications, *verification)
+		judgements := make([]labeling.Judgement, 0, len(all))
+		decisions := make([]string, 0, len(all))
+		for _, v := range all {
+			judgements = append(judgements, judgement(v))
+			decisions = append(decisions, v.Decision)
+		}
+		label.Accuracy = nil
+		if accuracy, ok := labeling.Accuracy(judgements); ok {
+			label.Accuracy = &accuracy
+		}
+		label.Agreement = agreement(all).Kappa
+		label.VerificationCount = len(all)
+		label.LastVerified = &now
+
+		status := labeling.Resolve(decisions, s.requiredVerifications())
+		if status == labeling.StatusSubmitted {
+			return verification, nil, nil
+		}
+		accepted := status == labeling.StatusAccepted
+		changes := model.JSON{
+			"status":    map[string]interface{}{"old": label.Status, "new": status},
+			"validated": map[string]interface{}{"old": label.Validated, "new": accepted},
+		}
+		label.Status = status
+		label.Validated = accepted
+		label.Version++
+		label.UpdatedAt = now
+		accepts := 0
+		for _, d := range decisions {
+			if d == labeling.DecisionAccept {
+				accepts++
+			}
+		}
+		return verification, &model.LabelRevision{
+			ID:        uuid.New().String(),
+			LabelID:   label.ID,
+			Version:   label.Version,
+			Changes:   changes,
+			Comment:   fmt.Sprintf("%s by %d verifiers (accept %d, reject %d)", status, len(decisions), accepts, len(decisions)-accepts),
+			UserID:    strconv.FormatUint(uint64(actorID), 10),
+			Timestamp: now,
+		}, nil
+	})
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ErrResourceNotFound
+	}
+	return label, err
+}
+
+// ListVerifications はラベルの検証（全ての回）と、現在の回の検証者間の一致度を返す
+func (s *QuantificationLabelService) ListVerifications(userID uint, id string) ([]model.LabelVerification, *model.LabelAgreement, error) {
+	label, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	verifications, err := s.Repo.FindVerifications(userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var current []model.LabelVerification
+	for _, v := range verifications {
+		if v.Round == label.ReviewRound {
+			current = append(current, v)
+		}
+	}
+	a := agreement(current)
+	return verifications, &a, nil
+}
+
+// ReviewQueue は actorID が検証できる検証待ちのラベルを返す
+func (s *QuantificationLabelService) ReviewQueue(actorID uint, domain string) ([]model.QuantificationLabel, error) {
+	return s.Repo.FindReviewQueue(actorID, domain)
+}
+
+func (s *QuantificationLabelService) DeleteLabel(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
+}
+
+func (s *QuantificationLabelService) ListRevisions(userID uint, id string) ([]model.LabelRevision, error) {
+	return s.Repo.FindRevisions(userID, id)
+}
+
+// GetStatistics は自分のラベル（domain を指定した場合はその分野）の統計を返す
+// ConceptDistribution は抽象度（AbstractLevel）ごと、Temporal は作成月（YYYY-MM）ごとの件数
+func (s *QuantificationLabelService) GetStatistics(userID uint, domain string) (*model.LabelStatistics, error) {
+	labels, err := s.Repo.FindAll(userID, domain)
+	if err != nil {
+		return nil, err
+	}
+	stats := &model.LabelStatistics{
+		TotalLabels:         len(labels),
+		LabelsByDomain:      map[string]int{},
+		LabelsByCategory:    map[string]int{},
+		LabelsByConcept:     map[string]int{},
+		AverageMetrics:      map[string]float64{},
+		ValueDistribution:   []map[string]interface{}{},
+		UnitDistribution:    map[string]int{},
+		ConceptDistribution: map[string]int{},
+		Temporal:            map[string]int{},
+		Quality:             map[string]int{"validated": 0, "unvalidated": 0, "low_confidence": 0, "missing_value": 0},
+	}
+	type valueStats struct {
+		count         int
+		min, max, sum float64
+	}
+	byUnit := map[string]*valueStats{}
+	confidenceSum, confidenceCount := 0.0, 0
+	for _, l := range labels {
+		stats.LabelsByDomain[l.Domain]++
+		stats.LabelsByCategory[l.Category]++
+		stats.ConceptDistribution[l.AbstractLevel]++
+		stats.Temporal[l.CreatedAt.Format("2006-01")]++
+		var concepts []string
+		_ = json.Unmarshal(l.RelatedConcepts, &concepts)
+		for _, c := range concepts {
+			stats.LabelsByConcept[c]++
+		}
+		unit := ""
+		if l.Unit != nil {
+			unit = *l.Unit
+		}
+		stats.UnitDistribution[unit]++
+
+		if l.Validated {
+			stats.Quality["validated"]++
+		} else {
+			stats.Quality["unvalidated"]++
+		}
+		if l.Confidence != nil {
+			confidenceSum += *l.Confidence
+			confidenceCount++
+			if *l.Confidence < 0.5 {
+				stats.Quality["low_confidence"]++
+			}
+		}
+		if l.Value == nil {
+			stats.Quality["missing_value"]++
+			continue
+		}
+		v := *l.Value
+		vs, ok := byUnit[unit]
+		if !ok {
+			vs = &valueStats{min: v, max: v}
+			byUnit[unit] = vs
+		}
+		vs.count++
+		vs.sum += v
+		vs.min = math.Min(vs.min, v)
+		vs.max = math.Max(vs.max, v)
+	}
+	if confidenceCount > 0 {
+		stats.AverageMetrics["confidence"] = confidenceSum / float64(confidenceCount)
+	}
+	if len(labels) > 0 {
+		stats.AverageMetrics["validated_ratio"] = float64(stats.Quality["validated"]) / float64(len(labels))
+	}
+	units := make([]string, 0, len(byUnit))
+	for unit := range byUnit {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	for _, unit := range units {
+		vs := byUnit[unit]
+		stats.ValueDistribution = append(stats.ValueDistribution, map[string]interface{}{
+			"unit":  unit,
+			"count": vs.count,
+			"min":   vs.min,
+			"max":   vs.max,
+			"mean":  vs.sum / float64(vs.count),
+		})
+	}
+	return stats, nil
+}
+
+// Suggest は語句（「バリの発生程度」「a bit faster」）に対して、同じ分野の検証済みラベルと
+// その同義語（LabelRelation の synonym）から単位付きの値を信頼度の高い順に提案する
+func (s *QuantificationLabelService) Suggest(userID uint, req *model.SuggestionRequest) (*model.SuggestionResponse, error) {
+	if strings.TrimSpace(req.Text) == "" {
+		return nil, errors.NewAppError(errors.VAL_MISSING_FIELD, errors.GetErrorMessage(errors.VAL_MISSING_FIELD), "text is required")
+	}
+	labels, err := s.Repo.FindSuggestionCandidates(userID, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]model.QuantificationLabel{}
+	candidates := make([]labeling.Candidate, 0, len(labels))
+	ids := make([]string, 0, len(labels))
+	for _, l := range labels {
+		value := l.TypicalValue
+		if value == nil {
+			value = l.Value
+		}
+		if value == nil {
+			continue
+		}
+		c := labeling.Candidate{
+			ID:       l.ID,
+			Texts:    []string{l.OriginalText, l.NormalizedText, l.Category},
+			Value:    *value,
+			MinRange: l.MinRange,
+			MaxRange: l.MaxRange,
+		}
+		if l.Unit != nil {
+			c.Unit = *l.Unit
+		}
+		if l.Confidence != nil {
+			c.Confidence = *l.Confidence
+		}
+		byID[l.ID] = l
+		candidates = append(candidates, c)
+		ids = append(ids, l.ID)
+	}
+	relations, err := s.Repo.FindRelations(ids, labeling.RelationSynonym)
+	if err != nil {
+		return nil, err
+	}
+	var synonyms []labeling.Synonym
+	for _, r := range relations {
+		synonyms = append(synonyms, labeling.Synonym{From: r.SourceID, To: r.TargetID, Strength: r.Strength})
+		if r.Bidirectional {
+			synonyms = append(synonyms, labeling.Synonym{From: r.TargetID, To: r.SourceID, Strength: r.Strength})
+		}
+	}
+
+	round := func(v float64) float64 { return math.Round(v*1e6) / 1e6 }
+	roundPtr := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		r := round(*v)
+		return &r
+	}
+	res := &model.SuggestionResponse{Suggestions: []model.LabelSuggestion{}}
+	for _, sg := range labeling.Suggest(req.Text, candidates, synonyms, req.Limit) {
+		res.Suggestions = append(res.Suggestions, model.LabelSuggestion{
+			LabelID:    sg.LabelID,
+			Text:       byID[sg.LabelID].OriginalText,
+			Value:      round(sg.Value),
+			MinRange:   roundPtr(sg.MinRange),
+			MaxRange:   roundPtr(sg.MaxRange),
+			Unit:       sg.Unit,
+			Confidence: round(sg.Confidence),
+			Source:     sg.Source,
+			Via:        sg.Via,
+		})
+	}
+	return res, nil
+}
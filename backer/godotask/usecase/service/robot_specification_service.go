@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+type RobotSpecificationService struct {
+	Repo repository.RobotSpecificationRepositoryInterface
+}
+
+func (s *RobotSpecificationService) CreateRobotSpecification(robot *model.RobotSpecification) error {
+	if robot.ID == "" || robot.ModelName == "" {
+		return errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"id and model_name are required",
+		)
+	}
+	return s.Repo.Create(robot)
+}
+func (s *RobotSpecificationService) GetRobotSpecificationByID(id string) (*model.RobotSpecification, error) {
+	return s.Repo.FindByID(id)
+}
+func (s *RobotSpecificationService) ListRobotSpecifications() ([]model.RobotSpecification, error) {
+	return s.Repo.FindAll()
+}
+func (s *RobotSpecificationService) UpdateRobotSpecification(id string, robot *model.RobotSpecification) error {
+	return s.Repo.Update(id, robot)
+}
+func (s *RobotSpecificationService) DeleteRobotSpecification(id string) error {
+	return s.Repo.Delete(id)
+}
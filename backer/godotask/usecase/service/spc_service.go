@@ -0,0 +1,304 @@
+package service
+
+import (
+	stderrors "errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/godotask/domain/spc"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+const (
+	// SPC のデータ源
+	SPCSourceMeasurement = "measurement"
+	SPCSourceMultimodal  = "multimodal"
+
+	// MaxMeasurementsPerRequest は1回のリクエストで登録できる測定値の上限
+	MaxMeasurementsPerRequest = 1000
+	// MaxChartMeasurements は管理図に使う測定値（最新から）の上限
+	MaxChartMeasurements = 1000
+	// DefaultSubgroupSize は測定値にサブグループが無い場合の X-bar/R 管理図のサブグループサイズ
+	DefaultSubgroupSize = 5
+)
+
+type SPCService struct {
+	Repo repository.SPCRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+// SPCChartQuery は管理図の計算条件
+// LSL / USL を指定しない場合はラベルの MinRange / MaxRange（マルチモーダルデータの場合はその範囲）を規格限界とする
+type SPCChartQuery struct {
+	TaskID       int
+	LabelID      string
+	Source       string
+	Type         string
+	SubgroupSize int
+	Unit         string
+	LSL          *float64
+	USL          *float64
+	Limit        int
+}
+
+// SPCChartResult は管理図と工程能力の計算結果
+type SPCChartResult struct {
+	TaskID     int            `json:"task_id"`
+	LabelID    string         `json:"label_id,omitempty"`
+	Source     string         `json:"source"`
+	Unit       string         `json:"unit,omitempty"`
+	Count      int            `json:"count"`
+	Chart      *spc.Chart     `json:"chart"`
+	Capability spc.Capability `json:"capability"`
+	// SpecSource は規格限界の出所（request, label, multimodal, none）
+	SpecSource string `json:"spec_source"`
+}
+
+func (s *SPCService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// AddMeasurements はラベルに対するタスク内の測定値を登録する
+// 単位を省略した場合はラベルの単位を使う
+func (s *SPCService) AddMeasurements(userID uint, req *model.SPCMeasurementsRequest) ([]model.SPCMeasurement, error) {
+	if err := validateMeasurements(req.Measurements); err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+	label, err := s.Repo.FindLabel(userID, req.TaskID, req.LabelID)
+	if err != nil {
+		return nil, err
+	}
+	unit := req.Unit
+	if unit == "" && label.Unit != nil {
+		unit = *label.Unit
+	}
+
+	now := s.now()
+	measurements := make([]model.SPCMeasurement, 0, len(req.Measurements))
+	for _, in := range req.Measurements {
+		at := now
+		if in.MeasuredAt != nil {
+			at = *in.MeasuredAt
+		}
+		measurements = append(measurements, model.SPCMeasurement{
+			UserID:     userID,
+			TaskID:     req.TaskID,
+			LabelID:    req.LabelID,
+			Value:      *in.Value,
+			Unit:       unit,
+			Subgroup:   in.Subgroup,
+			MeasuredAt: at,
+		})
+	}
+	if err := s.Repo.CreateMeasurements(userID, req.TaskID, measurements); err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
+
+func (s *SPCService) ListMeasurements(userID uint, taskID int, labelID string, limit int) ([]model.SPCMeasurement, error) {
+	return s.Repo.FindMeasurements(userID, taskID, labelID, clampLimit(limit))
+}
+
+// Chart は測定値から管理図・Western Electric ルール違反・工程能力指数を計算する
+func (s *SPCService) Chart(userID uint, q SPCChartQuery) (*SPCChartResult, error) {
+	if err := normalizeChartQuery(&q); err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+
+	result := &SPCChartResult{TaskID: q.TaskID, LabelID: q.LabelID, Source: q.Source, Unit: q.Unit, SpecSource: "none"}
+	var values []float64
+	var keys []string
+	var lsl, usl *float64
+
+	switch q.Source {
+	case SPCSourceMeasurement:
+		if q.LabelID == "" {
+			return nil, errors.NewAppError(
+				errors.VAL_MISSING_FIELD,
+				errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+				"label_id is required",
+			)
+		}
+		label, err := s.Repo.FindLabel(userID, q.TaskID, q.LabelID)
+		if err != nil {
+			return nil, err
+		}
+		measurements, err := s.Repo.FindMeasurements(userID, q.TaskID, q.LabelID, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range measurements {
+			values = append(values, m.Value)
+			keys = append(keys, m.Subgroup)
+		}
+		if result.Unit == "" && label.Unit != nil {
+			result.Unit = *label.Unit
+		}
+		lsl, usl = label.MinRange, label.MaxRange
+		if lsl != nil || usl != nil {
+			result.SpecSource = "label"
+		}
+	case SPCSourceMultimodal:
+		data, err := s.Repo.FindMultimodal(userID, q.TaskID, q.Unit, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range data {
+			values = append(values, d.Value)
+			keys = append(keys, "")
+			// 範囲が記録された最初のデータを規格限界とする
+			if lsl == nil && usl == nil && d.MaxRange > d.MinRange {
+				lo, hi := d.MinRange, d.MaxRange
+				lsl, usl = &lo, &hi
+				result.SpecSource = "multimodal"
+			}
+		}
+	}
+	if q.LSL != nil || q.USL != nil {
+		lsl, usl = q.LSL, q.USL
+		result.SpecSource = "request"
+	}
+	if lsl != nil && usl != nil && *lsl >= *usl {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"lsl must be less than usl",
+		)
+	}
+	result.Count = len(values)
+
+	var chart *spc.Chart
+	var err error
+	if q.Type == spc.ChartXbarR {
+		subgroups, labels := buildSubgroups(values, keys, q.SubgroupSize)
+		chart, err = spc.XbarRChart(subgroups, labels)
+	} else {
+		chart, err = spc.IndividualsChart(values, nil)
+	}
+	if stderrors.Is(err, spc.ErrInsufficientData) {
+		return nil, errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			fmt.Sprintf("%s (%d measurements)", err.Error(), len(values)),
+		)
+	}
+	if err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+	result.Chart = chart
+	result.Capability = spc.ComputeCapability(values, chart.SigmaWithin, lsl, usl)
+	return result, nil
+}
+
+func normalizeChartQuery(q *SPCChartQuery) error {
+	if q.TaskID <= 0 {
+		return fmt.Errorf("task_id is required")
+	}
+	if q.Source == "" {
+		q.Source = SPCSourceMeasurement
+	}
+	if q.Source != SPCSourceMeasurement && q.Source != SPCSourceMultimodal {
+		return fmt.Errorf("source must be %s or %s", SPCSourceMeasurement, SPCSourceMultimodal)
+	}
+	if q.Type == "" {
+		q.Type = spc.ChartIndividuals
+	}
+	if q.Type != spc.ChartIndividuals && q.Type != spc.ChartXbarR {
+		return fmt.Errorf("type must be %s or %s", spc.ChartIndividuals, spc.ChartXbarR)
+	}
+	if q.SubgroupSize != 0 && (q.SubgroupSize < spc.MinSubgroupSize || q.SubgroupSize > spc.MaxSubgroupSize) {
+		return fmt.Errorf("subgroup_size must be between %d and %d", spc.MinSubgroupSize, spc.MaxSubgroupSize)
+	}
+	q.Limit = clampLimit(q.Limit)
+	return nil
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 || limit > MaxChartMeasurements {
+		return MaxChartMeasurements
+	}
+	return limit
+}
+
+// buildSubgroups は測定値をサブグループに分ける
+// size を指定しない場合、すべての測定値にサブグループがあればそれで分け、無ければ既定サイズで順に区切る
+// 区切った場合の端数は捨てる
+func buildSubgroups(values []float64, keys []string, size int) ([][]float64, []string) {
+	if size == 0 && allKeyed(keys) {
+		var groups [][]float64
+		var labels []string
+		index := map[string]int{}
+		for i, v := range values {
+			n, ok := index[keys[i]]
+			if !ok {
+				n = len(groups)
+				index[keys[i]] = n
+				groups = append(groups, nil)
+				labels = append(labels, keys[i])
+			}
+			groups[n] = append(groups[n], v)
+		}
+		return groups, labels
+	}
+	if size == 0 {
+		size = DefaultSubgroupSize
+	}
+	var groups [][]float64
+	var labels []string
+	for start := 0; start+size <= len(values); start += size {
+		groups = append(groups, values[start:start+size])
+		labels = append(labels, strconv.Itoa(len(groups)))
+	}
+	return groups, labels
+}
+
+func allKeyed(keys []string) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	for _, k := range keys {
+		if k == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func validateMeasurements(inputs []model.SPCMeasurementInput) error {
+	if len(inputs) == 0 {
+		return fmt.Errorf("measurements must not be empty")
+	}
+	if len(inputs) > MaxMeasurementsPerRequest {
+		return fmt.Errorf("at most %d measurements can be sent at once", MaxMeasurementsPerRequest)
+	}
+	for i, in := range inputs {
+		if in.Value == nil {
+			return fmt.Errorf("measurements[%d]: value is required", i)
+		}
+		if math.IsNaN(*in.Value) || math.IsInf(*in.Value, 0) {
+			return fmt.Errorf("measurements[%d]: value must be a finite number", i)
+		}
+	}
+	return nil
+}
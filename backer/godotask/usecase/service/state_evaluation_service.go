@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"github.com/godotask/domain/evaluation"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+type StateEvaluationService struct {
+	Repo repository.StateEvaluationRepositoryInterface
+}
+
+// StateEvaluationHistory は作業対象ごとの評価の推移
+type StateEvaluationHistory struct {
+	WorkerID    string                  `json:"worker_id"`
+	WorkTarget  string                  `json:"work_target"`
+	Points      []evaluation.Point      `json:"points"`
+	Convergence evaluation.Convergence  `json:"convergence"`
+	Evaluations []model.StateEvaluation `json:"evaluations"`
+}
+
+// Evaluate は現在状態と目標状態のギャップを分析し、評価結果として保存する
+func (s *StateEvaluationService) Evaluate(userID uint, req *model.EvaluationRequest) (*model.StateEvaluation, error) {
+	directions := make(map[string]evaluation.Direction, len(req.Directions))
+	for name, d := range req.Directions {
+		directions[name] = evaluation.Direction(d)
+	}
+	analysis, err := evaluation.Analyze(req.CurrentState, req.TargetState, directions)
+	if err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+
+	currentState, err := json.Marshal(req.CurrentState)
+	if err != nil {
+		return nil, err
+	}
+	targetState, err := json.Marshal(req.TargetState)
+	if err != nil {
+		return nil, err
+	}
+	results, err := json.Marshal(map[string]interface{}{
+		"gap":                analysis.GapVector(),
+		"dimensions":         analysis.Dimensions,
+		"gap_norm":           analysis.GapNorm,
+		"missing_dimensions": analysis.Missing,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stateEvaluation := &model.StateEvaluation{
+		ID:              uuid.New().String(),
+		UserID:          req.UserID,
+		TaskID:          req.TaskID,
+		Level:           req.Level,
+		WorkTarget:      req.WorkTarget,
+		CurrentState:    datatypes.JSON(currentState),
+		TargetState:     datatypes.JSON(targetState),
+		EvaluationScore: analysis.Score,
+		Framework:       req.Framework,
+		Results:         datatypes.JSON(results),
+		Status:          "completed",
+	}
+	if err := s.Repo.Create(userID, stateEvaluation); err != nil {
+		return nil, err
+	}
+	return stateEvaluation, nil
+}
+
+func (s *StateEvaluationService) GetStateEvaluationByID(userID uint, id string) (*model.StateEvaluation, error) {
+	return s.Repo.FindByID(userID, id)
+}
+
+func (s *StateEvaluationService) ListStateEvaluations(userID uint) ([]model.StateEvaluation, error) {
+	return s.Repo.FindAll(userID)
+}
+
+// GetHistory は作業者の作業対象に対する評価履歴と収束状況を返す
+func (s *StateEvaluationService) GetHistory(userID uint, workerID string, workTarget string) (*StateEvaluationHistory, error) {
+	if workTarget == "" {
+		return nil, errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"work_target is required",
+		)
+	}
+	evaluations, err := s.Repo.History(userID, workerID, workTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]evaluation.Point, 0, len(evaluations))
+	for _, e := range evaluations {
+		var results struct {
+			GapNorm float64 `json:"gap_norm"`
+		}
+		// 結果が無い・壊れている評価はギャップ 0 として扱う
+		_ = json.Unmarshal(e.Results, &results)
+		points = append(points, evaluation.Point{At: e.CreatedAt, Score: e.EvaluationScore, GapNorm: results.GapNorm})
+	}
+
+	return &StateEvaluationHistory{
+		WorkerID:    workerID,
+		WorkTarget:  workTarget,
+		Points:      points,
+		Convergence: evaluation.Summarize(points),
+		Evaluations: evaluations,
+	}, nil
+}
@@ -10,11 +10,12 @@ type TaskService struct {
 	Repo repository.TaskRepositoryInterface
 }
 
-func (s *TaskService) CreateTask(task *model.Task) error {
+func (s *TaskService) CreateTask(userID uint, task *model.Task) error {
+	task.UserID = int(userID)
 	return s.Repo.Create(task)
 }
-func (s *TaskService) GetTaskByID(id string) (*model.Task, error) {
-	return s.Repo.FindByID(id)
+func (s *TaskService) GetTaskByID(userID uint, id string) (*model.Task, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *TaskService) ListTasks(userID uint) ([]model.Task, error) {
 	return s.Repo.FindAll(userID)
@@ -29,9 +30,9 @@ func (s *TaskService) ListSearchTasksPager(filter dtoquery.QueryFilter, pager dt
 func (s *TaskService) ListTasksByUserPager(userID uint, page int, perPage int, offset int) ([]model.Task, int64, error) {
     return s.Repo.ListTasksByUserPager(userID, offset, perPage)
 }
-func (s *TaskService) UpdateTask(id string, task *model.Task) error {
-	return s.Repo.Update(id, task)
+func (s *TaskService) UpdateTask(userID uint, id string, task *model.Task) error {
+	return s.Repo.Update(userID, id, task)
 }
-func (s *TaskService) DeleteTask(id string) error {
-	return s.Repo.Delete(id)
+func (s *TaskService) DeleteTask(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
@@ -1,26 +1,249 @@
 package service
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/godotask/domain/learning"
+	"github.com/godotask/domain/simulation"
+	"github.com/godotask/errors"
 	"github.com/godotask/infrastructure/db/model"
 	"github.com/godotask/infrastructure/db/repository"
 )
 
+const (
+	// MaxAttemptsPerRequest は1回のリクエストで登録できる試行の上限
+	MaxAttemptsPerRequest = 1000
+	// MaxAnalyticsAttempts は成功率・学習曲線の計算に使う試行（最新から）の上限
+	MaxAnalyticsAttempts = 10000
+)
+
 type TeachingFreeControlService struct {
-  Repo repository.TeachingFreeControlRepositoryInterface
+	Repo repository.TeachingFreeControlRepositoryInterface
+	// Now はテスト用に差し替えられる現在時刻
+	Now func() time.Time
+}
+
+// TeachingFreeControlAnalytics は試行の集計と学習曲線
+// 制御設定単位の場合は TeachingFreeControlID、ロボット・タスク種別単位の場合は RobotID / TaskType が集計条件
+type TeachingFreeControlAnalytics struct {
+	TeachingFreeControlID string                `json:"teaching_free_control_id,omitempty"`
+	RobotID               string                `json:"robot_id,omitempty"`
+	TaskType              string                `json:"task_type,omitempty"`
+	Window                int                   `json:"window"`
+	Stats                 learning.Stats        `json:"stats"`
+	Curve                 []learning.CurvePoint `json:"curve"`
+}
+
+// TeachingFreeControlSimulation はシミュレーションの結果
+type TeachingFreeControlSimulation struct {
+	Config              simulation.Config                  `json:"config"`
+	Recorded            bool                               `json:"recorded"`
+	Stats               learning.Stats                     `json:"stats"`
+	TeachingFreeControl *model.TeachingFreeControl         `json:"teaching_free_control"`
+	Attempts            []model.TeachingFreeControlAttempt `json:"attempts"`
 }
 
-func (s *TeachingFreeControlService) CreateTeachingFreeControl(teachingFreeControl *model.TeachingFreeControl) error {
-	return s.Repo.Create(teachingFreeControl)
+func (s *TeachingFreeControlService) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
 }
-func (s *TeachingFreeControlService) GetTeachingFreeControlByID(id string) (*model.TeachingFreeControl, error) {
-	return s.Repo.FindByID(id)
+
+// CreateTeachingFreeControl は制御設定を作成する。SuccessRate / AdaptationTime は試行から計算するため受け付けない
+func (s *TeachingFreeControlService) CreateTeachingFreeControl(userID uint, teachingFreeControl *model.TeachingFreeControl) error {
+	teachingFreeControl.SuccessRate = 0
+	teachingFreeControl.AdaptationTime = 0
+	return s.Repo.Create(userID, teachingFreeControl)
+}
+func (s *TeachingFreeControlService) GetTeachingFreeControlByID(userID uint, id string) (*model.TeachingFreeControl, error) {
+	return s.Repo.FindByID(userID, id)
 }
 func (s *TeachingFreeControlService) ListTeachingFreeControls(userID uint) ([]model.TeachingFreeControl, error) {
 	return s.Repo.FindAll(userID)
 }
-func (s *TeachingFreeControlService) UpdateTeachingFreeControl(id string, teachingFreeControl *model.TeachingFreeControl) error {
-	return s.Repo.Update(id, teachingFreeControl)
+
+// UpdateTeachingFreeControl は制御設定を更新する。SuccessRate / AdaptationTime は試行から計算するため更新しない
+func (s *TeachingFreeControlService) UpdateTeachingFreeControl(userID uint, id string, teachingFreeControl *model.TeachingFreeControl) error {
+	teachingFreeControl.SuccessRate = 0
+	teachingFreeControl.AdaptationTime = 0
+	return s.Repo.Update(userID, id, teachingFreeControl)
+}
+func (s *TeachingFreeControlService) DeleteTeachingFreeControl(userID uint, id string) error {
+	return s.Repo.Delete(userID, id)
 }
-func (s *TeachingFreeControlService) DeleteTeachingFreeControl(id string) error {
-	return s.Repo.Delete(id)
+
+// RecordAttempts は制御設定での実行試行を登録し、全試行から SuccessRate / AdaptationTime を計算し直す
+func (s *TeachingFreeControlService) RecordAttempts(userID uint, id string, req *model.TeachingFreeControlAttemptsRequest) (*model.TeachingFreeControl, []model.TeachingFreeControlAttempt, error) {
+	if len(req.Attempts) > MaxAttemptsPerRequest {
+		return nil, nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			fmt.Sprintf("at most %d attempts can be recorded at once", MaxAttemptsPerRequest),
+		)
+	}
+	now := s.now()
+	attempts := make([]model.TeachingFreeControlAttempt, 0, len(req.Attempts))
+	for _, in := range req.Attempts {
+		at := now
+		if in.AttemptedAt != nil {
+			at = *in.AttemptedAt
+		}
+		source := in.Source
+		if source == "" {
+			source = model.AttemptSourceRobot
+		}
+		attempts = append(attempts, model.TeachingFreeControlAttempt{
+			Outcome:        in.Outcome,
+			ErrorType:      in.ErrorType,
+			RecoveryAction: in.RecoveryAction,
+			Recovered:      in.Recovered,
+			Duration:       *in.Duration,
+			Source:         source,
+			Detail:         in.Detail,
+			AttemptedAt:    at,
+		})
+	}
+	control, err := s.Repo.RecordAttempts(userID, id, attempts, deriveFromAttempts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return control, attempts, nil
+}
+
+// Simulate は制御設定の vision_system / force_control / ai_model / error_recovery をシミュレータで trials 回実行する
+// 試行は実機と同じ形式で返し、Record の場合は登録して SuccessRate / AdaptationTime を計算し直す
+func (s *TeachingFreeControlService) Simulate(userID uint, id string, req *model.TeachingFreeControlSimulationRequest) (*TeachingFreeControlSimulation, error) {
+	control, err := s.Repo.FindByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	invalid := func(err error) error {
+		return errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+	cfg, err := simulation.ParseConfig(control.VisionSystem, control.ForceControl, control.AIModel, control.ErrorRecovery)
+	if err != nil {
+		return nil, invalid(err)
+	}
+	trials, err := simulation.Run(cfg, req.Trials, req.Seed)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	start := s.now()
+	if req.StartAt != nil {
+		start = *req.StartAt
+	}
+	attempts := make([]model.TeachingFreeControlAttempt, 0, len(trials))
+	for _, t := range trials {
+		attempts = append(attempts, model.TeachingFreeControlAttempt{
+			TeachingFreeControlID: control.ID,
+			RobotID:               control.RobotID,
+			TaskType:              control.TaskType,
+			Outcome:               t.Outcome,
+			ErrorType:             t.ErrorType,
+			RecoveryAction:        t.RecoveryAction,
+			Recovered:             t.Recovered,
+			Duration:              t.Duration,
+			Source:                model.AttemptSourceSimulation,
+			Detail:                model.JSON(t.Detail),
+			AttemptedAt:           start.Add(t.Offset),
+		})
+	}
+	if req.Record {
+		if control, err = s.Repo.RecordAttempts(userID, id, attempts, deriveFromAttempts); err != nil {
+			return nil, err
+		}
+	}
+	return &TeachingFreeControlSimulation{
+		Config:              cfg,
+		Recorded:            req.Record,
+		Stats:               learning.Summarize(toLearningAttempts(attempts)),
+		TeachingFreeControl: control,
+		Attempts:            attempts,
+	}, nil
+}
+
+// deriveFromAttempts は記録済みの全試行から SuccessRate / AdaptationTime を計算する
+func deriveFromAttempts(c *model.TeachingFreeControl, all []model.TeachingFreeControlAttempt) error {
+	stats := learning.Summarize(toLearningAttempts(all))
+	c.SuccessRate = stats.SuccessRate.Value
+	c.AdaptationTime = 0
+	if stats.AdaptationTime != nil {
+		c.AdaptationTime = stats.AdaptationTime.Value
+	}
+	return nil
+}
+
+func (s *TeachingFreeControlService) ListAttempts(userID uint, id string, limit int) ([]model.TeachingFreeControlAttempt, error) {
+	if limit <= 0 || limit > MaxAnalyticsAttempts {
+		limit = MaxAnalyticsAttempts
+	}
+	return s.Repo.FindAttempts(userID, id, limit)
+}
+
+// Analytics は制御設定の試行から成功率・適応時間の信頼区間と window 回ごとの学習曲線を計算する
+func (s *TeachingFreeControlService) Analytics(userID uint, id string, window int) (*TeachingFreeControlAnalytics, error) {
+	attempts, err := s.Repo.FindAttempts(userID, id, MaxAnalyticsAttempts)
+	if err != nil {
+		return nil, err
+	}
+	a := analyze(attempts, window)
+	a.TeachingFreeControlID = id
+	return a, nil
+}
+
+// LearningCurve はロボット・タスク種別ごとに、参照できるすべての制御設定の試行をまとめて学習曲線を計算する
+func (s *TeachingFreeControlService) LearningCurve(userID uint, robotID, taskType string, window int) (*TeachingFreeControlAnalytics, error) {
+	if robotID == "" && taskType == "" {
+		return nil, errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"robot_id or task_type is required",
+		)
+	}
+	attempts, err := s.Repo.FindAttemptsBy(userID, robotID, taskType, MaxAnalyticsAttempts)
+	if err != nil {
+		return nil, err
+	}
+	a := analyze(attempts, window)
+	a.RobotID = robotID
+	a.TaskType = taskType
+	return a, nil
+}
+
+func analyze(attempts []model.TeachingFreeControlAttempt, window int) *TeachingFreeControlAnalytics {
+	if window <= 0 {
+		window = learning.DefaultWindow
+	}
+	converted := toLearningAttempts(attempts)
+	curve := learning.Curve(converted, window)
+	if curve == nil {
+		curve = []learning.CurvePoint{}
+	}
+	return &TeachingFreeControlAnalytics{
+		Window: window,
+		Stats:  learning.Summarize(converted),
+		Curve:  curve,
+	}
+}
+
+func toLearningAttempts(attempts []model.TeachingFreeControlAttempt) []learning.Attempt {
+	out := make([]learning.Attempt, 0, len(attempts))
+	for _, a := range attempts {
+		out = append(out, learning.Attempt{
+			Success:        a.Outcome == model.AttemptSuccess,
+			Duration:       a.Duration,
+			ErrorType:      a.ErrorType,
+			RecoveryAction: a.RecoveryAction,
+			Recovered:      a.Recovered,
+			AttemptedAt:    a.AttemptedAt,
+		})
+	}
+	return out
 }
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+
+	"github.com/godotask/domain/matching"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+// ToolMatchingService は状態評価の要求に対してロボットを評価・順位付けする
+type ToolMatchingService struct {
+	Robots      repository.RobotSpecificationRepositoryInterface
+	Evaluations repository.StateEvaluationRepositoryInterface
+	Results     repository.ToolMatchingResultRepositoryInterface
+}
+
+// ToolMatchingOutcome はマッチングの結果。制約を満たさないロボットは Excluded に理由とともに返す
+type ToolMatchingOutcome struct {
+	StateEvaluationID string                     `json:"state_evaluation_id"`
+	Results           []model.ToolMatchingResult `json:"results"`
+	Excluded          []matching.Exclusion       `json:"excluded"`
+}
+
+// Match は登録済みロボットを要求・制約・重みで評価し、順位付きの結果を保存する
+// 状態評価の tools.robot_requirements を既定の要求とし、リクエストの requirements で上書きする
+func (s *ToolMatchingService) Match(userID uint, req *model.ToolMatchingRequest) (*ToolMatchingOutcome, error) {
+	stateEvaluation, err := s.Evaluations.FindByID(userID, req.StateEvaluationID)
+	if err != nil {
+		return nil, err
+	}
+
+	matchReq, err := buildMatchingRequest(stateEvaluation, req)
+	if err != nil {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			err.Error(),
+		)
+	}
+
+	specs, err := s.Robots.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	robots := make([]matching.Robot, 0, len(specs))
+	byID := make(map[string]model.RobotSpecification, len(specs))
+	for _, spec := range specs {
+		robots = append(robots, ToMatchingRobot(spec))
+		byID[spec.ID] = spec
+	}
+
+	matches, excluded := matching.Rank(robots, matchReq)
+	results := make([]model.ToolMatchingResult, 0, len(matches))
+	for _, m := range matches {
+		result, err := toToolMatchingResult(stateEvaluation.ID, byID[m.Robot.ID], m)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	if err := s.Results.ReplaceForEvaluation(userID, stateEvaluation.ID, results); err != nil {
+		return nil, err
+	}
+	return &ToolMatchingOutcome{StateEvaluationID: stateEvaluation.ID, Results: results, Excluded: excluded}, nil
+}
+
+// ListResults は状態評価に対して保存済みのマッチング結果を順位順に返す
+func (s *ToolMatchingService) ListResults(userID uint, stateEvaluationID string) ([]model.ToolMatchingResult, error) {
+	return s.Results.FindByEvaluation(userID, stateEvaluationID)
+}
+
+// ToMatchingRobot はロボット仕様をマッチングエンジンの入力に変換する
+func ToMatchingRobot(spec model.RobotSpecification) matching.Robot {
+	features := []string{spec.TeachingMethod, spec.ControlType, spec.VisionSystem, spec.ForceSensor, spec.WorkEnvelopeShape}
+	for _, raw := range []datatypes.JSON{spec.AICapability, spec.SafetyFeatures} {
+		var list []string
+		if len(raw) > 0 && json.Unmarshal(raw, &list) == nil {
+			features = append(features, list...)
+		}
+	}
+	return matching.Robot{
+		ID:               spec.ID,
+		ModelName:        spec.ModelName,
+		DOF:              spec.DOF,
+		ReachMm:          spec.ReachMm,
+		PayloadKg:        spec.PayloadKg,
+		RepeatAccuracyMm: spec.RepeatAccuracyMm,
+		MaxSpeedMmS:      spec.MaxSpeedMmS,
+		TeachingMethod:   spec.TeachingMethod,
+		ControlType:      spec.ControlType,
+		Features:         matching.NormalizeFeatures(features),
+	}
+}
+
+func buildMatchingRequest(stateEvaluation *model.StateEvaluation, req *model.ToolMatchingRequest) (matching.Request, error) {
+	var tools struct {
+		RobotRequirements map[string]interface{} `json:"robot_requirements"`
+	}
+	// tools が robot_requirements を持たない（自由形式の）場合は既定の要求なしとする
+	_ = json.Unmarshal(stateEvaluation.Tools, &tools)
+
+	base, err := matching.ParseCriteria(tools.RobotRequirements)
+	if err != nil {
+		return matching.Request{}, err
+	}
+	requirements, err := matching.ParseCriteria(req.Requirements)
+	if err != nil {
+		return matching.Request{}, err
+	}
+	constraints, err := matching.ParseCriteria(req.Constraints)
+	if err != nil {
+		return matching.Request{}, err
+	}
+	weights, err := matching.ParseWeights(req.Preferences)
+	if err != nil {
+		return matching.Request{}, err
+	}
+	return matching.Request{
+		Requirements: base.Merge(requirements),
+		Constraints:  constraints,
+		Weights:      weights,
+	}, nil
+}
+
+func toToolMatchingResult(stateEvaluationID string, spec model.RobotSpecification, m matching.Match) (model.ToolMatchingResult, error) {
+	recommendations, err := json.Marshal(map[string]interface{}{
+		"robot": map[string]interface{}{
+			"model":            spec.ModelName,
+			"dof":              spec.DOF,
+			"payload_capacity": spec.PayloadKg,
+			"reach":            spec.ReachMm,
+			"precision":        spec.RepeatAccuracyMm,
+		},
+		"criterion_scores":   m.CriterionScores,
+		"unmet_requirements": m.Unmet,
+	})
+	if err != nil {
+		return model.ToolMatchingResult{}, err
+	}
+	parameters, err := json.Marshal(map[string]interface{}{"robot": m.Parameters})
+	if err != nil {
+		return model.ToolMatchingResult{}, err
+	}
+	performance, err := json.Marshal(m.ExpectedPerformance)
+	if err != nil {
+		return model.ToolMatchingResult{}, err
+	}
+	return model.ToolMatchingResult{
+		ID:                  uuid.New().String(),
+		StateEvaluationID:   stateEvaluationID,
+		RobotID:             spec.ID,
+		MatchingScore:       m.Score,
+		Rank:                m.Rank,
+		Recommendations:     datatypes.JSON(recommendations),
+		Parameters:          datatypes.JSON(parameters),
+		ExpectedPerformance: datatypes.JSON(performance),
+	}, nil
+}
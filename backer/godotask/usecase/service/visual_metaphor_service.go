@@ -0,0 +1,68 @@
+package service
+
+import (
+	"math"
+
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+)
+
+type VisualMetaphorService struct {
+	Repo repository.VisualMetaphorRepositoryInterface
+}
+
+// validateVisualMetaphor は寸法（mm）が 0 以上で、ばらつきの範囲が min <= max であることを確認する
+func validateVisualMetaphor(metaphor *model.VisualMetaphor) error {
+	invalid := func(detail string) error {
+		return errors.NewAppError(errors.VAL_INVALID_INPUT, errors.GetErrorMessage(errors.VAL_INVALID_INPUT), detail)
+	}
+	for _, v := range []float64{metaphor.Width, metaphor.Height, metaphor.Depth, metaphor.MinVariability, metaphor.MaxVariability} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return invalid("dimensions and variability must be finite numbers")
+		}
+	}
+	if metaphor.Width < 0 || metaphor.Height < 0 || metaphor.Depth < 0 {
+		return invalid("dimensions must not be negative")
+	}
+	if metaphor.MinVariability > metaphor.MaxVariability {
+		return invalid("min_variability must not exceed max_variability")
+	}
+	return nil
+}
+
+func (s *VisualMetaphorService) CreateVisualMetaphor(metaphor *model.VisualMetaphor) error {
+	if metaphor.ID == "" || metaphor.ReferenceObject == "" {
+		return errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"id and reference_object are required",
+		)
+	}
+	if err := validateVisualMetaphor(metaphor); err != nil {
+		return err
+	}
+	if metaphor.Width == 0 && metaphor.Height == 0 && metaphor.Depth == 0 {
+		return errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"at least one of width, height and depth is required",
+		)
+	}
+	return s.Repo.Create(metaphor)
+}
+func (s *VisualMetaphorService) GetVisualMetaphorByID(id string) (*model.VisualMetaphor, error) {
+	return s.Repo.FindByID(id)
+}
+func (s *VisualMetaphorService) ListVisualMetaphors() ([]model.VisualMetaphor, error) {
+	return s.Repo.FindAll()
+}
+func (s *VisualMetaphorService) UpdateVisualMetaphor(id string, metaphor *model.VisualMetaphor) error {
+	if err := validateVisualMetaphor(metaphor); err != nil {
+		return err
+	}
+	return s.Repo.Update(id, metaphor)
+}
+func (s *VisualMetaphorService) DeleteVisualMetaphor(id string) error {
+	return s.Repo.Delete(id)
+}
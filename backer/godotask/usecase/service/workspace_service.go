@@ -0,0 +1,273 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/errors"
+	"github.com/godotask/infrastructure/db/model"
+	"github.com/godotask/infrastructure/db/repository"
+	"gorm.io/gorm"
+)
+
+// InvitationTTL は招待トークンの有効期間
+const InvitationTTL = 7 * 24 * time.Hour
+
+type WorkspaceService struct {
+	Repo repository.WorkspaceRepositoryInterface
+}
+
+// CreateWorkspace: ワークスペースを作成し、作成者を owner にする
+func (s *WorkspaceService) CreateWorkspace(userID uint, workspace *model.Workspace) error {
+	if strings.TrimSpace(workspace.Name) == "" {
+		return errors.NewAppError(
+			errors.VAL_MISSING_FIELD,
+			errors.GetErrorMessage(errors.VAL_MISSING_FIELD),
+			"name is required",
+		)
+	}
+	return s.Repo.Create(workspace, userID)
+}
+
+func (s *WorkspaceService) ListWorkspaces(userID uint) ([]model.Workspace, error) {
+	return s.Repo.ListByUser(userID)
+}
+
+// GetWorkspace: メンバーのみ参照できる（userID が 0 の場合は制限しない）
+func (s *WorkspaceService) GetWorkspace(userID, id uint) (*model.Workspace, error) {
+	if _, err := s.requireRole(userID, id, false); err != nil {
+		return nil, err
+	}
+	return s.Repo.FindByID(id)
+}
+
+func (s *WorkspaceService) UpdateWorkspace(userID, id uint, workspace *model.Workspace) error {
+	if _, err := s.requireRole(userID, id, true); err != nil {
+		return err
+	}
+	return s.Repo.Update(id, workspace)
+}
+
+func (s *WorkspaceService) DeleteWorkspace(userID, id uint) error {
+	if _, err := s.requireRole(userID, id, true); err != nil {
+		return err
+	}
+	return s.Repo.Delete(id)
+}
+
+// UpdateMemberRole: owner がメンバーのロールを変更する。最後の owner は降格できない
+func (s *WorkspaceService) UpdateMemberRole(userID, id, memberUserID uint, role string) error {
+	if !authz.IsValidWorkspaceRole(role) {
+		return errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"role must be one of owner, editor, viewer",
+		)
+	}
+	if _, err := s.requireRole(userID, id, true); err != nil {
+		return err
+	}
+	member, err := s.findMember(id, memberUserID)
+	if err != nil {
+		return err
+	}
+	if member.Role == string(authz.WorkspaceOwner) && role != string(authz.WorkspaceOwner) {
+		if err := s.ensureAnotherOwner(id); err != nil {
+			return err
+		}
+	}
+	return s.Repo.UpdateMemberRole(id, memberUserID, role)
+}
+
+// RemoveMember: owner はメンバーを外せる。メンバー自身は脱退できる。最後の owner は外せない
+func (s *WorkspaceService) RemoveMember(userID, id, memberUserID uint) error {
+	if userID != memberUserID {
+		if _, err := s.requireRole(userID, id, true); err != nil {
+			return err
+		}
+	}
+	member, err := s.findMember(id, memberUserID)
+	if err != nil {
+		return err
+	}
+	if member.Role == string(authz.WorkspaceOwner) {
+		if err := s.ensureAnotherOwner(id); err != nil {
+			return err
+		}
+	}
+	return s.Repo.RemoveMember(id, memberUserID)
+}
+
+// InviteMember: メールアドレス宛の招待を作成し、招待トークンを返す
+// トークンはハッシュのみ保存するため、返り値のトークンを招待メールに記載して送る
+func (s *WorkspaceService) InviteMember(userID, id uint, email, role string) (string, *model.WorkspaceInvitation, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return "", nil, errors.NewAppError(
+			errors.VAL_INVALID_FORMAT,
+			errors.GetErrorMessage(errors.VAL_INVALID_FORMAT),
+			"a valid email is required",
+		)
+	}
+	if role == "" {
+		role = string(authz.WorkspaceEditor)
+	}
+	if !authz.IsValidWorkspaceRole(role) {
+		return "", nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"role must be one of owner, editor, viewer",
+		)
+	}
+	if _, err := s.requireRole(userID, id, true); err != nil {
+		return "", nil, err
+	}
+
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return "", nil, err
+	}
+	invitation := &model.WorkspaceInvitation{
+		WorkspaceID: id,
+		Email:       email,
+		Role:        role,
+		TokenHash:   hash,
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().Add(InvitationTTL),
+	}
+	if err := s.Repo.CreateInvitation(invitation); err != nil {
+		return "", nil, err
+	}
+	return token, invitation, nil
+}
+
+func (s *WorkspaceService) ListInvitations(userID, id uint) ([]model.WorkspaceInvitation, error) {
+	if _, err := s.requireRole(userID, id, true); err != nil {
+		return nil, err
+	}
+	return s.Repo.ListPendingInvitations(id, time.Now())
+}
+
+func (s *WorkspaceService) RevokeInvitation(userID, id, invitationID uint) error {
+	if _, err := s.requireRole(userID, id, true); err != nil {
+		return err
+	}
+	if err := s.Repo.DeleteInvitation(id, invitationID); err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.ErrResourceNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// AcceptInvitation: 招待先のメールアドレスと一致するユーザーのみ承諾できる
+func (s *WorkspaceService) AcceptInvitation(userID uint, token string) (*model.WorkspaceMember, error) {
+	invitation, err := s.Repo.FindInvitationByHash(hashInvitationToken(token))
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if invitation.AcceptedAt != nil || !now.Before(invitation.ExpiresAt) {
+		return nil, errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			"invitation has already been used or has expired",
+		)
+	}
+
+	email, err := s.Repo.FindUserEmail(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(email, invitation.Email) {
+		return nil, errors.NewAppError(
+			errors.RES_ACCESS_DENIED,
+			errors.GetErrorMessage(errors.RES_ACCESS_DENIED),
+			"invitation was sent to a different email",
+		)
+	}
+
+	member, err := s.Repo.AcceptInvitation(invitation, userID, now)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.NewAppError(
+			errors.BIZ_INVALID_STATE,
+			errors.GetErrorMessage(errors.BIZ_INVALID_STATE),
+			"invitation has already been used or has expired",
+		)
+	}
+	return member, err
+}
+
+// requireRole: userID がワークスペースのメンバーであるかを確認する
+// manage が true の場合は owner のみ許可する。userID が 0（admin）の場合は存在確認のみ
+func (s *WorkspaceService) requireRole(userID, id uint, manage bool) (*model.WorkspaceMember, error) {
+	if userID == 0 {
+		if _, err := s.Repo.FindByID(id); err != nil {
+			if stderrors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.ErrResourceNotFound
+			}
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	member, err := s.Repo.FindMember(id, userID)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ErrResourceAccessDenied
+	}
+	if err != nil {
+		return nil, err
+	}
+	if manage && !authz.CanManageWorkspace(member.Role) {
+		return nil, errors.ErrResourceAccessDenied
+	}
+	return member, nil
+}
+
+func (s *WorkspaceService) findMember(id, userID uint) (*model.WorkspaceMember, error) {
+	member, err := s.Repo.FindMember(id, userID)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ErrResourceNotFound
+	}
+	return member, err
+}
+
+func (s *WorkspaceService) ensureAnotherOwner(id uint) error {
+	owners, err := s.Repo.CountOwners(id)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return errors.NewAppError(
+			errors.BIZ_OPERATION_NOT_ALLOWED,
+			errors.GetErrorMessage(errors.BIZ_OPERATION_NOT_ALLOWED),
+			"workspace must keep at least one owner",
+		)
+	}
+	return nil
+}
+
+func newInvitationToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashInvitationToken(token), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	stderrors "errors"
+	"time"
+
+	"github.com/godotask/domain/entity"
+	domainRepo "github.com/godotask/domain/repository"
+	"github.com/godotask/errors"
+	"gorm.io/gorm"
+)
+
+type SessionUsecase struct {
+	sessionRepo domainRepo.SessionRepository
+}
+
+func NewSessionUsecase(sessionRepo domainRepo.SessionRepository) *SessionUsecase {
+	return &SessionUsecase{sessionRepo: sessionRepo}
+}
+
+// ListSessions はユーザーの有効なセッション（デバイス）一覧を返す
+func (u *SessionUsecase) ListSessions(userID uint) ([]entity.Session, error) {
+	return u.sessionRepo.ListActiveByUser(userID, time.Now())
+}
+
+// RevokeSession はユーザー自身のセッションを失効させる
+// 他ユーザーのセッション ID は存在しないものとして扱う
+func (u *SessionUsecase) RevokeSession(userID, sessionID uint) error {
+	session, err := u.sessionRepo.FindByID(sessionID)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) || (err == nil && session.UserID != userID) {
+		return errors.ErrResourceNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return u.sessionRepo.Revoke(sessionID, time.Now())
+}
+
+// RevokeAllSessions はユーザーの全セッションを失効させ、失効させた件数を返す
+func (u *SessionUsecase) RevokeAllSessions(userID uint) (int64, error) {
+	return u.sessionRepo.RevokeAllByUser(userID, time.Now())
+}
+
+// IsActive はアクセストークンに含まれるセッションが有効かを返す
+func (u *SessionUsecase) IsActive(sessionID uint) bool {
+	session, err := u.sessionRepo.FindByID(sessionID)
+	if err != nil {
+		return false
+	}
+	return session.Active(time.Now())
+}
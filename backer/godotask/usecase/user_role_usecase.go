@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	stderrors "errors"
+
+	"github.com/godotask/domain/authz"
+	"github.com/godotask/domain/entity"
+	domainRepo "github.com/godotask/domain/repository"
+	"github.com/godotask/errors"
+	"gorm.io/gorm"
+)
+
+type UserRoleUsecase struct {
+	userRepo domainRepo.UserRepository
+}
+
+func NewUserRoleUsecase(userRepo domainRepo.UserRepository) *UserRoleUsecase {
+	return &UserRoleUsecase{userRepo: userRepo}
+}
+
+func (u *UserRoleUsecase) ListUsers() ([]entity.User, error) {
+	return u.userRepo.List()
+}
+
+// ChangeRole: ユーザーのロールを変更する
+// 最後の管理者を降格させることはできない
+func (u *UserRoleUsecase) ChangeRole(userID uint, role string) (*entity.User, error) {
+	if !authz.IsValidRole(role) {
+		return nil, errors.NewAppError(
+			errors.VAL_INVALID_INPUT,
+			errors.GetErrorMessage(errors.VAL_INVALID_INPUT),
+			"role must be one of admin, editor, viewer",
+		)
+	}
+
+	user, err := u.userRepo.FindByID(userID)
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == string(authz.RoleAdmin) && role != string(authz.RoleAdmin) {
+		admins, err := u.userRepo.CountByRole(string(authz.RoleAdmin))
+		if err != nil {
+			return nil, err
+		}
+		if admins <= 1 {
+			return nil, errors.NewAppError(
+				errors.BIZ_OPERATION_NOT_ALLOWED,
+				errors.GetErrorMessage(errors.BIZ_OPERATION_NOT_ALLOWED),
+				"cannot demote the last admin",
+			)
+		}
+	}
+
+	if err := u.userRepo.UpdateRole(userID, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+	return user, nil
+}
@@ -5,6 +5,7 @@ import { errorMessages, ErrorCode } from "@/response/errorCodes";
 import { SuccessCode, successMessages } from "@/response/resposeMessage";
 import { HttpError } from "@/response/httpError";
 import { handleError } from "../../utlts/handleRequest";
+import { setSessionCookies } from "../../utlts/authSession";
 
 export async function POST(req: NextRequest) {
   const { email, password } = await req.json();
@@ -41,15 +42,8 @@ export async function POST(req: NextRequest) {
         },
       );
 
-      response.cookies.set({
-        name: "token",
-        value: data.token,
-        httpOnly: true,
-        secure: process.env.NODE_ENV === "production", // 開発中は false でも可
-        sameSite: "strict",
-        path: "/",
-        maxAge: 60 * 60 * 24,
-      });
+      // アクセストークンは短命のため、リフレッシュトークンも保存して期限切れ時に取り直す
+      setSessionCookies(response.cookies, data.token, data.refresh_token);
 
       return response;
     }
@@ -1,8 +1,14 @@
 // /api/auth/logout.ts
 import { NextResponse } from "next/server";
+import { cookies } from "next/headers";
 import { successMessages, SuccessCode } from "@/response/resposeMessage";
 import { StatusCodes } from "@/response/statusCodes";
 import { URLs } from "@/constants/url";
+import {
+  ACCESS_TOKEN_COOKIE,
+  REFRESH_TOKEN_COOKIE,
+  clearSessionCookies,
+} from "../../utlts/authSession";
 
 export async function POST() {
   const response = NextResponse.json(
@@ -13,14 +19,27 @@ export async function POST() {
       status: StatusCodes.OK,
     },
   );
-  const backendRes = await fetch(URLs.logout, {
-    method: "POST",
-    headers: { "Content-Type": "application/json" },
-  });
 
-  const data = await backendRes.json();
-  console.log("Login response from backend:", data);
+  // バックエンドのセッションを失効させる（リフレッシュトークン、無ければアクセストークンのセッション）
+  const cookieStore = await cookies();
+  const token = cookieStore.get(ACCESS_TOKEN_COOKIE)?.value;
+  const refreshToken = cookieStore.get(REFRESH_TOKEN_COOKIE)?.value;
+  try {
+    const backendRes = await fetch(URLs.logout, {
+      method: "POST",
+      headers: {
+        "Content-Type": "application/json",
+        ...(token ? { Authorization: `Bearer ${token}` } : {}),
+      },
+      body: JSON.stringify({ refresh_token: refreshToken ?? "" }),
+    });
+    if (!backendRes.ok) {
+      console.error("Logout error from backend:", await backendRes.json());
+    }
+  } catch (error) {
+    console.error("Logout error:", error);
+  }
 
-  response.cookies.set("token", "", { maxAge: 0, path: "/" });
+  clearSessionCookies(response.cookies);
   return response;
 }
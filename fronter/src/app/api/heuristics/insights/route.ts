@@ -1,9 +1,9 @@
 import { NextRequest, NextResponse } from "next/server";
-import { cookies } from "next/headers";
 import { errorMessages, ErrorCode } from "@/response/errorCodes";
 import { StatusCodes } from "@/response/statusCodes";
 import { HttpError } from "@/response/httpError";
 import { handleBaseRequest, handleError } from "../../utlts/handleRequest";
+import { authorizedFetch } from "../../utlts/authSession";
 
 const API_BASE_URL =
   process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080";
@@ -16,25 +16,13 @@ export async function GET(request: NextRequest) {
     const searchParams = request.nextUrl.searchParams;
     const queryString = searchParams.toString();
 
-    // クッキーからトークンを取得
-    const cookieStore = await cookies();
-    const token = cookieStore.get("token")?.value;
-
-    if (!token) {
-      throw new HttpError(
-        StatusCodes.Unauthorized,
-        errorMessages[ErrorCode.AUTH_UNAUTHORIZED],
-      );
-    }
-
     // バックエンドAPIにリクエスト
-    const backendRes = await fetch(
+    const backendRes = await authorizedFetch(
       `${API_BASE_URL}/api/heuristics/insights${queryString ? `?${queryString}` : ""}`,
       {
         method: "GET",
         headers: {
           "Content-Type": "application/json",
-          Authorization: `Bearer ${token}`,
         },
         credentials: "include",
       },
@@ -1,10 +1,10 @@
 import { NextRequest, NextResponse } from "next/server";
-import { cookies } from "next/headers";
 import { URLs } from "@/constants/url";
 import { errorMessages, ErrorCode } from "@/response/errorCodes";
 import { StatusCodes } from "@/response/statusCodes";
 import { HttpError } from "@/response/httpError";
 import { handleBaseRequest, handleError } from "../../utlts/handleRequest";
+import { authorizedFetch } from "../../utlts/authSession";
 
 const END_POINT_HEURISTICS_PATTERNS = "heuristicsPatterns";
 
@@ -14,31 +14,16 @@ export async function GET(request: NextRequest) {
     const searchParams = request.nextUrl.searchParams;
     const queryString = searchParams.toString();
 
-    // クッキーからトークンを取得
-    const cookieStore = await cookies();
-    const token = cookieStore.get("token")?.value;
-
-    if (!token) {
-      return NextResponse.json(
-        {
-          error: errorMessages[ErrorCode.AUTH_UNAUTHORIZED],
-        },
-        {
-          status: StatusCodes.Unauthorized,
-        },
-      );
-    }
     console.log("Patterns API トークン:", queryString);
     console.log("searchParams API トークン:", searchParams);
 
     // バックエンドAPIにリクエスト
-    const backendRes = await fetch(
+    const backendRes = await authorizedFetch(
       `${URLs.heuristicsPatterns}${queryString ? `?${queryString}` : ""}`,
       {
         method: "GET",
         headers: {
           "Content-Type": "application/json",
-          Authorization: `Bearer ${token}`,
         },
         credentials: "include",
       },
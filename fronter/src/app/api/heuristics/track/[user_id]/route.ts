@@ -1,10 +1,10 @@
 import { NextRequest, NextResponse } from "next/server";
-import { cookies } from "next/headers";
 import { URLs } from "@/constants/url";
 import { errorMessages, ErrorCode } from "@/response/errorCodes";
 import { StatusCodes } from "@/response/statusCodes";
 import { HttpError } from "@/response/httpError";
 import { handleBaseRequest, handleError } from "../../../utlts/handleRequest";
+import { authorizedFetch } from "../../../utlts/authSession";
 
 const END_POINT_HEURISTICS_TRACK = "heuristicsTrack";
 
@@ -15,23 +15,11 @@ export async function GET(
   try {
     const { user_id } = await params;
 
-    // クッキーからトークンを取得
-    const cookieStore = await cookies();
-    const token = cookieStore.get("token")?.value;
-
-    if (!token) {
-      throw new HttpError(
-        StatusCodes.Unauthorized,
-        errorMessages[ErrorCode.AUTH_UNAUTHORIZED],
-      );
-    }
-
     // バックエンドAPIにリクエスト
-    const backendRes = await fetch(`${URLs.heuristicsTrack}/${user_id}`, {
+    const backendRes = await authorizedFetch(`${URLs.heuristicsTrack}/${user_id}`, {
       method: "GET",
       headers: {
         "Content-Type": "application/json",
-        Authorization: `Bearer ${token}`,
       },
       credentials: "include",
     });
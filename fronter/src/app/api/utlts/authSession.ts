@@ -0,0 +1,113 @@
+import { cookies } from "next/headers";
+import { URLs } from "@/constants/url";
+import { StatusCodes } from "@/response/statusCodes";
+import { HttpError } from "@/response/httpError";
+import { errorMessages, ErrorCode } from "@/response/errorCodes";
+
+// アクセストークンは短命（バックエンドの ACCESS_TOKEN_TTL、既定 15 分）のため、
+// 期限が切れたらリフレッシュトークンで取り直す
+export const ACCESS_TOKEN_COOKIE = "token";
+export const REFRESH_TOKEN_COOKIE = "refresh_token";
+
+// バックエンドの REFRESH_TOKEN_TTL（既定 30 日）に合わせる
+const SESSION_MAX_AGE = 60 * 60 * 24 * 30;
+
+const sessionCookieOptions = {
+  httpOnly: true,
+  secure: process.env.NODE_ENV === "production", // 開発中は false でも可
+  sameSite: "strict" as const,
+  path: "/",
+  maxAge: SESSION_MAX_AGE,
+};
+
+type CookieWriter = {
+  set(name: string, value: string, options?: object): unknown;
+};
+
+type TokenPair = { token: string; refresh_token: string };
+
+export function setSessionCookies(
+  store: CookieWriter,
+  token: string,
+  refreshToken?: string,
+) {
+  store.set(ACCESS_TOKEN_COOKIE, token, sessionCookieOptions);
+  if (refreshToken) {
+    store.set(REFRESH_TOKEN_COOKIE, refreshToken, sessionCookieOptions);
+  }
+}
+
+export function clearSessionCookies(store: CookieWriter) {
+  store.set(ACCESS_TOKEN_COOKIE, "", { maxAge: 0, path: "/" });
+  store.set(REFRESH_TOKEN_COOKIE, "", { maxAge: 0, path: "/" });
+}
+
+// 同じリフレッシュトークンでの同時リフレッシュはまとめる
+// （バックエンドは使用済みトークンの再提示をセッションごと失効させるため）
+const REFRESH_REUSE_MS = 10 * 1000;
+const refreshing = new Map<string, Promise<TokenPair | undefined>>();
+
+function refreshTokenPair(refreshToken: string) {
+  let pending = refreshing.get(refreshToken);
+  if (!pending) {
+    pending = fetch(URLs.refresh, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ refresh_token: refreshToken }),
+    })
+      .then(async (res) => (res.ok ? ((await res.json()) as TokenPair) : undefined))
+      .catch(() => undefined);
+    refreshing.set(refreshToken, pending);
+    setTimeout(() => refreshing.delete(refreshToken), REFRESH_REUSE_MS);
+  }
+  return pending;
+}
+
+// refreshSession はリフレッシュトークンで新しいトークンの組を取り、クッキーを更新する
+async function refreshSession(): Promise<string | undefined> {
+  const cookieStore = await cookies();
+  const refreshToken = cookieStore.get(REFRESH_TOKEN_COOKIE)?.value;
+  if (!refreshToken) {
+    return undefined;
+  }
+  const pair = await refreshTokenPair(refreshToken);
+  if (!pair?.token) {
+    clearSessionCookies(cookieStore);
+    return undefined;
+  }
+  setSessionCookies(cookieStore, pair.token, pair.refresh_token);
+  return pair.token;
+}
+
+// authorizedFetch はアクセストークンを付けてバックエンドを呼ぶ
+// トークンが無い・401 が返った場合は一度だけリフレッシュして送り直す
+export async function authorizedFetch(
+  url: string,
+  init: RequestInit = {},
+): Promise<Response> {
+  const cookieStore = await cookies();
+  const token =
+    cookieStore.get(ACCESS_TOKEN_COOKIE)?.value ?? (await refreshSession());
+  if (!token) {
+    throw new HttpError(
+      StatusCodes.Unauthorized,
+      errorMessages[ErrorCode.AUTH_UNAUTHORIZED],
+    );
+  }
+
+  const send = (accessToken: string) =>
+    fetch(url, {
+      ...init,
+      headers: {
+        ...(init.headers as Record<string, string> | undefined),
+        Authorization: `Bearer ${accessToken}`,
+      },
+    });
+
+  const res = await send(token);
+  if (res.status !== StatusCodes.Unauthorized) {
+    return res;
+  }
+  const refreshed = await refreshSession();
+  return refreshed ? send(refreshed) : res;
+}
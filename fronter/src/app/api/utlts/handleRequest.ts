@@ -1,9 +1,9 @@
 import { NextResponse } from "next/server";
-import { cookies } from "next/headers";
 import { URLs } from "@/constants/url";
 import { StatusCodes } from "@/response/statusCodes";
 import { HttpError } from "@/response/httpError";
-import { errorMessages, ErrorCode } from "@/response/errorCodes";
+import { ErrorCode } from "@/response/errorCodes";
+import { authorizedFetch } from "./authSession";
 
 export async function handleBaseRequest(
   method: "GET" | "POST" | "PUT" | "DELETE",
@@ -13,18 +13,7 @@ export async function handleBaseRequest(
   dynamicParams?: Record<string, string>,
 ) {
   try {
-    const cookieStore = await cookies();
-    const token = cookieStore.get("token")?.value;
-
-    if (!token) {
-      throw new HttpError(
-        StatusCodes.Unauthorized,
-        errorMessages[ErrorCode.AUTH_UNAUTHORIZED],
-      );
-    }
-
     const headers = {
-      Authorization: `Bearer ${token}`,
       "Content-Type": "application/json",
     };
 
@@ -85,7 +74,7 @@ export async function handleBaseRequest(
       delete sendData.body;
     }
 
-    const backendRes = await fetch(url, sendData);
+    const backendRes = await authorizedFetch(url, sendData);
 
     const data = await backendRes.json();
     if (!backendRes.ok) {
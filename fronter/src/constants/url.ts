@@ -4,6 +4,7 @@ const domainAndHost =
 const URLs = {
   login: `${domainAndHost}/api/login`,
   logout: `${domainAndHost}/api/logout`,
+  refresh: `${domainAndHost}/api/refresh`,
   register: `${domainAndHost}/api/register`,
   assessment: `${domainAndHost}/api/assessment`,
   assessmentPager: `${domainAndHost}/api/assessment/pager`,
@@ -25,8 +25,10 @@ export function middleware(request: NextRequest) {
     return NextResponse.next();
   }
 
-  // cookieからtokenを取得
-  const token = request.cookies.get("token")?.value;
+  // cookieからtokenを取得（アクセストークンが切れていてもリフレッシュトークンがあれば API 側で取り直す）
+  const token =
+    request.cookies.get("token")?.value ??
+    request.cookies.get("refresh_token")?.value;
   if (!token) {
     const loginUrl = new URL("/login", request.url);
     return NextResponse.redirect(loginUrl);